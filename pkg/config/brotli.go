@@ -0,0 +1,172 @@
+package config
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/urfave/negroni"
+)
+
+const (
+	brotliMinQuality     = brotli.BestSpeed
+	brotliMaxQuality     = brotli.BestCompression
+	brotliDefaultQuality = 5
+)
+
+// brotliCompressibleTypes are the media types worth compressing, anything else
+// (images, fonts, archives...) is usually already compressed
+var brotliCompressibleTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/x-javascript",
+	"application/xml",
+	"image/svg+xml",
+}
+
+// brotliMiddleware encodes the responses with brotli when the client prefers it over gzip.
+// When brotli is picked, Accept-Encoding is removed from the request so that the gzip
+// middleware further down the chain leaves the response alone.
+type brotliMiddleware struct {
+	quality int
+}
+
+func setupBrotliMiddleware() *brotliMiddleware {
+	quality := Config.MiddlewareBrotliQuality
+	if quality < brotliMinQuality || quality > brotliMaxQuality {
+		quality = brotliDefaultQuality
+	}
+	return &brotliMiddleware{quality: quality}
+}
+
+func (m *brotliMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if !prefersBrotli(r.Header.Get("Accept-Encoding")) || r.Header.Get("Sec-WebSocket-Key") != "" {
+		next(w, r)
+		return
+	}
+	r.Header.Del("Accept-Encoding")
+
+	bw := &brotliResponseWriter{ResponseWriter: negroni.NewResponseWriter(w), quality: m.quality}
+	defer bw.close()
+	next(bw, r)
+}
+
+// prefersBrotli negotiates the Accept-Encoding header, brotli wins ties with gzip
+func prefersBrotli(acceptEncoding string) bool {
+	br, gzip, wildcard := -1.0, 0.0, 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, q := parseAcceptEncodingPart(part)
+		switch coding {
+		case "br":
+			br = q
+		case "gzip":
+			gzip = q
+		case "*":
+			wildcard = q
+		}
+	}
+	if br < 0 {
+		br = wildcard
+	}
+	return br > 0 && br >= gzip
+}
+
+func parseAcceptEncodingPart(part string) (string, float64) {
+	fields := strings.Split(part, ";")
+	coding := strings.ToLower(strings.TrimSpace(fields[0]))
+	q := 1.0
+	for _, param := range fields[1:] {
+		param = strings.TrimSpace(param)
+		if strings.HasPrefix(param, "q=") {
+			v, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil {
+				v = 0
+			}
+			q = v
+		}
+	}
+	return coding, q
+}
+
+func isCompressibleContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range brotliCompressibleTypes {
+		if strings.HasPrefix(mediaType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// brotliResponseWriter holds the status code until the first write, so that the
+// content type can be sniffed before deciding whether to encode the body
+type brotliResponseWriter struct {
+	negroni.ResponseWriter
+	quality     int
+	status      int
+	wroteHeader bool
+	bw          *brotli.Writer
+}
+
+func (w *brotliResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *brotliResponseWriter) Status() int {
+	if w.status != 0 && !w.wroteHeader {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *brotliResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.writeHeader()
+	}
+	if w.bw != nil {
+		return w.bw.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *brotliResponseWriter) writeHeader() {
+	w.wroteHeader = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	h := w.Header()
+	h.Add("Vary", "Accept-Encoding")
+	if w.status != http.StatusNoContent &&
+		w.status != http.StatusNotModified &&
+		h.Get("Content-Encoding") == "" &&
+		isCompressibleContentType(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", "br")
+		h.Del("Content-Length")
+		w.bw = brotli.NewWriterLevel(w.ResponseWriter, w.quality)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *brotliResponseWriter) close() {
+	if !w.wroteHeader {
+		if w.status == 0 {
+			return
+		}
+		w.writeHeader()
+	}
+	if w.bw != nil {
+		w.bw.Close()
+	}
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/negroni"
+)
+
+func TestPrefersBrotli(t *testing.T) {
+	assert.True(t, prefersBrotli("gzip, deflate, br"))
+	assert.True(t, prefersBrotli("br"))
+	assert.True(t, prefersBrotli("*"))
+	assert.True(t, prefersBrotli("gzip;q=0.5, br;q=0.8"))
+	assert.False(t, prefersBrotli(""))
+	assert.False(t, prefersBrotli("gzip, deflate"))
+	assert.False(t, prefersBrotli("gzip;q=1.0, br;q=0.5"))
+	assert.False(t, prefersBrotli("br;q=0, *"))
+	assert.False(t, prefersBrotli("br;q=invalid"))
+}
+
+func TestIsCompressibleContentType(t *testing.T) {
+	assert.True(t, isCompressibleContentType("application/json"))
+	assert.True(t, isCompressibleContentType("text/html; charset=utf-8"))
+	assert.True(t, isCompressibleContentType("application/javascript"))
+	assert.True(t, isCompressibleContentType("image/svg+xml"))
+	assert.False(t, isCompressibleContentType("image/png"))
+	assert.False(t, isCompressibleContentType("application/octet-stream"))
+	assert.False(t, isCompressibleContentType(""))
+}
+
+func TestBrotliMiddleware(t *testing.T) {
+	body := strings.Repeat(`{"flagKey":"kmmcd"}`, 100)
+
+	serve := func(acceptEncoding string, h http.HandlerFunc) *httptest.ResponseRecorder {
+		n := negroni.New()
+		n.Use(setupBrotliMiddleware())
+		n.Use(setupGzipMiddleware())
+		n.UseHandler(h)
+
+		req := httptest.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		res := httptest.NewRecorder()
+		n.ServeHTTP(res, req)
+		return res
+	}
+	jsonHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+
+	t.Run("brotli when the client prefers it", func(t *testing.T) {
+		res := serve("gzip, deflate, br", jsonHandler)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "br", res.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", res.Header().Get("Vary"))
+		assert.Empty(t, res.Header().Get("Content-Length"))
+		assert.True(t, res.Body.Len() < len(body))
+
+		b, err := ioutil.ReadAll(brotli.NewReader(res.Body))
+		assert.NoError(t, err)
+		assert.Equal(t, body, string(b))
+	})
+
+	t.Run("falls back to gzip", func(t *testing.T) {
+		res := serve("gzip, deflate", jsonHandler)
+		assert.Equal(t, "gzip", res.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("identity without accept-encoding", func(t *testing.T) {
+		res := serve("", jsonHandler)
+		assert.Empty(t, res.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, res.Body.String())
+	})
+
+	t.Run("sniffs the content type", func(t *testing.T) {
+		res := serve("br", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("<html><body>" + body + "</body></html>"))
+		})
+		assert.Equal(t, "br", res.Header().Get("Content-Encoding"))
+		assert.Contains(t, res.Header().Get("Content-Type"), "text/html")
+	})
+
+	t.Run("skips content types that are not compressible", func(t *testing.T) {
+		res := serve("br", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte(body))
+		})
+		assert.Empty(t, res.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", res.Header().Get("Vary"))
+		assert.Equal(t, body, res.Body.String())
+	})
+
+	t.Run("keeps the status code", func(t *testing.T) {
+		res := serve("br", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(body))
+		})
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, "br", res.Header().Get("Content-Encoding"))
+
+		res = serve("br", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Empty(t, res.Header().Get("Content-Encoding"))
+		assert.Zero(t, res.Body.Len())
+	})
+
+	t.Run("invalid quality falls back to the default", func(t *testing.T) {
+		prev := Config.MiddlewareBrotliQuality
+		defer func() { Config.MiddlewareBrotliQuality = prev }()
+
+		Config.MiddlewareBrotliQuality = 12
+		assert.Equal(t, brotliDefaultQuality, setupBrotliMiddleware().quality)
+		Config.MiddlewareBrotliQuality = 11
+		assert.Equal(t, 11, setupBrotliMiddleware().quality)
+	})
+}
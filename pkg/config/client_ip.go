@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// clientIPResolver resolves the IP of the client behind the trusted proxies. The proxies append the
+// address they receive the request from to X-Forwarded-For, so the entries are trusted from the right,
+// starting from the remote address, until the first one that's neither within Hops nor in CIDRs.
+type clientIPResolver struct {
+	CIDRs []*net.IPNet
+	Hops  int
+}
+
+// setupClientIPResolver sets up the resolver from TrustedProxies, it falls back to
+// IPAllowlistTrustedProxies for the number of the proxies if TrustedProxies is not set
+func setupClientIPResolver() *clientIPResolver {
+	c := &clientIPResolver{}
+	for _, s := range Config.TrustedProxies {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if hops, err := strconv.Atoi(s); err == nil {
+			c.Hops = hops
+			continue
+		}
+		cidr, err := parseTrustedProxy(s)
+		if err != nil {
+			panic(fmt.Sprintf("unable to parse the trusted proxy %s. %s", s, err))
+		}
+		c.CIDRs = append(c.CIDRs, cidr)
+	}
+	if len(Config.TrustedProxies) == 0 {
+		c.Hops = Config.IPAllowlistTrustedProxies
+	}
+	return c
+}
+
+// parseTrustedProxy parses a CIDR, or a single IP as the CIDR of itself
+func parseTrustedProxy(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, cidr, err := net.ParseCIDR(s)
+		return cidr, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address")
+	}
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+func (c *clientIPResolver) trusted(ip net.IP) bool {
+	for _, cidr := range c.CIDRs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP of the client, it's nil if the IP is not valid.
+// X-Forwarded-For is ignored if there're no trusted proxies.
+func (c *clientIPResolver) ClientIP(req *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ips := []string{}
+	if c.Hops > 0 || len(c.CIDRs) > 0 {
+		for _, s := range strings.Split(req.Header.Get("X-Forwarded-For"), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				ips = append(ips, s)
+			}
+		}
+	}
+	ips = append(ips, host)
+
+	for i := len(ips) - 1; i > 0; i-- {
+		if len(ips)-1-i < c.Hops {
+			continue
+		}
+		ip := net.ParseIP(ips[i])
+		if ip == nil || !c.trusted(ip) {
+			return ip
+		}
+	}
+	return net.ParseIP(ips[0])
+}
+
+// clientIPString is the client IP for the logs and the metrics, it's empty if the IP is not valid
+func (c *clientIPResolver) clientIPString(req *http.Request) string {
+	ip := c.ClientIP(req)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
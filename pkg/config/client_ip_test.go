@@ -0,0 +1,75 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupClientIPResolver(t *testing.T) {
+	defer func() {
+		Config.TrustedProxies = []string{}
+		Config.IPAllowlistTrustedProxies = 0
+	}()
+
+	t.Run("it will parse the CIDRs, IPs and the number of the proxies", func(t *testing.T) {
+		Config.TrustedProxies = []string{"10.0.0.0/8", " 172.16.0.1", "::1", "2", ""}
+		c := setupClientIPResolver()
+		assert.Equal(t, 2, c.Hops)
+		assert.Len(t, c.CIDRs, 3)
+		assert.Equal(t, "172.16.0.1/32", c.CIDRs[1].String())
+		assert.Equal(t, "::1/128", c.CIDRs[2].String())
+	})
+
+	t.Run("it will fall back to the trusted proxies of the IP allowlist", func(t *testing.T) {
+		Config.TrustedProxies = []string{}
+		Config.IPAllowlistTrustedProxies = 3
+		assert.Equal(t, 3, setupClientIPResolver().Hops)
+	})
+
+	t.Run("it will panic with an invalid proxy", func(t *testing.T) {
+		Config.TrustedProxies = []string{"10.0.0.0/33"}
+		assert.Panics(t, func() { setupClientIPResolver() })
+
+		Config.TrustedProxies = []string{"proxy"}
+		assert.Panics(t, func() { setupClientIPResolver() })
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	clientIP := func(c *clientIPResolver, remoteAddr string, xff string) string {
+		req, _ := http.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil)
+		req.RemoteAddr = remoteAddr
+		if xff != "" {
+			req.Header.Set("X-Forwarded-For", xff)
+		}
+		return c.clientIPString(req)
+	}
+
+	t.Run("it will ignore X-Forwarded-For without trusted proxies", func(t *testing.T) {
+		c := &clientIPResolver{}
+		assert.Equal(t, "8.8.8.8", clientIP(c, "8.8.8.8:51234", "10.1.2.3"))
+		assert.Equal(t, "8.8.8.8", clientIP(c, "8.8.8.8", ""))
+		assert.Equal(t, "", clientIP(c, "invalid", ""))
+	})
+
+	t.Run("it will skip the number of the proxies", func(t *testing.T) {
+		c := &clientIPResolver{Hops: 1}
+		assert.Equal(t, "10.1.2.3", clientIP(c, "172.16.0.1:51234", "8.8.8.8, 10.1.2.3"))
+		c.Hops = 5
+		assert.Equal(t, "8.8.8.8", clientIP(c, "172.16.0.1:51234", "8.8.8.8, 10.1.2.3"))
+	})
+
+	t.Run("it will skip the proxies in the trusted CIDRs", func(t *testing.T) {
+		Config.TrustedProxies = []string{"172.16.0.0/12", "10.0.0.1"}
+		defer func() { Config.TrustedProxies = []string{} }()
+		c := setupClientIPResolver()
+
+		assert.Equal(t, "8.8.8.8", clientIP(c, "172.16.0.1:51234", "8.8.8.8, 10.0.0.1"))
+		assert.Equal(t, "8.8.4.4", clientIP(c, "172.16.0.1:51234", "8.8.8.8, 8.8.4.4, 172.16.0.2"))
+		assert.Equal(t, "", clientIP(c, "172.16.0.1:51234", "8.8.8.8, spoofed"))
+		// X-Forwarded-For of the untrusted remote address is spoofable
+		assert.Equal(t, "8.8.4.4", clientIP(c, "8.8.4.4:51234", "8.8.8.8"))
+	})
+}
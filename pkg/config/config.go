@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"runtime"
 
 	"github.com/DataDog/datadog-go/statsd"
 	"github.com/caarlos0/env"
@@ -20,6 +21,13 @@ var EvalOnlyModeDBDrivers = map[string]struct{}{
 	"json_http": {},
 }
 
+// Version, Commit and BuildDate describe the build of flagr, they're injected with the ldflags, see the Makefile
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
 // Global is the global dependency we can use, such as the new relic app instance
 var Global = struct {
 	NewrelicApp  newrelic.Application
@@ -28,6 +36,9 @@ var Global = struct {
 }{}
 
 func init() {
+	if err := loadConfigFile(os.Getenv("CONFIG_FILE")); err != nil {
+		logrus.WithField("err", err).Fatal("failed to load the config file")
+	}
 	env.Parse(&Config)
 
 	setupEvalOnlyMode()
@@ -71,7 +82,11 @@ func setupSentry() {
 
 func setupStatsd() {
 	if Config.StatsdEnabled {
-		client, err := statsd.New(fmt.Sprintf("%s:%s", Config.StatsdHost, Config.StatsdPort))
+		client, err := newStatsdClient(
+			fmt.Sprintf("%s:%s", Config.StatsdHost, Config.StatsdPort),
+			Config.StatsdBufferLength,
+			Config.StatsdFlushInterval,
+		)
 		if err != nil {
 			panic(fmt.Sprintf("unable to initialize statsd. %s", err))
 		}
@@ -94,29 +109,148 @@ func setupNewrelic() {
 }
 
 type prometheusMetrics struct {
-	ScrapePath       string
-	EvalCounter      *prometheus.CounterVec
-	RequestCounter   *prometheus.CounterVec
-	RequestHistogram *prometheus.HistogramVec
+	ScrapePath         string
+	EvalCounter        *prometheus.CounterVec
+	EvalVariantCounter *prometheus.CounterVec
+	EvalDuration       *prometheus.HistogramVec
+	RequestCounter     *prometheus.CounterVec
+	RequestHistogram   *prometheus.HistogramVec
+	EvalCacheFlags     prometheus.Gauge
+	EvalCacheWarmUp    prometheus.Gauge
+	EvalCacheSize      prometheus.Gauge
+	EvalCacheHits      prometheus.Counter
+	EvalCacheMisses    prometheus.Counter
+	EvalCacheEvictions prometheus.Counter
+	RecorderQueued     prometheus.Gauge
+	RecorderDropped    prometheus.Gauge
+	BuildInfo          prometheus.Gauge
 }
 
 func setupPrometheus() {
 	if Config.PrometheusEnabled {
 		Global.Prometheus.ScrapePath = Config.PrometheusPath
 		Global.Prometheus.EvalCounter = promauto.NewCounterVec(prometheus.CounterOpts{
-			Name: "flagr_eval_results",
-			Help: "A counter of eval results",
+			Namespace: Config.PrometheusNamespace,
+			Subsystem: Config.PrometheusSubsystem,
+			Name:      "eval_results",
+			Help:      "A counter of eval results",
 		}, []string{"EntityType", "FlagID", "VariantID", "VariantKey"})
+		if Config.EvalMetricsEnabled {
+			Global.Prometheus.EvalVariantCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "eval_variants_total",
+				Help:      "A counter of eval results by flag key and variant key",
+			}, []string{"flag_key", "variant_key"})
+			Global.Prometheus.EvalDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "eval_duration_seconds",
+				Help:      "A histogram of the durations of matching the segments of the flags by flag key",
+				// from 10µs to 160ms, the evaluations are way faster than the requests
+				Buckets: prometheus.ExponentialBuckets(0.00001, 4, 8),
+			}, []string{"flag_key"})
+		}
+		Global.Prometheus.EvalCacheFlags = promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: Config.PrometheusNamespace,
+			Subsystem: Config.PrometheusSubsystem,
+			Name:      "eval_cache_flags",
+			Help:      "The number of flags loaded when the evaluation cache is warmed up",
+		})
+		Global.Prometheus.EvalCacheWarmUp = promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: Config.PrometheusNamespace,
+			Subsystem: Config.PrometheusSubsystem,
+			Name:      "eval_cache_warm_up_seconds",
+			Help:      "The duration of warming up the evaluation cache on startup",
+		})
+		if Config.EvalCacheMaxFlags > 0 {
+			Global.Prometheus.EvalCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "eval_cache_size",
+				Help:      "The number of flags held by the bounded evaluation cache",
+			})
+			Global.Prometheus.EvalCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "eval_cache_hits_total",
+				Help:      "A counter of the flags found in the bounded evaluation cache",
+			})
+			Global.Prometheus.EvalCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "eval_cache_misses_total",
+				Help:      "A counter of the flags loaded from DB on demand by the bounded evaluation cache",
+			})
+			Global.Prometheus.EvalCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "eval_cache_evictions_total",
+				Help:      "A counter of the least recently evaluated flags evicted from the bounded evaluation cache",
+			})
+		}
+		if Config.RecorderEnabled {
+			Global.Prometheus.RecorderQueued = promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "data_recorder_queued_records",
+				Help:      "The number of the data records queued in memory waiting to be delivered by the data recorder",
+			})
+			Global.Prometheus.RecorderDropped = promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "data_recorder_dropped_records",
+				Help:      "The number of the data records the data recorder failed to deliver since the start",
+			})
+		}
 		Global.Prometheus.RequestCounter = promauto.NewCounterVec(prometheus.CounterOpts{
-			Name: "flagr_requests_total",
-			Help: "The total http requests received",
+			Namespace: Config.PrometheusNamespace,
+			Subsystem: Config.PrometheusSubsystem,
+			Name:      "requests_total",
+			Help:      "The total http requests received",
 		}, []string{"status", "path", "method"})
 
 		if Config.PrometheusIncludeLatencyHistogram {
 			Global.Prometheus.RequestHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
-				Name: "flagr_requests_buckets",
-				Help: "A histogram of latencies for requests received",
+				Namespace: Config.PrometheusNamespace,
+				Subsystem: Config.PrometheusSubsystem,
+				Name:      "requests_buckets",
+				Help:      "A histogram of latencies for requests received",
 			}, []string{"status", "path", "method"})
 		}
+		setupPrometheusRuntimeMetrics()
+	}
+}
+
+// setupPrometheusRuntimeMetrics registers the go runtime, the process and the build info metrics.
+// They can be registered already, e.g. the default registry comes with the go and the process collectors,
+// or the setup runs again in the tests, so the registered ones are kept.
+func setupPrometheusRuntimeMetrics() {
+	registerPrometheusCollector(prometheus.NewGoCollector())
+	registerPrometheusCollector(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+
+	Global.Prometheus.BuildInfo = registerPrometheusCollector(prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Config.PrometheusNamespace,
+		Subsystem: Config.PrometheusSubsystem,
+		Name:      "build_info",
+		Help:      "A gauge of 1 labeled with the version, the commit and the build date of flagr, and the go version",
+		ConstLabels: prometheus.Labels{
+			"version":    Version,
+			"commit":     Commit,
+			"build_date": BuildDate,
+			"goversion":  runtime.Version(),
+		},
+	})).(prometheus.Gauge)
+	Global.Prometheus.BuildInfo.Set(1)
+}
+
+// registerPrometheusCollector registers c, it returns the collector registered already if there's one
+func registerPrometheusCollector(c prometheus.Collector) prometheus.Collector {
+	if err := prometheus.DefaultRegisterer.Register(c); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector
+		}
+		panic(err)
 	}
+	return c
 }
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// loadConfigFile loads the YAML or JSON file at path into the env vars before they're parsed into Config.
+// The keys of the file are the same as the env vars, e.g. FLAGR_DB_DBDRIVER, and the env vars that are set
+// already take precedence over the file. The lists are joined with the envSeparator of their field.
+func loadConfigFile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read the config file %s. %s", path, err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("cannot parse the config file %s. %s", path, err)
+	}
+
+	fields := configEnvFields()
+	unknown := []string{}
+	for key := range values {
+		if _, ok := fields[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown keys in the config file %s: %s", path, strings.Join(unknown, ", "))
+	}
+
+	for key, v := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		s, err := configFileValue(v, fields[key].Tag.Get("envSeparator"))
+		if err != nil {
+			return fmt.Errorf("invalid value of %s in the config file %s. %s", key, path, err)
+		}
+		os.Setenv(key, s)
+	}
+	return nil
+}
+
+// configEnvFields maps the env vars to the fields of Config
+func configEnvFields() map[string]reflect.StructField {
+	t := reflect.TypeOf(Config)
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("env"), ",")[0]
+		if key != "" {
+			fields[key] = t.Field(i)
+		}
+	}
+	return fields
+}
+
+func configFileValue(v interface{}, separator string) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case []interface{}:
+		if separator == "" {
+			return "", fmt.Errorf("it's not a list")
+		}
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := configFileValue(item, "")
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, separator), nil
+	case map[interface{}]interface{}:
+		return "", fmt.Errorf("it's not a scalar or a list")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	dir, err := ioutil.TempDir("", "flagr_config")
+	assert.NoError(t, err)
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Run("it does nothing without a config file", func(t *testing.T) {
+		assert.NoError(t, loadConfigFile(""))
+	})
+
+	t.Run("it loads the yaml file into the env vars", func(t *testing.T) {
+		path := writeConfigFile(t, "flagr.yaml", `
+FLAGR_DB_DBDRIVER: mysql
+FLAGR_EVALCACHE_MAXFLAGS: 5000
+FLAGR_RECORDER_ENABLED: true
+FLAGR_TRUSTED_PROXIES:
+  - 10.0.0.0/8
+  - 172.16.0.1
+`)
+		defer os.RemoveAll(filepath.Dir(path))
+		defer unsetEnv("FLAGR_DB_DBDRIVER", "FLAGR_EVALCACHE_MAXFLAGS", "FLAGR_RECORDER_ENABLED", "FLAGR_TRUSTED_PROXIES")
+
+		assert.NoError(t, loadConfigFile(path))
+		assert.Equal(t, "mysql", os.Getenv("FLAGR_DB_DBDRIVER"))
+		assert.Equal(t, "5000", os.Getenv("FLAGR_EVALCACHE_MAXFLAGS"))
+		assert.Equal(t, "true", os.Getenv("FLAGR_RECORDER_ENABLED"))
+		assert.Equal(t, "10.0.0.0/8,172.16.0.1", os.Getenv("FLAGR_TRUSTED_PROXIES"))
+	})
+
+	t.Run("it loads the json file and the env vars take precedence", func(t *testing.T) {
+		path := writeConfigFile(t, "flagr.json", `{"FLAGR_DB_DBDRIVER": "mysql", "FLAGR_REQUEST_TIMEOUT": "5s"}`)
+		defer os.RemoveAll(filepath.Dir(path))
+		defer unsetEnv("FLAGR_DB_DBDRIVER", "FLAGR_REQUEST_TIMEOUT")
+
+		os.Setenv("FLAGR_DB_DBDRIVER", "postgres")
+		assert.NoError(t, loadConfigFile(path))
+		assert.Equal(t, "postgres", os.Getenv("FLAGR_DB_DBDRIVER"))
+		assert.Equal(t, "5s", os.Getenv("FLAGR_REQUEST_TIMEOUT"))
+	})
+
+	t.Run("it errors on the unknown keys", func(t *testing.T) {
+		path := writeConfigFile(t, "flagr.yaml", "FLAGR_DB_DBDRIVE: mysql\nFLAGR_RECORDER_ENABLE: true\n")
+		defer os.RemoveAll(filepath.Dir(path))
+
+		err := loadConfigFile(path)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "FLAGR_DB_DBDRIVE, FLAGR_RECORDER_ENABLE")
+		_, ok := os.LookupEnv("FLAGR_DB_DBDRIVE")
+		assert.False(t, ok)
+	})
+
+	t.Run("it errors on the invalid files and values", func(t *testing.T) {
+		assert.Error(t, loadConfigFile("/nonexistent/flagr.yaml"))
+
+		for _, content := range []string{
+			"FLAGR_DB_DBDRIVER: [mysql",
+			"FLAGR_DB_DBDRIVER: [mysql, postgres]",
+			"FLAGR_DB_DBDRIVER:\n  driver: mysql",
+		} {
+			path := writeConfigFile(t, "flagr.yaml", content)
+			assert.Error(t, loadConfigFile(path))
+			os.RemoveAll(filepath.Dir(path))
+		}
+	})
+}
+
+func unsetEnv(keys ...string) {
+	for _, key := range keys {
+		os.Unsetenv(key)
+	}
+}
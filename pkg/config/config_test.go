@@ -1,9 +1,12 @@
 package config
 
 import (
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,7 +31,17 @@ func TestSetupStatsd(t *testing.T) {
 	assert.NotPanics(t, func() {
 		setupStatsd()
 	})
+
+	Config.StatsdBufferLength = 10
+	assert.NotPanics(t, func() {
+		setupStatsd()
+	})
+	assert.NotPanics(t, func() {
+		ServerShutdown()
+	})
+	Config.StatsdBufferLength = 0
 	Config.StatsdEnabled = false
+	Global.StatsdClient = nil
 }
 
 func TestSetupPrometheus(t *testing.T) {
@@ -44,6 +57,42 @@ func TestSetupPrometheus(t *testing.T) {
 	Config.PrometheusEnabled = false
 }
 
+func TestSetupPrometheusRuntimeMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	prometheus.DefaultRegisterer = reg
+
+	assert.NotPanics(t, func() {
+		setupPrometheusRuntimeMetrics()
+		setupPrometheusRuntimeMetrics()
+	})
+
+	mfs, err := reg.Gather()
+	assert.NoError(t, err)
+	families := map[string]*dto.MetricFamily{}
+	for _, mf := range mfs {
+		families[mf.GetName()] = mf
+	}
+	assert.Contains(t, families, "go_goroutines")
+	assert.Contains(t, families, "go_memstats_alloc_bytes")
+	assert.Contains(t, families, "process_start_time_seconds")
+
+	buildInfo := families["flagr_build_info"]
+	if assert.NotNil(t, buildInfo) && assert.Len(t, buildInfo.GetMetric(), 1) {
+		m := buildInfo.GetMetric()[0]
+		assert.Equal(t, float64(1), m.GetGauge().GetValue())
+		labels := map[string]string{}
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		assert.Equal(t, map[string]string{
+			"version":    Version,
+			"commit":     Commit,
+			"build_date": BuildDate,
+			"goversion":  runtime.Version(),
+		}, labels)
+	}
+}
+
 func TestSetupPrometheusWithLatencies(t *testing.T) {
 	prometheus.DefaultRegisterer = prometheus.NewRegistry()
 	Config.PrometheusEnabled = true
@@ -54,3 +103,91 @@ func TestSetupPrometheusWithLatencies(t *testing.T) {
 	assert.NotNil(t, Global.Prometheus.RequestHistogram)
 	Config.PrometheusEnabled = false
 }
+
+func TestSetupPrometheusWithEvalMetrics(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+	Global.Prometheus.EvalVariantCounter = nil
+	Global.Prometheus.EvalDuration = nil
+	Config.PrometheusEnabled = true
+	setupPrometheus()
+	assert.Nil(t, Global.Prometheus.EvalVariantCounter)
+	assert.Nil(t, Global.Prometheus.EvalDuration)
+
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+	Config.EvalMetricsEnabled = true
+	setupPrometheus()
+	assert.NotNil(t, Global.Prometheus.EvalVariantCounter)
+	assert.NotNil(t, Global.Prometheus.EvalDuration)
+	Config.EvalMetricsEnabled = false
+	Config.PrometheusEnabled = false
+	Global.Prometheus.EvalVariantCounter = nil
+	Global.Prometheus.EvalDuration = nil
+}
+
+func TestSetupPrometheusWithRecorder(t *testing.T) {
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+	Global.Prometheus.RecorderQueued = nil
+	Global.Prometheus.RecorderDropped = nil
+	Config.PrometheusEnabled = true
+	setupPrometheus()
+	assert.Nil(t, Global.Prometheus.RecorderQueued)
+	assert.Nil(t, Global.Prometheus.RecorderDropped)
+
+	prometheus.DefaultRegisterer = prometheus.NewRegistry()
+	Config.RecorderEnabled = true
+	setupPrometheus()
+	assert.NotNil(t, Global.Prometheus.RecorderQueued)
+	assert.NotNil(t, Global.Prometheus.RecorderDropped)
+	Config.RecorderEnabled = false
+	Config.PrometheusEnabled = false
+	Global.Prometheus.RecorderQueued = nil
+	Global.Prometheus.RecorderDropped = nil
+}
+
+func TestSetupPrometheusWithNamespace(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	prometheus.DefaultRegisterer = registry
+	Config.PrometheusEnabled = true
+	Config.PrometheusIncludeLatencyHistogram = true
+	defer func() {
+		Config.PrometheusEnabled = false
+		Config.PrometheusIncludeLatencyHistogram = false
+	}()
+
+	metricNames := func() []string {
+		Global.Prometheus.EvalCounter.WithLabelValues("", "", "", "").Inc()
+		Global.Prometheus.RequestCounter.WithLabelValues("", "", "").Inc()
+		Global.Prometheus.RequestHistogram.WithLabelValues("", "", "").Observe(1)
+		mfs, err := registry.Gather()
+		assert.NoError(t, err)
+		names := []string{}
+		for _, mf := range mfs {
+			// the go runtime and the process metrics aren't prefixed, see TestSetupPrometheusRuntimeMetrics
+			if strings.HasPrefix(mf.GetName(), "go_") || strings.HasPrefix(mf.GetName(), "process_") {
+				continue
+			}
+			names = append(names, mf.GetName())
+		}
+		return names
+	}
+
+	setupPrometheus()
+	assert.ElementsMatch(t, []string{
+		"flagr_eval_results", "flagr_requests_total", "flagr_requests_buckets",
+		"flagr_eval_cache_flags", "flagr_eval_cache_warm_up_seconds", "flagr_build_info",
+	}, metricNames())
+
+	registry = prometheus.NewRegistry()
+	prometheus.DefaultRegisterer = registry
+	Config.PrometheusNamespace = "acme"
+	Config.PrometheusSubsystem = "flagr"
+	defer func() {
+		Config.PrometheusNamespace = "flagr"
+		Config.PrometheusSubsystem = ""
+	}()
+	setupPrometheus()
+	assert.ElementsMatch(t, []string{
+		"acme_flagr_eval_results", "acme_flagr_requests_total", "acme_flagr_requests_buckets",
+		"acme_flagr_eval_cache_flags", "acme_flagr_eval_cache_warm_up_seconds", "acme_flagr_build_info",
+	}, metricNames())
+}
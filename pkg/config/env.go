@@ -8,6 +8,28 @@ var Config = struct {
 	Host string `env:"HOST" envDefault:"localhost"`
 	// Port - Flagr server port
 	Port int `env:"PORT" envDefault:"18000"`
+	// GRPCPort - Flagr gRPC evaluation server port, the gRPC server is not started if it's 0
+	GRPCPort int `env:"GRPC_PORT" envDefault:"0"`
+	// ServerReadTimeout, ServerWriteTimeout and ServerIdleTimeout are the timeouts of the http server, they take
+	// precedence over the --read-timeout, --write-timeout and --cleanup-timeout flags of the server, 30s, 60s and 10s
+	// by default, and 0 keeps the flags. The read timeout closes the connections of the slow clients. The write timeout
+	// is the deadline of the whole response, so it also cuts the long responses like GET /api/v1/export/sqlite,
+	// /api/v1/flags/export.csv and the flag change stream. The idle timeout is for the keep-alive connections between the requests.
+	ServerReadTimeout  time.Duration `env:"FLAGR_SERVER_READ_TIMEOUT" envDefault:"0"`
+	ServerWriteTimeout time.Duration `env:"FLAGR_SERVER_WRITE_TIMEOUT" envDefault:"0"`
+	ServerIdleTimeout  time.Duration `env:"FLAGR_SERVER_IDLE_TIMEOUT" envDefault:"0"`
+	// RequestTimeout - the deadline of the requests, it's disabled if it's 0. The context of the request is cancelled at
+	// the deadline, which stops its db queries, and it's responded 503 unless the handler has started responding already.
+	// It should be shorter than the write timeout of the server, otherwise the connection is closed before the 503.
+	RequestTimeout time.Duration `env:"FLAGR_REQUEST_TIMEOUT" envDefault:"0"`
+	// TLSEnabled - to serve https instead of http with the certificate of TLSCertFile and TLSKeyFile,
+	// the https listener takes TLS_HOST and TLS_PORT. Send SIGHUP to reload the certificate from the files.
+	TLSEnabled  bool   `env:"FLAGR_TLS_ENABLED" envDefault:"false"`
+	TLSCertFile string `env:"FLAGR_TLS_CERT_FILE" envDefault:""`
+	TLSKeyFile  string `env:"FLAGR_TLS_KEY_FILE" envDefault:""`
+	// HTTP2H2CEnabled - to serve the cleartext HTTP/2 (h2c) besides HTTP/1.1 on the http listener, e.g. for the
+	// plaintext connections in a service mesh. HTTP/2 is always negotiated on the https listener.
+	HTTP2H2CEnabled bool `env:"FLAGR_HTTP2_H2C_ENABLED" envDefault:"false"`
 
 	// LogrusLevel sets the logrus logging level
 	LogrusLevel string `env:"FLAGR_LOGRUS_LEVEL" envDefault:"info"`
@@ -16,32 +38,139 @@ var Config = struct {
 
 	// MiddlewareVerboseLoggerEnabled - to enable the negroni-logrus logger for all the endpoints useful for debugging
 	MiddlewareVerboseLoggerEnabled bool `env:"FLAGR_MIDDLEWARE_VERBOSE_LOGGER_ENABLED" envDefault:"true"`
+	// MiddlewareLoggerSampleRate - the fraction of the successful requests logged by the verbose logger, the non-2xx responses are always logged
+	MiddlewareLoggerSampleRate float64 `env:"FLAGR_MIDDLEWARE_LOGGER_SAMPLE_RATE" envDefault:"1"`
 	// MiddlewareGzipEnabled - to enable gzip middleware
 	MiddlewareGzipEnabled bool `env:"FLAGR_MIDDLEWARE_GZIP_ENABLED" envDefault:"true"`
+	// MiddlewareGzipMinLength - the responses shorter than it in bytes are not gzipped, 0 gzips all of them
+	MiddlewareGzipMinLength int `env:"FLAGR_MIDDLEWARE_GZIP_MIN_LENGTH" envDefault:"0"`
+	// MiddlewareBrotliEnabled - to enable brotli middleware, it takes precedence over gzip when the client prefers br
+	MiddlewareBrotliEnabled bool `env:"FLAGR_MIDDLEWARE_BROTLI_ENABLED" envDefault:"false"`
+	// MiddlewareBrotliQuality - brotli quality level, from 0 (fastest) to 11 (smallest)
+	MiddlewareBrotliQuality int `env:"FLAGR_MIDDLEWARE_BROTLI_QUALITY" envDefault:"5"`
+	// MiddlewareMaxRequestBodyEnabled - to respond 413 to the requests with a body larger than MaxRequestBodyBytes
+	MiddlewareMaxRequestBodyEnabled bool `env:"FLAGR_MIDDLEWARE_MAX_REQUEST_BODY_ENABLED" envDefault:"false"`
+	// MaxRequestBodyBytes - the max size of the request body as it's sent, 10MB by default
+	MaxRequestBodyBytes int64 `env:"FLAGR_MAX_REQUEST_BODY_BYTES" envDefault:"10485760"`
 
 	// RateLimiterPerFlagPerSecondConsoleLogging - to rate limit the logging rate
 	// per flag per second
 	RateLimiterPerFlagPerSecondConsoleLogging int `env:"FLAGR_RATELIMITER_PERFLAG_PERSECOND_CONSOLE_LOGGING" envDefault:"100"`
+	// RateLimiterPerSecondFrontendEvents - to rate limit the number of frontend events
+	// accepted per second, because the frontend events endpoint is publicly reachable
+	RateLimiterPerSecondFrontendEvents int `env:"FLAGR_RATELIMITER_PERSECOND_FRONTEND_EVENTS" envDefault:"1000"`
 
 	// EvalEnableDebug - controls if we want to return evaluation debugging information back to the api requests
 	// Note that this is a global switch:
 	//     if it's disabled, no evaluation debug info will be returned.
 	//     if it's enabled, it respects evaluation request's enableDebug field
 	EvalDebugEnabled bool `env:"FLAGR_EVAL_DEBUG_ENABLED" envDefault:"true"`
+	// BucketingAlgorithm - the hash of the distribution bucketing for the flags without their own
+	// bucketingAlgorithm, one of crc32, murmur3 and xxhash. Changing it reshuffles the assignments
+	// of the entities, it's only meant for matching the bucketing of another system that the flags
+	// are migrated from.
+	BucketingAlgorithm string `env:"FLAGR_BUCKETING_ALGORITHM" envDefault:"crc32"`
+	// EvalContextFromJWT - merges the claims of EvalContextFromJWTClaims in the JWT token of the evaluation
+	// requests into their entityContext, and the entityContext of the request body takes precedence.
+	// The evaluation paths are usually whitelisted from JWT auth, so the token is optional there, and
+	// it's only used if it's valid.
+	EvalContextFromJWT       bool     `env:"FLAGR_EVAL_CONTEXT_FROM_JWT" envDefault:"false"`
+	EvalContextFromJWTClaims []string `env:"FLAGR_EVAL_CONTEXT_FROM_JWT_CLAIMS" envDefault:"sub" envSeparator:","`
+	// EvalEnrichmentURL - the URL to POST the entityID, entityType and entityContext of the evaluations to before
+	// evaluating them, the attributes of the JSON object it responds with are merged into the entityContext and
+	// take precedence over it. It's disabled if it's empty. The attributes are cached per entity for
+	// EvalEnrichmentCacheTTL. If the endpoint fails or doesn't respond within EvalEnrichmentTimeout, the entity
+	// is evaluated with its original entityContext if EvalEnrichmentFailOpen, otherwise the evaluation fails with 502.
+	EvalEnrichmentURL      string        `env:"FLAGR_EVAL_ENRICHMENT_URL" envDefault:""`
+	EvalEnrichmentTimeout  time.Duration `env:"FLAGR_EVAL_ENRICHMENT_TIMEOUT" envDefault:"100ms"`
+	EvalEnrichmentFailOpen bool          `env:"FLAGR_EVAL_ENRICHMENT_FAIL_OPEN" envDefault:"true"`
+	EvalEnrichmentCacheTTL time.Duration `env:"FLAGR_EVAL_ENRICHMENT_CACHE_TTL" envDefault:"30s"`
+	// EvalResultCacheTTL - to reuse the result of the same flag, entityID, entityType and entityContext for the TTL
+	// instead of evaluating the flag again, it's disabled if it's 0. The results of a flag are dropped once the flag is
+	// reloaded into the evaluation cache, so they don't outlive the changes of the flag. The debug evaluations, the
+	// entities without entityID, and the flags with a prerequisite, an exclusion group, time-window constraints or a
+	// ramping distribution are not cached. EvalResultCacheRecordHits writes a data record for every
+	// evaluation, the cached ones included, otherwise only the evaluations that are not cached are recorded.
+	EvalResultCacheTTL        time.Duration `env:"FLAGR_EVAL_RESULT_CACHE_TTL" envDefault:"0"`
+	EvalResultCacheRecordHits bool          `env:"FLAGR_EVAL_RESULT_CACHE_RECORD_HITS" envDefault:"true"`
 	// EvalLoggingEnabled - to enable the logging for eval results
 	EvalLoggingEnabled bool `env:"FLAGR_EVAL_LOGGING_ENABLED" envDefault:"true"`
 	// EvalCacheRefreshTimeout - timeout of getting the flags data from DB into the in-memory evaluation cache
 	EvalCacheRefreshTimeout time.Duration `env:"FLAGR_EVALCACHE_REFRESHTIMEOUT" envDefault:"59s"`
 	// EvalCacheRefreshInterval - time interval of getting the flags data from DB into the in-memory evaluation cache
 	EvalCacheRefreshInterval time.Duration `env:"FLAGR_EVALCACHE_REFRESHINTERVAL" envDefault:"3s"`
+	// EvalCacheMaxFlags - the max number of flags held by the in-memory evaluation cache, 0 caches all the flags.
+	// When it's set, the least recently evaluated flags are evicted and loaded from DB again on demand.
+	// It's ignored in the EvalOnlyMode, the flags can't be loaded one by one from the json drivers.
+	EvalCacheMaxFlags int `env:"FLAGR_EVALCACHE_MAXFLAGS" envDefault:"0"`
+	// EvalCacheInvalidationKafkaTopic - the Kafka topic for the replicas to invalidate each other's evaluation cache.
+	// Every flag change is published with the flag ID, and the other replicas reload that flag right away instead of
+	// waiting for EvalCacheRefreshInterval. It's disabled if it's empty. It connects with the FLAGR_RECORDER_KAFKA_* settings.
+	EvalCacheInvalidationKafkaTopic string `env:"FLAGR_EVALCACHE_INVALIDATION_KAFKA_TOPIC" envDefault:""`
 	// EvalOnlyMode - will only expose the evaluation related endpoints.
 	// This field will be derived from DBDriver
 	EvalOnlyMode bool `env:"FLAGR_EVAL_ONLY_MODE" envDefault:"false"`
 
+	// FlagExpiryCheckInterval - time interval of disabling the enabled flags past their expiresAt, 0 turns it off
+	FlagExpiryCheckInterval time.Duration `env:"FLAGR_FLAG_EXPIRY_CHECK_INTERVAL" envDefault:"1m"`
+
+	// FlagScheduleCheckInterval - time interval of applying the due flag schedules, 0 turns it off
+	FlagScheduleCheckInterval time.Duration `env:"FLAGR_FLAG_SCHEDULE_CHECK_INTERVAL" envDefault:"10s"`
+
+	// FlagChangeWebhookURL - the URL to POST every change of the flags to, one JSON event per request. It's disabled if it's empty.
+	// The body is signed with HMAC-SHA256 of FlagChangeWebhookSecret in the X-Flagr-Signature header. Events that still fail
+	// after the retries are dropped, so that a down receiver never blocks the flag changes
+	FlagChangeWebhookURL           string        `env:"FLAGR_FLAG_CHANGE_WEBHOOK_URL" envDefault:""`
+	FlagChangeWebhookSecret        string        `env:"FLAGR_FLAG_CHANGE_WEBHOOK_SECRET" envDefault:""`
+	FlagChangeWebhookBufferSize    int           `env:"FLAGR_FLAG_CHANGE_WEBHOOK_BUFFER_SIZE" envDefault:"1000"`
+	FlagChangeWebhookTimeout       time.Duration `env:"FLAGR_FLAG_CHANGE_WEBHOOK_TIMEOUT" envDefault:"5s"`
+	FlagChangeWebhookRetryAttempts uint          `env:"FLAGR_FLAG_CHANGE_WEBHOOK_RETRY_ATTEMPTS" envDefault:"5"`
+	FlagChangeWebhookRetryDelay    time.Duration `env:"FLAGR_FLAG_CHANGE_WEBHOOK_RETRY_DELAY" envDefault:"500ms"`
+
+	// ApprovalRequired - enabling a flag, or changing the distributions of an enabled flag, creates a pending change
+	// instead of applying it. It's applied once another user than its requester, by the JWT subject, approves it.
+	ApprovalRequired bool `env:"FLAGR_APPROVAL_REQUIRED" envDefault:"false"`
+
+	// FlagChangeStreamKeepAliveInterval - time interval of the keep-alive comments of GET /api/v1/flags/stream, so that
+	// the proxies don't time out the idle streams. 0 turns them off.
+	FlagChangeStreamKeepAliveInterval time.Duration `env:"FLAGR_FLAG_CHANGE_STREAM_KEEPALIVE_INTERVAL" envDefault:"15s"`
+
+	// FlagBackupInterval - time interval of backing up the definitions of all the flags to FlagBackupDest, 0 turns it off.
+	// The backup is a JSON file in the body of POST /api/v1/flags/batch. FlagBackupDest is overwritten on every backup,
+	// unless FlagBackupRotate is set, which writes a new file with the UTC timestamp before the extension every time.
+	FlagBackupInterval time.Duration `env:"FLAGR_FLAG_BACKUP_INTERVAL" envDefault:"0"`
+	FlagBackupDest     string        `env:"FLAGR_FLAG_BACKUP_DEST" envDefault:""`
+	FlagBackupRotate   bool          `env:"FLAGR_FLAG_BACKUP_ROTATE" envDefault:"false"`
+
+	// FlagLastEvaluatedFlushInterval - time interval of flushing the last evaluated time of the flags to the db,
+	// the evaluations only update it in memory. 0 turns off the tracking, and it's always off in the eval only mode.
+	FlagLastEvaluatedFlushInterval time.Duration `env:"FLAGR_FLAG_LAST_EVALUATED_FLUSH_INTERVAL" envDefault:"1m"`
+
+	// FlagChangeKafkaTopic - the Kafka topic to produce every change of the flags to, with the same JSON event as the
+	// flag change webhook and keyed by the flag ID. It's disabled if it's empty. It shares the producer of the Kafka
+	// recorder if it's enabled, otherwise it connects to the brokers with the FLAGR_RECORDER_KAFKA_* settings.
+	FlagChangeKafkaTopic string `env:"FLAGR_FLAG_CHANGE_KAFKA_TOPIC" envDefault:""`
+
+	// FlagsDefaultLimit - the page size of the flags list when limit is not set
+	FlagsDefaultLimit int `env:"FLAGR_FLAGS_DEFAULT_LIMIT" envDefault:"1000"`
+	// FlagsMaxLimit - the max page size of the flags list
+	FlagsMaxLimit int `env:"FLAGR_FLAGS_MAX_LIMIT" envDefault:"10000"`
+	// FlagsSearchMaxLimit - the max page size of the flags list when searching with q
+	FlagsSearchMaxLimit int `env:"FLAGR_FLAGS_SEARCH_MAX_LIMIT" envDefault:"100"`
+
+	// MaxSegmentsPerFlag, MaxConstraintsPerSegment and MaxVariantsPerFlag - the guardrails of the size of a flag, as every
+	// evaluation of the flag goes through its segments and constraints. Creating or saving a flag beyond them is responded
+	// with 422, the flags that exceed them already are still evaluated. 0 disables the limit.
+	MaxSegmentsPerFlag       int `env:"FLAGR_MAX_SEGMENTS_PER_FLAG" envDefault:"500"`
+	MaxConstraintsPerSegment int `env:"FLAGR_MAX_CONSTRAINTS_PER_SEGMENT" envDefault:"100"`
+	MaxVariantsPerFlag       int `env:"FLAGR_MAX_VARIANTS_PER_FLAG" envDefault:"500"`
+
 	/**
 	DBDriver and DBConnectionStr define how we can write and read flags data.
 	For databases, flagr supports sqlite3, mysql and postgres.
 	For read-only evaluation, flagr supports file and http.
+	On start, the text columns are widened to longtext in mysql, and the id sequences of postgres are moved
+	past the largest ids, e.g. after the data is copied from another db.
 
 	Examples:
 
@@ -64,6 +193,18 @@ var Config = struct {
 	// DBConnectionRetryAttempts controls how we are going to retry on db connection when start the flagr server
 	DBConnectionRetryAttempts uint          `env:"FLAGR_DB_DBCONNECTION_RETRY_ATTEMPTS" envDefault:"9"`
 	DBConnectionRetryDelay    time.Duration `env:"FLAGR_DB_DBCONNECTION_RETRY_DELAY" envDefault:"100ms"`
+	// DBMaxOpenConns, DBMaxIdleConns and DBConnMaxLifetime configure the connection pool of the db.
+	// DBMaxOpenConns <= 0 means unlimited open connections, DBConnMaxLifetime 0 means connections are reused forever.
+	DBMaxOpenConns    int           `env:"FLAGR_DB_MAX_OPEN_CONNS" envDefault:"100"`
+	DBMaxIdleConns    int           `env:"FLAGR_DB_MAX_IDLE_CONNS" envDefault:"10"`
+	DBConnMaxLifetime time.Duration `env:"FLAGR_DB_CONN_MAX_LIFETIME" envDefault:"1h"`
+	// IDStrategy - how the IDs of the new entities are generated, one of increment, uuid and snowflake.
+	// increment leaves them to the auto increment of the db. uuid generates the random IDs, the random bits
+	// of the version 4 UUIDs within 53 bits, as the IDs are integers in the API. snowflake generates the time
+	// sortable IDs that don't collide across the replicas and the environments with different IDSnowflakeNode
+	// (0 to 15), it has to be set with snowflake. uuid and snowflake widen the ID columns of mysql and postgres to bigint.
+	IDStrategy      string `env:"FLAGR_ID_STRATEGY" envDefault:"increment"`
+	IDSnowflakeNode int    `env:"FLAGR_ID_SNOWFLAKE_NODE" envDefault:"-1"`
 
 	// CORSEnabled - enable CORS
 	CORSEnabled bool `env:"FLAGR_CORS_ENABLED" envDefault:"true"`
@@ -85,6 +226,12 @@ var Config = struct {
 	StatsdAPMEnabled     bool   `env:"FLAGR_STATSD_APM_ENABLED" envDefault:"false"`
 	StatsdAPMPort        string `env:"FLAGR_STATSD_APM_PORT" envDefault:"8126"`
 	StatsdAPMServiceName string `env:"FLAGR_STATSD_APM_SERVICE_NAME" envDefault:"flagr"`
+	// StatsdClientIPTagEnabled - tag the request metrics with client_ip, mind the cardinality of the tags
+	StatsdClientIPTagEnabled bool `env:"FLAGR_STATSD_CLIENT_IP_TAG_ENABLED" envDefault:"false"`
+	// StatsdBufferLength - the number of metrics sent in one UDP packet, 0 sends every metric in its own packet
+	StatsdBufferLength int `env:"FLAGR_STATSD_BUFFER_LENGTH" envDefault:"0"`
+	// StatsdFlushInterval - how often the buffered metrics are sent, it's only used if StatsdBufferLength is positive
+	StatsdFlushInterval time.Duration `env:"FLAGR_STATSD_FLUSH_INTERVAL" envDefault:"100ms"`
 
 	// PrometheusEnabled - enable prometheus metrics export
 	PrometheusEnabled bool `env:"FLAGR_PROMETHEUS_ENABLED" envDefault:"false"`
@@ -92,11 +239,27 @@ var Config = struct {
 	PrometheusPath string `env:"FLAGR_PROMETHEUS_PATH" envDefault:"/metrics"`
 	// PrometheusIncludeLatencyHistogram - set whether Prometheus should also export a histogram of request latencies (this increases cardinality significantly)
 	PrometheusIncludeLatencyHistogram bool `env:"FLAGR_PROMETHEUS_INCLUDE_LATENCY_HISTOGRAM" envDefault:"false"`
+	// PrometheusNamespace and PrometheusSubsystem - prefix the names of the metrics, e.g. flagr_requests_total,
+	// so that they don't collide with the metrics of the other services scraped by the same prometheus
+	PrometheusNamespace string `env:"FLAGR_PROMETHEUS_NAMESPACE" envDefault:"flagr"`
+	PrometheusSubsystem string `env:"FLAGR_PROMETHEUS_SUBSYSTEM" envDefault:""`
+	// EvalMetricsEnabled - export a counter of the evaluation results labeled by flag key and variant key,
+	// and a histogram of the durations of matching the segments of the flags labeled by flag key.
+	// It requires PrometheusEnabled. The flags with more than EvalMetricsMaxVariants variants are not
+	// counted to bound the cardinality of the labels.
+	EvalMetricsEnabled     bool `env:"FLAGR_EVAL_METRICS_ENABLED" envDefault:"false"`
+	EvalMetricsMaxVariants int  `env:"FLAGR_EVAL_METRICS_MAX_VARIANTS" envDefault:"10"`
 
 	// RecorderEnabled - enable data records logging
 	RecorderEnabled bool `env:"FLAGR_RECORDER_ENABLED" envDefault:"false"`
 	// RecorderType - the pipeline to log data records, e.g. Kafka
 	RecorderType string `env:"FLAGR_RECORDER_TYPE" envDefault:"kafka"`
+	// RecorderSampleRate - the fraction (0.0 to 1.0) of the evaluation results to record.
+	// The sampling is deterministic per entityID, so an entity is either always or never recorded
+	RecorderSampleRate float64 `env:"FLAGR_RECORDER_SAMPLE_RATE" envDefault:"1.0"`
+	// RecorderQueueHighWaterMark - the readiness endpoint warns about the backlog of the data recorder when more records
+	// than the mark are queued in memory waiting to be delivered, e.g. when Kafka is slow. It's disabled with 0.
+	RecorderQueueHighWaterMark int `env:"FLAGR_RECORDER_QUEUE_HIGH_WATER_MARK" envDefault:"0"`
 
 	/**
 	RecorderFrameOutputMode - indicates which data record frame output mode should we use.
@@ -115,6 +278,11 @@ var Config = struct {
 	*/
 	RecorderFrameOutputMode string `env:"FLAGR_RECORDER_FRAME_OUTPUT_MODE" envDefault:"payload_string"`
 
+	// RecorderIncludeFlagMeta - adds the tags and the description of the flag to the payload of the data records,
+	// e.g. "flagTags":["checkout"],"flagDescription":"new checkout page". They're taken from the evaluation cache,
+	// so they're not set if the flag is not in the cache.
+	RecorderIncludeFlagMeta bool `env:"FLAGR_RECORDER_INCLUDE_FLAG_META" envDefault:"false"`
+
 	// Kafka related configurations for data records logging (Flagr Metrics)
 	RecorderKafkaVersion        string        `env:"FLAGR_RECORDER_KAFKA_VERSION" envDefault:"0.8.2.0"`
 	RecorderKafkaBrokers        string        `env:"FLAGR_RECORDER_KAFKA_BROKERS" envDefault:":9092"`
@@ -129,6 +297,14 @@ var Config = struct {
 	RecorderKafkaEncrypted      bool          `env:"FLAGR_RECORDER_KAFKA_ENCRYPTED" envDefault:"false"`
 	RecorderKafkaEncryptionKey  string        `env:"FLAGR_RECORDER_KAFKA_ENCRYPTION_KEY" envDefault:""`
 
+	// Kafka SASL/SSL authentication. SASL is enabled when the username is set,
+	// and the mechanism can be PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512.
+	// SSL can be enabled without the client cert, the RecorderKafkaCAFile is optional for it.
+	RecorderKafkaSASLUsername  string `env:"FLAGR_RECORDER_KAFKA_SASL_USERNAME" envDefault:""`
+	RecorderKafkaSASLPassword  string `env:"FLAGR_RECORDER_KAFKA_SASL_PASSWORD" envDefault:""`
+	RecorderKafkaSASLMechanism string `env:"FLAGR_RECORDER_KAFKA_SASL_MECHANISM" envDefault:"PLAIN"`
+	RecorderKafkaSSLEnabled    bool   `env:"FLAGR_RECORDER_KAFKA_SSL_ENABLED" envDefault:"false"`
+
 	// Kinesis related configurations for data records logging (Flagr Metrics)
 	RecorderKinesisStreamName          string        `env:"FLAGR_RECORDER_KINESIS_STREAM_NAME" envDefault:"flagr-records"`
 	RecorderKinesisBacklogCount        int           `env:"FLAGR_RECORDER_KINESIS_BACKLOG_COUNT" envDefault:"500"`
@@ -147,6 +323,32 @@ var Config = struct {
 	RecorderPubsubVerbose              bool          `env:"FLAGR_RECORDER_PUBSUB_VERBOSE" envDefault:"false"`
 	RecorderPubsubVerboseCancelTimeout time.Duration `env:"FLAGR_RECORDER_PUBSUB_VERBOSE_CANCEL_TIMEOUT" envDefault:"5s"`
 
+	// File related configurations for data records logging, records are appended as JSON lines,
+	// and the file is rotated to FilePath.<timestamp> once it grows beyond the FileMaxSize in bytes
+	RecorderFilePath          string        `env:"FLAGR_RECORDER_FILE_PATH" envDefault:"flagr-records.jsonl"`
+	RecorderFileMaxSize       int64         `env:"FLAGR_RECORDER_FILE_MAX_SIZE" envDefault:"104857600"`
+	RecorderFileFlushInterval time.Duration `env:"FLAGR_RECORDER_FILE_FLUSH_INTERVAL" envDefault:"1s"`
+
+	// HTTP related configurations for data records logging, records are POSTed in batches as a JSON array.
+	// Batches that still fail after the retries are dropped, so that a down collector never blocks the evaluation
+	RecorderHTTPURL           string        `env:"FLAGR_RECORDER_HTTP_URL" envDefault:""`
+	RecorderHTTPBearerToken   string        `env:"FLAGR_RECORDER_HTTP_BEARER_TOKEN" envDefault:""`
+	RecorderHTTPBatchSize     int           `env:"FLAGR_RECORDER_HTTP_BATCH_SIZE" envDefault:"100"`
+	RecorderHTTPBufferSize    int           `env:"FLAGR_RECORDER_HTTP_BUFFER_SIZE" envDefault:"10000"`
+	RecorderHTTPFlushInterval time.Duration `env:"FLAGR_RECORDER_HTTP_FLUSH_INTERVAL" envDefault:"1s"`
+	RecorderHTTPTimeout       time.Duration `env:"FLAGR_RECORDER_HTTP_TIMEOUT" envDefault:"5s"`
+	RecorderHTTPRetryAttempts uint          `env:"FLAGR_RECORDER_HTTP_RETRY_ATTEMPTS" envDefault:"3"`
+	RecorderHTTPRetryDelay    time.Duration `env:"FLAGR_RECORDER_HTTP_RETRY_DELAY" envDefault:"100ms"`
+
+	// NATS related configurations for data records logging, records are published to the Subject
+	// of the JetStream Stream. CredsFile is the optional NATS user credentials file
+	RecorderNatsURL             string        `env:"FLAGR_RECORDER_NATS_URL" envDefault:"nats://127.0.0.1:4222"`
+	RecorderNatsCredsFile       string        `env:"FLAGR_RECORDER_NATS_CREDS_FILE" envDefault:""`
+	RecorderNatsStream          string        `env:"FLAGR_RECORDER_NATS_STREAM" envDefault:"flagr-records"`
+	RecorderNatsSubject         string        `env:"FLAGR_RECORDER_NATS_SUBJECT" envDefault:"flagr.records"`
+	RecorderNatsFlushTimeout    time.Duration `env:"FLAGR_RECORDER_NATS_FLUSH_TIMEOUT" envDefault:"5s"`
+	RecorderNatsMaxPendingAsync int           `env:"FLAGR_RECORDER_NATS_MAX_PENDING_ASYNC" envDefault:"4000"`
+
 	/**
 	JWTAuthEnabled enables the JWT Auth
 
@@ -163,12 +365,15 @@ var Config = struct {
 		* RS256, in this case `FLAGR_JWT_AUTH_SECRET` contains the key in PEM Format
 
 	Note:
-		If the access_token is present in both the header and cookie only the latest will be used
+		If the access_token is present in both the header and cookie, the one of the first of
+		FLAGR_JWT_AUTH_TOKEN_SOURCES is used, which is the cookie by default
 	*/
 	JWTAuthEnabled              bool     `env:"FLAGR_JWT_AUTH_ENABLED" envDefault:"false"`
 	JWTAuthDebug                bool     `env:"FLAGR_JWT_AUTH_DEBUG" envDefault:"false"`
 	JWTAuthPrefixWhitelistPaths []string `env:"FLAGR_JWT_AUTH_WHITELIST_PATHS" envDefault:"/api/v1/evaluation,/static" envSeparator:","`
 	JWTAuthExactWhitelistPaths  []string `env:"FLAGR_JWT_AUTH_EXACT_WHITELIST_PATHS" envDefault:",/" envSeparator:","`
+	// JWTAuthPrefixBlacklistPaths are the paths that always require JWT auth, even if they match the whitelist paths
+	JWTAuthPrefixBlacklistPaths []string `env:"FLAGR_JWT_AUTH_BLACKLIST_PATHS" envDefault:"/api/v1/evaluation/cache" envSeparator:","`
 	JWTAuthCookieTokenName      string   `env:"FLAGR_JWT_AUTH_COOKIE_TOKEN_NAME" envDefault:"access_token"`
 	JWTAuthSecret               string   `env:"FLAGR_JWT_AUTH_SECRET" envDefault:""`
 	JWTAuthNoTokenStatusCode    int      `env:"FLAGR_JWT_AUTH_NO_TOKEN_STATUS_CODE" envDefault:"307"` // "307" or "401"
@@ -181,12 +386,67 @@ var Config = struct {
 	// JWTAuthRequireGroupClaim can be used to assert that groups claim must contain this named group
 	JWTAuthRequireGroupClaim string `env:"FLAGR_JWT_AUTH_REQUIRE_GROUP_CLAIM" envDefault:""`
 
+	// JWTAuthAdminGroupClaim - the users whose groups claim contains this named group are the admins, who can list
+	// the deleted flags and restore them. Without the JWT auth and the proxy auth, every caller is an admin.
+	JWTAuthAdminGroupClaim string `env:"FLAGR_JWT_AUTH_ADMIN_GROUP_CLAIM" envDefault:""`
+
+	// JWTAuthNamespaceClaim enables the namespaces of flags if it's set. The flags belong to the namespace
+	// of this claim in the JWT token of the creator, and the callers only see the flags of their own namespace.
+	// E.g. team, project, or tenant in a JWT token.
+	JWTAuthNamespaceClaim string `env:"FLAGR_JWT_AUTH_NAMESPACE_CLAIM" envDefault:""`
+
 	// "HS256" and "RS256" supported
 	JWTAuthSigningMethod string `env:"FLAGR_JWT_AUTH_SIGNING_METHOD" envDefault:"HS256"`
 
+	// JWTAuthClockSkew is the leeway of validating the exp, nbf and iat claims of the JWT token, for the clock
+	// drift between the IdP and flagr. E.g. 5s. It's 0 by default, i.e. the claims are validated strictly.
+	JWTAuthClockSkew time.Duration `env:"FLAGR_JWT_AUTH_CLOCK_SKEW" envDefault:"0"`
+
+	// JWTAuthTokenSources are where the JWT token is extracted from, in order, "cookie" for the cookie of
+	// JWTAuthCookieTokenName and "header" for the header of JWTAuthTokenHeaderName. Leave one out to disable it.
+	JWTAuthTokenSources    []string `env:"FLAGR_JWT_AUTH_TOKEN_SOURCES" envDefault:"cookie,header" envSeparator:","`
+	JWTAuthTokenHeaderName string   `env:"FLAGR_JWT_AUTH_TOKEN_HEADER_NAME" envDefault:"Authorization"`
+
+	// OAuthIntrospectionURL - the RFC 7662 token introspection endpoint of the IdP. If it's set, the JWT auth
+	// validates the bearer tokens, e.g. the opaque ones, with the IdP instead of checking their signatures.
+	// The client credentials authenticate flagr to the IdP, and the active tokens are cached until their exp.
+	// The response of the introspection is used as the claims, e.g. for JWTAuthUserClaim.
+	OAuthIntrospectionURL          string        `env:"FLAGR_OAUTH_INTROSPECTION_URL" envDefault:""`
+	OAuthIntrospectionClientID     string        `env:"FLAGR_OAUTH_INTROSPECTION_CLIENT_ID" envDefault:""`
+	OAuthIntrospectionClientSecret string        `env:"FLAGR_OAUTH_INTROSPECTION_CLIENT_SECRET" envDefault:""`
+	OAuthIntrospectionTimeout      time.Duration `env:"FLAGR_OAUTH_INTROSPECTION_TIMEOUT" envDefault:"5s"`
+
+	// ProxyAuthEnabled - to trust the user of ProxyAuthUserHeader set by the auth proxy in front of flagr, e.g. oauth2-proxy,
+	// instead of the JWT auth. The user is the JWTAuthUserClaim of the request, and the comma separated groups of
+	// ProxyAuthGroupsHeader are its groups claim for JWTAuthRequireGroupClaim. Any client can set the headers, so it
+	// requires IPAllowlistEnabled, and the headers are only trusted from the IPs of IPAllowlistCIDRs.
+	// The whitelist paths are the same as the JWT auth's.
+	ProxyAuthEnabled      bool   `env:"FLAGR_PROXY_AUTH_ENABLED" envDefault:"false"`
+	ProxyAuthUserHeader   string `env:"FLAGR_PROXY_AUTH_USER_HEADER" envDefault:"X-Forwarded-User"`
+	ProxyAuthGroupsHeader string `env:"FLAGR_PROXY_AUTH_GROUPS_HEADER" envDefault:"X-Forwarded-Groups"`
+
+	// IPAllowlistEnabled - to respond 403 to the requests of IPAllowlistPrefixPaths from the IPs outside IPAllowlistCIDRs.
+	// The paths that are not listed, e.g. the evaluation, are open to all the IPs.
+	IPAllowlistEnabled     bool     `env:"FLAGR_IP_ALLOWLIST_ENABLED" envDefault:"false"`
+	IPAllowlistCIDRs       []string `env:"FLAGR_IP_ALLOWLIST_CIDRS" envDefault:"" envSeparator:","`
+	IPAllowlistPrefixPaths []string `env:"FLAGR_IP_ALLOWLIST_PATHS" envDefault:"/api/v1/flags,/api/v1/tags,/api/v1/export,/api/v1/exclusion_groups,/api/v1/constraint_groups,/api/v1/segment_templates" envSeparator:","`
+	// IPAllowlistTrustedProxies - the number of proxies in front of flagr that append to X-Forwarded-For,
+	// the client IP is the one before them. X-Forwarded-For is ignored if it's 0.
+	// It's only used if TrustedProxies is not set.
+	IPAllowlistTrustedProxies int `env:"FLAGR_IP_ALLOWLIST_TRUSTED_PROXIES" envDefault:"0"`
+
+	// TrustedProxies - the proxies in front of flagr that append to X-Forwarded-For, the client IP is the
+	// one before them. It's used by the access logs, the statsd tags and the IP allowlist.
+	// The entries are the CIDRs or IPs of the proxies, or the number of them, e.g. 10.0.0.0/8,172.16.0.1 or 2.
+	// X-Forwarded-For is ignored if it's not set.
+	TrustedProxies []string `env:"FLAGR_TRUSTED_PROXIES" envDefault:"" envSeparator:","`
+
 	// WebPrefix - base path for web and API
 	// e.g. FLAGR_WEB_PREFIX=/foo
 	// UI path  => localhost:18000/foo"
 	// API path => localhost:18000/foo/api/v1"
 	WebPrefix string `env:"FLAGR_WEB_PREFIX" envDefault:""`
+	// WebUIEnabled - to serve the UI, e.g. it can be disabled for the evaluation only deployments without the UI assets.
+	// The unmatched paths are 404 of the API then, instead of the index.html of the UI.
+	WebUIEnabled bool `env:"FLAGR_WEB_UI_ENABLED" envDefault:"true"`
 }{}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/urfave/negroni"
+)
+
+// gzipMiddleware encodes the responses with gzip when the client accepts it. The responses shorter than
+// minLength are sent as they are, gzip costs more than it saves for them, e.g. for a single evaluation.
+type gzipMiddleware struct {
+	minLength int
+	pool      sync.Pool
+}
+
+func setupGzipMiddleware() *gzipMiddleware {
+	m := &gzipMiddleware{minLength: Config.MiddlewareGzipMinLength}
+	if m.minLength < 0 {
+		m.minLength = 0
+	}
+	m.pool.New = func() interface{} {
+		return gzip.NewWriter(ioutil.Discard)
+	}
+	return m
+}
+
+func (m *gzipMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if !acceptsGzip(r.Header.Get("Accept-Encoding")) || r.Header.Get("Sec-WebSocket-Key") != "" {
+		next(w, r)
+		return
+	}
+
+	gw := &gzipResponseWriter{ResponseWriter: negroni.NewResponseWriter(w), m: m}
+	defer gw.close()
+	next(gw, r)
+}
+
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		if coding, q := parseAcceptEncodingPart(part); coding == "gzip" && q > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter holds the status code until the first write, and the body until it reaches minLength
+// if its Content-Length is unknown, so that the short responses are sent as they are. A flush sends the body
+// held back right away, it's gzipped then, as the length of a flushed response is unknown.
+type gzipResponseWriter struct {
+	negroni.ResponseWriter
+	m           *gzipMiddleware
+	status      int
+	wroteHeader bool
+	buf         []byte
+	gz          *gzip.Writer
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *gzipResponseWriter) Status() int {
+	if w.status != 0 && !w.wroteHeader {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.wroteHeader {
+		return w.write(b)
+	}
+
+	h := w.Header()
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", http.DetectContentType(b))
+	}
+	if !w.compressible() {
+		w.writeHeader(false)
+		return w.write(b)
+	}
+	if n, err := strconv.Atoi(h.Get("Content-Length")); err == nil {
+		w.writeHeader(n >= w.m.minLength)
+		return w.write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) < w.m.minLength {
+		return len(b), nil
+	}
+	if err := w.writeBuffered(true); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *gzipResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.writeBuffered(w.compressible())
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *gzipResponseWriter) write(b []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// writeBuffered writes the header and the body held back
+func (w *gzipResponseWriter) writeBuffered(compress bool) error {
+	buf := w.buf
+	w.buf = nil
+	w.writeHeader(compress)
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.write(buf)
+	return err
+}
+
+// compressible tells if the response can be gzipped, the responses already encoded are left alone
+func (w *gzipResponseWriter) compressible() bool {
+	return w.status != http.StatusNoContent &&
+		w.status != http.StatusNotModified &&
+		w.Header().Get("Content-Encoding") == ""
+}
+
+func (w *gzipResponseWriter) writeHeader(compress bool) {
+	w.wroteHeader = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	h := w.Header()
+	if w.compressible() {
+		h.Add("Vary", "Accept-Encoding")
+	}
+	if compress {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		w.gz = w.m.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *gzipResponseWriter) close() {
+	if !w.wroteHeader {
+		if w.status == 0 && len(w.buf) == 0 {
+			return
+		}
+		// the whole body is shorter than minLength
+		if len(w.buf) > 0 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(w.buf)))
+		}
+		w.writeBuffered(false)
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		w.m.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
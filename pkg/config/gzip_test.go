@@ -0,0 +1,141 @@
+package config
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/negroni"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	assert.True(t, acceptsGzip("gzip, deflate, br"))
+	assert.True(t, acceptsGzip("gzip;q=0.5"))
+	assert.False(t, acceptsGzip(""))
+	assert.False(t, acceptsGzip("br, deflate"))
+	assert.False(t, acceptsGzip("gzip;q=0"))
+}
+
+func TestGzipMiddleware(t *testing.T) {
+	body := strings.Repeat(`{"flagKey":"kmmcd"}`, 100)
+
+	prev := Config.MiddlewareGzipMinLength
+	defer func() { Config.MiddlewareGzipMinLength = prev }()
+	Config.MiddlewareGzipMinLength = 1024
+
+	serve := func(acceptEncoding string, h http.HandlerFunc) *httptest.ResponseRecorder {
+		n := negroni.New()
+		n.Use(setupGzipMiddleware())
+		n.UseHandler(h)
+
+		req := httptest.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		res := httptest.NewRecorder()
+		n.ServeHTTP(res, req)
+		return res
+	}
+	gunzip := func(t *testing.T, res *httptest.ResponseRecorder) string {
+		r, err := gzip.NewReader(res.Body)
+		assert.NoError(t, err)
+		b, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		return string(b)
+	}
+	writeChunks := func(chunks ...string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			for _, c := range chunks {
+				w.Write([]byte(c))
+			}
+		}
+	}
+
+	t.Run("gzips the responses at least minLength long", func(t *testing.T) {
+		res := serve("gzip, deflate", writeChunks(body[:500], body[500:]))
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "gzip", res.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", res.Header().Get("Vary"))
+		assert.Empty(t, res.Header().Get("Content-Length"))
+		assert.Equal(t, body, gunzip(t, res))
+	})
+
+	t.Run("sends the shorter responses as they are", func(t *testing.T) {
+		res := serve("gzip", writeChunks(`{"flagKey":`, `"kmmcd"}`))
+		assert.Empty(t, res.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", res.Header().Get("Vary"))
+		assert.Equal(t, "19", res.Header().Get("Content-Length"))
+		assert.Equal(t, `{"flagKey":"kmmcd"}`, res.Body.String())
+	})
+
+	t.Run("decides by the Content-Length if it's set", func(t *testing.T) {
+		res := serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.Write([]byte(body[:10]))
+			w.Write([]byte(body[10:]))
+		})
+		assert.Equal(t, "gzip", res.Header().Get("Content-Encoding"))
+		assert.Empty(t, res.Header().Get("Content-Length"))
+		assert.Equal(t, body, gunzip(t, res))
+
+		res = serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", "2")
+			w.Write([]byte("{}"))
+		})
+		assert.Empty(t, res.Header().Get("Content-Encoding"))
+		assert.Equal(t, "{}", res.Body.String())
+	})
+
+	t.Run("gzips the flushed responses of unknown length", func(t *testing.T) {
+		res := serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("data: 1\n\n"))
+			w.(http.Flusher).Flush()
+			w.Write([]byte("data: 2\n\n"))
+		})
+		assert.Equal(t, "gzip", res.Header().Get("Content-Encoding"))
+		assert.Equal(t, "data: 1\n\ndata: 2\n\n", gunzip(t, res))
+	})
+
+	t.Run("identity without accept-encoding", func(t *testing.T) {
+		res := serve("", writeChunks(body))
+		assert.Empty(t, res.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, res.Body.String())
+	})
+
+	t.Run("leaves the encoded responses alone", func(t *testing.T) {
+		res := serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "identity")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(body))
+		})
+		assert.Equal(t, "identity", res.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, res.Body.String())
+	})
+
+	t.Run("keeps the status code", func(t *testing.T) {
+		res := serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(body))
+		})
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, "gzip", res.Header().Get("Content-Encoding"))
+
+		res = serve("gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Empty(t, res.Header().Get("Content-Encoding"))
+		assert.Zero(t, res.Body.Len())
+	})
+
+	t.Run("0 gzips all the responses", func(t *testing.T) {
+		Config.MiddlewareGzipMinLength = 0
+		res := serve("gzip", writeChunks("{}"))
+		assert.Equal(t, "gzip", res.Header().Get("Content-Encoding"))
+		assert.Equal(t, "{}", gunzip(t, res))
+	})
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"net/http"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// SetupH2C serves the cleartext HTTP/2 (h2c) on the http server besides HTTP/1.1 if HTTP2H2CEnabled,
+// both with the prior knowledge and with the upgrade from HTTP/1.1. It wraps the handler of the server,
+// i.e. the one of SetupGlobalMiddleware, so the HTTP/2 streams go through the same middleware chain.
+func SetupH2C(s *http.Server) {
+	if !Config.HTTP2H2CEnabled {
+		return
+	}
+	s.Handler = h2c.NewHandler(s.Handler, &http2.Server{IdleTimeout: s.IdleTimeout})
+}
+
+// preferHTTP2 puts h2 ahead of the other protocols of ALPN, as the server's order wins the negotiation.
+// It's h2 and http/1.1 if there's no protocol yet.
+func preferHTTP2(protos []string) []string {
+	preferred := []string{http2.NextProtoTLS}
+	for _, p := range protos {
+		if p != http2.NextProtoTLS {
+			preferred = append(preferred, p)
+		}
+	}
+	if len(preferred) == 1 {
+		preferred = append(preferred, "http/1.1")
+	}
+	return preferred
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+)
+
+type protoHandler struct{}
+
+func (o *protoHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Write([]byte(req.Proto))
+}
+
+func TestSetupH2C(t *testing.T) {
+	t.Run("it does nothing if h2c is not enabled", func(t *testing.T) {
+		s := &http.Server{Handler: &okHandler{}}
+		SetupH2C(s)
+		assert.Equal(t, &okHandler{}, s.Handler)
+	})
+
+	Config.HTTP2H2CEnabled = true
+	defer func() { Config.HTTP2H2CEnabled = false }()
+
+	s := httptest.NewUnstartedServer(SetupGlobalMiddleware(&protoHandler{}))
+	SetupServer(s.Config)
+	SetupH2C(s.Config)
+	s.Start()
+	defer s.Close()
+
+	get := func(client *http.Client) (*http.Response, string) {
+		res, err := client.Get(s.URL)
+		if !assert.NoError(t, err) {
+			return nil, ""
+		}
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		assert.NoError(t, err)
+		return res, string(body)
+	}
+
+	t.Run("it serves h2c with prior knowledge through the middleware chain", func(t *testing.T) {
+		client := &http.Client{Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		}}
+		res, body := get(client)
+		assert.Equal(t, 2, res.ProtoMajor)
+		assert.Equal(t, "HTTP/2.0", body)
+		assert.True(t, res.Uncompressed, "it's gzipped by the middleware")
+	})
+
+	t.Run("it still serves HTTP/1.1", func(t *testing.T) {
+		res, body := get(&http.Client{})
+		assert.Equal(t, 1, res.ProtoMajor)
+		assert.Equal(t, "HTTP/1.1", body)
+	})
+}
+
+func TestSetupTLSWithHTTP2(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flagr_http2")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeTestCert(t, certFile, keyFile, "localhost")
+
+	Config.TLSEnabled, Config.TLSCertFile, Config.TLSKeyFile = true, certFile, keyFile
+	defer func() {
+		Config.TLSEnabled = false
+		Config.TLSCertFile = ""
+		Config.TLSKeyFile = ""
+	}()
+
+	tlsConfig := &tls.Config{NextProtos: []string{"http/1.1", "h2"}}
+	assert.NoError(t, SetupTLS(tlsConfig))
+	assert.Equal(t, []string{"h2", "http/1.1"}, tlsConfig.NextProtos)
+
+	s := httptest.NewUnstartedServer(&protoHandler{})
+	s.EnableHTTP2 = true
+	s.TLS = tlsConfig
+	s.StartTLS()
+	defer s.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"http/1.1", "h2"}},
+		ForceAttemptHTTP2: true,
+	}}
+	res, err := client.Get(s.URL)
+	if assert.NoError(t, err) {
+		defer res.Body.Close()
+		assert.Equal(t, 2, res.ProtoMajor)
+	}
+}
+
+func TestPreferHTTP2(t *testing.T) {
+	assert.Equal(t, []string{"h2", "http/1.1"}, preferHTTP2(nil))
+	assert.Equal(t, []string{"h2", "http/1.1"}, preferHTTP2([]string{"http/1.1", "h2"}))
+	assert.Equal(t, []string{"h2", "http/1.1"}, preferHTTP2([]string{"h2", "http/1.1"}))
+	assert.Equal(t, []string{"h2", "http/1.1"}, preferHTTP2([]string{"http/1.1"}))
+}
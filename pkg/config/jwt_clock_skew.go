@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// clockSkewClaims validates the exp, nbf and iat claims with the leeway, instead of the strict validation of
+// jwt.MapClaims. The parser of the JWT middleware validates the claims right after it gets the key, so they're
+// swapped in by the key getter of withClockSkew, and the token gets its jwt.MapClaims back once they're validated.
+type clockSkewClaims struct {
+	jwt.MapClaims
+	token  *jwt.Token
+	leeway time.Duration
+}
+
+func (c *clockSkewClaims) Valid() error {
+	c.token.Claims = c.MapClaims
+
+	now := jwt.TimeFunc()
+	vErr := &jwt.ValidationError{}
+	if !c.VerifyExpiresAt(now.Add(-c.leeway).Unix(), false) {
+		vErr.Inner = errors.New("token is expired")
+		vErr.Errors |= jwt.ValidationErrorExpired
+	}
+	if !c.VerifyIssuedAt(now.Add(c.leeway).Unix(), false) {
+		vErr.Inner = errors.New("token used before issued")
+		vErr.Errors |= jwt.ValidationErrorIssuedAt
+	}
+	if !c.VerifyNotBefore(now.Add(c.leeway).Unix(), false) {
+		vErr.Inner = errors.New("token is not valid yet")
+		vErr.Errors |= jwt.ValidationErrorNotValidYet
+	}
+	if vErr.Errors != 0 {
+		return vErr
+	}
+	return nil
+}
+
+// withClockSkew wraps the key getter of the JWT middleware, so that the time claims of the
+// token are validated with the leeway. The claims are validated strictly if it's 0.
+func withClockSkew(keyFunc jwt.Keyfunc, leeway time.Duration) jwt.Keyfunc {
+	if leeway <= 0 {
+		return keyFunc
+	}
+	return func(token *jwt.Token) (interface{}, error) {
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			token.Claims = &clockSkewClaims{MapClaims: claims, token: token, leeway: leeway}
+		}
+		return keyFunc(token)
+	}
+}
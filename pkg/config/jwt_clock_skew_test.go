@@ -0,0 +1,79 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func signedHS256JWTToken(claims jwt.MapClaims) string {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(""))
+	return token
+}
+
+func TestWithClockSkew(t *testing.T) {
+	keyFunc := func(*jwt.Token) (interface{}, error) { return []byte(""), nil }
+	now := time.Now()
+
+	for _, tc := range []struct {
+		name   string
+		claims jwt.MapClaims
+		leeway time.Duration
+		valid  bool
+	}{
+		{"it should accept the token without the time claims", jwt.MapClaims{"sub": "1"}, 5 * time.Second, true},
+		{"it should accept the expired token within the leeway", jwt.MapClaims{"exp": now.Add(-3 * time.Second).Unix()}, 5 * time.Second, true},
+		{"it should reject the expired token beyond the leeway", jwt.MapClaims{"exp": now.Add(-10 * time.Second).Unix()}, 5 * time.Second, false},
+		{"it should accept the not yet valid token within the leeway", jwt.MapClaims{"nbf": now.Add(3 * time.Second).Unix()}, 5 * time.Second, true},
+		{"it should reject the not yet valid token beyond the leeway", jwt.MapClaims{"nbf": now.Add(10 * time.Second).Unix()}, 5 * time.Second, false},
+		{"it should accept the token issued in the future within the leeway", jwt.MapClaims{"iat": now.Add(3 * time.Second).Unix()}, 5 * time.Second, true},
+		{"it should reject the token issued in the future beyond the leeway", jwt.MapClaims{"iat": now.Add(10 * time.Second).Unix()}, 5 * time.Second, false},
+		{"it should validate the time claims strictly without leeway", jwt.MapClaims{"exp": now.Add(-3 * time.Second).Unix()}, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := jwt.Parse(signedHS256JWTToken(tc.claims), withClockSkew(keyFunc, tc.leeway))
+			assert.Equal(t, tc.valid, err == nil, "%v", err)
+			assert.Equal(t, tc.valid, token.Valid)
+			if tc.valid {
+				assert.IsType(t, jwt.MapClaims{}, token.Claims)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareWithClockSkew(t *testing.T) {
+	h := &okHandler{}
+	Config.JWTAuthEnabled = true
+	Config.JWTAuthNoTokenStatusCode = http.StatusUnauthorized
+	defer func() {
+		Config.JWTAuthEnabled = false
+		Config.JWTAuthNoTokenStatusCode = http.StatusTemporaryRedirect
+		Config.JWTAuthClockSkew = 0
+	}()
+	token := signedHS256JWTToken(jwt.MapClaims{
+		"flagr_user": "1234567890",
+		"nbf":        time.Now().Add(2 * time.Second).Unix(),
+		"exp":        time.Now().Add(-2 * time.Second).Unix(),
+	})
+
+	for _, tc := range []struct {
+		leeway time.Duration
+		code   int
+	}{
+		{0, http.StatusUnauthorized},
+		{5 * time.Second, http.StatusOK},
+	} {
+		Config.JWTAuthClockSkew = tc.leeway
+		hh := SetupGlobalMiddleware(h)
+
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil)
+		req.Header.Add("Authorization", "Bearer "+token)
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, tc.code, res.Code, "leeway %s", tc.leeway)
+	}
+}
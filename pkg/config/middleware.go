@@ -2,10 +2,20 @@ package config
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	mathrand "math/rand"
+	"net"
 	"net/http"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/DataDog/datadog-go/statsd"
@@ -14,7 +24,6 @@ import (
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/gohttp/pprof"
 	negronilogrus "github.com/meatballhat/negroni-logrus"
-	"github.com/phyber/negroni-gzip/gzip"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
@@ -24,28 +33,57 @@ import (
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 )
 
+// webUIDir is the directory of the built UI, it's served by the static middleware if WebUIEnabled
+var webUIDir = "./browser/flagr-ui/dist/"
+
 // ServerShutdown is a callback function that will be called when
 // we tear down the flagr server
 func ServerShutdown() {
 	if Config.StatsdEnabled && Config.StatsdAPMEnabled {
 		tracer.Stop()
 	}
+	if Global.StatsdClient != nil {
+		// it flushes the buffered metrics
+		Global.StatsdClient.Close()
+	}
+}
+
+// SetupServer applies the timeouts of the config to the http server, the unset ones keep the timeouts of the server flags
+func SetupServer(s *http.Server) {
+	if Config.ServerReadTimeout > 0 {
+		s.ReadTimeout = Config.ServerReadTimeout
+	}
+	if Config.ServerWriteTimeout > 0 {
+		s.WriteTimeout = Config.ServerWriteTimeout
+	}
+	if Config.ServerIdleTimeout > 0 {
+		s.IdleTimeout = Config.ServerIdleTimeout
+	}
 }
 
 // SetupGlobalMiddleware setup the global middleware
 func SetupGlobalMiddleware(handler http.Handler) http.Handler {
 	n := negroni.New()
+	clientIPs := setupClientIPResolver()
+
+	if Config.MiddlewareBrotliEnabled {
+		n.Use(setupBrotliMiddleware())
+	}
 
 	if Config.MiddlewareGzipEnabled {
-		n.Use(gzip.Gzip(gzip.DefaultCompression))
+		n.Use(setupGzipMiddleware())
 	}
 
 	if Config.MiddlewareVerboseLoggerEnabled {
-		n.Use(negronilogrus.NewMiddlewareFromLogger(logrus.StandardLogger(), "flagr"))
+		n.Use(setupVerboseLoggerMiddleware(clientIPs))
 	}
 
 	if Config.StatsdEnabled {
-		n.Use(&statsdMiddleware{StatsdClient: Global.StatsdClient})
+		statsdMW := &statsdMiddleware{StatsdClient: Global.StatsdClient}
+		if Config.StatsdClientIPTagEnabled {
+			statsdMW.ClientIPs = clientIPs
+		}
+		n.Use(statsdMW)
 
 		if Config.StatsdAPMEnabled {
 			tracer.Start(
@@ -70,13 +108,19 @@ func SetupGlobalMiddleware(handler http.Handler) http.Handler {
 		n.Use(cors.New(cors.Options{
 			AllowedOrigins:   []string{"*"},
 			AllowedHeaders:   []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization", "Time_Zone"},
-			ExposedHeaders:   []string{"Www-Authenticate"},
+			ExposedHeaders:   []string{"Www-Authenticate", "X-Total-Count"},
 			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "PATCH"},
 			AllowCredentials: true,
 		}))
 	}
 
-	if Config.JWTAuthEnabled {
+	if Config.IPAllowlistEnabled {
+		n.Use(setupIPAllowlistMiddleware(clientIPs))
+	}
+
+	if Config.ProxyAuthEnabled {
+		n.Use(setupProxyAuthMiddleware(clientIPs))
+	} else if Config.JWTAuthEnabled {
 		n.Use(setupJWTAuthMiddleware())
 	}
 
@@ -84,14 +128,28 @@ func SetupGlobalMiddleware(handler http.Handler) http.Handler {
 		n.Use(setupJWTRequireGroupClaimMiddleware())
 	}
 
-	n.Use(&negroni.Static{
-		Dir:       http.Dir("./browser/flagr-ui/dist/"),
-		Prefix:    Config.WebPrefix,
-		IndexFile: "index.html",
-	})
+	if Config.WebUIEnabled {
+		n.Use(&negroni.Static{
+			Dir:       http.Dir(webUIDir),
+			Prefix:    Config.WebPrefix,
+			IndexFile: "index.html",
+		})
+	}
 
 	n.Use(setupRecoveryMiddleware())
 
+	// they're the last ones because they wrap the response writer, which is not a negroni.ResponseWriter anymore
+	if Config.RequestTimeout > 0 {
+		n.Use(&requestTimeout{
+			timeout:          Config.RequestTimeout,
+			exactExemptPaths: []string{"/api/v1/flags/stream"},
+		})
+	}
+
+	if Config.MiddlewareMaxRequestBodyEnabled {
+		n.Use(&maxRequestBody{maxBytes: Config.MaxRequestBodyBytes})
+	}
+
 	if Config.WebPrefix != "" {
 		handler = http.StripPrefix(Config.WebPrefix, handler)
 	}
@@ -105,6 +163,56 @@ func SetupGlobalMiddleware(handler http.Handler) http.Handler {
 	return n
 }
 
+func setupVerboseLoggerMiddleware(clientIPs *clientIPResolver) negroni.Handler {
+	newLogger := func() *negronilogrus.Middleware {
+		logger := negronilogrus.NewMiddlewareFromLogger(logrus.StandardLogger(), "flagr")
+		logger.Before = func(entry *logrus.Entry, req *http.Request, remoteAddr string) *logrus.Entry {
+			return negronilogrus.DefaultBefore(entry, req, remoteAddr).WithField("client_ip", clientIPs.clientIPString(req))
+		}
+		return logger
+	}
+
+	if Config.MiddlewareLoggerSampleRate >= 1 {
+		return newLogger()
+	}
+
+	// the requests out of the sample are only logged once they complete with a non-2xx status
+	discard := logrus.New()
+	discard.Out = ioutil.Discard
+	unsampled := newLogger()
+	unsampled.SetLogStarting(false)
+	unsampled.After = func(entry *logrus.Entry, res negroni.ResponseWriter, latency time.Duration, name string) *logrus.Entry {
+		if res.Status() >= 200 && res.Status() < 300 {
+			return logrus.NewEntry(discard)
+		}
+		return negronilogrus.DefaultAfter(entry, res, latency, name)
+	}
+
+	return &sampledLogger{
+		sampled:    newLogger(),
+		unsampled:  unsampled,
+		sampleRate: Config.MiddlewareLoggerSampleRate,
+		random:     mathrand.Float64,
+	}
+}
+
+// sampledLogger logs a sampleRate fraction of the requests with the sampled logger,
+// and the rest with the unsampled one
+type sampledLogger struct {
+	sampled    negroni.Handler
+	unsampled  negroni.Handler
+	sampleRate float64
+	random     func() float64
+}
+
+func (s *sampledLogger) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if s.random() < s.sampleRate {
+		s.sampled.ServeHTTP(w, r, next)
+		return
+	}
+	s.unsampled.ServeHTTP(w, r, next)
+}
+
 type recoveryLogger struct{}
 
 func (r *recoveryLogger) Printf(format string, v ...interface{}) {
@@ -115,10 +223,88 @@ func (r *recoveryLogger) Println(v ...interface{}) {
 	logrus.Errorln(v...)
 }
 
-func setupRecoveryMiddleware() *negroni.Recovery {
-	r := negroni.NewRecovery()
-	r.Logger = &recoveryLogger{}
-	return r
+// recovery responds 500 to the panics, the stack is only logged and never sent to the client.
+// The API callers get a JSON error, and the HTML page is kept for the browsers of the UI.
+type recovery struct {
+	Logger    negroni.ALogger
+	StackSize int
+}
+
+// recoveryError is the JSON body of the recovered panic
+type recoveryError struct {
+	Error     string `json:"error"`
+	RequestID string `json:"requestId"`
+}
+
+func setupRecoveryMiddleware() *recovery {
+	return &recovery{
+		Logger:    &recoveryLogger{},
+		StackSize: 1024 * 8,
+	}
+}
+
+func (rec *recovery) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	defer func() {
+		if err := recover(); err != nil {
+			stack := make([]byte, rec.StackSize)
+			stack = stack[:runtime.Stack(stack, false)]
+			requestID := getRequestID(r)
+			rec.Logger.Printf("PANIC: %s\nrequestId: %s\n%s", err, requestID, stack)
+
+			w.Header().Set("X-Request-Id", requestID)
+			if prefersHTML(r) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(http.StatusInternalServerError)
+				(&negroni.HTMLPanicFormatter{}).FormatPanicError(w, r, &negroni.PanicInformation{RecoveredPanic: err, Request: r})
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(&recoveryError{Error: "internal server error", RequestID: requestID})
+		}
+	}()
+
+	next(w, r)
+}
+
+// getRequestID gets the X-Request-Id set by the proxies, or generates one so
+// that the response can be matched with the logs
+func getRequestID(r *http.Request) string {
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// prefersHTML tells if text/html has a higher quality than application/json in the Accept header.
+// The wildcards only count for JSON, so that the API callers without an Accept always get JSON.
+func prefersHTML(r *http.Request) bool {
+	htmlQ, jsonQ := 0.0, 0.0
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		parts := strings.Split(accept, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(parts[0]))
+		q := 1.0
+		for _, param := range parts[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && kv[0] == "q" {
+				if v, err := strconv.ParseFloat(kv[1], 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		switch mediaType {
+		case "text/html":
+			htmlQ = math.Max(htmlQ, q)
+		case "application/json", "application/*", "*/*":
+			jsonQ = math.Max(jsonQ, q)
+		}
+	}
+	return htmlQ > jsonQ
 }
 
 /**
@@ -141,28 +327,95 @@ func setupJWTAuthMiddleware() *auth {
 		validationKey = []byte("")
 	}
 
+	extractor := jwtTokenExtractor(Config.JWTAuthTokenSources)
+
+	options := jwtmiddleware.Options{
+		ValidationKeyGetter: withClockSkew(func(token *jwt.Token) (interface{}, error) {
+			return validationKey, errParsingKey
+		}, Config.JWTAuthClockSkew),
+		SigningMethod: signingMethod,
+		Extractor:     extractor,
+		UserProperty:  Config.JWTAuthUserProperty,
+		Debug:         Config.JWTAuthDebug,
+		ErrorHandler:  jwtErrorHandler,
+	}
+
+	a := &auth{
+		PrefixWhitelistPaths: Config.JWTAuthPrefixWhitelistPaths,
+		ExactWhitelistPaths:  Config.JWTAuthExactWhitelistPaths,
+		PrefixBlacklistPaths: Config.JWTAuthPrefixBlacklistPaths,
+		JWTMiddleware:        jwtmiddleware.New(options),
+	}
+	if Config.EvalContextFromJWT {
+		// the token on the whitelisted paths is parsed for its claims, but the
+		// requests without a valid token are still let through
+		optional := options
+		optional.CredentialsOptional = true
+		optional.ErrorHandler = func(http.ResponseWriter, *http.Request, string) {}
+		a.OptionalJWTMiddleware = jwtmiddleware.New(optional)
+	}
+	if Config.OAuthIntrospectionURL != "" {
+		a.Introspection = newOAuthIntrospection(extractor)
+	}
+	return a
+}
+
+// jwtTokenExtractor extracts the JWT token from the first of the sources that has it, in the order of the sources.
+// The sources are "cookie", the cookie of JWTAuthCookieTokenName, and "header", the header of JWTAuthTokenHeaderName.
+func jwtTokenExtractor(sources []string) jwtmiddleware.TokenExtractor {
+	extractors := []jwtmiddleware.TokenExtractor{}
+	for _, source := range sources {
+		switch strings.TrimSpace(source) {
+		case "cookie":
+			extractors = append(extractors, fromCookie(Config.JWTAuthCookieTokenName))
+		case "header":
+			extractors = append(extractors, fromHeader(Config.JWTAuthTokenHeaderName))
+		case "":
+		default:
+			panic(fmt.Sprintf("unknown JWT token source %s, it should be cookie or header", source))
+		}
+	}
+	if len(extractors) == 0 {
+		panic("FLAGR_JWT_AUTH_TOKEN_SOURCES requires at least one of cookie and header")
+	}
+	return jwtmiddleware.FromFirst(extractors...)
+}
+
+func fromCookie(name string) jwtmiddleware.TokenExtractor {
+	return func(r *http.Request) (string, error) {
+		c, err := r.Cookie(name)
+		if err != nil {
+			return "", nil
+		}
+		return c.Value, nil
+	}
+}
+
+// fromHeader extracts the token of "Bearer {token}" in the header. The Authorization header requires the
+// Bearer scheme, while the other headers can also have the bare token, e.g. X-Auth-Token: {token}.
+func fromHeader(name string) jwtmiddleware.TokenExtractor {
+	return func(r *http.Request) (string, error) {
+		parts := strings.Fields(r.Header.Get(name))
+		switch {
+		case len(parts) == 0:
+			return "", nil
+		case len(parts) == 2 && strings.EqualFold(parts[0], "bearer"):
+			return parts[1], nil
+		case len(parts) == 1 && !strings.EqualFold(name, "Authorization"):
+			return parts[0], nil
+		}
+		return "", fmt.Errorf("%s header format must be Bearer {token}", name)
+	}
+}
+
+// setupProxyAuthMiddleware sets up the auth of the user headers of the auth proxy, instead of the JWT tokens.
+// The whitelist paths are the same as the JWT auth's.
+func setupProxyAuthMiddleware(clientIPs *clientIPResolver) *auth {
 	return &auth{
 		PrefixWhitelistPaths: Config.JWTAuthPrefixWhitelistPaths,
 		ExactWhitelistPaths:  Config.JWTAuthExactWhitelistPaths,
-		JWTMiddleware: jwtmiddleware.New(jwtmiddleware.Options{
-			ValidationKeyGetter: func(token *jwt.Token) (interface{}, error) {
-				return validationKey, errParsingKey
-			},
-			SigningMethod: signingMethod,
-			Extractor: jwtmiddleware.FromFirst(
-				func(r *http.Request) (string, error) {
-					c, err := r.Cookie(Config.JWTAuthCookieTokenName)
-					if err != nil {
-						return "", nil
-					}
-					return c.Value, nil
-				},
-				jwtmiddleware.FromAuthHeader,
-			),
-			UserProperty: Config.JWTAuthUserProperty,
-			Debug:        Config.JWTAuthDebug,
-			ErrorHandler: jwtErrorHandler,
-		}),
+		PrefixBlacklistPaths: Config.JWTAuthPrefixBlacklistPaths,
+		Proxy:                newProxyAuth(clientIPs),
 	}
 }
 
@@ -181,7 +434,14 @@ func jwtErrorHandler(w http.ResponseWriter, r *http.Request, err string) {
 type auth struct {
 	PrefixWhitelistPaths []string
 	ExactWhitelistPaths  []string
+	PrefixBlacklistPaths []string
 	JWTMiddleware        *jwtmiddleware.JWTMiddleware
+	// OptionalJWTMiddleware parses the token on the whitelisted paths if it's set
+	OptionalJWTMiddleware *jwtmiddleware.JWTMiddleware
+	// Introspection validates the tokens with the IdP instead of JWTMiddleware if it's set
+	Introspection *oauthIntrospection
+	// Proxy trusts the user headers of the auth proxy instead of the tokens if it's set
+	Proxy *proxyAuth
 }
 
 func (a *auth) whitelist(req *http.Request) bool {
@@ -190,6 +450,11 @@ func (a *auth) whitelist(req *http.Request) bool {
 	if Config.WebPrefix != "" {
 		path = strings.TrimPrefix(path, Config.WebPrefix)
 	}
+	for _, p := range a.PrefixBlacklistPaths {
+		if p != "" && strings.HasPrefix(path, p) {
+			return false
+		}
+	}
 	// If we set to 401 unauthorized, let the client handles the 401 itself
 	if Config.JWTAuthNoTokenStatusCode == http.StatusUnauthorized {
 		for _, p := range a.ExactWhitelistPaths {
@@ -211,10 +476,27 @@ type whiteListed struct{}
 
 func (a *auth) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 	if a.whitelist(req) {
+		if a.Proxy != nil {
+			a.Proxy.check(req)
+		} else if a.OptionalJWTMiddleware != nil {
+			if a.Introspection != nil {
+				a.Introspection.check(req)
+			} else {
+				a.OptionalJWTMiddleware.CheckJWT(w, req)
+			}
+		}
 		req = req.WithContext(context.WithValue(req.Context(), whiteListed{}, true))
 		next(w, req)
 		return
 	}
+	if a.Proxy != nil {
+		a.Proxy.HandlerWithNext(w, req, next)
+		return
+	}
+	if a.Introspection != nil {
+		a.Introspection.HandlerWithNext(w, req, next)
+		return
+	}
 	a.JWTMiddleware.HandlerWithNext(w, req, next)
 }
 
@@ -257,8 +539,270 @@ func (c *requireGroupClaim) ServeHTTP(w http.ResponseWriter, r *http.Request, ne
 	next(w, r)
 }
 
+// maxRequestBody limits the request body before it's read by the handlers.
+// The gzip middleware only compresses the responses, so the limit is on the body as it's sent.
+type maxRequestBody struct {
+	maxBytes int64
+}
+
+func (m *maxRequestBody) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if r.ContentLength > m.maxBytes {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// the body can still be larger than its content length, e.g. the chunked ones
+	body := &maxRequestBodyReader{ReadCloser: http.MaxBytesReader(w, r.Body, m.maxBytes), maxBytes: m.maxBytes}
+	r.Body = body
+	next(&maxRequestBodyResponseWriter{ResponseWriter: w, body: body}, r)
+}
+
+type maxRequestBodyReader struct {
+	io.ReadCloser
+	maxBytes int64
+	read     int64
+	exceeded bool
+}
+
+func (b *maxRequestBodyReader) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if err != nil && err != io.EOF && b.read >= b.maxBytes {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// maxRequestBodyResponseWriter responds 413 instead of the error of the
+// handler if the handler failed to read the body over the limit
+type maxRequestBodyResponseWriter struct {
+	http.ResponseWriter
+	body *maxRequestBodyReader
+}
+
+func (w *maxRequestBodyResponseWriter) WriteHeader(code int) {
+	if w.body.exceeded && code >= http.StatusBadRequest {
+		code = http.StatusRequestEntityTooLarge
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *maxRequestBodyResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// requestTimeout cancels the context of the request at the deadline, so that the handler stops querying the db,
+// and responds 503 right away. The handler runs on in the background until it notices the cancellation,
+// and its response is dropped. The response can't be changed once the handler has started it, e.g. a streamed
+// export, so it waits for the handler then.
+type requestTimeout struct {
+	timeout time.Duration
+	// exactExemptPaths are the long-lived requests without a deadline, e.g. the flag change stream
+	exactExemptPaths []string
+}
+
+func (rt *requestTimeout) exempt(req *http.Request) bool {
+	path := req.URL.Path
+
+	if Config.WebPrefix != "" {
+		path = strings.TrimPrefix(path, Config.WebPrefix)
+	}
+	for _, p := range rt.exactExemptPaths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (rt *requestTimeout) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if rt.exempt(r) {
+		next(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), rt.timeout)
+	defer cancel()
+
+	tw := &requestTimeoutResponseWriter{ResponseWriter: w, ctx: ctx, h: http.Header{}}
+	for k, vv := range w.Header() {
+		tw.h[k] = vv
+	}
+	done := make(chan struct{})
+	panicked := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panicked <- p
+			}
+		}()
+		next(tw, r.WithContext(ctx))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case p := <-panicked:
+		panic(p)
+	case <-ctx.Done():
+		if !tw.timeout() {
+			select {
+			case <-done:
+			case p := <-panicked:
+				panic(p)
+			}
+		}
+	}
+}
+
+// requestTimeoutResponseWriter keeps the headers of the handler apart until it responds,
+// so that they don't race with the 503 of the timeout. The handler responding past the deadline gets the 503 too.
+type requestTimeoutResponseWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	mu          sync.Mutex
+	h           http.Header
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *requestTimeoutResponseWriter) Header() http.Header {
+	return w.h
+}
+
+func (w *requestTimeoutResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeaderLocked(code)
+}
+
+func (w *requestTimeoutResponseWriter) writeHeaderLocked(code int) {
+	if w.timedOut || w.wroteHeader {
+		return
+	}
+	if w.ctx.Err() == context.DeadlineExceeded {
+		w.timeoutLocked()
+		return
+	}
+	w.wroteHeader = true
+
+	dst := w.ResponseWriter.Header()
+	for k := range dst {
+		delete(dst, k)
+	}
+	for k, vv := range w.h {
+		dst[k] = vv
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *requestTimeoutResponseWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeaderLocked(http.StatusOK)
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *requestTimeoutResponseWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeaderLocked(http.StatusOK)
+	if w.timedOut {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// timeout responds 503 and drops the rest of the response of the handler,
+// it's false if the handler has started responding already
+func (w *requestTimeoutResponseWriter) timeout() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.wroteHeader {
+		return false
+	}
+	if !w.timedOut {
+		w.timeoutLocked()
+	}
+	return true
+}
+
+func (w *requestTimeoutResponseWriter) timeoutLocked() {
+	w.timedOut = true
+	http.Error(w.ResponseWriter, "Request timeout", http.StatusServiceUnavailable)
+}
+
+type ipAllowlist struct {
+	CIDRs       []*net.IPNet
+	PrefixPaths []string
+	ClientIPs   *clientIPResolver
+}
+
+func setupIPAllowlistMiddleware(clientIPs *clientIPResolver) *ipAllowlist {
+	cidrs := []*net.IPNet{}
+	for _, s := range Config.IPAllowlistCIDRs {
+		if s == "" {
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(strings.TrimSpace(s))
+		if err != nil {
+			panic(fmt.Sprintf("unable to parse the CIDR %s of the IP allowlist. %s", s, err))
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return &ipAllowlist{
+		CIDRs:       cidrs,
+		PrefixPaths: Config.IPAllowlistPrefixPaths,
+		ClientIPs:   clientIPs,
+	}
+}
+
+func (a *ipAllowlist) protected(req *http.Request) bool {
+	path := req.URL.Path
+
+	if Config.WebPrefix != "" {
+		path = strings.TrimPrefix(path, Config.WebPrefix)
+	}
+	for _, p := range a.PrefixPaths {
+		if p != "" && strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ipAllowlist) allowed(req *http.Request) bool {
+	ip := a.ClientIPs.ClientIP(req)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range a.CIDRs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ipAllowlist) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	if a.protected(req) && !a.allowed(req) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	next(w, req)
+}
+
 type statsdMiddleware struct {
 	StatsdClient *statsd.Client
+	// ClientIPs tags the metrics with the client IP if it's set
+	ClientIPs *clientIPResolver
 }
 
 func (s *statsdMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
@@ -271,6 +815,9 @@ func (s *statsdMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request, nex
 			"path:" + r.RequestURI,
 			"method:" + r.Method,
 		}
+		if s.ClientIPs != nil {
+			tags = append(tags, "client_ip:"+s.ClientIPs.clientIPString(r))
+		}
 
 		s.StatsdClient.Incr("http.requests.count", tags, 1)
 		s.StatsdClient.TimeInMilliseconds("http.requests.duration", duration, tags, 1)
@@ -2,12 +2,24 @@ package config
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	jwt "github.com/dgrijalva/jwt-go"
+	negronilogrus "github.com/meatballhat/negroni-logrus"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/urfave/negroni"
 )
 
 type okHandler struct{}
@@ -62,6 +74,39 @@ func TestSetupGlobalMiddleware(t *testing.T) {
 	Config.PProfEnabled = true
 }
 
+func TestWebUI(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flagr_ui")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("UI"), 0644))
+
+	prev := webUIDir
+	webUIDir = dir
+	defer func() {
+		webUIDir = prev
+		Config.WebUIEnabled = true
+	}()
+
+	serve := func(path string) string {
+		hh := SetupGlobalMiddleware(http.NotFoundHandler())
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000"+path, nil)
+		hh.ServeHTTP(res, req)
+		return res.Body.String()
+	}
+
+	t.Run("it serves the UI if it's enabled", func(t *testing.T) {
+		Config.WebUIEnabled = true
+		assert.Equal(t, "UI", serve("/"))
+	})
+
+	t.Run("it leaves all the paths to the API if it's disabled", func(t *testing.T) {
+		Config.WebUIEnabled = false
+		assert.Contains(t, serve("/"), "404")
+		assert.Contains(t, serve("/index.html"), "404")
+	})
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	h := &okHandler{}
 
@@ -122,6 +167,18 @@ func TestAuthMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusOK, res.Code)
 	})
 
+	t.Run("it will redirect if jwt enabled with blacklisted path under the whitelisted path", func(t *testing.T) {
+		Config.JWTAuthEnabled = true
+		defer func() { Config.JWTAuthEnabled = false }()
+		hh := SetupGlobalMiddleware(h)
+
+		res := httptest.NewRecorder()
+		res.Body = new(bytes.Buffer)
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation/cache/refresh", nil)
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusTemporaryRedirect, res.Code)
+	})
+
 	t.Run("it will pass if jwt enabled but with whitelisted path, when web prefix set", func(t *testing.T) {
 		savedPrefixes := Config.JWTAuthPrefixWhitelistPaths
 		Config.JWTAuthEnabled = true
@@ -261,6 +318,46 @@ o2kQ+X5xK9cipRgEKwIDAQAB
 	})
 }
 
+func TestAuthMiddlewareWithEvalContextFromJWT(t *testing.T) {
+	Config.JWTAuthEnabled = true
+	Config.EvalContextFromJWT = true
+	defer func() {
+		Config.JWTAuthEnabled = false
+		Config.EvalContextFromJWT = false
+	}()
+
+	hasToken := false
+	hh := SetupGlobalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasToken = r.Context().Value(Config.JWTAuthUserProperty).(*jwt.Token)
+	}))
+
+	t.Run("it parses the token on the whitelisted path", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation", nil)
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: validHS256JWTToken})
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, hasToken)
+	})
+
+	t.Run("it passes without the token on the whitelisted path", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation", nil)
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.False(t, hasToken)
+	})
+
+	t.Run("it passes with an invalid token on the whitelisted path", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation", nil)
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: "invalid_jwt"})
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.False(t, hasToken)
+	})
+}
+
 func TestAuthMiddlewareWithUnauthorized(t *testing.T) {
 	h := &okHandler{}
 
@@ -322,6 +419,63 @@ func TestAuthMiddlewareWithUnauthorized(t *testing.T) {
 	})
 }
 
+func TestJWTTokenSources(t *testing.T) {
+	h := &okHandler{}
+	Config.JWTAuthEnabled = true
+	defer func() { Config.JWTAuthEnabled = false }()
+
+	serve := func(sources []string, headerName string, setup func(req *http.Request)) int {
+		prevSources, prevHeaderName := Config.JWTAuthTokenSources, Config.JWTAuthTokenHeaderName
+		Config.JWTAuthTokenSources, Config.JWTAuthTokenHeaderName = sources, headerName
+		defer func() { Config.JWTAuthTokenSources, Config.JWTAuthTokenHeaderName = prevSources, prevHeaderName }()
+		hh := SetupGlobalMiddleware(h)
+
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil)
+		setup(req)
+		hh.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	t.Run("it extracts the token from a custom header", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve([]string{"header"}, "X-Auth-Token", func(req *http.Request) {
+			req.Header.Set("X-Auth-Token", validHS256JWTToken)
+		}))
+		assert.Equal(t, http.StatusOK, serve([]string{"header"}, "X-Auth-Token", func(req *http.Request) {
+			req.Header.Set("X-Auth-Token", "Bearer "+validHS256JWTToken)
+		}))
+		assert.Equal(t, http.StatusTemporaryRedirect, serve([]string{"header"}, "X-Auth-Token", func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+validHS256JWTToken)
+		}))
+	})
+
+	t.Run("it requires the bearer scheme in the Authorization header", func(t *testing.T) {
+		assert.Equal(t, http.StatusTemporaryRedirect, serve([]string{"header"}, "Authorization", func(req *http.Request) {
+			req.Header.Set("Authorization", validHS256JWTToken)
+		}))
+	})
+
+	t.Run("it extracts the token from the sources in order", func(t *testing.T) {
+		withBoth := func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: "invalid_jwt"})
+			req.Header.Set("X-Auth-Token", validHS256JWTToken)
+		}
+		assert.Equal(t, http.StatusOK, serve([]string{"header", "cookie"}, "X-Auth-Token", withBoth))
+		assert.Equal(t, http.StatusTemporaryRedirect, serve([]string{"cookie", "header"}, "X-Auth-Token", withBoth))
+	})
+
+	t.Run("it ignores the disabled sources", func(t *testing.T) {
+		assert.Equal(t, http.StatusTemporaryRedirect, serve([]string{"header"}, "Authorization", func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: validHS256JWTToken})
+		}))
+	})
+
+	t.Run("it panics with an unknown or no source", func(t *testing.T) {
+		assert.Panics(t, func() { jwtTokenExtractor([]string{"query"}) })
+		assert.Panics(t, func() { jwtTokenExtractor([]string{}) })
+	})
+}
+
 func TestRequireGroupClaimMiddleware(t *testing.T) {
 	h := &okHandler{}
 
@@ -382,3 +536,340 @@ func TestRequireGroupClaimMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, res.Code)
 	})
 }
+
+type readBodyHandler struct{}
+
+func (o *readBodyHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if _, err := ioutil.ReadAll(req.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Write([]byte("OK"))
+}
+
+func TestMaxRequestBodyMiddleware(t *testing.T) {
+	h := &readBodyHandler{}
+	body := strings.Repeat("x", 64)
+
+	serve := func(req *http.Request) int {
+		hh := SetupGlobalMiddleware(h)
+		res := httptest.NewRecorder()
+		hh.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	t.Run("it will return 200 when it's not enabled", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation/batch", strings.NewReader(body))
+		assert.Equal(t, http.StatusOK, serve(req))
+	})
+
+	Config.MiddlewareMaxRequestBodyEnabled = true
+	Config.MaxRequestBodyBytes = 32
+	defer func() {
+		Config.MiddlewareMaxRequestBodyEnabled = false
+		Config.MaxRequestBodyBytes = 10485760
+	}()
+
+	t.Run("it will return 200 when the body is within the limit", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation/batch", strings.NewReader(body[:32]))
+		assert.Equal(t, http.StatusOK, serve(req))
+	})
+
+	t.Run("it will return 413 when the content length is over the limit", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation/batch", strings.NewReader(body))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, serve(req))
+	})
+
+	t.Run("it will return 413 when the body without content length is over the limit", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation/batch", ioutil.NopCloser(strings.NewReader(body)))
+		req.ContentLength = -1
+		assert.Equal(t, http.StatusRequestEntityTooLarge, serve(req))
+	})
+}
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	serve := func(h http.HandlerFunc) *httptest.ResponseRecorder {
+		hh := SetupGlobalMiddleware(h)
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "http://localhost:18000/api/v1/evaluation", nil)
+		hh.ServeHTTP(res, req)
+		return res
+	}
+
+	t.Run("it will leave the context alone when it's not enabled", func(t *testing.T) {
+		res := serve(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.Context().Deadline()
+			assert.False(t, ok)
+			w.Write([]byte("OK"))
+		})
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	Config.RequestTimeout = 50 * time.Millisecond
+	defer func() { Config.RequestTimeout = 0 }()
+
+	t.Run("it will return the response within the deadline", func(t *testing.T) {
+		res := serve(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.Context().Deadline()
+			assert.True(t, ok)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		})
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+		assert.Equal(t, `{}`, res.Body.String())
+	})
+
+	t.Run("it will cancel the context and return 503 past the deadline", func(t *testing.T) {
+		cancelled := make(chan error, 1)
+		res := serve(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			cancelled <- r.Context().Err()
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"context deadline exceeded"}`))
+		})
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+		assert.Contains(t, res.Body.String(), "Request timeout")
+		assert.Equal(t, context.DeadlineExceeded, <-cancelled)
+	})
+
+	t.Run("it will not wait for the handler ignoring the context", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		res := serve(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			w.Write([]byte("OK"))
+		})
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+	})
+
+	t.Run("it will keep the response the handler has started", func(t *testing.T) {
+		res := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("first row\n"))
+			<-r.Context().Done()
+			w.Write([]byte("last row\n"))
+		})
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "first row\nlast row\n", res.Body.String())
+	})
+
+	t.Run("it will recover the panics of the handler", func(t *testing.T) {
+		res := serve(func(w http.ResponseWriter, r *http.Request) {
+			panic("something went wrong")
+		})
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+
+	t.Run("it will leave the flag change stream without a deadline", func(t *testing.T) {
+		hh := SetupGlobalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.Context().Deadline()
+			assert.False(t, ok)
+			time.Sleep(2 * Config.RequestTimeout)
+			w.Write([]byte(": keep-alive\n\n"))
+		}))
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000/api/v1/flags/stream", nil)
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, ": keep-alive\n\n", res.Body.String())
+	})
+}
+
+type panicHandler struct{}
+
+func (o *panicHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	panic("something went wrong")
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	hh := SetupGlobalMiddleware(&panicHandler{})
+
+	t.Run("it will return the JSON error to the API callers", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil)
+		req.Header.Set("X-Request-Id", "abc123")
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"error":"internal server error","requestId":"abc123"}`, res.Body.String())
+	})
+
+	t.Run("it will generate the request id", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil)
+		req.Header.Set("Accept", "*/*")
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.NotEmpty(t, res.Header().Get("X-Request-Id"))
+		assert.NotContains(t, res.Body.String(), "goroutine")
+	})
+
+	t.Run("it will return the HTML page without the stack to the browsers", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000/", nil)
+		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+		hh.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.Contains(t, res.Header().Get("Content-Type"), "text/html")
+		assert.NotContains(t, res.Body.String(), "goroutine")
+	})
+}
+
+func TestPrefersHTML(t *testing.T) {
+	for accept, expected := range map[string]bool{
+		"":                                    false,
+		"*/*":                                 false,
+		"application/json":                    false,
+		"text/html":                           true,
+		"text/html;q=0.5, application/json":   false,
+		"application/json;q=0.5, text/html":   true,
+		"text/html,application/xml;q=0.9,*/*": false,
+	} {
+		r, _ := http.NewRequest("GET", "http://localhost:18000/", nil)
+		r.Header.Set("Accept", accept)
+		assert.Equal(t, expected, prefersHTML(r), accept)
+	}
+}
+
+func TestSetupServer(t *testing.T) {
+	t.Run("it will keep the timeouts of the server flags by default", func(t *testing.T) {
+		s := &http.Server{ReadTimeout: 30 * time.Second, WriteTimeout: 60 * time.Second, IdleTimeout: 10 * time.Second}
+		SetupServer(s)
+		assert.Equal(t, 30*time.Second, s.ReadTimeout)
+		assert.Equal(t, 60*time.Second, s.WriteTimeout)
+		assert.Equal(t, 10*time.Second, s.IdleTimeout)
+	})
+
+	Config.ServerReadTimeout = 100 * time.Millisecond
+	defer func() { Config.ServerReadTimeout = 0 }()
+
+	s := httptest.NewUnstartedServer(&okHandler{})
+	s.Config.WriteTimeout = 60 * time.Second
+	SetupServer(s.Config)
+	assert.Equal(t, Config.ServerReadTimeout, s.Config.ReadTimeout)
+	assert.Equal(t, 60*time.Second, s.Config.WriteTimeout)
+
+	s.Start()
+	defer s.Close()
+
+	t.Run("it will close the connection of a slow client after the read timeout", func(t *testing.T) {
+		conn, err := net.Dial("tcp", s.Listener.Addr().String())
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n"))
+		assert.NoError(t, err)
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1024))
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func TestIPAllowlistMiddleware(t *testing.T) {
+	Config.IPAllowlistEnabled = true
+	Config.IPAllowlistCIDRs = []string{"10.0.0.0/8", "192.168.1.0/24"}
+	defer func() {
+		Config.IPAllowlistEnabled = false
+		Config.IPAllowlistCIDRs = []string{}
+		Config.IPAllowlistTrustedProxies = 0
+	}()
+
+	serve := func(path string, remoteAddr string, xff string) int {
+		hh := SetupGlobalMiddleware(&okHandler{})
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "http://localhost:18000"+path, nil)
+		req.RemoteAddr = remoteAddr
+		if xff != "" {
+			req.Header.Set("X-Forwarded-For", xff)
+		}
+		hh.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	t.Run("it will return 200 for the IPs in the allowlist", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags/1", "10.1.2.3:51234", ""))
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags/1", "192.168.1.20:51234", ""))
+	})
+
+	t.Run("it will return 403 for the IPs outside the allowlist", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/flags/1", "8.8.8.8:51234", ""))
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/tags", "192.168.2.20:51234", ""))
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/exclusion_groups/1", "8.8.8.8:51234", ""))
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/constraint_groups/1", "8.8.8.8:51234", ""))
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/segment_templates/1/apply", "8.8.8.8:51234", ""))
+	})
+
+	t.Run("it will keep the evaluation open", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/api/v1/evaluation", "8.8.8.8:51234", ""))
+	})
+
+	t.Run("it will ignore X-Forwarded-For without trusted proxies", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/flags/1", "8.8.8.8:51234", "10.1.2.3"))
+	})
+
+	t.Run("it will use the IP before the trusted proxies in X-Forwarded-For", func(t *testing.T) {
+		Config.IPAllowlistTrustedProxies = 1
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags/1", "172.16.0.1:51234", "8.8.8.8, 10.1.2.3"))
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/flags/1", "172.16.0.1:51234", "10.1.2.3, 8.8.8.8"))
+
+		Config.IPAllowlistTrustedProxies = 2
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags/1", "172.16.0.1:51234", "10.1.2.3, 8.8.8.8"))
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags/1", "172.16.0.1:51234", "10.1.2.3"))
+	})
+
+	t.Run("it will panic with an invalid CIDR", func(t *testing.T) {
+		Config.IPAllowlistCIDRs = []string{"10.0.0.0/33"}
+		assert.Panics(t, func() { setupIPAllowlistMiddleware(setupClientIPResolver()) })
+	})
+}
+
+func TestVerboseLoggerMiddlewareSampling(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logrus.SetOutput(buf)
+	defer logrus.SetOutput(os.Stdout)
+
+	prev := Config.MiddlewareLoggerSampleRate
+	defer func() { Config.MiddlewareLoggerSampleRate = prev }()
+
+	serve := func(h negroni.Handler, status int) string {
+		buf.Reset()
+		n := negroni.New(h)
+		n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		})
+		n.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "http://localhost:18000/api/v1/flags", nil))
+		return buf.String()
+	}
+
+	t.Run("it logs every request without sampling", func(t *testing.T) {
+		Config.MiddlewareLoggerSampleRate = 1
+		h := setupVerboseLoggerMiddleware(setupClientIPResolver())
+		assert.IsType(t, &negronilogrus.Middleware{}, h)
+		assert.Contains(t, serve(h, http.StatusOK), "completed handling request")
+	})
+
+	t.Run("it logs the sampled requests", func(t *testing.T) {
+		Config.MiddlewareLoggerSampleRate = 0.1
+		h := setupVerboseLoggerMiddleware(setupClientIPResolver()).(*sampledLogger)
+		h.random = func() float64 { return 0.05 }
+		out := serve(h, http.StatusOK)
+		assert.Contains(t, out, "started handling request")
+		assert.Contains(t, out, "completed handling request")
+	})
+
+	t.Run("it skips the successful requests out of the sample", func(t *testing.T) {
+		Config.MiddlewareLoggerSampleRate = 0.1
+		h := setupVerboseLoggerMiddleware(setupClientIPResolver()).(*sampledLogger)
+		h.random = func() float64 { return 0.5 }
+		assert.Empty(t, serve(h, http.StatusOK))
+		assert.Empty(t, serve(h, http.StatusNoContent))
+
+		out := serve(h, http.StatusInternalServerError)
+		assert.NotContains(t, out, "started handling request")
+		assert.Contains(t, out, "completed handling request")
+		assert.Contains(t, out, "status=500")
+		assert.Contains(t, serve(h, http.StatusNotFound), "status=404")
+	})
+}
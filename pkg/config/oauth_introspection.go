@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+)
+
+// oauthIntrospectionCacheSize caps the number of the cached tokens, the expired ones
+// are swept once it's reached, and the cache is reset if none of them expired
+const oauthIntrospectionCacheSize = 10000
+
+var errInactiveToken = errors.New("token is not active")
+
+// oauthIntrospection validates the opaque bearer tokens with the RFC 7662 introspection
+// endpoint of the IdP. The claims of an active token are put into the request context
+// as a *jwt.Token under Config.JWTAuthUserProperty, the same as the JWT middleware does.
+type oauthIntrospection struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	Client       *http.Client
+	Extractor    func(r *http.Request) (string, error)
+
+	mu    sync.Mutex
+	cache map[string]oauthIntrospectionCacheEntry
+	now   func() time.Time
+}
+
+type oauthIntrospectionCacheEntry struct {
+	claims    jwt.MapClaims
+	expiresAt time.Time
+}
+
+func newOAuthIntrospection(extractor func(r *http.Request) (string, error)) *oauthIntrospection {
+	return &oauthIntrospection{
+		URL:          Config.OAuthIntrospectionURL,
+		ClientID:     Config.OAuthIntrospectionClientID,
+		ClientSecret: Config.OAuthIntrospectionClientSecret,
+		Client:       &http.Client{Timeout: Config.OAuthIntrospectionTimeout},
+		Extractor:    extractor,
+		cache:        make(map[string]oauthIntrospectionCacheEntry),
+		now:          time.Now,
+	}
+}
+
+// HandlerWithNext responds with jwtErrorHandler unless the request has an active token
+func (o *oauthIntrospection) HandlerWithNext(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if err := o.check(r); err != nil {
+		if Config.JWTAuthDebug {
+			logrus.WithField("err", err).Info("oauth token introspection failed")
+		}
+		jwtErrorHandler(w, r, err.Error())
+		return
+	}
+	next(w, r)
+}
+
+// check puts the claims of the active token into the context of r, it's an error
+// if there's no token or the token is not active
+func (o *oauthIntrospection) check(r *http.Request) error {
+	token, err := o.Extractor(r)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return errors.New("required authorization token not found")
+	}
+
+	claims, err := o.introspect(token)
+	if err != nil {
+		return err
+	}
+
+	t := &jwt.Token{Raw: token, Claims: claims, Valid: true}
+	*r = *r.WithContext(context.WithValue(r.Context(), Config.JWTAuthUserProperty, t))
+	return nil
+}
+
+func (o *oauthIntrospection) introspect(token string) (jwt.MapClaims, error) {
+	now := o.now()
+
+	o.mu.Lock()
+	entry, ok := o.cache[token]
+	o.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.claims, nil
+	}
+
+	claims, err := o.request(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the active tokens with an exp are cached, until they expire
+	if exp, ok := claims["exp"].(float64); ok {
+		o.store(token, oauthIntrospectionCacheEntry{claims: claims, expiresAt: time.Unix(int64(exp), 0)}, now)
+	}
+	return claims, nil
+}
+
+func (o *oauthIntrospection) store(token string, entry oauthIntrospectionCacheEntry, now time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.cache) >= oauthIntrospectionCacheSize {
+		for k, e := range o.cache {
+			if !now.Before(e.expiresAt) {
+				delete(o.cache, k)
+			}
+		}
+		if len(o.cache) >= oauthIntrospectionCacheSize {
+			o.cache = make(map[string]oauthIntrospectionCacheEntry)
+		}
+	}
+	o.cache[token] = entry
+}
+
+func (o *oauthIntrospection) request(token string) (jwt.MapClaims, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequest(http.MethodPost, o.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(o.ClientID), url.QueryEscape(o.ClientSecret))
+	}
+
+	res, err := o.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot introspect the token. reason: %s", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot introspect the token. reason: status code %d", res.StatusCode)
+	}
+
+	claims := jwt.MapClaims{}
+	if err := json.NewDecoder(res.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("cannot decode the introspection response. reason: %s", err)
+	}
+	if active, _ := claims["active"].(bool); !active {
+		return nil, errInactiveToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIntrospectionServer(t *testing.T, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "flagr", id)
+		assert.Equal(t, "secret", secret)
+
+		r.ParseForm()
+		switch r.PostForm.Get("token") {
+		case "active_token":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"active": true,
+				"sub":    "user@example.com",
+				"exp":    time.Now().Add(time.Hour).Unix(),
+			})
+		case "active_token_without_exp":
+			json.NewEncoder(w).Encode(map[string]interface{}{"active": true, "sub": "user@example.com"})
+		case "error_token":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"active": false})
+		}
+	}))
+}
+
+func TestOAuthIntrospectionMiddleware(t *testing.T) {
+	calls := 0
+	server := newIntrospectionServer(t, &calls)
+	defer server.Close()
+
+	Config.JWTAuthEnabled = true
+	Config.JWTAuthNoTokenStatusCode = http.StatusUnauthorized
+	Config.OAuthIntrospectionURL = server.URL
+	Config.OAuthIntrospectionClientID = "flagr"
+	Config.OAuthIntrospectionClientSecret = "secret"
+	defer func() {
+		Config.JWTAuthEnabled = false
+		Config.JWTAuthNoTokenStatusCode = http.StatusTemporaryRedirect
+		Config.OAuthIntrospectionURL = ""
+		Config.OAuthIntrospectionClientID = ""
+		Config.OAuthIntrospectionClientSecret = ""
+	}()
+
+	var subject string
+	hh := SetupGlobalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = ""
+		if token, ok := r.Context().Value(Config.JWTAuthUserProperty).(*jwt.Token); ok {
+			subject = token.Claims.(jwt.MapClaims)["sub"].(string)
+		}
+		w.Write([]byte("OK"))
+	}))
+	serve := func(path string, token string) int {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000"+path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		hh.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	t.Run("it passes with an active token", func(t *testing.T) {
+		calls = 0
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags", "active_token"))
+		assert.Equal(t, "user@example.com", subject)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("it caches the active token until its exp", func(t *testing.T) {
+		calls = 0
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags", "active_token"))
+		assert.Equal(t, "user@example.com", subject)
+		assert.Equal(t, 0, calls)
+
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags", "active_token_without_exp"))
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags", "active_token_without_exp"))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("it rejects the inactive tokens", func(t *testing.T) {
+		calls = 0
+		assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/flags", "inactive_token"))
+		assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/flags", "inactive_token"))
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/flags", "error_token"))
+		assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/flags", ""))
+	})
+
+	t.Run("it passes on the whitelisted paths without a token", func(t *testing.T) {
+		calls = 0
+		assert.Equal(t, http.StatusOK, serve(Config.JWTAuthPrefixWhitelistPaths[0], ""))
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func TestOAuthIntrospectionCache(t *testing.T) {
+	calls := 0
+	server := newIntrospectionServer(t, &calls)
+	defer server.Close()
+
+	o := newOAuthIntrospection(func(r *http.Request) (string, error) { return "", nil })
+	o.URL = server.URL
+	o.ClientID = "flagr"
+	o.ClientSecret = "secret"
+
+	now := time.Now()
+	o.now = func() time.Time { return now }
+
+	_, err := o.introspect("active_token")
+	assert.NoError(t, err)
+	_, err = o.introspect("active_token")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(2 * time.Hour)
+	_, err = o.introspect("active_token")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	_, err = o.introspect("inactive_token")
+	assert.Equal(t, errInactiveToken, err)
+
+	for i := 0; i < oauthIntrospectionCacheSize; i++ {
+		o.store(fmt.Sprint(i), oauthIntrospectionCacheEntry{expiresAt: now.Add(time.Hour)}, now)
+	}
+	assert.True(t, len(o.cache) <= oauthIntrospectionCacheSize)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+)
+
+var errUntrustedProxy = errors.New("the auth proxy headers are not trusted from the client IP")
+
+// proxyAuth trusts the user and the groups in the headers set by the auth proxy in front of flagr, e.g. oauth2-proxy.
+// They're put into the request context as a *jwt.Token under Config.JWTAuthUserProperty, the same as the JWT middleware
+// does, with the user under JWTAuthUserClaim and the groups under the "groups" claim. Any client can set the headers,
+// so they're only trusted from the IPs of the IP allowlist.
+type proxyAuth struct {
+	UserHeader   string
+	GroupsHeader string
+	Allowlist    *ipAllowlist
+}
+
+func newProxyAuth(clientIPs *clientIPResolver) *proxyAuth {
+	if !Config.IPAllowlistEnabled {
+		panic("FLAGR_PROXY_AUTH_ENABLED requires FLAGR_IP_ALLOWLIST_ENABLED, so that only the auth proxy can set the user header")
+	}
+	return &proxyAuth{
+		UserHeader:   Config.ProxyAuthUserHeader,
+		GroupsHeader: Config.ProxyAuthGroupsHeader,
+		Allowlist:    setupIPAllowlistMiddleware(clientIPs),
+	}
+}
+
+// HandlerWithNext responds with jwtErrorHandler unless the request has the user header from a trusted IP
+func (p *proxyAuth) HandlerWithNext(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if err := p.check(r); err != nil {
+		if Config.JWTAuthDebug {
+			logrus.WithField("err", err).Info("proxy auth failed")
+		}
+		jwtErrorHandler(w, r, err.Error())
+		return
+	}
+	next(w, r)
+}
+
+// check puts the user and the groups of the headers into the context of r, it's an error
+// if the request is not from a trusted IP or it has no user header
+func (p *proxyAuth) check(r *http.Request) error {
+	if !p.Allowlist.allowed(r) {
+		return errUntrustedProxy
+	}
+
+	user := strings.TrimSpace(r.Header.Get(p.UserHeader))
+	if user == "" {
+		return fmt.Errorf("required %s header not found", p.UserHeader)
+	}
+
+	claims := jwt.MapClaims{Config.JWTAuthUserClaim: user}
+	if p.GroupsHeader != "" {
+		groups := []string{}
+		for _, g := range strings.Split(r.Header.Get(p.GroupsHeader), ",") {
+			if g = strings.TrimSpace(g); g != "" {
+				groups = append(groups, g)
+			}
+		}
+		claims["groups"] = groups
+	}
+
+	t := &jwt.Token{Claims: claims, Valid: true}
+	*r = *r.WithContext(context.WithValue(r.Context(), Config.JWTAuthUserProperty, t))
+	return nil
+}
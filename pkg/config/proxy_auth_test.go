@@ -0,0 +1,82 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyAuthMiddleware(t *testing.T) {
+	Config.ProxyAuthEnabled = true
+	Config.IPAllowlistEnabled = true
+	Config.IPAllowlistCIDRs = []string{"10.0.0.0/8"}
+	Config.JWTAuthNoTokenStatusCode = http.StatusUnauthorized
+	defer func() {
+		Config.ProxyAuthEnabled = false
+		Config.IPAllowlistEnabled = false
+		Config.IPAllowlistCIDRs = []string{}
+		Config.JWTAuthNoTokenStatusCode = http.StatusTemporaryRedirect
+		Config.JWTAuthRequireGroupClaim = ""
+	}()
+
+	var claims jwt.MapClaims
+	serve := func(path string, remoteAddr string, user string, groups string) int {
+		hh := SetupGlobalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims = nil
+			if token, ok := r.Context().Value(Config.JWTAuthUserProperty).(*jwt.Token); ok {
+				claims = token.Claims.(jwt.MapClaims)
+			}
+			w.Write([]byte("OK"))
+		}))
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "http://localhost:18000"+path, nil)
+		req.RemoteAddr = remoteAddr
+		if user != "" {
+			req.Header.Set("X-Forwarded-User", user)
+		}
+		if groups != "" {
+			req.Header.Set("X-Forwarded-Groups", groups)
+		}
+		hh.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	t.Run("it passes with the user header from the proxy", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags", "10.1.2.3:51234", "user@example.com", "admins, devs"))
+		assert.Equal(t, "user@example.com", claims["sub"])
+		assert.Equal(t, []string{"admins", "devs"}, claims["groups"])
+	})
+
+	t.Run("it fails without the user header", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/flags", "10.1.2.3:51234", "", "admins"))
+	})
+
+	t.Run("it doesn't trust the user header from the other IPs", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve("/api/v1/flags", "8.8.8.8:51234", "user@example.com", ""))
+		assert.Equal(t, http.StatusOK, serve("/api/v1/evaluation", "8.8.8.8:51234", "user@example.com", ""))
+		assert.Nil(t, claims)
+	})
+
+	t.Run("it takes the user header on the whitelist paths", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/api/v1/evaluation", "10.1.2.3:51234", "user@example.com", ""))
+		assert.Equal(t, "user@example.com", claims["sub"])
+		assert.Equal(t, http.StatusOK, serve("/api/v1/evaluation", "10.1.2.3:51234", "", ""))
+		assert.Nil(t, claims)
+	})
+
+	t.Run("it checks the groups header for the required group", func(t *testing.T) {
+		Config.JWTAuthRequireGroupClaim = "admins"
+		assert.Equal(t, http.StatusOK, serve("/api/v1/flags", "10.1.2.3:51234", "user@example.com", "devs,admins"))
+		assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/flags", "10.1.2.3:51234", "user@example.com", "devs"))
+		Config.JWTAuthRequireGroupClaim = ""
+	})
+
+	t.Run("it panics without the IP allowlist", func(t *testing.T) {
+		Config.IPAllowlistEnabled = false
+		assert.Panics(t, func() { setupProxyAuthMiddleware(setupClientIPResolver()) })
+		Config.IPAllowlistEnabled = true
+	})
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"bytes"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-go/statsd"
+)
+
+// statsdBufferedWriter batches the metrics of the statsd client into newline separated
+// UDP packets. A packet is sent once it holds bufferLength metrics or it would grow past
+// statsd.OptimalPayloadSize, and whatever is buffered is sent every flushInterval.
+// The vendored statsd.NewBuffered hardcodes its flush interval, hence this writer.
+type statsdBufferedWriter struct {
+	conn          net.Conn
+	bufferLength  int
+	flushInterval time.Duration
+
+	mu        sync.Mutex
+	buffer    bytes.Buffer
+	count     int
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newStatsdBufferedWriter(addr string, bufferLength int, flushInterval time.Duration) (*statsdBufferedWriter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	w := &statsdBufferedWriter{
+		conn:          conn,
+		bufferLength:  bufferLength,
+		flushInterval: flushInterval,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go w.watch()
+	return w, nil
+}
+
+func (w *statsdBufferedWriter) watch() {
+	defer close(w.done)
+	if w.flushInterval <= 0 {
+		<-w.stop
+		return
+	}
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.Flush()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Write buffers a single metric
+func (w *statsdBufferedWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.count > 0 && w.buffer.Len()+1+len(data) > statsd.OptimalPayloadSize {
+		if err := w.flushLocked(); err != nil {
+			return 0, err
+		}
+	}
+	if w.count > 0 {
+		w.buffer.WriteByte('\n')
+	}
+	w.buffer.Write(data)
+	w.count++
+
+	if w.count >= w.bufferLength {
+		if err := w.flushLocked(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// Flush sends the buffered metrics
+func (w *statsdBufferedWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushLocked()
+}
+
+func (w *statsdBufferedWriter) flushLocked() error {
+	if w.count == 0 {
+		return nil
+	}
+	_, err := w.conn.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	w.count = 0
+	return err
+}
+
+// SetWriteTimeout is a no-op, the writes of UDP don't block
+func (w *statsdBufferedWriter) SetWriteTimeout(time.Duration) error {
+	return nil
+}
+
+// Close flushes the buffered metrics and closes the connection
+func (w *statsdBufferedWriter) Close() (err error) {
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+
+		err = w.Flush()
+		if cerr := w.conn.Close(); err == nil {
+			err = cerr
+		}
+	})
+	return err
+}
+
+// newStatsdClient creates the statsd client, it's buffered if bufferLength is positive
+func newStatsdClient(addr string, bufferLength int, flushInterval time.Duration) (*statsd.Client, error) {
+	if bufferLength <= 0 {
+		return statsd.New(addr)
+	}
+	w, err := newStatsdBufferedWriter(addr, bufferLength, flushInterval)
+	if err != nil {
+		return nil, err
+	}
+	return statsd.NewWithWriter(w)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func listenStatsd(t *testing.T) (*net.UDPConn, func() string) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	assert.NoError(t, err)
+	read := func() string {
+		buf := make([]byte, 65536)
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, err := conn.Read(buf)
+		if err != nil {
+			return ""
+		}
+		return string(buf[:n])
+	}
+	return conn, read
+}
+
+func TestStatsdBufferedWriter(t *testing.T) {
+	t.Run("it sends a packet once the buffer is full", func(t *testing.T) {
+		conn, read := listenStatsd(t)
+		defer conn.Close()
+
+		client, err := newStatsdClient(conn.LocalAddr().String(), 3, time.Hour)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		client.Incr("a", nil, 1)
+		client.Incr("b", nil, 1)
+		client.Incr("c", nil, 1)
+		assert.Equal(t, "a:1|c\nb:1|c\nc:1|c", read())
+	})
+
+	t.Run("it sends the buffered metrics every flush interval", func(t *testing.T) {
+		conn, read := listenStatsd(t)
+		defer conn.Close()
+
+		client, err := newStatsdClient(conn.LocalAddr().String(), 100, 10*time.Millisecond)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		client.Incr("a", nil, 1)
+		assert.Equal(t, "a:1|c", read())
+	})
+
+	t.Run("it flushes the buffered metrics on close", func(t *testing.T) {
+		conn, read := listenStatsd(t)
+		defer conn.Close()
+
+		client, err := newStatsdClient(conn.LocalAddr().String(), 100, time.Hour)
+		assert.NoError(t, err)
+
+		client.Incr("a", nil, 1)
+		client.Incr("b", nil, 1)
+		assert.NoError(t, client.Close())
+		assert.Equal(t, "a:1|c\nb:1|c", read())
+		assert.NoError(t, client.Close())
+	})
+
+	t.Run("it splits the packets at the optimal payload size", func(t *testing.T) {
+		conn, read := listenStatsd(t)
+		defer conn.Close()
+
+		client, err := newStatsdClient(conn.LocalAddr().String(), 1000, time.Hour)
+		assert.NoError(t, err)
+
+		name := strings.Repeat("x", 500)
+		client.Incr(name, nil, 1)
+		client.Incr(name, nil, 1)
+		client.Incr(name, nil, 1)
+		assert.Equal(t, 2, strings.Count(read(), "\n")+1)
+		client.Close()
+		assert.Equal(t, name+":1|c", read())
+	})
+
+	t.Run("it's unbuffered without a buffer length", func(t *testing.T) {
+		conn, read := listenStatsd(t)
+		defer conn.Close()
+
+		client, err := newStatsdClient(conn.LocalAddr().String(), 0, time.Hour)
+		assert.NoError(t, err)
+		defer client.Close()
+
+		client.Incr("a", nil, 1)
+		assert.Equal(t, "a:1|c", read())
+	})
+}
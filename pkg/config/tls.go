@@ -0,0 +1,95 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SetupTLS serves the certificate of TLSCertFile and TLSKeyFile if TLS is enabled.
+// The certificate is reloaded from the files on SIGHUP, so that it can be rotated without a restart.
+// HTTP/2 is negotiated with ALPN when the client supports it.
+func SetupTLS(tlsConfig *tls.Config) error {
+	if !Config.TLSEnabled {
+		return nil
+	}
+
+	r, err := newCertReloader(Config.TLSCertFile, Config.TLSKeyFile)
+	if err != nil {
+		return err
+	}
+	r.watchSIGHUP()
+
+	tlsConfig.NextProtos = preferHTTP2(tlsConfig.NextProtos)
+
+	// every handshake gets the current certificate from the reloader, the certificate
+	// in tlsConfig itself is only there for the server's check of at least one certificate
+	base := tlsConfig.Clone()
+	base.Certificates = nil
+	base.GetCertificate = r.getCertificate
+	tlsConfig.Certificates = []tls.Certificate{*r.current()}
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return base, nil
+	}
+	return nil
+}
+
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	certLock sync.RWMutex
+	cert     *tls.Certificate
+}
+
+func newCertReloader(certFile string, keyFile string) (*certReloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("FLAGR_TLS_CERT_FILE and FLAGR_TLS_KEY_FILE are required when TLS is enabled")
+	}
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load the TLS certificate %s and key %s. %s", r.certFile, r.keyFile, err)
+	}
+
+	r.certLock.Lock()
+	defer r.certLock.Unlock()
+	r.cert = &cert
+	return nil
+}
+
+func (r *certReloader) current() *tls.Certificate {
+	r.certLock.RLock()
+	defer r.certLock.RUnlock()
+	return r.cert
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.current(), nil
+}
+
+func (r *certReloader) watchSIGHUP() {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	go func() {
+		for range c {
+			if err := r.reload(); err != nil {
+				logrus.WithField("err", err).Error("failed to reload the TLS certificate, the previous one is still served")
+				continue
+			}
+			logrus.Info("reloaded the TLS certificate")
+		}
+	}()
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestCert(t *testing.T, certFile string, keyFile string, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+}
+
+func TestSetupTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flagr_tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	defer func() {
+		Config.TLSEnabled = false
+		Config.TLSCertFile = ""
+		Config.TLSKeyFile = ""
+	}()
+
+	t.Run("it does nothing if TLS is not enabled", func(t *testing.T) {
+		tlsConfig := &tls.Config{}
+		assert.NoError(t, SetupTLS(tlsConfig))
+		assert.Empty(t, tlsConfig.Certificates)
+	})
+
+	Config.TLSEnabled = true
+
+	t.Run("it fails without the files", func(t *testing.T) {
+		err := SetupTLS(&tls.Config{})
+		assert.Contains(t, err.Error(), "are required")
+
+		Config.TLSCertFile, Config.TLSKeyFile = certFile, keyFile
+		err = SetupTLS(&tls.Config{})
+		assert.Contains(t, err.Error(), "unable to load the TLS certificate")
+	})
+
+	t.Run("it serves and reloads the certificate on SIGHUP", func(t *testing.T) {
+		writeTestCert(t, certFile, keyFile, "first")
+		tlsConfig := &tls.Config{}
+		assert.NoError(t, SetupTLS(tlsConfig))
+		assert.Len(t, tlsConfig.Certificates, 1)
+
+		s := httptest.NewUnstartedServer(&okHandler{})
+		s.TLS = tlsConfig
+		s.StartTLS()
+		defer s.Close()
+
+		peerCommonName := func() string {
+			client := &http.Client{Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+				DisableKeepAlives: true,
+			}}
+			res, err := client.Get(s.URL)
+			if !assert.NoError(t, err) {
+				return ""
+			}
+			defer res.Body.Close()
+			return res.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		assert.Equal(t, "first", peerCommonName())
+
+		writeTestCert(t, certFile, keyFile, "second")
+		assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+		for i := 0; i < 100 && peerCommonName() != "second"; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.Equal(t, "second", peerCommonName())
+	})
+}
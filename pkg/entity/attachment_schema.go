@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// ValidateAttachmentSchema validates the JSON schema of the variant attachments.
+// Only the draft 4 schemas without $ref and id are supported, so that the
+// validation never resolves the schemas from the remote.
+func ValidateAttachmentSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+
+	var s interface{}
+	if err := json.Unmarshal([]byte(schema), &s); err != nil {
+		return fmt.Errorf("invalid attachment schema. reason: %s", err)
+	}
+	m, ok := s.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid attachment schema. reason: the schema should be an object")
+	}
+	if _, ok := m["id"]; ok {
+		return fmt.Errorf("invalid attachment schema. reason: id is not supported")
+	}
+	if hasJSONSchemaRef(s) {
+		return fmt.Errorf("invalid attachment schema. reason: $ref is not supported")
+	}
+
+	// the meta schema is loaded for every validation because the validator expands its refs in place
+	meta, err := spec.JSONSchemaDraft04()
+	if err != nil {
+		return err
+	}
+	if err := validate.NewSchemaValidator(meta, nil, "attachmentSchema", strfmt.Default).Validate(s).AsError(); err != nil {
+		return fmt.Errorf("invalid attachment schema. reason: %s", err)
+	}
+	return nil
+}
+
+func hasJSONSchemaRef(v interface{}) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range t {
+			if k == "$ref" || hasJSONSchemaRef(vv) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, vv := range t {
+			if hasJSONSchemaRef(vv) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateSchema validates the attachment against the JSON schema,
+// the schema is expected to be validated by ValidateAttachmentSchema
+func (a Attachment) ValidateSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+
+	s := &spec.Schema{}
+	if err := json.Unmarshal([]byte(schema), s); err != nil {
+		return fmt.Errorf("invalid attachment schema. reason: %s", err)
+	}
+
+	data := make(map[string]interface{}, len(a))
+	for k, v := range a {
+		data[k] = v
+	}
+	if err := validate.NewSchemaValidator(s, nil, "attachment", strfmt.Default).Validate(data).AsError(); err != nil {
+		return fmt.Errorf("attachment doesn't match the attachment schema. reason: %s", err)
+	}
+	return nil
+}
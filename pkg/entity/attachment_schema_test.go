@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAttachmentSchema(t *testing.T) {
+	t.Run("happy code path", func(t *testing.T) {
+		assert.NoError(t, ValidateAttachmentSchema(""))
+		assert.NoError(t, ValidateAttachmentSchema(`{"type":"object","required":["color"],"properties":{"color":{"type":"string","enum":["red","blue"]}}}`))
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		assert.Error(t, ValidateAttachmentSchema(`{"type":`))
+		assert.Error(t, ValidateAttachmentSchema(`["color"]`))
+		assert.Error(t, ValidateAttachmentSchema(`{"type":"nope"}`))
+		assert.Error(t, ValidateAttachmentSchema(`{"required":"color"}`))
+	})
+
+	t.Run("unsupported schema", func(t *testing.T) {
+		assert.Error(t, ValidateAttachmentSchema(`{"id":"http://example.com/schema"}`))
+		assert.Error(t, ValidateAttachmentSchema(`{"properties":{"color":{"$ref":"#/definitions/color"}},"definitions":{"color":{"type":"string"}}}`))
+	})
+}
+
+func TestAttachmentValidateSchema(t *testing.T) {
+	schema := `{"type":"object","required":["color"],"properties":{"color":{"type":"string","enum":["red","blue"]}}}`
+
+	t.Run("happy code path", func(t *testing.T) {
+		assert.NoError(t, Attachment{"color": "red"}.ValidateSchema(schema))
+		assert.NoError(t, Attachment{}.ValidateSchema(""))
+	})
+
+	t.Run("attachment doesn't match the schema", func(t *testing.T) {
+		assert.Error(t, Attachment{"color": "green"}.ValidateSchema(schema))
+		assert.Error(t, Attachment{}.ValidateSchema(schema))
+	})
+}
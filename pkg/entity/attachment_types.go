@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cast"
+)
+
+// The types that the attachment values can be declared with
+const (
+	AttachmentTypeString  = "string"
+	AttachmentTypeNumber  = "number"
+	AttachmentTypeInteger = "integer"
+	AttachmentTypeBoolean = "boolean"
+	AttachmentTypeJSON    = "json"
+)
+
+// AttachmentTypes declares the types of the attachment keys of the variant
+type AttachmentTypes map[string]string
+
+// Validate validates the declared types
+func (t AttachmentTypes) Validate() error {
+	for k, typ := range t {
+		switch typ {
+		case AttachmentTypeString, AttachmentTypeNumber, AttachmentTypeInteger, AttachmentTypeBoolean, AttachmentTypeJSON:
+		default:
+			return fmt.Errorf("invalid attachment type %s of key %s", typ, k)
+		}
+	}
+	return nil
+}
+
+// Scan implements scanner interface
+func (t *AttachmentTypes) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	s := cast.ToString(value)
+	if err := json.Unmarshal([]byte(s), t); err != nil {
+		return fmt.Errorf("cannot scan %v into AttachmentTypes type. err: %v", value, err)
+	}
+	return nil
+}
+
+// Value implements valuer interface
+func (t AttachmentTypes) Value() (driver.Value, error) {
+	bytes, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+// Typed coerces the attachment values into the declared types,
+// the keys without a declared type stay strings
+func (a Attachment) Typed(types AttachmentTypes) (map[string]interface{}, error) {
+	typed := make(map[string]interface{}, len(a))
+	for k, s := range a {
+		v, err := coerceAttachmentValue(s, types[k])
+		if err != nil {
+			return nil, fmt.Errorf("attachment key %s doesn't conform to type %s. reason: %s", k, types[k], err)
+		}
+		typed[k] = v
+	}
+	return typed, nil
+}
+
+func coerceAttachmentValue(s string, typ string) (interface{}, error) {
+	switch typ {
+	case "", AttachmentTypeString:
+		return s, nil
+	case AttachmentTypeNumber:
+		return strconv.ParseFloat(s, 64)
+	case AttachmentTypeInteger:
+		return strconv.ParseInt(s, 10, 64)
+	case AttachmentTypeBoolean:
+		return strconv.ParseBool(s)
+	case AttachmentTypeJSON:
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("unknown attachment type")
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentTypesValidate(t *testing.T) {
+	assert.NoError(t, AttachmentTypes(nil).Validate())
+	assert.NoError(t, AttachmentTypes{"a": "string", "b": "number", "c": "integer", "d": "boolean", "e": "json"}.Validate())
+	assert.Error(t, AttachmentTypes{"a": "float"}.Validate())
+}
+
+func TestAttachmentTypesScan(t *testing.T) {
+	t.Run("happy code path", func(t *testing.T) {
+		a := &AttachmentTypes{}
+		err := a.Scan([]byte(`{"key": "number"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, AttachmentTypes{"key": "number"}, *a)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		a := &AttachmentTypes{}
+		err := a.Scan(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		a := &AttachmentTypes{}
+		err := a.Scan([]byte(`{`))
+		assert.Error(t, err)
+	})
+}
+
+func TestAttachmentTyped(t *testing.T) {
+	t.Run("happy code path", func(t *testing.T) {
+		a := Attachment{
+			"s": "hello",
+			"n": "1.5",
+			"i": "42",
+			"b": "false",
+			"j": `{"x": [1, 2]}`,
+			"u": "untyped",
+		}
+		typed, err := a.Typed(AttachmentTypes{"s": "string", "n": "number", "i": "integer", "b": "boolean", "j": "json", "missing": "integer"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"s": "hello",
+			"n": 1.5,
+			"i": int64(42),
+			"b": false,
+			"j": map[string]interface{}{"x": []interface{}{float64(1), float64(2)}},
+			"u": "untyped",
+		}, typed)
+	})
+
+	t.Run("values not conforming to the types", func(t *testing.T) {
+		for _, typ := range []string{"number", "integer", "boolean", "json"} {
+			_, err := Attachment{"k": "not a value"}.Typed(AttachmentTypes{"k": typ})
+			assert.Error(t, err)
+		}
+		_, err := Attachment{"k": "1.5"}.Typed(AttachmentTypes{"k": "integer"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math/bits"
+
+	"github.com/checkr/flagr/pkg/config"
+)
+
+// The bucketing algorithms. Changing the algorithm of a flag reshuffles the
+// assignments of its entities, so it's only meant for migrating the flags from
+// another system that buckets the entities with the same algorithm.
+const (
+	// BucketingAlgorithmCRC32 is the CRC32 (IEEE) checksum, it's the default one
+	BucketingAlgorithmCRC32 = "crc32"
+	// BucketingAlgorithmMurmur3 is the 32 bits MurmurHash3 (x86) with seed 0
+	BucketingAlgorithmMurmur3 = "murmur3"
+	// BucketingAlgorithmXXHash is the 64 bits xxHash with seed 0
+	BucketingAlgorithmXXHash = "xxhash"
+)
+
+// BucketingHasher hashes the key of an entity, i.e. the bucketing salt followed by the entityID.
+// The bucket of the entity is the hash modulo TotalBucketNum.
+type BucketingHasher interface {
+	Hash(key []byte) uint64
+}
+
+// BucketingHasherFunc is the adapter to use a function as a BucketingHasher
+type BucketingHasherFunc func(key []byte) uint64
+
+// Hash calls f(key)
+func (f BucketingHasherFunc) Hash(key []byte) uint64 {
+	return f(key)
+}
+
+var bucketingHashers = map[string]BucketingHasher{
+	BucketingAlgorithmCRC32:   BucketingHasherFunc(crc32Hash),
+	BucketingAlgorithmMurmur3: BucketingHasherFunc(murmur3Hash),
+	BucketingAlgorithmXXHash:  BucketingHasherFunc(xxHash),
+}
+
+// ValidateBucketingAlgorithm validates the bucketing algorithm, the empty one
+// falls back to the global FLAGR_BUCKETING_ALGORITHM
+func ValidateBucketingAlgorithm(algorithm string) error {
+	if algorithm == "" {
+		return nil
+	}
+	if _, ok := bucketingHashers[algorithm]; !ok {
+		return fmt.Errorf("invalid bucketing algorithm %s. expecting one of %s, %s, %s",
+			algorithm, BucketingAlgorithmCRC32, BucketingAlgorithmMurmur3, BucketingAlgorithmXXHash)
+	}
+	return nil
+}
+
+// GetBucketingHasher gets the hasher of the bucketing algorithm. The empty algorithm falls back to
+// the global FLAGR_BUCKETING_ALGORITHM, and the unknown ones fall back to crc32.
+func GetBucketingHasher(algorithm string) BucketingHasher {
+	if algorithm == "" {
+		algorithm = config.Config.BucketingAlgorithm
+	}
+	if h, ok := bucketingHashers[algorithm]; ok {
+		return h
+	}
+	return bucketingHashers[BucketingAlgorithmCRC32]
+}
+
+func crc32Hash(key []byte) uint64 {
+	// crc32 is good in terms of uniform distribution
+	// http://michiel.buddingh.eu/distribution-of-hash-values
+	return uint64(crc32.ChecksumIEEE(key))
+}
+
+func murmur3Hash(key []byte) uint64 {
+	const (
+		c1 uint32 = 0xcc9e2d51
+		c2 uint32 = 0x1b873593
+	)
+
+	var h uint32
+	n := len(key) / 4 * 4
+	for i := 0; i < n; i += 4 {
+		k := binary.LittleEndian.Uint32(key[i:])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	tail := key[n:]
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(key))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return uint64(h)
+}
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	acc ^= xxRound(0, val)
+	return acc*xxPrime1 + xxPrime4
+}
+
+func xxHash(key []byte) uint64 {
+	n := len(key)
+	var h uint64
+
+	if n >= 32 {
+		// the seeds of the accumulators wrap around, they're computed at runtime
+		p1, p2 := xxPrime1, xxPrime2
+		v1 := p1 + p2
+		v2 := p2
+		v3 := uint64(0)
+		v4 := -p1
+		for ; len(key) >= 32; key = key[32:] {
+			v1 = xxRound(v1, binary.LittleEndian.Uint64(key[0:]))
+			v2 = xxRound(v2, binary.LittleEndian.Uint64(key[8:]))
+			v3 = xxRound(v3, binary.LittleEndian.Uint64(key[16:]))
+			v4 = xxRound(v4, binary.LittleEndian.Uint64(key[24:]))
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxMergeRound(h, v1)
+		h = xxMergeRound(h, v2)
+		h = xxMergeRound(h, v3)
+		h = xxMergeRound(h, v4)
+	} else {
+		h = xxPrime5
+	}
+	h += uint64(n)
+
+	for ; len(key) >= 8; key = key[8:] {
+		h ^= xxRound(0, binary.LittleEndian.Uint64(key))
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+	}
+	if len(key) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(key)) * xxPrime1
+		h = bits.RotateLeft64(h, 23)*xxPrime2 + xxPrime3
+		key = key[4:]
+	}
+	for ; len(key) > 0; key = key[1:] {
+		h ^= uint64(key[0]) * xxPrime5
+		h = bits.RotateLeft64(h, 11) * xxPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
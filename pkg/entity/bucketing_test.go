@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// the hashes are pinned, changing any of them reshuffles the assignments of the flags using it
+func TestBucketingHashers(t *testing.T) {
+	fox := []byte("The quick brown fox jumps over the lazy dog")
+	long := []byte("The quick brown fox jumps over the lazy dog, the quick brown fox jumps over the lazy dog")
+
+	t.Run("crc32", func(t *testing.T) {
+		h := GetBucketingHasher(BucketingAlgorithmCRC32)
+		assert.Equal(t, uint64(0), h.Hash([]byte("")))
+		assert.Equal(t, uint64(0x414fa339), h.Hash(fox))
+	})
+
+	t.Run("murmur3", func(t *testing.T) {
+		h := GetBucketingHasher(BucketingAlgorithmMurmur3)
+		assert.Equal(t, uint64(0), h.Hash([]byte("")))
+		assert.Equal(t, uint64(0x248bfa47), h.Hash([]byte("hello")))
+		assert.Equal(t, uint64(0x2e4ff723), h.Hash(fox))
+	})
+
+	t.Run("xxhash", func(t *testing.T) {
+		h := GetBucketingHasher(BucketingAlgorithmXXHash)
+		assert.Equal(t, uint64(0xef46db3751d8e999), h.Hash([]byte("")))
+		assert.Equal(t, uint64(0x44bc2cf5ad770999), h.Hash([]byte("abc")))
+		assert.Equal(t, uint64(0x0b242d361fda71bc), h.Hash(fox))
+		assert.NotEqual(t, h.Hash(fox), h.Hash(long))
+	})
+}
+
+func TestGetBucketingHasher(t *testing.T) {
+	key := []byte("seed1entityID1")
+	crc32 := GetBucketingHasher(BucketingAlgorithmCRC32).Hash(key)
+	xxhash := GetBucketingHasher(BucketingAlgorithmXXHash).Hash(key)
+
+	t.Run("unknown algorithm falls back to crc32", func(t *testing.T) {
+		assert.Equal(t, crc32, GetBucketingHasher("md5").Hash(key))
+	})
+
+	t.Run("empty algorithm falls back to the global one", func(t *testing.T) {
+		assert.Equal(t, crc32, GetBucketingHasher("").Hash(key))
+
+		defer func() { config.Config.BucketingAlgorithm = BucketingAlgorithmCRC32 }()
+		config.Config.BucketingAlgorithm = BucketingAlgorithmXXHash
+		assert.Equal(t, xxhash, GetBucketingHasher("").Hash(key))
+		assert.Equal(t, xxhash, (&Flag{}).BucketingHasher().Hash(key))
+		assert.Equal(t, crc32, (&Flag{BucketingAlgorithm: BucketingAlgorithmCRC32}).BucketingHasher().Hash(key))
+	})
+}
+
+func TestValidateBucketingAlgorithm(t *testing.T) {
+	assert.NoError(t, ValidateBucketingAlgorithm(""))
+	assert.NoError(t, ValidateBucketingAlgorithm(BucketingAlgorithmCRC32))
+	assert.NoError(t, ValidateBucketingAlgorithm(BucketingAlgorithmMurmur3))
+	assert.NoError(t, ValidateBucketingAlgorithm(BucketingAlgorithmXXHash))
+	assert.Error(t, ValidateBucketingAlgorithm("md5"))
+}
@@ -1,8 +1,13 @@
 package entity
 
 import (
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/checkr/flagr/swagger_gen/models"
 	"github.com/jinzhu/gorm"
@@ -17,6 +22,9 @@ type Constraint struct {
 	Property  string
 	Operator  string
 	Value     string `sql:"type:text"`
+
+	// ConstraintGroupID is set instead of SegmentID for the constraints of a constraint group
+	ConstraintGroupID uint `gorm:"index:idx_constraint_constraintgroupid"`
 }
 
 // ConstraintArray is an array of Constraint
@@ -38,6 +46,158 @@ var OperatorToExprMap = map[string]string{
 	models.ConstraintOperatorNOTCONTAINS: "NOT CONTAINS",
 }
 
+// EvalTimeProperty is the reserved property that holds the evaluation time in
+// unix milliseconds. Time-window operators (BEFORE, AFTER, BETWEEN) compare it
+// with the RFC3339 timestamps in the constraint value, and their Property is ignored.
+const EvalTimeProperty = "@now"
+
+// PropertyExistencePrefix prefixes the property in the reserved property that tells if the property exists in
+// the entityContext, e.g. @exists.device.os. The existence operators (EXISTS, NOT_EXISTS) compare it with true.
+const PropertyExistencePrefix = "@exists"
+
+// PropertyMembershipPrefix prefixes the reserved property that tells if the property is in the numbers of an IN
+// or NOT_IN constraint, e.g. @in.h1a2b3c4d.account_id. The IN and NOT_IN constraints compare it with true, so
+// that the membership is looked up in the NumberSet instead of scanning the numbers on every evaluation.
+const PropertyMembershipPrefix = "@in"
+
+// PropertyPathSeparator separates the keys of a nested property, e.g. device.os
+// is the os of the device object in the entityContext
+const PropertyPathSeparator = "."
+
+var propertyPathRegex = regexp.MustCompile(`^@?[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// IsNestedProperty returns true if the property is a path into the objects of the entityContext.
+// The existence operators resolve the path by themselves, see PropertyExists.
+func (c *Constraint) IsNestedProperty() bool {
+	return !c.IsTimeWindow() && !c.IsExistence() && strings.Contains(c.Property, PropertyPathSeparator)
+}
+
+// ResolvePropertyPath returns the value at the path in the entityContext, it's not found
+// if any key of the path is missing or any value before the last key is not an object
+func ResolvePropertyPath(m map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, PropertyPathSeparator)
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = next
+	}
+	v, ok := m[keys[len(keys)-1]]
+	return v, ok
+}
+
+// PropertyExists returns true if the property is set in the entityContext. A missing property or an explicit
+// null doesn't exist, while an empty string, a zero, a false or an empty object or array exists. A path
+// doesn't exist if any key of it is missing.
+func PropertyExists(m map[string]interface{}, path string) bool {
+	v, ok := m[path]
+	if !ok {
+		v, ok = ResolvePropertyPath(m, path)
+	}
+	return ok && v != nil
+}
+
+// ExistenceProperty returns the reserved property that tells if the property exists
+func ExistenceProperty(property string) string {
+	return PropertyExistencePrefix + PropertyPathSeparator + property
+}
+
+// IsExistence returns true if the constraint checks if the property exists, regardless of its value
+func (c *Constraint) IsExistence() bool {
+	switch c.Operator {
+	case models.ConstraintOperatorEXISTS, models.ConstraintOperatorNOTEXISTS:
+		return true
+	}
+	return false
+}
+
+// IsTimeWindow returns true if the constraint compares the evaluation time
+func (c *Constraint) IsTimeWindow() bool {
+	switch c.Operator {
+	case models.ConstraintOperatorBEFORE, models.ConstraintOperatorAFTER, models.ConstraintOperatorBETWEEN:
+		return true
+	}
+	return false
+}
+
+// IsArrayContains returns true if the constraint matches an array property against a list of values
+func (c *Constraint) IsArrayContains() bool {
+	switch c.Operator {
+	case models.ConstraintOperatorARRAYCONTAINSANY, models.ConstraintOperatorARRAYCONTAINSALL:
+		return true
+	}
+	return false
+}
+
+// NumberSet is the set of the numbers of an IN or NOT_IN constraint, it's built once for the evaluation
+type NumberSet struct {
+	Property string
+	// Key is the reserved property of the membership, see PropertyMembershipPrefix
+	Key     string
+	numbers map[float64]struct{}
+}
+
+// Lookup tells if the value of the property is in the set, it's not ok if the value is not a number,
+// and the constraint fails then whether it's IN or NOT_IN
+func (s *NumberSet) Lookup(v interface{}) (in bool, ok bool) {
+	var n float64
+	switch val := v.(type) {
+	case float64:
+		n = val
+	case float32:
+		n = float64(val)
+	case int:
+		n = float64(val)
+	case int32:
+		n = float64(val)
+	case int64:
+		n = float64(val)
+	case json.Number:
+		f, err := val.Float64()
+		if err != nil {
+			return false, false
+		}
+		n = f
+	default:
+		return false, false
+	}
+	_, in = s.numbers[n]
+	return in, true
+}
+
+// NumberSet returns the set of the numbers of the IN or NOT_IN constraint, it's nil if the constraint is not one
+// of them or its value is not a JSON array of numbers. The arrays of strings are already sets in conditions.
+func (c *Constraint) NumberSet() *NumberSet {
+	if c.Operator != models.ConstraintOperatorIN && c.Operator != models.ConstraintOperatorNOTIN {
+		return nil
+	}
+	if c.Property == "" || !propertyPathRegex.MatchString(c.Property) {
+		return nil
+	}
+	numbers := []float64{}
+	if err := json.Unmarshal([]byte(c.Value), &numbers); err != nil || len(numbers) == 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(c.Value))
+	s := &NumberSet{
+		Property: c.Property,
+		Key:      fmt.Sprintf("%s%sh%x%s%s", PropertyMembershipPrefix, PropertyPathSeparator, h.Sum32(), PropertyPathSeparator, c.Property),
+		numbers:  make(map[float64]struct{}, len(numbers)),
+	}
+	for _, n := range numbers {
+		s.numbers[n] = struct{}{}
+	}
+	return s
+}
+
+// IsNumberRange returns true if the constraint matches a numeric property against a range of two numbers
+func (c *Constraint) IsNumberRange() bool {
+	return c.Operator == models.ConstraintOperatorNUMBERBETWEEN
+}
+
 // ToExpr transfer the constraint to conditions.Expr for evaluation
 func (c *Constraint) ToExpr() (conditions.Expr, error) {
 	s, err := c.toExprStr()
@@ -53,6 +213,9 @@ func (c *Constraint) ToExpr() (conditions.Expr, error) {
 }
 
 func (c *Constraint) toExprStr() (string, error) {
+	if c.IsExistence() && c.Property != "" {
+		return c.toExistenceExprStr(), nil
+	}
 	if c.Property == "" || c.Operator == "" || c.Value == "" {
 		return "", fmt.Errorf(
 			"empty Property/Operator/Value: %s/%s/%s",
@@ -61,16 +224,167 @@ func (c *Constraint) toExprStr() (string, error) {
 			c.Value,
 		)
 	}
+	if c.IsTimeWindow() {
+		return c.toTimeWindowExprStr()
+	}
+	if c.IsArrayContains() {
+		return c.toArrayContainsExprStr()
+	}
+	if c.IsNumberRange() {
+		return c.toNumberRangeExprStr()
+	}
+	if set := c.NumberSet(); set != nil {
+		prop := strings.Replace(set.Key, PropertyPathSeparator, "}{", -1)
+		return fmt.Sprintf("({%s} == %t)", prop, c.Operator == models.ConstraintOperatorIN), nil
+	}
 	o, ok := OperatorToExprMap[c.Operator]
 	if !ok {
 		return "", fmt.Errorf("not supported operator: %s", c.Operator)
 	}
 
-	return fmt.Sprintf("({%s} %s %s)", c.Property, o, c.Value), nil
+	// conditions reads {device}{os} as the variable device.os
+	prop := strings.Replace(c.Property, PropertyPathSeparator, "}{", -1)
+	return fmt.Sprintf("({%s} %s %s)", prop, o, c.Value), nil
+}
+
+// toExistenceExprStr compares the reserved property of ExistenceProperty, the value of the constraint is ignored
+func (c *Constraint) toExistenceExprStr() string {
+	prop := strings.Replace(ExistenceProperty(c.Property), PropertyPathSeparator, "}{", -1)
+	return fmt.Sprintf("({%s} == %t)", prop, c.Operator == models.ConstraintOperatorEXISTS)
+}
+
+func (c *Constraint) toTimeWindowExprStr() (string, error) {
+	if c.Operator != models.ConstraintOperatorBETWEEN {
+		t, err := parseConstraintTime(c.Value)
+		if err != nil {
+			return "", err
+		}
+		o := "<"
+		if c.Operator == models.ConstraintOperatorAFTER {
+			o = ">"
+		}
+		return fmt.Sprintf("({%s} %s %d)", EvalTimeProperty, o, unixMillis(t)), nil
+	}
+
+	ts := []string{}
+	if err := json.Unmarshal([]byte(c.Value), &ts); err != nil || len(ts) != 2 {
+		return "", fmt.Errorf(`BETWEEN expects an array of two timestamps, e.g. ["2019-01-01T00:00:00Z", "2019-02-01T00:00:00Z"], got %s`, c.Value)
+	}
+	start, err := parseConstraintTime(ts[0])
+	if err != nil {
+		return "", err
+	}
+	end, err := parseConstraintTime(ts[1])
+	if err != nil {
+		return "", err
+	}
+	if !start.Before(end) {
+		return "", fmt.Errorf("the start of BETWEEN %s is not before its end %s", ts[0], ts[1])
+	}
+	return fmt.Sprintf(
+		"(({%s} >= %d) AND ({%s} < %d))",
+		EvalTimeProperty, unixMillis(start),
+		EvalTimeProperty, unixMillis(end),
+	), nil
+}
+
+// toArrayContainsExprStr joins a CONTAINS of the property for each value of the list, with OR for
+// ARRAY_CONTAINS_ANY and AND for ARRAY_CONTAINS_ALL. CONTAINS fails if the property is missing or
+// it's not an array, so do these operators.
+func (c *Constraint) toArrayContainsExprStr() (string, error) {
+	values := []interface{}{}
+	if err := json.Unmarshal([]byte(c.Value), &values); err != nil || len(values) == 0 {
+		return "", fmt.Errorf(`%s expects a non-empty array of strings or numbers, e.g. ["admin", "billing"], got %s`, c.Operator, c.Value)
+	}
+
+	prop := strings.Replace(c.Property, PropertyPathSeparator, "}{", -1)
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		var literal string
+		switch val := v.(type) {
+		case string:
+			literal = strconv.Quote(val)
+		case float64:
+			literal = strconv.FormatFloat(val, 'f', -1, 64)
+		default:
+			return "", fmt.Errorf("%s expects an array of strings or numbers, got %v in %s", c.Operator, v, c.Value)
+		}
+		strs = append(strs, fmt.Sprintf("({%s} CONTAINS %s)", prop, literal))
+	}
+
+	join := " OR "
+	if c.Operator == models.ConstraintOperatorARRAYCONTAINSALL {
+		join = " AND "
+	}
+	return "(" + strings.Join(strs, join) + ")", nil
+}
+
+// toNumberRangeExprStr compares the property with the bounds of the range in the interval notation, [ and ] for
+// the inclusive bounds, ( and ) for the exclusive ones, e.g. [18, 65) is 18 <= age < 65. The comparisons fail
+// if the property is missing or it's not a number.
+func (c *Constraint) toNumberRangeExprStr() (string, error) {
+	v := strings.TrimSpace(c.Value)
+	invalid := fmt.Errorf(`%s expects a range of two numbers, [ and ] for the inclusive bounds, ( and ) for the exclusive ones, e.g. [18, 65), got %s`, c.Operator, c.Value)
+	if len(v) < 2 || !strings.ContainsAny(v[:1], "[(") || !strings.ContainsAny(v[len(v)-1:], "])") {
+		return "", invalid
+	}
+	bounds := strings.Split(v[1:len(v)-1], ",")
+	if len(bounds) != 2 {
+		return "", invalid
+	}
+	min, err := strconv.ParseFloat(strings.TrimSpace(bounds[0]), 64)
+	if err != nil {
+		return "", invalid
+	}
+	max, err := strconv.ParseFloat(strings.TrimSpace(bounds[1]), 64)
+	if err != nil {
+		return "", invalid
+	}
+	if min > max {
+		return "", fmt.Errorf("the min %v of %s is greater than its max %v", min, c.Operator, max)
+	}
+
+	minOp, maxOp := ">=", "<="
+	if v[0] == '(' {
+		minOp = ">"
+	}
+	if v[len(v)-1] == ')' {
+		maxOp = "<"
+	}
+	prop := strings.Replace(c.Property, PropertyPathSeparator, "}{", -1)
+	return fmt.Sprintf(
+		"(({%s} %s %s) AND ({%s} %s %s))",
+		prop, minOp, strconv.FormatFloat(min, 'f', -1, 64),
+		prop, maxOp, strconv.FormatFloat(max, 'f', -1, 64),
+	), nil
+}
+
+func parseConstraintTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, strings.Trim(strings.TrimSpace(s), `"`))
+	if err != nil {
+		return t, fmt.Errorf("invalid RFC3339 timestamp %s: %s", s, err)
+	}
+	return t, nil
+}
+
+// EvalTimeValue returns the value of EvalTimeProperty for the given time
+func EvalTimeValue(t time.Time) float64 {
+	return float64(unixMillis(t))
+}
+
+func unixMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
 }
 
 // Validate validates Constraint
 func (c *Constraint) Validate() error {
+	if !c.IsTimeWindow() && c.Property != "" && !propertyPathRegex.MatchString(c.Property) {
+		return fmt.Errorf(
+			"invalid property %s. it should be a key or a path of keys separated by %s, e.g. device.os, with only letters, digits and underscores in the keys",
+			c.Property,
+			PropertyPathSeparator,
+		)
+	}
 	_, err := c.ToExpr()
 	return err
 }
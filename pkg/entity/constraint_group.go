@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ConstraintGroup is a named set of constraints shared by the segments referencing it.
+// A referencing segment matches only if both its own constraints and the group's match,
+// so changing the group changes the targeting of all of them.
+type ConstraintGroup struct {
+	gorm.Model
+	Key         string `gorm:"type:varchar(64);unique_index:idx_constraint_group_key"`
+	Description string `sql:"type:text"`
+	Constraints ConstraintArray
+	// Namespace is the namespace of the flags that can reference the group, see Flag.Namespace
+	Namespace string `gorm:"index:idx_constraint_group_namespace"`
+}
+
+// PreloadConstraintGroupConstraints preloads the constraints of the constraint groups
+func PreloadConstraintGroupConstraints(db *gorm.DB) *gorm.DB {
+	return db.Preload("Constraints", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	})
+}
+
+// AttachConstraintGroups sets the ConstraintGroup of the segments referencing one,
+// all the groups are loaded in one query however many segments reference them
+func AttachConstraintGroups(db *gorm.DB, fs []Flag) error {
+	gs := []ConstraintGroup{}
+	if err := PreloadConstraintGroupConstraints(db).Find(&gs).Error; err != nil {
+		return err
+	}
+	groups := make(map[uint]*ConstraintGroup, len(gs))
+	for i := range gs {
+		groups[gs[i].ID] = &gs[i]
+	}
+
+	for i := range fs {
+		for j := range fs[i].Segments {
+			s := &fs[i].Segments[j]
+			if s.ConstraintGroupID == 0 {
+				continue
+			}
+			g, ok := groups[s.ConstraintGroupID]
+			if !ok {
+				return fmt.Errorf("cannot find constraint group %v of segment %v", s.ConstraintGroupID, s.ID)
+			}
+			s.ConstraintGroup = g
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachConstraintGroups(t *testing.T) {
+	db := NewTestDB()
+	defer db.Close()
+
+	g := ConstraintGroup{Key: "employees", Constraints: ConstraintArray{{Property: "employee", Operator: "EQ", Value: `"yes"`}}}
+	db.Create(&g)
+
+	t.Run("it should attach the groups to the referencing segments", func(t *testing.T) {
+		f := GenFixtureFlag()
+		f.Segments[0].ConstraintGroupID = g.ID
+		fs := []Flag{f}
+		assert.NoError(t, AttachConstraintGroups(db, fs))
+
+		s := fs[0].Segments[0]
+		assert.Equal(t, "employees", s.ConstraintGroup.Key)
+		assert.Len(t, s.EvalConstraints(), 2)
+		assert.Equal(t, "employee", s.EvalConstraints()[1].Property)
+		assert.NoError(t, s.PrepareEvaluation())
+	})
+
+	t.Run("it should fail on the missing groups", func(t *testing.T) {
+		f := GenFixtureFlag()
+		f.Segments[0].ConstraintGroupID = 999
+		assert.Error(t, AttachConstraintGroups(db, []Flag{f}))
+		assert.Error(t, f.Segments[0].PrepareEvaluation())
+	})
+}
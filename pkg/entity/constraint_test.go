@@ -1,10 +1,16 @@
 package entity
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/checkr/flagr/swagger_gen/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/zhouzhuojie/conditions"
 )
 
 func TestConstraintToExpr(t *testing.T) {
@@ -88,6 +94,226 @@ func TestConstraintToExpr(t *testing.T) {
 	})
 }
 
+func TestConstraintTimeWindowToExpr(t *testing.T) {
+	now := func(s string) map[string]interface{} {
+		ts, _ := time.Parse(time.RFC3339, s)
+		return map[string]interface{}{EvalTimeProperty: EvalTimeValue(ts)}
+	}
+
+	t.Run("BEFORE", func(t *testing.T) {
+		c := Constraint{Property: "now", Operator: models.ConstraintOperatorBEFORE, Value: `"2019-01-01T00:00:00Z"`}
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+
+		match, _ := conditions.Evaluate(expr, now("2018-12-31T23:59:59Z"))
+		assert.True(t, match)
+		match, _ = conditions.Evaluate(expr, now("2019-01-01T00:00:01Z"))
+		assert.False(t, match)
+	})
+
+	t.Run("AFTER", func(t *testing.T) {
+		c := Constraint{Property: "now", Operator: models.ConstraintOperatorAFTER, Value: "2019-01-01T00:00:00+08:00"}
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+
+		match, _ := conditions.Evaluate(expr, now("2018-12-31T16:00:01Z"))
+		assert.True(t, match)
+		match, _ = conditions.Evaluate(expr, now("2018-12-31T15:59:59Z"))
+		assert.False(t, match)
+	})
+
+	t.Run("BETWEEN", func(t *testing.T) {
+		c := Constraint{Property: "now", Operator: models.ConstraintOperatorBETWEEN, Value: `["2019-01-01T00:00:00Z", "2019-02-01T00:00:00Z"]`}
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+
+		match, _ := conditions.Evaluate(expr, now("2019-01-15T00:00:00Z"))
+		assert.True(t, match)
+		match, _ = conditions.Evaluate(expr, now("2019-02-01T00:00:00Z"))
+		assert.False(t, match)
+		match, _ = conditions.Evaluate(expr, now("2018-12-15T00:00:00Z"))
+		assert.False(t, match)
+	})
+
+	t.Run("invalid timestamps", func(t *testing.T) {
+		for _, c := range []Constraint{
+			{Property: "now", Operator: models.ConstraintOperatorBEFORE, Value: `"2019-01-01"`},
+			{Property: "now", Operator: models.ConstraintOperatorBETWEEN, Value: `"2019-01-01T00:00:00Z"`},
+			{Property: "now", Operator: models.ConstraintOperatorBETWEEN, Value: `["2019-02-01T00:00:00Z", "2019-01-01T00:00:00Z"]`},
+		} {
+			assert.Error(t, c.Validate())
+		}
+	})
+}
+
+func TestConstraintArrayContains(t *testing.T) {
+	roles := func(v interface{}) map[string]interface{} {
+		return map[string]interface{}{"roles": v}
+	}
+
+	t.Run("ARRAY_CONTAINS_ANY", func(t *testing.T) {
+		c := Constraint{Property: "roles", Operator: models.ConstraintOperatorARRAYCONTAINSANY, Value: `["admin", "billing"]`}
+		assert.True(t, c.IsArrayContains())
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+
+		match, _ := conditions.Evaluate(expr, roles([]interface{}{"billing", "support"}))
+		assert.True(t, match)
+		match, _ = conditions.Evaluate(expr, roles([]interface{}{"support"}))
+		assert.False(t, match)
+	})
+
+	t.Run("ARRAY_CONTAINS_ALL", func(t *testing.T) {
+		c := Constraint{Property: "roles", Operator: models.ConstraintOperatorARRAYCONTAINSALL, Value: `["admin", "billing"]`}
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+
+		match, _ := conditions.Evaluate(expr, roles([]interface{}{"billing", "admin", "support"}))
+		assert.True(t, match)
+		match, _ = conditions.Evaluate(expr, roles([]interface{}{"billing", "support"}))
+		assert.False(t, match)
+	})
+
+	t.Run("numbers and nested properties", func(t *testing.T) {
+		c := Constraint{Property: "account.plans", Operator: models.ConstraintOperatorARRAYCONTAINSANY, Value: `[1, 2.5]`}
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+
+		match, _ := conditions.Evaluate(expr, map[string]interface{}{"account.plans": []interface{}{float64(2.5)}})
+		assert.True(t, match)
+	})
+
+	t.Run("the property is missing or not an array", func(t *testing.T) {
+		c := Constraint{Property: "roles", Operator: models.ConstraintOperatorARRAYCONTAINSANY, Value: `["admin"]`}
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+
+		for _, m := range []map[string]interface{}{
+			{},
+			roles("admin"),
+			roles(float64(1)),
+			roles([]interface{}{}),
+		} {
+			match, _ := conditions.Evaluate(expr, m)
+			assert.False(t, match)
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for _, v := range []string{`"admin"`, `[]`, `["admin", {"a": 1}]`, `[true]`, `admin`} {
+			c := Constraint{Property: "roles", Operator: models.ConstraintOperatorARRAYCONTAINSALL, Value: v}
+			assert.Error(t, c.Validate(), v)
+		}
+	})
+}
+
+func TestConstraintNumberRange(t *testing.T) {
+	age := func(v interface{}) map[string]interface{} {
+		return map[string]interface{}{"age": v}
+	}
+	matches := func(c Constraint, m map[string]interface{}) bool {
+		expr, err := c.ToExpr()
+		assert.NoError(t, err)
+		match, _ := conditions.Evaluate(expr, m)
+		return match
+	}
+
+	t.Run("inclusive and exclusive bounds", func(t *testing.T) {
+		c := Constraint{Property: "age", Operator: models.ConstraintOperatorNUMBERBETWEEN, Value: "[18, 65]"}
+		assert.True(t, c.IsNumberRange())
+		assert.True(t, matches(c, age(float64(18))))
+		assert.True(t, matches(c, age(float64(65))))
+		assert.False(t, matches(c, age(float64(17.9))))
+
+		c.Value = "(18, 65)"
+		assert.False(t, matches(c, age(float64(18))))
+		assert.True(t, matches(c, age(float64(18.5))))
+		assert.False(t, matches(c, age(float64(65))))
+
+		c.Value = "[18,65)"
+		assert.True(t, matches(c, age(float64(18))))
+		assert.False(t, matches(c, age(float64(65))))
+
+		c.Value = "(-1.5, 0]"
+		assert.True(t, matches(c, age(float64(-1))))
+		assert.False(t, matches(c, age(float64(-1.5))))
+	})
+
+	t.Run("nested properties", func(t *testing.T) {
+		c := Constraint{Property: "user.age", Operator: models.ConstraintOperatorNUMBERBETWEEN, Value: "[18, 65]"}
+		assert.True(t, c.IsNestedProperty())
+		assert.True(t, matches(c, map[string]interface{}{"user.age": float64(30)}))
+	})
+
+	t.Run("the property is missing or not a number", func(t *testing.T) {
+		c := Constraint{Property: "age", Operator: models.ConstraintOperatorNUMBERBETWEEN, Value: "[18, 65]"}
+		for _, m := range []map[string]interface{}{
+			{},
+			age(nil),
+			age("30"),
+			age(true),
+		} {
+			assert.False(t, matches(c, m))
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for _, v := range []string{"[65, 18]", "[18]", "[18, 30, 65]", "[a, 65]", "18, 65", "{18, 65}", "[18, 65", `["18", "65"]`} {
+			c := Constraint{Property: "age", Operator: models.ConstraintOperatorNUMBERBETWEEN, Value: v}
+			assert.Error(t, c.Validate(), v)
+		}
+		c := Constraint{Property: "age", Operator: models.ConstraintOperatorNUMBERBETWEEN, Value: "[18, 18]"}
+		assert.NoError(t, c.Validate())
+	})
+}
+
+func TestConstraintExistence(t *testing.T) {
+	t.Run("EXISTS and NOT_EXISTS", func(t *testing.T) {
+		exists := Constraint{Property: "beta.opt_in", Operator: models.ConstraintOperatorEXISTS}
+		notExists := Constraint{Property: "beta.opt_in", Operator: models.ConstraintOperatorNOTEXISTS}
+		assert.True(t, exists.IsExistence())
+		assert.False(t, exists.IsNestedProperty())
+		assert.NoError(t, exists.Validate())
+		assert.NoError(t, notExists.Validate())
+
+		expr, err := exists.ToExpr()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"@exists.beta.opt_in"}, conditions.Variables(expr))
+		match, _ := conditions.Evaluate(expr, map[string]interface{}{"@exists.beta.opt_in": true})
+		assert.True(t, match)
+
+		expr, err = notExists.ToExpr()
+		assert.NoError(t, err)
+		match, _ = conditions.Evaluate(expr, map[string]interface{}{"@exists.beta.opt_in": false})
+		assert.True(t, match)
+	})
+
+	t.Run("the value is ignored but the property is required", func(t *testing.T) {
+		c := Constraint{Property: "beta", Operator: models.ConstraintOperatorEXISTS, Value: `"x"`}
+		assert.NoError(t, c.Validate())
+		c = Constraint{Operator: models.ConstraintOperatorEXISTS}
+		assert.Error(t, c.Validate())
+		c = Constraint{Property: "beta.", Operator: models.ConstraintOperatorNOTEXISTS}
+		assert.Error(t, c.Validate())
+	})
+
+	t.Run("null doesn't exist but the empty string does", func(t *testing.T) {
+		m := map[string]interface{}{
+			"empty":   "",
+			"null":    nil,
+			"zero":    float64(0),
+			"beta":    map[string]interface{}{"opt_in": false, "cohort": nil},
+			"flat.ok": "yes",
+		}
+		for _, p := range []string{"empty", "zero", "beta", "beta.opt_in", "flat.ok"} {
+			assert.True(t, PropertyExists(m, p), p)
+		}
+		for _, p := range []string{"null", "missing", "beta.cohort", "beta.missing", "empty.missing"} {
+			assert.False(t, PropertyExists(m, p), p)
+		}
+	})
+}
+
 func TestConstraintValidate(t *testing.T) {
 	t.Run("empty case", func(t *testing.T) {
 		c := Constraint{}
@@ -103,6 +329,137 @@ func TestConstraintValidate(t *testing.T) {
 		}
 		assert.NoError(t, c.Validate())
 	})
+
+	t.Run("property paths", func(t *testing.T) {
+		for _, p := range []string{"device.os", "user.address.zip_code", "@now"} {
+			c := Constraint{Property: p, Operator: models.ConstraintOperatorEQ, Value: `"x"`}
+			assert.NoError(t, c.Validate(), p)
+		}
+		for _, p := range []string{"device.", ".os", "device..os", "device os", "device-os", "device.@os"} {
+			c := Constraint{Property: p, Operator: models.ConstraintOperatorEQ, Value: `"x"`}
+			assert.Error(t, c.Validate(), p)
+		}
+	})
+}
+
+func TestConstraintNestedProperty(t *testing.T) {
+	c := Constraint{Property: "device.os", Operator: models.ConstraintOperatorEQ, Value: `"ios"`}
+	assert.True(t, c.IsNestedProperty())
+	expr, err := c.ToExpr()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"device.os"}, conditions.Variables(expr))
+
+	m := map[string]interface{}{
+		"device": map[string]interface{}{"os": "ios"},
+		"user":   "u1",
+	}
+	v, ok := ResolvePropertyPath(m, "device.os")
+	assert.True(t, ok)
+	assert.Equal(t, "ios", v)
+
+	_, ok = ResolvePropertyPath(m, "device.version")
+	assert.False(t, ok)
+	_, ok = ResolvePropertyPath(m, "user.id")
+	assert.False(t, ok)
+	_, ok = ResolvePropertyPath(m, "browser.name")
+	assert.False(t, ok)
+
+	assert.False(t, (&Constraint{Property: "dl_state", Operator: models.ConstraintOperatorEQ}).IsNestedProperty())
+}
+
+func TestConstraintNumberSet(t *testing.T) {
+	t.Run("IN and NOT_IN of numbers", func(t *testing.T) {
+		in := Constraint{Property: "account_id", Operator: models.ConstraintOperatorIN, Value: "[1, 2, 3]"}
+		notIn := Constraint{Property: "account_id", Operator: models.ConstraintOperatorNOTIN, Value: "[1, 2, 3]"}
+		assert.NoError(t, in.Validate())
+		assert.NoError(t, notIn.Validate())
+
+		set := in.NumberSet()
+		assert.NotNil(t, set)
+		assert.Equal(t, set.Key, notIn.NumberSet().Key)
+		assert.NotEqual(t, set.Key, (&Constraint{Property: "account_id", Operator: models.ConstraintOperatorIN, Value: "[4]"}).NumberSet().Key)
+
+		expr, err := in.ToExpr()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{set.Key}, conditions.Variables(expr))
+		match, _ := conditions.Evaluate(expr, map[string]interface{}{set.Key: true})
+		assert.True(t, match)
+
+		expr, err = notIn.ToExpr()
+		assert.NoError(t, err)
+		match, _ = conditions.Evaluate(expr, map[string]interface{}{set.Key: false})
+		assert.True(t, match)
+	})
+
+	t.Run("it looks up the numbers only", func(t *testing.T) {
+		set := (&Constraint{Property: "account_id", Operator: models.ConstraintOperatorIN, Value: "[1, 2.5]"}).NumberSet()
+		for _, v := range []interface{}{float64(1), 2.5, int(1), int64(1), json.Number("1")} {
+			in, ok := set.Lookup(v)
+			assert.True(t, ok)
+			assert.True(t, in)
+		}
+		in, ok := set.Lookup(float64(3))
+		assert.True(t, ok)
+		assert.False(t, in)
+		for _, v := range []interface{}{"1", nil, true, []interface{}{float64(1)}} {
+			_, ok = set.Lookup(v)
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("the other constraints have no number set", func(t *testing.T) {
+		assert.Nil(t, (&Constraint{Property: "dl_state", Operator: models.ConstraintOperatorIN, Value: `["CA", "NY"]`}).NumberSet())
+		assert.Nil(t, (&Constraint{Property: "dl_state", Operator: models.ConstraintOperatorIN, Value: "[]"}).NumberSet())
+		assert.Nil(t, (&Constraint{Property: "age", Operator: models.ConstraintOperatorEQ, Value: "1"}).NumberSet())
+	})
+
+	t.Run("the segment prepares the number sets", func(t *testing.T) {
+		s := Segment{Constraints: ConstraintArray{
+			{Property: "account_id", Operator: models.ConstraintOperatorIN, Value: "[1, 2, 3]"},
+			{Property: "dl_state", Operator: models.ConstraintOperatorIN, Value: `["CA", "NY"]`},
+		}}
+		assert.NoError(t, s.PrepareEvaluation())
+		assert.Len(t, s.SegmentEvaluation.NumberSets, 1)
+	})
+}
+
+// BenchmarkConstraintNumberIN compares the linear scan of conditions with the lookup of the number set,
+// for the membership of the last number of a list of 5,000 IDs
+func BenchmarkConstraintNumberIN(b *testing.B) {
+	ids := make([]string, 5000)
+	for i := range ids {
+		ids[i] = strconv.Itoa(1000000 + i)
+	}
+	c := Constraint{Property: "account_id", Operator: models.ConstraintOperatorIN, Value: "[" + strings.Join(ids, ", ") + "]"}
+	m := map[string]interface{}{"account_id": float64(1004999)}
+
+	b.Run("linear scan", func(b *testing.B) {
+		expr, err := conditions.NewParser(strings.NewReader(fmt.Sprintf("({account_id} IN %s)", c.Value))).Parse()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if match, _ := conditions.Evaluate(expr, m); !match {
+				b.Fatal("expected a match")
+			}
+		}
+	})
+
+	b.Run("set lookup", func(b *testing.B) {
+		expr, err := c.ToExpr()
+		if err != nil {
+			b.Fatal(err)
+		}
+		set := c.NumberSet()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			in, _ := set.Lookup(m[set.Property])
+			if match, _ := conditions.Evaluate(expr, map[string]interface{}{set.Key: in}); !match {
+				b.Fatal("expected a match")
+			}
+		}
+	})
 }
 
 func TestConstraintArray(t *testing.T) {
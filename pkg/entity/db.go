@@ -1,8 +1,11 @@
 package entity
 
 import (
+	"context"
+	"database/sql"
 	"os"
 	"sync"
+	"time"
 
 	_ "github.com/jinzhu/gorm/dialects/mysql"    // mysql driver
 	_ "github.com/jinzhu/gorm/dialects/postgres" // postgres driver
@@ -29,6 +32,15 @@ var AutoMigrateTables = []interface{}{
 	User{},
 	Variant{},
 	FlagEntityType{},
+	FlagHistory{},
+	Tag{},
+	FlagSchedule{},
+	ExclusionGroup{},
+	ConstraintGroup{},
+	SegmentTemplate{},
+	FlagEnvironment{},
+	FlagEnvironmentDistribution{},
+	FlagChange{},
 }
 
 func connectDB() (db *gorm.DB, err error) {
@@ -43,6 +55,19 @@ func connectDB() (db *gorm.DB, err error) {
 	return db, err
 }
 
+// dbPool is the connection pool settings part of *sql.DB
+type dbPool interface {
+	SetMaxOpenConns(n int)
+	SetMaxIdleConns(n int)
+	SetConnMaxLifetime(d time.Duration)
+}
+
+func setDBPool(p dbPool) {
+	p.SetMaxOpenConns(config.Config.DBMaxOpenConns)
+	p.SetMaxIdleConns(config.Config.DBMaxIdleConns)
+	p.SetConnMaxLifetime(config.Config.DBConnMaxLifetime)
+}
+
 // GetDB gets the db singleton
 func GetDB() *gorm.DB {
 	singletonOnce.Do(func() {
@@ -54,14 +79,74 @@ func GetDB() *gorm.DB {
 				logrus.Fatal("failed to connect to db")
 			}
 		}
+		setDBPool(db.DB())
 		db.SetLogger(logrus.StandardLogger())
 		db.Debug().AutoMigrate(AutoMigrateTables...)
+		migrateTagIndexes(db)
+		if err := migrateIDColumns(db); err != nil {
+			logrus.WithField("err", err).Fatal("failed to migrate the id columns")
+		}
+		if err := migrateTextColumns(db); err != nil {
+			logrus.WithField("err", err).Fatal("failed to migrate the text columns")
+		}
+		if err := migrateIDSequences(db); err != nil {
+			logrus.WithField("err", err).Fatal("failed to migrate the id sequences")
+		}
+		if err := setupIDStrategy(db); err != nil {
+			logrus.WithField("err", err).Fatal("failed to setup the id strategy")
+		}
 		singletonDB = db
 	})
 
 	return singletonDB
 }
 
+// contextDB runs the queries of gorm with the context
+type contextDB struct {
+	ctx context.Context
+	db  *sql.DB
+}
+
+func (c *contextDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) Prepare(query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(c.ctx, query)
+}
+
+func (c *contextDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(c.ctx, query, args...)
+}
+
+func (c *contextDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(c.ctx, query, args...)
+}
+
+// Begin begins the transactions with the context, they're rolled back if it's done before they're committed
+func (c *contextDB) Begin() (*sql.Tx, error) {
+	return c.db.BeginTx(c.ctx, nil)
+}
+
+// WithContext binds the queries of db to ctx, e.g. the context of a request, so that they're cancelled
+// once it's done instead of running on. db is returned as it is if it's not on a connection pool, e.g. in a transaction.
+func WithContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	sqlDB := db.DB()
+	if sqlDB == nil {
+		return db
+	}
+	cdb, err := gorm.Open(db.Dialect().GetName(), &contextDB{ctx: ctx, db: sqlDB})
+	if err != nil {
+		return db
+	}
+	cdb.SetLogger(logrus.StandardLogger())
+	if nextID, ok := db.Get(nextIDSetting); ok {
+		cdb.InstantSet(nextIDSetting, nextID)
+		registerAssignID(cdb, nextID.(func() uint))
+	}
+	return cdb
+}
+
 // NewSQLiteDB creates a new sqlite db
 // useful for backup exports and unit tests
 func NewSQLiteDB(filePath string) *gorm.DB {
@@ -73,6 +158,7 @@ func NewSQLiteDB(filePath string) *gorm.DB {
 	}
 	db.SetLogger(logrus.StandardLogger())
 	db.AutoMigrate(AutoMigrateTables...)
+	migrateTagIndexes(db)
 	return db
 }
 
@@ -1,9 +1,14 @@
 package entity
 
 import (
+	"context"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/checkr/flagr/pkg/config"
+	"github.com/jinzhu/gorm"
+	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,3 +50,136 @@ func TestGetDB(t *testing.T) {
 	db := GetDB()
 	assert.NotNil(t, db)
 }
+
+type mockDBPool struct {
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+}
+
+func (m *mockDBPool) SetMaxOpenConns(n int)              { m.maxOpenConns = n }
+func (m *mockDBPool) SetMaxIdleConns(n int)              { m.maxIdleConns = n }
+func (m *mockDBPool) SetConnMaxLifetime(d time.Duration) { m.connMaxLifetime = d }
+
+func TestSetDBPool(t *testing.T) {
+	old := config.Config
+	defer func() { config.Config = old }()
+
+	config.Config.DBMaxOpenConns = 20
+	config.Config.DBMaxIdleConns = 5
+	config.Config.DBConnMaxLifetime = 5 * time.Minute
+
+	p := &mockDBPool{}
+	setDBPool(p)
+	assert.Equal(t, 20, p.maxOpenConns)
+	assert.Equal(t, 5, p.maxIdleConns)
+	assert.Equal(t, 5*time.Minute, p.connMaxLifetime)
+
+	t.Run("applied to sql.DB", func(t *testing.T) {
+		db := NewTestDB()
+		setDBPool(db.DB())
+		assert.Equal(t, 20, db.DB().Stats().MaxOpenConnections)
+	})
+}
+
+// TestPostgresMigration runs against a real postgres, e.g.
+// FLAGR_TEST_POSTGRES_DBCONNECTIONSTR="sslmode=disable host=localhost user=test password=test dbname=flagr"
+func TestPostgresMigration(t *testing.T) {
+	connectionStr := os.Getenv("FLAGR_TEST_POSTGRES_DBCONNECTIONSTR")
+	if connectionStr == "" {
+		t.Skip("FLAGR_TEST_POSTGRES_DBCONNECTIONSTR is not set")
+	}
+
+	db, err := gorm.Open("postgres", connectionStr)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, db.DropTableIfExists(AutoMigrateTables...).Error)
+	assert.NoError(t, db.AutoMigrate(AutoMigrateTables...).Error)
+	// migrating an existing schema should be a no-op
+	assert.NoError(t, db.AutoMigrate(AutoMigrateTables...).Error)
+
+	f := GenFixtureFlag()
+	assert.NoError(t, db.Create(&f).Error)
+
+	got := &Flag{}
+	assert.NoError(t, db.First(got, f.ID).Error)
+	assert.NoError(t, got.Preload(db))
+	assert.Equal(t, f.Key, got.Key)
+	assert.Equal(t, f.Description, got.Description)
+	assert.Len(t, got.Segments, len(f.Segments))
+	assert.Len(t, got.Variants, len(f.Variants))
+	assert.Equal(t, f.Segments[0].Constraints[0].Value, got.Segments[0].Constraints[0].Value)
+	assert.Equal(t, f.Segments[0].Distributions[0].Percent, got.Segments[0].Distributions[0].Percent)
+	assert.Equal(t, f.Variants[0].Attachment, got.Variants[0].Attachment)
+
+	// new rows still get their ids from the serial sequences
+	s := &Segment{FlagID: f.ID, Description: "new segment"}
+	assert.NoError(t, db.Create(s).Error)
+	assert.NotZero(t, s.ID)
+
+	// the sequences move past the rows inserted with their ids
+	assert.NoError(t, db.Create(&Flag{Model: gorm.Model{ID: 1000}, Key: "flag_with_id"}).Error)
+	assert.NoError(t, migrateTextColumns(db))
+	assert.NoError(t, migrateIDSequences(db))
+	assert.NoError(t, migrateIDSequences(db))
+	next := &Flag{Key: "flag_after_id"}
+	assert.NoError(t, db.Create(next).Error)
+	assert.Equal(t, uint(1001), next.ID)
+}
+
+func TestMigrateDialectColumns(t *testing.T) {
+	t.Run("it should leave sqlite as it is", func(t *testing.T) {
+		db := PopulateTestDB(GenFixtureFlag())
+		defer db.Close()
+
+		assert.NoError(t, migrateTextColumns(db))
+		assert.NoError(t, migrateIDSequences(db))
+
+		f := &Flag{Key: "flag_after_fixture"}
+		assert.NoError(t, db.Create(f).Error)
+		assert.True(t, f.ID > 100)
+	})
+}
+
+func TestWithContext(t *testing.T) {
+	t.Run("it should query with the context", func(t *testing.T) {
+		db := PopulateTestDB(GenFixtureFlag())
+		defer db.Close()
+
+		f := &Flag{}
+		assert.NoError(t, WithContext(context.Background(), db).First(f).Error)
+		assert.Equal(t, uint(100), f.ID)
+	})
+
+	t.Run("it should stop querying once the context is done", func(t *testing.T) {
+		db := PopulateTestDB(GenFixtureFlag())
+		defer db.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cdb := WithContext(ctx, db)
+		assert.Equal(t, context.Canceled, cdb.First(&Flag{}).Error)
+		assert.Equal(t, context.Canceled, cdb.Begin().Error)
+	})
+
+	t.Run("it should keep assigning the snowflake ids", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.IDStrategy, IDStrategySnowflake).Stub(&config.Config.IDSnowflakeNode, 3).Reset()
+		db := NewTestDB()
+		defer db.Close()
+		assert.NoError(t, setupIDStrategy(db))
+
+		f := &Flag{Key: "flag_1"}
+		assert.NoError(t, WithContext(context.Background(), db).Create(f).Error)
+		assert.True(t, f.ID > 1<<32)
+	})
+
+	t.Run("it should leave the transactions as they are", func(t *testing.T) {
+		db := NewTestDB()
+		defer db.Close()
+
+		tx := db.Begin()
+		defer tx.Rollback()
+		assert.True(t, WithContext(context.Background(), tx) == tx)
+	})
+}
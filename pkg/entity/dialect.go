@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// migrateTextColumns widens the text columns to longtext in mysql, where text holds up to 64KB, which
+// the snapshots, the histories and the bitmaps of the big flags outgrow. text is unlimited in postgres and sqlite.
+// The auto migration of gorm never alters the existing columns, so they're altered here.
+func migrateTextColumns(db *gorm.DB) error {
+	if db.Dialect().GetName() != "mysql" {
+		return nil
+	}
+
+	for _, t := range AutoMigrateTables {
+		scope := db.NewScope(t)
+		for _, f := range scope.GetModelStruct().StructFields {
+			if f.IsIgnored || strings.ToLower(f.TagSettings["TYPE"]) != "text" {
+				continue
+			}
+			if err := widenTextColumn(db, scope.TableName(), f.DBName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func widenTextColumn(db *gorm.DB, table string, column string) error {
+	var dataType string
+	q := "SELECT data_type FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?"
+	if err := db.Raw(q, table, column).Row().Scan(&dataType); err != nil {
+		return fmt.Errorf("cannot find the type of column %s.%s. reason: %s", table, column, err)
+	}
+	if strings.ToLower(dataType) == "longtext" {
+		return nil
+	}
+
+	stmt := fmt.Sprintf("ALTER TABLE %s MODIFY %s LONGTEXT", db.Dialect().Quote(table), db.Dialect().Quote(column))
+	return db.Exec(stmt).Error
+}
+
+// migrateIDSequences moves the serial sequences of postgres past the largest IDs of their tables. Unlike
+// the auto increments of mysql and sqlite, the sequences don't follow the rows inserted with their IDs, e.g.
+// the data copied from another db or created with the snowflake IDs, and the next creates would reuse the IDs.
+func migrateIDSequences(db *gorm.DB) error {
+	if db.Dialect().GetName() != "postgres" {
+		return nil
+	}
+
+	for _, t := range AutoMigrateTables {
+		scope := db.NewScope(t)
+		f := scope.PrimaryField()
+		if f == nil || f.Struct.Type.Kind() != reflect.Uint {
+			continue
+		}
+		if err := syncIDSequence(db, scope.TableName(), f.DBName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncIDSequence(db *gorm.DB, table string, column string) error {
+	var sequence *string
+	if err := db.Raw("SELECT pg_get_serial_sequence(?, ?)", table, column).Row().Scan(&sequence); err != nil {
+		return fmt.Errorf("cannot find the sequence of column %s.%s. reason: %s", table, column, err)
+	}
+	if sequence == nil {
+		return nil
+	}
+
+	var maxID uint64
+	q := fmt.Sprintf("SELECT COALESCE(MAX(%s), 0) FROM %s", db.Dialect().Quote(column), db.Dialect().Quote(table))
+	if err := db.Raw(q).Row().Scan(&maxID); err != nil {
+		return fmt.Errorf("cannot find the max id of table %s. reason: %s", table, err)
+	}
+	if maxID == 0 {
+		return nil
+	}
+
+	// the sequences are 32-bit since postgres 10 if they're created for the serial columns
+	if maxID > math.MaxInt32 {
+		var version int
+		if err := db.Raw("SELECT current_setting('server_version_num')::int").Row().Scan(&version); err != nil {
+			return fmt.Errorf("cannot find the version of postgres. reason: %s", err)
+		}
+		if version >= 100000 {
+			if err := db.Exec(fmt.Sprintf("ALTER SEQUENCE %s AS BIGINT", *sequence)).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	// setval never moves the sequence backwards here, so the IDs of the deleted rows aren't reused
+	return db.Exec(
+		fmt.Sprintf("SELECT setval(?, GREATEST(?, (SELECT last_value FROM %s)))", *sequence),
+		*sequence, maxID,
+	).Error
+}
@@ -2,8 +2,8 @@ package entity
 
 import (
 	"fmt"
-	"hash/crc32"
 	"sort"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
@@ -26,6 +26,50 @@ type Distribution struct {
 
 	Percent uint   // Percent is an uint from 0 to 100, percent is always derived from Bitmap
 	Bitmap  string `sql:"type:text" json:"-"`
+
+	// RolloutStartAt, RolloutEndAt, RolloutStartPercent and RolloutEndPercent are the rollout schedule
+	// that ramps the percent over time, there's no schedule if RolloutEndAt is nil
+	RolloutStartAt      *time.Time
+	RolloutEndAt        *time.Time
+	RolloutStartPercent uint
+	RolloutEndPercent   uint
+}
+
+// HasRolloutSchedule tells if the percent of the distribution ramps over time
+func (d Distribution) HasRolloutSchedule() bool {
+	return d.RolloutEndAt != nil
+}
+
+// ValidateRolloutSchedule validates the rollout schedule if it's set
+func (d Distribution) ValidateRolloutSchedule() error {
+	if !d.HasRolloutSchedule() {
+		return nil
+	}
+	if d.RolloutStartAt == nil {
+		return fmt.Errorf("the rollout schedule of variant %s has no start time", d.VariantKey)
+	}
+	if !d.RolloutStartAt.Before(*d.RolloutEndAt) {
+		return fmt.Errorf("the rollout schedule of variant %s should start before it ends", d.VariantKey)
+	}
+	if d.RolloutStartPercent > 100 || d.RolloutEndPercent > 100 {
+		return fmt.Errorf("the rollout schedule percents of variant %s should be between 0 and 100", d.VariantKey)
+	}
+	return nil
+}
+
+// rolloutBucketsAt interpolates the percent of the rollout schedule at the time in the number of buckets,
+// so that it ramps by a tenth of a percent instead of a whole percent
+func (d Distribution) rolloutBucketsAt(now time.Time) int {
+	start := int(d.RolloutStartPercent * PercentMultiplier)
+	end := int(d.RolloutEndPercent * PercentMultiplier)
+	if !now.After(*d.RolloutStartAt) {
+		return start
+	}
+	if !now.Before(*d.RolloutEndAt) {
+		return end
+	}
+	elapsed := float64(now.Sub(*d.RolloutStartAt)) / float64(d.RolloutEndAt.Sub(*d.RolloutStartAt))
+	return start + int(float64(end-start)*elapsed)
 }
 
 // DistributionArray is useful for faster evalution
@@ -42,8 +86,8 @@ type DistributionDebugLog struct {
 	RolloutPercent    uint
 }
 
-// Rollout rolls out the entity based on the rolloutPercent
-func (d DistributionArray) Rollout(entityID string, salt string, rolloutPercent uint) (variantID *uint, msg string) {
+// Rollout rolls out the entity based on the rolloutPercent, the entity is bucketed by the hasher
+func (d DistributionArray) Rollout(hasher BucketingHasher, entityID string, salt string, rolloutPercent uint) (variantID *uint, msg string) {
 	if entityID == "" {
 		return nil, "rollout no. empty entityID"
 	}
@@ -56,7 +100,11 @@ func (d DistributionArray) Rollout(entityID string, salt string, rolloutPercent
 		return nil, "rollout no. there's no distribution set"
 	}
 
-	num := crc32Num(entityID, salt)
+	num := BucketNum(hasher, entityID, salt)
+	// the buckets are not all distributed when a ramping distribution is the only one
+	if int(num) >= d.PercentsAccumulated[len(d.PercentsAccumulated)-1] {
+		return nil, fmt.Sprintf("rollout no. bucket %v is out of the distributions", num)
+	}
 	vID, index := d.bucketByNum(num)
 	log := fmt.Sprintf("%+v", DistributionDebugLog{
 		BucketNum:         num,
@@ -71,6 +119,84 @@ func (d DistributionArray) Rollout(entityID string, salt string, rolloutPercent
 	return nil, "rollout no. " + log
 }
 
+// rampedDistributionArray puts the ramping distribution first, so that its buckets always start from 0
+// and the entities in it stay in it as it grows. The other distributions share the rest of the buckets
+// by their percents, or evenly if all their percents are 0, see allocateBuckets.
+func rampedDistributionArray(ramping Distribution, others []Distribution, now time.Time) DistributionArray {
+	rampingBuckets := ramping.rolloutBucketsAt(now)
+	d := DistributionArray{
+		VariantIDs:          []uint{ramping.VariantID},
+		PercentsAccumulated: []int{rampingBuckets},
+	}
+
+	percents := make([]uint, len(others))
+	for i, o := range others {
+		percents[i] = o.Percent
+	}
+	accumulated := rampingBuckets
+	for i, share := range allocateBuckets(percents, int(TotalBucketNum)-rampingBuckets) {
+		accumulated += share
+		d.VariantIDs = append(d.VariantIDs, others[i].VariantID)
+		d.PercentsAccumulated = append(d.PercentsAccumulated, accumulated)
+	}
+	return d
+}
+
+// allocateBuckets splits the buckets by the weights with the largest remainder method, so that the shares
+// always add up to exactly the buckets, whatever the number of weights. Every weight first gets the floor
+// of its exact share, then the leftover buckets go one by one to the largest fractional parts. The ties
+// go to the earlier weights, so the split only depends on the order of the distributions. The buckets
+// are split evenly if all the weights are 0.
+func allocateBuckets(weights []uint, buckets int) []int {
+	shares := make([]int, len(weights))
+	if len(weights) == 0 || buckets <= 0 {
+		return shares
+	}
+
+	total := 0
+	for _, w := range weights {
+		total += int(w)
+	}
+	even := total == 0
+	if even {
+		total = len(weights)
+	}
+	weight := func(i int) int {
+		if even {
+			return 1
+		}
+		return int(weights[i])
+	}
+
+	remainders := make([]int, len(weights))
+	allocated := 0
+	for i := range weights {
+		exact := buckets * weight(i)
+		shares[i] = exact / total
+		remainders[i] = exact % total
+		allocated += shares[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for _, i := range order[:buckets-allocated] {
+		shares[i]++
+	}
+	return shares
+}
+
+// VariantIDOfBucket returns the variant the bucket is distributed to, it's false if the bucket is out of the distributions
+func (d DistributionArray) VariantIDOfBucket(bucketNum uint) (variantID uint, ok bool) {
+	if len(d.PercentsAccumulated) == 0 || int(bucketNum) >= d.PercentsAccumulated[len(d.PercentsAccumulated)-1] {
+		return 0, false
+	}
+	variantID, _ = d.bucketByNum(bucketNum)
+	return variantID, true
+}
+
 func (d DistributionArray) bucketByNum(bucketNum uint) (variantID uint, index int) {
 	index = sort.SearchInts(d.PercentsAccumulated, int(bucketNum)+1)
 	return d.VariantIDs[index], index
@@ -96,8 +222,7 @@ func (d DistributionArray) rollout(bucketNum uint, rolloutPercent uint, index in
 	return 100*(bucketNum-uint(min)) <= uint(r)*rolloutPercent
 }
 
-func crc32Num(entityID string, salt string) uint {
-	// crc32 is good in terms of uniform distribution
-	// http://michiel.buddingh.eu/distribution-of-hash-values
-	return uint(crc32.ChecksumIEEE([]byte(salt+entityID))) % TotalBucketNum
+// BucketNum returns the bucket number the entityID falls into given the hasher and the salt
+func BucketNum(hasher BucketingHasher, entityID string, salt string) uint {
+	return uint(hasher.Hash([]byte(salt+entityID)) % uint64(TotalBucketNum))
 }
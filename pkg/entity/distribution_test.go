@@ -1,15 +1,17 @@
 package entity
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCRC32(t *testing.T) {
-	num1 := crc32Num("entity1", "salt1")
-	num2 := crc32Num("entity2", "salt1")
-	num3 := crc32Num("entity1", "salt1")
+	num1 := BucketNum(GetBucketingHasher(BucketingAlgorithmCRC32), "entity1", "salt1")
+	num2 := BucketNum(GetBucketingHasher(BucketingAlgorithmCRC32), "entity2", "salt1")
+	num3 := BucketNum(GetBucketingHasher(BucketingAlgorithmCRC32), "entity1", "salt1")
 	assert.Equal(t, num1, num3)
 	assert.NotEqual(t, num1, num2)
 }
@@ -113,6 +115,27 @@ func TestBucketByNum(t *testing.T) {
 	})
 }
 
+func TestVariantIDOfBucket(t *testing.T) {
+	d := DistributionArray{
+		VariantIDs:          []uint{1111, 2222},
+		PercentsAccumulated: []int{300, 600},
+	}
+
+	vID, ok := d.VariantIDOfBucket(299)
+	assert.True(t, ok)
+	assert.Equal(t, uint(1111), vID)
+
+	vID, ok = d.VariantIDOfBucket(300)
+	assert.True(t, ok)
+	assert.Equal(t, uint(2222), vID)
+
+	_, ok = d.VariantIDOfBucket(600)
+	assert.False(t, ok)
+
+	_, ok = DistributionArray{}.VariantIDOfBucket(0)
+	assert.False(t, ok)
+}
+
 func TestRollout(t *testing.T) {
 	d := DistributionArray{
 		VariantIDs:          []uint{1111, 2222},
@@ -147,19 +170,19 @@ func TestRolloutWithEntity(t *testing.T) {
 		var vID *uint
 		var msg string
 
-		vID, msg = d.Rollout("", "salt", uint(0))
+		vID, msg = d.Rollout(GetBucketingHasher(BucketingAlgorithmCRC32), "", "salt", uint(0))
 		assert.Nil(t, vID)
 		assert.Contains(t, msg, "no")
 
-		vID, msg = d.Rollout("entity123", "salt", uint(0))
+		vID, msg = d.Rollout(GetBucketingHasher(BucketingAlgorithmCRC32), "entity123", "salt", uint(0))
 		assert.Nil(t, vID)
 		assert.Contains(t, msg, "no")
 
-		vID, msg = d.Rollout("entity123", "salt", uint(100))
+		vID, msg = d.Rollout(GetBucketingHasher(BucketingAlgorithmCRC32), "entity123", "salt", uint(100))
 		assert.NotNil(t, vID)
 		assert.Contains(t, msg, "yes")
 
-		vID, msg = d.Rollout("entity123", "salt", uint(1))
+		vID, msg = d.Rollout(GetBucketingHasher(BucketingAlgorithmCRC32), "entity123", "salt", uint(1))
 		assert.Nil(t, vID)
 		assert.Contains(t, msg, "no")
 	})
@@ -172,8 +195,139 @@ func TestRolloutWithEntity(t *testing.T) {
 		var vID *uint
 		var msg string
 
-		vID, msg = d.Rollout("entity123", "salt", uint(100))
+		vID, msg = d.Rollout(GetBucketingHasher(BucketingAlgorithmCRC32), "entity123", "salt", uint(100))
 		assert.Nil(t, vID)
 		assert.Contains(t, msg, "no")
 	})
 }
+
+func TestValidateRolloutSchedule(t *testing.T) {
+	startAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(10 * time.Hour)
+
+	assert.NoError(t, Distribution{}.ValidateRolloutSchedule())
+	assert.NoError(t, Distribution{RolloutStartAt: &startAt, RolloutEndAt: &endAt, RolloutEndPercent: 100}.ValidateRolloutSchedule())
+	assert.Error(t, Distribution{RolloutEndAt: &endAt}.ValidateRolloutSchedule())
+	assert.Error(t, Distribution{RolloutStartAt: &endAt, RolloutEndAt: &startAt}.ValidateRolloutSchedule())
+	assert.Error(t, Distribution{RolloutStartAt: &startAt, RolloutEndAt: &endAt, RolloutEndPercent: 101}.ValidateRolloutSchedule())
+}
+
+func TestRolloutBucketsAt(t *testing.T) {
+	startAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(10 * time.Hour)
+	d := Distribution{RolloutStartAt: &startAt, RolloutEndAt: &endAt, RolloutStartPercent: 10, RolloutEndPercent: 60}
+
+	assert.Equal(t, 100, d.rolloutBucketsAt(startAt.Add(-time.Hour)))
+	assert.Equal(t, 100, d.rolloutBucketsAt(startAt))
+	assert.Equal(t, 350, d.rolloutBucketsAt(startAt.Add(5*time.Hour)))
+	assert.Equal(t, 600, d.rolloutBucketsAt(endAt))
+	assert.Equal(t, 600, d.rolloutBucketsAt(endAt.Add(time.Hour)))
+}
+
+func TestRampedDistributionArray(t *testing.T) {
+	startAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(10 * time.Hour)
+	ramping := Distribution{VariantID: 1111, RolloutStartAt: &startAt, RolloutEndAt: &endAt, RolloutEndPercent: 100}
+
+	t.Run("the others share the rest by their percents", func(t *testing.T) {
+		d := rampedDistributionArray(ramping, []Distribution{
+			{VariantID: 2222, Percent: 75},
+			{VariantID: 3333, Percent: 25},
+		}, startAt.Add(2*time.Hour))
+		assert.Equal(t, []uint{1111, 2222, 3333}, d.VariantIDs)
+		assert.Equal(t, []int{200, 800, 1000}, d.PercentsAccumulated)
+	})
+
+	t.Run("the others share the rest without leftover buckets", func(t *testing.T) {
+		d := rampedDistributionArray(ramping, []Distribution{
+			{VariantID: 2222, Percent: 33},
+			{VariantID: 3333, Percent: 33},
+			{VariantID: 4444, Percent: 34},
+		}, startAt.Add(30*time.Minute))
+		// 950 buckets make 313.5, 313.5 and 323 buckets, the tied leftover bucket goes to the first one
+		assert.Equal(t, []int{50, 364, 677, 1000}, d.PercentsAccumulated)
+	})
+
+	t.Run("the others share the rest evenly without percents", func(t *testing.T) {
+		d := rampedDistributionArray(ramping, []Distribution{
+			{VariantID: 2222},
+			{VariantID: 3333},
+		}, startAt.Add(5*time.Hour))
+		assert.Equal(t, []int{500, 750, 1000}, d.PercentsAccumulated)
+	})
+
+	t.Run("the entities in the ramp stay in it as it grows", func(t *testing.T) {
+		others := []Distribution{{VariantID: 2222, Percent: 100}}
+		rampedIn := map[string]bool{}
+		for h := 0; h <= 10; h++ {
+			d := rampedDistributionArray(ramping, others, startAt.Add(time.Duration(h)*time.Hour))
+			for i := 0; i < 200; i++ {
+				entityID := fmt.Sprintf("entity%d", i)
+				vID, _ := d.Rollout(GetBucketingHasher(BucketingAlgorithmCRC32), entityID, "salt", 100)
+				assert.NotNil(t, vID)
+				if rampedIn[entityID] {
+					assert.Equal(t, uint(1111), *vID)
+				}
+				rampedIn[entityID] = *vID == uint(1111)
+			}
+		}
+		assert.Len(t, rampedIn, 200)
+	})
+
+	t.Run("the buckets out of a lone ramping distribution are not rolled out", func(t *testing.T) {
+		d := rampedDistributionArray(ramping, nil, startAt)
+		assert.Equal(t, []int{0}, d.PercentsAccumulated)
+		vID, msg := d.Rollout(GetBucketingHasher(BucketingAlgorithmCRC32), "entity123", "salt", 100)
+		assert.Nil(t, vID)
+		assert.Contains(t, msg, "out of the distributions")
+	})
+}
+
+func TestAllocateBuckets(t *testing.T) {
+	sum := func(shares []int) int {
+		total := 0
+		for _, s := range shares {
+			total += s
+		}
+		return total
+	}
+
+	t.Run("1% each for 100 variants is split evenly without leftover buckets", func(t *testing.T) {
+		weights := make([]uint, 100)
+		for i := range weights {
+			weights[i] = 1
+		}
+		shares := allocateBuckets(weights, int(TotalBucketNum))
+		for _, s := range shares {
+			assert.Equal(t, 10, s)
+		}
+		assert.Equal(t, int(TotalBucketNum), sum(shares))
+	})
+
+	t.Run("the leftover buckets go to the largest remainders, and then to the earlier weights", func(t *testing.T) {
+		assert.Equal(t, []int{334, 333, 333}, allocateBuckets([]uint{1, 1, 1}, 1000))
+		assert.Equal(t, []int{143, 143, 143, 143, 143, 143, 142}, allocateBuckets([]uint{1, 1, 1, 1, 1, 1, 1}, 1000))
+		assert.Equal(t, []int{330, 330, 340}, allocateBuckets([]uint{33, 33, 34}, 1000))
+		assert.Equal(t, []int{314, 313, 323}, allocateBuckets([]uint{33, 33, 34}, 950))
+		assert.Equal(t, []int{1, 0, 2}, allocateBuckets([]uint{30, 10, 60}, 3))
+	})
+
+	t.Run("the buckets are split evenly without weights", func(t *testing.T) {
+		assert.Equal(t, []int{2, 2, 1}, allocateBuckets([]uint{0, 0, 0}, 5))
+		assert.Equal(t, []int{0, 5, 0}, allocateBuckets([]uint{0, 1, 0}, 5))
+		assert.Equal(t, []int{0, 0}, allocateBuckets([]uint{1, 1}, 0))
+		assert.Equal(t, []int{}, allocateBuckets(nil, 1000))
+	})
+
+	t.Run("the shares always add up to the buckets", func(t *testing.T) {
+		for n := 1; n <= 100; n++ {
+			weights := make([]uint, n)
+			for i := range weights {
+				weights[i] = uint((i*37 + n) % 11)
+			}
+			for _, buckets := range []int{1, 7, 999, 1000} {
+				assert.Equal(t, buckets, sum(allocateBuckets(weights, buckets)))
+			}
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ExclusionGroup is the shared bucketing space of mutually exclusive flags.
+// Each member flag gets ExclusionGroupPercent of the group's buckets, so that
+// an entity is routed to at most one of the members.
+type ExclusionGroup struct {
+	gorm.Model
+	Key         string `gorm:"type:varchar(64);unique_index:idx_exclusion_group_key"`
+	Description string `sql:"type:text"`
+}
+
+// ExclusionGroupBucketingSalt returns the salt of the group's bucketing hash, it's independent of the
+// member flags so that they all agree on the bucket of an entity. The group buckets the entities with
+// the global bucketing algorithm, not the ones of its members.
+func ExclusionGroupBucketingSalt(groupID uint) string {
+	return fmt.Sprintf("exclusion_group_%d", groupID)
+}
+
+// ExclusionGroupArray is the split of the group's buckets across the member flags
+type ExclusionGroupArray struct {
+	FlagIDs            []uint
+	BucketsAccumulated []int
+}
+
+// NewExclusionGroupArray splits the buckets across the members ordered by their IDs.
+// The disabled members keep their buckets, so toggling a member doesn't move the entities of the others.
+func NewExclusionGroupArray(members []*Flag) ExclusionGroupArray {
+	sorted := make([]*Flag, len(members))
+	copy(sorted, members)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	a := ExclusionGroupArray{}
+	accumulated := 0
+	for _, f := range sorted {
+		accumulated += int(f.ExclusionGroupPercent * PercentMultiplier)
+		a.FlagIDs = append(a.FlagIDs, f.ID)
+		a.BucketsAccumulated = append(a.BucketsAccumulated, accumulated)
+	}
+	return a
+}
+
+// FlagIDByBucketNum returns the member flag that the bucket is routed to, it's 0
+// if the bucket is out of all the members' percents
+func (a ExclusionGroupArray) FlagIDByBucketNum(bucketNum uint) uint {
+	index := sort.SearchInts(a.BucketsAccumulated, int(bucketNum)+1)
+	if index == len(a.FlagIDs) {
+		return 0
+	}
+	return a.FlagIDs[index]
+}
+
+// FindExclusionGroupMembers finds the member flags of the group ordered by their IDs
+func FindExclusionGroupMembers(db *gorm.DB, groupID uint) ([]Flag, error) {
+	fs := []Flag{}
+	err := db.Where(Flag{ExclusionGroupID: groupID}).Order("id").Find(&fs).Error
+	return fs, err
+}
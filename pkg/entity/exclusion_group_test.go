@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExclusionGroupArray(t *testing.T) {
+	a := NewExclusionGroupArray([]*Flag{
+		{Model: gorm.Model{ID: 102}, ExclusionGroupPercent: 30},
+		{Model: gorm.Model{ID: 101}, ExclusionGroupPercent: 50},
+	})
+	assert.Equal(t, []uint{101, 102}, a.FlagIDs)
+	assert.Equal(t, []int{500, 800}, a.BucketsAccumulated)
+
+	assert.Equal(t, uint(101), a.FlagIDByBucketNum(0))
+	assert.Equal(t, uint(101), a.FlagIDByBucketNum(499))
+	assert.Equal(t, uint(102), a.FlagIDByBucketNum(500))
+	assert.Equal(t, uint(102), a.FlagIDByBucketNum(799))
+	assert.Equal(t, uint(0), a.FlagIDByBucketNum(800))
+	assert.Equal(t, uint(0), ExclusionGroupArray{}.FlagIDByBucketNum(0))
+}
+
+func TestFindExclusionGroupMembers(t *testing.T) {
+	f := GenFixtureFlag()
+	f.ExclusionGroupID = 1
+	db := PopulateTestDB(f)
+	defer db.Close()
+
+	fs, err := FindExclusionGroupMembers(db, 1)
+	assert.NoError(t, err)
+	assert.Len(t, fs, 1)
+
+	fs, err = FindExclusionGroupMembers(db, 2)
+	assert.NoError(t, err)
+	assert.Len(t, fs, 0)
+}
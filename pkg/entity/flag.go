@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/checkr/flagr/pkg/util"
 	"github.com/jinzhu/gorm"
@@ -18,23 +19,55 @@ type Flag struct {
 	Enabled     bool
 	Segments    []Segment
 	Variants    []Variant
+	Tags        []Tag `gorm:"many2many:flags_tags;"`
 	SnapshotID  uint
 	Notes       string `sql:"type:text"`
 
 	DataRecordsEnabled bool
 	EntityType         string
+	BucketingSeed      string
+	BucketingAlgorithm string
+	BucketBy           string
+	DefaultVariantID   uint
+	ExpiresAt          *time.Time
+
+	// LastEvaluatedAt is flushed periodically from the evaluations, it's left out of
+	// the snapshots and the history so that the evaluations never show up as changes
+	LastEvaluatedAt *time.Time `json:"-"`
+
+	PrerequisiteFlagID     uint
+	PrerequisiteVariantKey string
+
+	AttachmentSchema string `sql:"type:text"`
+
+	Namespace string `gorm:"index:idx_flag_namespace"`
+
+	// ExclusionGroupID is the exclusion group of the flag, and ExclusionGroupPercent
+	// is the percent of the group's buckets routed to the flag
+	ExclusionGroupID      uint `gorm:"index:idx_flag_exclusiongroupid"`
+	ExclusionGroupPercent uint
+
+	// OverrideVariantID is the variant the flag evaluates to for every entity while
+	// OverrideEnabled is true, the segments are skipped. It's the kill switch of the flag.
+	OverrideEnabled   bool
+	OverrideVariantID uint
+
+	// Environments are the configs of the flag in the environments, the flag's own config
+	// is used in the evaluations without an environment or in the environments it has no config of
+	Environments []FlagEnvironment
 
 	FlagEvaluation FlagEvaluation `gorm:"-" json:"-"`
 }
 
 // FlagEvaluation is a struct that holds the necessary info for evaluation
 type FlagEvaluation struct {
-	VariantsMap map[uint]*Variant
+	VariantsMap  map[uint]*Variant
+	Environments map[string]*Flag
 }
 
 // PreloadSegmentsVariants preloads segments and variants for flag
 func PreloadSegmentsVariants(db *gorm.DB) *gorm.DB {
-	return db.
+	return PreloadEnvironments(db).
 		Preload("Segments", func(db *gorm.DB) *gorm.DB {
 			return PreloadConstraintsDistribution(db).
 				Order("rank ASC").
@@ -42,6 +75,9 @@ func PreloadSegmentsVariants(db *gorm.DB) *gorm.DB {
 		}).
 		Preload("Variants", func(db *gorm.DB) *gorm.DB {
 			return db.Order("id ASC")
+		}).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("value ASC")
 		})
 }
 
@@ -63,7 +99,22 @@ func (f *Flag) PrepareEvaluation() error {
 	for i := range f.Variants {
 		f.FlagEvaluation.VariantsMap[f.Variants[i].ID] = &f.Variants[i]
 	}
-	return nil
+	return f.prepareEnvironments()
+}
+
+// BucketingSalt returns the salt mixed into the bucketing hash of the flag.
+// It falls back to the flag ID so that existing flags keep their assignments.
+func (f *Flag) BucketingSalt() string {
+	if f.BucketingSeed != "" {
+		return f.BucketingSeed
+	}
+	return fmt.Sprint(f.ID)
+}
+
+// BucketingHasher returns the hasher of the bucketing algorithm of the flag,
+// it's the global one if the flag doesn't have its own algorithm
+func (f *Flag) BucketingHasher() BucketingHasher {
+	return GetBucketingHasher(f.BucketingAlgorithm)
 }
 
 // CreateFlagKey creates the key based on the given key
@@ -71,7 +122,7 @@ func CreateFlagKey(key string) (string, error) {
 	if key == "" {
 		key = util.NewSecureRandomKey()
 	} else {
-		ok, reason := util.IsSafeKey(key)
+		ok, reason := util.IsSafeFlagKey(key)
 		if !ok {
 			return "", fmt.Errorf("cannot create flag due to invalid key. reason: %s", reason)
 		}
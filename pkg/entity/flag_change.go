@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Types of the flag change
+const (
+	FlagChangeTypeEnabled       = "enabled"
+	FlagChangeTypeDistributions = "distributions"
+)
+
+// Statuses of the flag change
+const (
+	FlagChangeStatusPending  = "pending"
+	FlagChangeStatusApproved = "approved"
+)
+
+// FlagChange is a change of a flag pending the approval of another user than its requester, it's applied
+// once it's approved. It's either the enabled state of the flag, or the distributions of one of its segments,
+// in the environment if it's set.
+type FlagChange struct {
+	gorm.Model
+	FlagID        uint `gorm:"index:idx_flagchange_flagid"`
+	Type          string
+	Environment   string
+	Enabled       bool
+	SegmentID     uint
+	Distributions []byte `sql:"type:text"`
+	Note          string
+	Status        string
+	RequestedBy   string
+	ApprovedBy    string
+	ApprovedAt    *time.Time
+}
+
+// SetDistributions sets the distributions of the change
+func (c *FlagChange) SetDistributions(ds []Distribution) error {
+	b, err := json.Marshal(ds)
+	if err != nil {
+		return err
+	}
+	c.Distributions = b
+	return nil
+}
+
+// GetDistributions gets the distributions of the change, they're empty if it's not a change of the distributions
+func (c *FlagChange) GetDistributions() ([]Distribution, error) {
+	ds := []Distribution{}
+	if len(c.Distributions) == 0 {
+		return ds, nil
+	}
+	if err := json.Unmarshal(c.Distributions, &ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
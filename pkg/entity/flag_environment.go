@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// FlagEnvironment is the config of the flag in an environment, e.g. dev, staging or prod. In the evaluations
+// of the environment, it overrides the enabled state of the flag and the distributions of its segments.
+type FlagEnvironment struct {
+	gorm.Model
+	FlagID        uint   `gorm:"unique_index:idx_flag_environment"`
+	Environment   string `gorm:"type:varchar(64);unique_index:idx_flag_environment"`
+	Enabled       bool
+	Distributions []FlagEnvironmentDistribution
+}
+
+// FlagEnvironmentDistribution is a distribution of a segment in the environment,
+// the segments without any distribution in the environment keep their own
+type FlagEnvironmentDistribution struct {
+	gorm.Model
+	FlagEnvironmentID uint `gorm:"index:idx_flagenvironmentdistribution_flagenvironmentid"`
+	SegmentID         uint
+	VariantID         uint
+	VariantKey        string
+	Percent           uint
+}
+
+// SegmentDistributions gets the distributions of the segment in the environment,
+// it's nil if the segment keeps its own distributions
+func (fe *FlagEnvironment) SegmentDistributions(segmentID uint) []Distribution {
+	var ds []Distribution
+	for _, d := range fe.Distributions {
+		if d.SegmentID != segmentID {
+			continue
+		}
+		ds = append(ds, Distribution{
+			Model:      gorm.Model{ID: d.ID},
+			SegmentID:  d.SegmentID,
+			VariantID:  d.VariantID,
+			VariantKey: d.VariantKey,
+			Percent:    d.Percent,
+		})
+	}
+	return ds
+}
+
+// PreloadEnvironments preloads the environments of the flags with their distributions
+func PreloadEnvironments(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Environments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("environment ASC")
+		}).
+		Preload("Environments.Distributions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("variant_id ASC")
+		})
+}
+
+// Environment gets the config of the flag in the environment, it's nil if the flag has none
+func (f *Flag) Environment(env string) *FlagEnvironment {
+	if env == "" {
+		return nil
+	}
+	for i := range f.Environments {
+		if f.Environments[i].Environment == env {
+			return &f.Environments[i]
+		}
+	}
+	return nil
+}
+
+// InEnvironment gets the flag as it's configured in the environment, i.e. with the enabled state and the
+// distributions of the environment. It's the flag itself if it has no config of the environment, and it's
+// the prepared one of FlagEvaluation once the flag is prepared for the evaluation.
+func (f *Flag) InEnvironment(env string) *Flag {
+	if ef, ok := f.FlagEvaluation.Environments[env]; ok {
+		return ef
+	}
+	fe := f.Environment(env)
+	if fe == nil {
+		return f
+	}
+
+	ef := *f
+	ef.Enabled = fe.Enabled
+	ef.Segments = make([]Segment, len(f.Segments))
+	for i, s := range f.Segments {
+		ef.Segments[i] = s
+		if ds := fe.SegmentDistributions(s.ID); ds != nil {
+			ef.Segments[i].Distributions = ds
+		}
+	}
+	return &ef
+}
+
+// prepareEnvironments prepares the flag of every environment for the evaluation, they share the variants
+func (f *Flag) prepareEnvironments() error {
+	if len(f.Environments) == 0 {
+		return nil
+	}
+	envs := make(map[string]*Flag, len(f.Environments))
+	for _, fe := range f.Environments {
+		ef := f.InEnvironment(fe.Environment)
+		for i := range ef.Segments {
+			if err := ef.Segments[i].PrepareEvaluation(); err != nil {
+				return err
+			}
+		}
+		envs[fe.Environment] = ef
+	}
+	for _, ef := range envs {
+		ef.FlagEvaluation.Environments = envs
+	}
+	f.FlagEvaluation.Environments = envs
+	return nil
+}
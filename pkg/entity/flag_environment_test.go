@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagInEnvironment(t *testing.T) {
+	genFlag := func() Flag {
+		f := GenFixtureFlag()
+		f.Environments = []FlagEnvironment{
+			{FlagID: 100, Environment: "dev", Enabled: false},
+			{
+				FlagID:      100,
+				Environment: "prod",
+				Enabled:     true,
+				Distributions: []FlagEnvironmentDistribution{
+					{SegmentID: 200, VariantID: 301, VariantKey: "treatment", Percent: 100},
+				},
+			},
+		}
+		return f
+	}
+
+	t.Run("it's the flag itself without the config of the environment", func(t *testing.T) {
+		f := genFlag()
+		assert.True(t, f.InEnvironment("") == &f)
+		assert.True(t, f.InEnvironment("staging") == &f)
+	})
+
+	t.Run("it overrides the enabled state and the distributions of the environment", func(t *testing.T) {
+		f := genFlag()
+		dev := f.InEnvironment("dev")
+		assert.False(t, dev.Enabled)
+		assert.Len(t, dev.Segments[0].Distributions, 2)
+
+		prod := f.InEnvironment("prod")
+		assert.True(t, prod.Enabled)
+		assert.Len(t, prod.Segments[0].Distributions, 1)
+		assert.Equal(t, uint(301), prod.Segments[0].Distributions[0].VariantID)
+		assert.Len(t, f.Segments[0].Distributions, 2)
+	})
+
+	t.Run("it prepares the flags of the environments for the evaluation", func(t *testing.T) {
+		f := genFlag()
+		assert.NoError(t, f.PrepareEvaluation())
+		assert.Len(t, f.FlagEvaluation.Environments, 2)
+
+		prod := f.InEnvironment("prod")
+		assert.True(t, prod == f.InEnvironment("prod"))
+		assert.True(t, prod == prod.InEnvironment("prod"))
+		assert.Equal(t, []uint{301}, prod.Segments[0].SegmentEvaluation.DistributionArray.VariantIDs)
+		assert.Equal(t, []uint{300, 301}, f.Segments[0].SegmentEvaluation.DistributionArray.VariantIDs)
+		assert.NotNil(t, prod.FlagEvaluation.VariantsMap[301])
+	})
+}
+
+func TestFlagPreloadEnvironments(t *testing.T) {
+	f := GenFixtureFlag()
+	db := PopulateTestDB(f)
+	defer db.Close()
+
+	fe := FlagEnvironment{
+		FlagID:        100,
+		Environment:   "prod",
+		Enabled:       true,
+		Distributions: []FlagEnvironmentDistribution{{SegmentID: 200, VariantID: 300, VariantKey: "control", Percent: 100}},
+	}
+	assert.NoError(t, db.Create(&fe).Error)
+
+	loaded := Flag{}
+	loaded.ID = 100
+	assert.NoError(t, loaded.Preload(db))
+	assert.Len(t, loaded.Environments, 1)
+	assert.Len(t, loaded.Environment("prod").Distributions, 1)
+	assert.Nil(t, loaded.Environment("dev"))
+}
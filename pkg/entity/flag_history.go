@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// Actions of the flag history
+const (
+	FlagHistoryActionCreate = "create"
+	FlagHistoryActionUpdate = "update"
+	FlagHistoryActionDelete = "delete"
+)
+
+// Entity types of the flag history
+const (
+	FlagHistoryEntityTypeFlag            = "flag"
+	FlagHistoryEntityTypeSegment         = "segment"
+	FlagHistoryEntityTypeConstraint      = "constraint"
+	FlagHistoryEntityTypeDistribution    = "distribution"
+	FlagHistoryEntityTypeEnvironment     = "environment"
+	FlagHistoryEntityTypeConstraintGroup = "constraint_group"
+)
+
+// flagHistoryIgnoredFields are the fields that change on every write,
+// they are bookkeeping rather than the changes made by the actor
+var flagHistoryIgnoredFields = map[string]bool{
+	"CreatedAt":  true,
+	"UpdatedAt":  true,
+	"UpdatedBy":  true,
+	"SnapshotID": true,
+}
+
+// FlagHistoryHook is called with every saved flag history if it's set, for example, to notify the flag changes
+var FlagHistoryHook func(fh *FlagHistory)
+
+// FlagHistory is the audit trail of a flag.
+// Every create, update and delete of the flag, its segments, constraints and
+// distributions creates a new history record with the actor and the diff.
+type FlagHistory struct {
+	gorm.Model
+	FlagID     uint `gorm:"index:idx_flaghistory_flagid"`
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uint
+	Diff       []byte `sql:"type:text"`
+	Note       string `sql:"type:text"`
+}
+
+// FlagHistoryFieldDiff is the before and after values of a changed field
+type FlagHistoryFieldDiff struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// DiffFlagHistory returns the changed top level fields between before and after.
+// A nil before means the entity is created, and a nil after means it's deleted.
+func DiffFlagHistory(before interface{}, after interface{}) (map[string]FlagHistoryFieldDiff, error) {
+	b, err := toFlagHistoryFields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toFlagHistoryFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]FlagHistoryFieldDiff)
+	for k, bv := range b {
+		if av, ok := a[k]; (!ok && bv != nil) || (ok && !reflect.DeepEqual(bv, av)) {
+			diff[k] = FlagHistoryFieldDiff{Before: bv, After: a[k]}
+		}
+	}
+	for k, av := range a {
+		if _, ok := b[k]; !ok && av != nil {
+			diff[k] = FlagHistoryFieldDiff{Before: nil, After: av}
+		}
+	}
+	return diff, nil
+}
+
+func isNilFlagHistoryEntity(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func toFlagHistoryFields(v interface{}) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	if isNilFlagHistoryEntity(v) {
+		return m, nil
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &m); err != nil {
+		return nil, err
+	}
+	for k := range flagHistoryIgnoredFields {
+		delete(m, k)
+	}
+	return m, nil
+}
+
+// SaveFlagHistory saves the history of a change made by the actor.
+// before and after should be pointers of the entity, nil before means
+// the entity is created, and nil after means it's deleted.
+func SaveFlagHistory(db *gorm.DB, flagID uint, actor string, entityType string, entityID uint, before interface{}, after interface{}) {
+	SaveFlagHistoryWithNote(db, flagID, actor, "", entityType, entityID, before, after)
+}
+
+// SaveFlagHistoryWithNote is SaveFlagHistory with the rationale of the change given by the actor
+func SaveFlagHistoryWithNote(db *gorm.DB, flagID uint, actor string, note string, entityType string, entityID uint, before interface{}, after interface{}) {
+	action := FlagHistoryActionUpdate
+	if isNilFlagHistoryEntity(before) {
+		action = FlagHistoryActionCreate
+	} else if isNilFlagHistoryEntity(after) {
+		action = FlagHistoryActionDelete
+	}
+
+	logFields := logrus.Fields{
+		"flagID":     flagID,
+		"entityType": entityType,
+		"entityID":   entityID,
+	}
+
+	diff, err := DiffFlagHistory(before, after)
+	if err != nil {
+		logFields["err"] = err
+		logrus.WithFields(logFields).Error("failed to diff the entity when SaveFlagHistory")
+		return
+	}
+	if action == FlagHistoryActionUpdate && len(diff) == 0 {
+		return
+	}
+
+	b, err := json.Marshal(diff)
+	if err != nil {
+		logFields["err"] = err
+		logrus.WithFields(logFields).Error("failed to marshal the diff into JSON when SaveFlagHistory")
+		return
+	}
+
+	fh := &FlagHistory{
+		FlagID:     flagID,
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Diff:       b,
+		Note:       note,
+	}
+	if err := db.Create(fh).Error; err != nil {
+		logFields["err"] = err
+		logrus.WithFields(logFields).Error("failed to save FlagHistory")
+		return
+	}
+	if FlagHistoryHook != nil {
+		FlagHistoryHook(fh)
+	}
+}
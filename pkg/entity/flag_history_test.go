@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffFlagHistory(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		diff, err := DiffFlagHistory(nil, &Segment{Description: "s1", RolloutPercent: 50})
+		assert.NoError(t, err)
+		assert.Equal(t, FlagHistoryFieldDiff{Before: nil, After: "s1"}, diff["Description"])
+		assert.NotContains(t, diff, "UpdatedAt")
+	})
+
+	t.Run("update only returns the changed fields", func(t *testing.T) {
+		diff, err := DiffFlagHistory(
+			&Segment{Description: "s1", RolloutPercent: 50},
+			&Segment{Description: "s1", RolloutPercent: 100},
+		)
+		assert.NoError(t, err)
+		assert.Len(t, diff, 1)
+		assert.Equal(t, FlagHistoryFieldDiff{Before: float64(50), After: float64(100)}, diff["RolloutPercent"])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		diff, err := DiffFlagHistory(&Constraint{Property: "state"}, (*Constraint)(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, FlagHistoryFieldDiff{Before: "state", After: nil}, diff["Property"])
+	})
+}
+
+func TestSaveFlagHistory(t *testing.T) {
+	f := GenFixtureFlag()
+	db := PopulateTestDB(f)
+	defer db.Close()
+
+	t.Run("happy code path", func(t *testing.T) {
+		before := f
+		after := f
+		after.Enabled = !f.Enabled
+		SaveFlagHistory(db, f.ID, "flagr-test@example.com", FlagHistoryEntityTypeFlag, f.ID, &before, &after)
+
+		fh := &FlagHistory{}
+		assert.NoError(t, db.Where(FlagHistory{FlagID: f.ID}).First(fh).Error)
+		assert.Equal(t, "flagr-test@example.com", fh.Actor)
+		assert.Equal(t, FlagHistoryActionUpdate, fh.Action)
+		assert.Contains(t, string(fh.Diff), "Enabled")
+	})
+
+	t.Run("no-op update is not saved", func(t *testing.T) {
+		s := f.Segments[0]
+		SaveFlagHistory(db, f.ID, "flagr-test@example.com", FlagHistoryEntityTypeSegment, s.ID, &s, &s)
+
+		count := 0
+		db.Model(&FlagHistory{}).Where(FlagHistory{EntityType: FlagHistoryEntityTypeSegment}).Count(&count)
+		assert.Zero(t, count)
+	})
+}
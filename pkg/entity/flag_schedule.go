@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Actions of the flag schedule
+const (
+	FlagScheduleActionEnable  = "enable"
+	FlagScheduleActionDisable = "disable"
+)
+
+// FlagSchedule is a pending enable or disable of a flag at ScheduledAt,
+// it's deleted once the action is applied
+type FlagSchedule struct {
+	gorm.Model
+	FlagID      uint `gorm:"index:idx_flagschedule_flagid"`
+	Action      string
+	ScheduledAt time.Time `gorm:"index:idx_flagschedule_scheduledat"`
+	CreatedBy   string
+}
+
+// Enabled returns the enabled state of the flag after the action is applied
+func (s *FlagSchedule) Enabled() bool {
+	return s.Action == FlagScheduleActionEnable
+}
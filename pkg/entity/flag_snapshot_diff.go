@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Change types of the variants, segments, constraints and distributions in the snapshot diff
+const (
+	FlagSnapshotDiffAdded   = "added"
+	FlagSnapshotDiffRemoved = "removed"
+	FlagSnapshotDiffChanged = "changed"
+)
+
+// FlagSnapshotEntityDiff is the change of a variant, segment, constraint or distribution, they are matched by ID
+type FlagSnapshotEntityDiff struct {
+	ID         uint
+	ChangeType string
+	Fields     map[string]FlagHistoryFieldDiff
+}
+
+// FlagSnapshotSegmentDiff is the change of a segment and its constraints and distributions.
+// A segment with changed constraints or distributions is changed even if its own fields are not.
+type FlagSnapshotSegmentDiff struct {
+	FlagSnapshotEntityDiff
+	Constraints   []FlagSnapshotEntityDiff
+	Distributions []FlagSnapshotEntityDiff
+}
+
+// FlagSnapshotDiff is the structured diff between two snapshots of a flag
+type FlagSnapshotDiff struct {
+	Flag     map[string]FlagHistoryFieldDiff
+	Variants []FlagSnapshotEntityDiff
+	Segments []FlagSnapshotSegmentDiff
+}
+
+// flagSnapshotDiffIgnoredFields are the fields diffed on their own, or the ones that never differ for the same entity
+var flagSnapshotDiffIgnoredFields = []string{"ID", "DeletedAt", "Segments", "Variants", "Constraints", "Distributions"}
+
+// DiffFlagSnapshots returns the changes to get from the flag in snapshot from to the one in snapshot to.
+// Either of them can be the older one, swapping them reverses the diff.
+func DiffFlagSnapshots(from *FlagSnapshot, to *FlagSnapshot) (*FlagSnapshotDiff, error) {
+	before, after := &Flag{}, &Flag{}
+	if err := json.Unmarshal(from.Flag, before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(to.Flag, after); err != nil {
+		return nil, err
+	}
+
+	flagFields, err := diffFlagSnapshotFields(flagSnapshotFields(before), flagSnapshotFields(after))
+	if err != nil {
+		return nil, err
+	}
+	d := &FlagSnapshotDiff{Flag: flagFields, Variants: []FlagSnapshotEntityDiff{}, Segments: []FlagSnapshotSegmentDiff{}}
+
+	beforeVariants, afterVariants := make(map[uint]interface{}), make(map[uint]interface{})
+	for i := range before.Variants {
+		beforeVariants[before.Variants[i].ID] = &before.Variants[i]
+	}
+	for i := range after.Variants {
+		afterVariants[after.Variants[i].ID] = &after.Variants[i]
+	}
+	if d.Variants, err = diffFlagSnapshotEntities(beforeVariants, afterVariants); err != nil {
+		return nil, err
+	}
+
+	beforeSegments, afterSegments := make(map[uint]interface{}), make(map[uint]interface{})
+	for i := range before.Segments {
+		beforeSegments[before.Segments[i].ID] = &before.Segments[i]
+	}
+	for i := range after.Segments {
+		afterSegments[after.Segments[i].ID] = &after.Segments[i]
+	}
+	for _, id := range flagSnapshotDiffIDs(beforeSegments, afterSegments) {
+		b, _ := beforeSegments[id].(*Segment)
+		a, _ := afterSegments[id].(*Segment)
+		sd, err := diffFlagSnapshotSegment(id, b, a)
+		if err != nil {
+			return nil, err
+		}
+		if sd != nil {
+			d.Segments = append(d.Segments, *sd)
+		}
+	}
+	return d, nil
+}
+
+// flagSnapshotFields replaces the tags of the flag with their values, the IDs of the tags are not meaningful to diff
+func flagSnapshotFields(f *Flag) interface{} {
+	m := make(map[string]interface{})
+	bs, _ := json.Marshal(f)
+	json.Unmarshal(bs, &m)
+
+	tags := make([]string, len(f.Tags))
+	for i, t := range f.Tags {
+		tags[i] = t.Value
+	}
+	sort.Strings(tags)
+	m["Tags"] = tags
+	return m
+}
+
+func diffFlagSnapshotSegment(id uint, before *Segment, after *Segment) (*FlagSnapshotSegmentDiff, error) {
+	var b, a interface{}
+	beforeConstraints, afterConstraints := make(map[uint]interface{}), make(map[uint]interface{})
+	beforeDistributions, afterDistributions := make(map[uint]interface{}), make(map[uint]interface{})
+	if before != nil {
+		b = before
+		for i := range before.Constraints {
+			beforeConstraints[before.Constraints[i].ID] = &before.Constraints[i]
+		}
+		for i := range before.Distributions {
+			beforeDistributions[before.Distributions[i].ID] = &before.Distributions[i]
+		}
+	}
+	if after != nil {
+		a = after
+		for i := range after.Constraints {
+			afterConstraints[after.Constraints[i].ID] = &after.Constraints[i]
+		}
+		for i := range after.Distributions {
+			afterDistributions[after.Distributions[i].ID] = &after.Distributions[i]
+		}
+	}
+
+	fields, err := diffFlagSnapshotFields(b, a)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err := diffFlagSnapshotEntities(beforeConstraints, afterConstraints)
+	if err != nil {
+		return nil, err
+	}
+	distributions, err := diffFlagSnapshotEntities(beforeDistributions, afterDistributions)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 && len(constraints) == 0 && len(distributions) == 0 {
+		return nil, nil
+	}
+
+	return &FlagSnapshotSegmentDiff{
+		FlagSnapshotEntityDiff: FlagSnapshotEntityDiff{ID: id, ChangeType: flagSnapshotChangeType(b, a), Fields: fields},
+		Constraints:            constraints,
+		Distributions:          distributions,
+	}, nil
+}
+
+// diffFlagSnapshotEntities diffs the entities matched by ID, the unchanged ones are left out
+func diffFlagSnapshotEntities(before map[uint]interface{}, after map[uint]interface{}) ([]FlagSnapshotEntityDiff, error) {
+	diffs := []FlagSnapshotEntityDiff{}
+	for _, id := range flagSnapshotDiffIDs(before, after) {
+		fields, err := diffFlagSnapshotFields(before[id], after[id])
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		diffs = append(diffs, FlagSnapshotEntityDiff{ID: id, ChangeType: flagSnapshotChangeType(before[id], after[id]), Fields: fields})
+	}
+	return diffs, nil
+}
+
+func diffFlagSnapshotFields(before interface{}, after interface{}) (map[string]FlagHistoryFieldDiff, error) {
+	diff, err := DiffFlagHistory(before, after)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range flagSnapshotDiffIgnoredFields {
+		delete(diff, k)
+	}
+	return diff, nil
+}
+
+func flagSnapshotChangeType(before interface{}, after interface{}) string {
+	switch {
+	case isNilFlagHistoryEntity(before):
+		return FlagSnapshotDiffAdded
+	case isNilFlagHistoryEntity(after):
+		return FlagSnapshotDiffRemoved
+	default:
+		return FlagSnapshotDiffChanged
+	}
+}
+
+// flagSnapshotDiffIDs returns the sorted IDs in either of the maps, so that the diff is stable
+func flagSnapshotDiffIDs(before map[uint]interface{}, after map[uint]interface{}) []uint {
+	ids := []uint{}
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func genFixtureFlagSnapshot(t *testing.T, f Flag) *FlagSnapshot {
+	b, err := json.Marshal(f)
+	assert.NoError(t, err)
+	return &FlagSnapshot{FlagID: f.ID, Flag: b}
+}
+
+func TestDiffFlagSnapshots(t *testing.T) {
+	before := GenFixtureFlag()
+	after := GenFixtureFlag()
+	after.Enabled = false
+	after.Tags = []Tag{{Value: "beta"}}
+	after.Variants = after.Variants[:1]
+	after.Segments[0].Constraints = ConstraintArray{}
+	after.Segments[0].Distributions[0].Percent = 20
+	after.Segments[0].Distributions[1].Percent = 80
+	after.Segments = append(after.Segments, Segment{Model: gorm.Model{ID: 201}, FlagID: 100, RolloutPercent: 10})
+
+	from, to := genFixtureFlagSnapshot(t, before), genFixtureFlagSnapshot(t, after)
+
+	t.Run("it should diff the flag, variants, segments, constraints and distributions", func(t *testing.T) {
+		d, err := DiffFlagSnapshots(from, to)
+		assert.NoError(t, err)
+
+		assert.Equal(t, FlagHistoryFieldDiff{Before: true, After: false}, d.Flag["Enabled"])
+		assert.Equal(t, []interface{}{"beta"}, d.Flag["Tags"].After)
+		assert.NotContains(t, d.Flag, "Segments")
+		assert.NotContains(t, d.Flag, "Variants")
+
+		assert.Len(t, d.Variants, 1)
+		assert.Equal(t, uint(301), d.Variants[0].ID)
+		assert.Equal(t, FlagSnapshotDiffRemoved, d.Variants[0].ChangeType)
+
+		assert.Len(t, d.Segments, 2)
+		s := d.Segments[0]
+		assert.Equal(t, uint(200), s.ID)
+		assert.Equal(t, FlagSnapshotDiffChanged, s.ChangeType)
+		assert.Empty(t, s.Fields)
+		assert.Len(t, s.Constraints, 1)
+		assert.Equal(t, FlagSnapshotDiffRemoved, s.Constraints[0].ChangeType)
+		assert.Len(t, s.Distributions, 2)
+		assert.Equal(t, uint(400), s.Distributions[0].ID)
+		assert.Equal(t, FlagHistoryFieldDiff{Before: float64(50), After: float64(20)}, s.Distributions[0].Fields["Percent"])
+
+		assert.Equal(t, uint(201), d.Segments[1].ID)
+		assert.Equal(t, FlagSnapshotDiffAdded, d.Segments[1].ChangeType)
+		assert.Equal(t, float64(10), d.Segments[1].Fields["RolloutPercent"].After)
+	})
+
+	t.Run("it should reverse the diff when the snapshots are swapped", func(t *testing.T) {
+		d, err := DiffFlagSnapshots(to, from)
+		assert.NoError(t, err)
+		assert.Equal(t, FlagHistoryFieldDiff{Before: false, After: true}, d.Flag["Enabled"])
+		assert.Equal(t, FlagSnapshotDiffAdded, d.Variants[0].ChangeType)
+		assert.Equal(t, FlagSnapshotDiffRemoved, d.Segments[1].ChangeType)
+		assert.Equal(t, FlagSnapshotDiffAdded, d.Segments[0].Constraints[0].ChangeType)
+	})
+
+	t.Run("it should return an empty diff for the same snapshot", func(t *testing.T) {
+		d, err := DiffFlagSnapshots(from, from)
+		assert.NoError(t, err)
+		assert.Empty(t, d.Flag)
+		assert.Empty(t, d.Variants)
+		assert.Empty(t, d.Segments)
+	})
+
+	t.Run("it should fail on a broken snapshot", func(t *testing.T) {
+		_, err := DiffFlagSnapshots(from, &FlagSnapshot{Flag: []byte("{")})
+		assert.Error(t, err)
+	})
+}
@@ -25,6 +25,19 @@ func TestFlagPreload(t *testing.T) {
 	})
 }
 
+func TestFlagBucketingSalt(t *testing.T) {
+	t.Run("default to flag ID", func(t *testing.T) {
+		f := GenFixtureFlag()
+		assert.Equal(t, "100", f.BucketingSalt())
+	})
+
+	t.Run("use bucketingSeed if it's set", func(t *testing.T) {
+		f := GenFixtureFlag()
+		f.BucketingSeed = "seed1"
+		assert.Equal(t, "seed1", f.BucketingSalt())
+	})
+}
+
 func TestCreateFlagKey(t *testing.T) {
 	t.Run("happy code path", func(t *testing.T) {
 		key, err := CreateFlagKey("")
@@ -32,6 +45,12 @@ func TestCreateFlagKey(t *testing.T) {
 		assert.NotZero(t, key)
 	})
 
+	t.Run("slug key", func(t *testing.T) {
+		key, err := CreateFlagKey("checkout-redesign")
+		assert.NoError(t, err)
+		assert.Equal(t, "checkout-redesign", key)
+	})
+
 	t.Run("invalid key", func(t *testing.T) {
 		key, err := CreateFlagKey("1-2-3")
 		assert.Error(t, err)
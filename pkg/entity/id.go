@@ -0,0 +1,190 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/jinzhu/gorm"
+)
+
+// The strategies of generating the IDs of the new entities, see config.Config.IDStrategy
+const (
+	IDStrategyIncrement = "increment"
+	IDStrategyUUID      = "uuid"
+	IDStrategySnowflake = "snowflake"
+)
+
+// maxJSONSafeID is the largest ID the JavaScript clients, like the UI, parse exactly
+const maxJSONSafeID = 1<<53 - 1
+
+// nextRandomID generates the random IDs of the uuid strategy. They're the random bits of the version 4 UUIDs,
+// kept within 53 bits because the IDs are integers in the API, so they don't leak the order or the count of
+// the entities. A collision fails the create like a duplicate key, one in about 10^5 for a million entities.
+func nextRandomID() uint {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("cannot read the random id. reason: %s", err))
+	}
+	id := binary.BigEndian.Uint64(b) & maxJSONSafeID
+	if id == 0 {
+		return nextRandomID()
+	}
+	return uint(id)
+}
+
+// The snowflake IDs are the milliseconds since snowflakeEpoch followed by the node and a sequence within
+// the millisecond. They're kept within 53 bits so that the JavaScript clients, like the UI, parse them exactly,
+// and the 41 bits of milliseconds last until 2088.
+const (
+	snowflakeNodeBits     = 4
+	snowflakeSequenceBits = 8
+	snowflakeMaxNode      = 1<<snowflakeNodeBits - 1
+	snowflakeSequenceMask = 1<<snowflakeSequenceBits - 1
+)
+
+var snowflakeEpoch = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// snowflakeGenerator generates the increasing IDs of a node. When the sequence of a millisecond
+// runs out, or the clock goes backwards, it carries on with the following milliseconds.
+type snowflakeGenerator struct {
+	mu       sync.Mutex
+	node     uint64
+	lastMs   int64
+	sequence uint64
+	now      func() time.Time
+}
+
+func newSnowflakeGenerator(node uint) (*snowflakeGenerator, error) {
+	if node > snowflakeMaxNode {
+		return nil, fmt.Errorf("invalid snowflake node %v. it should be between 0 and %v", node, snowflakeMaxNode)
+	}
+	return &snowflakeGenerator{node: uint64(node), now: time.Now}, nil
+}
+
+func (g *snowflakeGenerator) NextID() uint {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := int64(g.now().Sub(snowflakeEpoch) / time.Millisecond)
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		g.sequence = (g.sequence + 1) & snowflakeSequenceMask
+		if g.sequence == 0 {
+			ms++
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastMs = ms
+	return uint(uint64(ms)<<(snowflakeNodeBits+snowflakeSequenceBits) | g.node<<snowflakeSequenceBits | g.sequence)
+}
+
+// nextIDSetting keeps the ID generator in the settings of db, so that the dbs bound to a context can assign them too
+const nextIDSetting = "flagr:next_id"
+
+// setupIDStrategy makes the creates of db assign the IDs of the strategy in config.Config.IDStrategy
+func setupIDStrategy(db *gorm.DB) error {
+	switch config.Config.IDStrategy {
+	case "", IDStrategyIncrement:
+		return nil
+	case IDStrategyUUID:
+		db.InstantSet(nextIDSetting, nextRandomID)
+		registerAssignID(db, nextRandomID)
+		return nil
+	case IDStrategySnowflake:
+		// the replicas sharing a node would generate the same ids in the same millisecond
+		if config.Config.IDSnowflakeNode < 0 {
+			return fmt.Errorf("the snowflake id strategy needs FLAGR_ID_SNOWFLAKE_NODE, a different one for every replica")
+		}
+		g, err := newSnowflakeGenerator(uint(config.Config.IDSnowflakeNode))
+		if err != nil {
+			return err
+		}
+		db.InstantSet(nextIDSetting, g.NextID)
+		registerAssignID(db, g.NextID)
+		return nil
+	default:
+		return fmt.Errorf("invalid id strategy %s. it should be one of %s, %s and %s",
+			config.Config.IDStrategy, IDStrategyIncrement, IDStrategyUUID, IDStrategySnowflake)
+	}
+}
+
+func registerAssignID(db *gorm.DB, nextID func() uint) {
+	db.Callback().Create().Before("gorm:create").Register("flagr:assign_id", func(scope *gorm.Scope) {
+		assignID(scope, nextID)
+	})
+}
+
+// assignID sets the ID of the entity being created, unless it's set already, e.g. by the sqlite export
+func assignID(scope *gorm.Scope, nextID func() uint) {
+	if scope.HasError() {
+		return
+	}
+	f := scope.PrimaryField()
+	if f == nil || !f.IsBlank || f.Field.Kind() != reflect.Uint {
+		return
+	}
+	scope.SetColumn(f.Name, nextID())
+}
+
+// migrateIDColumns widens the ID and the foreign key columns to bigint for the uuid and the snowflake IDs. The auto
+// migration of gorm creates them as 32-bit integers in mysql and postgres, and it never alters the existing columns.
+func migrateIDColumns(db *gorm.DB) error {
+	if config.Config.IDStrategy != IDStrategyUUID && config.Config.IDStrategy != IDStrategySnowflake {
+		return nil
+	}
+	dialect := db.Dialect().GetName()
+	if dialect != "mysql" && dialect != "postgres" {
+		return nil
+	}
+
+	for _, t := range AutoMigrateTables {
+		scope := db.NewScope(t)
+		for _, f := range scope.GetModelStruct().StructFields {
+			if f.IsIgnored || f.Struct.Type.Kind() != reflect.Uint {
+				continue
+			}
+			if !f.IsPrimaryKey && !strings.HasSuffix(f.DBName, "_id") {
+				continue
+			}
+			if err := widenIDColumn(db, dialect, scope.TableName(), f.DBName, f.IsPrimaryKey); err != nil {
+				return err
+			}
+		}
+	}
+	for _, column := range []string{"flag_id", "tag_id"} {
+		if err := widenIDColumn(db, dialect, "flags_tags", column, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func widenIDColumn(db *gorm.DB, dialect string, table string, column string, primaryKey bool) error {
+	var dataType string
+	q := "SELECT data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?"
+	if dialect == "mysql" {
+		q = "SELECT data_type FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?"
+	}
+	if err := db.Raw(q, table, column).Row().Scan(&dataType); err != nil {
+		return fmt.Errorf("cannot find the type of column %s.%s. reason: %s", table, column, err)
+	}
+	if strings.ToLower(dataType) == "bigint" {
+		return nil
+	}
+
+	stmt := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE BIGINT", db.Dialect().Quote(table), db.Dialect().Quote(column))
+	if dialect == "mysql" {
+		stmt = fmt.Sprintf("ALTER TABLE %s MODIFY %s BIGINT UNSIGNED", db.Dialect().Quote(table), db.Dialect().Quote(column))
+		if primaryKey {
+			stmt += " NOT NULL AUTO_INCREMENT"
+		}
+	}
+	return db.Exec(stmt).Error
+}
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+
+	"github.com/jinzhu/gorm"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnowflakeGenerator(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newGenerator := func(node uint) *snowflakeGenerator {
+		g, err := newSnowflakeGenerator(node)
+		assert.NoError(t, err)
+		g.now = func() time.Time { return now }
+		return g
+	}
+
+	t.Run("it should generate the increasing ids within 53 bits", func(t *testing.T) {
+		g := newGenerator(3)
+		last := uint(0)
+		for i := 0; i < 1000; i++ {
+			id := g.NextID()
+			assert.True(t, id > last)
+			last = id
+		}
+		assert.True(t, last < 1<<53)
+
+		now = now.Add(-time.Hour)
+		assert.True(t, g.NextID() > last)
+	})
+
+	t.Run("it should generate different ids on different nodes", func(t *testing.T) {
+		assert.NotEqual(t, newGenerator(1).NextID(), newGenerator(2).NextID())
+	})
+
+	t.Run("it should keep the ids within 53 bits for 60 years", func(t *testing.T) {
+		g := newGenerator(snowflakeMaxNode)
+		g.now = func() time.Time { return snowflakeEpoch.Add(60 * 365 * 24 * time.Hour) }
+		assert.True(t, g.NextID() < 1<<53)
+	})
+
+	t.Run("it should fail on the invalid nodes", func(t *testing.T) {
+		_, err := newSnowflakeGenerator(snowflakeMaxNode + 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetupIDStrategy(t *testing.T) {
+	newDB := func(strategy string) (*gorm.DB, error) {
+		defer gostub.Stub(&config.Config.IDStrategy, strategy).Stub(&config.Config.IDSnowflakeNode, 3).Reset()
+		db := NewTestDB()
+		return db, setupIDStrategy(db)
+	}
+
+	t.Run("it should leave the ids to the db by default", func(t *testing.T) {
+		db, err := newDB(IDStrategyIncrement)
+		assert.NoError(t, err)
+		defer db.Close()
+
+		f := &Flag{Key: "flag_1"}
+		assert.NoError(t, db.Create(f).Error)
+		assert.Equal(t, uint(1), f.ID)
+	})
+
+	t.Run("it should assign the random uuid ids", func(t *testing.T) {
+		db, err := newDB(IDStrategyUUID)
+		assert.NoError(t, err)
+		defer db.Close()
+
+		f := GenFixtureFlag()
+		f.ID = 0
+		f.Segments[0].ID = 0
+		f.Segments[0].Constraints[0].ID = 0
+		assert.NoError(t, db.Create(&f).Error)
+		assert.True(t, f.ID > 0 && f.ID < 1<<53)
+		assert.NotEqual(t, f.ID, f.Segments[0].ID)
+		assert.Equal(t, f.ID, f.Segments[0].FlagID)
+		assert.Equal(t, f.Segments[0].ID, f.Segments[0].Constraints[0].SegmentID)
+
+		found := &Flag{}
+		assert.NoError(t, PreloadSegmentsVariants(db).First(found, f.ID).Error)
+		assert.Len(t, found.Segments, 1)
+
+		ids := map[uint]bool{}
+		for i := 0; i < 1000; i++ {
+			id := nextRandomID()
+			assert.True(t, id > 0 && id < 1<<53)
+			ids[id] = true
+		}
+		assert.Len(t, ids, 1000)
+	})
+
+	t.Run("it should assign the snowflake ids", func(t *testing.T) {
+		db, err := newDB(IDStrategySnowflake)
+		assert.NoError(t, err)
+		defer db.Close()
+
+		f := GenFixtureFlag()
+		f.ID = 0
+		f.Segments[0].ID = 0
+		f.Segments[0].Constraints[0].ID = 0
+		assert.NoError(t, db.Create(&f).Error)
+		assert.True(t, f.ID > 1<<32)
+		assert.True(t, f.Segments[0].ID > f.ID)
+		assert.Equal(t, f.ID, f.Segments[0].FlagID)
+		assert.Equal(t, f.Segments[0].ID, f.Segments[0].Constraints[0].SegmentID)
+
+		found := &Flag{}
+		assert.NoError(t, PreloadSegmentsVariants(db).First(found, f.ID).Error)
+		assert.Len(t, found.Segments, 1)
+
+		s := &Segment{Model: gorm.Model{ID: 7}, FlagID: f.ID}
+		assert.NoError(t, db.Create(s).Error)
+		assert.Equal(t, uint(7), s.ID)
+	})
+
+	t.Run("it should fail on the snowflake ids without a node", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.IDStrategy, IDStrategySnowflake).Stub(&config.Config.IDSnowflakeNode, -1).Reset()
+		db := NewTestDB()
+		defer db.Close()
+		assert.Error(t, setupIDStrategy(db))
+	})
+
+	t.Run("it should fail on the unknown strategies", func(t *testing.T) {
+		db, err := newDB("ulid")
+		assert.Error(t, err)
+		db.Close()
+	})
+}
@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/jinzhu/gorm"
 	"github.com/zhouzhuojie/conditions"
 )
@@ -18,6 +21,11 @@ type Segment struct {
 	Constraints    ConstraintArray
 	Distributions  []Distribution
 
+	// ConstraintGroupID is the constraint group the segment references, its constraints are
+	// evaluated together with the segment's own. ConstraintGroup is resolved for the evaluation.
+	ConstraintGroupID uint             `gorm:"index:idx_segment_constraintgroupid"`
+	ConstraintGroup   *ConstraintGroup `gorm:"-" json:",omitempty"`
+
 	// Purely for evaluation
 	SegmentEvaluation SegmentEvaluation `gorm:"-" json:"-"`
 }
@@ -43,6 +51,46 @@ func (s *Segment) Preload(db *gorm.DB) error {
 type SegmentEvaluation struct {
 	ConditionsExpr    conditions.Expr
 	DistributionArray DistributionArray
+
+	// EvalTimeRequired is set when there're time-window constraints, so that
+	// the evaluation time needs to be passed in as EvalTimeProperty
+	EvalTimeRequired bool
+
+	// NestedProperties are the property paths of the constraints, they're resolved
+	// from the objects of the entityContext before the evaluation
+	NestedProperties []string
+
+	// ExistenceProperties are the properties of the existence constraints, whether they
+	// exist in the entityContext is passed in as their ExistenceProperty
+	ExistenceProperties []string
+
+	// NumberSets are the sets of the numbers of the IN and NOT_IN constraints, whether the properties
+	// are in them is passed in as their reserved properties, see PropertyMembershipPrefix
+	NumberSets []*NumberSet
+
+	// RampingDistribution is the distribution with a rollout schedule, the
+	// distribution array is derived from the evaluation time if it's set
+	RampingDistribution *Distribution
+	OtherDistributions  []Distribution
+}
+
+// DistributionArrayAt gets the distribution array at the evaluation time
+func (se SegmentEvaluation) DistributionArrayAt(now time.Time) DistributionArray {
+	if se.RampingDistribution == nil {
+		return se.DistributionArray
+	}
+	return rampedDistributionArray(*se.RampingDistribution, se.OtherDistributions, now)
+}
+
+// EvalConstraints returns the constraints the segment is evaluated with,
+// its own ones followed by the ones of its constraint group
+func (s *Segment) EvalConstraints() ConstraintArray {
+	if s.ConstraintGroup == nil || len(s.ConstraintGroup.Constraints) == 0 {
+		return s.Constraints
+	}
+	constraints := make(ConstraintArray, 0, len(s.Constraints)+len(s.ConstraintGroup.Constraints))
+	constraints = append(constraints, s.Constraints...)
+	return append(constraints, s.ConstraintGroup.Constraints...)
 }
 
 // PrepareEvaluation prepares the segment for evaluation by parsing constraints
@@ -56,21 +104,55 @@ func (s *Segment) PrepareEvaluation() error {
 		},
 	}
 
-	if len(s.Constraints) != 0 {
-		expr, err := s.Constraints.ToExpr()
+	if s.ConstraintGroupID != 0 && s.ConstraintGroup == nil {
+		return fmt.Errorf("constraint group %v of segment %v is not resolved", s.ConstraintGroupID, s.ID)
+	}
+
+	constraints := s.EvalConstraints()
+	if len(constraints) != 0 {
+		expr, err := constraints.ToExpr()
 		if err != nil {
 			return err
 		}
 		se.ConditionsExpr = expr
+
+		for _, c := range constraints {
+			if c.IsTimeWindow() {
+				se.EvalTimeRequired = true
+			}
+			if c.IsNestedProperty() {
+				se.NestedProperties = append(se.NestedProperties, c.Property)
+			}
+			if c.IsExistence() {
+				se.ExistenceProperties = append(se.ExistenceProperties, c.Property)
+			}
+			if set := c.NumberSet(); set != nil {
+				se.NumberSets = append(se.NumberSets, set)
+			}
+		}
 	}
 
+	percents := make([]uint, dLen)
+	totalPercent := uint(0)
 	for i, d := range s.Distributions {
-		se.DistributionArray.VariantIDs[i] = d.VariantID
-		if i == 0 {
-			se.DistributionArray.PercentsAccumulated[i] = int(d.Percent * PercentMultiplier)
+		if d.HasRolloutSchedule() && se.RampingDistribution == nil {
+			ramping := d
+			se.RampingDistribution = &ramping
 		} else {
-			se.DistributionArray.PercentsAccumulated[i] = se.DistributionArray.PercentsAccumulated[i-1] + int(d.Percent*PercentMultiplier)
+			se.OtherDistributions = append(se.OtherDistributions, d)
 		}
+
+		se.DistributionArray.VariantIDs[i] = d.VariantID
+		percents[i] = d.Percent
+		totalPercent += d.Percent
+	}
+	accumulated := 0
+	for i, share := range allocateBuckets(percents, int(totalPercent*PercentMultiplier)) {
+		accumulated += share
+		se.DistributionArray.PercentsAccumulated[i] = accumulated
+	}
+	if se.RampingDistribution == nil {
+		se.OtherDistributions = nil
 	}
 
 	s.SegmentEvaluation = se
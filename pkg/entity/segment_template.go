@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"encoding/json"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SegmentTemplate is a reusable skeleton of a segment with its constraints and distributions.
+// The distributions reference the variants only by key, the variants of the target flag are
+// filled in when the template is applied, so the same template fits any flag.
+type SegmentTemplate struct {
+	gorm.Model
+	Key         string `gorm:"type:varchar(64);unique_index:idx_segment_template_key"`
+	Description string `sql:"type:text"`
+	Segment     []byte `sql:"type:text"`
+}
+
+// SetSegment stores the skeleton of the segment, the IDs of the segment and its children are dropped
+func (t *SegmentTemplate) SetSegment(s *Segment) error {
+	skeleton := Segment{
+		Description:    s.Description,
+		RolloutPercent: s.RolloutPercent,
+		Constraints:    make(ConstraintArray, len(s.Constraints)),
+		Distributions:  make([]Distribution, len(s.Distributions)),
+	}
+	for i, c := range s.Constraints {
+		skeleton.Constraints[i] = Constraint{Property: c.Property, Operator: c.Operator, Value: c.Value}
+	}
+	for i, d := range s.Distributions {
+		skeleton.Distributions[i] = Distribution{
+			VariantKey:          d.VariantKey,
+			Percent:             d.Percent,
+			RolloutStartAt:      d.RolloutStartAt,
+			RolloutEndAt:        d.RolloutEndAt,
+			RolloutStartPercent: d.RolloutStartPercent,
+			RolloutEndPercent:   d.RolloutEndPercent,
+		}
+	}
+
+	b, err := json.Marshal(skeleton)
+	if err != nil {
+		return err
+	}
+	t.Segment = b
+	return nil
+}
+
+// NewSegment returns a new segment of the flag from the skeleton, it's ranked after the existing segments.
+// The variant IDs of the distributions are left for the caller to fill in.
+func (t *SegmentTemplate) NewSegment(flagID uint) (*Segment, error) {
+	s := &Segment{}
+	if err := json.Unmarshal(t.Segment, s); err != nil {
+		return nil, err
+	}
+	s.FlagID = flagID
+	s.Rank = SegmentDefaultRank
+	return s, nil
+}
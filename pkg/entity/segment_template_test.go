@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentTemplate(t *testing.T) {
+	t.Run("it should create the segment from the skeleton without the IDs", func(t *testing.T) {
+		f := GenFixtureFlag()
+		tpl := &SegmentTemplate{Key: "californians"}
+		assert.NoError(t, tpl.SetSegment(&f.Segments[0]))
+
+		s, err := tpl.NewSegment(7)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(0), s.ID)
+		assert.Equal(t, uint(7), s.FlagID)
+		assert.Equal(t, SegmentDefaultRank, s.Rank)
+		assert.Equal(t, f.Segments[0].RolloutPercent, s.RolloutPercent)
+
+		assert.Len(t, s.Constraints, 1)
+		assert.Equal(t, uint(0), s.Constraints[0].ID)
+		assert.Equal(t, uint(0), s.Constraints[0].SegmentID)
+		assert.Equal(t, "dl_state", s.Constraints[0].Property)
+
+		assert.Len(t, s.Distributions, 2)
+		assert.Equal(t, uint(0), s.Distributions[0].ID)
+		assert.Equal(t, uint(0), s.Distributions[0].VariantID)
+		assert.Equal(t, "control", s.Distributions[0].VariantKey)
+		assert.Equal(t, uint(50), s.Distributions[0].Percent)
+	})
+
+	t.Run("it should fail on the corrupted skeletons", func(t *testing.T) {
+		tpl := &SegmentTemplate{Segment: []byte("{")}
+		_, err := tpl.NewSegment(7)
+		assert.Error(t, err)
+	})
+}
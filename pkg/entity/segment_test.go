@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -23,6 +24,42 @@ func TestSegmentPrepareEvaluation(t *testing.T) {
 		assert.Empty(t, s.SegmentEvaluation.DistributionArray.VariantIDs)
 		assert.Empty(t, s.SegmentEvaluation.DistributionArray.PercentsAccumulated)
 	})
+
+	t.Run("1% each for 100 variants leaves no bucket out", func(t *testing.T) {
+		s := GenFixtureSegment()
+		s.Distributions = nil
+		for i := 0; i < 100; i++ {
+			s.Distributions = append(s.Distributions, Distribution{VariantID: uint(i + 1), Percent: 1})
+		}
+		assert.NoError(t, s.PrepareEvaluation())
+
+		accumulated := s.SegmentEvaluation.DistributionArray.PercentsAccumulated
+		assert.Len(t, accumulated, 100)
+		for i, a := range accumulated {
+			assert.Equal(t, (i+1)*10, a)
+		}
+		assert.Equal(t, int(TotalBucketNum), accumulated[99])
+	})
+}
+
+func TestSegmentDistributionArrayAt(t *testing.T) {
+	s := GenFixtureSegment()
+	assert.NoError(t, s.PrepareEvaluation())
+	assert.Nil(t, s.SegmentEvaluation.RampingDistribution)
+	assert.Equal(t, s.SegmentEvaluation.DistributionArray, s.SegmentEvaluation.DistributionArrayAt(time.Now()))
+
+	startAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(10 * time.Hour)
+	s.Distributions[1].RolloutStartAt = &startAt
+	s.Distributions[1].RolloutEndAt = &endAt
+	s.Distributions[1].RolloutEndPercent = 100
+	assert.NoError(t, s.PrepareEvaluation())
+	assert.Equal(t, s.Distributions[1].VariantID, s.SegmentEvaluation.RampingDistribution.VariantID)
+	assert.Len(t, s.SegmentEvaluation.OtherDistributions, 1)
+
+	d := s.SegmentEvaluation.DistributionArrayAt(startAt.Add(3 * time.Hour))
+	assert.Equal(t, []uint{s.Distributions[1].VariantID, s.Distributions[0].VariantID}, d.VariantIDs)
+	assert.Equal(t, []int{300, 1000}, d.PercentsAccumulated)
 }
 
 func TestSegmentPreload(t *testing.T) {
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/jinzhu/gorm"
+)
+
+// Tag is the label of flags, it's used to organize and filter the flags
+type Tag struct {
+	gorm.Model
+	Value string  `gorm:"type:varchar(64);unique_index:idx_tag_value"`
+	Flags []*Flag `gorm:"many2many:flags_tags;"`
+}
+
+// CreateTag creates the Tag if not exists
+func CreateTag(db *gorm.DB, value string) (*Tag, error) {
+	ok, reason := util.IsSafeKey(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid tag. reason: %s", reason)
+	}
+	t := &Tag{Value: value}
+	if err := db.Where(Tag{Value: value}).FirstOrCreate(t).Error; err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// migrateTagIndexes indexes the tag side of the join table, the primary key
+// of flags_tags only covers the lookups starting from flag_id
+func migrateTagIndexes(db *gorm.DB) {
+	db.Table("flags_tags").AddIndex("idx_flags_tags_tag_id", "tag_id")
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTag(t *testing.T) {
+	db := NewTestDB()
+	defer db.Close()
+
+	t1, err := CreateTag(db, "team_a")
+	assert.NoError(t, err)
+	assert.NotZero(t, t1.ID)
+
+	t2, err := CreateTag(db, "team_a")
+	assert.NoError(t, err)
+	assert.Equal(t, t1.ID, t2.ID)
+
+	_, err = CreateTag(db, "Team A")
+	assert.Error(t, err)
+
+	assert.True(t, db.Dialect().HasIndex("flags_tags", "idx_flags_tags_tag_id"))
+}
@@ -16,6 +16,8 @@ type Variant struct {
 	FlagID     uint `gorm:"index:idx_variant_flagid"`
 	Key        string
 	Attachment Attachment `sql:"type:text"`
+
+	AttachmentTypes AttachmentTypes `sql:"type:text"`
 }
 
 // Validate validates the Variant
@@ -24,6 +26,12 @@ func (v *Variant) Validate() error {
 	if !ok {
 		return fmt.Errorf(msg)
 	}
+	if err := v.AttachmentTypes.Validate(); err != nil {
+		return err
+	}
+	if _, err := v.Attachment.Typed(v.AttachmentTypes); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -23,6 +23,17 @@ func TestValidate(t *testing.T) {
 		err := v.Validate()
 		assert.NoError(t, err)
 	})
+	t.Run("attachment not conforming to the attachment types", func(t *testing.T) {
+		v := Variant{
+			Key:             "a123",
+			Attachment:      Attachment{"value": "abc"},
+			AttachmentTypes: AttachmentTypes{"value": "integer"},
+		}
+		assert.Error(t, v.Validate())
+
+		v.AttachmentTypes = AttachmentTypes{"value": "unknown"}
+		assert.Error(t, v.Validate())
+	})
 }
 
 func TestVariantScan(t *testing.T) {
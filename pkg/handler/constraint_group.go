@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/mapper/entity_restapi/e2r"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint_group"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+func (c *crud) FindConstraintGroups(params constraint_group.FindConstraintGroupsParams) middleware.Responder {
+	gs := []entity.ConstraintGroup{}
+	tx := whereNamespace(entity.PreloadConstraintGroupConstraints(getRequestDB(params.HTTPRequest)), params.HTTPRequest)
+	if err := tx.Order("key").Find(&gs).Error; err != nil {
+		return constraint_group.NewFindConstraintGroupsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	ss := []entity.Segment{}
+	if err := getRequestDB(params.HTTPRequest).Where("constraint_group_id <> 0").Order("id").Find(&ss).Error; err != nil {
+		return constraint_group.NewFindConstraintGroupsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	segmentIDsByGroup := make(map[uint][]uint)
+	for _, s := range ss {
+		segmentIDsByGroup[s.ConstraintGroupID] = append(segmentIDsByGroup[s.ConstraintGroupID], s.ID)
+	}
+
+	payload := make([]*models.ConstraintGroup, len(gs))
+	for i := range gs {
+		payload[i] = e2r.MapConstraintGroup(&gs[i], segmentIDsByGroup[gs[i].ID])
+	}
+	resp := constraint_group.NewFindConstraintGroupsOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+func (c *crud) CreateConstraintGroup(params constraint_group.CreateConstraintGroupParams) middleware.Responder {
+	key := util.SafeString(params.Body.Key)
+	if ok, reason := util.IsSafeKey(key); !ok {
+		return constraint_group.NewCreateConstraintGroupDefault(400).WithPayload(
+			ErrorMessage("cannot create constraint group due to invalid key. reason: %s", reason))
+	}
+
+	count := 0
+	if err := getRequestDB(params.HTTPRequest).Model(&entity.ConstraintGroup{}).Where(entity.ConstraintGroup{Key: key}).Count(&count).Error; err != nil {
+		return constraint_group.NewCreateConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if count > 0 {
+		return constraint_group.NewCreateConstraintGroupDefault(409).WithPayload(
+			ErrorMessage("cannot create constraint group. key %s already exists", key))
+	}
+
+	constraints, e := mapConstraintGroupConstraints(params.Body.Constraints)
+	if e != nil {
+		return constraint_group.NewCreateConstraintGroupDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	g := &entity.ConstraintGroup{
+		Key:         key,
+		Description: params.Body.Description,
+		Constraints: constraints,
+		Namespace:   getNamespaceFromRequest(params.HTTPRequest),
+	}
+	if err := getRequestDB(params.HTTPRequest).Create(g).Error; err != nil {
+		return constraint_group.NewCreateConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := constraint_group.NewCreateConstraintGroupOK()
+	resp.SetPayload(e2r.MapConstraintGroup(g, nil))
+	return resp
+}
+
+func (c *crud) GetConstraintGroup(params constraint_group.GetConstraintGroupParams) middleware.Responder {
+	g := &entity.ConstraintGroup{}
+	tx := whereNamespace(entity.PreloadConstraintGroupConstraints(getRequestDB(params.HTTPRequest)), params.HTTPRequest)
+	if err := tx.First(g, params.ConstraintGroupID).Error; err != nil {
+		return constraint_group.NewGetConstraintGroupDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	segmentIDs, err := findConstraintGroupSegmentIDs(getRequestDB(params.HTTPRequest), g.ID)
+	if err != nil {
+		return constraint_group.NewGetConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := constraint_group.NewGetConstraintGroupOK()
+	resp.SetPayload(e2r.MapConstraintGroup(g, segmentIDs))
+	return resp
+}
+
+// PutConstraintGroup replaces the description and the constraints of the group. Every flag referencing it records
+// the change in its history and snapshot, and it's reloaded in the eval cache right away, like the changes of its own constraints.
+func (c *crud) PutConstraintGroup(params constraint_group.PutConstraintGroupParams) middleware.Responder {
+	g := &entity.ConstraintGroup{}
+	tx := whereNamespace(entity.PreloadConstraintGroupConstraints(getRequestDB(params.HTTPRequest)), params.HTTPRequest)
+	if err := tx.First(g, params.ConstraintGroupID).Error; err != nil {
+		return constraint_group.NewPutConstraintGroupDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+	before := constraintGroupHistory(g)
+
+	constraints, e := mapConstraintGroupConstraints(params.Body.Constraints)
+	if e != nil {
+		return constraint_group.NewPutConstraintGroupDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	fs, err := findConstraintGroupFlags(getRequestDB(params.HTTPRequest), g.ID)
+	if err != nil {
+		return constraint_group.NewPutConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	for i := range fs {
+		enabled, err := isFlagEnabledAnywhere(getRequestDB(params.HTTPRequest), &fs[i])
+		if err != nil {
+			return constraint_group.NewPutConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		if e := validateWithoutApproval(enabled, "put constraint group %v referenced by flag %v", g.ID, fs[i].ID); e != nil {
+			return constraint_group.NewPutConstraintGroupDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
+
+	tx = getRequestDB(params.HTTPRequest).Begin()
+	if err := tx.Delete(entity.Constraint{}, "constraint_group_id = ?", g.ID).Error; err != nil {
+		tx.Rollback()
+		return constraint_group.NewPutConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	g.Description = params.Body.Description
+	g.Constraints = constraints
+	if err := tx.Save(g).Error; err != nil {
+		tx.Rollback()
+		return constraint_group.NewPutConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return constraint_group.NewPutConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	saveConstraintGroupFlagsHistory(params.HTTPRequest, fs, g.ID, before, constraintGroupHistory(g))
+
+	segmentIDs, err := findConstraintGroupSegmentIDs(getRequestDB(params.HTTPRequest), g.ID)
+	if err != nil {
+		return constraint_group.NewPutConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := constraint_group.NewPutConstraintGroupOK()
+	resp.SetPayload(e2r.MapConstraintGroup(g, segmentIDs))
+	return resp
+}
+
+// DeleteConstraintGroup deletes the group and its constraints, the groups still referenced by segments can't be
+// deleted, so no flag is changed by it. The references are checked in the transaction of the delete.
+func (c *crud) DeleteConstraintGroup(params constraint_group.DeleteConstraintGroupParams) middleware.Responder {
+	g := &entity.ConstraintGroup{}
+	if err := whereNamespace(getRequestDB(params.HTTPRequest), params.HTTPRequest).First(g, params.ConstraintGroupID).Error; err != nil {
+		return constraint_group.NewDeleteConstraintGroupDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	tx := getRequestDB(params.HTTPRequest).Begin()
+	segmentIDs, err := findConstraintGroupSegmentIDs(tx, g.ID)
+	if err != nil {
+		tx.Rollback()
+		return constraint_group.NewDeleteConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if len(segmentIDs) > 0 {
+		tx.Rollback()
+		return constraint_group.NewDeleteConstraintGroupDefault(400).WithPayload(
+			ErrorMessage("cannot delete constraint group %v. it's referenced by segments %v", g.ID, segmentIDs))
+	}
+	if err := tx.Delete(entity.Constraint{}, "constraint_group_id = ?", g.ID).Error; err != nil {
+		tx.Rollback()
+		return constraint_group.NewDeleteConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := tx.Delete(g).Error; err != nil {
+		tx.Rollback()
+		return constraint_group.NewDeleteConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return constraint_group.NewDeleteConstraintGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	return constraint_group.NewDeleteConstraintGroupOK()
+}
+
+func mapConstraintGroupConstraints(rs []*models.CreateConstraintRequest) (entity.ConstraintArray, *Error) {
+	constraints := make(entity.ConstraintArray, len(rs))
+	for i, r := range rs {
+		constraints[i] = entity.Constraint{
+			Property: util.SafeString(r.Property),
+			Operator: util.SafeString(r.Operator),
+			Value:    util.SafeString(r.Value),
+		}
+		if err := constraints[i].Validate(); err != nil {
+			return nil, NewError(400, "invalid constraint %d. reason: %s", i, err)
+		}
+	}
+	return constraints, nil
+}
+
+func findConstraintGroupSegmentIDs(db *gorm.DB, groupID uint) ([]uint, error) {
+	ss := []entity.Segment{}
+	if err := db.Where(entity.Segment{ConstraintGroupID: groupID}).Order("id").Find(&ss).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(ss))
+	for i, s := range ss {
+		ids[i] = s.ID
+	}
+	return ids, nil
+}
+
+// findConstraintGroupFlags finds the flags of the segments referencing the constraint group
+func findConstraintGroupFlags(db *gorm.DB, groupID uint) ([]entity.Flag, error) {
+	ss := []entity.Segment{}
+	if err := db.Where(entity.Segment{ConstraintGroupID: groupID}).Find(&ss).Error; err != nil {
+		return nil, err
+	}
+	flagIDs := []uint{}
+	seen := make(map[uint]bool, len(ss))
+	for _, s := range ss {
+		if !seen[s.FlagID] {
+			seen[s.FlagID] = true
+			flagIDs = append(flagIDs, s.FlagID)
+		}
+	}
+
+	fs := []entity.Flag{}
+	if len(flagIDs) == 0 {
+		return fs, nil
+	}
+	if err := db.Where("id IN (?)", flagIDs).Order("id").Find(&fs).Error; err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// constraintGroupHistory is the constraint group in the flag history, the ids of its constraints
+// are regenerated on every put, the properties, the operators and the values are what change
+func constraintGroupHistory(g *entity.ConstraintGroup) map[string]interface{} {
+	cs := make([]string, len(g.Constraints))
+	for i, c := range g.Constraints {
+		cs[i] = fmt.Sprintf("%s %s %s", c.Property, c.Operator, c.Value)
+	}
+	return map[string]interface{}{"Key": g.Key, "Description": g.Description, "Constraints": cs}
+}
+
+// saveConstraintGroupFlagsHistory records the change of the constraint group in the history and the snapshot of
+// the flags referencing it, which notifies their changes, and reloads them so that their cached results are dropped
+func saveConstraintGroupFlagsHistory(r *http.Request, fs []entity.Flag, groupID uint, before interface{}, after interface{}) {
+	actor := getSubjectFromRequest(r)
+	for _, f := range fs {
+		entity.SaveFlagHistory(getDB(), f.ID, actor, entity.FlagHistoryEntityTypeConstraintGroup, groupID, before, after)
+		entity.SaveFlagSnapshot(getDB(), f.ID, actor)
+		if err := GetEvalCache().reloadFlag(f.ID); err != nil {
+			logrus.WithFields(logrus.Fields{"err": err, "flagID": f.ID}).Error("failed to reload the flag in the eval cache")
+		}
+	}
+}
+
+// validateConstraintGroupID makes sure the constraint group referenced by a segment exists in the namespace
+// of the caller, which is the namespace of the segment's flag, 0 references none
+var validateConstraintGroupID = func(r *http.Request, groupID uint) *Error {
+	if groupID == 0 {
+		return nil
+	}
+	if err := whereNamespace(getRequestDB(r), r).First(&entity.ConstraintGroup{}, groupID).Error; err != nil {
+		return NewError(400, "cannot find constraint group %v. reason: %s", groupID, err)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint_group"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrudConstraintGroups(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(f)
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	employees := func(value string) []*models.CreateConstraintRequest {
+		return []*models.CreateConstraintRequest{{
+			Property: util.StringPtr("employee"),
+			Operator: util.StringPtr(models.ConstraintOperatorEQ),
+			Value:    util.StringPtr(value),
+		}}
+	}
+	putSegment := func(groupID int64) middleware.Responder {
+		return c.PutSegment(segment.PutSegmentParams{
+			FlagID:    int64(f.ID),
+			SegmentID: int64(200),
+			Body: &models.PutSegmentRequest{
+				Description:       util.StringPtr("segment1"),
+				RolloutPercent:    util.Int64Ptr(100),
+				ConstraintGroupID: util.Int64Ptr(groupID),
+			},
+		})
+	}
+	evalFixtureSegment := func(entityContext map[string]interface{}) *uint {
+		fs, err := (&dbFetcher{db: db}).fetch()
+		assert.NoError(t, err)
+		assert.NoError(t, fs[0].PrepareEvaluation())
+		vID, _, _, _ := evalSegment(&fs[0], models.EvalContext{
+			EntityContext: entityContext,
+			EntityID:      "entityID1",
+			FlagID:        int64(f.ID),
+		}, fs[0].Segments[0], time.Now())
+		return vID
+	}
+
+	t.Run("it should create the constraint group", func(t *testing.T) {
+		res := c.CreateConstraintGroup(constraint_group.CreateConstraintGroupParams{
+			Body: &models.CreateConstraintGroupRequest{Key: util.StringPtr("employees"), Constraints: employees(`"yes"`)},
+		})
+		payload := res.(*constraint_group.CreateConstraintGroupOK).Payload
+		assert.Equal(t, int64(1), payload.ID)
+		assert.Len(t, payload.Constraints, 1)
+
+		res = c.CreateConstraintGroup(constraint_group.CreateConstraintGroupParams{
+			Body: &models.CreateConstraintGroupRequest{Key: util.StringPtr("employees")},
+		})
+		assert.IsType(t, &constraint_group.CreateConstraintGroupDefault{}, res)
+
+		res = c.CreateConstraintGroup(constraint_group.CreateConstraintGroupParams{
+			Body: &models.CreateConstraintGroupRequest{Key: util.StringPtr("admins"), Constraints: employees("")},
+		})
+		assert.IsType(t, &constraint_group.CreateConstraintGroupDefault{}, res)
+	})
+
+	t.Run("it should reference the constraint group from the segment", func(t *testing.T) {
+		assert.IsType(t, &segment.PutSegmentDefault{}, putSegment(999))
+
+		res := putSegment(1)
+		assert.Equal(t, int64(1), *res.(*segment.PutSegmentOK).Payload.ConstraintGroupID)
+
+		res = c.GetConstraintGroup(constraint_group.GetConstraintGroupParams{ConstraintGroupID: 1})
+		assert.Equal(t, []int64{200}, res.(*constraint_group.GetConstraintGroupOK).Payload.SegmentIds)
+	})
+
+	t.Run("it should evaluate the segment with the constraints of the group", func(t *testing.T) {
+		assert.Nil(t, evalFixtureSegment(map[string]interface{}{"dl_state": "CA"}))
+		assert.NotNil(t, evalFixtureSegment(map[string]interface{}{"dl_state": "CA", "employee": "yes"}))
+		assert.Nil(t, evalFixtureSegment(map[string]interface{}{"dl_state": "NY", "employee": "yes"}))
+	})
+
+	t.Run("it should evaluate the segment with the updated constraints of the group", func(t *testing.T) {
+		res := c.PutConstraintGroup(constraint_group.PutConstraintGroupParams{
+			ConstraintGroupID: 1,
+			Body:              &models.PutConstraintGroupRequest{Constraints: employees(`"true"`)},
+		})
+		assert.Len(t, res.(*constraint_group.PutConstraintGroupOK).Payload.Constraints, 1)
+
+		assert.Nil(t, evalFixtureSegment(map[string]interface{}{"dl_state": "CA", "employee": "yes"}))
+		assert.NotNil(t, evalFixtureSegment(map[string]interface{}{"dl_state": "CA", "employee": "true"}))
+	})
+
+	t.Run("it should record the change of the group in the referencing flags", func(t *testing.T) {
+		notified := []*entity.FlagHistory{}
+		defer gostub.Stub(&entity.FlagHistoryHook, func(fh *entity.FlagHistory) { notified = append(notified, fh) }).Reset()
+		snapshots := 0
+		db.Model(&entity.FlagSnapshot{}).Where("flag_id = ?", f.ID).Count(&snapshots)
+
+		res := c.PutConstraintGroup(constraint_group.PutConstraintGroupParams{
+			ConstraintGroupID: 1,
+			Body:              &models.PutConstraintGroupRequest{Constraints: employees(`"yes"`)},
+		})
+		assert.IsType(t, &constraint_group.PutConstraintGroupOK{}, res)
+
+		assert.Len(t, notified, 1)
+		assert.Equal(t, f.ID, notified[0].FlagID)
+		assert.Equal(t, entity.FlagHistoryEntityTypeConstraintGroup, notified[0].EntityType)
+		assert.Equal(t, uint(1), notified[0].EntityID)
+		assert.Contains(t, string(notified[0].Diff), `employee EQ \"yes\"`)
+
+		count := 0
+		db.Model(&entity.FlagSnapshot{}).Where("flag_id = ?", f.ID).Count(&count)
+		assert.Equal(t, snapshots+1, count)
+	})
+
+	t.Run("it should not put the group of an enabled flag without an approval", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.ApprovalRequired, true).Reset()
+
+		res := c.PutConstraintGroup(constraint_group.PutConstraintGroupParams{
+			ConstraintGroupID: 1,
+			Body:              &models.PutConstraintGroupRequest{Constraints: employees(`"no"`)},
+		})
+		assert.IsType(t, &constraint_group.PutConstraintGroupDefault{}, res)
+		assert.Contains(t, *res.(*constraint_group.PutConstraintGroupDefault).Payload.Message, "without an approval")
+
+		res = c.GetConstraintGroup(constraint_group.GetConstraintGroupParams{ConstraintGroupID: 1})
+		assert.Equal(t, `"yes"`, *res.(*constraint_group.GetConstraintGroupOK).Payload.Constraints[0].Value)
+	})
+
+	t.Run("it should only delete the constraint group without references", func(t *testing.T) {
+		res := c.DeleteConstraintGroup(constraint_group.DeleteConstraintGroupParams{ConstraintGroupID: 1})
+		assert.IsType(t, &constraint_group.DeleteConstraintGroupDefault{}, res)
+
+		putSegment(0)
+		res = c.DeleteConstraintGroup(constraint_group.DeleteConstraintGroupParams{ConstraintGroupID: 1})
+		assert.IsType(t, &constraint_group.DeleteConstraintGroupOK{}, res)
+
+		res = c.FindConstraintGroups(constraint_group.FindConstraintGroupsParams{})
+		assert.Len(t, res.(*constraint_group.FindConstraintGroupsOK).Payload, 0)
+		count := 0
+		db.Model(&entity.Constraint{}).Where("constraint_group_id <> 0").Count(&count)
+		assert.Equal(t, 0, count)
+	})
+}
+
+func TestCrudConstraintGroupsNamespace(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	f.Namespace = "checkout"
+	db := entity.PopulateTestDB(f)
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+
+	res := c.CreateConstraintGroup(constraint_group.CreateConstraintGroupParams{
+		HTTPRequest: genNamespaceRequest("search"),
+		Body:        &models.CreateConstraintGroupRequest{Key: util.StringPtr("search_employees")},
+	})
+	g := res.(*constraint_group.CreateConstraintGroupOK).Payload
+	assert.Equal(t, "search", g.Namespace)
+
+	t.Run("it should only find the groups of the namespace", func(t *testing.T) {
+		res := c.FindConstraintGroups(constraint_group.FindConstraintGroupsParams{HTTPRequest: genNamespaceRequest("search")})
+		assert.Len(t, res.(*constraint_group.FindConstraintGroupsOK).Payload, 1)
+
+		res = c.FindConstraintGroups(constraint_group.FindConstraintGroupsParams{HTTPRequest: genNamespaceRequest("checkout")})
+		assert.Len(t, res.(*constraint_group.FindConstraintGroupsOK).Payload, 0)
+	})
+
+	t.Run("it should not get, put or delete the groups of the other namespaces", func(t *testing.T) {
+		r := genNamespaceRequest("checkout")
+		res := c.GetConstraintGroup(constraint_group.GetConstraintGroupParams{HTTPRequest: r, ConstraintGroupID: g.ID})
+		assert.IsType(t, &constraint_group.GetConstraintGroupDefault{}, res)
+
+		res = c.PutConstraintGroup(constraint_group.PutConstraintGroupParams{
+			HTTPRequest:       r,
+			ConstraintGroupID: g.ID,
+			Body:              &models.PutConstraintGroupRequest{Description: "taken over"},
+		})
+		assert.IsType(t, &constraint_group.PutConstraintGroupDefault{}, res)
+
+		res = c.DeleteConstraintGroup(constraint_group.DeleteConstraintGroupParams{HTTPRequest: r, ConstraintGroupID: g.ID})
+		assert.IsType(t, &constraint_group.DeleteConstraintGroupDefault{}, res)
+	})
+
+	t.Run("it should not reference the groups of the other namespaces", func(t *testing.T) {
+		res := c.PutSegment(segment.PutSegmentParams{
+			HTTPRequest: genNamespaceRequest("checkout"),
+			FlagID:      int64(f.ID),
+			SegmentID:   int64(200),
+			Body: &models.PutSegmentRequest{
+				Description:       util.StringPtr("segment1"),
+				RolloutPercent:    util.Int64Ptr(100),
+				ConstraintGroupID: util.Int64Ptr(g.ID),
+			},
+		})
+		assert.IsType(t, &segment.PutSegmentDefault{}, res)
+	})
+}
@@ -1,33 +1,62 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
 	"github.com/checkr/flagr/pkg/mapper/entity_restapi/e2r"
 	"github.com/checkr/flagr/pkg/mapper/entity_restapi/r2e"
 	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint_group"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/exclusion_group"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment_template"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/tag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
 )
 
 // CRUD is the CRUD interface
 type CRUD interface {
 	// Flags
 	FindFlags(flag.FindFlagsParams) middleware.Responder
+	FindStaleFlags(flag.FindStaleFlagsParams) middleware.Responder
 	CreateFlag(flag.CreateFlagParams) middleware.Responder
+	SaveFlagsBatch(flag.SaveFlagsBatchParams) middleware.Responder
+	ExportFlag(flag.ExportFlagParams) middleware.Responder
+	ImportFlag(flag.ImportFlagParams) middleware.Responder
+	ValidateFlag(flag.ValidateFlagParams) middleware.Responder
+	CloneFlag(flag.CloneFlagParams) middleware.Responder
 	GetFlag(flag.GetFlagParams) middleware.Responder
 	PutFlag(flag.PutFlagParams) middleware.Responder
 	DeleteFlag(flag.DeleteFlagParams) middleware.Responder
+	RestoreFlag(flag.RestoreFlagParams) middleware.Responder
+	RestoreFlagSnapshot(flag.RestoreFlagSnapshotParams) middleware.Responder
 	SetFlagEnabledState(flag.SetFlagEnabledParams) middleware.Responder
+	PatchFlagEnabled(flag.PatchFlagEnabledParams) middleware.Responder
+	PromoteFlagEnvironment(flag.PromoteFlagEnvironmentParams) middleware.Responder
+	PutFlagOverride(flag.PutFlagOverrideParams) middleware.Responder
 	GetFlagSnapshots(params flag.GetFlagSnapshotsParams) middleware.Responder
+	GetFlagSnapshotsDiff(params flag.GetFlagSnapshotsDiffParams) middleware.Responder
+	GetFlagHistory(params flag.GetFlagHistoryParams) middleware.Responder
+	GetFlagBucketCheck(params flag.GetFlagBucketCheckParams) middleware.Responder
+	SimulateFlagRollout(params flag.SimulateFlagRolloutParams) middleware.Responder
 	GetFlagEntityTypes(params flag.GetFlagEntityTypesParams) middleware.Responder
+	FindFlagSchedules(flag.FindFlagSchedulesParams) middleware.Responder
+	CreateFlagSchedule(flag.CreateFlagScheduleParams) middleware.Responder
+	FindFlagChanges(flag.FindFlagChangesParams) middleware.Responder
+	ApproveFlagChange(flag.ApproveFlagChangeParams) middleware.Responder
 
 	// Segments
 	CreateSegment(segment.CreateSegmentParams) middleware.Responder
@@ -45,12 +74,43 @@ type CRUD interface {
 	// Distributions
 	FindDistributions(distribution.FindDistributionsParams) middleware.Responder
 	PutDistributions(distribution.PutDistributionsParams) middleware.Responder
+	RebalanceDistributions(distribution.RebalanceDistributionsParams) middleware.Responder
 
 	// Variants
 	CreateVariant(variant.CreateVariantParams) middleware.Responder
 	FindVariants(variant.FindVariantsParams) middleware.Responder
 	PutVariant(variant.PutVariantParams) middleware.Responder
 	DeleteVariant(variant.DeleteVariantParams) middleware.Responder
+
+	// Tags
+	CreateTag(tag.CreateTagParams) middleware.Responder
+	FindTags(tag.FindTagsParams) middleware.Responder
+	DeleteTag(tag.DeleteTagParams) middleware.Responder
+	FindAllTags(tag.FindAllTagsParams) middleware.Responder
+	BulkTags(tag.BulkTagsParams) middleware.Responder
+
+	// Constraint Groups
+	FindConstraintGroups(constraint_group.FindConstraintGroupsParams) middleware.Responder
+	CreateConstraintGroup(constraint_group.CreateConstraintGroupParams) middleware.Responder
+	GetConstraintGroup(constraint_group.GetConstraintGroupParams) middleware.Responder
+	PutConstraintGroup(constraint_group.PutConstraintGroupParams) middleware.Responder
+	DeleteConstraintGroup(constraint_group.DeleteConstraintGroupParams) middleware.Responder
+
+	// Segment Templates
+	FindSegmentTemplates(segment_template.FindSegmentTemplatesParams) middleware.Responder
+	CreateSegmentTemplate(segment_template.CreateSegmentTemplateParams) middleware.Responder
+	GetSegmentTemplate(segment_template.GetSegmentTemplateParams) middleware.Responder
+	PutSegmentTemplate(segment_template.PutSegmentTemplateParams) middleware.Responder
+	DeleteSegmentTemplate(segment_template.DeleteSegmentTemplateParams) middleware.Responder
+	ApplySegmentTemplate(segment_template.ApplySegmentTemplateParams) middleware.Responder
+
+	// Exclusion Groups
+	FindExclusionGroups(exclusion_group.FindExclusionGroupsParams) middleware.Responder
+	CreateExclusionGroup(exclusion_group.CreateExclusionGroupParams) middleware.Responder
+	GetExclusionGroup(exclusion_group.GetExclusionGroupParams) middleware.Responder
+	PutExclusionGroup(exclusion_group.PutExclusionGroupParams) middleware.Responder
+	DeleteExclusionGroup(exclusion_group.DeleteExclusionGroupParams) middleware.Responder
+	PutFlagExclusionGroup(exclusion_group.PutFlagExclusionGroupParams) middleware.Responder
 }
 
 // NewCRUD creates a new CRUD instance
@@ -61,20 +121,35 @@ func NewCRUD() CRUD {
 type crud struct{}
 
 var (
-	e2rMapFlag          = e2r.MapFlag
-	e2rMapFlags         = e2r.MapFlags
-	e2rMapFlagSnapshots = e2r.MapFlagSnapshots
-
-	r2eMapAttachment    = r2e.MapAttachment
-	r2eMapDistributions = r2e.MapDistributions
+	e2rMapFlag           = e2r.MapFlag
+	e2rMapFlags          = e2r.MapFlags
+	e2rMapFlagSnapshots  = e2r.MapFlagSnapshots
+	e2rMapFlagHistories  = e2r.MapFlagHistories
+	e2rMapFlagDefinition = e2r.MapFlagDefinition
+
+	r2eMapAttachment       = r2e.MapAttachment
+	r2eMapAttachmentSchema = r2e.MapAttachmentSchema
+	r2eMapDistributions    = r2e.MapDistributions
 )
 
 func (c *crud) FindFlags(params flag.FindFlagsParams) middleware.Responder {
-	tx := getDB()
+	tx := getRequestDB(params.HTTPRequest)
 	fs := []entity.Flag{}
 	q := entity.Flag{}
 
-	if params.Enabled != nil {
+	if params.Environment != nil {
+		if e := validateEnvironment(*params.Environment); e != nil {
+			return flag.NewFindFlagsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
+	if params.Enabled != nil && params.Environment != nil {
+		var err error
+		tx, err = whereEnabledInEnvironment(tx, getRequestDB(params.HTTPRequest), *params.Environment, *params.Enabled)
+		if err != nil {
+			return flag.NewFindFlagsDefault(500).WithPayload(
+				ErrorMessage("cannot query flags by environment. %s", err))
+		}
+	} else if params.Enabled != nil {
 		q.Enabled = *params.Enabled
 	}
 	if params.Description != nil {
@@ -83,14 +158,50 @@ func (c *crud) FindFlags(params flag.FindFlagsParams) middleware.Responder {
 	if params.Key != nil {
 		q.Key = *params.Key
 	}
-	if params.Offset != nil {
-		tx = tx.Offset(int(*params.Offset))
+	if params.Tags != nil {
+		tags := splitTags(*params.Tags)
+		if len(tags) > 0 {
+			flagIDs, err := findFlagIDsWithAllTags(getRequestDB(params.HTTPRequest), tags)
+			if err != nil {
+				return flag.NewFindFlagsDefault(500).WithPayload(
+					ErrorMessage("cannot query flags by tags. %s", err))
+			}
+			tx = tx.Where("id IN (?)", flagIDs)
+		}
 	}
-	if params.Limit != nil {
-		tx = tx.Limit(int(*params.Limit))
+	if params.IncludeDeleted != nil && *params.IncludeDeleted {
+		if !isAdminRequest(params.HTTPRequest) {
+			return flag.NewFindFlagsDefault(403).WithPayload(
+				ErrorMessage("only the admins can list the deleted flags"))
+		}
+		// it only unscopes the flags, the preloads are new queries that still hide the deleted segments and variants
+		tx = tx.Unscoped()
 	}
-	if params.Preload != nil && *params.Preload {
-		tx = entity.PreloadSegmentsVariants(tx)
+	if params.UnusedSince != nil {
+		d, err := util.ParseDuration(*params.UnusedSince)
+		if err != nil {
+			return flag.NewFindFlagsDefault(400).WithPayload(
+				ErrorMessage("cannot parse unusedSince. %s", err))
+		}
+		since := time.Now().UTC().Add(-d)
+		tx = tx.Where("last_evaluated_at < ? OR (last_evaluated_at IS NULL AND created_at < ?)", since, since)
+	}
+	searchTerms := []string{}
+	if params.Q != nil {
+		searchTerms = strings.Fields(strings.ToLower(*params.Q))
+	}
+	keyColumn := getRequestDB(params.HTTPRequest).Dialect().Quote("key")
+	for _, term := range searchTerms {
+		pattern := fmt.Sprintf("%%%s%%", term)
+		flagIDs, err := findFlagIDsWithTagLike(getRequestDB(params.HTTPRequest), pattern)
+		if err != nil {
+			return flag.NewFindFlagsDefault(500).WithPayload(
+				ErrorMessage("cannot search flags by tags. %s", err))
+		}
+		tx = tx.Where(
+			fmt.Sprintf("lower(%s) like ? OR lower(description) like ? OR id IN (?)", keyColumn),
+			pattern, pattern, flagIDs,
+		)
 	}
 	if params.DescriptionLike != nil {
 		tx = tx.Where(
@@ -98,13 +209,45 @@ func (c *crud) FindFlags(params flag.FindFlagsParams) middleware.Responder {
 			fmt.Sprintf("%%%s%%", strings.ToLower(*params.DescriptionLike)),
 		)
 	}
+	tx = whereNamespace(tx.Where(q), params.HTTPRequest)
+
+	total := int64(0)
+	if err := tx.Model(&entity.Flag{}).Count(&total).Error; err != nil {
+		return flag.NewFindFlagsDefault(500).WithPayload(
+			ErrorMessage("cannot count all flags. %s", err))
+	}
+
+	if params.Offset != nil {
+		tx = tx.Offset(int(*params.Offset))
+	}
+	limit := findFlagsLimit(params.Limit)
+	if len(searchTerms) > 0 {
+		if limit > config.Config.FlagsSearchMaxLimit {
+			limit = config.Config.FlagsSearchMaxLimit
+		}
+		tx = tx.Order(gorm.Expr(
+			fmt.Sprintf("CASE WHEN lower(%s) = ? THEN 0 ELSE 1 END", keyColumn),
+			strings.Join(searchTerms, " "),
+		))
+	}
+	tx = tx.Limit(limit)
+	if params.Preload != nil && *params.Preload {
+		tx = entity.PreloadSegmentsVariants(tx)
+	} else if params.Environment != nil {
+		tx = tx.Preload("Environments", "environment = ?", *params.Environment)
+	}
 
-	err := tx.Order("id").Where(q).Find(&fs).Error
+	err := tx.Order("id").Find(&fs).Error
 	if err != nil {
 		return flag.NewFindFlagsDefault(500).WithPayload(
 			ErrorMessage("cannot query all flags. %s", err))
 	}
-	resp := flag.NewFindFlagsOK()
+	if params.Environment != nil {
+		for i := range fs {
+			fs[i] = *fs[i].InEnvironment(*params.Environment)
+		}
+	}
+	resp := flag.NewFindFlagsOK().WithXTotalCount(total)
 	payload, err := e2rMapFlags(fs)
 	if err != nil {
 		return flag.NewFindFlagsDefault(500).WithPayload(
@@ -119,15 +262,34 @@ func (c *crud) CreateFlag(params flag.CreateFlagParams) middleware.Responder {
 	if params.Body != nil {
 		f.Description = util.SafeString(params.Body.Description)
 		f.CreatedBy = getSubjectFromRequest(params.HTTPRequest)
+		f.Namespace = getNamespaceFromRequest(params.HTTPRequest)
 
 		key, err := entity.CreateFlagKey(params.Body.Key)
 		if err != nil {
 			return flag.NewCreateFlagDefault(400).WithPayload(
 				ErrorMessage("cannot create flag. %s", err))
 		}
+		if params.Body.Key != "" {
+			exists, err := flagKeyExists(getRequestDB(params.HTTPRequest), key)
+			if err != nil {
+				return flag.NewCreateFlagDefault(500).WithPayload(
+					ErrorMessage("cannot create flag. %s", err))
+			}
+			if exists {
+				return flag.NewCreateFlagDefault(409).WithPayload(
+					ErrorMessage("cannot create flag. flag key %s already exists", key))
+			}
+		}
 		f.Key = key
+		if params.Body.DataRecordsEnabled != nil {
+			f.DataRecordsEnabled = *params.Body.DataRecordsEnabled
+		}
+		if params.Body.ExpiresAt != nil {
+			expiresAt := time.Time(*params.Body.ExpiresAt)
+			f.ExpiresAt = &expiresAt
+		}
 	}
-	err := getDB().Create(f).Error
+	err := getRequestDB(params.HTTPRequest).Create(f).Error
 	if err != nil {
 		return flag.NewCreateFlagDefault(500).WithPayload(
 			ErrorMessage("cannot create flag. %s", err))
@@ -141,17 +303,155 @@ func (c *crud) CreateFlag(params flag.CreateFlagParams) middleware.Responder {
 	}
 	resp.SetPayload(payload)
 
+	entity.SaveFlagHistory(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeFlag, f.ID, nil, f)
 	entity.SaveFlagSnapshot(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
 
+// SaveFlagsBatch saves all the flag definitions in one transaction
+func (c *crud) SaveFlagsBatch(params flag.SaveFlagsBatchParams) middleware.Responder {
+	actor := getSubjectFromRequest(params.HTTPRequest)
+	namespace := getNamespaceFromRequest(params.HTTPRequest)
+	results := make([]*models.SaveFlagsBatchResult, len(params.Body.Flags))
+	saved := make([]*entity.Flag, len(params.Body.Flags))
+	befores := make([]*entity.Flag, len(params.Body.Flags))
+	failed := false
+
+	tx := getRequestDB(params.HTTPRequest).Begin()
+	for i, def := range params.Body.Flags {
+		results[i] = &models.SaveFlagsBatchResult{Index: int64(i)}
+		f, before, err := saveFlagDefinition(tx, def, actor, namespace)
+		if err != nil {
+			results[i].Error = fmt.Sprintf(err.Message, err.Values...)
+			failed = true
+			continue
+		}
+		saved[i], befores[i] = f, before
+	}
+	if failed {
+		tx.Rollback()
+		return flag.NewSaveFlagsBatchBadRequest().WithPayload(
+			&models.SaveFlagsBatchResponse{Results: results})
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return flag.NewSaveFlagsBatchDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	for i, f := range saved {
+		payload, err := e2rMapFlag(f)
+		if err != nil {
+			return flag.NewSaveFlagsBatchDefault(500).WithPayload(ErrorMessage("cannot map flag. %s", err))
+		}
+		results[i].Flag = payload
+
+		after := *f
+		after.Segments, after.Variants, after.Tags = nil, nil, nil
+		entity.SaveFlagHistory(getDB(), f.ID, actor, entity.FlagHistoryEntityTypeFlag, f.ID, befores[i], &after)
+		entity.SaveFlagSnapshot(getDB(), f.ID, actor)
+	}
+
+	resp := flag.NewSaveFlagsBatchOK()
+	resp.SetPayload(&models.SaveFlagsBatchResponse{Results: results})
+	return resp
+}
+
+func (c *crud) ExportFlag(params flag.ExportFlagParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(getRequestDB(params.HTTPRequest)).First(f, params.FlagID).Error; err != nil {
+		return flag.NewExportFlagDefault(404).WithPayload(
+			ErrorMessage("cannot find flag %v. %s", params.FlagID, err))
+	}
+
+	resp := flag.NewExportFlagOK()
+	resp.SetPayload(e2rMapFlagDefinition(f))
+	return resp
+}
+
+// ImportFlag creates a new flag from the definition, an existing flag with
+// the same key is never overwritten, use SaveFlagsBatch for that
+func (c *crud) ImportFlag(params flag.ImportFlagParams) middleware.Responder {
+	def := *params.Body.Flag
+	if params.Body.Key != "" {
+		def.Key = params.Body.Key
+	}
+
+	f, e := createFlagFromDefinition(getRequestDB(params.HTTPRequest), &def, getSubjectFromRequest(params.HTTPRequest), getNamespaceFromRequest(params.HTTPRequest))
+	if e != nil {
+		return flag.NewImportFlagDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	resp := flag.NewImportFlagOK()
+	payload, err := e2rMapFlag(f)
+	if err != nil {
+		return flag.NewImportFlagDefault(500).WithPayload(ErrorMessage("cannot map flag. %s", err))
+	}
+	resp.SetPayload(payload)
+	return resp
+}
+
+// ValidateFlag validates the flag definition like ImportFlag and SaveFlagsBatch would, without saving
+// anything. All the errors are returned instead of the first one.
+func (c *crud) ValidateFlag(params flag.ValidateFlagParams) middleware.Responder {
+	errs, e := validateFlagDefinition(params.Body, getNamespaceFromRequest(params.HTTPRequest))
+	if e != nil {
+		return flag.NewValidateFlagDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	return flag.NewValidateFlagOK().WithPayload(&models.ValidateFlagResponse{
+		Valid:  util.BoolPtr(len(errs) == 0),
+		Errors: errs,
+	})
+}
+
+// CloneFlag copies the flag into a new disabled flag, the variants and
+// segments of the new flag get new ids
+func (c *crud) CloneFlag(params flag.CloneFlagParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(getRequestDB(params.HTTPRequest)).First(f, params.FlagID).Error; err != nil {
+		return flag.NewCloneFlagDefault(404).WithPayload(
+			ErrorMessage("cannot find flag %v. %s", params.FlagID, err))
+	}
+
+	def := e2rMapFlagDefinition(f)
+	def.Enabled = false
+	if params.Body != nil && params.Body.Key != "" {
+		def.Key = params.Body.Key
+	} else {
+		key, err := findCloneFlagKey(getRequestDB(params.HTTPRequest), f.Key)
+		if err != nil {
+			return flag.NewCloneFlagDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		def.Key = key
+	}
+
+	clone, e := createFlagFromDefinition(getRequestDB(params.HTTPRequest), def, getSubjectFromRequest(params.HTTPRequest), f.Namespace)
+	if e != nil {
+		return flag.NewCloneFlagDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	resp := flag.NewCloneFlagOK()
+	payload, err := e2rMapFlag(clone)
+	if err != nil {
+		return flag.NewCloneFlagDefault(500).WithPayload(ErrorMessage("cannot map flag. %s", err))
+	}
+	resp.SetPayload(payload)
+	return resp
+}
+
 func (c *crud) GetFlag(params flag.GetFlagParams) middleware.Responder {
 	f := &entity.Flag{}
-	err := entity.PreloadSegmentsVariants(getDB()).First(f, params.FlagID).Error
+	err := entity.PreloadSegmentsVariants(getRequestDB(params.HTTPRequest)).First(f, params.FlagID).Error
 	if err != nil {
 		return flag.NewGetFlagDefault(404).WithPayload(
 			ErrorMessage("cannot find flag %v. %s", params.FlagID, err))
 	}
+	if params.Environment != nil {
+		if e := validateEnvironment(*params.Environment); e != nil {
+			return flag.NewGetFlagDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+		f = f.InEnvironment(*params.Environment)
+	}
 
 	resp := flag.NewGetFlagOK()
 	payload, err := e2rMapFlag(f)
@@ -163,13 +463,34 @@ func (c *crud) GetFlag(params flag.GetFlagParams) middleware.Responder {
 	return resp
 }
 
+// GetFlagSnapshots lists the snapshots of the flag created in the range [from, to), the latest first
 func (c *crud) GetFlagSnapshots(params flag.GetFlagSnapshotsParams) middleware.Responder {
+	tx := getRequestDB(params.HTTPRequest).
+		Model(&entity.FlagSnapshot{}).
+		Where(entity.FlagSnapshot{FlagID: util.SafeUint(params.FlagID)})
+	if params.From != nil {
+		tx = tx.Where("created_at >= ?", time.Time(*params.From))
+	}
+	if params.To != nil {
+		tx = tx.Where("created_at < ?", time.Time(*params.To))
+	}
+
+	total := int64(0)
+	if err := tx.Count(&total).Error; err != nil {
+		return flag.NewGetFlagSnapshotsDefault(500).WithPayload(
+			ErrorMessage("cannot count flag snapshots for %v. %s", params.FlagID, err))
+	}
+
+	tx = tx.Order("created_at desc").Order("id desc")
+	if params.Limit != nil {
+		tx = tx.Limit(int(*params.Limit))
+	}
+	if params.Offset != nil {
+		tx = tx.Offset(int(*params.Offset))
+	}
+
 	fs := []entity.FlagSnapshot{}
-	err := getDB().
-		Order("created_at desc").
-		Where(entity.FlagSnapshot{FlagID: util.SafeUint(params.FlagID)}).
-		Find(&fs).Error
-	if err != nil {
+	if err := tx.Find(&fs).Error; err != nil {
 		return flag.NewGetFlagSnapshotsDefault(500).WithPayload(
 			ErrorMessage("cannot find flag snapshots for %v. %s", params.FlagID, err))
 	}
@@ -178,14 +499,116 @@ func (c *crud) GetFlagSnapshots(params flag.GetFlagSnapshotsParams) middleware.R
 		return flag.NewGetFlagSnapshotsDefault(500).WithPayload(
 			ErrorMessage("cannot map flag snapshots for flagID %v. %s", params.FlagID, err))
 	}
-	resp := flag.NewGetFlagSnapshotsOK()
+	resp := flag.NewGetFlagSnapshotsOK().WithXTotalCount(total)
 	resp.SetPayload(payload)
 	return resp
 }
 
+// GetFlagSnapshotsDiff diffs the snapshots from and to of the flag, either of them can be the older one
+func (c *crud) GetFlagSnapshotsDiff(params flag.GetFlagSnapshotsDiffParams) middleware.Responder {
+	snapshots := make([]*entity.FlagSnapshot, 2)
+	for i, id := range []int64{params.From, params.To} {
+		fs := &entity.FlagSnapshot{}
+		if err := getRequestDB(params.HTTPRequest).First(fs, id).Error; err != nil {
+			return flag.NewGetFlagSnapshotsDiffDefault(404).WithPayload(
+				ErrorMessage("cannot find snapshot %v. %s", id, err))
+		}
+		if fs.FlagID != util.SafeUint(params.FlagID) {
+			return flag.NewGetFlagSnapshotsDiffDefault(400).WithPayload(
+				ErrorMessage("snapshot %v does not belong to flag %v", id, params.FlagID))
+		}
+		snapshots[i] = fs
+	}
+
+	diff, err := entity.DiffFlagSnapshots(snapshots[0], snapshots[1])
+	if err != nil {
+		return flag.NewGetFlagSnapshotsDiffDefault(500).WithPayload(
+			ErrorMessage("cannot diff snapshots %v and %v. %s", params.From, params.To, err))
+	}
+
+	resp := flag.NewGetFlagSnapshotsDiffOK()
+	resp.SetPayload(e2r.MapFlagSnapshotDiff(snapshots[0].ID, snapshots[1].ID, diff))
+	return resp
+}
+
+func (c *crud) GetFlagHistory(params flag.GetFlagHistoryParams) middleware.Responder {
+	tx := getRequestDB(params.HTTPRequest).
+		Order("created_at desc").
+		Order("id desc").
+		Where(entity.FlagHistory{FlagID: util.SafeUint(params.FlagID)})
+	if params.Limit != nil {
+		tx = tx.Limit(int(*params.Limit))
+	}
+	if params.Offset != nil {
+		tx = tx.Offset(int(*params.Offset))
+	}
+
+	fhs := []entity.FlagHistory{}
+	if err := tx.Find(&fhs).Error; err != nil {
+		return flag.NewGetFlagHistoryDefault(500).WithPayload(
+			ErrorMessage("cannot find flag history for %v. %s", params.FlagID, err))
+	}
+	payload, err := e2rMapFlagHistories(fhs)
+	if err != nil {
+		return flag.NewGetFlagHistoryDefault(500).WithPayload(
+			ErrorMessage("cannot map flag history for flagID %v. %s", params.FlagID, err))
+	}
+	resp := flag.NewGetFlagHistoryOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+func (c *crud) FindFlagSchedules(params flag.FindFlagSchedulesParams) middleware.Responder {
+	ss := []entity.FlagSchedule{}
+	err := getRequestDB(params.HTTPRequest).
+		Where(entity.FlagSchedule{FlagID: util.SafeUint(params.FlagID)}).
+		Order("scheduled_at").
+		Order("id").
+		Find(&ss).Error
+	if err != nil {
+		return flag.NewFindFlagSchedulesDefault(500).WithPayload(
+			ErrorMessage("cannot find the schedules of flag %v. %s", params.FlagID, err))
+	}
+	resp := flag.NewFindFlagSchedulesOK()
+	resp.SetPayload(e2r.MapFlagSchedules(ss))
+	return resp
+}
+
+func (c *crud) CreateFlagSchedule(params flag.CreateFlagScheduleParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
+		return flag.NewCreateFlagScheduleDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	scheduledAt := time.Time(*params.Body.ScheduledAt)
+	if !scheduledAt.After(time.Now()) {
+		return flag.NewCreateFlagScheduleDefault(400).WithPayload(
+			ErrorMessage("scheduledAt %s is not in the future", params.Body.ScheduledAt))
+	}
+
+	if config.Config.ApprovalRequired && util.SafeString(params.Body.Action) == entity.FlagScheduleActionEnable {
+		return flag.NewCreateFlagScheduleDefault(403).WithPayload(
+			ErrorMessage("cannot schedule enabling flag %v, it requires an approval with FLAGR_APPROVAL_REQUIRED", f.ID))
+	}
+
+	s := &entity.FlagSchedule{
+		FlagID:      f.ID,
+		Action:      util.SafeString(params.Body.Action),
+		ScheduledAt: scheduledAt,
+		CreatedBy:   getSubjectFromRequest(params.HTTPRequest),
+	}
+	if err := getRequestDB(params.HTTPRequest).Create(s).Error; err != nil {
+		return flag.NewCreateFlagScheduleDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := flag.NewCreateFlagScheduleOK()
+	resp.SetPayload(e2r.MapFlagSchedule(s))
+	return resp
+}
+
 func (c *crud) GetFlagEntityTypes(params flag.GetFlagEntityTypesParams) middleware.Responder {
 	entityTypes := []entity.FlagEntityType{}
-	if err := getDB().Order("key").Find(&entityTypes).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Order("key").Find(&entityTypes).Error; err != nil {
 		return flag.NewGetFlagEntityTypesDefault(500).WithPayload(
 			ErrorMessage("cannot find flag entity types. err:%s", err))
 
@@ -202,11 +625,12 @@ func (c *crud) GetFlagEntityTypes(params flag.GetFlagEntityTypesParams) middlewa
 
 func (c *crud) PutFlag(params flag.PutFlagParams) middleware.Responder {
 	f := &entity.Flag{}
-	tx := getDB()
+	tx := getRequestDB(params.HTTPRequest)
 
 	if err := tx.First(f, params.FlagID).Error; err != nil {
 		return flag.NewPutFlagDefault(404).WithPayload(ErrorMessage("%s", err))
 	}
+	before := *f
 
 	if params.Body.Description != nil {
 		f.Description = *params.Body.Description
@@ -219,6 +643,15 @@ func (c *crud) PutFlag(params flag.PutFlagParams) middleware.Responder {
 		if err != nil {
 			return flag.NewPutFlagDefault(400).WithPayload(ErrorMessage("%s", err))
 		}
+		if key != f.Key {
+			exists, err := flagKeyExists(tx, key)
+			if err != nil {
+				return flag.NewPutFlagDefault(500).WithPayload(ErrorMessage("%s", err))
+			}
+			if exists {
+				return flag.NewPutFlagDefault(409).WithPayload(ErrorMessage("flag key %s already exists", key))
+			}
+		}
 		f.Key = key
 	}
 	if params.Body.EntityType != nil {
@@ -233,9 +666,75 @@ func (c *crud) PutFlag(params flag.PutFlagParams) middleware.Responder {
 		f.Notes = *params.Body.Notes
 	}
 
+	if params.Body.BucketingSeed != nil {
+		f.BucketingSeed = *params.Body.BucketingSeed
+	}
+
+	if params.Body.BucketingAlgorithm != nil {
+		if err := entity.ValidateBucketingAlgorithm(*params.Body.BucketingAlgorithm); err != nil {
+			return flag.NewPutFlagDefault(400).WithPayload(ErrorMessage("%s", err))
+		}
+		f.BucketingAlgorithm = *params.Body.BucketingAlgorithm
+	}
+
+	if params.Body.BucketBy != nil {
+		f.BucketBy = *params.Body.BucketBy
+	}
+
+	if params.Body.DefaultVariantID != nil {
+		vID := util.SafeUint(*params.Body.DefaultVariantID)
+		if vID != 0 {
+			v := &entity.Variant{}
+			if err := tx.Where(entity.Variant{FlagID: f.ID}).First(v, vID).Error; err != nil {
+				return flag.NewPutFlagDefault(400).WithPayload(ErrorMessage("error finding variantID %v under this flag. reason %s", vID, err))
+			}
+		}
+		f.DefaultVariantID = vID
+	}
+
+	if params.Body.PrerequisiteFlagID != nil || params.Body.PrerequisiteVariantKey != nil {
+		if params.Body.PrerequisiteFlagID != nil {
+			f.PrerequisiteFlagID = util.SafeUint(*params.Body.PrerequisiteFlagID)
+		}
+		if params.Body.PrerequisiteVariantKey != nil {
+			f.PrerequisiteVariantKey = *params.Body.PrerequisiteVariantKey
+		}
+		if e := validatePrerequisiteFlag(f); e != nil {
+			return flag.NewPutFlagDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
+
+	if params.Body.ExpiresAt != nil {
+		expiresAt := time.Time(*params.Body.ExpiresAt)
+		f.ExpiresAt = &expiresAt
+		if expiresAt.IsZero() {
+			f.ExpiresAt = nil
+		}
+	}
+
+	if f.BucketingSeed != before.BucketingSeed || f.BucketingAlgorithm != before.BucketingAlgorithm || f.BucketBy != before.BucketBy ||
+		f.DefaultVariantID != before.DefaultVariantID ||
+		f.PrerequisiteFlagID != before.PrerequisiteFlagID || f.PrerequisiteVariantKey != before.PrerequisiteVariantKey {
+		if e := validateFlagWithoutApproval(tx, f.ID, "change the bucketing, the default variant or the prerequisite of flag %v", f.ID); e != nil {
+			return flag.NewPutFlagDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
+
+	if params.Body.AttachmentSchema != nil {
+		schema, err := r2eMapAttachmentSchema(params.Body.AttachmentSchema)
+		if err != nil {
+			return flag.NewPutFlagDefault(400).WithPayload(ErrorMessage("%s", err))
+		}
+		if e := validateAttachmentSchema(tx, f.ID, schema); e != nil {
+			return flag.NewPutFlagDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+		f.AttachmentSchema = schema
+	}
+
 	if err := tx.Save(f).Error; err != nil {
 		return flag.NewPutFlagDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
+	after := *f
 
 	if err := entity.PreloadSegmentsVariants(tx).First(f, params.FlagID).Error; err != nil {
 		return flag.NewPutFlagDefault(500).WithPayload(ErrorMessage("%s", err))
@@ -248,19 +747,42 @@ func (c *crud) PutFlag(params flag.PutFlagParams) middleware.Responder {
 	}
 	resp.SetPayload(payload)
 
+	entity.SaveFlagHistoryWithNote(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), params.Body.Note, entity.FlagHistoryEntityTypeFlag, f.ID, &before, &after)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
 
 func (c *crud) SetFlagEnabledState(params flag.SetFlagEnabledParams) middleware.Responder {
+	change, e := requestEnabledChange(params.HTTPRequest, util.SafeUint(params.FlagID), util.SafeString(params.Environment), *params.Body.Enabled, params.Body.Note)
+	if e != nil {
+		return flag.NewSetFlagEnabledDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if change != nil {
+		return flag.NewSetFlagEnabledAccepted().WithPayload(change)
+	}
+
+	if params.Environment != nil {
+		f, e := setFlagEnvironmentEnabled(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), *params.Environment,
+			*params.Body.Enabled, getSubjectFromRequest(params.HTTPRequest), params.Body.Note)
+		if e != nil {
+			return flag.NewSetFlagEnabledDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+		payload, err := e2rMapFlag(f)
+		if err != nil {
+			return flag.NewSetFlagEnabledDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		return flag.NewSetFlagEnabledOK().WithPayload(payload)
+	}
+
 	f := &entity.Flag{}
-	if err := getDB().First(f, params.FlagID).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
 		return flag.NewSetFlagEnabledDefault(404).WithPayload(ErrorMessage("%s", err))
 	}
+	before := *f
 
 	f.Enabled = *params.Body.Enabled
 
-	if err := getDB().Save(f).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Save(f).Error; err != nil {
 		return flag.NewSetFlagEnabledDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
@@ -271,25 +793,255 @@ func (c *crud) SetFlagEnabledState(params flag.SetFlagEnabledParams) middleware.
 	}
 	resp.SetPayload(payload)
 
+	entity.SaveFlagHistoryWithNote(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), params.Body.Note, entity.FlagHistoryEntityTypeFlag, f.ID, &before, f)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
 
+// PatchFlagEnabled updates only the enabled column of the flag, so that it doesn't overwrite the other changes
+// of the flag made in the meantime, and it reloads the flag in the eval cache right away
+func (c *crud) PatchFlagEnabled(params flag.PatchFlagEnabledParams) middleware.Responder {
+	change, e := requestEnabledChange(params.HTTPRequest, util.SafeUint(params.FlagID), util.SafeString(params.Environment), *params.Body.Enabled, params.Body.Note)
+	if e != nil {
+		return flag.NewPatchFlagEnabledDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if change != nil {
+		return flag.NewPatchFlagEnabledAccepted().WithPayload(change)
+	}
+
+	if params.Environment != nil {
+		f, e := setFlagEnvironmentEnabled(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), *params.Environment,
+			*params.Body.Enabled, getSubjectFromRequest(params.HTTPRequest), params.Body.Note)
+		if e != nil {
+			return flag.NewPatchFlagEnabledDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+		payload, err := e2rMapFlag(f)
+		if err != nil {
+			return flag.NewPatchFlagEnabledDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		if err := GetEvalCache().reloadFlag(f.ID); err != nil {
+			logrus.WithFields(logrus.Fields{"err": err, "flagID": f.ID}).Error("failed to reload the flag in the eval cache")
+		}
+		return flag.NewPatchFlagEnabledOK().WithPayload(payload)
+	}
+
+	before := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(before, params.FlagID).Error; err != nil {
+		return flag.NewPatchFlagEnabledDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	err := getRequestDB(params.HTTPRequest).Model(&entity.Flag{}).
+		Where("id = ?", before.ID).
+		Update("enabled", *params.Body.Enabled).Error
+	if err != nil {
+		return flag.NewPatchFlagEnabledDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, before.ID).Error; err != nil {
+		return flag.NewPatchFlagEnabledDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := flag.NewPatchFlagEnabledOK()
+	payload, err := e2rMapFlag(f)
+	if err != nil {
+		return flag.NewPatchFlagEnabledDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp.SetPayload(payload)
+
+	entity.SaveFlagHistoryWithNote(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), params.Body.Note, entity.FlagHistoryEntityTypeFlag, f.ID, before, f)
+	entity.SaveFlagSnapshot(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest))
+	if err := GetEvalCache().reloadFlag(f.ID); err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "flagID": f.ID}).Error("failed to reload the flag in the eval cache")
+	}
+	return resp
+}
+
+func (c *crud) PutFlagOverride(params flag.PutFlagOverrideParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
+		return flag.NewPutFlagOverrideDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+	before := *f
+
+	vID := util.SafeUint(params.Body.VariantID)
+	if vID != 0 {
+		v := &entity.Variant{}
+		if err := getRequestDB(params.HTTPRequest).Where(entity.Variant{FlagID: f.ID}).First(v, vID).Error; err != nil {
+			return flag.NewPutFlagOverrideDefault(400).WithPayload(ErrorMessage("error finding variantID %v under this flag. reason %s", vID, err))
+		}
+	}
+	f.OverrideVariantID = vID
+	f.OverrideEnabled = vID != 0 && params.Body.Enabled
+	if f.OverrideEnabled {
+		enabled, err := isFlagEnabledAnywhere(getRequestDB(params.HTTPRequest), f)
+		if err != nil {
+			return flag.NewPutFlagOverrideDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		if e := validateWithoutApproval(enabled, "override flag %v", f.ID); e != nil {
+			return flag.NewPutFlagOverrideDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
+
+	if err := getRequestDB(params.HTTPRequest).Save(f).Error; err != nil {
+		return flag.NewPutFlagOverrideDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := flag.NewPutFlagOverrideOK()
+	payload, err := e2rMapFlag(f)
+	if err != nil {
+		return flag.NewPutFlagOverrideDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp.SetPayload(payload)
+
+	entity.SaveFlagHistory(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeFlag, f.ID, &before, f)
+	entity.SaveFlagSnapshot(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest))
+	return resp
+}
+
 func (c *crud) DeleteFlag(params flag.DeleteFlagParams) middleware.Responder {
-	if err := getDB().Delete(&entity.Flag{}, params.FlagID).Error; err != nil {
+	before := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(before, params.FlagID).Error; err != nil {
+		before = nil
+	}
+
+	if err := getRequestDB(params.HTTPRequest).Delete(&entity.Flag{}, params.FlagID).Error; err != nil {
 		return flag.NewDeleteFlagDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
+
+	if before != nil {
+		entity.SaveFlagHistory(getDB(), before.ID, getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeFlag, before.ID, before, nil)
+	}
 	return flag.NewDeleteFlagOK()
 }
 
+// RestoreFlag undeletes the soft deleted flag. The segments and variants
+// are untouched by DeleteFlag, so they come back with the flag.
+func (c *crud) RestoreFlag(params flag.RestoreFlagParams) middleware.Responder {
+	if !isAdminRequest(params.HTTPRequest) {
+		return flag.NewRestoreFlagDefault(403).WithPayload(ErrorMessage("only the admins can restore the deleted flags"))
+	}
+
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).Unscoped().First(f, params.FlagID).Error; err != nil {
+		return flag.NewRestoreFlagDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+	if f.DeletedAt == nil {
+		return flag.NewRestoreFlagDefault(400).WithPayload(ErrorMessage("flag %v is not deleted", params.FlagID))
+	}
+	before := *f
+
+	if err := getRequestDB(params.HTTPRequest).Unscoped().Model(f).Update("deleted_at", nil).Error; err != nil {
+		return flag.NewRestoreFlagDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	if err := entity.PreloadSegmentsVariants(getRequestDB(params.HTTPRequest)).First(f, params.FlagID).Error; err != nil {
+		return flag.NewRestoreFlagDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := flag.NewRestoreFlagOK()
+	payload, err := e2rMapFlag(f)
+	if err != nil {
+		return flag.NewRestoreFlagDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp.SetPayload(payload)
+
+	after := *f
+	after.Segments, after.Variants, after.Tags = nil, nil, nil
+	entity.SaveFlagHistory(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeFlag, f.ID, &before, &after)
+	entity.SaveFlagSnapshot(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest))
+	return resp
+}
+
+// RestoreFlagSnapshot rolls the variants, segments, constraints and distributions
+// of the flag back to the snapshot, the other properties of the live flag are kept
+func (c *crud) RestoreFlagSnapshot(params flag.RestoreFlagSnapshotParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(getRequestDB(params.HTTPRequest)).First(f, params.FlagID).Error; err != nil {
+		return flag.NewRestoreFlagSnapshotDefault(404).WithPayload(
+			ErrorMessage("cannot find flag %v. %s", params.FlagID, err))
+	}
+
+	fs := &entity.FlagSnapshot{}
+	if err := getRequestDB(params.HTTPRequest).First(fs, params.SnapshotID).Error; err != nil {
+		return flag.NewRestoreFlagSnapshotDefault(404).WithPayload(
+			ErrorMessage("cannot find snapshot %v. %s", params.SnapshotID, err))
+	}
+	if fs.FlagID != f.ID {
+		return flag.NewRestoreFlagSnapshotDefault(400).WithPayload(
+			ErrorMessage("snapshot %v does not belong to flag %v", params.SnapshotID, params.FlagID))
+	}
+	enabled, err := isFlagEnabledAnywhere(getRequestDB(params.HTTPRequest), f)
+	if err != nil {
+		return flag.NewRestoreFlagSnapshotDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if e := validateWithoutApproval(enabled, "restore snapshot %v of flag %v", fs.ID, f.ID); e != nil {
+		return flag.NewRestoreFlagSnapshotDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	snapshot := &entity.Flag{}
+	if err := json.Unmarshal(fs.Flag, snapshot); err != nil {
+		return flag.NewRestoreFlagSnapshotDefault(500).WithPayload(
+			ErrorMessage("cannot parse snapshot %v. %s", params.SnapshotID, err))
+	}
+	if e := resolveSnapshotDistributions(snapshot); e != nil {
+		return flag.NewRestoreFlagSnapshotDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	snapshotDef := e2rMapFlagDefinition(snapshot)
+	def := e2rMapFlagDefinition(f)
+	def.DefaultVariantKey = snapshotDef.DefaultVariantKey
+	def.Variants = snapshotDef.Variants
+	def.Segments = snapshotDef.Segments
+
+	actor := getSubjectFromRequest(params.HTTPRequest)
+	before := *f
+	before.Segments, before.Variants, before.Tags = nil, nil, nil
+	entity.SaveFlagSnapshot(getDB(), f.ID, actor)
+
+	tx := getRequestDB(params.HTTPRequest).Begin()
+	f, _, e := saveFlagDefinition(tx, def, actor, f.Namespace)
+	if e != nil {
+		tx.Rollback()
+		return flag.NewRestoreFlagSnapshotDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return flag.NewRestoreFlagSnapshotDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := flag.NewRestoreFlagSnapshotOK()
+	payload, err := e2rMapFlag(f)
+	if err != nil {
+		return flag.NewRestoreFlagSnapshotDefault(500).WithPayload(ErrorMessage("cannot map flag. %s", err))
+	}
+	resp.SetPayload(payload)
+
+	after := *f
+	after.Segments, after.Variants, after.Tags = nil, nil, nil
+	entity.SaveFlagHistory(getDB(), f.ID, actor, entity.FlagHistoryEntityTypeFlag, f.ID, &before, &after)
+	entity.SaveFlagSnapshot(getDB(), f.ID, actor)
+	return resp
+}
+
 func (c *crud) CreateSegment(params segment.CreateSegmentParams) middleware.Responder {
 	s := &entity.Segment{}
 	s.FlagID = uint(params.FlagID)
 	s.RolloutPercent = uint(*params.Body.RolloutPercent)
 	s.Description = util.SafeString(params.Body.Description)
 	s.Rank = entity.SegmentDefaultRank
+	s.ConstraintGroupID = util.SafeUint(params.Body.ConstraintGroupID)
+	if e := validateConstraintGroupID(params.HTTPRequest, s.ConstraintGroupID); e != nil {
+		return segment.NewCreateSegmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if e := validateNewSegment(getRequestDB(params.HTTPRequest), s.FlagID, 0); e != nil {
+		return segment.NewCreateSegmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if e := validateFlagWithoutApproval(getRequestDB(params.HTTPRequest), s.FlagID, "create a segment of flag %v", s.FlagID); e != nil {
+		return segment.NewCreateSegmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
 
-	err := getDB().Create(s).Error
+	err := getRequestDB(params.HTTPRequest).Create(s).Error
 	if err != nil {
 		return segment.NewCreateSegmentDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
@@ -297,6 +1049,7 @@ func (c *crud) CreateSegment(params segment.CreateSegmentParams) middleware.Resp
 	resp := segment.NewCreateSegmentOK()
 	resp.SetPayload(e2r.MapSegment(s))
 
+	entity.SaveFlagHistory(getDB(), s.FlagID, getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeSegment, s.ID, nil, s)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
@@ -304,7 +1057,7 @@ func (c *crud) CreateSegment(params segment.CreateSegmentParams) middleware.Resp
 func (c *crud) FindSegments(params segment.FindSegmentsParams) middleware.Responder {
 	ss := []entity.Segment{}
 	err := entity.
-		PreloadConstraintsDistribution(getDB()).
+		PreloadConstraintsDistribution(getRequestDB(params.HTTPRequest)).
 		Order("rank").
 		Order("id").
 		Where(entity.Segment{FlagID: uint(params.FlagID)}).
@@ -323,36 +1076,65 @@ func (c *crud) FindSegments(params segment.FindSegmentsParams) middleware.Respon
 func (c *crud) PutSegment(params segment.PutSegmentParams) middleware.Responder {
 	s := &entity.Segment{}
 	err := entity.
-		PreloadConstraintsDistribution(getDB()).
+		PreloadConstraintsDistribution(getRequestDB(params.HTTPRequest)).
+		Where("flag_id = ?", params.FlagID).
 		First(s, params.SegmentID).
 		Error
+	if gorm.IsRecordNotFoundError(err) {
+		return segment.NewPutSegmentDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
 	if err != nil {
 		return segment.NewPutSegmentDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
+	before := *s
 
 	s.RolloutPercent = util.SafeUint(params.Body.RolloutPercent)
 	s.Description = util.SafeString(params.Body.Description)
+	if params.Body.ConstraintGroupID != nil {
+		s.ConstraintGroupID = util.SafeUint(params.Body.ConstraintGroupID)
+		if e := validateConstraintGroupID(params.HTTPRequest, s.ConstraintGroupID); e != nil {
+			return segment.NewPutSegmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
+	if s.RolloutPercent != before.RolloutPercent || s.ConstraintGroupID != before.ConstraintGroupID {
+		if e := validateFlagWithoutApproval(getRequestDB(params.HTTPRequest), s.FlagID, "put segment %v of flag %v", s.ID, s.FlagID); e != nil {
+			return segment.NewPutSegmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
 
-	if err := getDB().Save(s).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Save(s).Error; err != nil {
 		return segment.NewPutSegmentDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
 	resp := segment.NewPutSegmentOK()
 	resp.SetPayload(e2r.MapSegment(s))
 
+	entity.SaveFlagHistory(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeSegment, s.ID, &before, s)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
 
 func (c *crud) PutSegmentsReorder(params segment.PutSegmentsReorderParams) middleware.Responder {
-	tx := getDB().Begin()
+	if e := validatePutSegmentsReorder(params); e != nil {
+		return segment.NewPutSegmentsReorderDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if e := validateFlagWithoutApproval(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), "reorder the segments of flag %v", params.FlagID); e != nil {
+		return segment.NewPutSegmentsReorderDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	befores := []entity.Segment{}
+	afters := []entity.Segment{}
+
+	tx := getRequestDB(params.HTTPRequest).Begin()
 	for i, segmentID := range params.Body.SegmentIds {
 		s := &entity.Segment{}
-		if err := tx.First(s, segmentID).Error; err != nil {
+		if err := tx.Where("flag_id = ?", params.FlagID).First(s, segmentID).Error; err != nil {
 			tx.Rollback()
 			return segment.NewPutSegmentsReorderDefault(404).WithPayload(ErrorMessage("%s", err))
 		}
+		befores = append(befores, *s)
 		s.Rank = uint(i)
+		afters = append(afters, *s)
 		if err := tx.Save(s).Error; err != nil {
 			tx.Rollback()
 			return segment.NewPutSegmentsReorderDefault(500).WithPayload(ErrorMessage("%s", err))
@@ -364,16 +1146,28 @@ func (c *crud) PutSegmentsReorder(params segment.PutSegmentsReorderParams) middl
 		return segment.NewPutSegmentsReorderDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
+	for i := range befores {
+		entity.SaveFlagHistory(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeSegment, befores[i].ID, &befores[i], &afters[i])
+	}
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 
 	return segment.NewPutSegmentsReorderOK()
 }
 
 func (c *crud) DeleteSegment(params segment.DeleteSegmentParams) middleware.Responder {
-	if err := getDB().Delete(&entity.Segment{}, util.SafeUint(params.SegmentID)).Error; err != nil {
+	before := &entity.Segment{}
+	if err := getRequestDB(params.HTTPRequest).Where("flag_id = ?", params.FlagID).First(before, util.SafeUint(params.SegmentID)).Error; err != nil {
+		return segment.NewDeleteSegmentDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+	if e := validateFlagWithoutApproval(getRequestDB(params.HTTPRequest), before.FlagID, "delete segment %v of flag %v", before.ID, before.FlagID); e != nil {
+		return segment.NewDeleteSegmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	if err := getRequestDB(params.HTTPRequest).Delete(&entity.Segment{}, before.ID).Error; err != nil {
 		return segment.NewDeleteSegmentDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
+	entity.SaveFlagHistory(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeSegment, before.ID, before, nil)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return segment.NewDeleteSegmentOK()
 }
@@ -386,23 +1180,34 @@ func (c *crud) CreateConstraint(params constraint.CreateConstraintParams) middle
 		cons.Operator = util.SafeString(params.Body.Operator)
 		cons.Value = util.SafeString(params.Body.Value)
 	}
+	if e := validateFlagSegment(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), cons.SegmentID); e != nil {
+		return constraint.NewCreateConstraintDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
 	if err := cons.Validate(); err != nil {
 		return constraint.NewCreateConstraintDefault(400).WithPayload(ErrorMessage("%s", err))
 	}
-	if err := getDB().Create(cons).Error; err != nil {
+	if e := validateNewConstraint(getRequestDB(params.HTTPRequest), cons.SegmentID); e != nil {
+		return constraint.NewCreateConstraintDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if err := getRequestDB(params.HTTPRequest).Create(cons).Error; err != nil {
 		return constraint.NewCreateConstraintDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
 	resp := constraint.NewCreateConstraintOK()
 	resp.SetPayload(e2r.MapConstraint(cons))
 
+	entity.SaveFlagHistory(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeConstraint, cons.ID, nil, cons)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
 
 func (c *crud) FindConstraints(params constraint.FindConstraintsParams) middleware.Responder {
+	if e := validateFlagSegment(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID)); e != nil {
+		return constraint.NewFindConstraintsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
 	cs := []entity.Constraint{}
-	if err := getDB().Order("created_at").Where(entity.Constraint{SegmentID: uint(params.SegmentID)}).Find(&cs).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Order("created_at").Where(entity.Constraint{SegmentID: uint(params.SegmentID)}).Find(&cs).Error; err != nil {
 		return constraint.NewFindConstraintsDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
@@ -412,11 +1217,15 @@ func (c *crud) FindConstraints(params constraint.FindConstraintsParams) middlewa
 }
 
 func (c *crud) PutConstraint(params constraint.PutConstraintParams) middleware.Responder {
-	cons := &entity.Constraint{}
+	if e := validateFlagSegment(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID)); e != nil {
+		return constraint.NewPutConstraintDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
 
-	if err := getDB().First(cons, params.ConstraintID).Error; err != nil {
+	cons := &entity.Constraint{}
+	if err := getRequestDB(params.HTTPRequest).Where("segment_id = ?", params.SegmentID).First(cons, params.ConstraintID).Error; err != nil {
 		return constraint.NewPutConstraintDefault(404).WithPayload(ErrorMessage("%s", err))
 	}
+	before := *cons
 
 	if params.Body != nil {
 		cons.Property = util.SafeString(params.Body.Property)
@@ -427,24 +1236,35 @@ func (c *crud) PutConstraint(params constraint.PutConstraintParams) middleware.R
 		return constraint.NewPutConstraintDefault(400).WithPayload(ErrorMessage("%s", err))
 	}
 
-	if err := getDB().Save(&cons).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Save(&cons).Error; err != nil {
 		return constraint.NewPutConstraintDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
 	resp := constraint.NewPutConstraintOK()
 	resp.SetPayload(e2r.MapConstraint(cons))
 
+	entity.SaveFlagHistory(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeConstraint, cons.ID, &before, cons)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
 
 func (c *crud) DeleteConstraint(params constraint.DeleteConstraintParams) middleware.Responder {
-	if err := getDB().Delete(entity.Constraint{}, params.ConstraintID).Error; err != nil {
+	if e := validateFlagSegment(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID)); e != nil {
+		return constraint.NewDeleteConstraintDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	before := &entity.Constraint{}
+	if err := getRequestDB(params.HTTPRequest).Where("segment_id = ?", params.SegmentID).First(before, params.ConstraintID).Error; err != nil {
+		return constraint.NewDeleteConstraintDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	if err := getRequestDB(params.HTTPRequest).Delete(entity.Constraint{}, before.ID).Error; err != nil {
 		return constraint.NewDeleteConstraintDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
 	resp := constraint.NewDeleteConstraintOK()
 
+	entity.SaveFlagHistory(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeConstraint, before.ID, before, nil)
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return resp
 }
@@ -455,39 +1275,174 @@ func (c *crud) PutDistributions(params distribution.PutDistributionsParams) midd
 		return distribution.NewPutDistributionsDefault(err.StatusCode).WithPayload(ErrorMessage("%s", err))
 	}
 
-	segmentID := uint(params.SegmentID)
+	ds := r2eMapDistributions(params.Body.Distributions, uint(params.SegmentID))
+	if params.Environment != nil {
+		if e := validateEnvironment(*params.Environment); e != nil {
+			return distribution.NewPutDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+		for _, d := range ds {
+			if d.HasRolloutSchedule() {
+				return distribution.NewPutDistributionsDefault(400).WithPayload(
+					ErrorMessage("the rollout schedules are not supported in the environments"))
+			}
+		}
+	}
 
-	tx := getDB().Begin()
-	err := tx.Delete(entity.Distribution{}, "segment_id = ?", segmentID).Error
-	if err != nil {
-		tx.Rollback()
-		return distribution.NewPutDistributionsDefault(500).WithPayload(ErrorMessage("%s", err))
+	change, e := requestDistributionsChange(params.HTTPRequest, util.SafeUint(params.FlagID), uint(params.SegmentID), util.SafeString(params.Environment), ds)
+	if e != nil {
+		return distribution.NewPutDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if change != nil {
+		return distribution.NewPutDistributionsAccepted().WithPayload(change)
 	}
 
-	ds := r2eMapDistributions(params.Body.Distributions, segmentID)
-	for _, d := range ds {
-		err1 := tx.Create(&d).Error
-		if err1 != nil {
-			tx.Rollback()
-			return distribution.NewPutDistributionsDefault(500).WithPayload(ErrorMessage("%s", err))
+	if params.Environment != nil {
+		eds, e := replaceFlagEnvironmentDistributions(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID), *params.Environment, ds, getSubjectFromRequest(params.HTTPRequest))
+		if e != nil {
+			return distribution.NewPutDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
 		}
+		return distribution.NewPutDistributionsOK().WithPayload(e2r.MapDistributions(eds))
 	}
-	err = tx.Commit().Error
-	if err != nil {
-		tx.Rollback()
-		return distribution.NewPutDistributionsDefault(500).WithPayload(ErrorMessage("%s", err))
+	if e := replaceDistributions(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID), ds, getSubjectFromRequest(params.HTTPRequest)); e != nil {
+		return distribution.NewPutDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
 	}
 
 	resp := distribution.NewPutDistributionsOK()
 	resp.SetPayload(e2r.MapDistributions(ds))
+	return resp
+}
 
-	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
+// RebalanceDistributions puts the distributions that split 100 percent evenly across the variants
+func (c *crud) RebalanceDistributions(params distribution.RebalanceDistributionsParams) middleware.Responder {
+	ds, e := newRebalancedDistributions(params)
+	if e != nil {
+		return distribution.NewRebalanceDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	change, e := requestDistributionsChange(params.HTTPRequest, util.SafeUint(params.FlagID), uint(params.SegmentID), "", ds)
+	if e != nil {
+		return distribution.NewRebalanceDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if change != nil {
+		return distribution.NewRebalanceDistributionsAccepted().WithPayload(change)
+	}
+
+	if e := replaceDistributions(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID), ds, getSubjectFromRequest(params.HTTPRequest)); e != nil {
+		return distribution.NewRebalanceDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	resp := distribution.NewRebalanceDistributionsOK()
+	resp.SetPayload(e2r.MapDistributions(ds))
 	return resp
 }
 
+// newRebalancedDistributions splits 100 percent across the variants of the params,
+// the remainder is given to the first variants by their order in the params
+var newRebalancedDistributions = func(params distribution.RebalanceDistributionsParams) ([]entity.Distribution, *Error) {
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
+		return nil, NewError(404, "error finding flagID %v. reason %s", params.FlagID, err)
+	}
+	f.Preload(getRequestDB(params.HTTPRequest))
+
+	segmentFound := false
+	for _, s := range f.Segments {
+		if s.ID == uint(params.SegmentID) {
+			segmentFound = true
+		}
+	}
+	if !segmentFound {
+		return nil, NewError(404, "error finding segmentID %v under this flag", params.SegmentID)
+	}
+
+	vMap := make(map[uint]string)
+	vIDs := []uint{}
+	for _, v := range f.Variants {
+		vMap[v.ID] = v.Key
+		vIDs = append(vIDs, v.ID)
+	}
+
+	n := len(params.Body.VariantIds)
+	ds := make([]entity.Distribution, n)
+	seen := make(map[uint]bool, n)
+	for i, id := range params.Body.VariantIds {
+		vID := uint(id)
+		k, ok := vMap[vID]
+		if !ok {
+			return nil, NewError(400, "error finding variantID %v under this flag. expecting %v", vID, vIDs)
+		}
+		if seen[vID] {
+			return nil, NewError(400, "duplicate variantID %v", vID)
+		}
+		seen[vID] = true
+
+		percent := 100 / n
+		if i < 100%n {
+			percent++
+		}
+		ds[i] = entity.Distribution{
+			SegmentID:  uint(params.SegmentID),
+			VariantID:  vID,
+			VariantKey: k,
+			Percent:    uint(percent),
+		}
+	}
+	return ds, nil
+}
+
+// replaceDistributions replaces the distributions of the segment in one transaction and records the history
+func replaceDistributions(db *gorm.DB, flagID uint, segmentID uint, ds []entity.Distribution, actor string) *Error {
+	before := []entity.Distribution{}
+	if err := db.Order("variant_id").Where(entity.Distribution{SegmentID: segmentID}).Find(&before).Error; err != nil {
+		return NewError(500, "%s", err)
+	}
+
+	tx := db.Begin()
+	if err := tx.Delete(entity.Distribution{}, "segment_id = ?", segmentID).Error; err != nil {
+		tx.Rollback()
+		return NewError(500, "%s", err)
+	}
+	for i := range ds {
+		if err := tx.Create(&ds[i]).Error; err != nil {
+			tx.Rollback()
+			return NewError(500, "%s", err)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return NewError(500, "%s", err)
+	}
+
+	entity.SaveFlagHistory(
+		getDB(),
+		flagID,
+		actor,
+		entity.FlagHistoryEntityTypeDistribution,
+		segmentID,
+		map[string]interface{}{"Distributions": distributionPercents(before)},
+		map[string]interface{}{"Distributions": distributionPercents(ds)},
+	)
+	entity.SaveFlagSnapshot(getDB(), flagID, actor)
+	return nil
+}
+
 func (c *crud) FindDistributions(params distribution.FindDistributionsParams) middleware.Responder {
+	if e := validateFlagSegment(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID)); e != nil {
+		return distribution.NewFindDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if params.Environment != nil {
+		if e := validateEnvironment(*params.Environment); e != nil {
+			return distribution.NewFindDistributionsDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+		ds, err := findSegmentDistributionsInEnvironment(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID), *params.Environment)
+		if err != nil {
+			return distribution.NewFindDistributionsDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		return distribution.NewFindDistributionsOK().WithPayload(e2r.MapDistributions(ds))
+	}
+
 	ds := []entity.Distribution{}
-	err := getDB().
+	err := getRequestDB(params.HTTPRequest).
 		Order("variant_id").
 		Where(entity.Distribution{SegmentID: uint(params.SegmentID)}).
 		Find(&ds).
@@ -511,12 +1466,19 @@ func (c *crud) CreateVariant(params variant.CreateVariantParams) middleware.Resp
 		return variant.NewCreateVariantDefault(400).WithPayload(ErrorMessage("%s", err))
 	}
 	v.Attachment = a
+	v.AttachmentTypes = entity.AttachmentTypes(params.Body.AttachmentTypes)
 
 	if err := v.Validate(); err != nil {
 		return variant.NewCreateVariantDefault(400).WithPayload(ErrorMessage("%s", err))
 	}
+	if e := validateVariantAttachment(v); e != nil {
+		return variant.NewCreateVariantDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if e := validateNewVariant(getRequestDB(params.HTTPRequest), v.FlagID); e != nil {
+		return variant.NewCreateVariantDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
 
-	if err := getDB().Create(v).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Create(v).Error; err != nil {
 		return variant.NewCreateVariantDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
@@ -529,7 +1491,7 @@ func (c *crud) CreateVariant(params variant.CreateVariantParams) middleware.Resp
 
 func (c *crud) FindVariants(params variant.FindVariantsParams) middleware.Responder {
 	vs := []entity.Variant{}
-	err := getDB().
+	err := getRequestDB(params.HTTPRequest).
 		Order("id").
 		Where(entity.Variant{FlagID: uint(params.FlagID)}).
 		Find(&vs).
@@ -546,7 +1508,7 @@ func (c *crud) FindVariants(params variant.FindVariantsParams) middleware.Respon
 func (c *crud) PutVariant(params variant.PutVariantParams) middleware.Responder {
 	v := &entity.Variant{}
 
-	if err := getDB().First(v, params.VariantID).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Where("flag_id = ?", params.FlagID).First(v, params.VariantID).Error; err != nil {
 		return variant.NewPutVariantDefault(404).WithPayload(ErrorMessage("%s", err))
 	}
 
@@ -558,12 +1520,18 @@ func (c *crud) PutVariant(params variant.PutVariantParams) middleware.Responder
 		}
 		v.Attachment = a
 	}
+	if params.Body.AttachmentTypes != nil {
+		v.AttachmentTypes = entity.AttachmentTypes(params.Body.AttachmentTypes)
+	}
 
 	if err := v.Validate(); err != nil {
 		return variant.NewPutVariantDefault(400).WithPayload(ErrorMessage("%s", err))
 	}
+	if e := validateVariantAttachment(v); e != nil {
+		return variant.NewPutVariantDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
 
-	if err := getDB().Save(&v).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Save(&v).Error; err != nil {
 		return variant.NewPutVariantDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
@@ -583,10 +1551,153 @@ func (c *crud) DeleteVariant(params variant.DeleteVariantParams) middleware.Resp
 		return variant.NewDeleteVariantDefault(err.StatusCode).WithPayload(ErrorMessage("%s", err))
 	}
 
-	if err := getDB().Delete(entity.Variant{}, params.VariantID).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).Delete(entity.Variant{}, params.VariantID).Error; err != nil {
 		return variant.NewDeleteVariantDefault(500).WithPayload(ErrorMessage("%s", err))
 	}
 
 	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
 	return variant.NewDeleteVariantOK()
 }
+
+// distributionPercents maps the distributions by variant keys, the ids of
+// distributions are regenerated on every put, the percents are what change
+func distributionPercents(ds []entity.Distribution) map[string]uint {
+	m := make(map[string]uint, len(ds))
+	for _, d := range ds {
+		m[d.VariantKey] = d.Percent
+	}
+	return m
+}
+
+// findFlagsLimit returns the page size of FindFlags, it's capped by FlagsMaxLimit
+func findFlagsLimit(limit *int64) int {
+	l := config.Config.FlagsDefaultLimit
+	if limit != nil {
+		l = int(*limit)
+	}
+	if l <= 0 || l > config.Config.FlagsMaxLimit {
+		l = config.Config.FlagsMaxLimit
+	}
+	return l
+}
+
+func splitTags(s string) []string {
+	tags := []string{}
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// findFlagIDsWithAllTags finds the ids of flags having all the tags
+func findFlagIDsWithAllTags(db *gorm.DB, tags []string) ([]uint, error) {
+	flagIDs := []uint{}
+	err := db.
+		Table("flags_tags").
+		Joins("JOIN tags ON tags.id = flags_tags.tag_id").
+		Where("tags.value IN (?)", tags).
+		Where("tags.deleted_at IS NULL").
+		Group("flags_tags.flag_id").
+		Having("COUNT(DISTINCT tags.id) = ?", len(tags)).
+		Pluck("flags_tags.flag_id", &flagIDs).
+		Error
+	return flagIDs, err
+}
+
+// findFlagIDsWithTagLike finds the ids of flags having any tag matching the like pattern
+func findFlagIDsWithTagLike(db *gorm.DB, pattern string) ([]uint, error) {
+	flagIDs := []uint{}
+	err := db.
+		Table("flags_tags").
+		Joins("JOIN tags ON tags.id = flags_tags.tag_id").
+		Where("lower(tags.value) like ?", pattern).
+		Where("tags.deleted_at IS NULL").
+		Group("flags_tags.flag_id").
+		Pluck("flags_tags.flag_id", &flagIDs).
+		Error
+	return flagIDs, err
+}
+
+func (c *crud) CreateTag(params tag.CreateTagParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
+		return tag.NewCreateTagDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	t, err := entity.CreateTag(getRequestDB(params.HTTPRequest), util.SafeString(params.Body.Value))
+	if err != nil {
+		return tag.NewCreateTagDefault(400).WithPayload(ErrorMessage("%s", err))
+	}
+
+	if err := getRequestDB(params.HTTPRequest).Model(f).Association("Tags").Append(t).Error; err != nil {
+		return tag.NewCreateTagDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := tag.NewCreateTagOK()
+	resp.SetPayload(e2r.MapTag(t))
+
+	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
+	return resp
+}
+
+func (c *crud) FindTags(params tag.FindTagsParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
+		return tag.NewFindTagsDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	ts := []entity.Tag{}
+	if err := getRequestDB(params.HTTPRequest).Model(f).Order("value").Association("Tags").Find(&ts).Error; err != nil {
+		return tag.NewFindTagsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := tag.NewFindTagsOK()
+	resp.SetPayload(e2r.MapTags(ts))
+	return resp
+}
+
+func (c *crud) DeleteTag(params tag.DeleteTagParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
+		return tag.NewDeleteTagDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	t := &entity.Tag{}
+	if err := getRequestDB(params.HTTPRequest).First(t, params.TagID).Error; err != nil {
+		return tag.NewDeleteTagDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	if err := getRequestDB(params.HTTPRequest).Model(f).Association("Tags").Delete(t).Error; err != nil {
+		return tag.NewDeleteTagDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	entity.SaveFlagSnapshot(getDB(), util.SafeUint(params.FlagID), getSubjectFromRequest(params.HTTPRequest))
+	return tag.NewDeleteTagOK()
+}
+
+func (c *crud) FindAllTags(params tag.FindAllTagsParams) middleware.Responder {
+	tx := getRequestDB(params.HTTPRequest)
+	if params.ValueLike != nil {
+		tx = tx.Where(
+			"lower(value) like ?",
+			fmt.Sprintf("%%%s%%", strings.ToLower(*params.ValueLike)),
+		)
+	}
+	if params.Offset != nil {
+		tx = tx.Offset(int(*params.Offset))
+	}
+	if params.Limit != nil {
+		tx = tx.Limit(int(*params.Limit))
+	}
+
+	ts := []entity.Tag{}
+	if err := tx.Order("value").Find(&ts).Error; err != nil {
+		return tag.NewFindAllTagsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := tag.NewFindAllTagsOK()
+	resp.SetPayload(e2r.MapTags(ts))
+	return resp
+}
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
 	"github.com/checkr/flagr/pkg/util"
 	"github.com/checkr/flagr/swagger_gen/models"
@@ -11,9 +16,12 @@ import (
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/tag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
 
+	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 )
@@ -47,6 +55,14 @@ func TestCrudFlags(t *testing.T) {
 		assert.NotZero(t, len(res.(*flag.FindFlagsOK).Payload))
 	})
 
+	t.Run("it should stop querying once the request is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, _ := http.NewRequest("GET", "/api/v1/flags", nil)
+		res = c.FindFlags(flag.FindFlagsParams{HTTPRequest: req.WithContext(ctx)})
+		assert.NotZero(t, res.(*flag.FindFlagsDefault).Payload)
+	})
+
 	t.Run("it should be able to get the flag after creation", func(t *testing.T) {
 		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
 		assert.NotZero(t, res.(*flag.GetFlagOK).Payload.ID)
@@ -98,6 +114,65 @@ func TestCrudFlags(t *testing.T) {
 		assert.True(t, *res.(*flag.SetFlagEnabledOK).Payload.Enabled)
 	})
 
+	t.Run("it should be able to patch the flag enabled state and reload it in the eval cache", func(t *testing.T) {
+		ec := &EvalCache{idCache: map[string]*entity.Flag{}, keyCache: map[string]*entity.Flag{}}
+		defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+		res = c.PatchFlagEnabled(flag.PatchFlagEnabledParams{
+			FlagID: int64(1),
+			Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(false)},
+		})
+		assert.False(t, *res.(*flag.PatchFlagEnabledOK).Payload.Enabled)
+		assert.Equal(t, "flag_key_1", res.(*flag.PatchFlagEnabledOK).Payload.Key)
+		assert.False(t, ec.idCache["1"].Enabled)
+
+		res = c.PatchFlagEnabled(flag.PatchFlagEnabledParams{
+			FlagID: int64(1),
+			Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true)},
+		})
+		assert.True(t, *res.(*flag.PatchFlagEnabledOK).Payload.Enabled)
+		assert.True(t, ec.idCache["1"].Enabled)
+	})
+
+	t.Run("it should be able to put and clear the flag override", func(t *testing.T) {
+		res = c.PutFlagOverride(flag.PutFlagOverrideParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagOverrideRequest{
+				VariantID: util.Int64Ptr(int64(1)),
+				Enabled:   true,
+			}},
+		)
+		assert.True(t, res.(*flag.PutFlagOverrideOK).Payload.OverrideEnabled)
+		assert.Equal(t, int64(1), res.(*flag.PutFlagOverrideOK).Payload.OverrideVariantID)
+
+		res = c.PutFlagOverride(flag.PutFlagOverrideParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagOverrideRequest{
+				VariantID: util.Int64Ptr(int64(999)),
+				Enabled:   true,
+			}},
+		)
+		assert.IsType(t, &flag.PutFlagOverrideDefault{}, res)
+
+		res = c.PutFlagOverride(flag.PutFlagOverrideParams{
+			FlagID: int64(999),
+			Body: &models.PutFlagOverrideRequest{
+				VariantID: util.Int64Ptr(int64(0)),
+			}},
+		)
+		assert.IsType(t, &flag.PutFlagOverrideDefault{}, res)
+
+		res = c.PutFlagOverride(flag.PutFlagOverrideParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagOverrideRequest{
+				VariantID: util.Int64Ptr(int64(0)),
+				Enabled:   true,
+			}},
+		)
+		assert.False(t, res.(*flag.PutFlagOverrideOK).Payload.OverrideEnabled)
+		assert.Zero(t, res.(*flag.PutFlagOverrideOK).Payload.OverrideVariantID)
+	})
+
 	t.Run("it should be able to put flag's EntityType", func(t *testing.T) {
 		res = c.PutFlag(flag.PutFlagParams{
 			FlagID: int64(1),
@@ -112,6 +187,86 @@ func TestCrudFlags(t *testing.T) {
 		assert.NotZero(t, len(ds))
 	})
 
+	t.Run("it should be able to put and unset flag's ExpiresAt", func(t *testing.T) {
+		expiresAt := strfmt.DateTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagRequest{ExpiresAt: &expiresAt},
+		})
+		assert.Equal(t, expiresAt.String(), res.(*flag.PutFlagOK).Payload.ExpiresAt.String())
+
+		zero := strfmt.DateTime(time.Time{})
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagRequest{ExpiresAt: &zero},
+		})
+		assert.Nil(t, res.(*flag.PutFlagOK).Payload.ExpiresAt)
+	})
+
+	t.Run("it should be able to put flag's BucketingSeed", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				BucketingSeed: util.StringPtr("seed1"),
+			}},
+		)
+		assert.Equal(t, "seed1", res.(*flag.PutFlagOK).Payload.BucketingSeed)
+	})
+
+	t.Run("it should be able to put flag's BucketingAlgorithm", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				BucketingAlgorithm: util.StringPtr("murmur3"),
+			}},
+		)
+		assert.Equal(t, "murmur3", res.(*flag.PutFlagOK).Payload.BucketingAlgorithm)
+
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				BucketingAlgorithm: util.StringPtr("md5"),
+			}},
+		)
+		assert.IsType(t, &flag.PutFlagDefault{}, res)
+	})
+
+	t.Run("it should be able to put flag's BucketBy", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				BucketBy: util.StringPtr("accountID"),
+			}},
+		)
+		assert.Equal(t, "accountID", res.(*flag.PutFlagOK).Payload.BucketBy)
+	})
+
+	t.Run("it should be able to put flag's DefaultVariantID", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				DefaultVariantID: util.Int64Ptr(int64(1)),
+			}},
+		)
+		assert.Equal(t, int64(1), res.(*flag.PutFlagOK).Payload.DefaultVariantID)
+
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				DefaultVariantID: util.Int64Ptr(int64(999)),
+			}},
+		)
+		assert.NotZero(t, res.(*flag.PutFlagDefault).Payload)
+
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				DefaultVariantID: util.Int64Ptr(int64(0)),
+			}},
+		)
+		assert.Zero(t, res.(*flag.PutFlagOK).Payload.DefaultVariantID)
+	})
+
 	t.Run("it should be able to get all the flags' EntityType", func(t *testing.T) {
 		res = c.GetFlagEntityTypes(flag.GetFlagEntityTypesParams{})
 		assert.NotZero(t, len(res.(*flag.GetFlagEntityTypesOK).Payload))
@@ -123,10 +278,146 @@ func TestCrudFlags(t *testing.T) {
 
 	})
 
+	t.Run("it should be able to get the flag history", func(t *testing.T) {
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(1)})
+		payload := res.(*flag.GetFlagHistoryOK).Payload
+		assert.NotZero(t, len(payload))
+		assert.Equal(t, entity.FlagHistoryActionUpdate, *payload[0].Action)
+
+		last := payload[len(payload)-1]
+		assert.Equal(t, entity.FlagHistoryActionCreate, *last.Action)
+		assert.Equal(t, entity.FlagHistoryEntityTypeFlag, *last.EntityType)
+
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(1), Limit: util.Int64Ptr(1), Offset: util.Int64Ptr(1)})
+		assert.Len(t, res.(*flag.GetFlagHistoryOK).Payload, 1)
+		assert.Equal(t, *payload[1].ID, *res.(*flag.GetFlagHistoryOK).Payload[0].ID)
+	})
+
+	t.Run("it should keep the note of the change in the flag history", func(t *testing.T) {
+		c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				Notes: util.StringPtr("# notes with rationale"),
+				Note:  "clarify the notes",
+			},
+		})
+		c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+			FlagID: int64(1),
+			Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(false), Note: "pause the rollout"},
+		})
+
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(1), Limit: util.Int64Ptr(2)})
+		payload := res.(*flag.GetFlagHistoryOK).Payload
+		assert.Equal(t, "pause the rollout", payload[0].Note)
+		assert.Contains(t, payload[0].Diff, "Enabled")
+		assert.Equal(t, "clarify the notes", payload[1].Note)
+		assert.Contains(t, payload[1].Diff, "Notes")
+	})
+
 	t.Run("it should be able to delete the flag", func(t *testing.T) {
 		res = c.DeleteFlag(flag.DeleteFlagParams{FlagID: int64(1)})
 		assert.NotZero(t, res.(*flag.DeleteFlagOK))
 	})
+
+	t.Run("it should hide the deleted flag unless includeDeleted is set", func(t *testing.T) {
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
+		assert.IsType(t, &flag.GetFlagDefault{}, res)
+
+		res = c.FindFlags(flag.FindFlagsParams{})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 0)
+
+		res = c.FindFlags(flag.FindFlagsParams{IncludeDeleted: util.BoolPtr(true)})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 1)
+		assert.NotNil(t, res.(*flag.FindFlagsOK).Payload[0].DeletedAt)
+	})
+
+	t.Run("it should be able to restore the deleted flag", func(t *testing.T) {
+		res = c.RestoreFlag(flag.RestoreFlagParams{FlagID: int64(1)})
+		payload := res.(*flag.RestoreFlagOK).Payload
+		assert.Nil(t, payload.DeletedAt)
+		assert.NotZero(t, len(payload.Segments))
+		assert.NotZero(t, len(payload.Variants))
+
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
+		assert.NotZero(t, res.(*flag.GetFlagOK).Payload.ID)
+
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(1), Limit: util.Int64Ptr(1)})
+		h := res.(*flag.GetFlagHistoryOK).Payload[0]
+		assert.Equal(t, entity.FlagHistoryActionUpdate, *h.Action)
+		assert.Contains(t, h.Diff, "DeletedAt")
+	})
+
+	t.Run("it should not restore a flag that's not deleted or doesn't exist", func(t *testing.T) {
+		res = c.RestoreFlag(flag.RestoreFlagParams{FlagID: int64(1)})
+		assert.NotZero(t, res.(*flag.RestoreFlagDefault).Payload)
+
+		res = c.RestoreFlag(flag.RestoreFlagParams{FlagID: int64(99999)})
+		assert.NotZero(t, res.(*flag.RestoreFlagDefault).Payload)
+	})
+}
+
+func TestCrudFlagsDeletedByAdmins(t *testing.T) {
+	var res middleware.Responder
+	f := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(f)
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.JWTAuthEnabled, true).Reset()
+	defer gostub.Stub(&config.Config.JWTAuthAdminGroupClaim, "flagr-admins").Reset()
+
+	genGroupsRequest := func(groups ...string) *http.Request {
+		r, _ := http.NewRequest("GET", "", nil)
+		ctx := context.WithValue(context.TODO(), interface{}(config.Config.JWTAuthUserProperty), &jwt.Token{
+			Claims: jwt.MapClaims{"sub": "someone", "groups": groups},
+			Valid:  true,
+		})
+		return r.WithContext(ctx)
+	}
+
+	db.Delete(&f.Segments[0])
+	db.Delete(&f.Variants[0])
+	c.DeleteFlag(flag.DeleteFlagParams{FlagID: int64(f.ID)})
+
+	t.Run("it should only list the deleted flags for the admins", func(t *testing.T) {
+		res = c.FindFlags(flag.FindFlagsParams{IncludeDeleted: util.BoolPtr(true)})
+		assert.Contains(t, *res.(*flag.FindFlagsDefault).Payload.Message, "only the admins")
+
+		res = c.FindFlags(flag.FindFlagsParams{
+			HTTPRequest:    genGroupsRequest("engineers"),
+			IncludeDeleted: util.BoolPtr(true),
+		})
+		assert.Contains(t, *res.(*flag.FindFlagsDefault).Payload.Message, "only the admins")
+
+		res = c.FindFlags(flag.FindFlagsParams{
+			HTTPRequest:    genGroupsRequest("engineers", "flagr-admins"),
+			IncludeDeleted: util.BoolPtr(true),
+			Preload:        util.BoolPtr(true),
+		})
+		payload := res.(*flag.FindFlagsOK).Payload
+		assert.Len(t, payload, 1)
+		assert.NotNil(t, payload[0].DeletedAt)
+		assert.Len(t, payload[0].Segments, len(f.Segments)-1)
+		assert.Len(t, payload[0].Variants, len(f.Variants)-1)
+	})
+
+	t.Run("it should only restore the deleted flags for the admins", func(t *testing.T) {
+		res = c.RestoreFlag(flag.RestoreFlagParams{
+			HTTPRequest: genGroupsRequest("engineers"),
+			FlagID:      int64(f.ID),
+		})
+		assert.Contains(t, *res.(*flag.RestoreFlagDefault).Payload.Message, "only the admins")
+
+		res = c.RestoreFlag(flag.RestoreFlagParams{
+			HTTPRequest: genGroupsRequest("flagr-admins"),
+			FlagID:      int64(f.ID),
+		})
+		payload := res.(*flag.RestoreFlagOK).Payload
+		assert.Nil(t, payload.DeletedAt)
+		assert.Len(t, payload.Segments, len(f.Segments)-1)
+		assert.Len(t, payload.Variants, len(f.Variants)-1)
+	})
 }
 
 func TestCrudFlagsWithFailures(t *testing.T) {
@@ -256,6 +547,14 @@ func TestCrudFlagsWithFailures(t *testing.T) {
 		assert.NotZero(t, res.(*flag.SetFlagEnabledDefault).Payload)
 	})
 
+	t.Run("PatchFlagEnabled - try to patch a non-existing flag", func(t *testing.T) {
+		res = c.PatchFlagEnabled(flag.PatchFlagEnabledParams{
+			FlagID: int64(99999),
+			Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true)},
+		})
+		assert.NotZero(t, res.(*flag.PatchFlagEnabledDefault).Payload)
+	})
+
 	t.Run("SetFlagEnabledState - got e2r error", func(t *testing.T) {
 		defer gostub.StubFunc(&e2rMapFlag, nil, fmt.Errorf("e2r MapFlag error")).Reset()
 		res = c.SetFlagEnabledState(flag.SetFlagEnabledParams{
@@ -298,6 +597,48 @@ func TestCrudFlagsWithFailures(t *testing.T) {
 		res = c.GetFlagSnapshots(flag.GetFlagSnapshotsParams{FlagID: int64(99999)})
 		assert.NotZero(t, res.(*flag.GetFlagSnapshotsDefault).Payload)
 	})
+
+	t.Run("GetFlagHistory - db generic error", func(t *testing.T) {
+		db.Error = fmt.Errorf("db generic error")
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(99999)})
+		assert.NotZero(t, res.(*flag.GetFlagHistoryDefault).Payload)
+		db.Error = nil
+	})
+
+	t.Run("GetFlagHistory - e2r MapFlagHistories error", func(t *testing.T) {
+		defer gostub.StubFunc(&e2rMapFlagHistories, nil, fmt.Errorf("e2r MapFlagHistories error")).Reset()
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(99999)})
+		assert.NotZero(t, res.(*flag.GetFlagHistoryDefault).Payload)
+	})
+}
+
+func TestCreateFlagDataRecordsEnabled(t *testing.T) {
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	t.Run("it's disabled if not provided", func(t *testing.T) {
+		res := c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("funny flag")},
+		})
+		assert.False(t, *res.(*flag.CreateFlagOK).Payload.DataRecordsEnabled)
+	})
+
+	t.Run("it can be enabled on creation", func(t *testing.T) {
+		res := c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{
+				Description:        util.StringPtr("funny flag"),
+				DataRecordsEnabled: util.BoolPtr(true),
+			},
+		})
+		assert.True(t, *res.(*flag.CreateFlagOK).Payload.DataRecordsEnabled)
+
+		f := &entity.Flag{}
+		db.First(f, res.(*flag.CreateFlagOK).Payload.ID)
+		assert.True(t, f.DataRecordsEnabled)
+	})
 }
 
 func TestFindFlags(t *testing.T) {
@@ -323,6 +664,30 @@ func TestFindFlags(t *testing.T) {
 		assert.Len(t, res.(*flag.FindFlagsOK).Payload, numOfFlags)
 	})
 
+	t.Run("FindFlags - filter by tags with AND semantics", func(t *testing.T) {
+		for _, flagID := range []int64{1, 2, 3} {
+			c.CreateTag(tag.CreateTagParams{FlagID: flagID, Body: &models.CreateTagRequest{Value: util.StringPtr("team_a")}})
+		}
+		c.CreateTag(tag.CreateTagParams{FlagID: 2, Body: &models.CreateTagRequest{Value: util.StringPtr("beta")}})
+		c.CreateTag(tag.CreateTagParams{FlagID: 3, Body: &models.CreateTagRequest{Value: util.StringPtr("beta")}})
+		c.SetFlagEnabledState(flag.SetFlagEnabledParams{FlagID: 3, Body: &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true)}})
+
+		res = c.FindFlags(flag.FindFlagsParams{Tags: util.StringPtr("team_a")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 3)
+
+		res = c.FindFlags(flag.FindFlagsParams{Tags: util.StringPtr("team_a, beta")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 2)
+
+		res = c.FindFlags(flag.FindFlagsParams{Tags: util.StringPtr("team_a,beta"), Enabled: util.BoolPtr(true)})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 1)
+		assert.Equal(t, int64(3), res.(*flag.FindFlagsOK).Payload[0].ID)
+
+		res = c.FindFlags(flag.FindFlagsParams{Tags: util.StringPtr("team_a,not_exist")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 0)
+
+		c.SetFlagEnabledState(flag.SetFlagEnabledParams{FlagID: 3, Body: &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(false)}})
+	})
+
 	t.Run("FindFlags - got all the results without segments and variants", func(t *testing.T) {
 		c.CreateSegment(segment.CreateSegmentParams{
 			FlagID: int64(1),
@@ -403,6 +768,78 @@ func TestFindFlags(t *testing.T) {
 		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 2)
 		assert.Equal(t, res.(*flag.FindFlagsOK).Payload[0].ID, int64(3))
 		assert.Equal(t, res.(*flag.FindFlagsOK).Payload[1].ID, int64(4))
+		assert.Equal(t, int64(numOfFlags), res.(*flag.FindFlagsOK).XTotalCount)
+	})
+	t.Run("FindFlags (total count respects the filters)", func(t *testing.T) {
+		res = c.FindFlags(flag.FindFlagsParams{
+			Limit:           util.Int64Ptr(int64(1)),
+			DescriptionLike: util.StringPtr("flag_1"),
+		})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 1)
+		// flag_1 and flag_10 to flag_19
+		assert.Equal(t, int64(11), res.(*flag.FindFlagsOK).XTotalCount)
+	})
+	t.Run("FindFlags (with default limit and max limit)", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.FlagsDefaultLimit, 5).Reset()
+		defer gostub.Stub(&config.Config.FlagsMaxLimit, 10).Reset()
+
+		res = c.FindFlags(flag.FindFlagsParams{})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 5)
+
+		res = c.FindFlags(flag.FindFlagsParams{Limit: util.Int64Ptr(int64(100))})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 10)
+		assert.Equal(t, int64(numOfFlags), res.(*flag.FindFlagsOK).XTotalCount)
+	})
+	t.Run("FindFlags (with q searching keys, descriptions and tags)", func(t *testing.T) {
+		c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{
+				Description: util.StringPtr("exact key match"),
+				Key:         "team",
+			},
+		})
+
+		// flag_key_0 to flag_key_2 are tagged with team_a
+		res = c.FindFlags(flag.FindFlagsParams{Q: util.StringPtr("TEAM")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 4)
+		assert.Equal(t, "team", res.(*flag.FindFlagsOK).Payload[0].Key)
+		assert.Equal(t, int64(1), res.(*flag.FindFlagsOK).Payload[1].ID)
+
+		res = c.FindFlags(flag.FindFlagsParams{Q: util.StringPtr(" beta  flag_key_2 ")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 1)
+		assert.Equal(t, "flag_key_2", res.(*flag.FindFlagsOK).Payload[0].Key)
+
+		res = c.FindFlags(flag.FindFlagsParams{Q: util.StringPtr("exact MATCH")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 1)
+
+		res = c.FindFlags(flag.FindFlagsParams{Q: util.StringPtr("not_exist")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 0)
+	})
+	t.Run("FindFlags (with q capped by the search max limit)", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.FlagsSearchMaxLimit, 3).Reset()
+
+		res = c.FindFlags(flag.FindFlagsParams{Q: util.StringPtr("flag_key"), Limit: util.Int64Ptr(int64(100))})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 3)
+		assert.Equal(t, int64(numOfFlags), res.(*flag.FindFlagsOK).XTotalCount)
+	})
+	t.Run("FindFlags (with unusedSince)", func(t *testing.T) {
+		now := time.Now().UTC()
+		db.Model(&entity.Flag{}).Where("id IN (?)", []int{1, 2, 3}).UpdateColumn("created_at", now.Add(-60*24*time.Hour))
+		db.Model(&entity.Flag{}).Where("id = ?", 1).UpdateColumn("last_evaluated_at", now.Add(-40*24*time.Hour))
+		db.Model(&entity.Flag{}).Where("id = ?", 3).UpdateColumn("last_evaluated_at", now.Add(-24*time.Hour))
+
+		res = c.FindFlags(flag.FindFlagsParams{UnusedSince: util.StringPtr("30d")})
+		payload := res.(*flag.FindFlagsOK).Payload
+		assert.Len(t, payload, 2)
+		assert.Equal(t, int64(1), payload[0].ID)
+		assert.NotNil(t, payload[0].LastEvaluatedAt)
+		assert.Equal(t, int64(2), payload[1].ID)
+		assert.Nil(t, payload[1].LastEvaluatedAt)
+
+		res = c.FindFlags(flag.FindFlagsParams{UnusedSince: util.StringPtr("12h")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 3)
+
+		res = c.FindFlags(flag.FindFlagsParams{UnusedSince: util.StringPtr("a month")})
+		assert.IsType(t, &flag.FindFlagsDefault{}, res)
 	})
 }
 
@@ -466,7 +903,18 @@ func TestCrudSegments(t *testing.T) {
 	res = c.FindSegments(segment.FindSegmentsParams{FlagID: int64(1)})
 	assert.Equal(t, int64(2), res.(*segment.FindSegmentsOK).Payload[0].ID)
 
-	// step 6. it should be able to delete the segment
+	// step 6. it should reject the partial or duplicate segments
+	for _, ids := range [][]int64{{int64(2)}, {int64(2), int64(2)}, {int64(2), int64(1), int64(999)}} {
+		res = c.PutSegmentsReorder(segment.PutSegmentsReorderParams{
+			FlagID: int64(1),
+			Body:   &models.PutSegmentReorderRequest{SegmentIds: ids},
+		})
+		assert.NotZero(t, res.(*segment.PutSegmentsReorderDefault).Payload)
+	}
+	res = c.FindSegments(segment.FindSegmentsParams{FlagID: int64(1)})
+	assert.Equal(t, int64(2), res.(*segment.FindSegmentsOK).Payload[0].ID)
+
+	// step 7. it should be able to delete the segment
 	res = c.DeleteSegment(segment.DeleteSegmentParams{
 		FlagID:    int64(1),
 		SegmentID: int64(2),
@@ -521,6 +969,7 @@ func TestCrudSegmentsWithFailures(t *testing.T) {
 	})
 
 	t.Run("PutSegmentsReorder - db generic error", func(t *testing.T) {
+		defer gostub.StubFunc(&validatePutSegmentsReorder, nil).Reset()
 		db.Error = fmt.Errorf("db generic error")
 		res = c.PutSegmentsReorder(segment.PutSegmentsReorderParams{
 			FlagID: int64(1),
@@ -962,6 +1411,18 @@ func TestCrudDistributions(t *testing.T) {
 		SegmentID: int64(1),
 	})
 	assert.NotZero(t, len(res.(*distribution.FindDistributionsOK).Payload))
+
+	// step 3. it should record the distribution change in the flag history
+	res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(1), Limit: util.Int64Ptr(1)})
+	h := res.(*flag.GetFlagHistoryOK).Payload[0]
+	assert.Equal(t, entity.FlagHistoryEntityTypeDistribution, *h.EntityType)
+	assert.Equal(t, int64(1), *h.EntityID)
+	assert.Equal(t, map[string]interface{}{
+		"Distributions": map[string]interface{}{
+			"before": map[string]interface{}{},
+			"after":  map[string]interface{}{"control": float64(100)},
+		},
+	}, h.Diff)
 }
 
 func TestCrudDistributionsWithFailures(t *testing.T) {
@@ -1051,3 +1512,914 @@ func TestCrudDistributionsWithFailures(t *testing.T) {
 		db.Error = nil
 	})
 }
+
+func TestCrudDistributionsRebalance(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{
+			Description: util.StringPtr("funny flag"),
+		},
+	})
+	c.CreateSegment(segment.CreateSegmentParams{
+		FlagID: int64(1),
+		Body: &models.CreateSegmentRequest{
+			Description:    util.StringPtr("segment1"),
+			RolloutPercent: util.Int64Ptr(int64(100)),
+		},
+	})
+	for _, key := range []string{"control", "treatment_a", "treatment_b"} {
+		c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body:   &models.CreateVariantRequest{Key: util.StringPtr(key)},
+		})
+	}
+
+	t.Run("it should split 100 percent evenly across the variants", func(t *testing.T) {
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.RebalanceDistributionsRequest{VariantIds: []int64{3, 1, 2}},
+		})
+		payload := res.(*distribution.RebalanceDistributionsOK).Payload
+		assert.Len(t, payload, 3)
+		assert.Equal(t, "treatment_b", *payload[0].VariantKey)
+		assert.Equal(t, int64(34), *payload[0].Percent)
+		assert.Equal(t, int64(33), *payload[1].Percent)
+		assert.Equal(t, int64(33), *payload[2].Percent)
+
+		res = c.FindDistributions(distribution.FindDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+		})
+		assert.Len(t, res.(*distribution.FindDistributionsOK).Payload, 3)
+	})
+
+	t.Run("it should replace the previous distributions", func(t *testing.T) {
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.RebalanceDistributionsRequest{VariantIds: []int64{1, 2}},
+		})
+		assert.Len(t, res.(*distribution.RebalanceDistributionsOK).Payload, 2)
+
+		res = c.FindDistributions(distribution.FindDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+		})
+		payload := res.(*distribution.FindDistributionsOK).Payload
+		assert.Len(t, payload, 2)
+		assert.Equal(t, int64(50), *payload[0].Percent)
+		assert.Equal(t, int64(50), *payload[1].Percent)
+	})
+
+	t.Run("it should reject the invalid variants", func(t *testing.T) {
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.RebalanceDistributionsRequest{VariantIds: []int64{1, 999}},
+		})
+		assert.NotZero(t, res.(*distribution.RebalanceDistributionsDefault).Payload)
+
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.RebalanceDistributionsRequest{VariantIds: []int64{1, 1}},
+		})
+		assert.NotZero(t, res.(*distribution.RebalanceDistributionsDefault).Payload)
+	})
+
+	t.Run("it should reject the segment not under the flag", func(t *testing.T) {
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(999),
+			Body:      &models.RebalanceDistributionsRequest{VariantIds: []int64{1}},
+		})
+		assert.NotZero(t, res.(*distribution.RebalanceDistributionsDefault).Payload)
+
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:    int64(999),
+			SegmentID: int64(1),
+			Body:      &models.RebalanceDistributionsRequest{VariantIds: []int64{1}},
+		})
+		assert.NotZero(t, res.(*distribution.RebalanceDistributionsDefault).Payload)
+	})
+
+	t.Run("it should fail if it cannot replace the distributions", func(t *testing.T) {
+		defer gostub.StubFunc(&newRebalancedDistributions, []entity.Distribution{}, nil).Reset()
+		db.Error = fmt.Errorf("cannot delete previous distribution")
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.RebalanceDistributionsRequest{VariantIds: []int64{1}},
+		})
+		assert.NotZero(t, res.(*distribution.RebalanceDistributionsDefault).Payload)
+		db.Error = nil
+	})
+}
+
+func TestCrudTags(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{
+			Description: util.StringPtr("funny flag"),
+		},
+	})
+
+	t.Run("it should be able to create tags", func(t *testing.T) {
+		res = c.CreateTag(tag.CreateTagParams{FlagID: int64(1), Body: &models.CreateTagRequest{Value: util.StringPtr("team_b")}})
+		assert.NotZero(t, res.(*tag.CreateTagOK).Payload.ID)
+		res = c.CreateTag(tag.CreateTagParams{FlagID: int64(1), Body: &models.CreateTagRequest{Value: util.StringPtr("team_a")}})
+		assert.NotZero(t, res.(*tag.CreateTagOK).Payload.ID)
+	})
+
+	t.Run("it should be able to find the tags of the flag", func(t *testing.T) {
+		res = c.FindTags(tag.FindTagsParams{FlagID: int64(1)})
+		payload := res.(*tag.FindTagsOK).Payload
+		assert.Len(t, payload, 2)
+		assert.Equal(t, "team_a", *payload[0].Value)
+
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
+		assert.Len(t, res.(*flag.GetFlagOK).Payload.Tags, 2)
+	})
+
+	t.Run("it should be able to find all the tags", func(t *testing.T) {
+		res = c.FindAllTags(tag.FindAllTagsParams{ValueLike: util.StringPtr("B")})
+		assert.Len(t, res.(*tag.FindAllTagsOK).Payload, 1)
+	})
+
+	t.Run("it should be able to delete the tag from the flag", func(t *testing.T) {
+		res = c.DeleteTag(tag.DeleteTagParams{FlagID: int64(1), TagID: int64(1)})
+		assert.NotZero(t, res.(*tag.DeleteTagOK))
+
+		res = c.FindTags(tag.FindTagsParams{FlagID: int64(1)})
+		assert.Len(t, res.(*tag.FindTagsOK).Payload, 1)
+
+		res = c.FindAllTags(tag.FindAllTagsParams{})
+		assert.Len(t, res.(*tag.FindAllTagsOK).Payload, 2)
+	})
+
+	t.Run("it should fail with invalid tags or flags", func(t *testing.T) {
+		res = c.CreateTag(tag.CreateTagParams{FlagID: int64(1), Body: &models.CreateTagRequest{Value: util.StringPtr("Invalid Tag")}})
+		assert.NotZero(t, res.(*tag.CreateTagDefault).Payload)
+
+		res = c.CreateTag(tag.CreateTagParams{FlagID: int64(99999), Body: &models.CreateTagRequest{Value: util.StringPtr("team_a")}})
+		assert.NotZero(t, res.(*tag.CreateTagDefault).Payload)
+
+		res = c.FindTags(tag.FindTagsParams{FlagID: int64(99999)})
+		assert.NotZero(t, res.(*tag.FindTagsDefault).Payload)
+
+		res = c.DeleteTag(tag.DeleteTagParams{FlagID: int64(1), TagID: int64(99999)})
+		assert.NotZero(t, res.(*tag.DeleteTagDefault).Payload)
+	})
+}
+
+func genFlagDefinition(key string) *models.FlagDefinition {
+	return &models.FlagDefinition{
+		Key:               key,
+		Description:       util.StringPtr("flag " + key),
+		Enabled:           true,
+		DefaultVariantKey: "control",
+		Tags:              []string{"seeded"},
+		Variants: []*models.CreateVariantRequest{
+			{Key: util.StringPtr("control")},
+			{Key: util.StringPtr("treatment"), Attachment: map[string]interface{}{"color": "red"}},
+		},
+		Segments: []*models.SegmentDefinition{
+			{
+				Description:    util.StringPtr("CA users"),
+				RolloutPercent: util.Int64Ptr(int64(50)),
+				Constraints: []*models.CreateConstraintRequest{
+					{Property: util.StringPtr("state"), Operator: util.StringPtr("EQ"), Value: util.StringPtr(`"CA"`)},
+				},
+				Distributions: []*models.DistributionDefinition{
+					{VariantKey: util.StringPtr("control"), Percent: util.Int64Ptr(int64(20))},
+					{VariantKey: util.StringPtr("treatment"), Percent: util.Int64Ptr(int64(80))},
+				},
+			},
+			{
+				Description:    util.StringPtr("everyone"),
+				RolloutPercent: util.Int64Ptr(int64(100)),
+			},
+		},
+	}
+}
+
+func TestValidateFlag(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	t.Run("it should validate the definition without saving it", func(t *testing.T) {
+		res = c.ValidateFlag(flag.ValidateFlagParams{
+			Body: &models.ValidateFlagRequest{Flag: genFlagDefinition("flag_a")},
+		})
+		payload := res.(*flag.ValidateFlagOK).Payload
+		assert.True(t, *payload.Valid)
+		assert.Empty(t, payload.Errors)
+
+		count := 0
+		db.Model(&entity.Flag{}).Count(&count)
+		assert.Zero(t, count)
+	})
+
+	t.Run("it should return all the errors with their paths", func(t *testing.T) {
+		def := genFlagDefinition("flag_a")
+		def.DefaultVariantKey = "unknown"
+		def.Segments[0].Constraints[0].Operator = util.StringPtr("EQ")
+		def.Segments[0].Constraints[0].Value = util.StringPtr("CA")
+		def.Segments[0].Distributions[1].Percent = util.Int64Ptr(int64(70))
+		res = c.ValidateFlag(flag.ValidateFlagParams{
+			Body: &models.ValidateFlagRequest{Flag: def},
+		})
+		payload := res.(*flag.ValidateFlagOK).Payload
+		assert.False(t, *payload.Valid)
+
+		paths := []string{}
+		for _, e := range payload.Errors {
+			paths = append(paths, *e.Path)
+			assert.NotEmpty(t, *e.Message)
+		}
+		assert.Equal(t, []string{
+			"$.flag.defaultVariantKey",
+			"$.flag.segments[0].constraints[0]",
+			"$.flag.segments[0].distributions",
+		}, paths)
+	})
+}
+
+func TestSaveFlagsBatch(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	t.Run("it should create the flags with the nested definitions", func(t *testing.T) {
+		res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+			Body: &models.SaveFlagsBatchRequest{
+				Flags: []*models.FlagDefinition{genFlagDefinition("flag_a"), genFlagDefinition("flag_b")},
+			},
+		})
+		results := res.(*flag.SaveFlagsBatchOK).Payload.Results
+		assert.Len(t, results, 2)
+
+		f := results[0].Flag
+		assert.Equal(t, "flag_a", f.Key)
+		assert.True(t, *f.Enabled)
+		assert.Len(t, f.Variants, 2)
+		assert.Len(t, f.Segments, 2)
+		assert.Len(t, f.Tags, 1)
+		assert.Equal(t, "CA users", *f.Segments[0].Description)
+		assert.Len(t, f.Segments[0].Constraints, 1)
+		assert.Len(t, f.Segments[0].Distributions, 2)
+		assert.Equal(t, f.Variants[0].ID, f.DefaultVariantID)
+		assert.Equal(t, f.Variants[1].ID, *f.Segments[0].Distributions[1].VariantID)
+	})
+
+	t.Run("it should update the existing flag and keep the variant ids", func(t *testing.T) {
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
+		old := res.(*flag.GetFlagOK).Payload
+
+		def := genFlagDefinition("flag_a")
+		def.Description = util.StringPtr("updated")
+		def.Segments = def.Segments[1:]
+		def.Variants = def.Variants[:1]
+		res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+			Body: &models.SaveFlagsBatchRequest{Flags: []*models.FlagDefinition{def}},
+		})
+		f := res.(*flag.SaveFlagsBatchOK).Payload.Results[0].Flag
+		assert.Equal(t, old.ID, f.ID)
+		assert.Equal(t, "updated", *f.Description)
+		assert.Len(t, f.Segments, 1)
+		assert.Len(t, f.Variants, 1)
+		assert.Equal(t, old.Variants[0].ID, f.Variants[0].ID)
+	})
+
+	t.Run("it should roll back all the flags if any of them is invalid", func(t *testing.T) {
+		invalid := genFlagDefinition("flag_d")
+		invalid.Segments[0].Distributions[0].Percent = util.Int64Ptr(int64(10))
+		res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+			Body: &models.SaveFlagsBatchRequest{
+				Flags: []*models.FlagDefinition{genFlagDefinition("flag_c"), invalid},
+			},
+		})
+		results := res.(*flag.SaveFlagsBatchBadRequest).Payload.Results
+		assert.Empty(t, results[0].Error)
+		assert.Contains(t, results[1].Error, "not 100")
+
+		res = c.FindFlags(flag.FindFlagsParams{Key: util.StringPtr("flag_c")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 0)
+	})
+
+	t.Run("it should reject the unresolved variant references", func(t *testing.T) {
+		def := genFlagDefinition("flag_e")
+		def.DefaultVariantKey = "not_exist"
+		res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+			Body: &models.SaveFlagsBatchRequest{Flags: []*models.FlagDefinition{def}},
+		})
+		assert.NotEmpty(t, res.(*flag.SaveFlagsBatchBadRequest).Payload.Results[0].Error)
+	})
+}
+
+func TestExportImportFlag(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+		Body: &models.SaveFlagsBatchRequest{
+			Flags: []*models.FlagDefinition{genFlagDefinition("flag_a")},
+		},
+	})
+	flagID := res.(*flag.SaveFlagsBatchOK).Payload.Results[0].Flag.ID
+
+	t.Run("it should export the flag definition", func(t *testing.T) {
+		res = c.ExportFlag(flag.ExportFlagParams{FlagID: flagID})
+		def := res.(*flag.ExportFlagOK).Payload
+		assert.Equal(t, "flag_a", def.Key)
+		assert.Equal(t, "control", def.DefaultVariantKey)
+		assert.Equal(t, []string{"seeded"}, def.Tags)
+		assert.Len(t, def.Variants, 2)
+		assert.Len(t, def.Segments, 2)
+		assert.Equal(t, "treatment", *def.Segments[0].Distributions[1].VariantKey)
+		assert.Equal(t, int64(80), *def.Segments[0].Distributions[1].Percent)
+	})
+
+	t.Run("it should import the exported definition under another key", func(t *testing.T) {
+		res = c.ExportFlag(flag.ExportFlagParams{FlagID: flagID})
+		exported := res.(*flag.ExportFlagOK).Payload
+
+		res = c.ImportFlag(flag.ImportFlagParams{
+			Body: &models.ImportFlagRequest{Flag: exported, Key: "flag_a_copy"},
+		})
+		imported := res.(*flag.ImportFlagOK).Payload
+		assert.NotEqual(t, flagID, imported.ID)
+		assert.Equal(t, "flag_a_copy", imported.Key)
+
+		res = c.ExportFlag(flag.ExportFlagParams{FlagID: imported.ID})
+		reexported := res.(*flag.ExportFlagOK).Payload
+		assert.Equal(t, "flag_a_copy", reexported.Key)
+		reexported.Key = exported.Key
+		assert.Equal(t, exported, reexported)
+	})
+
+	t.Run("it should not overwrite the flag with the same key", func(t *testing.T) {
+		res = c.ImportFlag(flag.ImportFlagParams{
+			Body: &models.ImportFlagRequest{Flag: genFlagDefinition("flag_a")},
+		})
+		assert.NotZero(t, res.(*flag.ImportFlagDefault).Payload)
+
+		res = c.ExportFlag(flag.ExportFlagParams{FlagID: 999999})
+		assert.NotZero(t, res.(*flag.ExportFlagDefault).Payload)
+	})
+
+	t.Run("it should fail with invalid definitions", func(t *testing.T) {
+		def := genFlagDefinition("flag_invalid")
+		def.DefaultVariantKey = "unknown"
+		res = c.ImportFlag(flag.ImportFlagParams{
+			Body: &models.ImportFlagRequest{Flag: def},
+		})
+		assert.NotZero(t, res.(*flag.ImportFlagDefault).Payload)
+
+		count := 0
+		db.Model(&entity.Flag{}).Where(entity.Flag{Key: "flag_invalid"}).Count(&count)
+		assert.Zero(t, count)
+	})
+}
+
+func TestRestoreFlagSnapshot(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+		Body: &models.SaveFlagsBatchRequest{
+			Flags: []*models.FlagDefinition{genFlagDefinition("flag_a"), genFlagDefinition("flag_b")},
+		},
+	})
+	results := res.(*flag.SaveFlagsBatchOK).Payload.Results
+	original := results[0].Flag
+	otherFlagID := results[1].Flag.ID
+
+	fs := entity.FlagSnapshot{}
+	db.Where(entity.FlagSnapshot{FlagID: uint(original.ID)}).Last(&fs)
+
+	broken := genFlagDefinition("flag_a")
+	broken.Description = util.StringPtr("broken flag a")
+	broken.Segments = broken.Segments[1:]
+	broken.Variants = append(broken.Variants, &models.CreateVariantRequest{Key: util.StringPtr("extra")})
+	c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+		Body: &models.SaveFlagsBatchRequest{Flags: []*models.FlagDefinition{broken}},
+	})
+
+	t.Run("it should restore the segments and variants of the snapshot", func(t *testing.T) {
+		snapshotCount := 0
+		db.Model(&entity.FlagSnapshot{}).Where(entity.FlagSnapshot{FlagID: uint(original.ID)}).Count(&snapshotCount)
+
+		res = c.RestoreFlagSnapshot(flag.RestoreFlagSnapshotParams{FlagID: original.ID, SnapshotID: int64(fs.ID)})
+		f := res.(*flag.RestoreFlagSnapshotOK).Payload
+		assert.Equal(t, "broken flag a", *f.Description)
+		assert.Len(t, f.Variants, 2)
+		assert.Equal(t, original.Variants[1].ID, f.Variants[1].ID)
+		assert.Equal(t, original.DefaultVariantID, f.DefaultVariantID)
+		assert.Len(t, f.Segments, 2)
+		assert.Equal(t, "CA users", *f.Segments[0].Description)
+		assert.Len(t, f.Segments[0].Constraints, 1)
+		assert.Equal(t, int64(80), *f.Segments[0].Distributions[1].Percent)
+		assert.Equal(t, original.Variants[1].ID, *f.Segments[0].Distributions[1].VariantID)
+
+		newSnapshotCount := 0
+		db.Model(&entity.FlagSnapshot{}).Where(entity.FlagSnapshot{FlagID: uint(original.ID)}).Count(&newSnapshotCount)
+		assert.Equal(t, snapshotCount+2, newSnapshotCount)
+	})
+
+	t.Run("it should fail with snapshots of other flags", func(t *testing.T) {
+		res = c.RestoreFlagSnapshot(flag.RestoreFlagSnapshotParams{FlagID: otherFlagID, SnapshotID: int64(fs.ID)})
+		assert.NotZero(t, res.(*flag.RestoreFlagSnapshotDefault).Payload)
+
+		res = c.RestoreFlagSnapshot(flag.RestoreFlagSnapshotParams{FlagID: original.ID, SnapshotID: 999999})
+		assert.NotZero(t, res.(*flag.RestoreFlagSnapshotDefault).Payload)
+	})
+
+	t.Run("it should fail when the distributions reference missing variants", func(t *testing.T) {
+		snapshot := &entity.Flag{}
+		assert.NoError(t, json.Unmarshal(fs.Flag, snapshot))
+		snapshot.Segments[0].Distributions[0].VariantID = 999999
+		b, _ := json.Marshal(snapshot)
+		corrupted := entity.FlagSnapshot{FlagID: uint(original.ID), Flag: b}
+		db.Create(&corrupted)
+
+		res = c.RestoreFlagSnapshot(flag.RestoreFlagSnapshotParams{FlagID: original.ID, SnapshotID: int64(corrupted.ID)})
+		assert.NotZero(t, res.(*flag.RestoreFlagSnapshotDefault).Payload)
+	})
+}
+
+func TestGetFlagSnapshotsPagination(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{Description: util.StringPtr("funny flag")},
+	})
+	for i := 0; i < 4; i++ {
+		c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+			FlagID: int64(1),
+			Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(i%2 == 0)},
+		})
+	}
+	fs := []entity.FlagSnapshot{}
+	db.Order("id").Find(&fs)
+	assert.Len(t, fs, 5)
+	day := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, f := range fs {
+		db.Model(&entity.FlagSnapshot{}).Where("id = ?", f.ID).UpdateColumn("created_at", day.AddDate(0, 0, i))
+	}
+
+	t.Run("it should page through the snapshots, the latest first", func(t *testing.T) {
+		res = c.GetFlagSnapshots(flag.GetFlagSnapshotsParams{FlagID: int64(1), Limit: util.Int64Ptr(2), Offset: util.Int64Ptr(1)})
+		ok := res.(*flag.GetFlagSnapshotsOK)
+		assert.Equal(t, int64(5), ok.XTotalCount)
+		assert.Len(t, ok.Payload, 2)
+		assert.Equal(t, int64(fs[3].ID), *ok.Payload[0].ID)
+		assert.Equal(t, int64(fs[2].ID), *ok.Payload[1].ID)
+	})
+
+	t.Run("it should filter the snapshots by the date range", func(t *testing.T) {
+		from, to := strfmt.DateTime(day.AddDate(0, 0, 1)), strfmt.DateTime(day.AddDate(0, 0, 3))
+		res = c.GetFlagSnapshots(flag.GetFlagSnapshotsParams{FlagID: int64(1), From: &from, To: &to})
+		ok := res.(*flag.GetFlagSnapshotsOK)
+		assert.Equal(t, int64(2), ok.XTotalCount)
+		assert.Len(t, ok.Payload, 2)
+		assert.Equal(t, int64(fs[2].ID), *ok.Payload[0].ID)
+		assert.Equal(t, int64(fs[1].ID), *ok.Payload[1].ID)
+
+		res = c.GetFlagSnapshots(flag.GetFlagSnapshotsParams{FlagID: int64(1), From: &to})
+		assert.Equal(t, int64(2), res.(*flag.GetFlagSnapshotsOK).XTotalCount)
+	})
+
+	t.Run("it should return all the snapshots without limit", func(t *testing.T) {
+		res = c.GetFlagSnapshots(flag.GetFlagSnapshotsParams{FlagID: int64(1)})
+		ok := res.(*flag.GetFlagSnapshotsOK)
+		assert.Equal(t, int64(5), ok.XTotalCount)
+		assert.Len(t, ok.Payload, 5)
+		assert.Equal(t, int64(fs[4].ID), *ok.Payload[0].ID)
+	})
+}
+
+func TestGetFlagSnapshotsDiff(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+		Body: &models.SaveFlagsBatchRequest{
+			Flags: []*models.FlagDefinition{genFlagDefinition("flag_a"), genFlagDefinition("flag_b")},
+		},
+	})
+	results := res.(*flag.SaveFlagsBatchOK).Payload.Results
+	flagID, otherFlagID := results[0].Flag.ID, results[1].Flag.ID
+
+	from := entity.FlagSnapshot{}
+	db.Where(entity.FlagSnapshot{FlagID: uint(flagID)}).Last(&from)
+	c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+		FlagID: flagID,
+		Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(false)},
+	})
+	to := entity.FlagSnapshot{}
+	db.Where(entity.FlagSnapshot{FlagID: uint(flagID)}).Last(&to)
+	other := entity.FlagSnapshot{}
+	db.Where(entity.FlagSnapshot{FlagID: uint(otherFlagID)}).Last(&other)
+
+	t.Run("it should diff the snapshots in either order", func(t *testing.T) {
+		res = c.GetFlagSnapshotsDiff(flag.GetFlagSnapshotsDiffParams{FlagID: flagID, From: int64(from.ID), To: int64(to.ID)})
+		d := res.(*flag.GetFlagSnapshotsDiffOK).Payload
+		assert.Equal(t, int64(from.ID), *d.FromSnapshotID)
+		assert.Len(t, d.Flag, 1)
+		assert.Equal(t, "Enabled", *d.Flag[0].Field)
+		assert.Equal(t, true, d.Flag[0].Before)
+		assert.Equal(t, false, d.Flag[0].After)
+		assert.Empty(t, d.Segments)
+
+		res = c.GetFlagSnapshotsDiff(flag.GetFlagSnapshotsDiffParams{FlagID: flagID, From: int64(to.ID), To: int64(from.ID)})
+		d = res.(*flag.GetFlagSnapshotsDiffOK).Payload
+		assert.Equal(t, false, d.Flag[0].Before)
+		assert.Equal(t, true, d.Flag[0].After)
+	})
+
+	t.Run("it should fail on the snapshots of the other flags", func(t *testing.T) {
+		res = c.GetFlagSnapshotsDiff(flag.GetFlagSnapshotsDiffParams{FlagID: flagID, From: int64(from.ID), To: int64(other.ID)})
+		assert.IsType(t, &flag.GetFlagSnapshotsDiffDefault{}, res)
+
+		res = c.GetFlagSnapshotsDiff(flag.GetFlagSnapshotsDiffParams{FlagID: flagID, From: int64(from.ID), To: int64(999999)})
+		assert.IsType(t, &flag.GetFlagSnapshotsDiffDefault{}, res)
+	})
+}
+
+func TestCloneFlag(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+		Body: &models.SaveFlagsBatchRequest{
+			Flags: []*models.FlagDefinition{genFlagDefinition("flag_a")},
+		},
+	})
+	original := res.(*flag.SaveFlagsBatchOK).Payload.Results[0].Flag
+
+	t.Run("it should clone the flag into a disabled flag", func(t *testing.T) {
+		res = c.CloneFlag(flag.CloneFlagParams{FlagID: original.ID})
+		clone := res.(*flag.CloneFlagOK).Payload
+		assert.Equal(t, "flag_a_clone", clone.Key)
+		assert.NotEqual(t, original.ID, clone.ID)
+		assert.True(t, *original.Enabled)
+		assert.False(t, *clone.Enabled)
+
+		assert.Len(t, clone.Variants, 2)
+		assert.NotEqual(t, original.Variants[1].ID, clone.Variants[1].ID)
+		assert.Equal(t, clone.Variants[0].ID, clone.DefaultVariantID)
+		assert.Equal(t, original.Variants[1].Attachment, clone.Variants[1].Attachment)
+
+		assert.Len(t, clone.Segments, 2)
+		assert.NotEqual(t, original.Segments[0].ID, clone.Segments[0].ID)
+		assert.Len(t, clone.Segments[0].Constraints, 1)
+		assert.Equal(t, int64(80), *clone.Segments[0].Distributions[1].Percent)
+		assert.Equal(t, clone.Variants[1].ID, *clone.Segments[0].Distributions[1].VariantID)
+		assert.Equal(t, original.Tags[0].Value, clone.Tags[0].Value)
+	})
+
+	t.Run("it should suffix the key until it's not used", func(t *testing.T) {
+		res = c.CloneFlag(flag.CloneFlagParams{FlagID: original.ID})
+		assert.Equal(t, "flag_a_clone_2", res.(*flag.CloneFlagOK).Payload.Key)
+
+		res = c.CloneFlag(flag.CloneFlagParams{FlagID: original.ID, Body: &models.CloneFlagRequest{Key: "flag_a_v2"}})
+		assert.Equal(t, "flag_a_v2", res.(*flag.CloneFlagOK).Payload.Key)
+	})
+
+	t.Run("it should fail with used keys or missing flags", func(t *testing.T) {
+		res = c.CloneFlag(flag.CloneFlagParams{FlagID: original.ID, Body: &models.CloneFlagRequest{Key: "flag_a"}})
+		assert.NotZero(t, res.(*flag.CloneFlagDefault).Payload)
+
+		res = c.CloneFlag(flag.CloneFlagParams{FlagID: 999999})
+		assert.NotZero(t, res.(*flag.CloneFlagDefault).Payload)
+	})
+}
+
+func TestCrudFlagSchedules(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{
+			Description: util.StringPtr("funny flag"),
+		},
+	})
+
+	t.Run("it should be able to create and find the schedules", func(t *testing.T) {
+		later := strfmt.DateTime(time.Now().Add(2 * time.Hour))
+		sooner := strfmt.DateTime(time.Now().Add(time.Hour))
+		res = c.CreateFlagSchedule(flag.CreateFlagScheduleParams{
+			FlagID: int64(1),
+			Body:   &models.CreateFlagScheduleRequest{Action: util.StringPtr("disable"), ScheduledAt: &later},
+		})
+		assert.Equal(t, "disable", *res.(*flag.CreateFlagScheduleOK).Payload.Action)
+		res = c.CreateFlagSchedule(flag.CreateFlagScheduleParams{
+			FlagID: int64(1),
+			Body:   &models.CreateFlagScheduleRequest{Action: util.StringPtr("enable"), ScheduledAt: &sooner},
+		})
+		assert.Equal(t, int64(1), *res.(*flag.CreateFlagScheduleOK).Payload.FlagID)
+
+		res = c.FindFlagSchedules(flag.FindFlagSchedulesParams{FlagID: int64(1)})
+		ss := res.(*flag.FindFlagSchedulesOK).Payload
+		assert.Len(t, ss, 2)
+		assert.Equal(t, "enable", *ss[0].Action)
+		assert.Equal(t, "disable", *ss[1].Action)
+	})
+
+	t.Run("it should fail with past times or missing flags", func(t *testing.T) {
+		past := strfmt.DateTime(time.Now().Add(-time.Hour))
+		res = c.CreateFlagSchedule(flag.CreateFlagScheduleParams{
+			FlagID: int64(1),
+			Body:   &models.CreateFlagScheduleRequest{Action: util.StringPtr("enable"), ScheduledAt: &past},
+		})
+		assert.NotZero(t, res.(*flag.CreateFlagScheduleDefault).Payload)
+
+		future := strfmt.DateTime(time.Now().Add(time.Hour))
+		res = c.CreateFlagSchedule(flag.CreateFlagScheduleParams{
+			FlagID: int64(999999),
+			Body:   &models.CreateFlagScheduleRequest{Action: util.StringPtr("enable"), ScheduledAt: &future},
+		})
+		assert.NotZero(t, res.(*flag.CreateFlagScheduleDefault).Payload)
+
+		db.Error = fmt.Errorf("db generic error")
+		res = c.FindFlagSchedules(flag.FindFlagSchedulesParams{FlagID: int64(1)})
+		assert.NotZero(t, res.(*flag.FindFlagSchedulesDefault).Payload)
+		db.Error = nil
+	})
+}
+
+func TestCrudFlagKey(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	t.Run("it should create the flag with a slug key", func(t *testing.T) {
+		res = c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("checkout"), Key: "checkout-redesign"},
+		})
+		assert.Equal(t, "checkout-redesign", res.(*flag.CreateFlagOK).Payload.Key)
+	})
+
+	t.Run("it should generate the key if not provided", func(t *testing.T) {
+		res = c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("generated")},
+		})
+		assert.NotZero(t, res.(*flag.CreateFlagOK).Payload.Key)
+	})
+
+	t.Run("it should reject the invalid key", func(t *testing.T) {
+		res = c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("invalid"), Key: "Checkout Redesign"},
+		})
+		assert.NotZero(t, res.(*flag.CreateFlagDefault).Payload)
+	})
+
+	t.Run("it should reject the duplicate key", func(t *testing.T) {
+		res = c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("duplicate"), Key: "checkout-redesign"},
+		})
+		assert.Contains(t, *res.(*flag.CreateFlagDefault).Payload.Message, "already exists")
+
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(2),
+			Body:   &models.PutFlagRequest{Key: util.StringPtr("checkout-redesign")},
+		})
+		assert.Contains(t, *res.(*flag.PutFlagDefault).Payload.Message, "already exists")
+	})
+
+	t.Run("it should reject the key of a deleted flag", func(t *testing.T) {
+		c.DeleteFlag(flag.DeleteFlagParams{FlagID: int64(1)})
+		res = c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("deleted"), Key: "checkout-redesign"},
+		})
+		assert.Contains(t, *res.(*flag.CreateFlagDefault).Payload.Message, "already exists")
+	})
+
+	t.Run("it should keep the key when put with the same key", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(2),
+			Body:   &models.PutFlagRequest{Key: util.StringPtr("checkout-v2")},
+		})
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(2),
+			Body:   &models.PutFlagRequest{Key: util.StringPtr("checkout-v2")},
+		})
+		assert.Equal(t, "checkout-v2", res.(*flag.PutFlagOK).Payload.Key)
+	})
+}
+
+func TestCrudFlagAttachmentSchema(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"color"},
+		"properties": map[string]interface{}{
+			"color": map[string]interface{}{"type": "string", "enum": []interface{}{"red", "blue"}},
+		},
+	}
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{Description: util.StringPtr("schema"), Key: "schema_flag"},
+	})
+	c.CreateVariant(variant.CreateVariantParams{
+		FlagID: int64(1),
+		Body: &models.CreateVariantRequest{
+			Key:        util.StringPtr("red"),
+			Attachment: map[string]interface{}{"color": "red"},
+		},
+	})
+	c.CreateVariant(variant.CreateVariantParams{
+		FlagID: int64(1),
+		Body: &models.CreateVariantRequest{
+			Key:        util.StringPtr("green"),
+			Attachment: map[string]interface{}{"color": "green"},
+		},
+	})
+
+	t.Run("it should reject the invalid schema", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagRequest{AttachmentSchema: map[string]interface{}{"type": "nope"}},
+		})
+		assert.Contains(t, *res.(*flag.PutFlagDefault).Payload.Message, "invalid attachment schema")
+	})
+
+	t.Run("it should reject the schema that the existing variants don't match", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagRequest{AttachmentSchema: schema},
+		})
+		assert.Contains(t, *res.(*flag.PutFlagDefault).Payload.Message, "existing variant green")
+	})
+
+	t.Run("it should set the schema", func(t *testing.T) {
+		c.PutVariant(variant.PutVariantParams{
+			FlagID:    int64(1),
+			VariantID: int64(2),
+			Body: &models.PutVariantRequest{
+				Key:        util.StringPtr("blue"),
+				Attachment: map[string]interface{}{"color": "blue"},
+			},
+		})
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagRequest{AttachmentSchema: schema},
+		})
+		assert.Equal(t, schema, res.(*flag.PutFlagOK).Payload.AttachmentSchema)
+	})
+
+	t.Run("it should validate the attachments of the variants", func(t *testing.T) {
+		res = c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body: &models.CreateVariantRequest{
+				Key:        util.StringPtr("green"),
+				Attachment: map[string]interface{}{"color": "green"},
+			},
+		})
+		assert.Contains(t, *res.(*variant.CreateVariantDefault).Payload.Message, "error validating variant green")
+
+		res = c.PutVariant(variant.PutVariantParams{
+			FlagID:    int64(1),
+			VariantID: int64(1),
+			Body: &models.PutVariantRequest{
+				Key:        util.StringPtr("red"),
+				Attachment: map[string]interface{}{},
+			},
+		})
+		assert.Contains(t, *res.(*variant.PutVariantDefault).Payload.Message, "error validating variant red")
+
+		res = c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body: &models.CreateVariantRequest{
+				Key:        util.StringPtr("another_red"),
+				Attachment: map[string]interface{}{"color": "red"},
+			},
+		})
+		assert.NotZero(t, res.(*variant.CreateVariantOK).Payload.ID)
+	})
+
+	t.Run("it should validate the attachments of the imported flag", func(t *testing.T) {
+		res = c.ExportFlag(flag.ExportFlagParams{FlagID: int64(1)})
+		def := res.(*flag.ExportFlagOK).Payload
+		assert.Equal(t, schema, def.AttachmentSchema)
+
+		def.Key = "schema_flag_imported"
+		def.Variants[0].Attachment = map[string]interface{}{"color": "green"}
+		res = c.ImportFlag(flag.ImportFlagParams{Body: &models.ImportFlagRequest{Flag: def}})
+		assert.Contains(t, *res.(*flag.ImportFlagDefault).Payload.Message, "error validating variant")
+	})
+
+	t.Run("it should unset the schema", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagRequest{AttachmentSchema: map[string]interface{}{}},
+		})
+		assert.Nil(t, res.(*flag.PutFlagOK).Payload.AttachmentSchema)
+	})
+}
+
+func TestCrudFlagPrerequisite(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	for _, key := range []string{"dependent_flag", "parent_flag"} {
+		c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr(key), Key: key},
+		})
+	}
+	c.CreateVariant(variant.CreateVariantParams{
+		FlagID: int64(2),
+		Body:   &models.CreateVariantRequest{Key: util.StringPtr("on")},
+	})
+
+	t.Run("it should be able to put flag's prerequisite", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				PrerequisiteFlagID:     util.Int64Ptr(2),
+				PrerequisiteVariantKey: util.StringPtr("on"),
+			},
+		})
+		assert.Equal(t, int64(2), res.(*flag.PutFlagOK).Payload.PrerequisiteFlagID)
+		assert.Equal(t, "on", res.(*flag.PutFlagOK).Payload.PrerequisiteVariantKey)
+	})
+
+	t.Run("it should reject the cycles of prerequisites", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(2),
+			Body:   &models.PutFlagRequest{PrerequisiteFlagID: util.Int64Ptr(1)},
+		})
+		assert.NotZero(t, res.(*flag.PutFlagDefault).Payload)
+	})
+
+	t.Run("it should be able to unset flag's prerequisite", func(t *testing.T) {
+		res = c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				PrerequisiteFlagID:     util.Int64Ptr(0),
+				PrerequisiteVariantKey: util.StringPtr(""),
+			},
+		})
+		assert.Zero(t, res.(*flag.PutFlagOK).Payload.PrerequisiteFlagID)
+		assert.Empty(t, res.(*flag.PutFlagOK).Payload.PrerequisiteVariantKey)
+	})
+}
@@ -37,6 +37,7 @@ type DataRecordFrameOptions struct {
 	Encrypted       bool
 	Encryptor       dataRecordEncryptor
 	FrameOutputMode string
+	IncludeFlagMeta bool
 }
 
 type rawPayload struct {
@@ -48,6 +49,17 @@ type stringPayload struct {
 	Encrypted bool   `json:"encrypted"`
 }
 
+// dataRecordFlagMeta is the metadata of the flag added to the payload with FLAGR_RECORDER_INCLUDE_FLAG_META
+type dataRecordFlagMeta struct {
+	FlagTags        []string `json:"flagTags"`
+	FlagDescription string   `json:"flagDescription"`
+}
+
+type evalResultWithFlagMeta struct {
+	*models.EvalResult
+	*dataRecordFlagMeta
+}
+
 // DataRecordFrame represents the structure we can json.Marshal into data recorders
 type DataRecordFrame struct {
 	evalResult models.EvalResult
@@ -56,7 +68,7 @@ type DataRecordFrame struct {
 
 // MarshalJSON defines the behavior of MarshalJSON for DataRecordFrame
 func (drf *DataRecordFrame) MarshalJSON() ([]byte, error) {
-	payload, err := drf.evalResult.MarshalBinary()
+	payload, err := drf.payload()
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +96,17 @@ func (drf *DataRecordFrame) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// payload marshals the eval result, with the metadata of the flag in the evaluation cache if it's included
+func (drf *DataRecordFrame) payload() ([]byte, error) {
+	if !drf.options.IncludeFlagMeta {
+		return drf.evalResult.MarshalBinary()
+	}
+	return json.Marshal(&evalResultWithFlagMeta{
+		EvalResult:         &drf.evalResult,
+		dataRecordFlagMeta: GetEvalCache().getFlagMeta(drf.evalResult.FlagID),
+	})
+}
+
 // GetPartitionKey gets the partition key from entityID
 func (drf *DataRecordFrame) GetPartitionKey() string {
 	if drf.evalResult.EvalContext == nil {
@@ -3,7 +3,9 @@ package handler
 import (
 	"testing"
 
+	"github.com/checkr/flagr/pkg/entity"
 	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,6 +61,38 @@ func TestFrameOutput(t *testing.T) {
 		assert.Contains(t, string(output), "payload")
 		assert.NotContains(t, string(output), `"payload":""`)
 	})
+
+	t.Run("payload with the flag meta from the eval cache", func(t *testing.T) {
+		ec := GenFixtureEvalCache()
+		f := ec.idCache["100"]
+		f.Description = "funny flag"
+		f.Tags = []entity.Tag{{Value: "checkout"}, {Value: "web"}}
+		defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+		frame := DataRecordFrame{
+			evalResult: models.EvalResult{FlagID: 100, VariantKey: "control"},
+			options: DataRecordFrameOptions{
+				FrameOutputMode: frameOutputModePayloadRawJSON,
+				IncludeFlagMeta: true,
+			},
+		}
+		output, err := frame.Output()
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), `"flagTags":["checkout","web"]`)
+		assert.Contains(t, string(output), `"flagDescription":"funny flag"`)
+		assert.Contains(t, string(output), `"variantKey":"control"`)
+
+		frame.evalResult.FlagID = 1
+		output, err = frame.Output()
+		assert.NoError(t, err)
+		assert.NotContains(t, string(output), "flagTags")
+
+		frame.options.IncludeFlagMeta = false
+		frame.evalResult.FlagID = 100
+		output, err = frame.Output()
+		assert.NoError(t, err)
+		assert.NotContains(t, string(output), "flagTags")
+	})
 }
 
 func TestGetPartitionKey(t *testing.T) {
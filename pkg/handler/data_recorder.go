@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"fmt"
+	"hash/fnv"
 	"sync"
+	"time"
 
 	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/swagger_gen/models"
@@ -16,6 +19,16 @@ var (
 type DataRecorder interface {
 	AsyncRecord(models.EvalResult)
 	NewDataRecordFrame(models.EvalResult) DataRecordFrame
+	Stats() DataRecorderStats
+}
+
+// DataRecorderStats is the backlog of the data recorder. The recorders that can't
+// see their queue, e.g. the kinesis producer's, report the dropped records only.
+type DataRecorderStats struct {
+	// Queued is the number of the records queued in memory waiting to be delivered
+	Queued int64
+	// Dropped is the number of the records that failed to be delivered since the start
+	Dropped int64
 }
 
 // GetDataRecorder gets the data recorder
@@ -29,6 +42,12 @@ func GetDataRecorder() DataRecorder {
 			singletonDataRecorder = NewKinesisRecorder()
 		case "pubsub":
 			singletonDataRecorder = NewPubsubRecorder()
+		case "file":
+			singletonDataRecorder = NewFileRecorder()
+		case "http":
+			singletonDataRecorder = NewHTTPRecorder()
+		case "nats":
+			singletonDataRecorder = NewNatsRecorder()
 		default:
 			panic("recorderType not supported")
 		}
@@ -36,3 +55,49 @@ func GetDataRecorder() DataRecorder {
 
 	return singletonDataRecorder
 }
+
+// dataRecordSampleBuckets is the granularity of the data record sampling
+const dataRecordSampleBuckets = 10000
+
+// isDataRecordSampled tells if the evaluation result should be recorded given the sample rate.
+// It hashes the entityID, so that the decision is consistent for the same entity.
+func isDataRecordSampled(r *models.EvalResult, sampleRate float64) bool {
+	if sampleRate >= 1 {
+		return true
+	}
+	if sampleRate <= 0 {
+		return false
+	}
+
+	entityID := ""
+	if r.EvalContext != nil {
+		entityID = r.EvalContext.EntityID
+	}
+	h := fnv.New64a()
+	h.Write([]byte(entityID))
+	return h.Sum64()%dataRecordSampleBuckets < uint64(sampleRate*dataRecordSampleBuckets)
+}
+
+// dataRecorderStatsInterval is the interval of exporting the stats of the data recorder to prometheus
+const dataRecorderStatsInterval = 5 * time.Second
+
+// exportDataRecorderStats exports the stats of the data recorder to prometheus
+func exportDataRecorderStats(rec DataRecorder) {
+	s := rec.Stats()
+	config.Global.Prometheus.RecorderQueued.Set(float64(s.Queued))
+	config.Global.Prometheus.RecorderDropped.Set(float64(s.Dropped))
+}
+
+// dataRecorderBacklogWarning warns about the records queued over FLAGR_RECORDER_QUEUE_HIGH_WATER_MARK,
+// it's empty if there's no backlog or the recorder is not enabled
+func dataRecorderBacklogWarning() string {
+	mark := config.Config.RecorderQueueHighWaterMark
+	if !config.Config.RecorderEnabled || mark <= 0 {
+		return ""
+	}
+	s := GetDataRecorder().Stats()
+	if s.Queued <= int64(mark) {
+		return ""
+	}
+	return fmt.Sprintf("the data recorder has %d records queued over the high-water mark of %d, %d records are dropped since the start", s.Queued, mark, s.Dropped)
+}
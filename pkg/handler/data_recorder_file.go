@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/sirupsen/logrus"
+)
+
+type fileRecorder struct {
+	// dropped is the number of the records that failed to be written, it's updated atomically
+	dropped int64
+
+	path          string
+	maxSize       int64
+	flushInterval time.Duration
+	options       DataRecordFrameOptions
+
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	size   int64
+	closed bool
+	done   chan struct{}
+}
+
+// NewFileRecorder creates a new file recorder that appends JSON lines to a local file
+var NewFileRecorder = func() DataRecorder {
+	fr := &fileRecorder{
+		path:          config.Config.RecorderFilePath,
+		maxSize:       config.Config.RecorderFileMaxSize,
+		flushInterval: config.Config.RecorderFileFlushInterval,
+		options: DataRecordFrameOptions{
+			Encrypted:       false, // not implemented yet
+			FrameOutputMode: config.Config.RecorderFrameOutputMode,
+			IncludeFlagMeta: config.Config.RecorderIncludeFlagMeta,
+		},
+		done: make(chan struct{}),
+	}
+	if err := fr.open(); err != nil {
+		logrus.WithField("file_error", err).Fatal("error opening data record file")
+	}
+
+	go fr.flushPeriodically()
+	return fr
+}
+
+func (fr *fileRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return DataRecordFrame{
+		evalResult: r,
+		options:    fr.options,
+	}
+}
+
+func (fr *fileRecorder) AsyncRecord(r models.EvalResult) {
+	frame := fr.NewDataRecordFrame(r)
+	output, err := frame.Output()
+	if err != nil {
+		logrus.WithField("err", err).Error("failed to generate data record frame for file recorder")
+		return
+	}
+	output = append(output, '\n')
+
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	if fr.closed {
+		atomic.AddInt64(&fr.dropped, 1)
+		return
+	}
+
+	if fr.maxSize > 0 && fr.size > 0 && fr.size+int64(len(output)) > fr.maxSize {
+		if err := fr.rotate(); err != nil {
+			logrus.WithField("file_error", err).Error("error rotating data record file")
+			atomic.AddInt64(&fr.dropped, 1)
+			return
+		}
+	}
+
+	n, err := fr.writer.Write(output)
+	fr.size += int64(n)
+	if err != nil {
+		logrus.WithField("file_error", err).Error("error writing to data record file")
+		atomic.AddInt64(&fr.dropped, 1)
+	}
+}
+
+// Stats reports no queued records, the records are written to the file buffer synchronously
+func (fr *fileRecorder) Stats() DataRecorderStats {
+	return DataRecorderStats{Dropped: atomic.LoadInt64(&fr.dropped)}
+}
+
+// Close flushes the buffered records and closes the file
+func (fr *fileRecorder) Close() error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	if fr.closed {
+		return nil
+	}
+	fr.closed = true
+	close(fr.done)
+
+	if err := fr.writer.Flush(); err != nil {
+		return err
+	}
+	return fr.file.Close()
+}
+
+func (fr *fileRecorder) open() error {
+	f, err := os.OpenFile(fr.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	fr.file = f
+	fr.writer = bufio.NewWriter(f)
+	fr.size = info.Size()
+	return nil
+}
+
+// rotate moves the current file to path.<timestamp> and reopens the path.
+// It should be called with fr.mu held.
+func (fr *fileRecorder) rotate() error {
+	if err := fr.writer.Flush(); err != nil {
+		return err
+	}
+	if err := fr.file.Close(); err != nil {
+		return err
+	}
+
+	rotated := fmt.Sprintf("%s.%s", fr.path, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(fr.path, rotated); err != nil {
+		return err
+	}
+	return fr.open()
+}
+
+func (fr *fileRecorder) flushPeriodically() {
+	ticker := time.NewTicker(fr.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fr.done:
+			return
+		case <-ticker.C:
+			fr.mu.Lock()
+			if err := fr.writer.Flush(); err != nil {
+				logrus.WithField("file_error", err).Error("error flushing data record file")
+			}
+			fr.mu.Unlock()
+		}
+	}
+}
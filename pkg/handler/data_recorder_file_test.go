@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func countLines(t *testing.T, path string) int {
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	n := 0
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		n++
+	}
+	return n
+}
+
+func TestFileRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flagr_file_recorder")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	r := models.EvalResult{
+		EvalContext: &models.EvalContext{
+			EntityID: "d08042018",
+		},
+		FlagID:         1,
+		FlagSnapshotID: 1,
+		SegmentID:      1,
+		VariantID:      1,
+		VariantKey:     "control",
+	}
+
+	t.Run("it should flush the records on close", func(t *testing.T) {
+		path := filepath.Join(dir, "records.jsonl")
+		defer gostub.Stub(&config.Config.RecorderFilePath, path).Reset()
+
+		fr := NewFileRecorder()
+		fr.AsyncRecord(r)
+		fr.AsyncRecord(r)
+		assert.NoError(t, fr.(*fileRecorder).Close())
+		assert.Equal(t, 2, countLines(t, path))
+
+		// it should not panic after it's closed
+		assert.NotPanics(t, func() { fr.AsyncRecord(r) })
+	})
+
+	t.Run("it should rotate the file when it's beyond the max size", func(t *testing.T) {
+		path := filepath.Join(dir, "rotated.jsonl")
+		defer gostub.Stub(&config.Config.RecorderFilePath, path).Reset()
+		defer gostub.Stub(&config.Config.RecorderFileMaxSize, int64(1)).Reset()
+
+		fr := NewFileRecorder()
+		fr.AsyncRecord(r)
+		fr.AsyncRecord(r)
+		fr.AsyncRecord(r)
+		assert.NoError(t, fr.(*fileRecorder).Close())
+
+		matches, err := filepath.Glob(path + "*")
+		assert.NoError(t, err)
+		assert.Len(t, matches, 3)
+		assert.Equal(t, 1, countLines(t, path))
+	})
+}
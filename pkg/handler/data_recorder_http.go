@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/sirupsen/logrus"
+)
+
+type httpRecorder struct {
+	// dropped is the number of the dropped records, it's updated atomically
+	dropped int64
+
+	url           string
+	bearerToken   string
+	batchSize     int
+	flushInterval time.Duration
+	retryAttempts uint
+	retryDelay    time.Duration
+	client        *http.Client
+	options       DataRecordFrameOptions
+
+	records   chan json.RawMessage
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewHTTPRecorder creates a new HTTP recorder that POSTs batches of records to a collector
+var NewHTTPRecorder = func() DataRecorder {
+	if config.Config.RecorderHTTPURL == "" {
+		logrus.Fatal("FLAGR_RECORDER_HTTP_URL is required for the http recorder")
+	}
+
+	hr := &httpRecorder{
+		url:           config.Config.RecorderHTTPURL,
+		bearerToken:   config.Config.RecorderHTTPBearerToken,
+		batchSize:     config.Config.RecorderHTTPBatchSize,
+		flushInterval: config.Config.RecorderHTTPFlushInterval,
+		retryAttempts: config.Config.RecorderHTTPRetryAttempts,
+		retryDelay:    config.Config.RecorderHTTPRetryDelay,
+		client:        &http.Client{Timeout: config.Config.RecorderHTTPTimeout},
+		options: DataRecordFrameOptions{
+			Encrypted:       false, // not implemented yet
+			FrameOutputMode: config.Config.RecorderFrameOutputMode,
+			IncludeFlagMeta: config.Config.RecorderIncludeFlagMeta,
+		},
+		records: make(chan json.RawMessage, config.Config.RecorderHTTPBufferSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go hr.run()
+	return hr
+}
+
+func (hr *httpRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return DataRecordFrame{
+		evalResult: r,
+		options:    hr.options,
+	}
+}
+
+func (hr *httpRecorder) AsyncRecord(r models.EvalResult) {
+	frame := hr.NewDataRecordFrame(r)
+	output, err := frame.Output()
+	if err != nil {
+		logrus.WithField("err", err).Error("failed to generate data record frame for http recorder")
+		return
+	}
+
+	select {
+	case <-hr.done:
+		hr.drop(1, "recorder is closed")
+	case hr.records <- output:
+	default:
+		hr.drop(1, "buffer is full")
+	}
+}
+
+// Stats reports the records in the buffer as queued, the batch being posted is not counted
+func (hr *httpRecorder) Stats() DataRecorderStats {
+	return DataRecorderStats{
+		Queued:  int64(len(hr.records)),
+		Dropped: atomic.LoadInt64(&hr.dropped),
+	}
+}
+
+// Close stops accepting new records and flushes the ones in the buffer
+func (hr *httpRecorder) Close() error {
+	hr.closeOnce.Do(func() {
+		close(hr.done)
+		<-hr.stopped
+	})
+	return nil
+}
+
+func (hr *httpRecorder) run() {
+	defer close(hr.stopped)
+
+	ticker := time.NewTicker(hr.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]json.RawMessage, 0, hr.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		hr.post(batch)
+		batch = make([]json.RawMessage, 0, hr.batchSize)
+	}
+
+	for {
+		select {
+		case r := <-hr.records:
+			batch = append(batch, r)
+			if len(batch) >= hr.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-hr.done:
+			for {
+				select {
+				case r := <-hr.records:
+					batch = append(batch, r)
+					if len(batch) >= hr.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+type httpRecorderRetryableError struct{ error }
+
+func (hr *httpRecorder) post(batch []json.RawMessage) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		hr.drop(len(batch), err.Error())
+		return
+	}
+
+	err = retry.Do(
+		func() error {
+			req, err := http.NewRequest(http.MethodPost, hr.url, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			if hr.bearerToken != "" {
+				req.Header.Set("Authorization", "Bearer "+hr.bearerToken)
+			}
+
+			resp, err := hr.client.Do(req)
+			if err != nil {
+				return httpRecorderRetryableError{err}
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode >= 500 {
+				return httpRecorderRetryableError{fmt.Errorf("collector responded with %d", resp.StatusCode)}
+			}
+			if resp.StatusCode >= 300 {
+				return fmt.Errorf("collector responded with %d", resp.StatusCode)
+			}
+			return nil
+		},
+		retry.Attempts(hr.retryAttempts),
+		retry.Delay(hr.retryDelay),
+		retry.RetryIf(func(err error) bool {
+			_, ok := err.(httpRecorderRetryableError)
+			return ok
+		}),
+		retry.LastErrorOnly(true),
+	)
+	if err != nil {
+		hr.drop(len(batch), err.Error())
+	}
+}
+
+func (hr *httpRecorder) drop(n int, reason string) {
+	atomic.AddInt64(&hr.dropped, int64(n))
+	logrus.WithFields(logrus.Fields{"http_error": reason, "count": n}).Error("dropping data records of http recorder")
+
+	if config.Global.StatsdClient != nil {
+		config.Global.StatsdClient.Count("data_recorder.http.dropped", int64(n), nil, float64(1))
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPRecorder(t *testing.T) {
+	r := models.EvalResult{
+		EvalContext: &models.EvalContext{
+			EntityID: "d08042018",
+		},
+		FlagID:         1,
+		FlagSnapshotID: 1,
+		SegmentID:      1,
+		VariantID:      1,
+		VariantKey:     "control",
+	}
+
+	t.Run("it should post the records in batches", func(t *testing.T) {
+		var mu sync.Mutex
+		batches := [][]json.RawMessage{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "Bearer token1", req.Header.Get("Authorization"))
+			batch := []json.RawMessage{}
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&batch))
+			mu.Lock()
+			batches = append(batches, batch)
+			mu.Unlock()
+		}))
+		defer server.Close()
+
+		defer gostub.Stub(&config.Config.RecorderHTTPURL, server.URL).Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPBearerToken, "token1").Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPBatchSize, 2).Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPFlushInterval, time.Hour).Reset()
+
+		hr := NewHTTPRecorder()
+		hr.AsyncRecord(r)
+		hr.AsyncRecord(r)
+		hr.AsyncRecord(r)
+		assert.NoError(t, hr.(*httpRecorder).Close())
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Len(t, batches, 2)
+		assert.Len(t, batches[0], 2)
+		assert.Len(t, batches[1], 1)
+	})
+
+	t.Run("it should retry on 5xx and drop the batch eventually", func(t *testing.T) {
+		var mu sync.Mutex
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			attempts++
+			mu.Unlock()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		defer gostub.Stub(&config.Config.RecorderHTTPURL, server.URL).Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPRetryAttempts, uint(3)).Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPRetryDelay, time.Millisecond).Reset()
+
+		hr := NewHTTPRecorder()
+		hr.AsyncRecord(r)
+		assert.NoError(t, hr.(*httpRecorder).Close())
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("it should not retry on 4xx", func(t *testing.T) {
+		var mu sync.Mutex
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			attempts++
+			mu.Unlock()
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		defer gostub.Stub(&config.Config.RecorderHTTPURL, server.URL).Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPRetryDelay, time.Millisecond).Reset()
+
+		hr := NewHTTPRecorder()
+		hr.AsyncRecord(r)
+		assert.NoError(t, hr.(*httpRecorder).Close())
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("it should not block when the buffer is full", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.RecorderHTTPURL, "http://127.0.0.1:0").Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPBufferSize, 0).Reset()
+		defer gostub.Stub(&config.Config.RecorderHTTPRetryAttempts, uint(1)).Reset()
+
+		hr := NewHTTPRecorder()
+		assert.NotPanics(t, func() {
+			for i := 0; i < 100; i++ {
+				hr.AsyncRecord(r)
+			}
+		})
+		assert.NoError(t, hr.(*httpRecorder).Close())
+		assert.Equal(t, DataRecorderStats{Dropped: 100}, hr.Stats())
+	})
+}
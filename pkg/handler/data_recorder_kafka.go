@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/checkr/flagr/pkg/config"
@@ -30,6 +31,26 @@ func mustParseKafkaVersion(version string) sarama.KafkaVersion {
 
 // NewKafkaRecorder creates a new Kafka recorder
 var NewKafkaRecorder = func() DataRecorder {
+	var encryptor dataRecordEncryptor
+	if config.Config.RecorderKafkaEncrypted && config.Config.RecorderKafkaEncryptionKey != "" {
+		encryptor = newSimpleboxEncryptor(config.Config.RecorderKafkaEncryptionKey)
+	}
+
+	kr := &kafkaRecorder{
+		topic: config.Config.RecorderKafkaTopic,
+		options: DataRecordFrameOptions{
+			Encrypted:       config.Config.RecorderKafkaEncrypted,
+			Encryptor:       encryptor,
+			FrameOutputMode: config.Config.RecorderFrameOutputMode,
+			IncludeFlagMeta: config.Config.RecorderIncludeFlagMeta,
+		},
+	}
+	kr.producer = newKafkaProducer(newKafkaConfig(), "failed to write access log entry", kr.delivered)
+	return kr
+}
+
+// newKafkaConfig creates the sarama config from the FLAGR_RECORDER_KAFKA_* settings
+func newKafkaConfig() *sarama.Config {
 	cfg := sarama.NewConfig()
 	tlscfg := createTLSConfiguration(
 		config.Config.RecorderKafkaCertFile,
@@ -37,44 +58,67 @@ var NewKafkaRecorder = func() DataRecorder {
 		config.Config.RecorderKafkaCAFile,
 		config.Config.RecorderKafkaVerifySSL,
 	)
+	if tlscfg == nil && config.Config.RecorderKafkaSSLEnabled {
+		tlscfg = createSSLConfiguration(
+			config.Config.RecorderKafkaCAFile,
+			config.Config.RecorderKafkaVerifySSL,
+		)
+	}
 	if tlscfg != nil {
 		cfg.Net.TLS.Enable = true
 		cfg.Net.TLS.Config = tlscfg
 	}
+	if err := setupKafkaSASL(
+		cfg,
+		config.Config.RecorderKafkaSASLUsername,
+		config.Config.RecorderKafkaSASLPassword,
+		config.Config.RecorderKafkaSASLMechanism,
+	); err != nil {
+		logrus.WithField("kafka_error", err).Fatal("invalid kafka sasl configuration")
+	}
 	cfg.Producer.RequiredAcks = sarama.WaitForLocal
 	cfg.Producer.Retry.Max = config.Config.RecorderKafkaRetryMax
 	cfg.Producer.Flush.Frequency = config.Config.RecorderKafkaFlushFrequency
 	cfg.Version = mustParseKafkaVersion(config.Config.RecorderKafkaVersion)
+	return cfg
+}
 
+// newKafkaProducer starts the producer to FLAGR_RECORDER_KAFKA_BROKERS, the messages that fail to be produced
+// are logged with errMsg. If delivered is not nil, it's called with every message once it's produced or it fails.
+func newKafkaProducer(cfg *sarama.Config, errMsg string, delivered func(*sarama.ProducerMessage, error)) sarama.AsyncProducer {
+	if delivered != nil {
+		cfg.Producer.Return.Successes = true
+	}
 	brokerList := strings.Split(config.Config.RecorderKafkaBrokers, ",")
 	producer, err := saramaNewAsyncProducer(brokerList, cfg)
 	if err != nil {
-		logrus.WithField("kafka_error", err).Fatal("Failed to start Sarama producer:")
+		logrus.WithFields(logrus.Fields{
+			"kafka_error":    err,
+			"brokers":        brokerList,
+			"tls":            cfg.Net.TLS.Enable,
+			"sasl_mechanism": cfg.Net.SASL.Mechanism,
+		}).Fatal("Failed to start Sarama producer, please check the connection to the kafka brokers")
 	}
 
 	// We will just log to STDOUT if we're not able to produce messages.
 	if producer != nil {
 		go func() {
 			for err := range producer.Errors() {
-				logrus.WithField("kafka_error", err).Error("failed to write access log entry")
+				logrus.WithField("kafka_error", err).Error(errMsg)
+				if delivered != nil {
+					delivered(err.Msg, err.Err)
+				}
 			}
 		}()
+		if delivered != nil {
+			go func() {
+				for msg := range producer.Successes() {
+					delivered(msg, nil)
+				}
+			}()
+		}
 	}
-
-	var encryptor dataRecordEncryptor
-	if config.Config.RecorderKafkaEncrypted && config.Config.RecorderKafkaEncryptionKey != "" {
-		encryptor = newSimpleboxEncryptor(config.Config.RecorderKafkaEncryptionKey)
-	}
-
-	return &kafkaRecorder{
-		topic:    config.Config.RecorderKafkaTopic,
-		producer: producer,
-		options: DataRecordFrameOptions{
-			Encrypted:       config.Config.RecorderKafkaEncrypted,
-			Encryptor:       encryptor,
-			FrameOutputMode: config.Config.RecorderFrameOutputMode,
-		},
-	}
+	return producer
 }
 
 func createTLSConfiguration(certFile string, keyFile string, caFile string, verifySSL bool) (t *tls.Config) {
@@ -102,7 +146,53 @@ func createTLSConfiguration(certFile string, keyFile string, caFile string, veri
 	return t
 }
 
+func createSSLConfiguration(caFile string, verifySSL bool) *tls.Config {
+	t := &tls.Config{
+		InsecureSkipVerify: !verifySSL,
+	}
+	if caFile != "" {
+		caCert, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			logrus.WithField("TLSConfigurationError", err).Panic(err)
+		}
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(caCert)
+		t.RootCAs = caCertPool
+	}
+	return t
+}
+
+func setupKafkaSASL(cfg *sarama.Config, username string, password string, mechanism string) error {
+	if username == "" {
+		return nil
+	}
+
+	cfg.Net.SASL.Enable = true
+	cfg.Net.SASL.User = username
+	cfg.Net.SASL.Password = password
+	cfg.Net.SASL.Mechanism = sarama.SASLMechanism(mechanism)
+
+	switch mechanism {
+	case sarama.SASLTypePlaintext:
+	case sarama.SASLTypeSCRAMSHA256:
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{HashGeneratorFcn: scramSHA256}
+		}
+	case sarama.SASLTypeSCRAMSHA512:
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{HashGeneratorFcn: scramSHA512}
+		}
+	default:
+		return fmt.Errorf("not supported sasl mechanism: %s", mechanism)
+	}
+	return nil
+}
+
 type kafkaRecorder struct {
+	// queued and dropped are the stats of the data records, they're updated atomically
+	queued  int64
+	dropped int64
+
 	producer sarama.AsyncProducer
 	topic    string
 	options  DataRecordFrameOptions
@@ -122,16 +212,37 @@ func (k *kafkaRecorder) AsyncRecord(r models.EvalResult) {
 		logrus.WithField("err", err).Error("failed to generate data record frame for kafka recorder")
 		return
 	}
+	atomic.AddInt64(&k.queued, 1)
 	k.producer.Input() <- &sarama.ProducerMessage{
 		Topic:     k.topic,
 		Key:       sarama.StringEncoder(frame.GetPartitionKey()),
 		Value:     sarama.ByteEncoder(output),
 		Timestamp: time.Now().UTC(),
+		Metadata:  k,
 	}
 
 	logKafkaAsyncRecordToDatadog(r)
 }
 
+// delivered updates the stats once the data record is produced or it fails. The producer can be
+// shared with the flag changes, so the data records are told apart by their metadata.
+func (k *kafkaRecorder) delivered(msg *sarama.ProducerMessage, err error) {
+	if msg == nil || msg.Metadata != k {
+		return
+	}
+	atomic.AddInt64(&k.queued, -1)
+	if err != nil {
+		atomic.AddInt64(&k.dropped, 1)
+	}
+}
+
+func (k *kafkaRecorder) Stats() DataRecorderStats {
+	return DataRecorderStats{
+		Queued:  atomic.LoadInt64(&k.queued),
+		Dropped: atomic.LoadInt64(&k.dropped),
+	}
+}
+
 var logKafkaAsyncRecordToDatadog = func(r models.EvalResult) {
 	if config.Global.StatsdClient == nil {
 		return
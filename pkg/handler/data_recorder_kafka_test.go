@@ -77,6 +77,61 @@ func TestCreateTLSConfiguration(t *testing.T) {
 	})
 }
 
+func TestCreateSSLConfiguration(t *testing.T) {
+	t.Run("happy code path", func(t *testing.T) {
+		tlsConfig := createSSLConfiguration("./testdata/certificates/ca.crt", true)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+
+		tlsConfig = createSSLConfiguration("", false)
+		assert.Nil(t, tlsConfig.RootCAs)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+	})
+
+	t.Run("ca file not found", func(t *testing.T) {
+		assert.Panics(t, func() {
+			createSSLConfiguration("./testdata/certificates/not_found.crt", true)
+		})
+	})
+}
+
+func TestSetupKafkaSASL(t *testing.T) {
+	t.Run("sasl is disabled without username", func(t *testing.T) {
+		cfg := sarama.NewConfig()
+		assert.NoError(t, setupKafkaSASL(cfg, "", "", sarama.SASLTypeSCRAMSHA256))
+		assert.False(t, cfg.Net.SASL.Enable)
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		cfg := sarama.NewConfig()
+		assert.NoError(t, setupKafkaSASL(cfg, "user", "password", sarama.SASLTypePlaintext))
+		assert.True(t, cfg.Net.SASL.Enable)
+		assert.Nil(t, cfg.Net.SASL.SCRAMClientGeneratorFunc)
+		assert.NoError(t, cfg.Validate())
+	})
+
+	t.Run("scram", func(t *testing.T) {
+		for _, mechanism := range []string{sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512} {
+			cfg := sarama.NewConfig()
+			assert.NoError(t, setupKafkaSASL(cfg, "user", "password", mechanism))
+			assert.Equal(t, sarama.SASLMechanism(mechanism), cfg.Net.SASL.Mechanism)
+			assert.NoError(t, cfg.Validate())
+
+			c := cfg.Net.SASL.SCRAMClientGeneratorFunc()
+			assert.NoError(t, c.Begin("user", "password", ""))
+			msg, err := c.Step("")
+			assert.NoError(t, err)
+			assert.Contains(t, msg, "n=user")
+			assert.False(t, c.Done())
+		}
+	})
+
+	t.Run("not supported mechanism", func(t *testing.T) {
+		cfg := sarama.NewConfig()
+		assert.Error(t, setupKafkaSASL(cfg, "user", "password", "GSSAPI"))
+	})
+}
+
 func TestAsyncRecord(t *testing.T) {
 	t.Run("happy code path", func(t *testing.T) {
 		p := &mockAsyncProducer{inputCh: make(chan *sarama.ProducerMessage)}
@@ -89,6 +144,24 @@ func TestAsyncRecord(t *testing.T) {
 		r := <-p.inputCh
 		assert.NotNil(t, r)
 	})
+
+	t.Run("it counts the queued and the dropped records", func(t *testing.T) {
+		p := &mockAsyncProducer{inputCh: make(chan *sarama.ProducerMessage, 3)}
+		kr := &kafkaRecorder{
+			producer: p,
+			topic:    "test-topic",
+		}
+
+		for i := 0; i < 3; i++ {
+			kr.AsyncRecord(models.EvalResult{})
+		}
+		assert.Equal(t, DataRecorderStats{Queued: 3}, kr.Stats())
+
+		kr.delivered(<-p.inputCh, nil)
+		kr.delivered(<-p.inputCh, sarama.ErrOutOfBrokers)
+		kr.delivered(&sarama.ProducerMessage{Topic: "flag-changes"}, nil)
+		assert.Equal(t, DataRecorderStats{Queued: 1, Dropped: 1}, kr.Stats())
+	})
 }
 
 func TestMustParseKafkaVersion(t *testing.T) {
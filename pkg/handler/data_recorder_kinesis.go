@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"sync/atomic"
+
 	producer "github.com/a8m/kinesis-producer"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -15,6 +17,9 @@ var (
 )
 
 type kinesisRecorder struct {
+	// dropped is the number of the records that failed to be put, it's updated atomically
+	dropped int64
+
 	producer *producer.Producer
 	options  DataRecordFrameOptions
 }
@@ -44,19 +49,23 @@ var NewKinesisRecorder = func() DataRecorder {
 
 	p.Start()
 
-	go func() {
-		for err := range p.NotifyFailures() {
-			logrus.WithField("kinesis_error", err).Error("error pushing to kinesis")
-		}
-	}()
-
-	return &kinesisRecorder{
+	kr := &kinesisRecorder{
 		producer: p,
 		options: DataRecordFrameOptions{
 			Encrypted:       false, // not implemented yet
 			FrameOutputMode: config.Config.RecorderFrameOutputMode,
+			IncludeFlagMeta: config.Config.RecorderIncludeFlagMeta,
 		},
 	}
+
+	go func() {
+		for err := range p.NotifyFailures() {
+			logrus.WithField("kinesis_error", err).Error("error pushing to kinesis")
+			atomic.AddInt64(&kr.dropped, 1)
+		}
+	}()
+
+	return kr
 }
 
 func (k *kinesisRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
@@ -76,5 +85,11 @@ func (k *kinesisRecorder) AsyncRecord(r models.EvalResult) {
 	err = k.producer.Put(output, frame.GetPartitionKey())
 	if err != nil {
 		logrus.WithField("kinesis_error", err).Error("error pushing to kinesis")
+		atomic.AddInt64(&k.dropped, 1)
 	}
 }
+
+// Stats reports the dropped records only, the backlog of the kinesis producer is not exposed
+func (k *kinesisRecorder) Stats() DataRecorderStats {
+	return DataRecorderStats{Dropped: atomic.LoadInt64(&k.dropped)}
+}
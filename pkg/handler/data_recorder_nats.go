@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+)
+
+var newNatsJetStream = func(errHandler nats.MsgErrHandler) (*nats.Conn, nats.JetStreamContext, error) {
+	opts := []nats.Option{nats.Name("flagr")}
+	if config.Config.RecorderNatsCredsFile != "" {
+		opts = append(opts, nats.UserCredentials(config.Config.RecorderNatsCredsFile))
+	}
+
+	nc, err := nats.Connect(config.Config.RecorderNatsURL, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	js, err := nc.JetStream(
+		nats.PublishAsyncMaxPending(config.Config.RecorderNatsMaxPendingAsync),
+		nats.PublishAsyncErrHandler(errHandler),
+	)
+	if err != nil {
+		nc.Close()
+		return nil, nil, err
+	}
+
+	if _, err := js.StreamInfo(config.Config.RecorderNatsStream); err != nil {
+		nc.Close()
+		return nil, nil, err
+	}
+	return nc, js, nil
+}
+
+type natsRecorder struct {
+	// dropped is the number of the records that failed to be published, it's updated atomically
+	dropped int64
+
+	conn         *nats.Conn
+	js           nats.JetStreamContext
+	stream       string
+	subject      string
+	flushTimeout time.Duration
+	options      DataRecordFrameOptions
+}
+
+// NewNatsRecorder creates a new NATS JetStream recorder
+var NewNatsRecorder = func() DataRecorder {
+	n := &natsRecorder{
+		stream:       config.Config.RecorderNatsStream,
+		subject:      config.Config.RecorderNatsSubject,
+		flushTimeout: config.Config.RecorderNatsFlushTimeout,
+		options: DataRecordFrameOptions{
+			Encrypted:       false, // not implemented yet
+			FrameOutputMode: config.Config.RecorderFrameOutputMode,
+			IncludeFlagMeta: config.Config.RecorderIncludeFlagMeta,
+		},
+	}
+
+	nc, js, err := newNatsJetStream(func(_ nats.JetStream, _ *nats.Msg, err error) {
+		logrus.WithField("nats_error", err).Error("error publishing to nats")
+		atomic.AddInt64(&n.dropped, 1)
+	})
+	if err != nil {
+		logrus.WithField("nats_error", err).Fatal("error connecting to nats jetstream")
+	}
+	n.conn, n.js = nc, js
+	return n
+}
+
+func (n *natsRecorder) NewDataRecordFrame(r models.EvalResult) DataRecordFrame {
+	return DataRecordFrame{
+		evalResult: r,
+		options:    n.options,
+	}
+}
+
+func (n *natsRecorder) AsyncRecord(r models.EvalResult) {
+	frame := n.NewDataRecordFrame(r)
+	output, err := frame.Output()
+	if err != nil {
+		logrus.WithField("err", err).Error("failed to generate data record frame for nats recorder")
+		return
+	}
+
+	if _, err := n.js.PublishAsync(n.subject, output, nats.ExpectStream(n.stream)); err != nil {
+		logrus.WithField("nats_error", err).Error("error publishing to nats")
+		atomic.AddInt64(&n.dropped, 1)
+	}
+}
+
+// Stats reports the publishes waiting for their acks as queued
+func (n *natsRecorder) Stats() DataRecorderStats {
+	return DataRecorderStats{
+		Queued:  int64(n.js.PublishAsyncPending()),
+		Dropped: atomic.LoadInt64(&n.dropped),
+	}
+}
+
+// Close waits for the pending publishes and closes the connection
+func (n *natsRecorder) Close() error {
+	select {
+	case <-n.js.PublishAsyncComplete():
+	case <-time.After(n.flushTimeout):
+		logrus.WithField("pending", n.js.PublishAsyncPending()).Error("timeout waiting for the pending publishes to nats")
+	}
+	if n.conn != nil {
+		n.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockJetStream struct {
+	nats.JetStreamContext
+	published [][]byte
+	err       error
+}
+
+func (m *mockJetStream) PublishAsync(subj string, data []byte, opts ...nats.PubOpt) (nats.PubAckFuture, error) {
+	m.published = append(m.published, data)
+	return nil, m.err
+}
+
+func (m *mockJetStream) PublishAsyncPending() int {
+	return len(m.published)
+}
+
+func (m *mockJetStream) PublishAsyncComplete() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func TestNewNatsRecorder(t *testing.T) {
+	t.Run("no panics", func(t *testing.T) {
+		defer gostub.StubFunc(&newNatsJetStream, nil, &mockJetStream{}, nil).Reset()
+		assert.NotPanics(t, func() { NewNatsRecorder() })
+	})
+}
+
+func TestNatsAsyncRecord(t *testing.T) {
+	r := models.EvalResult{
+		EvalContext: &models.EvalContext{
+			EntityID: "d08042018",
+		},
+		FlagID:         1,
+		FlagSnapshotID: 1,
+		SegmentID:      1,
+		VariantID:      1,
+		VariantKey:     "control",
+	}
+
+	t.Run("happy code path", func(t *testing.T) {
+		js := &mockJetStream{}
+		n := &natsRecorder{js: js, subject: "flagr.records", flushTimeout: time.Second}
+		n.AsyncRecord(r)
+		assert.Len(t, js.published, 1)
+		assert.Equal(t, DataRecorderStats{Queued: 1}, n.Stats())
+		assert.NoError(t, n.Close())
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		js := &mockJetStream{err: errors.New("nats is down")}
+		n := &natsRecorder{js: js, subject: "flagr.records"}
+		assert.NotPanics(t, func() { n.AsyncRecord(r) })
+		assert.Equal(t, int64(1), n.Stats().Dropped)
+	})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"sync/atomic"
 
 	"cloud.google.com/go/pubsub"
 	"github.com/checkr/flagr/pkg/config"
@@ -11,6 +12,10 @@ import (
 )
 
 type pubsubRecorder struct {
+	// queued and dropped are the stats of the data records, they're updated atomically
+	queued  int64
+	dropped int64
+
 	producer *pubsub.Client
 	topic    *pubsub.Topic
 	options  DataRecordFrameOptions
@@ -39,6 +44,7 @@ var NewPubsubRecorder = func() DataRecorder {
 		options: DataRecordFrameOptions{
 			Encrypted:       false, // not implemented yet
 			FrameOutputMode: config.Config.RecorderFrameOutputMode,
+			IncludeFlagMeta: config.Config.RecorderIncludeFlagMeta,
 		},
 	}
 }
@@ -60,13 +66,27 @@ func (p *pubsubRecorder) AsyncRecord(r models.EvalResult) {
 	ctx := context.Background()
 	res := p.topic.Publish(ctx, &pubsub.Message{Data: output})
 	if config.Config.RecorderPubsubVerbose {
+		atomic.AddInt64(&p.queued, 1)
 		go func() {
 			ctx, cancel := context.WithTimeout(ctx, config.Config.RecorderPubsubVerboseCancelTimeout)
 			defer cancel()
 			id, err := res.Get(ctx)
+			atomic.AddInt64(&p.queued, -1)
 			if err != nil {
 				logrus.WithFields(logrus.Fields{"pubsub_error": err, "id": id}).Error("error pushing to pubsub")
+				if ctx.Err() == nil {
+					atomic.AddInt64(&p.dropped, 1)
+				}
 			}
 		}()
 	}
 }
+
+// Stats reports the records only with FLAGR_RECORDER_PUBSUB_VERBOSE, as the results of the publishes
+// are not awaited otherwise. A record still waiting after the verbose cancel timeout is neither queued nor dropped.
+func (p *pubsubRecorder) Stats() DataRecorderStats {
+	return DataRecorderStats{
+		Queued:  atomic.LoadInt64(&p.queued),
+		Dropped: atomic.LoadInt64(&p.dropped),
+	}
+}
@@ -1,12 +1,17 @@
 package handler
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 
 	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/health"
 
 	"github.com/prashantv/gostub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,6 +48,42 @@ func TestGetDataRecorderWhenPubsubIsSet(t *testing.T) {
 	config.Config.RecorderType = "kafka"
 }
 
+func TestGetDataRecorderWhenFileIsSet(t *testing.T) {
+	singletonDataRecorderOnce = sync.Once{}
+	defer gostub.StubFunc(&NewFileRecorder, nil).Reset()
+	config.Config.RecorderType = "file"
+
+	assert.NotPanics(t, func() {
+		GetDataRecorder()
+	})
+
+	config.Config.RecorderType = "kafka"
+}
+
+func TestGetDataRecorderWhenHTTPIsSet(t *testing.T) {
+	singletonDataRecorderOnce = sync.Once{}
+	defer gostub.StubFunc(&NewHTTPRecorder, nil).Reset()
+	config.Config.RecorderType = "http"
+
+	assert.NotPanics(t, func() {
+		GetDataRecorder()
+	})
+
+	config.Config.RecorderType = "kafka"
+}
+
+func TestGetDataRecorderWhenNatsIsSet(t *testing.T) {
+	singletonDataRecorderOnce = sync.Once{}
+	defer gostub.StubFunc(&NewNatsRecorder, nil).Reset()
+	config.Config.RecorderType = "nats"
+
+	assert.NotPanics(t, func() {
+		GetDataRecorder()
+	})
+
+	config.Config.RecorderType = "kafka"
+}
+
 func TestGetDataRecorderPanicsWhenRecorderIsInvalid(t *testing.T) {
 	singletonDataRecorderOnce = sync.Once{}
 	config.Config.RecorderType = "invalid"
@@ -53,3 +94,64 @@ func TestGetDataRecorderPanicsWhenRecorderIsInvalid(t *testing.T) {
 
 	config.Config.RecorderType = "kafka"
 }
+
+func TestIsDataRecordSampled(t *testing.T) {
+	r := func(entityID string) *models.EvalResult {
+		return &models.EvalResult{EvalContext: &models.EvalContext{EntityID: entityID}}
+	}
+
+	t.Run("sample everything or nothing", func(t *testing.T) {
+		assert.True(t, isDataRecordSampled(r("entity1"), 1))
+		assert.False(t, isDataRecordSampled(r("entity1"), 0))
+		assert.True(t, isDataRecordSampled(&models.EvalResult{}, 1))
+	})
+
+	t.Run("it's deterministic per entity and close to the sample rate", func(t *testing.T) {
+		sampled := 0
+		for i := 0; i < 10000; i++ {
+			entityID := fmt.Sprintf("entity%d", i)
+			s := isDataRecordSampled(r(entityID), 0.1)
+			assert.Equal(t, s, isDataRecordSampled(r(entityID), 0.1))
+			if s {
+				sampled++
+			}
+		}
+		assert.InDelta(t, 1000, sampled, 200)
+	})
+}
+
+func TestDataRecorderStats(t *testing.T) {
+	singletonDataRecorderOnce = sync.Once{}
+	defer gostub.StubFunc(&NewKafkaRecorder, &kafkaRecorder{queued: 5, dropped: 2}).Reset()
+	defer gostub.Stub(&config.Config.RecorderEnabled, true).Reset()
+
+	t.Run("it exports the stats to prometheus", func(t *testing.T) {
+		queued := prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"})
+		dropped := prometheus.NewGauge(prometheus.GaugeOpts{Name: "dropped"})
+		config.Global.Prometheus.RecorderQueued = queued
+		config.Global.Prometheus.RecorderDropped = dropped
+		defer func() {
+			config.Global.Prometheus.RecorderQueued = nil
+			config.Global.Prometheus.RecorderDropped = nil
+		}()
+
+		exportDataRecorderStats(GetDataRecorder())
+		assert.Equal(t, float64(5), testutil.ToFloat64(queued))
+		assert.Equal(t, float64(2), testutil.ToFloat64(dropped))
+	})
+
+	t.Run("it warns about the backlog over the high-water mark", func(t *testing.T) {
+		assert.Empty(t, dataRecorderBacklogWarning())
+
+		defer gostub.Stub(&config.Config.RecorderQueueHighWaterMark, 5).Reset()
+		assert.Empty(t, dataRecorderBacklogWarning())
+
+		config.Config.RecorderQueueHighWaterMark = 4
+		assert.Contains(t, dataRecorderBacklogWarning(), "5 records queued over the high-water mark of 4")
+
+		ec := &EvalCache{warmedUp: 1}
+		defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+		res := getReady(health.GetReadyParams{})
+		assert.Len(t, res.(*health.GetReadyOK).Payload.Warnings, 1)
+	})
+}
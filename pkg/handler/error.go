@@ -34,3 +34,10 @@ func ErrorMessage(s string, data ...interface{}) *models.Error {
 		Message: util.StringPtr(fmt.Sprintf(s, data...)),
 	}
 }
+
+// ErrorCodeMessage generates error messages with the machine-readable code of the evaluation errors
+func ErrorCodeMessage(code string, s string, data ...interface{}) *models.Error {
+	e := ErrorMessage(s, data...)
+	e.ErrorCode = code
+	return e
+}
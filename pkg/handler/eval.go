@@ -1,15 +1,18 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"time"
 
 	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
 	"github.com/checkr/flagr/pkg/util"
 	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/evaluation"
 	"github.com/jinzhu/gorm"
 
@@ -21,10 +24,33 @@ import (
 
 // Eval is the Eval interface
 type Eval interface {
+	GetEvaluation(evaluation.GetEvaluationParams) middleware.Responder
 	PostEvaluation(evaluation.PostEvaluationParams) middleware.Responder
 	PostEvaluationBatch(evaluation.PostEvaluationBatchParams) middleware.Responder
+	PostEvaluationByTag(evaluation.PostEvaluationByTagParams) middleware.Responder
+	PostEvaluationExplain(evaluation.PostEvaluationExplainParams) middleware.Responder
+	PostEvaluationFrontendEvents(evaluation.PostEvaluationFrontendEventsParams) middleware.Responder
+	PostEvaluationCacheRefresh(evaluation.PostEvaluationCacheRefreshParams) middleware.Responder
+	EvaluateConstraint(constraint.EvaluateConstraintParams) middleware.Responder
 }
 
+// Reasons of the final evaluation decision, returned in the debug log
+const (
+	EvalReasonFlagNotFound       = "FLAG_NOT_FOUND"
+	EvalReasonFlagDisabled       = "FLAG_DISABLED"
+	EvalReasonOverridden         = "OVERRIDDEN"
+	EvalReasonPrerequisiteNotMet = "PREREQUISITE_NOT_MET"
+	EvalReasonExcludedByGroup    = "EXCLUDED_BY_GROUP"
+	EvalReasonNoSegments         = "NO_SEGMENTS"
+	EvalReasonSegmentMatched     = "SEGMENT_MATCHED"
+	EvalReasonOutOfRollout       = "OUT_OF_ROLLOUT"
+	EvalReasonNoSegmentMatched   = "NO_SEGMENT_MATCHED"
+)
+
+// maxPrerequisiteDepth caps the chain of prerequisite flags. The cycles are
+// rejected when the flags are saved, but not in the flags of the eval-only mode.
+const maxPrerequisiteDepth = 10
+
 // NewEval creates a new Eval instance
 func NewEval() Eval {
 	return &eval{}
@@ -36,50 +62,243 @@ func (e *eval) PostEvaluation(params evaluation.PostEvaluationParams) middleware
 	evalContext := params.Body
 	if evalContext == nil {
 		return evaluation.NewPostEvaluationDefault(400).WithPayload(
-			ErrorMessage("empty body"))
+			ErrorCodeMessage(models.ErrorErrorCodeINVALIDREQUEST, "empty body"))
 	}
 
-	evalResult := evalFlag(*evalContext)
+	evalResult, err := evalRequest(params.HTTPRequest, evalContext)
+	if err != nil {
+		return evaluation.NewPostEvaluationDefault(502).WithPayload(ErrorCodeMessage(models.ErrorErrorCodeENRICHMENTFAILED, "%s", err))
+	}
 	resp := evaluation.NewPostEvaluationOK()
 	resp.SetPayload(evalResult)
 	return resp
 }
 
+// GetEvaluation is PostEvaluation with the evaluation context in the query parameters, the entity context is
+// a JSON object in the entityContext parameter
+func (e *eval) GetEvaluation(params evaluation.GetEvaluationParams) middleware.Responder {
+	evalContext := &models.EvalContext{
+		EntityID:          util.SafeString(params.EntityID),
+		EntityType:        util.SafeString(params.EntityType),
+		EnableDebug:       params.EnableDebug != nil && *params.EnableDebug,
+		IncludeAllMatches: params.IncludeAllMatches != nil && *params.IncludeAllMatches,
+		FlagKey:           util.SafeString(params.FlagKey),
+		Environment:       util.SafeString(params.Environment),
+	}
+	if params.FlagID != nil {
+		evalContext.FlagID = *params.FlagID
+	}
+	if params.EntityContext != nil {
+		entityContext := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(*params.EntityContext), &entityContext); err != nil {
+			return evaluation.NewGetEvaluationDefault(400).WithPayload(
+				ErrorCodeMessage(models.ErrorErrorCodeINVALIDREQUEST, "entityContext is not a JSON object. %s", err))
+		}
+		evalContext.EntityContext = entityContext
+	}
+
+	evalResult, err := evalRequest(params.HTTPRequest, evalContext)
+	if err != nil {
+		return evaluation.NewGetEvaluationDefault(502).WithPayload(ErrorCodeMessage(models.ErrorErrorCodeENRICHMENTFAILED, "%s", err))
+	}
+	resp := evaluation.NewGetEvaluationOK()
+	resp.SetPayload(evalResult)
+	return resp
+}
+
+// evalRequest evaluates the flag of the evaluation context with the JWT claims, the enrichment and the environment
+// of the request. The error is the one of the enrichment.
+func evalRequest(r *http.Request, evalContext *models.EvalContext) (*models.EvalResult, error) {
+	evalContext.EntityContext = withJWTClaims(r, evalContext.EntityContext)
+	entityContext, err := withEnrichment(requestContext(r), evalContext.EntityID, evalContext.EntityType, evalContext.EntityContext)
+	if err != nil {
+		return nil, err
+	}
+	evalContext.EntityContext = entityContext
+	evalContext.Environment = getEnvironmentFromRequest(r, evalContext.Environment)
+
+	return evalFlag(requestContext(r), getNamespaceFromRequest(r), *evalContext), nil
+}
+
 func (e *eval) PostEvaluationBatch(params evaluation.PostEvaluationBatchParams) middleware.Responder {
-	entities := params.Body.Entities
-	flagIDs := params.Body.FlagIds
-	flagKeys := params.Body.FlagKeys
+	for _, entity := range params.Body.Entities {
+		entityContext, err := withEnrichment(requestContext(params.HTTPRequest), entity.EntityID, entity.EntityType,
+			withJWTClaims(params.HTTPRequest, entity.EntityContext))
+		if err != nil {
+			return evaluation.NewPostEvaluationBatchDefault(502).WithPayload(ErrorCodeMessage(models.ErrorErrorCodeENRICHMENTFAILED, "%s", err))
+		}
+		entity.EntityContext = entityContext
+	}
+	params.Body.Environment = getEnvironmentFromRequest(params.HTTPRequest, params.Body.Environment)
+	results := evalBatch(requestContext(params.HTTPRequest), getNamespaceFromRequest(params.HTTPRequest), *params.Body)
+	resp := evaluation.NewPostEvaluationBatchOK()
+	resp.SetPayload(results)
+	return resp
+}
+
+func (e *eval) PostEvaluationByTag(params evaluation.PostEvaluationByTagParams) middleware.Responder {
+	entity := params.Body.Entity
+	entityContext, err := withEnrichment(requestContext(params.HTTPRequest), entity.EntityID, entity.EntityType,
+		withJWTClaims(params.HTTPRequest, entity.EntityContext))
+	if err != nil {
+		return evaluation.NewPostEvaluationByTagDefault(502).WithPayload(ErrorCodeMessage(models.ErrorErrorCodeENRICHMENTFAILED, "%s", err))
+	}
+	entity.EntityContext = entityContext
+	params.Body.Environment = getEnvironmentFromRequest(params.HTTPRequest, params.Body.Environment)
+
+	results := evalByTag(requestContext(params.HTTPRequest), getNamespaceFromRequest(params.HTTPRequest), *params.Body)
+	resp := evaluation.NewPostEvaluationByTagOK()
+	resp.SetPayload(results)
+	return resp
+}
+
+func (e *eval) PostEvaluationExplain(params evaluation.PostEvaluationExplainParams) middleware.Responder {
+	evalContext := params.Body
+	if evalContext == nil {
+		return evaluation.NewPostEvaluationExplainDefault(400).WithPayload(
+			ErrorCodeMessage(models.ErrorErrorCodeINVALIDREQUEST, "empty body"))
+	}
+	if !config.Config.EvalDebugEnabled {
+		return evaluation.NewPostEvaluationExplainDefault(403).WithPayload(
+			ErrorCodeMessage(models.ErrorErrorCodeEVALDEBUGDISABLED, "evaluation debugging is disabled"))
+	}
+
+	evalContext.EntityContext = withJWTClaims(params.HTTPRequest, evalContext.EntityContext)
+	entityContext, err := withEnrichment(requestContext(params.HTTPRequest), evalContext.EntityID, evalContext.EntityType, evalContext.EntityContext)
+	if err != nil {
+		return evaluation.NewPostEvaluationExplainDefault(502).WithPayload(ErrorCodeMessage(models.ErrorErrorCodeENRICHMENTFAILED, "%s", err))
+	}
+	evalContext.EntityContext = entityContext
+	evalContext.Environment = getEnvironmentFromRequest(params.HTTPRequest, evalContext.Environment)
+
+	evalResult := explainFlag(requestContext(params.HTTPRequest), getNamespaceFromRequest(params.HTTPRequest), *evalContext)
+	resp := evaluation.NewPostEvaluationExplainOK()
+	resp.SetPayload(evalResult)
+	return resp
+}
+
+func (e *eval) PostEvaluationCacheRefresh(params evaluation.PostEvaluationCacheRefreshParams) middleware.Responder {
+	count, d, err := refreshEvalCache()
+	if err != nil {
+		return evaluation.NewPostEvaluationCacheRefreshDefault(500).WithPayload(
+			ErrorCodeMessage(models.ErrorErrorCodeEVALCACHEREFRESHFAILED, "cannot refresh the evaluation cache. %s", err))
+	}
+
+	resp := evaluation.NewPostEvaluationCacheRefreshOK()
+	resp.SetPayload(&models.EvalCacheRefreshResponse{
+		FlagsCount:     util.Int64Ptr(int64(count)),
+		DurationMillis: util.Int64Ptr(int64(d / time.Millisecond)),
+	})
+	return resp
+}
+
+func (e *eval) EvaluateConstraint(params constraint.EvaluateConstraintParams) middleware.Responder {
+	body := params.Body
+	if body == nil || body.Constraint == nil {
+		return constraint.NewEvaluateConstraintDefault(400).WithPayload(
+			ErrorCodeMessage(models.ErrorErrorCodeINVALIDREQUEST, "empty body"))
+	}
+
+	c := entity.Constraint{
+		Property: util.SafeString(body.Constraint.Property),
+		Operator: util.SafeString(body.Constraint.Operator),
+		Value:    util.SafeString(body.Constraint.Value),
+	}
+	resp := constraint.NewEvaluateConstraintOK()
+	resp.SetPayload(&models.EvaluateConstraintResponse{
+		Results: evalConstraint(c, body.EntityContexts, time.Now()),
+	})
+	return resp
+}
+
+// evalConstraint evaluates the constraint against every entityContext, the same way
+// as the constraints of the segments are evaluated, but it doesn't save anything
+var evalConstraint = func(c entity.Constraint, entityContexts []interface{}, now time.Time) []*models.ConstraintEvaluationResult {
+	results := make([]*models.ConstraintEvaluationResult, len(entityContexts))
+	expr, parseErr := c.ToExpr()
+	for i, entityContext := range entityContexts {
+		r := &models.ConstraintEvaluationResult{EntityContext: entityContext}
+		results[i] = r
+		if parseErr != nil {
+			r.Error = parseErr.Error()
+			continue
+		}
+
+		m, ok := entityContext.(map[string]interface{})
+		if !ok {
+			r.Error = fmt.Sprintf("invalid entity_context: %s", spew.Sdump(entityContext))
+			continue
+		}
+		passed, err := conditions.Evaluate(expr, constraintEntityContext(c, m, now))
+		if err != nil {
+			r.Error = err.Error()
+			continue
+		}
+		r.Passed = passed
+	}
+	return results
+}
+
+// evalBatch evaluates all the flags of the namespace for all the entities of the batch request.
+// It stops evaluating once ctx is done, e.g. the request has timed out, and the results are partial then.
+func evalBatch(ctx context.Context, namespace string, body models.EvaluationBatchRequest) *models.EvaluationBatchResponse {
+	entities := body.Entities
+	flagIDs := body.FlagIds
+	flagKeys := body.FlagKeys
 	results := &models.EvaluationBatchResponse{}
 
 	// TODO make it concurrent
 	for _, entity := range entities {
 		for _, flagID := range flagIDs {
+			if ctx.Err() != nil {
+				return results
+			}
 			evalContext := models.EvalContext{
-				EnableDebug:   params.Body.EnableDebug,
-				EntityContext: entity.EntityContext,
-				EntityID:      entity.EntityID,
-				EntityType:    entity.EntityType,
-				FlagID:        flagID,
+				EnableDebug:       body.EnableDebug,
+				IncludeAllMatches: body.IncludeAllMatches,
+				EntityContext:     entity.EntityContext,
+				EntityID:          entity.EntityID,
+				EntityType:        entity.EntityType,
+				Environment:       body.Environment,
+				FlagID:            flagID,
 			}
-			evalResult := evalFlag(evalContext)
+			evalResult := evalFlag(ctx, namespace, evalContext)
 			results.EvaluationResults = append(results.EvaluationResults, evalResult)
 		}
 		for _, flagKey := range flagKeys {
+			if ctx.Err() != nil {
+				return results
+			}
 			evalContext := models.EvalContext{
-				EnableDebug:   params.Body.EnableDebug,
-				EntityContext: entity.EntityContext,
-				EntityID:      entity.EntityID,
-				EntityType:    entity.EntityType,
-				FlagKey:       flagKey,
+				EnableDebug:       body.EnableDebug,
+				IncludeAllMatches: body.IncludeAllMatches,
+				EntityContext:     entity.EntityContext,
+				EntityID:          entity.EntityID,
+				EntityType:        entity.EntityType,
+				Environment:       body.Environment,
+				FlagKey:           flagKey,
 			}
-			evalResult := evalFlag(evalContext)
+			evalResult := evalFlag(ctx, namespace, evalContext)
 			results.EvaluationResults = append(results.EvaluationResults, evalResult)
 		}
 	}
+	return results
+}
 
-	resp := evaluation.NewPostEvaluationBatchOK()
-	resp.SetPayload(results)
-	return resp
+// evalByTag evaluates the enabled flags of the namespace carrying the tag for the entity.
+// It's the batch evaluation of these flags, and the results are empty if no flag carries the tag.
+func evalByTag(ctx context.Context, namespace string, body models.EvaluationByTagRequest) *models.EvaluationBatchResponse {
+	flagIDs := GetEvalCache().GetEnabledFlagIDsByTag(namespace, util.SafeString(body.Tag), body.Environment)
+	if len(flagIDs) == 0 {
+		return &models.EvaluationBatchResponse{EvaluationResults: []*models.EvalResult{}}
+	}
+	return evalBatch(ctx, namespace, models.EvaluationBatchRequest{
+		Entities:          []*models.EvaluationEntity{body.Entity},
+		EnableDebug:       body.EnableDebug,
+		IncludeAllMatches: body.IncludeAllMatches,
+		Environment:       body.Environment,
+		FlagIds:           flagIDs,
+	})
 }
 
 // BlankResult creates a blank result
@@ -105,67 +324,257 @@ func BlankResult(f *entity.Flag, evalContext models.EvalContext, msg string) *mo
 	}
 }
 
-var evalFlag = func(evalContext models.EvalContext) *models.EvalResult {
+// evalFlag evaluates the flag of the namespace, the flags of the other namespaces are not found
+var evalFlag = func(ctx context.Context, namespace string, evalContext models.EvalContext) *models.EvalResult {
+	return doEvalFlag(ctx, namespace, evalContext, true, 0)
+}
+
+// explainFlag evaluates the flag with debugging enabled, and it doesn't log
+// the result anywhere, so no data record is written
+var explainFlag = func(ctx context.Context, namespace string, evalContext models.EvalContext) *models.EvalResult {
+	evalContext.EnableDebug = true
+	return doEvalFlag(ctx, namespace, evalContext, false, 0)
+}
+
+// doEvalFlag evaluates the flag, ctx bounds the db queries of loading the flag into the bounded evaluation cache
+func doEvalFlag(ctx context.Context, namespace string, evalContext models.EvalContext, logResult bool, depth int) *models.EvalResult {
 	cache := GetEvalCache()
 	flagID := util.SafeUint(evalContext.FlagID)
 	flagKey := util.SafeString(evalContext.FlagKey)
-	f := cache.GetByFlagKeyOrID(flagID)
+	f := cache.GetByNamespaceFlagKeyOrID(ctx, namespace, flagID)
 	if f == nil {
-		f = cache.GetByFlagKeyOrID(flagKey)
+		f = cache.GetByNamespaceFlagKeyOrID(ctx, namespace, flagKey)
 	}
 
 	if f == nil {
 		emptyFlag := &entity.Flag{Model: gorm.Model{ID: flagID}, Key: flagKey}
-		return BlankResult(emptyFlag, evalContext, fmt.Sprintf("flagID %v not found or deleted", flagID))
+		r := BlankResult(emptyFlag, evalContext, fmt.Sprintf("flagID %v not found or deleted", flagID))
+		r.EvalDebugLog.Reason = EvalReasonFlagNotFound
+		r.ErrorCode = models.EvalResultErrorCodeFLAGNOTFOUND
+		return r
+	}
+	if logResult && flagLastEvaluated != nil {
+		flagLastEvaluated.touch(f.ID, time.Now())
 	}
+	f = f.InEnvironment(evalContext.Environment)
 
 	if !f.Enabled {
-		return BlankResult(f, evalContext, fmt.Sprintf("flagID %v is not enabled", f.ID))
+		r := BlankResult(f, evalContext, fmt.Sprintf("flagID %v is not enabled", f.ID))
+		r.EvalDebugLog.Reason = EvalReasonFlagDisabled
+		r.ErrorCode = models.EvalResultErrorCodeFLAGDISABLED
+		return r
+	}
+
+	if f.OverrideEnabled && f.OverrideVariantID != 0 {
+		return evalOverride(f, evalContext, logResult)
+	}
+
+	if len(f.Segments) == 0 && f.DefaultVariantID == 0 {
+		r := BlankResult(f, evalContext, fmt.Sprintf("flagID %v has no segments", f.ID))
+		r.EvalDebugLog.Reason = EvalReasonNoSegments
+		r.ErrorCode = models.EvalResultErrorCodeNOSEGMENTMATCH
+		return r
 	}
 
-	if len(f.Segments) == 0 {
-		return BlankResult(f, evalContext, fmt.Sprintf("flagID %v has no segments", f.ID))
+	cacheKey, cached := getCachedEvalResult(f, evalContext, logResult)
+	if cached != nil {
+		return cached
 	}
 
 	if evalContext.EntityID == "" {
 		evalContext.EntityID = fmt.Sprintf("randomly_generated_%d", rand.Int31())
 	}
 
+	// the prerequisite is evaluated with the entity type of the request, not the one of this flag
+	prerequisiteMet, prerequisiteLog := evalPrerequisite(ctx, f, evalContext, depth)
+	exclusionGroupIncluded, exclusionGroupLog := evalExclusionGroup(f, evalContext)
+
 	if f.EntityType != "" {
 		evalContext.EntityType = f.EntityType
 	}
 
+	// a single "now" for all the time-window constraints of this evaluation, it also starts its duration
+	now := time.Now().UTC()
 	logs := []*models.SegmentDebugLog{}
 	var vID int64
 	var sID int64
+	reason := EvalReasonNoSegmentMatched
+	segments := f.Segments
+	if !prerequisiteMet {
+		reason = EvalReasonPrerequisiteNotMet
+		segments = nil
+	} else if !exclusionGroupIncluded {
+		reason = EvalReasonExcludedByGroup
+		segments = nil
+	}
 
-	for _, segment := range f.Segments {
+	var matchedSegments []*models.MatchedSegment
+	invalidContext := false
+	for i, segment := range segments {
 		sID = int64(segment.ID)
-		variantID, log, evalNextSegment := evalSegment(f.ID, evalContext, segment)
+		variantID, log, evalNextSegment, contextErr := evalSegment(f, evalContext, segment, now)
 		if config.Config.EvalDebugEnabled && evalContext.EnableDebug {
 			logs = append(logs, log)
 		}
+		if contextErr != nil {
+			invalidContext = true
+		}
 		if variantID != nil {
 			vID = int64(*variantID)
 		}
 		if !evalNextSegment {
+			reason = EvalReasonOutOfRollout
+			if variantID != nil {
+				reason = EvalReasonSegmentMatched
+			}
+			if evalContext.IncludeAllMatches {
+				matchedSegments = allMatchedSegments(segments[i:], evalContext, now)
+			}
 			break
 		}
 	}
+	if logResult {
+		logEvalDurationToPrometheus(f, time.Since(now))
+	}
+
 	evalResult := BlankResult(f, evalContext, "")
 	evalResult.EvalDebugLog.SegmentDebugLogs = logs
+	evalResult.EvalDebugLog.Reason = reason
+	if config.Config.EvalDebugEnabled && evalContext.EnableDebug {
+		evalResult.EvalDebugLog.PrerequisiteDebugLog = prerequisiteLog
+		evalResult.EvalDebugLog.ExclusionGroupDebugLog = exclusionGroupLog
+	}
 	evalResult.SegmentID = sID
 	evalResult.VariantID = vID
-	v := f.FlagEvaluation.VariantsMap[util.SafeUint(vID)]
-	if v != nil {
-		evalResult.VariantAttachment = v.Attachment
-		evalResult.VariantKey = v.Key
+	if vID == 0 && f.DefaultVariantID != 0 {
+		// no segment matched, fall back to the default variant of the flag
+		evalResult.SegmentID = 0
+		evalResult.VariantID = int64(f.DefaultVariantID)
+		evalResult.IsDefaultVariant = true
+	}
+	evalResult.MatchedSegments = matchedSegments
+	if evalResult.VariantID == 0 {
+		evalResult.ErrorCode = models.EvalResultErrorCodeNOSEGMENTMATCH
+		if invalidContext {
+			evalResult.ErrorCode = models.EvalResultErrorCodeINVALIDCONTEXT
+		}
+	}
+	setEvalResultVariant(evalResult, f.FlagEvaluation.VariantsMap[util.SafeUint(evalResult.VariantID)])
+	if cacheKey != "" {
+		getEvalResultCache().set(f, cacheKey, evalResult)
+	}
+
+	if logResult {
+		logEvalResult(evalResult, f.DataRecordsEnabled)
+		logEvalVariantToPrometheus(evalResult, len(f.Variants))
+	}
+	return evalResult
+}
+
+// evalOverride returns the override variant of the flag for every entity, none of the
+// segments, prerequisites or exclusion groups is evaluated
+func evalOverride(f *entity.Flag, evalContext models.EvalContext, logResult bool) *models.EvalResult {
+	if evalContext.EntityID == "" {
+		evalContext.EntityID = fmt.Sprintf("randomly_generated_%d", rand.Int31())
+	}
+	if f.EntityType != "" {
+		evalContext.EntityType = f.EntityType
 	}
 
-	logEvalResult(evalResult, f.DataRecordsEnabled)
+	evalResult := BlankResult(f, evalContext, fmt.Sprintf("flagID %v is overridden to variantID %v", f.ID, f.OverrideVariantID))
+	evalResult.EvalDebugLog.Reason = EvalReasonOverridden
+	evalResult.VariantID = int64(f.OverrideVariantID)
+	evalResult.IsOverridden = true
+	setEvalResultVariant(evalResult, f.FlagEvaluation.VariantsMap[f.OverrideVariantID])
+
+	if logResult {
+		logEvalResult(evalResult, f.DataRecordsEnabled)
+		logEvalVariantToPrometheus(evalResult, len(f.Variants))
+	}
 	return evalResult
 }
 
+// setEvalResultVariant sets the key and the attachment of the variant, it does nothing if v is nil
+func setEvalResultVariant(evalResult *models.EvalResult, v *entity.Variant) {
+	if v == nil {
+		return
+	}
+	evalResult.VariantAttachment = v.Attachment
+	if len(v.AttachmentTypes) > 0 {
+		typed, err := v.Attachment.Typed(v.AttachmentTypes)
+		if err != nil {
+			evalResult.VariantAttachmentError = err.Error()
+		} else {
+			evalResult.TypedVariantAttachment = typed
+		}
+	}
+	evalResult.VariantKey = v.Key
+}
+
+// allMatchedSegments returns the segments whose constraints passed, starting from the segment that
+// decided the result. The rollout of the segments is not considered, only their constraints.
+func allMatchedSegments(segments []entity.Segment, evalContext models.EvalContext, now time.Time) []*models.MatchedSegment {
+	matched := []*models.MatchedSegment{}
+	for i, segment := range segments {
+		if i > 0 && !segmentConstraintsPassed(segment, evalContext, now) {
+			continue
+		}
+		matched = append(matched, &models.MatchedSegment{
+			SegmentID:   int64(segment.ID),
+			Rank:        int64(segment.Rank),
+			Description: segment.Description,
+		})
+	}
+	return matched
+}
+
+func segmentConstraintsPassed(segment entity.Segment, evalContext models.EvalContext, now time.Time) bool {
+	if len(segment.EvalConstraints()) == 0 {
+		return true
+	}
+	m, ok := evalContext.EntityContext.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	match, err := conditions.Evaluate(segment.SegmentEvaluation.ConditionsExpr, segmentEntityContext(segment, m, now))
+	return err == nil && match
+}
+
+// evalPrerequisite evaluates the prerequisite flag of f for the same entity,
+// it's always met if f has no prerequisite. The prerequisite is looked up in the namespace of f.
+func evalPrerequisite(ctx context.Context, f *entity.Flag, evalContext models.EvalContext, depth int) (bool, *models.PrerequisiteDebugLog) {
+	if f.PrerequisiteFlagID == 0 {
+		return true, nil
+	}
+
+	log := &models.PrerequisiteDebugLog{
+		FlagID:             int64(f.PrerequisiteFlagID),
+		ExpectedVariantKey: f.PrerequisiteVariantKey,
+	}
+	if depth >= maxPrerequisiteDepth {
+		log.Msg = fmt.Sprintf("the chain of prerequisite flags is longer than %d", maxPrerequisiteDepth)
+		return false, log
+	}
+
+	evalContext.FlagID = int64(f.PrerequisiteFlagID)
+	evalContext.FlagKey = ""
+	evalContext.EnableDebug = false
+	r := doEvalFlag(ctx, f.Namespace, evalContext, false, depth+1)
+	log.FlagKey = r.FlagKey
+	log.VariantKey = r.VariantKey
+
+	switch {
+	case r.VariantKey == "":
+		log.Msg = fmt.Sprintf("prerequisite flagID %v evaluated to no variant. reason: %s", f.PrerequisiteFlagID, r.EvalDebugLog.Reason)
+	case f.PrerequisiteVariantKey != "" && r.VariantKey != f.PrerequisiteVariantKey:
+		log.Msg = fmt.Sprintf("prerequisite flagID %v evaluated to variant %s instead of %s", f.PrerequisiteFlagID, r.VariantKey, f.PrerequisiteVariantKey)
+	default:
+		log.Met = true
+		log.Msg = fmt.Sprintf("prerequisite flagID %v evaluated to variant %s", f.PrerequisiteFlagID, r.VariantKey)
+	}
+	return log.Met, log
+}
+
 var logEvalResult = func(r *models.EvalResult, dataRecordsEnabled bool) {
 	if r == nil {
 		// this is just a safety check, r is from BlankResult,
@@ -183,8 +592,14 @@ var logEvalResult = func(r *models.EvalResult, dataRecordsEnabled bool) {
 	if !config.Config.RecorderEnabled || !dataRecordsEnabled {
 		return
 	}
+	if !isDataRecordSampled(r, config.Config.RecorderSampleRate) {
+		return
+	}
+	// the matched segments are only returned to the caller, the data records stay the same
+	record := *r
+	record.MatchedSegments = nil
 	rec := GetDataRecorder()
-	rec.AsyncRecord(*r)
+	rec.AsyncRecord(record)
 }
 
 var logEvalResultToDatadog = func(r *models.EvalResult) {
@@ -216,57 +631,196 @@ var logEvalResultToPrometheus = func(r *models.EvalResult) {
 
 }
 
+// logEvalVariantToPrometheus counts the result by flag key and variant key, the flags with too
+// many variants are skipped so that the cardinality of the labels stays bounded
+var logEvalVariantToPrometheus = func(r *models.EvalResult, variantsCount int) {
+	if config.Global.Prometheus.EvalVariantCounter == nil {
+		return
+	}
+	if variantsCount > config.Config.EvalMetricsMaxVariants {
+		return
+	}
+	config.Global.Prometheus.EvalVariantCounter.WithLabelValues(
+		util.SafeStringWithDefault(r.FlagKey, "null"),
+		util.SafeStringWithDefault(r.VariantKey, "null"),
+	).Inc()
+}
+
+// logEvalDurationToPrometheus observes the duration of matching the segments of the flag
+var logEvalDurationToPrometheus = func(f *entity.Flag, d time.Duration) {
+	if config.Global.Prometheus.EvalDuration == nil {
+		return
+	}
+	config.Global.Prometheus.EvalDuration.WithLabelValues(util.SafeStringWithDefault(f.Key, "null")).Observe(d.Seconds())
+}
+
 var evalSegment = func(
-	flagID uint,
+	f *entity.Flag,
 	evalContext models.EvalContext,
 	segment entity.Segment,
+	now time.Time,
 ) (
 	vID *uint, // returns VariantID
 	log *models.SegmentDebugLog,
 	evalNextSegment bool,
+	contextErr error, // the entityContext can't be evaluated against the constraints
 ) {
-	if len(segment.Constraints) != 0 {
+	if constraints := segment.EvalConstraints(); len(constraints) != 0 {
 		m, ok := evalContext.EntityContext.(map[string]interface{})
 		if !ok {
 			log = &models.SegmentDebugLog{
 				Msg:       fmt.Sprintf("constraints are present in the segment_id %v, but got invalid entity_context: %s.", segment.ID, spew.Sdump(evalContext.EntityContext)),
 				SegmentID: int64(segment.ID),
 			}
-			return nil, log, true
+			return nil, log, true, fmt.Errorf("invalid entity_context")
 		}
+		m = segmentEntityContext(segment, m, now)
 
 		expr := segment.SegmentEvaluation.ConditionsExpr
 		match, err := conditions.Evaluate(expr, m)
 		if err != nil {
 			log = &models.SegmentDebugLog{
-				Msg:       err.Error(),
-				SegmentID: int64(segment.ID),
+				Msg:                 err.Error(),
+				SegmentID:           int64(segment.ID),
+				ConstraintDebugLogs: debugConstraintLogs(evalContext.EnableDebug, constraints, m),
 			}
-			return nil, log, true
+			return nil, log, true, err
 		}
 		if !match {
 			log = &models.SegmentDebugLog{
-				Msg:       debugConstraintMsg(evalContext.EnableDebug, expr, m),
-				SegmentID: int64(segment.ID),
+				Msg:                 debugConstraintMsg(evalContext.EnableDebug, expr, m),
+				SegmentID:           int64(segment.ID),
+				ConstraintDebugLogs: debugConstraintLogs(evalContext.EnableDebug, constraints, m),
 			}
-			return nil, log, true
+			return nil, log, true, nil
 		}
 	}
 
-	vID, debugMsg := segment.SegmentEvaluation.DistributionArray.Rollout(
-		evalContext.EntityID,
-		fmt.Sprint(flagID), // default use the flagID as salt
+	entityID := bucketingEntityID(f, evalContext)
+	vID, debugMsg := segment.SegmentEvaluation.DistributionArrayAt(now).Rollout(
+		f.BucketingHasher(),
+		entityID,
+		f.BucketingSalt(),
 		segment.RolloutPercent,
 	)
 
 	log = &models.SegmentDebugLog{
 		Msg:       "matched all constraints. " + debugMsg,
 		SegmentID: int64(segment.ID),
+		Matched:   true,
+	}
+	if evalContext.EnableDebug {
+		log.BucketNum = int64(entity.BucketNum(f.BucketingHasher(), entityID, f.BucketingSalt()))
+		m, _ := evalContext.EntityContext.(map[string]interface{})
+		m = segmentEntityContext(segment, m, now)
+		log.ConstraintDebugLogs = debugConstraintLogs(true, segment.EvalConstraints(), m)
 	}
 
 	// at this point, all constraints are matched, so we shouldn't go to next segment
 	// thus setting evalNextSegment = false
-	return vID, log, false
+	return vID, log, false, nil
+}
+
+// segmentEntityContext returns the entityContext with the values the constraints of the segment need at the evaluation.
+// The entityContext is copied once with all of them, so that we don't leak the reserved properties into the
+// entityContext of the result, and it's returned as it is if the segment needs none.
+func segmentEntityContext(segment entity.Segment, m map[string]interface{}, now time.Time) map[string]interface{} {
+	se := segment.SegmentEvaluation
+	extra := len(se.NestedProperties) + len(se.ExistenceProperties) + len(se.NumberSets)
+	if !se.EvalTimeRequired && extra == 0 {
+		return m
+	}
+
+	ret := copyEntityContext(m, extra+1)
+	if se.EvalTimeRequired {
+		ret[entity.EvalTimeProperty] = entity.EvalTimeValue(now)
+	}
+	addNestedProperties(ret, m, se.NestedProperties)
+	addPropertyExistence(ret, m, se.ExistenceProperties)
+	addNumberSetMembership(ret, m, se.NumberSets)
+	return ret
+}
+
+// constraintEntityContext is segmentEntityContext for a single constraint
+func constraintEntityContext(c entity.Constraint, m map[string]interface{}, now time.Time) map[string]interface{} {
+	set := c.NumberSet()
+	if !c.IsTimeWindow() && !c.IsNestedProperty() && !c.IsExistence() && set == nil {
+		return m
+	}
+
+	ret := copyEntityContext(m, 1)
+	if c.IsTimeWindow() {
+		ret[entity.EvalTimeProperty] = entity.EvalTimeValue(now)
+	}
+	if c.IsNestedProperty() {
+		addNestedProperties(ret, m, []string{c.Property})
+	}
+	if c.IsExistence() {
+		addPropertyExistence(ret, m, []string{c.Property})
+	}
+	if set != nil {
+		addNumberSetMembership(ret, m, []*entity.NumberSet{set})
+	}
+	return ret
+}
+
+func copyEntityContext(m map[string]interface{}, extra int) map[string]interface{} {
+	ret := make(map[string]interface{}, len(m)+extra)
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+// addNestedProperties adds the values of the property paths of m to ret.
+// The missing paths are left out, so that their constraints fail like the missing properties.
+func addNestedProperties(ret map[string]interface{}, m map[string]interface{}, paths []string) {
+	for _, p := range paths {
+		if _, ok := m[p]; ok {
+			continue
+		}
+		if v, ok := entity.ResolvePropertyPath(m, p); ok {
+			ret[p] = v
+		}
+	}
+}
+
+// addPropertyExistence adds whether the properties exist in m to ret,
+// see entity.PropertyExists, so that the existence constraints can compare them
+func addPropertyExistence(ret map[string]interface{}, m map[string]interface{}, props []string) {
+	for _, p := range props {
+		ret[entity.ExistenceProperty(p)] = entity.PropertyExists(m, p)
+	}
+}
+
+// addNumberSetMembership adds whether the properties of m are in the number sets of the IN and NOT_IN constraints
+// to ret. The properties missing or not numbers are left out, so both IN and NOT_IN fail on them like the other operators.
+func addNumberSetMembership(ret map[string]interface{}, m map[string]interface{}, sets []*entity.NumberSet) {
+	for _, s := range sets {
+		v, ok := m[s.Property]
+		if !ok {
+			v, ok = entity.ResolvePropertyPath(m, s.Property)
+		}
+		if !ok {
+			continue
+		}
+		if in, ok := s.Lookup(v); ok {
+			ret[s.Key] = in
+		}
+	}
+}
+
+// bucketingEntityID returns the value we hash to bucket the entity. It's the
+// flag's BucketBy attribute from the entityContext if present, otherwise the entityID.
+func bucketingEntityID(f *entity.Flag, evalContext models.EvalContext) string {
+	if f.BucketBy == "" {
+		return evalContext.EntityID
+	}
+	m, ok := evalContext.EntityContext.(map[string]interface{})
+	if !ok {
+		return evalContext.EntityID
+	}
+	return util.SafeStringWithDefault(m[f.BucketBy], evalContext.EntityID)
 }
 
 func debugConstraintMsg(enableDebug bool, expr conditions.Expr, m map[string]interface{}) string {
@@ -276,6 +830,33 @@ func debugConstraintMsg(enableDebug bool, expr conditions.Expr, m map[string]int
 	return fmt.Sprintf("constraint not match. constraint: %s, entity_context: %+v.", expr, m)
 }
 
+// debugConstraintLogs evaluates the constraints one by one to tell which of them passed or failed
+func debugConstraintLogs(enableDebug bool, cs entity.ConstraintArray, entityContext interface{}) []*models.ConstraintDebugLog {
+	if !enableDebug || len(cs) == 0 {
+		return nil
+	}
+
+	m, _ := entityContext.(map[string]interface{})
+	logs := make([]*models.ConstraintDebugLog, 0, len(cs))
+	for _, c := range cs {
+		log := &models.ConstraintDebugLog{
+			ConstraintID: int64(c.ID),
+			Property:     c.Property,
+			Operator:     c.Operator,
+			Value:        c.Value,
+		}
+		expr, err := c.ToExpr()
+		if err == nil {
+			log.Matched, err = conditions.Evaluate(expr, m)
+		}
+		if err != nil {
+			log.Msg = err.Error()
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
 var rateLimitMap = make(map[uint]*ratelimit.RateLimiter)
 
 var rateLimitPerFlagConsoleLogging = func(r *models.EvalResult) {
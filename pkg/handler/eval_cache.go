@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"context"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/checkr/flagr/pkg/config"
@@ -19,14 +22,34 @@ var (
 
 type mapCache map[string]*entity.Flag
 
+// namespaceCache is the partition of the id and key caches for the flags of one namespace
+type namespaceCache struct {
+	idCache  mapCache
+	keyCache mapCache
+}
+
 // EvalCache is the in-memory cache just for evaluation
 type EvalCache struct {
-	mapCacheLock sync.RWMutex
-	idCache      mapCache
-	keyCache     mapCache
+	mapCacheLock    sync.RWMutex
+	idCache         mapCache
+	keyCache        mapCache
+	namespaceCaches map[string]*namespaceCache
+	exclusionGroups map[uint]entity.ExclusionGroupArray
+	tagCache        map[string][]*entity.Flag
+
+	// flags holds the prepared flags if the cache is bounded by EvalCacheMaxFlags,
+	// then the caches above only index the flags without their segments and variants
+	flags *flagLRU
+
+	// reloadLock serializes the reloads of all the flags and of the single flags, from the fetch to the swap of
+	// the caches, otherwise a reload could swap in the caches fetched before the ones it replaces
+	reloadLock sync.Mutex
 
 	refreshTimeout  time.Duration
 	refreshInterval time.Duration
+
+	// warmedUp is set to 1 once all the flags are loaded for the first time
+	warmedUp int32
 }
 
 // GetEvalCache gets the EvalCache
@@ -38,17 +61,22 @@ var GetEvalCache = func() *EvalCache {
 			refreshTimeout:  config.Config.EvalCacheRefreshTimeout,
 			refreshInterval: config.Config.EvalCacheRefreshInterval,
 		}
+		if config.Config.EvalCacheMaxFlags > 0 {
+			if config.Config.EvalOnlyMode {
+				logrus.Warn("FLAGR_EVALCACHE_MAXFLAGS is ignored in the eval only mode")
+			} else {
+				ec.flags = newFlagLRU(config.Config.EvalCacheMaxFlags)
+			}
+		}
 		singletonEvalCache = ec
 	})
 	return singletonEvalCache
 }
 
-// Start starts the polling of EvalCache
+// Start warms up EvalCache and then starts its polling, it blocks
+// until all the flags are loaded so that the first evaluations hit the cache
 func (ec *EvalCache) Start() {
-	err := ec.reloadMapCache()
-	if err != nil {
-		panic(err)
-	}
+	ec.warmUp()
 	go func() {
 		for range time.Tick(ec.refreshInterval) {
 			err := ec.reloadMapCache()
@@ -59,17 +87,205 @@ func (ec *EvalCache) Start() {
 	}()
 }
 
-// GetByFlagKeyOrID gets the flag by Key or ID
-func (ec *EvalCache) GetByFlagKeyOrID(keyOrID interface{}) *entity.Flag {
+func (ec *EvalCache) warmUp() {
+	start := time.Now()
+	if err := ec.reloadMapCache(); err != nil {
+		panic(err)
+	}
+	duration := time.Since(start)
+
 	ec.mapCacheLock.RLock()
-	defer ec.mapCacheLock.RUnlock()
+	flags := len(ec.idCache)
+	ec.mapCacheLock.RUnlock()
+	atomic.StoreInt32(&ec.warmedUp, 1)
+
+	logrus.WithFields(logrus.Fields{
+		"flags":    flags,
+		"duration": duration.String(),
+	}).Info("warmed up the evaluation cache")
 
+	if config.Global.Prometheus.EvalCacheFlags != nil {
+		config.Global.Prometheus.EvalCacheFlags.Set(float64(flags))
+		config.Global.Prometheus.EvalCacheWarmUp.Set(duration.Seconds())
+	}
+	if config.Global.StatsdClient != nil {
+		config.Global.StatsdClient.Gauge("eval_cache.flags", float64(flags), nil, float64(1))
+		config.Global.StatsdClient.Timing("eval_cache.warm_up", duration, nil, float64(1))
+	}
+}
+
+// IsWarmedUp tells if all the flags have been loaded into EvalCache
+func (ec *EvalCache) IsWarmedUp() bool {
+	return atomic.LoadInt32(&ec.warmedUp) == 1
+}
+
+// GetByFlagKeyOrID gets the flag by Key or ID, ctx bounds loading it from DB if it's been evicted
+func (ec *EvalCache) GetByFlagKeyOrID(ctx context.Context, keyOrID interface{}) *entity.Flag {
+	ec.mapCacheLock.RLock()
 	s := util.SafeString(keyOrID)
 	f, ok := ec.idCache[s]
 	if !ok {
 		f = ec.keyCache[s]
 	}
-	return f
+	ec.mapCacheLock.RUnlock()
+
+	return ec.load(ctx, f)
+}
+
+// GetByNamespaceFlagKeyOrID gets the flag by Key or ID in the namespace.
+// All the flags are in the same namespace if the namespaces are not enabled.
+func (ec *EvalCache) GetByNamespaceFlagKeyOrID(ctx context.Context, namespace string, keyOrID interface{}) *entity.Flag {
+	if !namespacesEnabled() {
+		return ec.GetByFlagKeyOrID(ctx, keyOrID)
+	}
+
+	ec.mapCacheLock.RLock()
+	nc, ok := ec.namespaceCaches[namespace]
+	if !ok {
+		ec.mapCacheLock.RUnlock()
+		return nil
+	}
+	s := util.SafeString(keyOrID)
+	f, ok := nc.idCache[s]
+	if !ok {
+		f = nc.keyCache[s]
+	}
+	ec.mapCacheLock.RUnlock()
+
+	return ec.load(ctx, f)
+}
+
+// load swaps the indexed flag for its prepared one if the cache is bounded,
+// the flag is loaded from DB if it's been evicted
+func (ec *EvalCache) load(ctx context.Context, f *entity.Flag) *entity.Flag {
+	if f == nil || ec.flags == nil {
+		return f
+	}
+
+	if prepared := ec.flags.get(f.ID); prepared != nil {
+		if config.Global.Prometheus.EvalCacheHits != nil {
+			config.Global.Prometheus.EvalCacheHits.Inc()
+		}
+		return prepared
+	}
+	if config.Global.Prometheus.EvalCacheMisses != nil {
+		config.Global.Prometheus.EvalCacheMisses.Inc()
+	}
+
+	fs, err := fetchFlagsByIDs(ctx, []uint{f.ID})
+	if err == nil && len(fs) == 0 {
+		return nil
+	}
+	if err == nil {
+		err = fs[0].PrepareEvaluation()
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "flagID": f.ID}).Error("failed to load the flag into the evaluation cache")
+		return nil
+	}
+	ec.flags.add(&fs[0])
+	return &fs[0]
+}
+
+// newNamespaceCaches partitions the flags of the id cache by their namespaces
+func newNamespaceCaches(idCache mapCache) map[string]*namespaceCache {
+	ncs := make(map[string]*namespaceCache)
+	for id, f := range idCache {
+		nc, ok := ncs[f.Namespace]
+		if !ok {
+			nc = &namespaceCache{idCache: make(mapCache), keyCache: make(mapCache)}
+			ncs[f.Namespace] = nc
+		}
+		nc.idCache[id] = f
+		if f.Key != "" {
+			nc.keyCache[f.Key] = f
+		}
+	}
+	return ncs
+}
+
+// GetExclusionGroupArray gets the split of the exclusion group's buckets across its member flags
+func (ec *EvalCache) GetExclusionGroupArray(groupID uint) entity.ExclusionGroupArray {
+	ec.mapCacheLock.RLock()
+	defer ec.mapCacheLock.RUnlock()
+
+	return ec.exclusionGroups[groupID]
+}
+
+// newExclusionGroupArrays groups the flags of the id cache by their exclusion groups
+func newExclusionGroupArrays(idCache mapCache) map[uint]entity.ExclusionGroupArray {
+	members := make(map[uint][]*entity.Flag)
+	for _, f := range idCache {
+		if f.ExclusionGroupID != 0 {
+			members[f.ExclusionGroupID] = append(members[f.ExclusionGroupID], f)
+		}
+	}
+	arrays := make(map[uint]entity.ExclusionGroupArray, len(members))
+	for groupID, fs := range members {
+		arrays[groupID] = entity.NewExclusionGroupArray(fs)
+	}
+	return arrays
+}
+
+// GetEnabledFlagIDsByTag gets the IDs of the flags carrying the tag in the namespace that are enabled in the environment,
+// ordered by ID
+func (ec *EvalCache) GetEnabledFlagIDsByTag(namespace string, tag string, environment string) []int64 {
+	ec.mapCacheLock.RLock()
+	defer ec.mapCacheLock.RUnlock()
+
+	flagIDs := []int64{}
+	for _, f := range ec.tagCache[tag] {
+		if !f.InEnvironment(environment).Enabled || (namespacesEnabled() && f.Namespace != namespace) {
+			continue
+		}
+		flagIDs = append(flagIDs, int64(f.ID))
+	}
+	return flagIDs
+}
+
+// getFlagMeta gets the tags and the description of the flag for the data records, it's nil if the flag is not in
+// the cache, as it never loads the flag from DB
+func (ec *EvalCache) getFlagMeta(flagID int64) *dataRecordFlagMeta {
+	ec.mapCacheLock.RLock()
+	defer ec.mapCacheLock.RUnlock()
+
+	f, ok := ec.idCache[util.SafeString(flagID)]
+	if !ok {
+		return nil
+	}
+	tags := make([]string, 0, len(f.Tags))
+	for _, t := range f.Tags {
+		tags = append(tags, t.Value)
+	}
+	return &dataRecordFlagMeta{FlagTags: tags, FlagDescription: f.Description}
+}
+
+// newTagCache indexes the flags of the id cache by the values of their tags
+func newTagCache(idCache mapCache) map[string][]*entity.Flag {
+	tc := make(map[string][]*entity.Flag)
+	for _, f := range idCache {
+		for _, t := range f.Tags {
+			tc[t.Value] = append(tc[t.Value], f)
+		}
+	}
+	for _, fs := range tc {
+		sort.Slice(fs, func(i, j int) bool { return fs[i].ID < fs[j].ID })
+	}
+	return tc
+}
+
+// refreshEvalCache reloads the evaluation cache immediately, and returns the number of flags loaded
+var refreshEvalCache = func() (int, time.Duration, error) {
+	ec := GetEvalCache()
+	start := time.Now()
+	if err := ec.reloadMapCache(); err != nil {
+		return 0, time.Since(start), err
+	}
+	d := time.Since(start)
+
+	ec.mapCacheLock.RLock()
+	defer ec.mapCacheLock.RUnlock()
+	return len(ec.idCache), d, nil
 }
 
 func (ec *EvalCache) reloadMapCache() error {
@@ -78,18 +294,110 @@ func (ec *EvalCache) reloadMapCache() error {
 	}
 
 	_, _, err := withtimeout.Do(ec.refreshTimeout, func() (interface{}, error) {
+		ec.reloadLock.Lock()
+		defer ec.reloadLock.Unlock()
+
 		idCache, keyCache, err := ec.fetchAllFlags()
 		if err != nil {
 			return nil, err
 		}
-
-		ec.mapCacheLock.Lock()
-		defer ec.mapCacheLock.Unlock()
-
-		ec.idCache = idCache
-		ec.keyCache = keyCache
+		if ec.flags != nil {
+			if err := ec.reloadFlags(); err != nil {
+				return nil, err
+			}
+		}
+		ec.setMapCache(idCache, keyCache)
 		return nil, err
 	})
 
 	return err
 }
+
+// setMapCache swaps the id and key caches, and rebuilds the other caches from the id cache
+func (ec *EvalCache) setMapCache(idCache mapCache, keyCache mapCache) {
+	namespaceCaches := newNamespaceCaches(idCache)
+	exclusionGroups := newExclusionGroupArrays(idCache)
+	tagCache := newTagCache(idCache)
+
+	ec.mapCacheLock.Lock()
+	defer ec.mapCacheLock.Unlock()
+
+	ec.idCache = idCache
+	ec.keyCache = keyCache
+	ec.namespaceCaches = namespaceCaches
+	ec.exclusionGroups = exclusionGroups
+	ec.tagCache = tagCache
+}
+
+// reloadFlag reloads a single flag right away, e.g. when it's changed by another replica. The flags
+// can't be fetched one by one from the json drivers, so all of them are reloaded in the eval only mode.
+func (ec *EvalCache) reloadFlag(flagID uint) error {
+	if config.Config.EvalOnlyMode {
+		return ec.reloadMapCache()
+	}
+
+	ec.reloadLock.Lock()
+	defer ec.reloadLock.Unlock()
+
+	fs, err := fetchFlagsByIDs(context.Background(), []uint{flagID})
+	if err != nil {
+		return err
+	}
+	var f *entity.Flag
+	if len(fs) > 0 {
+		f = &fs[0]
+		if err := f.PrepareEvaluation(); err != nil {
+			return err
+		}
+	}
+
+	ec.mapCacheLock.RLock()
+	idCache := make(mapCache, len(ec.idCache)+1)
+	for id, cached := range ec.idCache {
+		idCache[id] = cached
+	}
+	ec.mapCacheLock.RUnlock()
+
+	delete(idCache, util.SafeString(flagID))
+	switch {
+	case f == nil && ec.flags != nil:
+		ec.flags.remove(flagID)
+	case f != nil && ec.flags != nil:
+		ec.flags.update(f)
+		indexed := *f
+		indexed.Segments, indexed.Variants, indexed.FlagEvaluation = nil, nil, entity.FlagEvaluation{}
+		idCache[util.SafeString(flagID)] = &indexed
+	case f != nil:
+		idCache[util.SafeString(flagID)] = f
+	}
+	ec.setMapCache(idCache, newKeyCache(idCache))
+	return nil
+}
+
+// newKeyCache indexes the flags of the id cache by their keys
+func newKeyCache(idCache mapCache) mapCache {
+	kc := make(mapCache, len(idCache))
+	for _, f := range idCache {
+		if f.Key != "" {
+			kc[f.Key] = f
+		}
+	}
+	return kc
+}
+
+// reloadFlags reloads the flags held by the bounded cache, the deleted flags are removed
+func (ec *EvalCache) reloadFlags() error {
+	fs, err := fetchFlagsByIDs(context.Background(), ec.flags.ids())
+	if err != nil {
+		return err
+	}
+	prepared := make(map[uint]*entity.Flag, len(fs))
+	for i := range fs {
+		if err := fs[i].PrepareEvaluation(); err != nil {
+			return err
+		}
+		prepared[fs[i].ID] = &fs[i]
+	}
+	ec.flags.replace(prepared)
+	return nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -17,21 +18,33 @@ type EvalCacheJSON struct {
 	Flags []entity.Flag
 }
 
-func (ec *EvalCache) export() EvalCacheJSON {
-	fs := make([]entity.Flag, 0, len(ec.idCache))
+// export dumps the flags of the cache, they're all fetched if the cache is bounded
+// because the cache only holds the segments and variants of some of them
+func (ec *EvalCache) export() (EvalCacheJSON, error) {
+	if ec.flags != nil {
+		fs, err := fetchAllFlags()
+		return EvalCacheJSON{Flags: fs}, err
+	}
 
 	ec.mapCacheLock.RLock()
 	defer ec.mapCacheLock.RUnlock()
 
+	fs := make([]entity.Flag, 0, len(ec.idCache))
 	for _, f := range ec.idCache {
 		ff := *f
 		fs = append(fs, ff)
 	}
-	return EvalCacheJSON{Flags: fs}
+	return EvalCacheJSON{Flags: fs}, nil
 }
 
+// fetchAllFlags fetches the flags into the id and key caches, if the cache is bounded
+// they're indexed without their segments and variants, which are loaded on demand
 func (ec *EvalCache) fetchAllFlags() (idCache mapCache, keyCache mapCache, err error) {
-	fs, err := fetchAllFlags()
+	fetch := fetchAllFlags
+	if ec.flags != nil {
+		fetch = fetchFlagIndex
+	}
+	fs, err := fetch()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -41,8 +54,10 @@ func (ec *EvalCache) fetchAllFlags() (idCache mapCache, keyCache mapCache, err e
 
 	for i := range fs {
 		f := &fs[i]
-		if err := f.PrepareEvaluation(); err != nil {
-			return nil, nil, err
+		if ec.flags == nil {
+			if err := f.PrepareEvaluation(); err != nil {
+				return nil, nil, err
+			}
 		}
 
 		if f.ID != 0 {
@@ -85,6 +100,29 @@ var fetchAllFlags = func() ([]entity.Flag, error) {
 	return fetcher.fetch()
 }
 
+// fetchFlagIndex fetches the flags with their tags and environments, but without their segments and variants
+var fetchFlagIndex = func() ([]entity.Flag, error) {
+	fs := []entity.Flag{}
+	err := getDB().Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("value ASC")
+	}).Preload("Environments").Find(&fs).Error
+	return fs, err
+}
+
+// fetchFlagsByIDs fetches the flags with their segments and variants, the queries stop once ctx is done
+var fetchFlagsByIDs = func(ctx context.Context, ids []uint) ([]entity.Flag, error) {
+	fs := []entity.Flag{}
+	if len(ids) == 0 {
+		return fs, nil
+	}
+	db := entity.WithContext(ctx, getDB())
+	if err := entity.PreloadSegmentsVariants(db).Where("id IN (?)", ids).Find(&fs).Error; err != nil {
+		return fs, err
+	}
+	err := entity.AttachConstraintGroups(db, fs)
+	return fs, err
+}
+
 type jsonFileFetcher struct {
 	filePath string
 }
@@ -135,6 +173,10 @@ func (df *dbFetcher) fetch() ([]entity.Flag, error) {
 	// Use eager loading to avoid N+1 problem
 	// doc: http://jinzhu.me/gorm/crud.html#preloading-eager-loading
 	fs := []entity.Flag{}
-	err := entity.PreloadSegmentsVariants(df.db).Find(&fs).Error
+	if err := entity.PreloadSegmentsVariants(df.db).Find(&fs).Error; err != nil {
+		return fs, err
+	}
+	// the constraint groups are shared by the segments, they're loaded once instead of per segment
+	err := entity.AttachConstraintGroups(df.db, fs)
 	return fs, err
 }
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/sirupsen/logrus"
+)
+
+var saramaNewConsumer = sarama.NewConsumer
+
+// evalCacheInvalidationMessage is the value of the eval cache invalidation kafka messages
+type evalCacheInvalidationMessage struct {
+	FlagID    uint   `json:"flagID"`
+	ReplicaID string `json:"replicaID"`
+}
+
+// evalCacheInvalidation publishes the IDs of the changed flags to FLAGR_EVALCACHE_INVALIDATION_KAFKA_TOPIC,
+// and reloads the flags published by the other replicas. Every replica consumes all the partitions from
+// the newest offset without a consumer group, because every replica needs every message.
+type evalCacheInvalidation struct {
+	ec        *EvalCache
+	topic     string
+	replicaID string
+
+	producer   sarama.AsyncProducer
+	consumer   sarama.Consumer
+	partitions []sarama.PartitionConsumer
+	wg         sync.WaitGroup
+}
+
+// newEvalCacheInvalidation starts consuming the invalidations of the other replicas,
+// the producer is only needed to publish the flag changes when it's not in the eval only mode
+var newEvalCacheInvalidation = func(ec *EvalCache) *evalCacheInvalidation {
+	inv := &evalCacheInvalidation{
+		ec:        ec,
+		topic:     config.Config.EvalCacheInvalidationKafkaTopic,
+		replicaID: newReplicaID(),
+	}
+
+	cfg := newKafkaConfig()
+	cfg.Consumer.Return.Errors = true
+	if !config.Config.EvalOnlyMode {
+		inv.producer = newKafkaProducer(cfg, "failed to produce the eval cache invalidation", nil)
+	}
+
+	brokerList := strings.Split(config.Config.RecorderKafkaBrokers, ",")
+	consumer, err := saramaNewConsumer(brokerList, cfg)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"kafka_error": err,
+			"brokers":     brokerList,
+		}).Fatal("Failed to start Sarama consumer, please check the connection to the kafka brokers")
+	}
+	inv.consumer = consumer
+
+	partitions, err := consumer.Partitions(inv.topic)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"kafka_error": err,
+			"topic":       inv.topic,
+		}).Fatal("Failed to get the partitions of the eval cache invalidation topic")
+	}
+	for _, p := range partitions {
+		pc, err := consumer.ConsumePartition(inv.topic, p, sarama.OffsetNewest)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{
+				"kafka_error": err,
+				"topic":       inv.topic,
+				"partition":   p,
+			}).Fatal("Failed to consume the eval cache invalidation topic")
+		}
+		inv.partitions = append(inv.partitions, pc)
+		inv.wg.Add(2)
+		go inv.consume(pc)
+		go inv.logErrors(pc)
+	}
+	return inv
+}
+
+// newReplicaID identifies the replica in the invalidations it publishes, so that it can skip its own ones
+func newReplicaID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Notify reloads the changed flag in the local cache, and publishes it to the other replicas. It never blocks.
+func (inv *evalCacheInvalidation) Notify(fh *entity.FlagHistory) {
+	inv.reload(fh.FlagID)
+
+	body, err := json.Marshal(evalCacheInvalidationMessage{FlagID: fh.FlagID, ReplicaID: inv.replicaID})
+	if err != nil {
+		inv.drop(err.Error())
+		return
+	}
+
+	select {
+	case inv.producer.Input() <- &sarama.ProducerMessage{
+		Topic: inv.topic,
+		Key:   sarama.StringEncoder(strconv.FormatUint(uint64(fh.FlagID), 10)),
+		Value: sarama.ByteEncoder(body),
+	}:
+	default:
+		inv.drop("producer buffer is full")
+	}
+}
+
+func (inv *evalCacheInvalidation) consume(pc sarama.PartitionConsumer) {
+	defer inv.wg.Done()
+	for msg := range pc.Messages() {
+		inv.handle(msg)
+	}
+}
+
+func (inv *evalCacheInvalidation) logErrors(pc sarama.PartitionConsumer) {
+	defer inv.wg.Done()
+	for err := range pc.Errors() {
+		logrus.WithField("kafka_error", err).Error("failed to consume the eval cache invalidation")
+	}
+}
+
+// handle reloads the flag of the message, unless the replica published it itself,
+// in which case the flag has been reloaded already
+func (inv *evalCacheInvalidation) handle(msg *sarama.ConsumerMessage) {
+	m := evalCacheInvalidationMessage{}
+	if err := json.Unmarshal(msg.Value, &m); err != nil {
+		logrus.WithField("err", err).Error("failed to unmarshal the eval cache invalidation")
+		return
+	}
+	if m.ReplicaID == inv.replicaID {
+		return
+	}
+	inv.reload(m.FlagID)
+}
+
+func (inv *evalCacheInvalidation) reload(flagID uint) {
+	if err := inv.ec.reloadFlag(flagID); err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "flagID": flagID}).Error("failed to reload the flag of the eval cache invalidation")
+	}
+}
+
+// Close stops consuming and flushes the buffered invalidations
+func (inv *evalCacheInvalidation) Close() error {
+	for _, pc := range inv.partitions {
+		pc.AsyncClose()
+	}
+	inv.wg.Wait()
+	if err := inv.consumer.Close(); err != nil {
+		return err
+	}
+	if inv.producer != nil {
+		return inv.producer.Close()
+	}
+	return nil
+}
+
+func (inv *evalCacheInvalidation) drop(reason string) {
+	logrus.WithField("kafka_error", reason).Error("dropping the eval cache invalidation")
+
+	if config.Global.StatsdClient != nil {
+		config.Global.StatsdClient.Incr("eval_cache_invalidation.dropped", nil, float64(1))
+	}
+}
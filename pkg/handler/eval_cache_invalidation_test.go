@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockConsumer struct {
+	partitionConsumer *mockPartitionConsumer
+}
+
+func (m *mockConsumer) Topics() ([]string, error)                  { return nil, nil }
+func (m *mockConsumer) Partitions(string) ([]int32, error)         { return []int32{0}, nil }
+func (m *mockConsumer) HighWaterMarks() map[string]map[int32]int64 { return nil }
+func (m *mockConsumer) Close() error                               { return nil }
+func (m *mockConsumer) ConsumePartition(string, int32, int64) (sarama.PartitionConsumer, error) {
+	return m.partitionConsumer, nil
+}
+
+type mockPartitionConsumer struct {
+	messagesCh chan *sarama.ConsumerMessage
+	errorsCh   chan *sarama.ConsumerError
+}
+
+func (m *mockPartitionConsumer) AsyncClose() {
+	close(m.messagesCh)
+	close(m.errorsCh)
+}
+func (m *mockPartitionConsumer) Close() error                             { m.AsyncClose(); return nil }
+func (m *mockPartitionConsumer) Messages() <-chan *sarama.ConsumerMessage { return m.messagesCh }
+func (m *mockPartitionConsumer) Errors() <-chan *sarama.ConsumerError     { return m.errorsCh }
+func (m *mockPartitionConsumer) HighWaterMarkOffset() int64               { return 0 }
+
+func TestEvalCacheInvalidation(t *testing.T) {
+	fixtureFlag := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(fixtureFlag)
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.EvalCacheInvalidationKafkaTopic, "eval-cache-invalidation").Reset()
+
+	ec := &EvalCache{refreshTimeout: config.Config.EvalCacheRefreshTimeout}
+	assert.NoError(t, ec.reloadMapCache())
+
+	p := &mockAsyncProducer{inputCh: make(chan *sarama.ProducerMessage, 10)}
+	pc := &mockPartitionConsumer{
+		messagesCh: make(chan *sarama.ConsumerMessage, 10),
+		errorsCh:   make(chan *sarama.ConsumerError),
+	}
+	defer gostub.StubFunc(&saramaNewAsyncProducer, p, nil).Reset()
+	defer gostub.StubFunc(&saramaNewConsumer, &mockConsumer{partitionConsumer: pc}, nil).Reset()
+	inv := newEvalCacheInvalidation(ec)
+
+	message := func(flagID uint, replicaID string) *sarama.ConsumerMessage {
+		b, _ := json.Marshal(evalCacheInvalidationMessage{FlagID: flagID, ReplicaID: replicaID})
+		return &sarama.ConsumerMessage{Value: b}
+	}
+
+	t.Run("it reloads the changed flag locally and publishes it", func(t *testing.T) {
+		db.Model(&entity.Flag{}).Where("id = ?", fixtureFlag.ID).Update("key", "flag_key_renamed")
+		inv.Notify(&entity.FlagHistory{FlagID: fixtureFlag.ID})
+
+		assert.Nil(t, ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.Key))
+		assert.NotNil(t, ec.GetByFlagKeyOrID(context.Background(), "flag_key_renamed"))
+
+		assert.Len(t, p.inputCh, 1)
+		msg := <-p.inputCh
+		assert.Equal(t, "eval-cache-invalidation", msg.Topic)
+		assert.Equal(t, sarama.StringEncoder("100"), msg.Key)
+		value, _ := msg.Value.Encode()
+		m := evalCacheInvalidationMessage{}
+		assert.NoError(t, json.Unmarshal(value, &m))
+		assert.Equal(t, evalCacheInvalidationMessage{FlagID: fixtureFlag.ID, ReplicaID: inv.replicaID}, m)
+	})
+
+	t.Run("it ignores its own invalidations", func(t *testing.T) {
+		db.Model(&entity.Flag{}).Where("id = ?", fixtureFlag.ID).Update("description", "own change")
+		inv.handle(message(fixtureFlag.ID, inv.replicaID))
+		assert.NotEqual(t, "own change", ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.ID).Description)
+	})
+
+	t.Run("it reloads the flags of the other replicas", func(t *testing.T) {
+		db.Model(&entity.Flag{}).Where("id = ?", fixtureFlag.ID).Update("description", "other change")
+		pc.messagesCh <- message(fixtureFlag.ID, "other-replica")
+		pc.messagesCh <- &sarama.ConsumerMessage{Value: []byte("not json")}
+
+		// closing waits for the buffered messages to be handled
+		assert.NoError(t, inv.Close())
+		assert.Equal(t, "other change", ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.ID).Description)
+	})
+
+	t.Run("it removes the deleted flags", func(t *testing.T) {
+		db.Delete(&entity.Flag{}, fixtureFlag.ID)
+		inv.handle(message(fixtureFlag.ID, "other-replica"))
+		assert.Nil(t, ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.ID))
+		assert.Nil(t, ec.GetByFlagKeyOrID(context.Background(), "flag_key_renamed"))
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+)
+
+// flagLRU holds the prepared flags of the bounded evaluation cache,
+// the least recently evaluated flag is evicted when it's full
+type flagLRU struct {
+	lock     sync.Mutex
+	maxFlags int
+	ll       *list.List
+	items    map[uint]*list.Element
+}
+
+func newFlagLRU(maxFlags int) *flagLRU {
+	return &flagLRU{
+		maxFlags: maxFlags,
+		ll:       list.New(),
+		items:    make(map[uint]*list.Element),
+	}
+}
+
+// get gets the flag and marks it as the most recently evaluated one
+func (c *flagLRU) get(id uint) *entity.Flag {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.items[id]
+	if !ok {
+		return nil
+	}
+	c.ll.MoveToFront(e)
+	return e.Value.(*entity.Flag)
+}
+
+// add adds or replaces the flag, and evicts the least recently evaluated flags past maxFlags
+func (c *flagLRU) add(f *entity.Flag) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.items[f.ID]; ok {
+		e.Value = f
+		c.ll.MoveToFront(e)
+		return
+	}
+	c.items[f.ID] = c.ll.PushFront(f)
+
+	evicted := 0
+	for c.ll.Len() > c.maxFlags {
+		e := c.ll.Back()
+		c.ll.Remove(e)
+		delete(c.items, e.Value.(*entity.Flag).ID)
+		evicted++
+	}
+
+	if config.Global.Prometheus.EvalCacheEvictions != nil && evicted > 0 {
+		config.Global.Prometheus.EvalCacheEvictions.Add(float64(evicted))
+	}
+	c.setSizeGauge()
+}
+
+// update replaces the flag if it's held, without marking it as evaluated
+func (c *flagLRU) update(f *entity.Flag) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.items[f.ID]; ok {
+		e.Value = f
+	}
+}
+
+// remove removes the flag, e.g. when it's deleted
+func (c *flagLRU) remove(id uint) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.items[id]; ok {
+		c.ll.Remove(e)
+		delete(c.items, id)
+	}
+	c.setSizeGauge()
+}
+
+// ids returns the IDs of the flags from the most to the least recently evaluated
+func (c *flagLRU) ids() []uint {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ids := make([]uint, 0, c.ll.Len())
+	for e := c.ll.Front(); e != nil; e = e.Next() {
+		ids = append(ids, e.Value.(*entity.Flag).ID)
+	}
+	return ids
+}
+
+// replace swaps the flags for their reloaded ones without changing the order,
+// the flags missing from fs have been deleted so they're removed
+func (c *flagLRU) replace(fs map[uint]*entity.Flag) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for id, e := range c.items {
+		f, ok := fs[id]
+		if !ok {
+			c.ll.Remove(e)
+			delete(c.items, id)
+			continue
+		}
+		e.Value = f
+	}
+	c.setSizeGauge()
+}
+
+func (c *flagLRU) setSizeGauge() {
+	if config.Global.Prometheus.EvalCacheSize != nil {
+		config.Global.Prometheus.EvalCacheSize.Set(float64(c.ll.Len()))
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+
+	"github.com/jinzhu/gorm"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagLRU(t *testing.T) {
+	genFlag := func(id uint) *entity.Flag {
+		return &entity.Flag{Model: gorm.Model{ID: id}}
+	}
+	evictions := prometheus.NewCounter(prometheus.CounterOpts{Name: "evictions"})
+	size := prometheus.NewGauge(prometheus.GaugeOpts{Name: "size"})
+	config.Global.Prometheus.EvalCacheEvictions = evictions
+	config.Global.Prometheus.EvalCacheSize = size
+	defer func() {
+		config.Global.Prometheus.EvalCacheEvictions = nil
+		config.Global.Prometheus.EvalCacheSize = nil
+	}()
+
+	c := newFlagLRU(2)
+	c.add(genFlag(1))
+	c.add(genFlag(2))
+	assert.Equal(t, []uint{2, 1}, c.ids())
+
+	t.Run("it evicts the least recently evaluated flag", func(t *testing.T) {
+		assert.NotNil(t, c.get(1))
+		c.add(genFlag(3))
+		assert.Equal(t, []uint{3, 1}, c.ids())
+		assert.Nil(t, c.get(2))
+
+		m := &dto.Metric{}
+		evictions.Write(m)
+		assert.Equal(t, float64(1), m.GetCounter().GetValue())
+		size.Write(m)
+		assert.Equal(t, float64(2), m.GetGauge().GetValue())
+	})
+
+	t.Run("it replaces the flags and removes the deleted ones", func(t *testing.T) {
+		reloaded := genFlag(1)
+		reloaded.Description = "reloaded"
+		c.replace(map[uint]*entity.Flag{1: reloaded})
+		assert.Equal(t, []uint{1}, c.ids())
+		assert.Equal(t, "reloaded", c.get(1).Description)
+	})
+}
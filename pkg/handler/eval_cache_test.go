@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/health"
 
+	"github.com/jinzhu/gorm"
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,6 +22,197 @@ func TestGetByFlagKeyOrID(t *testing.T) {
 
 	ec := GetEvalCache()
 	ec.reloadMapCache()
-	f := ec.GetByFlagKeyOrID(fixtureFlag.ID)
+	f := ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.ID)
 	assert.Equal(t, f.ID, fixtureFlag.ID)
 }
+
+func TestRefreshEvalCache(t *testing.T) {
+	fixtureFlag := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(fixtureFlag)
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	count, _, err := refreshEvalCache()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NotNil(t, GetEvalCache().GetByFlagKeyOrID(context.Background(), fixtureFlag.Key))
+}
+
+func TestGetByNamespaceFlagKeyOrID(t *testing.T) {
+	checkout := entity.GenFixtureFlag()
+	checkout.Namespace = "checkout"
+	search := entity.GenFixtureFlag()
+	search.ID, search.Key, search.Namespace = 101, "flag_key_101", "search"
+
+	idCache := mapCache{"100": &checkout, "101": &search}
+	ec := &EvalCache{
+		idCache:         idCache,
+		keyCache:        mapCache{checkout.Key: &checkout, search.Key: &search},
+		namespaceCaches: newNamespaceCaches(idCache),
+	}
+
+	t.Run("it gets the flags of all the namespaces if they are not enabled", func(t *testing.T) {
+		assert.NotNil(t, ec.GetByNamespaceFlagKeyOrID(context.Background(), "", checkout.ID))
+		assert.NotNil(t, ec.GetByNamespaceFlagKeyOrID(context.Background(), "", search.Key))
+	})
+
+	t.Run("it only gets the flags of the namespace", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+
+		assert.Equal(t, checkout.ID, ec.GetByNamespaceFlagKeyOrID(context.Background(), "checkout", checkout.ID).ID)
+		assert.Equal(t, checkout.ID, ec.GetByNamespaceFlagKeyOrID(context.Background(), "checkout", checkout.Key).ID)
+		assert.Nil(t, ec.GetByNamespaceFlagKeyOrID(context.Background(), "checkout", search.ID))
+		assert.Nil(t, ec.GetByNamespaceFlagKeyOrID(context.Background(), "search", checkout.Key))
+		assert.Nil(t, ec.GetByNamespaceFlagKeyOrID(context.Background(), "", checkout.Key))
+	})
+}
+
+func TestGetEnabledFlagIDsByTag(t *testing.T) {
+	genFlag := func(id uint, enabled bool, namespace string, tags ...string) *entity.Flag {
+		f := entity.GenFixtureFlag()
+		f.ID = id
+		f.Enabled = enabled
+		f.Namespace = namespace
+		for _, tag := range tags {
+			f.Tags = append(f.Tags, entity.Tag{Value: tag})
+		}
+		return &f
+	}
+	idCache := mapCache{
+		"102": genFlag(102, true, "checkout", "checkout"),
+		"101": genFlag(101, true, "", "checkout", "search"),
+		"103": genFlag(103, false, "", "checkout"),
+	}
+	ec := &EvalCache{idCache: idCache, tagCache: newTagCache(idCache)}
+
+	assert.Equal(t, []int64{101, 102}, ec.GetEnabledFlagIDsByTag("", "checkout", ""))
+	assert.Equal(t, []int64{101}, ec.GetEnabledFlagIDsByTag("", "search", ""))
+	assert.Equal(t, []int64{}, ec.GetEnabledFlagIDsByTag("", "billing", ""))
+
+	t.Run("it only gets the flags of the namespace", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+		assert.Equal(t, []int64{102}, ec.GetEnabledFlagIDsByTag("checkout", "checkout", ""))
+	})
+
+	t.Run("it gets the flags enabled in the environment", func(t *testing.T) {
+		idCache["101"].Environments = []entity.FlagEnvironment{{Environment: "prod", Enabled: false}}
+		idCache["103"].Environments = []entity.FlagEnvironment{{Environment: "prod", Enabled: true}}
+		assert.Equal(t, []int64{102, 103}, ec.GetEnabledFlagIDsByTag("", "checkout", "prod"))
+		assert.Equal(t, []int64{101, 102}, ec.GetEnabledFlagIDsByTag("", "checkout", "dev"))
+	})
+}
+
+func TestEvalCacheWarmUp(t *testing.T) {
+	fixtureFlag := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(fixtureFlag)
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	ec := &EvalCache{refreshTimeout: config.Config.EvalCacheRefreshTimeout}
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+	assert.False(t, ec.IsWarmedUp())
+	assert.IsType(t, &health.GetReadyServiceUnavailable{}, getReady(health.GetReadyParams{}))
+
+	ec.warmUp()
+	assert.True(t, ec.IsWarmedUp())
+	assert.NotNil(t, ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.ID))
+	assert.IsType(t, &health.GetReadyOK{}, getReady(health.GetReadyParams{}))
+	assert.Empty(t, getReady(health.GetReadyParams{}).(*health.GetReadyOK).Payload.Warnings)
+}
+
+func TestBoundedEvalCache(t *testing.T) {
+	fixtureFlag := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(fixtureFlag)
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	db.Create(&entity.Flag{Model: gorm.Model{ID: 101}, Key: "flag_key_101", Enabled: true})
+
+	ec := &EvalCache{flags: newFlagLRU(1), refreshTimeout: config.Config.EvalCacheRefreshTimeout}
+	assert.NoError(t, ec.reloadMapCache())
+
+	t.Run("it indexes the flags without their segments and variants", func(t *testing.T) {
+		assert.Len(t, ec.idCache, 2)
+		assert.Empty(t, ec.idCache["100"].Segments)
+		assert.Empty(t, ec.flags.ids())
+	})
+
+	t.Run("it loads the flags on demand and evicts the least recently evaluated one", func(t *testing.T) {
+		f := ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.Key)
+		assert.Len(t, f.Segments, 1)
+		assert.NotEmpty(t, f.FlagEvaluation.VariantsMap)
+		assert.Equal(t, []uint{100}, ec.flags.ids())
+		assert.True(t, f == ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.ID))
+
+		assert.NotNil(t, ec.GetByFlagKeyOrID(context.Background(), 101))
+		assert.Equal(t, []uint{101}, ec.flags.ids())
+		assert.Nil(t, ec.GetByFlagKeyOrID(context.Background(), 102))
+	})
+
+	t.Run("it reloads a single flag", func(t *testing.T) {
+		db.Model(&entity.Flag{}).Where("id = ?", 101).Update("key", "flag_key_renamed")
+		assert.NoError(t, ec.reloadFlag(101))
+		assert.Empty(t, ec.idCache["101"].Segments)
+		assert.Nil(t, ec.GetByFlagKeyOrID(context.Background(), "flag_key_101"))
+		assert.Equal(t, "flag_key_renamed", ec.GetByFlagKeyOrID(context.Background(), 101).Key)
+	})
+
+	t.Run("it reloads the cached flags", func(t *testing.T) {
+		db.Model(&entity.Flag{}).Where("id = ?", 101).Update("description", "reloaded")
+		assert.NoError(t, ec.reloadMapCache())
+		assert.Equal(t, "reloaded", ec.GetByFlagKeyOrID(context.Background(), 101).Description)
+
+		db.Delete(&entity.Flag{Model: gorm.Model{ID: 101}})
+		assert.NoError(t, ec.reloadMapCache())
+		assert.Empty(t, ec.flags.ids())
+		assert.Nil(t, ec.GetByFlagKeyOrID(context.Background(), 101))
+	})
+
+	t.Run("it exports all the flags with their segments and variants", func(t *testing.T) {
+		ecj, err := ec.export()
+		assert.NoError(t, err)
+		assert.Len(t, ecj.Flags, 1)
+		assert.Len(t, ecj.Flags[0].Segments, 1)
+	})
+}
+
+func TestConcurrentReloadsOfEvalCache(t *testing.T) {
+	fixtureFlag := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(fixtureFlag)
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	ec := &EvalCache{refreshTimeout: config.Config.EvalCacheRefreshTimeout}
+	assert.NoError(t, ec.reloadMapCache())
+
+	fetched, release := make(chan struct{}), make(chan struct{})
+	origFetchAllFlags := fetchAllFlags
+	defer gostub.Stub(&fetchAllFlags, func() ([]entity.Flag, error) {
+		fs, err := origFetchAllFlags()
+		close(fetched)
+		<-release
+		return fs, err
+	}).Reset()
+
+	reloaded := make(chan error, 2)
+	go func() { reloaded <- ec.reloadMapCache() }()
+	<-fetched
+
+	db.Model(&entity.Flag{}).Where("id = ?", fixtureFlag.ID).Update("description", "changed")
+	go func() { reloaded <- ec.reloadFlag(fixtureFlag.ID) }()
+
+	// the reload of the flag waits for the reload of all the flags, which would otherwise swap in its stale flag
+	errs := []error{}
+	select {
+	case err := <-reloaded:
+		errs = append(errs, err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	for len(errs) < 2 {
+		errs = append(errs, <-reloaded)
+	}
+	assert.Equal(t, []error{nil, nil}, errs)
+
+	assert.Equal(t, "changed", ec.GetByFlagKeyOrID(context.Background(), fixtureFlag.ID).Description)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// evalEnrichmentCacheSize caps the number of the cached entities, the expired ones
+// are swept once it's reached, and the cache is reset if none of them expired
+const evalEnrichmentCacheSize = 10000
+
+var (
+	singletonEvalEnrichment     *evalEnrichment
+	singletonEvalEnrichmentOnce sync.Once
+)
+
+// evalEnrichment merges the attributes that EvalEnrichmentURL responds with for the entity into its entityContext,
+// e.g. the attributes living in another service that the clients don't have at hand
+type evalEnrichment struct {
+	url      string
+	ttl      time.Duration
+	failOpen bool
+	client   *http.Client
+
+	mu    sync.Mutex
+	cache map[string]evalEnrichmentCacheEntry
+	now   func() time.Time
+}
+
+type evalEnrichmentCacheEntry struct {
+	attributes map[string]interface{}
+	expiresAt  time.Time
+}
+
+// evalEnrichmentRequest is the body POSTed to EvalEnrichmentURL
+type evalEnrichmentRequest struct {
+	EntityID      string      `json:"entityID"`
+	EntityType    string      `json:"entityType"`
+	EntityContext interface{} `json:"entityContext"`
+}
+
+// getEvalEnrichment gets the enrichment singleton, it's nil if EvalEnrichmentURL is not set
+var getEvalEnrichment = func() *evalEnrichment {
+	singletonEvalEnrichmentOnce.Do(func() {
+		if config.Config.EvalEnrichmentURL == "" {
+			return
+		}
+		singletonEvalEnrichment = newEvalEnrichment()
+	})
+	return singletonEvalEnrichment
+}
+
+func newEvalEnrichment() *evalEnrichment {
+	return &evalEnrichment{
+		url:      config.Config.EvalEnrichmentURL,
+		ttl:      config.Config.EvalEnrichmentCacheTTL,
+		failOpen: config.Config.EvalEnrichmentFailOpen,
+		client:   &http.Client{Timeout: config.Config.EvalEnrichmentTimeout},
+		cache:    make(map[string]evalEnrichmentCacheEntry),
+		now:      time.Now,
+	}
+}
+
+// withEnrichment merges the attributes of the entity from EvalEnrichmentURL into the entityContext.
+// It's only an error if the enrichment fails and it's not EvalEnrichmentFailOpen.
+func withEnrichment(ctx context.Context, entityID string, entityType string, entityContext interface{}) (interface{}, error) {
+	en := getEvalEnrichment()
+	if en == nil {
+		return entityContext, nil
+	}
+	return en.enrich(ctx, entityID, entityType, entityContext)
+}
+
+// enrich leaves the entities without entityID alone, they're random ones that there's nothing to look up for,
+// and so are the entityContexts that are not objects
+func (en *evalEnrichment) enrich(ctx context.Context, entityID string, entityType string, entityContext interface{}) (interface{}, error) {
+	m, ok := entityContext.(map[string]interface{})
+	if entityID == "" || (!ok && entityContext != nil) {
+		return entityContext, nil
+	}
+
+	attributes, err := en.attributes(ctx, entityID, entityType, entityContext)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "entityID": entityID}).Error("failed to enrich the entity context")
+		if config.Global.StatsdClient != nil {
+			config.Global.StatsdClient.Incr("eval_enrichment.failed", nil, float64(1))
+		}
+		if en.failOpen {
+			return entityContext, nil
+		}
+		return nil, err
+	}
+
+	ret := make(map[string]interface{}, len(m)+len(attributes))
+	for k, v := range m {
+		ret[k] = v
+	}
+	for k, v := range attributes {
+		ret[k] = v
+	}
+	return ret, nil
+}
+
+func (en *evalEnrichment) attributes(ctx context.Context, entityID string, entityType string, entityContext interface{}) (map[string]interface{}, error) {
+	key := entityType + "/" + entityID
+	now := en.now()
+
+	en.mu.Lock()
+	entry, ok := en.cache[key]
+	en.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.attributes, nil
+	}
+
+	attributes, err := en.request(ctx, evalEnrichmentRequest{
+		EntityID:      entityID,
+		EntityType:    entityType,
+		EntityContext: entityContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	en.store(key, evalEnrichmentCacheEntry{attributes: attributes, expiresAt: now.Add(en.ttl)}, now)
+	return attributes, nil
+}
+
+func (en *evalEnrichment) store(key string, entry evalEnrichmentCacheEntry, now time.Time) {
+	en.mu.Lock()
+	defer en.mu.Unlock()
+
+	if len(en.cache) >= evalEnrichmentCacheSize {
+		for k, e := range en.cache {
+			if !now.Before(e.expiresAt) {
+				delete(en.cache, k)
+			}
+		}
+		if len(en.cache) >= evalEnrichmentCacheSize {
+			en.cache = make(map[string]evalEnrichmentCacheEntry)
+		}
+	}
+	en.cache[key] = entry
+}
+
+func (en *evalEnrichment) request(ctx context.Context, body evalEnrichmentRequest) (map[string]interface{}, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, en.url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := en.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot enrich the entity context. reason: %s", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot enrich the entity context. reason: status code %d", res.StatusCode)
+	}
+
+	attributes := map[string]interface{}{}
+	if err := json.NewDecoder(res.Body).Decode(&attributes); err != nil {
+		return nil, fmt.Errorf("cannot decode the enrichment response. reason: %s", err)
+	}
+	return attributes, nil
+}
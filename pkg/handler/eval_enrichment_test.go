@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/evaluation"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEnrichmentServer(t *testing.T, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body := evalEnrichmentRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "user", body.EntityType)
+
+		switch body.EntityID {
+		case "error":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "slow":
+			time.Sleep(200 * time.Millisecond)
+			json.NewEncoder(w).Encode(map[string]interface{}{"tier": "gold"})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"tier": "gold", "state": "NY"})
+		}
+	}))
+}
+
+func TestEvalEnrichment(t *testing.T) {
+	calls := 0
+	server := newEnrichmentServer(t, &calls)
+	defer server.Close()
+
+	defer gostub.Stub(&config.Config.EvalEnrichmentURL, server.URL).
+		Stub(&config.Config.EvalEnrichmentTimeout, 50*time.Millisecond).
+		Stub(&config.Config.EvalEnrichmentCacheTTL, time.Minute).
+		Reset()
+
+	t.Run("it merges the attributes into the entity context", func(t *testing.T) {
+		calls = 0
+		en := newEvalEnrichment()
+		ret, err := en.enrich(context.Background(), "user_1", "user", map[string]interface{}{"state": "CA", "age": 20})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"state": "NY", "age": 20, "tier": "gold"}, ret)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("it caches the attributes of the entity until the ttl", func(t *testing.T) {
+		calls = 0
+		now := time.Now()
+		en := newEvalEnrichment()
+		en.now = func() time.Time { return now }
+
+		en.enrich(context.Background(), "user_1", "user", nil)
+		ret, err := en.enrich(context.Background(), "user_1", "user", map[string]interface{}{"age": 20})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"state": "NY", "age": 20, "tier": "gold"}, ret)
+		assert.Equal(t, 1, calls)
+
+		en.enrich(context.Background(), "user_2", "user", nil)
+		assert.Equal(t, 2, calls)
+
+		now = now.Add(time.Minute)
+		en.enrich(context.Background(), "user_1", "user", nil)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("it leaves the entities without entityID alone", func(t *testing.T) {
+		calls = 0
+		en := newEvalEnrichment()
+		ret, err := en.enrich(context.Background(), "", "user", map[string]interface{}{"age": 20})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"age": 20}, ret)
+		assert.Zero(t, calls)
+	})
+
+	t.Run("it proceeds with the original entity context if it fails open", func(t *testing.T) {
+		en := newEvalEnrichment()
+		en.failOpen = true
+		for _, entityID := range []string{"error", "slow"} {
+			ret, err := en.enrich(context.Background(), entityID, "user", map[string]interface{}{"age": 20})
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"age": 20}, ret)
+		}
+	})
+
+	t.Run("it fails if it fails closed", func(t *testing.T) {
+		en := newEvalEnrichment()
+		en.failOpen = false
+		for _, entityID := range []string{"error", "slow"} {
+			_, err := en.enrich(context.Background(), entityID, "user", map[string]interface{}{"age": 20})
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("it responds 502 to the evaluation if it fails closed", func(t *testing.T) {
+		en := newEvalEnrichment()
+		en.failOpen = false
+		defer gostub.StubFunc(&getEvalEnrichment, en).Reset()
+		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+		defer gostub.StubFunc(&logEvalResult).Reset()
+
+		e := NewEval()
+		res := e.PostEvaluation(evaluation.PostEvaluationParams{Body: &models.EvalContext{
+			EntityID:   "user_1",
+			EntityType: "user",
+			FlagID:     int64(100),
+		}})
+		assert.Equal(t, "gold", res.(*evaluation.PostEvaluationOK).Payload.EvalContext.EntityContext.(map[string]interface{})["tier"])
+
+		res = e.PostEvaluation(evaluation.PostEvaluationParams{Body: &models.EvalContext{
+			EntityID:   "error",
+			EntityType: "user",
+			FlagID:     int64(100),
+		}})
+		assert.Contains(t, *res.(*evaluation.PostEvaluationDefault).Payload.Message, "status code 500")
+
+		res = e.PostEvaluationBatch(evaluation.PostEvaluationBatchParams{Body: &models.EvaluationBatchRequest{
+			Entities: []*models.EvaluationEntity{{EntityID: "user_1", EntityType: "user"}, {EntityID: "error", EntityType: "user"}},
+			FlagIds:  []int64{100},
+		}})
+		assert.NotZero(t, res.(*evaluation.PostEvaluationBatchDefault).Payload)
+	})
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bsm/ratelimit"
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/evaluation"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// FrontendEventSource is the source marker of the data records reported by the frontend clients
+const FrontendEventSource = "frontend"
+
+var (
+	frontendEventsRateLimiter     *ratelimit.RateLimiter
+	frontendEventsRateLimiterOnce sync.Once
+)
+
+// rateLimitFrontendEvents returns true if the n events exceed the rate limit
+var rateLimitFrontendEvents = func(n int) bool {
+	frontendEventsRateLimiterOnce.Do(func() {
+		frontendEventsRateLimiter = ratelimit.New(
+			config.Config.RateLimiterPerSecondFrontendEvents,
+			time.Second,
+		)
+	})
+	for i := 0; i < n; i++ {
+		if frontendEventsRateLimiter.Limit() {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *eval) PostEvaluationFrontendEvents(params evaluation.PostEvaluationFrontendEventsParams) middleware.Responder {
+	if params.Body == nil {
+		return evaluation.NewPostEvaluationFrontendEventsDefault(400).WithPayload(
+			ErrorCodeMessage(models.ErrorErrorCodeINVALIDREQUEST, "empty body"))
+	}
+	if rateLimitFrontendEvents(len(params.Body.Events)) {
+		return evaluation.NewPostEvaluationFrontendEventsDefault(429).WithPayload(
+			ErrorCodeMessage(models.ErrorErrorCodeRATELIMITED, "too many frontend events"))
+	}
+
+	records := make([]*models.EvalResult, 0, len(params.Body.Events))
+	dataRecordsEnabled := make([]bool, 0, len(params.Body.Events))
+	for i, ev := range params.Body.Events {
+		r, enabled, err := mapFrontendEvent(requestContext(params.HTTPRequest), getNamespaceFromRequest(params.HTTPRequest), ev)
+		if err != nil {
+			return evaluation.NewPostEvaluationFrontendEventsDefault(err.StatusCode).WithPayload(
+				ErrorCodeMessage(models.ErrorErrorCodeINVALIDREQUEST, "invalid event %d. %s", i, fmt.Sprintf(err.Message, err.Values...)))
+		}
+		records = append(records, r)
+		dataRecordsEnabled = append(dataRecordsEnabled, enabled)
+	}
+
+	for i, r := range records {
+		recordFrontendEvent(r, dataRecordsEnabled[i])
+	}
+	return evaluation.NewPostEvaluationFrontendEventsOK()
+}
+
+// mapFrontendEvent validates the event against the flags of the namespace in the evaluation cache,
+// and maps it into the data record. It also returns if the flag has data records enabled.
+var mapFrontendEvent = func(ctx context.Context, namespace string, ev *models.FrontendEvent) (*models.EvalResult, bool, *Error) {
+	if ev == nil {
+		return nil, false, NewError(400, "empty event")
+	}
+
+	flagKey := util.SafeString(ev.FlagKey)
+	f := GetEvalCache().GetByNamespaceFlagKeyOrID(ctx, namespace, flagKey)
+	if f == nil {
+		return nil, false, NewError(400, "flagKey %s not found", flagKey)
+	}
+
+	variantKey := util.SafeString(ev.VariantKey)
+	var variantID uint
+	for _, v := range f.Variants {
+		if v.Key == variantKey {
+			variantID = v.ID
+			break
+		}
+	}
+	if variantID == 0 {
+		return nil, false, NewError(400, "variantKey %s not found in flag %s", variantKey, flagKey)
+	}
+
+	timestamp := ev.Timestamp
+	if timestamp == "" {
+		timestamp = util.TimeNow()
+	} else if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+		return nil, false, NewError(400, "timestamp %s is not in RFC3339 format", timestamp)
+	}
+
+	return &models.EvalResult{
+		EvalContext: &models.EvalContext{
+			EntityID:   util.SafeString(ev.EntityID),
+			EntityType: ev.EntityType,
+			FlagKey:    flagKey,
+		},
+		EventType:      util.SafeString(ev.EventType),
+		FlagID:         int64(f.ID),
+		FlagKey:        f.Key,
+		FlagSnapshotID: int64(f.SnapshotID),
+		Source:         FrontendEventSource,
+		Timestamp:      timestamp,
+		VariantID:      int64(variantID),
+		VariantKey:     variantKey,
+	}, f.DataRecordsEnabled, nil
+}
+
+var recordFrontendEvent = func(r *models.EvalResult, dataRecordsEnabled bool) {
+	if !config.Config.RecorderEnabled || !dataRecordsEnabled {
+		return
+	}
+	if !isDataRecordSampled(r, config.Config.RecorderSampleRate) {
+		return
+	}
+	GetDataRecorder().AsyncRecord(*r)
+}
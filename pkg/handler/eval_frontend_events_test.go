@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/evaluation"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func genFrontendEvent(flagKey string, variantKey string) *models.FrontendEvent {
+	return &models.FrontendEvent{
+		EntityID:   util.StringPtr("entityID1"),
+		EventType:  util.StringPtr(models.FrontendEventEventTypeImpression),
+		FlagKey:    util.StringPtr(flagKey),
+		VariantKey: util.StringPtr(variantKey),
+	}
+}
+
+func TestPostEvaluationFrontendEvents(t *testing.T) {
+	defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+
+	t.Run("test empty body", func(t *testing.T) {
+		e := NewEval()
+		resp := e.PostEvaluationFrontendEvents(evaluation.PostEvaluationFrontendEventsParams{})
+		assert.IsType(t, &evaluation.PostEvaluationFrontendEventsDefault{}, resp)
+	})
+
+	t.Run("test happy code path", func(t *testing.T) {
+		var recorded []*models.EvalResult
+		defer gostub.Stub(&recordFrontendEvent, func(r *models.EvalResult, dataRecordsEnabled bool) {
+			recorded = append(recorded, r)
+		}).Reset()
+
+		e := NewEval()
+		resp := e.PostEvaluationFrontendEvents(evaluation.PostEvaluationFrontendEventsParams{
+			Body: &models.FrontendEventsRequest{
+				Events: []*models.FrontendEvent{genFrontendEvent("flag_key_100", "control")},
+			},
+		})
+		assert.IsType(t, &evaluation.PostEvaluationFrontendEventsOK{}, resp)
+		assert.Len(t, recorded, 1)
+		assert.Equal(t, FrontendEventSource, recorded[0].Source)
+		assert.Equal(t, models.FrontendEventEventTypeImpression, recorded[0].EventType)
+		assert.Equal(t, int64(100), recorded[0].FlagID)
+		assert.Equal(t, int64(300), recorded[0].VariantID)
+		assert.NotEmpty(t, recorded[0].Timestamp)
+	})
+
+	t.Run("test invalid events are rejected without recording", func(t *testing.T) {
+		var recorded []*models.EvalResult
+		defer gostub.Stub(&recordFrontendEvent, func(r *models.EvalResult, dataRecordsEnabled bool) {
+			recorded = append(recorded, r)
+		}).Reset()
+
+		e := NewEval()
+		resp := e.PostEvaluationFrontendEvents(evaluation.PostEvaluationFrontendEventsParams{
+			Body: &models.FrontendEventsRequest{
+				Events: []*models.FrontendEvent{
+					genFrontendEvent("flag_key_100", "control"),
+					genFrontendEvent("flag_key_100", "unknown_variant"),
+				},
+			},
+		})
+		assert.IsType(t, &evaluation.PostEvaluationFrontendEventsDefault{}, resp)
+		assert.Empty(t, recorded)
+	})
+
+	t.Run("test rate limited", func(t *testing.T) {
+		defer gostub.StubFunc(&rateLimitFrontendEvents, true).Reset()
+		e := NewEval()
+		resp := e.PostEvaluationFrontendEvents(evaluation.PostEvaluationFrontendEventsParams{
+			Body: &models.FrontendEventsRequest{
+				Events: []*models.FrontendEvent{genFrontendEvent("flag_key_100", "control")},
+			},
+		})
+		assert.Equal(t, models.ErrorErrorCodeRATELIMITED, resp.(*evaluation.PostEvaluationFrontendEventsDefault).Payload.ErrorCode)
+	})
+}
+
+func TestMapFrontendEvent(t *testing.T) {
+	defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+
+	t.Run("flag not found", func(t *testing.T) {
+		_, _, err := mapFrontendEvent(context.Background(), "", genFrontendEvent("not_exist", "control"))
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		ev := genFrontendEvent("flag_key_100", "control")
+		ev.Timestamp = "yesterday"
+		_, _, err := mapFrontendEvent(context.Background(), "", ev)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("keeps the client timestamp", func(t *testing.T) {
+		ev := genFrontendEvent("flag_key_100", "treatment")
+		ev.Timestamp = "2019-01-02T03:04:05Z"
+		r, _, err := mapFrontendEvent(context.Background(), "", ev)
+		assert.Nil(t, err)
+		assert.Equal(t, "2019-01-02T03:04:05Z", r.Timestamp)
+		assert.Equal(t, int64(301), r.VariantID)
+	})
+}
+
+func TestRateLimitFrontendEvents(t *testing.T) {
+	assert.False(t, rateLimitFrontendEvents(1))
+	assert.True(t, rateLimitFrontendEvents(config.Config.RateLimiterPerSecondFrontendEvents+1))
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/checkr/flagr/grpc_gen/flagr"
+	"github.com/checkr/flagr/pkg/mapper/restapi_grpc/g2r"
+	"github.com/checkr/flagr/pkg/mapper/restapi_grpc/r2g"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewEvalGRPC creates a new gRPC evaluation server. It shares the same
+// evaluation and data recording path with the REST evaluation api.
+func NewEvalGRPC() flagr.EvaluationServer {
+	return &evalGRPC{}
+}
+
+type evalGRPC struct{}
+
+// The grpc requests carry no JWT token, so they are evaluated in the default namespace
+func (e *evalGRPC) PostEvaluation(ctx context.Context, req *flagr.EvalContext) (*flagr.EvalResult, error) {
+	evalContext := g2r.MapEvalContext(req)
+	entityContext, err := withEnrichment(ctx, evalContext.EntityID, evalContext.EntityType, evalContext.EntityContext)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	evalContext.EntityContext = entityContext
+
+	evalResult := evalFlag(ctx, "", evalContext)
+	return r2g.MapEvalResult(evalResult), nil
+}
+
+func (e *evalGRPC) PostEvaluationBatch(ctx context.Context, req *flagr.EvaluationBatchRequest) (*flagr.EvaluationBatchResponse, error) {
+	if len(req.Entities) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "entities should not be empty")
+	}
+
+	body := g2r.MapEvaluationBatchRequest(req)
+	for _, entity := range body.Entities {
+		entityContext, err := withEnrichment(ctx, entity.EntityID, entity.EntityType, entity.EntityContext)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		entity.EntityContext = entityContext
+	}
+
+	results := evalBatch(ctx, "", body)
+	return r2g.MapEvaluationBatchResponse(results), nil
+}
+
+var startGRPCServer = func(host string, port int) *grpc.Server {
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", host, port))
+	if err != nil {
+		logrus.WithField("err", err).Fatal("failed to listen for the grpc server")
+	}
+
+	s := grpc.NewServer()
+	flagr.RegisterEvaluationServer(s, NewEvalGRPC())
+	go func() {
+		logrus.Infof("Serving flagr grpc at %s", lis.Addr())
+		if err := s.Serve(lis); err != nil {
+			logrus.WithField("err", err).Error("grpc server stopped")
+		}
+	}()
+	return s
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/checkr/flagr/grpc_gen/flagr"
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalGRPCPostEvaluation(t *testing.T) {
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+
+	e := NewEvalGRPC()
+	result, err := e.PostEvaluation(context.Background(), &flagr.EvalContext{
+		EntityID: "entityID1",
+		EntityContext: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"dl_state": {Kind: &structpb.Value_StringValue{StringValue: "CA"}},
+		}},
+		FlagID: int64(100),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), result.FlagID)
+	assert.NotZero(t, result.VariantID)
+	assert.NotEmpty(t, result.VariantKey)
+	assert.Equal(t, "entityID1", result.EvalContext.EntityID)
+	assert.Equal(t, "CA", result.EvalContext.EntityContext.Fields["dl_state"].GetStringValue())
+	assert.Empty(t, result.ErrorCode)
+
+	result, err = e.PostEvaluation(context.Background(), &flagr.EvalContext{EntityID: "entityID1", FlagID: int64(999)})
+	assert.NoError(t, err)
+	assert.Equal(t, models.EvalResultErrorCodeFLAGNOTFOUND, result.ErrorCode)
+}
+
+func TestEvalGRPCPostEvaluationBatch(t *testing.T) {
+	t.Run("test empty entities", func(t *testing.T) {
+		e := NewEvalGRPC()
+		_, err := e.PostEvaluationBatch(context.Background(), &flagr.EvaluationBatchRequest{})
+		assert.Error(t, err)
+	})
+
+	t.Run("test happy code path", func(t *testing.T) {
+		defer gostub.StubFunc(&evalFlag, &models.EvalResult{
+			FlagID:      int64(100),
+			VariantID:   int64(300),
+			EvalContext: &models.EvalContext{},
+			VariantAttachment: map[string]string{
+				"color": "red",
+			},
+		}).Reset()
+
+		e := NewEvalGRPC()
+		resp, err := e.PostEvaluationBatch(context.Background(), &flagr.EvaluationBatchRequest{
+			Entities: []*flagr.EvaluationEntity{
+				{EntityID: "entityID1"},
+				{EntityID: "entityID2"},
+			},
+			FlagIDs:  []int64{100},
+			FlagKeys: []string{"flag_key_100"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, resp.EvaluationResults, 4)
+		assert.Equal(t, "red", resp.EvaluationResults[0].VariantAttachment.Fields["color"].GetStringValue())
+	})
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+)
+
+// evalResultCacheSize caps the number of the cached results, the expired ones
+// are swept once it's reached, and the cache is reset if none of them expired
+const evalResultCacheSize = 10000
+
+var (
+	singletonEvalResultCache     *evalResultCache
+	singletonEvalResultCacheOnce sync.Once
+)
+
+// evalResultCache holds the results of the flags for the same entity and entityContext for a short TTL,
+// e.g. for a page that re-renders. A result is only valid for the very flag it was evaluated with, the
+// evaluation cache swaps in a new flag on every reload of it, so the results don't outlive the flag changes.
+type evalResultCache struct {
+	ttl time.Duration
+
+	mu    sync.Mutex
+	cache map[string]evalResultCacheEntry
+	now   func() time.Time
+}
+
+type evalResultCacheEntry struct {
+	flag      *entity.Flag
+	result    models.EvalResult
+	expiresAt time.Time
+}
+
+// getEvalResultCache gets the result cache singleton, it's nil if EvalResultCacheTTL is 0
+var getEvalResultCache = func() *evalResultCache {
+	singletonEvalResultCacheOnce.Do(func() {
+		if config.Config.EvalResultCacheTTL <= 0 {
+			return
+		}
+		singletonEvalResultCache = newEvalResultCache()
+	})
+	return singletonEvalResultCache
+}
+
+func newEvalResultCache() *evalResultCache {
+	return &evalResultCache{
+		ttl:   config.Config.EvalResultCacheTTL,
+		cache: make(map[string]evalResultCacheEntry),
+		now:   time.Now,
+	}
+}
+
+// getCachedEvalResult gets the cached result of the evaluation, and it's logged like an evaluated one. The key
+// is empty if the evaluation can't be cached, otherwise the result of the evaluation is to be set with it.
+func getCachedEvalResult(f *entity.Flag, evalContext models.EvalContext, logResult bool) (string, *models.EvalResult) {
+	rc := getEvalResultCache()
+	if rc == nil {
+		return "", nil
+	}
+	key, ok := evalResultCacheKey(f, evalContext)
+	if !ok {
+		return "", nil
+	}
+
+	if f.EntityType != "" {
+		evalContext.EntityType = f.EntityType
+	}
+	r := rc.get(f, key, evalContext)
+	if r != nil && logResult {
+		logEvalResult(r, f.DataRecordsEnabled && config.Config.EvalResultCacheRecordHits)
+		logEvalVariantToPrometheus(r, len(f.Variants))
+	}
+	return key, r
+}
+
+// evalResultCacheKey is the flag, the environment, the entity and the hash of its entityContext. It's false if the evaluation
+// can't be cached, i.e. the debug evaluations, the random entities without entityID and the flags whose result depends on
+// more than the flag and the entity, see isEvalResultCacheable.
+func evalResultCacheKey(f *entity.Flag, evalContext models.EvalContext) (string, bool) {
+	if evalContext.EntityID == "" || evalContext.EnableDebug || !isEvalResultCacheable(f) {
+		return "", false
+	}
+	b, err := json.Marshal(evalContext.EntityContext)
+	if err != nil {
+		return "", false
+	}
+	h := sha256.Sum256(b)
+	return fmt.Sprintf("%d/%s/%s/%s/%t/%s",
+		f.ID, evalContext.Environment, evalContext.EntityType, evalContext.EntityID, evalContext.IncludeAllMatches, hex.EncodeToString(h[:])), true
+}
+
+// isEvalResultCacheable is false for the flags with a prerequisite or an exclusion group, which depend on the other flags
+// that are reloaded on their own, and for the flags with the time-window constraints or the ramping distributions,
+// which depend on the evaluation time
+func isEvalResultCacheable(f *entity.Flag) bool {
+	if f.PrerequisiteFlagID != 0 || f.ExclusionGroupID != 0 {
+		return false
+	}
+	for _, s := range f.Segments {
+		if s.SegmentEvaluation.EvalTimeRequired || s.SegmentEvaluation.RampingDistribution != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// get gets a copy of the result of the key with evalContext of the caller, it's nil if
+// there's no result or the result has expired or it was evaluated with another flag
+func (rc *evalResultCache) get(f *entity.Flag, key string, evalContext models.EvalContext) *models.EvalResult {
+	rc.mu.Lock()
+	entry, ok := rc.cache[key]
+	rc.mu.Unlock()
+	if !ok || entry.flag != f || !rc.now().Before(entry.expiresAt) {
+		return nil
+	}
+
+	r := entry.result
+	r.EvalContext = &evalContext
+	r.Timestamp = util.TimeNow()
+	return &r
+}
+
+func (rc *evalResultCache) set(f *entity.Flag, key string, r *models.EvalResult) {
+	now := rc.now()
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if len(rc.cache) >= evalResultCacheSize {
+		for k, e := range rc.cache {
+			if !now.Before(e.expiresAt) {
+				delete(rc.cache, k)
+			}
+		}
+		if len(rc.cache) >= evalResultCacheSize {
+			rc.cache = make(map[string]evalResultCacheEntry)
+		}
+	}
+	rc.cache[key] = evalResultCacheEntry{flag: f, result: *r, expiresAt: now.Add(rc.ttl)}
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalResultCache(t *testing.T) {
+	segmentEvals := 0
+	origEvalSegment := evalSegment
+	defer gostub.Stub(&evalSegment, func(f *entity.Flag, evalContext models.EvalContext, segment entity.Segment, now time.Time) (*uint, *models.SegmentDebugLog, bool, error) {
+		segmentEvals++
+		return origEvalSegment(f, evalContext, segment, now)
+	}).Reset()
+
+	records := []bool{}
+	defer gostub.Stub(&logEvalResult, func(r *models.EvalResult, dataRecordsEnabled bool) {
+		records = append(records, dataRecordsEnabled)
+	}).Reset()
+
+	now := time.Now()
+	rc := newEvalResultCache()
+	rc.ttl = time.Minute
+	rc.now = func() time.Time { return now }
+	defer gostub.StubFunc(&getEvalResultCache, rc).Reset()
+
+	ec := GenFixtureEvalCache()
+	ec.idCache["100"].DataRecordsEnabled = true
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+	evalContext := models.EvalContext{
+		EntityContext: map[string]interface{}{"dl_state": "CA"},
+		EntityID:      "entityID1",
+		EntityType:    "entityType1",
+		FlagID:        int64(100),
+	}
+
+	t.Run("it reuses the result of the same entity and records every evaluation", func(t *testing.T) {
+		segmentEvals, records = 0, []bool{}
+		first := evalFlag(context.Background(), "", evalContext)
+		second := evalFlag(context.Background(), "", evalContext)
+		assert.NotZero(t, first.VariantID)
+		assert.Equal(t, first.VariantID, second.VariantID)
+		assert.Equal(t, first.VariantKey, second.VariantKey)
+		assert.Equal(t, "entityID1", second.EvalContext.EntityID)
+		assert.Equal(t, 1, segmentEvals)
+		assert.Equal(t, []bool{true, true}, records)
+	})
+
+	t.Run("it doesn't record the cached results without EvalResultCacheRecordHits", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.EvalResultCacheRecordHits, false).Reset()
+		records = []bool{}
+		evalFlag(context.Background(), "", evalContext)
+		assert.Equal(t, []bool{false}, records)
+	})
+
+	t.Run("it evaluates another entity context", func(t *testing.T) {
+		segmentEvals = 0
+		other := evalContext
+		other.EntityContext = map[string]interface{}{"dl_state": "NY"}
+		evalFlag(context.Background(), "", other)
+		assert.Equal(t, 1, segmentEvals)
+	})
+
+	t.Run("it evaluates the entity again in another environment", func(t *testing.T) {
+		segmentEvals = 0
+		prod := evalContext
+		prod.Environment = "prod"
+		evalFlag(context.Background(), "", prod)
+		evalFlag(context.Background(), "", prod)
+		assert.Equal(t, 1, segmentEvals)
+	})
+
+	t.Run("it doesn't cache the debug evaluations and the entities without entityID", func(t *testing.T) {
+		segmentEvals = 0
+		debug := evalContext
+		debug.EnableDebug = true
+		evalFlag(context.Background(), "", debug)
+
+		random := evalContext
+		random.EntityID = ""
+		evalFlag(context.Background(), "", random)
+		evalFlag(context.Background(), "", random)
+		assert.Equal(t, 3, segmentEvals)
+	})
+
+	t.Run("it evaluates again once the flag is reloaded or the result expires", func(t *testing.T) {
+		segmentEvals = 0
+		reloaded := *ec.idCache["100"]
+		ec.idCache["100"] = &reloaded
+		evalFlag(context.Background(), "", evalContext)
+		assert.Equal(t, 1, segmentEvals)
+
+		now = now.Add(time.Minute)
+		evalFlag(context.Background(), "", evalContext)
+		evalFlag(context.Background(), "", evalContext)
+		assert.Equal(t, 2, segmentEvals)
+	})
+
+	t.Run("it evaluates the flags with the results beyond the flag and the entity every time", func(t *testing.T) {
+		f := ec.idCache["100"]
+		defer func() { ec.idCache["100"] = f }()
+
+		timed := *f
+		timed.Segments = append([]entity.Segment{}, f.Segments...)
+		timed.Segments[0].SegmentEvaluation.EvalTimeRequired = true
+		ec.idCache["100"] = &timed
+		segmentEvals = 0
+		evalFlag(context.Background(), "", evalContext)
+		evalFlag(context.Background(), "", evalContext)
+		assert.Equal(t, 2, segmentEvals)
+
+		ramping := *f
+		ramping.Segments = append([]entity.Segment{}, f.Segments...)
+		ramping.Segments[0].SegmentEvaluation.RampingDistribution = &f.Segments[0].Distributions[0]
+		assert.False(t, isEvalResultCacheable(&ramping))
+
+		prerequisite := *f
+		prerequisite.PrerequisiteFlagID = 101
+		assert.False(t, isEvalResultCacheable(&prerequisite))
+
+		excluded := *f
+		excluded.ExclusionGroupID = 1
+		assert.False(t, isEvalResultCacheable(&excluded))
+
+		assert.True(t, isEvalResultCacheable(f))
+	})
+}
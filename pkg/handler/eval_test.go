@@ -1,37 +1,50 @@
 package handler
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
 	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/evaluation"
 	"github.com/jinzhu/gorm"
 
 	"github.com/prashantv/gostub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestEvalSegment(t *testing.T) {
 	t.Run("test empty evalContext", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
 		s := entity.GenFixtureSegment()
-		vID, log, evalNextSegment := evalSegment(100, models.EvalContext{}, s)
+		vID, log, evalNextSegment, contextErr := evalSegment(&f, models.EvalContext{}, s, time.Now())
 
 		assert.Nil(t, vID)
 		assert.NotEmpty(t, log)
 		assert.True(t, evalNextSegment)
+		assert.Error(t, contextErr)
 	})
 
 	t.Run("test happy code path", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
 		s := entity.GenFixtureSegment()
 		s.RolloutPercent = uint(100)
-		vID, log, evalNextSegment := evalSegment(100, models.EvalContext{
+		vID, log, evalNextSegment, _ := evalSegment(&f, models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA"},
 			EntityID:      "entityID1",
 			EntityType:    "entityType1",
 			FlagID:        int64(100),
-		}, s)
+		}, s, time.Now())
 
 		assert.NotNil(t, vID)
 		assert.NotEmpty(t, log)
@@ -39,47 +52,244 @@ func TestEvalSegment(t *testing.T) {
 	})
 
 	t.Run("test constraint evaluation error", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
 		s := entity.GenFixtureSegment()
 		s.RolloutPercent = uint(100)
-		vID, log, evalNextSegment := evalSegment(100, models.EvalContext{
+		vID, log, evalNextSegment, contextErr := evalSegment(&f, models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{},
 			EntityID:      "entityID1",
 			EntityType:    "entityType1",
 			FlagID:        int64(100),
-		}, s)
+		}, s, time.Now())
 
 		assert.Nil(t, vID)
 		assert.NotEmpty(t, log)
 		assert.True(t, evalNextSegment)
+		assert.Error(t, contextErr)
+	})
+
+	t.Run("test nested property", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		s := entity.GenFixtureSegment()
+		s.RolloutPercent = uint(100)
+		s.Constraints = []entity.Constraint{{Property: "device.os", Operator: models.ConstraintOperatorEQ, Value: `"ios"`}}
+		assert.NoError(t, s.PrepareEvaluation())
+
+		for _, tc := range []struct {
+			entityContext map[string]interface{}
+			matched       bool
+		}{
+			{map[string]interface{}{"device": map[string]interface{}{"os": "ios"}}, true},
+			{map[string]interface{}{"device": map[string]interface{}{"os": "android"}}, false},
+			{map[string]interface{}{"device": map[string]interface{}{}}, false},
+			{map[string]interface{}{"device": "ios"}, false},
+			{map[string]interface{}{}, false},
+		} {
+			vID, log, evalNextSegment, _ := evalSegment(&f, models.EvalContext{
+				EnableDebug:   true,
+				EntityContext: tc.entityContext,
+				EntityID:      "entityID1",
+				FlagID:        int64(100),
+			}, s, time.Now())
+			assert.Equal(t, tc.matched, vID != nil, "%v", tc.entityContext)
+			assert.Equal(t, tc.matched, log.Matched)
+			assert.Equal(t, !tc.matched, evalNextSegment)
+		}
+	})
+
+	t.Run("test IN and NOT_IN constraints of numbers", func(t *testing.T) {
+		for _, op := range []string{models.ConstraintOperatorIN, models.ConstraintOperatorNOTIN} {
+			f := entity.GenFixtureFlag()
+			s := entity.GenFixtureSegment()
+			s.RolloutPercent = uint(100)
+			s.Constraints = []entity.Constraint{{Property: "account_id", Operator: op, Value: "[1, 2, 3]"}}
+			assert.NoError(t, s.PrepareEvaluation())
+
+			in := op == models.ConstraintOperatorIN
+			for _, tc := range []struct {
+				entityContext map[string]interface{}
+				matched       bool
+				err           bool
+			}{
+				{map[string]interface{}{"account_id": float64(4)}, !in, false},
+				{map[string]interface{}{"account_id": float64(2)}, in, false},
+				{map[string]interface{}{"account_id": "2"}, false, true},
+				{map[string]interface{}{}, false, true},
+			} {
+				vID, _, _, contextErr := evalSegment(&f, models.EvalContext{
+					EntityContext: tc.entityContext,
+					EntityID:      "entityID1",
+					FlagID:        int64(100),
+				}, s, time.Now())
+				assert.Equal(t, tc.matched, vID != nil, "%s %v", op, tc.entityContext)
+				assert.Equal(t, tc.err, contextErr != nil, "%s %v", op, tc.entityContext)
+				assert.NotContains(t, tc.entityContext, s.SegmentEvaluation.NumberSets[0].Key)
+			}
+		}
+	})
+
+	t.Run("test existence constraints", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		s := entity.GenFixtureSegment()
+		s.RolloutPercent = uint(100)
+		s.Constraints = []entity.Constraint{
+			{Property: "dl_state", Operator: models.ConstraintOperatorEXISTS},
+			{Property: "device.jailbroken", Operator: models.ConstraintOperatorNOTEXISTS},
+		}
+		assert.NoError(t, s.PrepareEvaluation())
+
+		for _, tc := range []struct {
+			entityContext map[string]interface{}
+			matched       bool
+		}{
+			{map[string]interface{}{"dl_state": ""}, true},
+			{map[string]interface{}{"dl_state": "CA", "device": map[string]interface{}{"jailbroken": nil}}, true},
+			{map[string]interface{}{"dl_state": "CA", "device": map[string]interface{}{"jailbroken": false}}, false},
+			{map[string]interface{}{"dl_state": nil}, false},
+			{map[string]interface{}{}, false},
+		} {
+			vID, log, _, _ := evalSegment(&f, models.EvalContext{
+				EnableDebug:   true,
+				EntityContext: tc.entityContext,
+				EntityID:      "entityID1",
+				FlagID:        int64(100),
+			}, s, time.Now())
+			assert.Equal(t, tc.matched, vID != nil, "%v", tc.entityContext)
+			assert.Equal(t, tc.matched, log.Matched)
+		}
 	})
 
 	t.Run("test constraint not match", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
 		s := entity.GenFixtureSegment()
 		s.RolloutPercent = uint(100)
-		vID, log, evalNextSegment := evalSegment(100, models.EvalContext{
+		vID, log, evalNextSegment, _ := evalSegment(&f, models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "NY"},
 			EntityID:      "entityID1",
 			EntityType:    "entityType1",
 			FlagID:        int64(100),
-		}, s)
+		}, s, time.Now())
 
 		assert.Nil(t, vID)
 		assert.NotEmpty(t, log)
 		assert.True(t, evalNextSegment)
 	})
 
+	t.Run("test bucketingSeed", func(t *testing.T) {
+		evalContext := models.EvalContext{
+			EnableDebug:   true,
+			EntityContext: map[string]interface{}{"dl_state": "CA"},
+			EntityID:      "entityID1",
+			EntityType:    "entityType1",
+			FlagID:        int64(100),
+		}
+		s := entity.GenFixtureSegment()
+
+		f1 := entity.GenFixtureFlag()
+		_, log1, _, _ := evalSegment(&f1, evalContext, s, time.Now())
+
+		f2 := entity.GenFixtureFlag()
+		f2.BucketingSeed = "seed1"
+		_, log2, _, _ := evalSegment(&f2, evalContext, s, time.Now())
+
+		assert.NotEqual(t, log1.Msg, log2.Msg)
+	})
+
+	t.Run("test bucketingAlgorithm", func(t *testing.T) {
+		evalContext := models.EvalContext{
+			EnableDebug:   true,
+			EntityContext: map[string]interface{}{"dl_state": "CA"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		}
+		s := entity.GenFixtureSegment()
+
+		f1 := entity.GenFixtureFlag()
+		_, log1, _, _ := evalSegment(&f1, evalContext, s, time.Now())
+
+		f2 := entity.GenFixtureFlag()
+		f2.BucketingAlgorithm = entity.BucketingAlgorithmXXHash
+		_, log2, _, _ := evalSegment(&f2, evalContext, s, time.Now())
+
+		assert.NotEqual(t, log1.Msg, log2.Msg)
+	})
+
+	t.Run("test bucketBy", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		f.BucketBy = "accountID"
+		s := entity.GenFixtureSegment()
+
+		var firstVID *uint
+		for i := 0; i < 100; i++ {
+			vID, _, _, _ := evalSegment(&f, models.EvalContext{
+				EntityContext: map[string]interface{}{"dl_state": "CA", "accountID": "account1"},
+				EntityID:      fmt.Sprintf("entityID%d", i),
+				FlagID:        int64(100),
+			}, s, time.Now())
+			assert.NotNil(t, vID)
+			if firstVID == nil {
+				firstVID = vID
+			}
+			assert.Equal(t, *firstVID, *vID)
+		}
+	})
+
+	t.Run("test bucketBy falls back to entityID", func(t *testing.T) {
+		evalContext := models.EvalContext{
+			EnableDebug:   true,
+			EntityContext: map[string]interface{}{"dl_state": "CA"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		}
+		s := entity.GenFixtureSegment()
+
+		f1 := entity.GenFixtureFlag()
+		_, log1, _, _ := evalSegment(&f1, evalContext, s, time.Now())
+
+		f2 := entity.GenFixtureFlag()
+		f2.BucketBy = "accountID"
+		_, log2, _, _ := evalSegment(&f2, evalContext, s, time.Now())
+
+		assert.Equal(t, log1.Msg, log2.Msg)
+	})
+
+	t.Run("test time-window constraint", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		s := entity.GenFixtureSegment()
+		s.Constraints = append(s.Constraints, entity.Constraint{
+			Property: "now",
+			Operator: models.ConstraintOperatorBETWEEN,
+			Value:    `["2019-01-01T00:00:00Z", "2019-02-01T00:00:00Z"]`,
+		})
+		s.PrepareEvaluation()
+		evalContext := models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "CA"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		}
+
+		vID, _, _, _ := evalSegment(&f, evalContext, s, time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, vID)
+
+		vID, _, evalNextSegment, _ := evalSegment(&f, evalContext, s, time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, vID)
+		assert.True(t, evalNextSegment)
+		assert.NotContains(t, evalContext.EntityContext, entity.EvalTimeProperty)
+	})
+
 	t.Run("test evalContext wrong format", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
 		s := entity.GenFixtureSegment()
 		s.RolloutPercent = uint(100)
-		vID, log, evalNextSegment := evalSegment(100, models.EvalContext{
+		vID, log, evalNextSegment, _ := evalSegment(&f, models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: nil,
 			EntityID:      "entityID1",
 			EntityType:    "entityType1",
 			FlagID:        int64(100),
-		}, s)
+		}, s, time.Now())
 
 		assert.Nil(t, vID)
 		assert.NotEmpty(t, log)
@@ -92,7 +302,7 @@ func TestEvalFlag(t *testing.T) {
 
 	t.Run("test empty evalContext", func(t *testing.T) {
 		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
-		result := evalFlag(models.EvalContext{FlagID: int64(100)})
+		result := evalFlag(context.Background(), "", models.EvalContext{FlagID: int64(100)})
 		assert.Zero(t, result.VariantID)
 		assert.NotZero(t, result.FlagID)
 		assert.NotEmpty(t, result.EvalContext.EntityID)
@@ -100,7 +310,7 @@ func TestEvalFlag(t *testing.T) {
 
 	t.Run("test happy code path", func(t *testing.T) {
 		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
-		result := evalFlag(models.EvalContext{
+		result := evalFlag(context.Background(), "", models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA"},
 			EntityID:      "entityID1",
@@ -113,7 +323,7 @@ func TestEvalFlag(t *testing.T) {
 
 	t.Run("test happy code path with flagKey", func(t *testing.T) {
 		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
-		result := evalFlag(models.EvalContext{
+		result := evalFlag(context.Background(), "", models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA"},
 			EntityID:      "entityID1",
@@ -126,7 +336,7 @@ func TestEvalFlag(t *testing.T) {
 
 	t.Run("test happy code path with flagKey", func(t *testing.T) {
 		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
-		result := evalFlag(models.EvalContext{
+		result := evalFlag(context.Background(), "", models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA"},
 			EntityID:      "entityID1",
@@ -165,7 +375,7 @@ func TestEvalFlag(t *testing.T) {
 		f.PrepareEvaluation()
 		cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
 		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
-		result := evalFlag(models.EvalContext{
+		result := evalFlag(context.Background(), "", models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA", "state": "CA", "rate": 2000},
 			EntityID:      "entityID1",
@@ -185,7 +395,7 @@ func TestEvalFlag(t *testing.T) {
 		f.PrepareEvaluation()
 		cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
 		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
-		result := evalFlag(models.EvalContext{
+		result := evalFlag(context.Background(), "", models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA", "state": "CA", "rate": 2000},
 			EntityID:      "entityID1",
@@ -217,7 +427,7 @@ func TestEvalFlag(t *testing.T) {
 		f.PrepareEvaluation()
 		cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
 		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
-		result := evalFlag(models.EvalContext{
+		result := evalFlag(context.Background(), "", models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA", "state": "NY"},
 			EntityID:      "entityID1",
@@ -228,12 +438,155 @@ func TestEvalFlag(t *testing.T) {
 		assert.Zero(t, result.VariantID)
 	})
 
+	t.Run("test default variant when no segment matches", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		f.DefaultVariantID = 301
+		cache := &EvalCache{
+			idCache:  map[string]*entity.Flag{"100": &f},
+			keyCache: map[string]*entity.Flag{"flag_key_100": &f},
+		}
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := evalFlag(context.Background(), "", models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "NY"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		})
+		assert.Equal(t, int64(301), result.VariantID)
+		assert.Equal(t, "treatment", result.VariantKey)
+		assert.NotEmpty(t, result.EvalContext.EntityID)
+		assert.True(t, result.IsDefaultVariant)
+	})
+
+	t.Run("test flag override", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		f.OverrideEnabled = true
+		f.OverrideVariantID = 301
+		cache := &EvalCache{
+			idCache:  map[string]*entity.Flag{"100": &f},
+			keyCache: map[string]*entity.Flag{"flag_key_100": &f},
+		}
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := evalFlag(context.Background(), "", models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "NY"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		})
+		assert.Equal(t, int64(301), result.VariantID)
+		assert.Equal(t, "treatment", result.VariantKey)
+		assert.NotEmpty(t, result.EvalContext.EntityID)
+		assert.True(t, result.IsOverridden)
+		assert.Equal(t, EvalReasonOverridden, result.EvalDebugLog.Reason)
+
+		f.OverrideEnabled = false
+		result = evalFlag(context.Background(), "", models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "NY"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		})
+		assert.False(t, result.IsOverridden)
+		assert.NotEqual(t, EvalReasonOverridden, result.EvalDebugLog.Reason)
+
+		f.OverrideEnabled = true
+		f.Enabled = false
+		result = evalFlag(context.Background(), "", models.EvalContext{
+			EntityID: "entityID1",
+			FlagID:   int64(100),
+		})
+		assert.Zero(t, result.VariantID)
+		assert.Equal(t, EvalReasonFlagDisabled, result.EvalDebugLog.Reason)
+		assert.Equal(t, models.EvalResultErrorCodeFLAGDISABLED, result.ErrorCode)
+	})
+
+	t.Run("test includeAllMatches", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		everyone := entity.GenFixtureSegment()
+		everyone.ID = 201
+		everyone.Rank = 1
+		everyone.Description = "everyone"
+		everyone.Constraints = []entity.Constraint{}
+		ny := entity.GenFixtureSegment()
+		ny.ID = 202
+		ny.Rank = 2
+		ny.Constraints[0].Value = `"NY"`
+		f.Segments = append(f.Segments, everyone, ny)
+		f.PrepareEvaluation()
+		cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+
+		evalContext := models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "CA"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		}
+		result := evalFlag(context.Background(), "", evalContext)
+		assert.Nil(t, result.MatchedSegments)
+
+		evalContext.IncludeAllMatches = true
+		result = evalFlag(context.Background(), "", evalContext)
+		assert.Equal(t, int64(200), result.SegmentID)
+		assert.NotZero(t, result.VariantID)
+		assert.Equal(t, []*models.MatchedSegment{
+			{SegmentID: 200},
+			{SegmentID: 201, Rank: 1, Description: "everyone"},
+		}, result.MatchedSegments)
+		assert.Empty(t, result.EvalDebugLog.SegmentDebugLogs)
+
+		evalContext.EntityContext = map[string]interface{}{"dl_state": "NY"}
+		result = evalFlag(context.Background(), "", evalContext)
+		assert.Equal(t, int64(201), result.SegmentID)
+		assert.Equal(t, []*models.MatchedSegment{
+			{SegmentID: 201, Rank: 1, Description: "everyone"},
+			{SegmentID: 202, Rank: 2},
+		}, result.MatchedSegments)
+	})
+
+	t.Run("test typed variant attachment", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		for i := range f.Variants {
+			f.Variants[i].Attachment = entity.Attachment{"value": "321", "enabled": "true"}
+			f.Variants[i].AttachmentTypes = entity.AttachmentTypes{"value": "integer", "enabled": "boolean"}
+		}
+		f.PrepareEvaluation()
+		cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+
+		evalContext := models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "CA"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		}
+		result := evalFlag(context.Background(), "", evalContext)
+		assert.NotZero(t, result.VariantID)
+		assert.Equal(t, map[string]interface{}{"value": int64(321), "enabled": true}, result.TypedVariantAttachment)
+		assert.Empty(t, result.VariantAttachmentError)
+
+		for i := range f.Variants {
+			f.Variants[i].AttachmentTypes["value"] = "boolean"
+		}
+		result = evalFlag(context.Background(), "", evalContext)
+		assert.Nil(t, result.TypedVariantAttachment)
+		assert.Contains(t, result.VariantAttachmentError, "attachment key value doesn't conform to type boolean")
+	})
+
+	t.Run("test the flag of another namespace", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		f.Namespace = "checkout"
+		idCache := mapCache{"100": &f}
+		cache := &EvalCache{idCache: idCache, namespaceCaches: newNamespaceCaches(idCache)}
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+
+		evalContext := models.EvalContext{EntityID: "entityID1", FlagID: int64(100)}
+		assert.Equal(t, EvalReasonFlagNotFound, evalFlag(context.Background(), "search", evalContext).EvalDebugLog.Reason)
+		assert.NotEqual(t, EvalReasonFlagNotFound, evalFlag(context.Background(), "checkout", evalContext).EvalDebugLog.Reason)
+	})
+
 	t.Run("test enabled=false", func(t *testing.T) {
 		f := entity.GenFixtureFlag()
 		f.Enabled = false
 		cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
 		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
-		result := evalFlag(models.EvalContext{
+		result := evalFlag(context.Background(), "", models.EvalContext{
 			EnableDebug:   true,
 			EntityContext: map[string]interface{}{"dl_state": "CA"},
 			EntityID:      "entityID1",
@@ -250,7 +603,7 @@ func TestEvalFlag(t *testing.T) {
 			f.EntityType = ""
 			cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
 			defer gostub.StubFunc(&GetEvalCache, cache).Reset()
-			result := evalFlag(models.EvalContext{
+			result := evalFlag(context.Background(), "", models.EvalContext{
 				EnableDebug:   true,
 				EntityContext: map[string]interface{}{"dl_state": "CA"},
 				EntityID:      "entityID1",
@@ -266,7 +619,7 @@ func TestEvalFlag(t *testing.T) {
 			f.EntityType = "some_entity_type"
 			cache := &EvalCache{idCache: map[string]*entity.Flag{"100": &f}}
 			defer gostub.StubFunc(&GetEvalCache, cache).Reset()
-			result := evalFlag(models.EvalContext{
+			result := evalFlag(context.Background(), "", models.EvalContext{
 				EnableDebug:   true,
 				EntityContext: map[string]interface{}{"dl_state": "CA"},
 				EntityID:      "entityID1",
@@ -305,6 +658,50 @@ func TestPostEvaluation(t *testing.T) {
 	})
 }
 
+func TestGetEvaluation(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	cache := &EvalCache{idCache: mapCache{util.SafeString(f.ID): &f}, keyCache: mapCache{f.Key: &f}}
+
+	defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+	var logged *models.EvalResult
+	defer gostub.Stub(&logEvalResult, func(r *models.EvalResult, dataRecordsEnabled bool) { logged = r }).Reset()
+	e := NewEval()
+
+	t.Run("test the evaluation context in the query", func(t *testing.T) {
+		resp := e.GetEvaluation(evaluation.GetEvaluationParams{
+			FlagKey:       util.StringPtr(f.Key),
+			EntityID:      util.StringPtr("entityID1"),
+			EntityContext: util.StringPtr(`{"dl_state":"CA","age":30}`),
+			EnableDebug:   util.BoolPtr(true),
+		})
+		result := resp.(*evaluation.GetEvaluationOK).Payload
+		assert.Equal(t, int64(100), result.FlagID)
+		assert.NotZero(t, result.VariantID)
+		assert.Equal(t, "entityID1", result.EvalContext.EntityID)
+		assert.Equal(t, float64(30), result.EvalContext.EntityContext.(map[string]interface{})["age"])
+		assert.NotNil(t, result.EvalDebugLog)
+		assert.Equal(t, result, logged)
+	})
+
+	t.Run("test the flagID without the entity context", func(t *testing.T) {
+		resp := e.GetEvaluation(evaluation.GetEvaluationParams{FlagID: util.Int64Ptr(100)})
+		result := resp.(*evaluation.GetEvaluationOK).Payload
+		assert.Equal(t, int64(100), result.FlagID)
+		assert.NotEmpty(t, result.EvalContext.EntityID)
+	})
+
+	t.Run("test the entity context that is not a JSON object", func(t *testing.T) {
+		for _, entityContext := range []string{`{"dl_state":`, `["CA"]`} {
+			resp := e.GetEvaluation(evaluation.GetEvaluationParams{
+				FlagID:        util.Int64Ptr(100),
+				EntityContext: util.StringPtr(entityContext),
+			})
+			payload := resp.(*evaluation.GetEvaluationDefault).Payload
+			assert.Equal(t, models.ErrorErrorCodeINVALIDREQUEST, payload.ErrorCode)
+		}
+	})
+}
+
 func TestPostEvaluationBatch(t *testing.T) {
 	t.Run("test happy code path", func(t *testing.T) {
 		defer gostub.StubFunc(&evalFlag, &models.EvalResult{}).Reset()
@@ -325,6 +722,478 @@ func TestPostEvaluationBatch(t *testing.T) {
 		})
 		assert.NotNil(t, resp)
 	})
+
+	t.Run("test it stops evaluating once the request is done", func(t *testing.T) {
+		defer gostub.StubFunc(&evalFlag, &models.EvalResult{}).Reset()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, _ := http.NewRequest("POST", "/api/v1/evaluation/batch", nil)
+		e := NewEval()
+		resp := e.PostEvaluationBatch(evaluation.PostEvaluationBatchParams{
+			HTTPRequest: req.WithContext(ctx),
+			Body: &models.EvaluationBatchRequest{
+				Entities: []*models.EvaluationEntity{{EntityID: "entityID1"}},
+				FlagIds:  []int64{100, 200},
+			},
+		})
+		assert.Empty(t, resp.(*evaluation.PostEvaluationBatchOK).Payload.EvaluationResults)
+	})
+}
+
+func TestPostEvaluationByTag(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	f.Tags = []entity.Tag{{Value: "checkout"}}
+	idCache := mapCache{util.SafeString(f.ID): &f}
+	cache := &EvalCache{idCache: idCache, tagCache: newTagCache(idCache)}
+
+	defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	e := NewEval()
+	genParams := func(tag string) evaluation.PostEvaluationByTagParams {
+		return evaluation.PostEvaluationByTagParams{
+			Body: &models.EvaluationByTagRequest{
+				Tag: util.StringPtr(tag),
+				Entity: &models.EvaluationEntity{
+					EntityContext: map[string]interface{}{"dl_state": "CA"},
+					EntityID:      "entityID1",
+				},
+			},
+		}
+	}
+
+	t.Run("test the flags carrying the tag", func(t *testing.T) {
+		resp := e.PostEvaluationByTag(genParams("checkout"))
+		results := resp.(*evaluation.PostEvaluationByTagOK).Payload.EvaluationResults
+		assert.Len(t, results, 1)
+		assert.Equal(t, int64(100), results[0].FlagID)
+		assert.NotZero(t, results[0].VariantID)
+	})
+
+	t.Run("test no flag carrying the tag", func(t *testing.T) {
+		resp := e.PostEvaluationByTag(genParams("billing"))
+		results := resp.(*evaluation.PostEvaluationByTagOK).Payload.EvaluationResults
+		assert.NotNil(t, results)
+		assert.Len(t, results, 0)
+	})
+}
+
+func TestEvalFlagWithPrerequisite(t *testing.T) {
+	genCache := func(expectedVariantKey string) (*EvalCache, *entity.Flag, *entity.Flag) {
+		parent := entity.GenFixtureFlag()
+		dependent := entity.GenFixtureFlag()
+		dependent.ID = 101
+		dependent.Key = "flag_key_101"
+		dependent.PrerequisiteFlagID = 100
+		dependent.PrerequisiteVariantKey = expectedVariantKey
+		dependent.PrepareEvaluation()
+		cache := &EvalCache{
+			idCache:  map[string]*entity.Flag{"100": &parent, "101": &dependent},
+			keyCache: map[string]*entity.Flag{parent.Key: &parent, dependent.Key: &dependent},
+		}
+		return cache, &parent, &dependent
+	}
+	evalContext := models.EvalContext{
+		EntityContext: map[string]interface{}{"dl_state": "CA"},
+		EntityID:      "entityID1",
+		FlagID:        int64(101),
+	}
+
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	cache, _, _ := genCache("")
+	defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+	parentVariantKey := evalFlag(context.Background(), "", models.EvalContext{
+		EntityContext: evalContext.EntityContext,
+		EntityID:      evalContext.EntityID,
+		FlagID:        int64(100),
+	}).VariantKey
+	otherVariantKey := "control"
+	if parentVariantKey == "control" {
+		otherVariantKey = "treatment"
+	}
+
+	t.Run("test prerequisite met with any variant", func(t *testing.T) {
+		result := evalFlag(context.Background(), "", evalContext)
+		assert.NotZero(t, result.VariantID)
+		assert.Equal(t, EvalReasonSegmentMatched, result.EvalDebugLog.Reason)
+	})
+
+	t.Run("test prerequisite met with the expected variant", func(t *testing.T) {
+		cache, _, _ := genCache(parentVariantKey)
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := evalFlag(context.Background(), "", evalContext)
+		assert.NotZero(t, result.VariantID)
+		assert.Equal(t, EvalReasonSegmentMatched, result.EvalDebugLog.Reason)
+	})
+
+	t.Run("test prerequisite not met", func(t *testing.T) {
+		cache, _, _ := genCache(otherVariantKey)
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := evalFlag(context.Background(), "", evalContext)
+		assert.Zero(t, result.VariantID)
+		assert.NotEmpty(t, result.EvalContext.EntityID)
+		assert.Equal(t, EvalReasonPrerequisiteNotMet, result.EvalDebugLog.Reason)
+	})
+
+	t.Run("test prerequisite not met falls back to the default variant", func(t *testing.T) {
+		cache, _, dependent := genCache(otherVariantKey)
+		dependent.DefaultVariantID = 301
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := evalFlag(context.Background(), "", evalContext)
+		assert.Equal(t, int64(301), result.VariantID)
+		assert.True(t, result.IsDefaultVariant)
+		assert.Equal(t, EvalReasonPrerequisiteNotMet, result.EvalDebugLog.Reason)
+	})
+
+	t.Run("test prerequisite disabled", func(t *testing.T) {
+		cache, parent, _ := genCache("")
+		parent.Enabled = false
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := explainFlag(context.Background(), "", evalContext)
+		assert.Zero(t, result.VariantID)
+		assert.Equal(t, EvalReasonPrerequisiteNotMet, result.EvalDebugLog.Reason)
+		assert.False(t, result.EvalDebugLog.PrerequisiteDebugLog.Met)
+		assert.Contains(t, result.EvalDebugLog.PrerequisiteDebugLog.Msg, EvalReasonFlagDisabled)
+	})
+
+	t.Run("test prerequisite in the explain output", func(t *testing.T) {
+		cache, _, _ := genCache(parentVariantKey)
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := explainFlag(context.Background(), "", evalContext)
+		log := result.EvalDebugLog.PrerequisiteDebugLog
+		assert.True(t, log.Met)
+		assert.Equal(t, int64(100), log.FlagID)
+		assert.Equal(t, "flag_key_100", log.FlagKey)
+		assert.Equal(t, parentVariantKey, log.ExpectedVariantKey)
+		assert.Equal(t, parentVariantKey, log.VariantKey)
+	})
+
+	t.Run("test prerequisite cycle", func(t *testing.T) {
+		cache, parent, _ := genCache("")
+		parent.PrerequisiteFlagID = 101
+		defer gostub.StubFunc(&GetEvalCache, cache).Reset()
+		result := evalFlag(context.Background(), "", evalContext)
+		assert.Zero(t, result.VariantID)
+		assert.Equal(t, EvalReasonPrerequisiteNotMet, result.EvalDebugLog.Reason)
+	})
+}
+
+func TestLogEvalVariantToPrometheus(t *testing.T) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "eval_variants_total"}, []string{"flag_key", "variant_key"})
+	defer gostub.Stub(&config.Global.Prometheus.EvalVariantCounter, counter).Reset()
+	r := &models.EvalResult{FlagKey: "flag_key_100", VariantKey: "control"}
+
+	logEvalVariantToPrometheus(r, 2)
+	logEvalVariantToPrometheus(r, 2)
+	assert.Equal(t, float64(2), testutil.ToFloat64(counter.WithLabelValues("flag_key_100", "control")))
+
+	t.Run("it skips the flags with too many variants", func(t *testing.T) {
+		logEvalVariantToPrometheus(r, config.Config.EvalMetricsMaxVariants+1)
+		assert.Equal(t, float64(2), testutil.ToFloat64(counter.WithLabelValues("flag_key_100", "control")))
+	})
+
+	t.Run("it counts the results without a variant", func(t *testing.T) {
+		logEvalVariantToPrometheus(&models.EvalResult{FlagKey: "flag_key_100"}, 2)
+		assert.Equal(t, float64(1), testutil.ToFloat64(counter.WithLabelValues("flag_key_100", "null")))
+	})
+}
+
+func TestLogEvalDurationToPrometheus(t *testing.T) {
+	defer gostub.StubFunc(&logEvalResult).Reset()
+	defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "eval_duration_seconds"}, []string{"flag_key"})
+	defer gostub.Stub(&config.Global.Prometheus.EvalDuration, histogram).Reset()
+
+	sampleCount := func() uint64 {
+		m := &dto.Metric{}
+		histogram.WithLabelValues("flag_key_100").(prometheus.Histogram).Write(m)
+		return m.Histogram.GetSampleCount()
+	}
+	evalContext := models.EvalContext{
+		EntityContext: map[string]interface{}{"dl_state": "CA"},
+		EntityID:      "entityID1",
+		FlagID:        int64(100),
+	}
+
+	evalFlag(context.Background(), "", evalContext)
+	assert.Equal(t, uint64(1), sampleCount())
+
+	t.Run("it doesn't observe the explained evaluations", func(t *testing.T) {
+		explainFlag(context.Background(), "", evalContext)
+		assert.Equal(t, uint64(1), sampleCount())
+	})
+}
+
+func TestExplainFlag(t *testing.T) {
+	t.Run("test happy code path", func(t *testing.T) {
+		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+		logged := false
+		defer gostub.Stub(&logEvalResult, func(*models.EvalResult, bool) { logged = true }).Reset()
+
+		result := explainFlag(context.Background(), "", models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "CA"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		})
+		assert.False(t, logged)
+		assert.NotZero(t, result.VariantID)
+		assert.Equal(t, EvalReasonSegmentMatched, result.EvalDebugLog.Reason)
+		assert.Empty(t, result.ErrorCode)
+
+		assert.Len(t, result.EvalDebugLog.SegmentDebugLogs, 1)
+		segmentLog := result.EvalDebugLog.SegmentDebugLogs[0]
+		assert.True(t, segmentLog.Matched)
+		assert.Len(t, segmentLog.ConstraintDebugLogs, 1)
+		assert.True(t, segmentLog.ConstraintDebugLogs[0].Matched)
+		assert.Equal(t, "dl_state", segmentLog.ConstraintDebugLogs[0].Property)
+	})
+
+	t.Run("test no match path", func(t *testing.T) {
+		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+		defer gostub.StubFunc(&logEvalResult).Reset()
+
+		result := explainFlag(context.Background(), "", models.EvalContext{
+			EntityContext: map[string]interface{}{"dl_state": "NY"},
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		})
+		assert.Zero(t, result.VariantID)
+		assert.Equal(t, EvalReasonNoSegmentMatched, result.EvalDebugLog.Reason)
+		assert.Equal(t, models.EvalResultErrorCodeNOSEGMENTMATCH, result.ErrorCode)
+
+		assert.Len(t, result.EvalDebugLog.SegmentDebugLogs, 1)
+		segmentLog := result.EvalDebugLog.SegmentDebugLogs[0]
+		assert.False(t, segmentLog.Matched)
+		assert.Len(t, segmentLog.ConstraintDebugLogs, 1)
+		assert.False(t, segmentLog.ConstraintDebugLogs[0].Matched)
+	})
+
+	t.Run("test flag not found", func(t *testing.T) {
+		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+		result := explainFlag(context.Background(), "", models.EvalContext{FlagID: int64(999)})
+		assert.Equal(t, EvalReasonFlagNotFound, result.EvalDebugLog.Reason)
+		assert.Equal(t, models.EvalResultErrorCodeFLAGNOTFOUND, result.ErrorCode)
+	})
+
+	t.Run("test invalid entity context", func(t *testing.T) {
+		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+		result := explainFlag(context.Background(), "", models.EvalContext{
+			EntityContext: "dl_state=CA",
+			EntityID:      "entityID1",
+			FlagID:        int64(100),
+		})
+		assert.Zero(t, result.VariantID)
+		assert.Equal(t, models.EvalResultErrorCodeINVALIDCONTEXT, result.ErrorCode)
+	})
+}
+
+func TestPostEvaluationExplain(t *testing.T) {
+	t.Run("test empty body", func(t *testing.T) {
+		defer gostub.StubFunc(&explainFlag, &models.EvalResult{}).Reset()
+		e := NewEval()
+		resp := e.PostEvaluationExplain(evaluation.PostEvaluationExplainParams{})
+		assert.Equal(t, models.ErrorErrorCodeINVALIDREQUEST, resp.(*evaluation.PostEvaluationExplainDefault).Payload.ErrorCode)
+	})
+
+	t.Run("test debugging disabled", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.EvalDebugEnabled, false).Reset()
+		e := NewEval()
+		resp := e.PostEvaluationExplain(evaluation.PostEvaluationExplainParams{Body: &models.EvalContext{FlagID: int64(100)}})
+		assert.Equal(t, models.ErrorErrorCodeEVALDEBUGDISABLED, resp.(*evaluation.PostEvaluationExplainDefault).Payload.ErrorCode)
+	})
+
+	t.Run("test happy code path", func(t *testing.T) {
+		defer gostub.StubFunc(&explainFlag, &models.EvalResult{}).Reset()
+		e := NewEval()
+		resp := e.PostEvaluationExplain(evaluation.PostEvaluationExplainParams{
+			Body: &models.EvalContext{
+				EntityContext: map[string]interface{}{"dl_state": "CA"},
+				EntityID:      "entityID1",
+				FlagID:        int64(100),
+			},
+		})
+		assert.IsType(t, &evaluation.PostEvaluationExplainOK{}, resp)
+	})
+}
+
+func TestPostEvaluationCacheRefresh(t *testing.T) {
+	t.Run("test happy code path", func(t *testing.T) {
+		defer gostub.StubFunc(&refreshEvalCache, 2, 5*time.Millisecond, nil).Reset()
+		e := NewEval()
+		resp := e.PostEvaluationCacheRefresh(evaluation.PostEvaluationCacheRefreshParams{})
+		payload := resp.(*evaluation.PostEvaluationCacheRefreshOK).Payload
+		assert.Equal(t, int64(2), *payload.FlagsCount)
+		assert.Equal(t, int64(5), *payload.DurationMillis)
+	})
+
+	t.Run("test refresh error", func(t *testing.T) {
+		defer gostub.StubFunc(&refreshEvalCache, 0, time.Duration(0), fmt.Errorf("refresh error")).Reset()
+		e := NewEval()
+		resp := e.PostEvaluationCacheRefresh(evaluation.PostEvaluationCacheRefreshParams{})
+		assert.Equal(t, models.ErrorErrorCodeEVALCACHEREFRESHFAILED, resp.(*evaluation.PostEvaluationCacheRefreshDefault).Payload.ErrorCode)
+	})
+}
+
+func TestEvaluateConstraint(t *testing.T) {
+	t.Run("test empty body", func(t *testing.T) {
+		e := NewEval()
+		resp := e.EvaluateConstraint(constraint.EvaluateConstraintParams{})
+		assert.IsType(t, &constraint.EvaluateConstraintDefault{}, resp)
+	})
+
+	t.Run("test happy code path", func(t *testing.T) {
+		e := NewEval()
+		resp := e.EvaluateConstraint(constraint.EvaluateConstraintParams{
+			Body: &models.EvaluateConstraintRequest{
+				Constraint: &models.CreateConstraintRequest{
+					Property: util.StringPtr("dl_state"),
+					Operator: util.StringPtr(models.ConstraintOperatorEQ),
+					Value:    util.StringPtr(`"CA"`),
+				},
+				EntityContexts: []interface{}{
+					map[string]interface{}{"dl_state": "CA"},
+					map[string]interface{}{"dl_state": "NY"},
+				},
+			},
+		})
+		results := resp.(*constraint.EvaluateConstraintOK).Payload.Results
+		assert.Len(t, results, 2)
+		assert.True(t, results[0].Passed)
+		assert.False(t, results[1].Passed)
+		assert.Empty(t, results[1].Error)
+	})
+}
+
+func TestEvalConstraint(t *testing.T) {
+	t.Run("test parse error", func(t *testing.T) {
+		c := entity.Constraint{Property: "dl_state", Operator: models.ConstraintOperatorEQ, Value: "CA"}
+		results := evalConstraint(c, []interface{}{
+			map[string]interface{}{"dl_state": "CA"},
+			map[string]interface{}{"dl_state": "NY"},
+		}, time.Now())
+		assert.Len(t, results, 2)
+		for _, r := range results {
+			assert.False(t, r.Passed)
+			assert.NotEmpty(t, r.Error)
+		}
+	})
+
+	t.Run("test invalid entity context", func(t *testing.T) {
+		c := entity.Constraint{Property: "dl_state", Operator: models.ConstraintOperatorEQ, Value: `"CA"`}
+		results := evalConstraint(c, []interface{}{"CA"}, time.Now())
+		assert.False(t, results[0].Passed)
+		assert.NotEmpty(t, results[0].Error)
+	})
+
+	t.Run("test evaluation error", func(t *testing.T) {
+		c := entity.Constraint{Property: "age", Operator: models.ConstraintOperatorGT, Value: "18"}
+		results := evalConstraint(c, []interface{}{map[string]interface{}{"age": "old"}}, time.Now())
+		assert.False(t, results[0].Passed)
+		assert.NotEmpty(t, results[0].Error)
+	})
+
+	t.Run("test time-window constraint", func(t *testing.T) {
+		c := entity.Constraint{
+			Property: "now",
+			Operator: models.ConstraintOperatorBETWEEN,
+			Value:    `["2019-01-01T00:00:00Z", "2019-02-01T00:00:00Z"]`,
+		}
+		entityContext := map[string]interface{}{"dl_state": "CA"}
+		results := evalConstraint(c, []interface{}{entityContext}, time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC))
+		assert.True(t, results[0].Passed)
+		assert.NotContains(t, entityContext, entity.EvalTimeProperty)
+
+		results = evalConstraint(c, []interface{}{entityContext}, time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))
+		assert.False(t, results[0].Passed)
+	})
+
+	t.Run("test nested property", func(t *testing.T) {
+		c := entity.Constraint{Property: "device.os", Operator: models.ConstraintOperatorEQ, Value: `"ios"`}
+		results := evalConstraint(c, []interface{}{
+			map[string]interface{}{"device": map[string]interface{}{"os": "ios"}},
+			map[string]interface{}{"device": map[string]interface{}{"os": "android"}},
+			map[string]interface{}{"device": map[string]interface{}{}},
+			map[string]interface{}{"device": "ios"},
+		}, time.Now())
+		assert.True(t, results[0].Passed)
+		assert.False(t, results[1].Passed)
+		assert.Empty(t, results[1].Error)
+		for _, r := range results[2:] {
+			assert.False(t, r.Passed)
+			assert.NotEmpty(t, r.Error)
+		}
+		assert.NotContains(t, results[0].EntityContext, "device.os")
+	})
+
+	t.Run("test existence constraints", func(t *testing.T) {
+		entityContexts := []interface{}{
+			map[string]interface{}{"beta": map[string]interface{}{"cohort": ""}},
+			map[string]interface{}{"beta": map[string]interface{}{"cohort": nil}},
+			map[string]interface{}{"beta": map[string]interface{}{}},
+		}
+		c := entity.Constraint{Property: "beta.cohort", Operator: models.ConstraintOperatorEXISTS}
+		results := evalConstraint(c, entityContexts, time.Now())
+		assert.True(t, results[0].Passed)
+		assert.False(t, results[1].Passed)
+		assert.False(t, results[2].Passed)
+		for _, r := range results {
+			assert.Empty(t, r.Error)
+		}
+
+		c.Operator = models.ConstraintOperatorNOTEXISTS
+		results = evalConstraint(c, entityContexts, time.Now())
+		assert.False(t, results[0].Passed)
+		assert.True(t, results[1].Passed)
+		assert.True(t, results[2].Passed)
+		assert.NotContains(t, results[0].EntityContext, entity.ExistenceProperty("beta.cohort"))
+	})
+
+	t.Run("test IN constraints of numbers", func(t *testing.T) {
+		entityContexts := []interface{}{
+			map[string]interface{}{"account": map[string]interface{}{"id": float64(2)}},
+			map[string]interface{}{"account": map[string]interface{}{"id": float64(4)}},
+			map[string]interface{}{"account": map[string]interface{}{"id": "2"}},
+			map[string]interface{}{"account": map[string]interface{}{}},
+		}
+		c := entity.Constraint{Property: "account.id", Operator: models.ConstraintOperatorIN, Value: "[1, 2, 3]"}
+		results := evalConstraint(c, entityContexts, time.Now())
+		assert.True(t, results[0].Passed)
+		assert.False(t, results[1].Passed)
+		assert.Empty(t, results[1].Error)
+		for _, r := range results[2:] {
+			assert.False(t, r.Passed)
+			assert.NotEmpty(t, r.Error)
+		}
+
+		c.Operator = models.ConstraintOperatorNOTIN
+		results = evalConstraint(c, entityContexts, time.Now())
+		assert.False(t, results[0].Passed)
+		assert.True(t, results[1].Passed)
+		for _, r := range results[2:] {
+			assert.False(t, r.Passed)
+			assert.NotEmpty(t, r.Error)
+		}
+		assert.NotContains(t, results[0].EntityContext, c.NumberSet().Key)
+	})
+
+	t.Run("test IN and NOT_IN constraints of numbers with a missing property", func(t *testing.T) {
+		for _, op := range []string{models.ConstraintOperatorIN, models.ConstraintOperatorNOTIN} {
+			c := entity.Constraint{Property: "account_id", Operator: op, Value: "[1, 2, 3]"}
+			results := evalConstraint(c, []interface{}{map[string]interface{}{"state": "CA"}, map[string]interface{}{}}, time.Now())
+			for _, r := range results {
+				assert.False(t, r.Passed, op)
+				assert.NotEmpty(t, r.Error, op)
+			}
+		}
+	})
+
+	t.Run("test IN and NOT_IN constraints of numbers with a string property", func(t *testing.T) {
+		for _, op := range []string{models.ConstraintOperatorIN, models.ConstraintOperatorNOTIN} {
+			c := entity.Constraint{Property: "account_id", Operator: op, Value: "[1, 2, 3]"}
+			results := evalConstraint(c, []interface{}{map[string]interface{}{"account_id": "2"}}, time.Now())
+			assert.False(t, results[0].Passed, op)
+			assert.NotEmpty(t, results[0].Error, op)
+		}
+	})
 }
 
 func TestRateLimitPerFlagConsoleLogging(t *testing.T) {
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/mapper/entity_restapi/e2r"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/exclusion_group"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
+)
+
+func (c *crud) FindExclusionGroups(params exclusion_group.FindExclusionGroupsParams) middleware.Responder {
+	gs := []entity.ExclusionGroup{}
+	if err := getRequestDB(params.HTTPRequest).Order("key").Find(&gs).Error; err != nil {
+		return exclusion_group.NewFindExclusionGroupsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	members := []entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).Where("exclusion_group_id <> 0").Order("id").Find(&members).Error; err != nil {
+		return exclusion_group.NewFindExclusionGroupsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	membersByGroup := make(map[uint][]entity.Flag)
+	for _, f := range members {
+		membersByGroup[f.ExclusionGroupID] = append(membersByGroup[f.ExclusionGroupID], f)
+	}
+
+	payload := make([]*models.ExclusionGroup, len(gs))
+	for i := range gs {
+		payload[i] = e2r.MapExclusionGroup(&gs[i], membersByGroup[gs[i].ID])
+	}
+	resp := exclusion_group.NewFindExclusionGroupsOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+func (c *crud) CreateExclusionGroup(params exclusion_group.CreateExclusionGroupParams) middleware.Responder {
+	key := util.SafeString(params.Body.Key)
+	if ok, reason := util.IsSafeKey(key); !ok {
+		return exclusion_group.NewCreateExclusionGroupDefault(400).WithPayload(
+			ErrorMessage("cannot create exclusion group due to invalid key. reason: %s", reason))
+	}
+
+	count := 0
+	if err := getRequestDB(params.HTTPRequest).Model(&entity.ExclusionGroup{}).Where(entity.ExclusionGroup{Key: key}).Count(&count).Error; err != nil {
+		return exclusion_group.NewCreateExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if count > 0 {
+		return exclusion_group.NewCreateExclusionGroupDefault(409).WithPayload(
+			ErrorMessage("cannot create exclusion group. key %s already exists", key))
+	}
+
+	g := &entity.ExclusionGroup{Key: key, Description: params.Body.Description}
+	if err := getRequestDB(params.HTTPRequest).Create(g).Error; err != nil {
+		return exclusion_group.NewCreateExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := exclusion_group.NewCreateExclusionGroupOK()
+	resp.SetPayload(e2r.MapExclusionGroup(g, nil))
+	return resp
+}
+
+func (c *crud) GetExclusionGroup(params exclusion_group.GetExclusionGroupParams) middleware.Responder {
+	g := &entity.ExclusionGroup{}
+	if err := getRequestDB(params.HTTPRequest).First(g, params.ExclusionGroupID).Error; err != nil {
+		return exclusion_group.NewGetExclusionGroupDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	members, err := entity.FindExclusionGroupMembers(getRequestDB(params.HTTPRequest), g.ID)
+	if err != nil {
+		return exclusion_group.NewGetExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := exclusion_group.NewGetExclusionGroupOK()
+	resp.SetPayload(e2r.MapExclusionGroup(g, members))
+	return resp
+}
+
+func (c *crud) PutExclusionGroup(params exclusion_group.PutExclusionGroupParams) middleware.Responder {
+	g := &entity.ExclusionGroup{}
+	if err := getRequestDB(params.HTTPRequest).First(g, params.ExclusionGroupID).Error; err != nil {
+		return exclusion_group.NewPutExclusionGroupDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	g.Description = params.Body.Description
+	if err := getRequestDB(params.HTTPRequest).Save(g).Error; err != nil {
+		return exclusion_group.NewPutExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	members, err := entity.FindExclusionGroupMembers(getRequestDB(params.HTTPRequest), g.ID)
+	if err != nil {
+		return exclusion_group.NewPutExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := exclusion_group.NewPutExclusionGroupOK()
+	resp.SetPayload(e2r.MapExclusionGroup(g, members))
+	return resp
+}
+
+// DeleteExclusionGroup deletes the group and takes its members out of it in the same transaction,
+// so that none of the flags is left in a group that doesn't exist
+func (c *crud) DeleteExclusionGroup(params exclusion_group.DeleteExclusionGroupParams) middleware.Responder {
+	groupID := util.SafeUint(params.ExclusionGroupID)
+	members, err := entity.FindExclusionGroupMembers(getRequestDB(params.HTTPRequest), groupID)
+	if err != nil {
+		return exclusion_group.NewDeleteExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	tx := getRequestDB(params.HTTPRequest).Begin()
+	err = tx.Model(&entity.Flag{}).
+		Where(entity.Flag{ExclusionGroupID: groupID}).
+		Updates(map[string]interface{}{"exclusion_group_id": 0, "exclusion_group_percent": 0}).Error
+	if err != nil {
+		tx.Rollback()
+		return exclusion_group.NewDeleteExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := tx.Delete(&entity.ExclusionGroup{}, groupID).Error; err != nil {
+		tx.Rollback()
+		return exclusion_group.NewDeleteExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return exclusion_group.NewDeleteExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	for _, f := range members {
+		entity.SaveFlagSnapshot(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest))
+	}
+	return exclusion_group.NewDeleteExclusionGroupOK()
+}
+
+func (c *crud) PutFlagExclusionGroup(params exclusion_group.PutFlagExclusionGroupParams) middleware.Responder {
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
+		return exclusion_group.NewPutFlagExclusionGroupDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+	before := *f
+
+	groupID := util.SafeUint(params.Body.ExclusionGroupID)
+	percent := util.SafeUint(params.Body.Percent)
+	if groupID == 0 {
+		percent = 0
+	}
+	if e := validateExclusionGroupPercent(getRequestDB(params.HTTPRequest), f.ID, groupID, percent); e != nil {
+		return exclusion_group.NewPutFlagExclusionGroupDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	f.ExclusionGroupID = groupID
+	f.ExclusionGroupPercent = percent
+	if err := getRequestDB(params.HTTPRequest).Save(f).Error; err != nil {
+		return exclusion_group.NewPutFlagExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := exclusion_group.NewPutFlagExclusionGroupOK()
+	payload, err := e2rMapFlag(f)
+	if err != nil {
+		return exclusion_group.NewPutFlagExclusionGroupDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp.SetPayload(payload)
+
+	entity.SaveFlagHistory(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeFlag, f.ID, &before, f)
+	entity.SaveFlagSnapshot(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest))
+	return resp
+}
+
+// validateExclusionGroupPercent makes sure the group exists and the percents of
+// its members, including the flag with the new percent, add up to at most 100
+var validateExclusionGroupPercent = func(db *gorm.DB, flagID uint, groupID uint, percent uint) *Error {
+	if groupID == 0 {
+		return nil
+	}
+	if err := db.First(&entity.ExclusionGroup{}, groupID).Error; err != nil {
+		return NewError(400, "cannot find exclusion group %v. reason: %s", groupID, err)
+	}
+
+	members, err := entity.FindExclusionGroupMembers(db, groupID)
+	if err != nil {
+		return NewError(500, "cannot find the members of exclusion group %v. reason: %s", groupID, err)
+	}
+	sum := percent
+	for _, m := range members {
+		if m.ID != flagID {
+			sum += m.ExclusionGroupPercent
+		}
+	}
+	if sum > 100 {
+		return NewError(400, "the sum of the percents %v in exclusion group %v is over 100", sum, groupID)
+	}
+	return nil
+}
+
+// evalExclusionGroup buckets the entity in the bucketing space of the exclusion group of f,
+// and tells if the bucket is routed to f. It's always true if f is not in any group.
+func evalExclusionGroup(f *entity.Flag, evalContext models.EvalContext) (bool, *models.ExclusionGroupDebugLog) {
+	if f.ExclusionGroupID == 0 {
+		return true, nil
+	}
+
+	bucketNum := entity.BucketNum(
+		entity.GetBucketingHasher(""),
+		bucketingEntityID(f, evalContext),
+		entity.ExclusionGroupBucketingSalt(f.ExclusionGroupID),
+	)
+	memberID := GetEvalCache().GetExclusionGroupArray(f.ExclusionGroupID).FlagIDByBucketNum(bucketNum)
+	log := &models.ExclusionGroupDebugLog{
+		ExclusionGroupID: int64(f.ExclusionGroupID),
+		BucketNum:        int64(bucketNum),
+		FlagID:           int64(memberID),
+		Included:         memberID == f.ID,
+	}
+
+	switch memberID {
+	case f.ID:
+		log.Msg = fmt.Sprintf("bucket %v of exclusion group %v is routed to flagID %v", bucketNum, f.ExclusionGroupID, f.ID)
+	case 0:
+		log.Msg = fmt.Sprintf("bucket %v of exclusion group %v is not routed to any member flag", bucketNum, f.ExclusionGroupID)
+	default:
+		log.Msg = fmt.Sprintf("bucket %v of exclusion group %v is routed to the other member flagID %v", bucketNum, f.ExclusionGroupID, memberID)
+	}
+	return log.Included, log
+}
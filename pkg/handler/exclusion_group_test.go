@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/exclusion_group"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrudExclusionGroups(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(f)
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	t.Run("it should create the exclusion group", func(t *testing.T) {
+		res := c.CreateExclusionGroup(exclusion_group.CreateExclusionGroupParams{
+			Body: &models.CreateExclusionGroupRequest{Key: util.StringPtr("checkout"), Description: "checkout experiments"},
+		})
+		payload := res.(*exclusion_group.CreateExclusionGroupOK).Payload
+		assert.Equal(t, int64(1), payload.ID)
+		assert.Equal(t, "checkout", *payload.Key)
+		assert.Len(t, payload.Members, 0)
+
+		res = c.CreateExclusionGroup(exclusion_group.CreateExclusionGroupParams{
+			Body: &models.CreateExclusionGroupRequest{Key: util.StringPtr("checkout")},
+		})
+		assert.IsType(t, &exclusion_group.CreateExclusionGroupDefault{}, res)
+
+		res = c.CreateExclusionGroup(exclusion_group.CreateExclusionGroupParams{
+			Body: &models.CreateExclusionGroupRequest{Key: util.StringPtr("check out")},
+		})
+		assert.IsType(t, &exclusion_group.CreateExclusionGroupDefault{}, res)
+	})
+
+	t.Run("it should add the flag to the exclusion group", func(t *testing.T) {
+		res := c.PutFlagExclusionGroup(exclusion_group.PutFlagExclusionGroupParams{
+			FlagID: int64(f.ID),
+			Body:   &models.PutFlagExclusionGroupRequest{ExclusionGroupID: util.Int64Ptr(1), Percent: util.Int64Ptr(60)},
+		})
+		payload := res.(*exclusion_group.PutFlagExclusionGroupOK).Payload
+		assert.Equal(t, int64(1), payload.ExclusionGroupID)
+		assert.Equal(t, int64(60), payload.ExclusionGroupPercent)
+
+		res = c.GetExclusionGroup(exclusion_group.GetExclusionGroupParams{ExclusionGroupID: 1})
+		members := res.(*exclusion_group.GetExclusionGroupOK).Payload.Members
+		assert.Len(t, members, 1)
+		assert.Equal(t, f.Key, members[0].FlagKey)
+		assert.Equal(t, int64(60), members[0].Percent)
+	})
+
+	t.Run("it should not let the percents of the group go over 100", func(t *testing.T) {
+		other := entity.GenFixtureFlag()
+		other.ID, other.Key = 101, "flag_key_101"
+		db.Create(&other)
+
+		res := c.PutFlagExclusionGroup(exclusion_group.PutFlagExclusionGroupParams{
+			FlagID: 101,
+			Body:   &models.PutFlagExclusionGroupRequest{ExclusionGroupID: util.Int64Ptr(1), Percent: util.Int64Ptr(50)},
+		})
+		assert.IsType(t, &exclusion_group.PutFlagExclusionGroupDefault{}, res)
+
+		res = c.PutFlagExclusionGroup(exclusion_group.PutFlagExclusionGroupParams{
+			FlagID: 101,
+			Body:   &models.PutFlagExclusionGroupRequest{ExclusionGroupID: util.Int64Ptr(1), Percent: util.Int64Ptr(40)},
+		})
+		assert.IsType(t, &exclusion_group.PutFlagExclusionGroupOK{}, res)
+
+		res = c.PutFlagExclusionGroup(exclusion_group.PutFlagExclusionGroupParams{
+			FlagID: 101,
+			Body:   &models.PutFlagExclusionGroupRequest{ExclusionGroupID: util.Int64Ptr(999), Percent: util.Int64Ptr(40)},
+		})
+		assert.IsType(t, &exclusion_group.PutFlagExclusionGroupDefault{}, res)
+	})
+
+	t.Run("it should find and update the exclusion groups", func(t *testing.T) {
+		res := c.PutExclusionGroup(exclusion_group.PutExclusionGroupParams{
+			ExclusionGroupID: 1,
+			Body:             &models.PutExclusionGroupRequest{Description: "updated"},
+		})
+		assert.Equal(t, "updated", res.(*exclusion_group.PutExclusionGroupOK).Payload.Description)
+
+		res = c.FindExclusionGroups(exclusion_group.FindExclusionGroupsParams{})
+		payload := res.(*exclusion_group.FindExclusionGroupsOK).Payload
+		assert.Len(t, payload, 1)
+		assert.Len(t, payload[0].Members, 2)
+
+		res = c.GetExclusionGroup(exclusion_group.GetExclusionGroupParams{ExclusionGroupID: 999})
+		assert.IsType(t, &exclusion_group.GetExclusionGroupDefault{}, res)
+	})
+
+	t.Run("it should take the members out of the deleted exclusion group", func(t *testing.T) {
+		res := c.DeleteExclusionGroup(exclusion_group.DeleteExclusionGroupParams{ExclusionGroupID: 1})
+		assert.IsType(t, &exclusion_group.DeleteExclusionGroupOK{}, res)
+
+		members, err := entity.FindExclusionGroupMembers(db, 1)
+		assert.NoError(t, err)
+		assert.Len(t, members, 0)
+	})
+}
+
+func TestEvalFlagWithExclusionGroup(t *testing.T) {
+	genCache := func(percents ...uint) *EvalCache {
+		idCache := mapCache{}
+		for i, p := range percents {
+			f := entity.GenFixtureFlag()
+			f.ID = uint(100 + i)
+			f.Key = fmt.Sprintf("flag_key_%d", f.ID)
+			f.ExclusionGroupID = 1
+			f.ExclusionGroupPercent = p
+			f.PrepareEvaluation()
+			idCache[util.SafeString(f.ID)] = &f
+		}
+		return &EvalCache{idCache: idCache, exclusionGroups: newExclusionGroupArrays(idCache)}
+	}
+
+	entityContext := map[string]interface{}{"dl_state": "CA"}
+	defer gostub.StubFunc(&logEvalResult).Reset()
+
+	t.Run("test an entity is in at most one member flag", func(t *testing.T) {
+		defer gostub.StubFunc(&GetEvalCache, genCache(50, 30)).Reset()
+		counts := map[int64]int{}
+		for i := 0; i < 1000; i++ {
+			included := int64(0)
+			for _, flagID := range []int64{100, 101} {
+				result := evalFlag(context.Background(), "", models.EvalContext{EntityID: fmt.Sprintf("entity%d", i), EntityContext: entityContext, FlagID: flagID})
+				if result.VariantID != 0 {
+					assert.Zero(t, included)
+					included = flagID
+				} else {
+					assert.Equal(t, EvalReasonExcludedByGroup, result.EvalDebugLog.Reason)
+				}
+			}
+			counts[included]++
+		}
+		assert.InDelta(t, 500, counts[100], 60)
+		assert.InDelta(t, 300, counts[101], 60)
+		assert.InDelta(t, 200, counts[0], 60)
+	})
+
+	t.Run("test the group decision in the explain output", func(t *testing.T) {
+		defer gostub.StubFunc(&GetEvalCache, genCache(100)).Reset()
+		result := explainFlag(context.Background(), "", models.EvalContext{EntityID: "entity1", EntityContext: entityContext, FlagID: 100})
+		log := result.EvalDebugLog.ExclusionGroupDebugLog
+		assert.True(t, log.Included)
+		assert.Equal(t, int64(1), log.ExclusionGroupID)
+		assert.Equal(t, int64(100), log.FlagID)
+		assert.Equal(t, int64(entity.BucketNum(entity.GetBucketingHasher(""), "entity1", entity.ExclusionGroupBucketingSalt(1))), log.BucketNum)
+
+		defer gostub.StubFunc(&GetEvalCache, genCache(0)).Reset()
+		result = explainFlag(context.Background(), "", models.EvalContext{EntityID: "entity1", EntityContext: entityContext, FlagID: 100})
+		assert.Zero(t, result.VariantID)
+		assert.Equal(t, EvalReasonExcludedByGroup, result.EvalDebugLog.Reason)
+		assert.False(t, result.EvalDebugLog.ExclusionGroupDebugLog.Included)
+		assert.Contains(t, result.EvalDebugLog.ExclusionGroupDebugLog.Msg, "not routed to any member")
+	})
+}
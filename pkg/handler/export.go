@@ -2,14 +2,19 @@ package handler
 
 import (
 	"bytes"
+	"encoding/csv"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/export"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/jinzhu/gorm"
 	"github.com/sirupsen/logrus"
@@ -43,6 +48,12 @@ var exportSQLiteFile = func() (file io.ReadCloser, done func(), err error) {
 	if err := exportFlagEntityTypes(tmpDB); err != nil {
 		return nil, done, err
 	}
+	if err := exportExclusionGroups(tmpDB); err != nil {
+		return nil, done, err
+	}
+	if err := exportConstraintGroups(tmpDB); err != nil {
+		return nil, done, err
+	}
 
 	content, err := ioutil.ReadFile(fname)
 	if err != nil {
@@ -94,8 +105,158 @@ var exportFlagEntityTypes = func(tmpDB *gorm.DB) error {
 	return nil
 }
 
+var exportExclusionGroups = func(tmpDB *gorm.DB) error {
+	var gs []entity.ExclusionGroup
+	if err := getDB().Find(&gs).Error; err != nil {
+		return err
+	}
+	for _, g := range gs {
+		if err := tmpDB.Create(g).Error; err != nil {
+			return err
+		}
+	}
+	logrus.WithField("count", len(gs)).Debugf("export exclusion groups")
+	return nil
+}
+
+var exportConstraintGroups = func(tmpDB *gorm.DB) error {
+	var gs []entity.ConstraintGroup
+	if err := entity.PreloadConstraintGroupConstraints(getDB()).Find(&gs).Error; err != nil {
+		return err
+	}
+	for _, g := range gs {
+		if err := tmpDB.Create(g).Error; err != nil {
+			return err
+		}
+	}
+	logrus.WithField("count", len(gs)).Debugf("export constraint groups")
+	return nil
+}
+
 var exportEvalCacheJSONHandler = func(export.GetExportEvalCacheJSONParams) middleware.Responder {
-	return export.NewGetExportEvalCacheJSONOK().WithPayload(
-		GetEvalCache().export(),
-	)
+	ecj, err := GetEvalCache().export()
+	if err != nil {
+		return export.NewGetExportEvalCacheJSONDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	return export.NewGetExportEvalCacheJSONOK().WithPayload(ecj)
+}
+
+// exportFlagsCSVBatchSize is the number of flags loaded from the db at a time when streaming the CSV
+var exportFlagsCSVBatchSize = 100
+
+var exportFlagsCSVHeader = []string{
+	"flag_id",
+	"flag_key",
+	"description",
+	"enabled",
+	"tags",
+	"segment_count",
+	"segment_rank",
+	"segment_description",
+	"rollout_percent",
+	"variant_key",
+	"percent",
+}
+
+var exportFlagsCSVHandler = func(params export.GetExportFlagsCsvParams) middleware.Responder {
+	tx := whereNamespace(getRequestDB(params.HTTPRequest), params.HTTPRequest)
+	if params.Enabled != nil {
+		tx = tx.Where("enabled = ?", *params.Enabled)
+	}
+	if params.Tags != nil {
+		tags := splitTags(*params.Tags)
+		if len(tags) > 0 {
+			flagIDs, err := findFlagIDsWithAllTags(getRequestDB(params.HTTPRequest), tags)
+			if err != nil {
+				return export.NewGetExportFlagsCsvDefault(500).WithPayload(
+					ErrorMessage("cannot query flags by tags. %s", err))
+			}
+			tx = tx.Where("id IN (?)", flagIDs)
+		}
+	}
+
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		rw.Header().Set("Content-Type", "text/csv")
+		rw.Header().Set("Content-Disposition", `attachment; filename="flags.csv"`)
+		rw.WriteHeader(http.StatusOK)
+		if err := writeFlagsCSV(rw, tx); err != nil {
+			// the status is already sent, so we can only log the error
+			logrus.WithField("err", err).Error("failed to export flags in CSV")
+		}
+	})
+}
+
+// writeFlagsCSV streams the flags of the query in batches, so that only one batch of flags is in memory
+var writeFlagsCSV = func(w io.Writer, tx *gorm.DB) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportFlagsCSVHeader); err != nil {
+		return err
+	}
+
+	lastID := uint(0)
+	for {
+		fs := []entity.Flag{}
+		err := entity.PreloadSegmentsVariants(tx).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(exportFlagsCSVBatchSize).
+			Find(&fs).
+			Error
+		if err != nil {
+			return err
+		}
+		for _, f := range fs {
+			for _, record := range flagCSVRecords(f) {
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			}
+			lastID = f.ID
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		if len(fs) < exportFlagsCSVBatchSize {
+			return nil
+		}
+	}
+}
+
+// flagCSVRecords returns one record per distribution of the segments,
+// or one record with the flag columns only if there's no distribution
+func flagCSVRecords(f entity.Flag) [][]string {
+	tags := make([]string, len(f.Tags))
+	for i, t := range f.Tags {
+		tags[i] = t.Value
+	}
+	flagColumns := []string{
+		util.SafeString(f.ID),
+		f.Key,
+		f.Description,
+		util.SafeString(f.Enabled),
+		strings.Join(tags, ","),
+		util.SafeString(len(f.Segments)),
+	}
+
+	records := [][]string{}
+	for _, s := range f.Segments {
+		for _, d := range s.Distributions {
+			records = append(records, append(append([]string{}, flagColumns...),
+				util.SafeString(s.Rank),
+				s.Description,
+				util.SafeString(s.RolloutPercent),
+				d.VariantKey,
+				util.SafeString(d.Percent),
+			))
+		}
+	}
+	if len(records) == 0 {
+		records = append(records, append(flagColumns, "", "", "", "", ""))
+	}
+	return records
 }
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/export"
+	"github.com/go-openapi/runtime"
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 )
@@ -107,3 +112,70 @@ func TestExportEvalCacheJSONHandler(t *testing.T) {
 		assert.IsType(t, res.(*export.GetExportEvalCacheJSONOK), res)
 	})
 }
+
+func TestExportFlagsCSVHandler(t *testing.T) {
+	fixtureFlag := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(fixtureFlag)
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	f := &entity.Flag{Key: "flag_without_segments", Description: "disabled flag"}
+	db.Create(f)
+	tag, _ := entity.CreateTag(db, "team_a")
+	db.Model(f).Association("Tags").Append(tag)
+
+	exportCSV := func(params export.GetExportFlagsCsvParams) [][]string {
+		rec := httptest.NewRecorder()
+		exportFlagsCSVHandler(params).WriteResponse(rec, runtime.TextProducer())
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		assert.NoError(t, err)
+		return records
+	}
+
+	t.Run("happy code path", func(t *testing.T) {
+		defer gostub.Stub(&exportFlagsCSVBatchSize, 1).Reset()
+
+		records := exportCSV(export.GetExportFlagsCsvParams{})
+		assert.Len(t, records, 4)
+		assert.Equal(t, exportFlagsCSVHeader, records[0])
+		assert.Equal(t, []string{"100", "flag_key_100", "", "true", "", "1", "0", "", "100", "control", "50"}, records[1])
+		assert.Equal(t, []string{"100", "flag_key_100", "", "true", "", "1", "0", "", "100", "treatment", "50"}, records[2])
+		assert.Equal(t, []string{"101", "flag_without_segments", "disabled flag", "false", "team_a", "0", "", "", "", "", ""}, records[3])
+	})
+
+	t.Run("filter by enabled and tags", func(t *testing.T) {
+		records := exportCSV(export.GetExportFlagsCsvParams{Enabled: util.BoolPtr(false)})
+		assert.Len(t, records, 2)
+		assert.Equal(t, "flag_without_segments", records[1][1])
+
+		records = exportCSV(export.GetExportFlagsCsvParams{Tags: util.StringPtr("team_a")})
+		assert.Len(t, records, 2)
+		assert.Equal(t, "flag_without_segments", records[1][1])
+
+		records = exportCSV(export.GetExportFlagsCsvParams{Tags: util.StringPtr("team_b")})
+		assert.Len(t, records, 1)
+	})
+
+	t.Run("tags query error code path", func(t *testing.T) {
+		db.Error = fmt.Errorf("db generic error")
+		defer func() { db.Error = nil }()
+
+		res := exportFlagsCSVHandler(export.GetExportFlagsCsvParams{Tags: util.StringPtr("team_a")})
+		assert.IsType(t, &export.GetExportFlagsCsvDefault{}, res)
+	})
+
+	t.Run("writeFlagsCSV error code path", func(t *testing.T) {
+		tx := db.New()
+		tx.Error = fmt.Errorf("db generic error")
+		err := writeFlagsCSV(&bytes.Buffer{}, tx)
+		assert.Error(t, err)
+	})
+
+	t.Run("writeFlagsCSV error after the status is sent", func(t *testing.T) {
+		defer gostub.StubFunc(&writeFlagsCSV, fmt.Errorf("error")).Reset()
+		rec := httptest.NewRecorder()
+		exportFlagsCSVHandler(export.GetExportFlagsCsvParams{}).WriteResponse(rec, runtime.TextProducer())
+		assert.Equal(t, 200, rec.Code)
+	})
+}
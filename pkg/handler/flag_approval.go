@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/mapper/entity_restapi/e2r"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// requestFlagChange creates the pending change instead of applying it if FLAGR_APPROVAL_REQUIRED is set and the change
+// needs an approval, i.e. it enables the flag, or it changes the distributions of the enabled flag, in the environment
+// of the change. It's nil if the change can be applied right away.
+func requestFlagChange(r *http.Request, fc *entity.FlagChange) (*models.FlagChange, *Error) {
+	if !config.Config.ApprovalRequired {
+		return nil, nil
+	}
+
+	if fc.Environment != "" {
+		if e := validateEnvironment(fc.Environment); e != nil {
+			return nil, e
+		}
+	}
+	db := getRequestDB(r)
+	f := &entity.Flag{}
+	if err := db.First(f, fc.FlagID).Error; err != nil {
+		return nil, NewError(404, "error finding flagID %v. reason %s", fc.FlagID, err)
+	}
+	enabled := f.Enabled
+	if fc.Environment != "" {
+		fe, _, err := findFlagEnvironment(db, f, fc.Environment)
+		if err != nil {
+			return nil, NewError(500, "%s", err)
+		}
+		enabled = fe.Enabled
+	}
+
+	switch fc.Type {
+	case entity.FlagChangeTypeEnabled:
+		if !fc.Enabled || enabled {
+			return nil, nil
+		}
+	case entity.FlagChangeTypeDistributions:
+		if !enabled {
+			return nil, nil
+		}
+	}
+
+	fc.Status = entity.FlagChangeStatusPending
+	fc.RequestedBy = getSubjectFromRequest(r)
+	if err := db.Create(fc).Error; err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	payload, err := e2r.MapFlagChange(fc)
+	if err != nil {
+		return nil, NewError(500, "cannot map flag change. %s", err)
+	}
+	return payload, nil
+}
+
+// validateWithoutApproval rejects the change that can't be requested for an approval, if FLAGR_APPROVAL_REQUIRED is set
+// and it leaves the flag enabled, i.e. it enables the flag or it changes the rollout of the enabled flag. Such changes
+// are made with the enabled and the distributions endpoints instead, or while the flag is disabled.
+func validateWithoutApproval(enabled bool, format string, a ...interface{}) *Error {
+	if !config.Config.ApprovalRequired || !enabled {
+		return nil
+	}
+	return NewError(403, "cannot %s, it enables the flag or changes the rollout of the enabled flag without an approval. "+
+		"use the enabled and the distributions endpoints with FLAGR_APPROVAL_REQUIRED", fmt.Sprintf(format, a...))
+}
+
+// validateFlagWithoutApproval is validateWithoutApproval of the change of the flag, which leaves the flag enabled
+// if it's enabled in any environment
+func validateFlagWithoutApproval(db *gorm.DB, flagID uint, format string, a ...interface{}) *Error {
+	if !config.Config.ApprovalRequired {
+		return nil
+	}
+	f := &entity.Flag{}
+	if err := db.First(f, flagID).Error; err != nil {
+		return NewError(404, "error finding flagID %v. reason %s", flagID, err)
+	}
+	enabled, err := isFlagEnabledAnywhere(db, f)
+	if err != nil {
+		return NewError(500, "error finding the environments of flagID %v. reason %s", flagID, err)
+	}
+	return validateWithoutApproval(enabled, format, a...)
+}
+
+// isFlagEnabledAnywhere tells if the flag is enabled, either its own config or its config in any environment
+func isFlagEnabledAnywhere(db *gorm.DB, f *entity.Flag) (bool, error) {
+	if f.Enabled {
+		return true, nil
+	}
+	count := 0
+	err := db.Model(&entity.FlagEnvironment{}).Where("flag_id = ? AND enabled = ?", f.ID, true).Count(&count).Error
+	return count > 0, err
+}
+
+// requestEnabledChange requests the change of the enabled state of the flag, see requestFlagChange
+func requestEnabledChange(r *http.Request, flagID uint, env string, enabled bool, note string) (*models.FlagChange, *Error) {
+	return requestFlagChange(r, &entity.FlagChange{
+		FlagID:      flagID,
+		Type:        entity.FlagChangeTypeEnabled,
+		Environment: env,
+		Enabled:     enabled,
+		Note:        note,
+	})
+}
+
+// requestDistributionsChange requests the change of the distributions of the segment, see requestFlagChange
+func requestDistributionsChange(r *http.Request, flagID uint, segmentID uint, env string, ds []entity.Distribution) (*models.FlagChange, *Error) {
+	fc := &entity.FlagChange{
+		FlagID:      flagID,
+		Type:        entity.FlagChangeTypeDistributions,
+		Environment: env,
+		SegmentID:   segmentID,
+	}
+	if err := fc.SetDistributions(ds); err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	return requestFlagChange(r, fc)
+}
+
+// FindFlagChanges finds the changes of the flag requested with FLAGR_APPROVAL_REQUIRED, the latest first
+func (c *crud) FindFlagChanges(params flag.FindFlagChangesParams) middleware.Responder {
+	tx := getRequestDB(params.HTTPRequest).Where(entity.FlagChange{FlagID: util.SafeUint(params.FlagID)})
+	if params.Status != nil {
+		tx = tx.Where("status = ?", *params.Status)
+	}
+
+	fcs := []entity.FlagChange{}
+	if err := tx.Order("id DESC").Find(&fcs).Error; err != nil {
+		return flag.NewFindFlagChangesDefault(500).WithPayload(
+			ErrorMessage("cannot find the changes of flag %v. %s", params.FlagID, err))
+	}
+	payload, err := e2r.MapFlagChanges(fcs)
+	if err != nil {
+		return flag.NewFindFlagChangesDefault(500).WithPayload(ErrorMessage("cannot map flag changes. %s", err))
+	}
+	resp := flag.NewFindFlagChangesOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+// ApproveFlagChange applies the pending change of the flag, it has to be approved by another user than its requester
+func (c *crud) ApproveFlagChange(params flag.ApproveFlagChangeParams) middleware.Responder {
+	db := getRequestDB(params.HTTPRequest)
+	fc := &entity.FlagChange{}
+	err := db.Where(entity.FlagChange{FlagID: util.SafeUint(params.FlagID)}).First(fc, params.ChangeID).Error
+	if err != nil {
+		return flag.NewApproveFlagChangeDefault(404).WithPayload(
+			ErrorMessage("cannot find change %v of flag %v. %s", params.ChangeID, params.FlagID, err))
+	}
+	if fc.Status != entity.FlagChangeStatusPending {
+		return flag.NewApproveFlagChangeDefault(409).WithPayload(
+			ErrorMessage("change %v is already %s", fc.ID, fc.Status))
+	}
+
+	approver := getSubjectFromRequest(params.HTTPRequest)
+	if approver == "" {
+		return flag.NewApproveFlagChangeDefault(403).WithPayload(
+			ErrorMessage("cannot approve change %v without the subject of the JWT token", fc.ID))
+	}
+	if approver == fc.RequestedBy {
+		return flag.NewApproveFlagChangeDefault(403).WithPayload(
+			ErrorMessage("change %v has to be approved by another user than its requester %s", fc.ID, fc.RequestedBy))
+	}
+
+	// the change is claimed before it's applied, so that the concurrent approvals apply it only once
+	now := time.Now()
+	res := db.Model(&entity.FlagChange{}).
+		Where("id = ? AND status = ?", fc.ID, entity.FlagChangeStatusPending).
+		Updates(map[string]interface{}{
+			"status":      entity.FlagChangeStatusApproved,
+			"approved_by": approver,
+			"approved_at": now,
+		})
+	if res.Error != nil {
+		return flag.NewApproveFlagChangeDefault(500).WithPayload(ErrorMessage("%s", res.Error))
+	}
+	if res.RowsAffected == 0 {
+		return flag.NewApproveFlagChangeDefault(409).WithPayload(
+			ErrorMessage("change %v is already approved", fc.ID))
+	}
+	fc.Status, fc.ApprovedBy, fc.ApprovedAt = entity.FlagChangeStatusApproved, approver, &now
+
+	if e := applyFlagChange(db, fc); e != nil {
+		// it's put back to pending, so that it can be approved again once the cause is fixed
+		db.Model(&entity.FlagChange{}).Where("id = ?", fc.ID).Updates(map[string]interface{}{
+			"status":      entity.FlagChangeStatusPending,
+			"approved_by": "",
+			"approved_at": gorm.Expr("NULL"),
+		})
+		return flag.NewApproveFlagChangeDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if err := GetEvalCache().reloadFlag(fc.FlagID); err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "flagID": fc.FlagID}).Error("failed to reload the flag in the eval cache")
+	}
+
+	payload, err := e2r.MapFlagChange(fc)
+	if err != nil {
+		return flag.NewApproveFlagChangeDefault(500).WithPayload(ErrorMessage("cannot map flag change. %s", err))
+	}
+	resp := flag.NewApproveFlagChangeOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+// applyFlagChange applies the approved change, it's saved in the flag history and the snapshot by its approver
+func applyFlagChange(db *gorm.DB, fc *entity.FlagChange) *Error {
+	note := fmt.Sprintf("requested by %s", fc.RequestedBy)
+	if fc.Note != "" {
+		note = fmt.Sprintf("%s, %s", fc.Note, note)
+	}
+
+	switch fc.Type {
+	case entity.FlagChangeTypeEnabled:
+		if fc.Environment != "" {
+			_, e := setFlagEnvironmentEnabled(db, fc.FlagID, fc.Environment, fc.Enabled, fc.ApprovedBy, note)
+			return e
+		}
+		before := &entity.Flag{}
+		if err := db.First(before, fc.FlagID).Error; err != nil {
+			return NewError(404, "%s", err)
+		}
+		if err := db.Model(&entity.Flag{}).Where("id = ?", fc.FlagID).Update("enabled", fc.Enabled).Error; err != nil {
+			return NewError(500, "%s", err)
+		}
+		f := &entity.Flag{}
+		if err := db.First(f, fc.FlagID).Error; err != nil {
+			return NewError(500, "%s", err)
+		}
+		entity.SaveFlagHistoryWithNote(getDB(), f.ID, fc.ApprovedBy, note, entity.FlagHistoryEntityTypeFlag, f.ID, before, f)
+		entity.SaveFlagSnapshot(getDB(), f.ID, fc.ApprovedBy)
+		return nil
+	case entity.FlagChangeTypeDistributions:
+		ds, err := fc.GetDistributions()
+		if err != nil {
+			return NewError(500, "%s", err)
+		}
+		if fc.Environment != "" {
+			_, e := replaceFlagEnvironmentDistributions(db, fc.FlagID, fc.SegmentID, fc.Environment, ds, fc.ApprovedBy)
+			return e
+		}
+		return replaceDistributions(db, fc.FlagID, fc.SegmentID, ds, fc.ApprovedBy)
+	}
+	return NewError(500, "unknown type %s of change %v", fc.Type, fc.ID)
+}
@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment_template"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func genSubjectRequest(sub string) *http.Request {
+	r, _ := http.NewRequest("POST", "", nil)
+	ctx := context.WithValue(context.TODO(), interface{}(config.Config.JWTAuthUserProperty), &jwt.Token{
+		Claims: jwt.MapClaims{"sub": sub},
+		Valid:  true,
+	})
+	return r.WithContext(ctx)
+}
+
+func TestCrudFlagApproval(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	ec := &EvalCache{idCache: map[string]*entity.Flag{}, keyCache: map[string]*entity.Flag{}}
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{
+			Description: util.StringPtr("funny flag"),
+			Key:         "flag_key_1",
+		},
+	})
+	c.CreateSegment(segment.CreateSegmentParams{
+		FlagID: int64(1),
+		Body: &models.CreateSegmentRequest{
+			Description:    util.StringPtr("segment1"),
+			RolloutPercent: util.Int64Ptr(int64(100)),
+		},
+	})
+	for _, key := range []string{"control", "treatment"} {
+		c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body:   &models.CreateVariantRequest{Key: util.StringPtr(key)},
+		})
+	}
+
+	defer gostub.Stub(&config.Config.ApprovalRequired, true).Reset()
+
+	t.Run("it should apply the distributions of the disabled flag right away", func(t *testing.T) {
+		res = c.PutDistributions(distribution.PutDistributionsParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body: &models.PutDistributionsRequest{
+				Distributions: []*models.Distribution{
+					{Percent: util.Int64Ptr(100), VariantID: util.Int64Ptr(1), VariantKey: util.StringPtr("control")},
+				},
+			},
+			HTTPRequest: genSubjectRequest("alice"),
+		})
+		assert.Len(t, res.(*distribution.PutDistributionsOK).Payload, 1)
+	})
+
+	t.Run("it should create a pending change to enable the flag", func(t *testing.T) {
+		res = c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+			FlagID:      int64(1),
+			Body:        &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true), Note: "launch"},
+			HTTPRequest: genSubjectRequest("alice"),
+		})
+		change := res.(*flag.SetFlagEnabledAccepted).Payload
+		assert.Equal(t, models.FlagChangeStatusPending, *change.Status)
+		assert.Equal(t, models.FlagChangeTypeEnabled, *change.Type)
+		assert.Equal(t, "alice", *change.RequestedBy)
+
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
+		assert.False(t, *res.(*flag.GetFlagOK).Payload.Enabled)
+
+		res = c.FindFlagChanges(flag.FindFlagChangesParams{FlagID: int64(1), Status: util.StringPtr(models.FlagChangeStatusPending)})
+		assert.Len(t, res.(*flag.FindFlagChangesOK).Payload, 1)
+	})
+
+	t.Run("it should reject the approval of the requester or an unknown user", func(t *testing.T) {
+		res = c.ApproveFlagChange(flag.ApproveFlagChangeParams{FlagID: int64(1), ChangeID: int64(1), HTTPRequest: genSubjectRequest("alice")})
+		assert.Contains(t, *res.(*flag.ApproveFlagChangeDefault).Payload.Message, "another user")
+
+		res = c.ApproveFlagChange(flag.ApproveFlagChangeParams{FlagID: int64(1), ChangeID: int64(1)})
+		assert.NotZero(t, res.(*flag.ApproveFlagChangeDefault).Payload)
+
+		res = c.ApproveFlagChange(flag.ApproveFlagChangeParams{FlagID: int64(2), ChangeID: int64(1), HTTPRequest: genSubjectRequest("bob")})
+		assert.Contains(t, *res.(*flag.ApproveFlagChangeDefault).Payload.Message, "cannot find change")
+	})
+
+	t.Run("it should apply the change on the approval of another user", func(t *testing.T) {
+		res = c.ApproveFlagChange(flag.ApproveFlagChangeParams{FlagID: int64(1), ChangeID: int64(1), HTTPRequest: genSubjectRequest("bob")})
+		change := res.(*flag.ApproveFlagChangeOK).Payload
+		assert.Equal(t, models.FlagChangeStatusApproved, *change.Status)
+		assert.Equal(t, "bob", change.ApprovedBy)
+		assert.NotNil(t, change.ApprovedAt)
+
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
+		assert.True(t, *res.(*flag.GetFlagOK).Payload.Enabled)
+		assert.True(t, ec.idCache["1"].Enabled)
+
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(1), Limit: util.Int64Ptr(1)})
+		history := res.(*flag.GetFlagHistoryOK).Payload
+		assert.Equal(t, "bob", history[0].Actor)
+		assert.Equal(t, "launch, requested by alice", history[0].Note)
+
+		snapshot := &entity.FlagSnapshot{}
+		db.Order("id DESC").First(snapshot)
+		assert.Equal(t, "bob", snapshot.UpdatedBy)
+
+		res = c.ApproveFlagChange(flag.ApproveFlagChangeParams{FlagID: int64(1), ChangeID: int64(1), HTTPRequest: genSubjectRequest("carol")})
+		assert.Contains(t, *res.(*flag.ApproveFlagChangeDefault).Payload.Message, "already approved")
+	})
+
+	t.Run("it should create a pending change of the distributions of the enabled flag", func(t *testing.T) {
+		res = c.RebalanceDistributions(distribution.RebalanceDistributionsParams{
+			FlagID:      int64(1),
+			SegmentID:   int64(1),
+			Body:        &models.RebalanceDistributionsRequest{VariantIds: []int64{1, 2}},
+			HTTPRequest: genSubjectRequest("alice"),
+		})
+		change := res.(*distribution.RebalanceDistributionsAccepted).Payload
+		assert.Equal(t, models.FlagChangeTypeDistributions, *change.Type)
+		assert.Len(t, change.Distributions, 2)
+
+		res = c.FindDistributions(distribution.FindDistributionsParams{FlagID: int64(1), SegmentID: int64(1)})
+		assert.Len(t, res.(*distribution.FindDistributionsOK).Payload, 1)
+
+		res = c.ApproveFlagChange(flag.ApproveFlagChangeParams{FlagID: int64(1), ChangeID: *change.ID, HTTPRequest: genSubjectRequest("bob")})
+		assert.NotZero(t, res.(*flag.ApproveFlagChangeOK).Payload)
+
+		res = c.FindDistributions(distribution.FindDistributionsParams{FlagID: int64(1), SegmentID: int64(1)})
+		ds := res.(*distribution.FindDistributionsOK).Payload
+		assert.Len(t, ds, 2)
+		assert.Equal(t, int64(50), *ds[0].Percent)
+	})
+
+	t.Run("it should create a pending change to enable the flag in the environment", func(t *testing.T) {
+		res = c.PatchFlagEnabled(flag.PatchFlagEnabledParams{
+			FlagID:      int64(1),
+			Environment: util.StringPtr("prod"),
+			Body:        &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(false)},
+			HTTPRequest: genSubjectRequest("alice"),
+		})
+		assert.False(t, *res.(*flag.PatchFlagEnabledOK).Payload.Enabled)
+
+		res = c.PatchFlagEnabled(flag.PatchFlagEnabledParams{
+			FlagID:      int64(1),
+			Environment: util.StringPtr("prod"),
+			Body:        &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true)},
+			HTTPRequest: genSubjectRequest("alice"),
+		})
+		change := res.(*flag.PatchFlagEnabledAccepted).Payload
+		assert.Equal(t, "prod", change.Environment)
+
+		res = c.ApproveFlagChange(flag.ApproveFlagChangeParams{FlagID: int64(1), ChangeID: *change.ID, HTTPRequest: genSubjectRequest("bob")})
+		assert.NotZero(t, res.(*flag.ApproveFlagChangeOK).Payload)
+
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1), Environment: util.StringPtr("prod")})
+		assert.True(t, *res.(*flag.GetFlagOK).Payload.Enabled)
+	})
+
+	t.Run("it should disable the flag right away", func(t *testing.T) {
+		res = c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+			FlagID:      int64(1),
+			Body:        &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(false)},
+			HTTPRequest: genSubjectRequest("alice"),
+		})
+		assert.False(t, *res.(*flag.SetFlagEnabledOK).Payload.Enabled)
+	})
+
+	t.Run("it should reject scheduling to enable the flag", func(t *testing.T) {
+		scheduledAt := strfmt.DateTime(time.Now().Add(time.Hour))
+		res = c.CreateFlagSchedule(flag.CreateFlagScheduleParams{
+			FlagID: int64(1),
+			Body: &models.CreateFlagScheduleRequest{
+				Action:      util.StringPtr(entity.FlagScheduleActionEnable),
+				ScheduledAt: &scheduledAt,
+			},
+		})
+		assert.Contains(t, *res.(*flag.CreateFlagScheduleDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+	})
+
+	t.Run("it should find all the changes of the flag, the latest first", func(t *testing.T) {
+		res = c.FindFlagChanges(flag.FindFlagChangesParams{FlagID: int64(1)})
+		changes := res.(*flag.FindFlagChangesOK).Payload
+		assert.Len(t, changes, 3)
+		assert.Equal(t, int64(3), *changes[0].ID)
+
+		res = c.FindFlagChanges(flag.FindFlagChangesParams{FlagID: int64(1), Status: util.StringPtr(models.FlagChangeStatusPending)})
+		assert.Len(t, res.(*flag.FindFlagChangesOK).Payload, 0)
+	})
+
+	t.Run("it should reject saving the definitions that enable the flag or change the enabled flag", func(t *testing.T) {
+		def := genFlagDefinition("flag_key_1")
+		def.Enabled = false
+		res = c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+			Body: &models.SaveFlagsBatchRequest{Flags: []*models.FlagDefinition{def, genFlagDefinition("flag_key_2")}},
+		})
+		results := res.(*flag.SaveFlagsBatchBadRequest).Payload.Results
+		assert.Contains(t, results[0].Error, "FLAGR_APPROVAL_REQUIRED")
+		assert.Contains(t, results[1].Error, "FLAGR_APPROVAL_REQUIRED")
+
+		res = c.ImportFlag(flag.ImportFlagParams{
+			Body: &models.ImportFlagRequest{Flag: genFlagDefinition("flag_key_3")},
+		})
+		assert.Contains(t, *res.(*flag.ImportFlagDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+
+		def = genFlagDefinition("flag_key_3")
+		def.Enabled = false
+		res = c.ImportFlag(flag.ImportFlagParams{Body: &models.ImportFlagRequest{Flag: def}})
+		assert.False(t, *res.(*flag.ImportFlagOK).Payload.Enabled)
+	})
+
+	t.Run("it should reject restoring a snapshot of the enabled flag", func(t *testing.T) {
+		snapshot := &entity.FlagSnapshot{}
+		db.Where("flag_id = ?", 1).First(snapshot)
+		res = c.RestoreFlagSnapshot(flag.RestoreFlagSnapshotParams{FlagID: int64(1), SnapshotID: int64(snapshot.ID)})
+		assert.Contains(t, *res.(*flag.RestoreFlagSnapshotDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+	})
+
+	t.Run("it should reject promoting the enabled environment", func(t *testing.T) {
+		res = c.PromoteFlagEnvironment(flag.PromoteFlagEnvironmentParams{
+			FlagID:      int64(1),
+			Environment: "prod",
+			Body:        &models.PromoteFlagEnvironmentRequest{To: util.StringPtr("staging")},
+		})
+		assert.Contains(t, *res.(*flag.PromoteFlagEnvironmentDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+
+		res = c.PromoteFlagEnvironment(flag.PromoteFlagEnvironmentParams{
+			FlagID:      int64(1),
+			Environment: "staging",
+			Body:        &models.PromoteFlagEnvironmentRequest{To: util.StringPtr("qa")},
+		})
+		assert.False(t, *res.(*flag.PromoteFlagEnvironmentOK).Payload.Enabled)
+	})
+
+	t.Run("it should reject overriding the enabled flag", func(t *testing.T) {
+		res = c.PutFlagOverride(flag.PutFlagOverrideParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagOverrideRequest{VariantID: util.Int64Ptr(1), Enabled: true},
+		})
+		assert.Contains(t, *res.(*flag.PutFlagOverrideDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+
+		res = c.PutFlagOverride(flag.PutFlagOverrideParams{
+			FlagID: int64(1),
+			Body:   &models.PutFlagOverrideRequest{VariantID: util.Int64Ptr(0)},
+		})
+		assert.False(t, res.(*flag.PutFlagOverrideOK).Payload.OverrideEnabled)
+	})
+
+	t.Run("it should reject applying a segment template to the enabled flag", func(t *testing.T) {
+		res = c.CreateSegmentTemplate(segment_template.CreateSegmentTemplateParams{
+			Body: &models.CreateSegmentTemplateRequest{
+				Key: util.StringPtr("everyone"),
+				Segment: &models.SegmentDefinition{
+					Description:    util.StringPtr("everyone"),
+					RolloutPercent: util.Int64Ptr(100),
+					Distributions: []*models.DistributionDefinition{
+						{VariantKey: util.StringPtr("treatment"), Percent: util.Int64Ptr(100)},
+					},
+				},
+			},
+		})
+		templateID := res.(*segment_template.CreateSegmentTemplateOK).Payload.ID
+
+		res = c.ApplySegmentTemplate(segment_template.ApplySegmentTemplateParams{
+			SegmentTemplateID: templateID,
+			Body:              &models.ApplySegmentTemplateRequest{FlagID: util.Int64Ptr(1)},
+		})
+		assert.Contains(t, *res.(*segment_template.ApplySegmentTemplateDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+	})
+
+	t.Run("it should reject changing the segments of the enabled flag", func(t *testing.T) {
+		res = c.CreateSegment(segment.CreateSegmentParams{
+			FlagID: int64(1),
+			Body:   &models.CreateSegmentRequest{Description: util.StringPtr("segment2"), RolloutPercent: util.Int64Ptr(100)},
+		})
+		assert.Contains(t, *res.(*segment.CreateSegmentDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+
+		res = c.PutSegment(segment.PutSegmentParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.PutSegmentRequest{Description: util.StringPtr("segment1"), RolloutPercent: util.Int64Ptr(50)},
+		})
+		assert.Contains(t, *res.(*segment.PutSegmentDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+
+		res = c.PutSegmentsReorder(segment.PutSegmentsReorderParams{
+			FlagID: int64(1),
+			Body:   &models.PutSegmentReorderRequest{SegmentIds: []int64{1}},
+		})
+		assert.Contains(t, *res.(*segment.PutSegmentsReorderDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+
+		res = c.DeleteSegment(segment.DeleteSegmentParams{FlagID: int64(1), SegmentID: int64(1)})
+		assert.Contains(t, *res.(*segment.DeleteSegmentDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+	})
+
+	t.Run("it should put the description of the segment of the enabled flag", func(t *testing.T) {
+		res = c.PutSegment(segment.PutSegmentParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.PutSegmentRequest{Description: util.StringPtr("everyone"), RolloutPercent: util.Int64Ptr(100)},
+		})
+		assert.Equal(t, "everyone", *res.(*segment.PutSegmentOK).Payload.Description)
+	})
+
+	t.Run("it should reject changing the bucketing, the default variant or the prerequisite of the enabled flag", func(t *testing.T) {
+		for _, body := range []*models.PutFlagRequest{
+			{BucketingSeed: util.StringPtr("seed")},
+			{BucketBy: util.StringPtr("account_id")},
+			{BucketingAlgorithm: util.StringPtr(entity.BucketingAlgorithmMurmur3)},
+			{DefaultVariantID: util.Int64Ptr(2)},
+			{PrerequisiteFlagID: util.Int64Ptr(2)},
+		} {
+			res = c.PutFlag(flag.PutFlagParams{FlagID: int64(1), Body: body})
+			assert.Contains(t, *res.(*flag.PutFlagDefault).Payload.Message, "FLAGR_APPROVAL_REQUIRED")
+		}
+
+		res = c.PutFlag(flag.PutFlagParams{FlagID: int64(1), Body: &models.PutFlagRequest{Notes: util.StringPtr("notes")}})
+		assert.Equal(t, "notes", res.(*flag.PutFlagOK).Payload.Notes)
+	})
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// flagBackupTimeFormat is the timestamp in the names of the rotated backup files
+const flagBackupTimeFormat = "20060102T150405Z"
+
+// startFlagBackup periodically backs up the definitions of all the flags to FlagBackupDest
+func startFlagBackup() {
+	interval := config.Config.FlagBackupInterval
+	if interval <= 0 || config.Config.FlagBackupDest == "" {
+		return
+	}
+	go func() {
+		for now := range time.Tick(interval) {
+			backupFlags(getDB(), now)
+		}
+	}()
+}
+
+func backupFlags(db *gorm.DB, now time.Time) {
+	path := flagBackupPath(config.Config.FlagBackupDest, config.Config.FlagBackupRotate, now)
+	size, err := writeFlagBackup(db, path)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "path": path}).Error("failed to back up the flags")
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"path":     path,
+		"size":     size,
+		"duration": time.Since(now).String(),
+	}).Info("backed up the flags")
+}
+
+// flagBackupPath returns dest itself, or dest with the UTC timestamp before its extension if the backups rotate,
+// e.g. /backups/flagr.json becomes /backups/flagr.20190102T150405Z.json
+func flagBackupPath(dest string, rotate bool, now time.Time) string {
+	if !rotate {
+		return dest
+	}
+	ext := filepath.Ext(dest)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(dest, ext), now.UTC().Format(flagBackupTimeFormat), ext)
+}
+
+// writeFlagBackup writes the definitions of all the flags in the body of POST /flags/batch, so that the backup
+// can be restored with it. The file is replaced atomically, a failed backup never truncates the previous one.
+var writeFlagBackup = func(db *gorm.DB, path string) (size int, err error) {
+	fs := []entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(db).Order("id").Find(&fs).Error; err != nil {
+		return 0, err
+	}
+
+	body := &models.SaveFlagsBatchRequest{Flags: make([]*models.FlagDefinition, len(fs))}
+	for i := range fs {
+		body.Flags[i] = e2rMapFlagDefinition(&fs[i])
+	}
+	content, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return len(content), nil
+}
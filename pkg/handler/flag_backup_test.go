@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagBackupPath(t *testing.T) {
+	now := time.Date(2019, 1, 2, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, "/backups/flagr.json", flagBackupPath("/backups/flagr.json", false, now))
+	assert.Equal(t, "/backups/flagr.20190102T150405Z.json", flagBackupPath("/backups/flagr.json", true, now))
+	assert.Equal(t, "/backups/flagr.20190102T150405Z", flagBackupPath("/backups/flagr", true, now))
+}
+
+func TestBackupFlags(t *testing.T) {
+	db := entity.PopulateTestDB(entity.GenFixtureFlag())
+	defer db.Close()
+
+	dir, err := ioutil.TempDir("", "flagr_backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dest := filepath.Join(dir, "flagr.json")
+	defer gostub.Stub(&config.Config.FlagBackupDest, dest).Reset()
+
+	t.Run("it should write the flag definitions", func(t *testing.T) {
+		backupFlags(db, time.Now())
+
+		content, err := ioutil.ReadFile(dest)
+		assert.NoError(t, err)
+		body := &models.SaveFlagsBatchRequest{}
+		assert.NoError(t, json.Unmarshal(content, body))
+		assert.Len(t, body.Flags, 1)
+		assert.Equal(t, "flag_key_100", body.Flags[0].Key)
+		assert.Len(t, body.Flags[0].Variants, 2)
+		assert.Len(t, body.Flags[0].Segments, 1)
+	})
+
+	t.Run("it should keep the previous backup if the backup fails", func(t *testing.T) {
+		defer gostub.StubFunc(&writeFlagBackup, 0, fmt.Errorf("db is down")).Reset()
+		backupFlags(db, time.Now())
+
+		content, err := ioutil.ReadFile(dest)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "flag_key_100")
+	})
+
+	t.Run("it should rotate the backups by timestamp", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.FlagBackupRotate, true).Reset()
+		now := time.Date(2019, 1, 2, 15, 4, 5, 0, time.UTC)
+		backupFlags(db, now)
+
+		_, err := os.Stat(filepath.Join(dir, "flagr.20190102T150405Z.json"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("it should not leave the temp files behind", func(t *testing.T) {
+		_, err := writeFlagBackup(db, filepath.Join(dir, "missing", "flagr.json"))
+		assert.Error(t, err)
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*.tmp*"))
+		assert.Empty(t, files)
+	})
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// bucketCheckDefaultN is the default number of the synthetic entity IDs
+const bucketCheckDefaultN = 100000
+
+func (c *crud) GetFlagBucketCheck(params flag.GetFlagBucketCheckParams) middleware.Responder {
+	f := entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(getRequestDB(params.HTTPRequest)).First(&f, params.FlagID).Error; err != nil {
+		return flag.NewGetFlagBucketCheckDefault(404).WithPayload(
+			ErrorMessage("cannot find flag %v. %s", params.FlagID, err))
+	}
+	fs := []entity.Flag{f}
+	if err := entity.AttachConstraintGroups(getRequestDB(params.HTTPRequest), fs); err != nil {
+		return flag.NewGetFlagBucketCheckDefault(500).WithPayload(
+			ErrorMessage("cannot find the constraint groups of flag %v. %s", params.FlagID, err))
+	}
+	if err := fs[0].PrepareEvaluation(); err != nil {
+		return flag.NewGetFlagBucketCheckDefault(500).WithPayload(
+			ErrorMessage("cannot prepare flag %v for evaluation. %s", params.FlagID, err))
+	}
+
+	n := int64(bucketCheckDefaultN)
+	if params.N != nil {
+		n = *params.N
+	}
+
+	resp := flag.NewGetFlagBucketCheckOK()
+	resp.SetPayload(bucketCheck(&fs[0], n, time.Now()))
+	return resp
+}
+
+// bucketCheck buckets n synthetic entity IDs through the distributions of every segment the same way as the
+// evaluation does, and compares the observed counts of the variants with the expected ones by the chi-square statistic.
+// The rollout percent of the segments is left out so that only the split between the variants is checked.
+func bucketCheck(f *entity.Flag, n int64, now time.Time) *models.BucketCheck {
+	hasher := f.BucketingHasher()
+	salt := f.BucketingSalt()
+
+	segments := make([]*models.SegmentBucketCheck, 0, len(f.Segments))
+	for _, s := range f.Segments {
+		d := s.SegmentEvaluation.DistributionArrayAt(now)
+
+		observed := make(map[uint]int64, len(d.VariantIDs))
+		unassigned := int64(0)
+		for i := int64(0); i < n; i++ {
+			bucket := entity.BucketNum(hasher, strconv.FormatInt(i, 10), salt)
+			if vID, ok := d.VariantIDOfBucket(bucket); ok {
+				observed[vID]++
+			} else {
+				unassigned++
+			}
+		}
+
+		sc := &models.SegmentBucketCheck{
+			SegmentID:   util.Int64Ptr(int64(s.ID)),
+			Description: s.Description,
+			Variants:    []*models.VariantBucketCount{},
+		}
+		chiSquare := 0.0
+		categories := int64(0)
+		accumulated := 0
+		for i, vID := range d.VariantIDs {
+			count := &models.VariantBucketCount{
+				VariantID: int64(vID),
+				Expected:  util.Float64Ptr(expectedBucketCount(n, d.PercentsAccumulated[i]-accumulated)),
+				Observed:  util.Int64Ptr(observed[vID]),
+			}
+			accumulated = d.PercentsAccumulated[i]
+			if v := f.FlagEvaluation.VariantsMap[vID]; v != nil {
+				count.VariantKey = v.Key
+			}
+			if *count.Expected > 0 {
+				chiSquare += chiSquareTerm(*count.Observed, *count.Expected)
+				categories++
+			}
+			sc.Variants = append(sc.Variants, count)
+		}
+		if accumulated < int(entity.TotalBucketNum) {
+			sc.Unassigned = &models.VariantBucketCount{
+				Expected: util.Float64Ptr(expectedBucketCount(n, int(entity.TotalBucketNum)-accumulated)),
+				Observed: util.Int64Ptr(unassigned),
+			}
+			chiSquare += chiSquareTerm(unassigned, *sc.Unassigned.Expected)
+			categories++
+		}
+
+		sc.ChiSquare = util.Float64Ptr(chiSquare)
+		sc.DegreesOfFreedom = util.Int64Ptr(0)
+		if categories > 1 {
+			sc.DegreesOfFreedom = util.Int64Ptr(categories - 1)
+		}
+		segments = append(segments, sc)
+	}
+
+	return &models.BucketCheck{
+		FlagID:   util.Int64Ptr(int64(f.ID)),
+		N:        util.Int64Ptr(n),
+		Segments: segments,
+	}
+}
+
+func expectedBucketCount(n int64, buckets int) float64 {
+	return float64(n) * float64(buckets) / float64(entity.TotalBucketNum)
+}
+
+func chiSquareTerm(observed int64, expected float64) float64 {
+	diff := float64(observed) - expected
+	return diff * diff / expected
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketCheck(t *testing.T) {
+	t.Run("it should split evenly between the identical variants", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		bc := bucketCheck(&f, 10000, time.Now())
+
+		assert.Equal(t, int64(10000), *bc.N)
+		assert.Len(t, bc.Segments, 1)
+		s := bc.Segments[0]
+		assert.Nil(t, s.Unassigned)
+		assert.Len(t, s.Variants, 2)
+		assert.Equal(t, "control", s.Variants[0].VariantKey)
+		assert.Equal(t, "treatment", s.Variants[1].VariantKey)
+		assert.Equal(t, int64(10000), *s.Variants[0].Observed+*s.Variants[1].Observed)
+		assert.Equal(t, 5000.0, *s.Variants[0].Expected)
+		assert.Equal(t, 5000.0, *s.Variants[1].Expected)
+		assert.Equal(t, int64(1), *s.DegreesOfFreedom)
+		// the critical value of 1 degree of freedom at p = 0.001
+		assert.True(t, *s.ChiSquare < 10.83)
+	})
+
+	t.Run("it should be the same as the evaluation", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		d := f.Segments[0].SegmentEvaluation.DistributionArray
+		vID, _ := d.Rollout(f.BucketingHasher(), "0", f.BucketingSalt(), 100)
+
+		bc := bucketCheck(&f, 1, time.Now())
+		for _, v := range bc.Segments[0].Variants {
+			if v.VariantID == int64(*vID) {
+				assert.Equal(t, int64(1), *v.Observed)
+			} else {
+				assert.Equal(t, int64(0), *v.Observed)
+			}
+		}
+	})
+
+	t.Run("it should count the unassigned entities while ramping", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		now := time.Now()
+		startAt, endAt := now.Add(-time.Hour), now.Add(time.Hour)
+		f.Segments[0].Distributions = f.Segments[0].Distributions[:1]
+		f.Segments[0].Distributions[0].RolloutStartAt = &startAt
+		f.Segments[0].Distributions[0].RolloutEndAt = &endAt
+		f.Segments[0].Distributions[0].RolloutEndPercent = 40
+		f.PrepareEvaluation()
+
+		s := bucketCheck(&f, 10000, now).Segments[0]
+		assert.Len(t, s.Variants, 1)
+		assert.Equal(t, 2000.0, *s.Variants[0].Expected)
+		assert.NotNil(t, s.Unassigned)
+		assert.Equal(t, 8000.0, *s.Unassigned.Expected)
+		assert.Equal(t, int64(10000), *s.Variants[0].Observed+*s.Unassigned.Observed)
+		assert.Equal(t, int64(1), *s.DegreesOfFreedom)
+	})
+
+	t.Run("it should leave everyone unassigned without distributions", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		f.Segments[0].Distributions = nil
+		f.PrepareEvaluation()
+
+		s := bucketCheck(&f, 100, time.Now()).Segments[0]
+		assert.Empty(t, s.Variants)
+		assert.Equal(t, int64(100), *s.Unassigned.Observed)
+		assert.Equal(t, 0.0, *s.ChiSquare)
+		assert.Equal(t, int64(0), *s.DegreesOfFreedom)
+	})
+}
+
+func TestGetFlagBucketCheck(t *testing.T) {
+	db := entity.PopulateTestDB(entity.GenFixtureFlag())
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	t.Run("it should check the bucketing of the flag", func(t *testing.T) {
+		res := c.GetFlagBucketCheck(flag.GetFlagBucketCheckParams{FlagID: 100, N: util.Int64Ptr(1000)})
+		payload := res.(*flag.GetFlagBucketCheckOK).Payload
+		assert.Equal(t, int64(100), *payload.FlagID)
+		assert.Equal(t, int64(1000), *payload.N)
+		assert.Len(t, payload.Segments, 1)
+		assert.Len(t, payload.Segments[0].Variants, 2)
+	})
+
+	t.Run("it should fail on the missing flag", func(t *testing.T) {
+		res := c.GetFlagBucketCheck(flag.GetFlagBucketCheckParams{FlagID: 999})
+		assert.IsType(t, &flag.GetFlagBucketCheckDefault{}, res)
+	})
+}
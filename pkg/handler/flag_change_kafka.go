@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/sirupsen/logrus"
+)
+
+type flagChangeKafka struct {
+	producer sarama.AsyncProducer
+	topic    string
+
+	// ownsProducer is false when the producer of the kafka recorder is shared
+	ownsProducer bool
+}
+
+// newFlagChangeKafka creates the producer of the flag changes to FLAGR_FLAG_CHANGE_KAFKA_TOPIC. It shares the
+// producer of the kafka recorder if it's enabled, otherwise it connects with the FLAGR_RECORDER_KAFKA_* settings.
+var newFlagChangeKafka = func() *flagChangeKafka {
+	k := &flagChangeKafka{topic: config.Config.FlagChangeKafkaTopic}
+
+	if config.Config.RecorderEnabled {
+		if kr, ok := GetDataRecorder().(*kafkaRecorder); ok {
+			k.producer = kr.producer
+			return k
+		}
+	}
+
+	cfg := newKafkaConfig()
+	// a single in-flight request keeps the events of a flag in order when the produce requests are retried
+	cfg.Net.MaxOpenRequests = 1
+	k.producer = newKafkaProducer(cfg, "failed to produce the flag change event", nil)
+	k.ownsProducer = true
+	return k
+}
+
+// Notify produces the flag history keyed by the flag ID, so that the changes
+// of a flag land in the same partition in order. It never blocks.
+func (k *flagChangeKafka) Notify(fh *entity.FlagHistory) {
+	body, err := marshalFlagChangeEvent(fh)
+	if err != nil {
+		k.drop(err.Error())
+		return
+	}
+
+	select {
+	case k.producer.Input() <- &sarama.ProducerMessage{
+		Topic:     k.topic,
+		Key:       sarama.StringEncoder(strconv.FormatUint(uint64(fh.FlagID), 10)),
+		Value:     sarama.ByteEncoder(body),
+		Timestamp: fh.CreatedAt.UTC(),
+	}:
+	default:
+		k.drop("producer buffer is full")
+	}
+}
+
+// Close flushes the buffered events, the shared producer of the kafka recorder is left open
+func (k *flagChangeKafka) Close() error {
+	if !k.ownsProducer {
+		return nil
+	}
+	return k.producer.Close()
+}
+
+func (k *flagChangeKafka) drop(reason string) {
+	logrus.WithField("kafka_error", reason).Error("dropping the event of flag change kafka")
+
+	if config.Global.StatsdClient != nil {
+		config.Global.StatsdClient.Incr("flag_change_kafka.dropped", nil, float64(1))
+	}
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagChangeKafka(t *testing.T) {
+	db := entity.NewTestDB()
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.FlagChangeKafkaTopic, "flag-changes").Reset()
+
+	t.Run("it should produce the flag changes keyed by flag ID", func(t *testing.T) {
+		p := &mockAsyncProducer{inputCh: make(chan *sarama.ProducerMessage, 10)}
+		defer gostub.StubFunc(&saramaNewAsyncProducer, p, nil).Reset()
+
+		k := newFlagChangeKafka()
+		assert.True(t, k.ownsProducer)
+		defer gostub.Stub(&entity.FlagHistoryHook, k.Notify).Reset()
+
+		c := &crud{}
+		c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("funny flag"), Key: "flag_key_1"},
+		})
+		c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+			FlagID: int64(1),
+			Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true)},
+		})
+		assert.NoError(t, k.Close())
+
+		assert.Len(t, p.inputCh, 2)
+		for _, changeType := range []string{entity.FlagHistoryActionCreate, entity.FlagHistoryActionUpdate} {
+			msg := <-p.inputCh
+			assert.Equal(t, "flag-changes", msg.Topic)
+			assert.Equal(t, sarama.StringEncoder("1"), msg.Key)
+
+			value, _ := msg.Value.Encode()
+			ev := flagChangeEvent{}
+			assert.NoError(t, json.Unmarshal(value, &ev))
+			assert.Equal(t, changeType, ev.ChangeType)
+			assert.Equal(t, "flag_key_1", ev.FlagKey)
+		}
+	})
+
+	t.Run("it should drop the events when the producer buffer is full", func(t *testing.T) {
+		p := &mockAsyncProducer{inputCh: make(chan *sarama.ProducerMessage)}
+		k := &flagChangeKafka{producer: p, topic: "flag-changes"}
+
+		assert.NotPanics(t, func() { k.Notify(&entity.FlagHistory{FlagID: 1}) })
+		assert.Len(t, p.inputCh, 0)
+	})
+}
+
+func TestAddFlagHistoryHook(t *testing.T) {
+	defer gostub.Stub(&entity.FlagHistoryHook, (func(fh *entity.FlagHistory))(nil)).Reset()
+
+	notified := []string{}
+	addFlagHistoryHook(func(fh *entity.FlagHistory) { notified = append(notified, "webhook") })
+	addFlagHistoryHook(func(fh *entity.FlagHistory) { notified = append(notified, "kafka") })
+
+	entity.FlagHistoryHook(&entity.FlagHistory{})
+	assert.Equal(t, []string{"webhook", "kafka"}, notified)
+}
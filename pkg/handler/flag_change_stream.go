@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// flagChangeStreamBufferSize is the number of events buffered per client, a client that falls
+	// further behind is disconnected, so that it reconnects and gets the missed changes replayed
+	flagChangeStreamBufferSize = 100
+	// flagChangeStreamReplayLimit is the max number of changes replayed since Last-Event-ID
+	flagChangeStreamReplayLimit = 1000
+	// flagChangeStreamRetry is the reconnection time the clients are told to wait
+	flagChangeStreamRetry = 3 * time.Second
+)
+
+// flagChangeStreamEvent is the data of the server-sent events of the flag changes
+type flagChangeStreamEvent struct {
+	FlagID     uint      `json:"flagID"`
+	ChangeType string    `json:"changeType"`
+	EntityType string    `json:"entityType"`
+	EntityID   uint      `json:"entityID"`
+	Timestamp  time.Time `json:"timestamp"`
+
+	id        uint
+	namespace string
+}
+
+// flagChangeStream fans out the flag changes to the clients of GET /flags/stream
+type flagChangeStream struct {
+	lock        sync.Mutex
+	subscribers map[chan *flagChangeStreamEvent]struct{}
+	closed      bool
+	keepAlive   time.Duration
+}
+
+func newFlagChangeStream() *flagChangeStream {
+	return &flagChangeStream{
+		subscribers: make(map[chan *flagChangeStreamEvent]struct{}),
+		keepAlive:   config.Config.FlagChangeStreamKeepAliveInterval,
+	}
+}
+
+var flagChangeStreamer = newFlagChangeStream()
+
+// CloseFlagChangeStream ends the streams of the flag changes, the connections are long-lived,
+// so they have to be ended for the graceful shutdown of the server
+func CloseFlagChangeStream() {
+	flagChangeStreamer.Close()
+}
+
+func newFlagChangeStreamEvent(fh *entity.FlagHistory, namespace string) *flagChangeStreamEvent {
+	return &flagChangeStreamEvent{
+		FlagID:     fh.FlagID,
+		ChangeType: fh.Action,
+		EntityType: fh.EntityType,
+		EntityID:   fh.EntityID,
+		Timestamp:  fh.CreatedAt.UTC(),
+		id:         fh.ID,
+		namespace:  namespace,
+	}
+}
+
+// Notify sends the flag history to all the clients, it never blocks. A client with a full buffer is
+// disconnected instead of blocking the flag changes.
+func (s *flagChangeStream) Notify(fh *entity.FlagHistory) {
+	if s.subscriberCount() == 0 {
+		return
+	}
+
+	// the namespace is found before taking the lock, so that the query doesn't hold back the clients and the flag changes
+	namespace := ""
+	if namespacesEnabled() {
+		f := &entity.Flag{}
+		if err := getDB().Unscoped().Select("namespace").First(f, fh.FlagID).Error; err != nil {
+			logrus.WithFields(logrus.Fields{"err": err, "flagID": fh.FlagID}).Error("failed to find the flag of the flag change event")
+			return
+		}
+		namespace = f.Namespace
+	}
+	e := newFlagChangeStreamEvent(fh, namespace)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- e:
+		default:
+			logrus.WithField("flagID", fh.FlagID).Warn("disconnecting a slow client of the flag change stream")
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (s *flagChangeStream) subscriberCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.subscribers)
+}
+
+// subscribe adds a client of the stream, it's false if the stream is closed
+func (s *flagChangeStream) subscribe() (chan *flagChangeStreamEvent, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil, false
+	}
+	ch := make(chan *flagChangeStreamEvent, flagChangeStreamBufferSize)
+	s.subscribers[ch] = struct{}{}
+	return ch, true
+}
+
+func (s *flagChangeStream) unsubscribe(ch chan *flagChangeStreamEvent) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.subscribers[ch]; ok {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Close disconnects all the clients and rejects the new ones
+func (s *flagChangeStream) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	for ch := range s.subscribers {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+}
+
+// GetFlagsStream streams the flag changes as server-sent events until the client disconnects.
+// The changes since Last-Event-ID are replayed first, so that a reconnecting client misses none.
+func (s *flagChangeStream) GetFlagsStream(params flag.GetFlagsStreamParams) middleware.Responder {
+	ch, ok := s.subscribe()
+	if !ok {
+		return flag.NewGetFlagsStreamDefault(503).WithPayload(ErrorMessage("the flag change stream is closed"))
+	}
+
+	replay := []*flagChangeStreamEvent{}
+	if params.LastEventID != nil {
+		var err error
+		if replay, err = findFlagChangeStreamReplay(params.HTTPRequest, uint(*params.LastEventID)); err != nil {
+			s.unsubscribe(ch)
+			return flag.NewGetFlagsStreamDefault(500).WithPayload(ErrorMessage("cannot find the flag changes to replay. %s", err))
+		}
+	}
+
+	namespace := getNamespaceFromRequest(params.HTTPRequest)
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		defer s.unsubscribe(ch)
+
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.Header().Set("Cache-Control", "no-cache")
+		// the gzip and brotli middlewares buffer the events, they skip the responses already encoded
+		rw.Header().Set("Content-Encoding", "identity")
+		rw.Header().Set("X-Accel-Buffering", "no")
+		rw.WriteHeader(http.StatusOK)
+
+		flusher, _ := rw.(http.Flusher)
+		write := func(format string, a ...interface{}) bool {
+			if _, err := fmt.Fprintf(rw, format, a...); err != nil {
+				return false
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return true
+		}
+
+		if !write("retry: %d\n\n", int64(flagChangeStreamRetry/time.Millisecond)) {
+			return
+		}
+		lastID := uint(0)
+		for _, e := range replay {
+			if !writeFlagChangeStreamEvent(write, e) {
+				return
+			}
+			lastID = e.id
+		}
+
+		var keepAlive <-chan time.Time
+		if s.keepAlive > 0 {
+			ticker := time.NewTicker(s.keepAlive)
+			defer ticker.Stop()
+			keepAlive = ticker.C
+		}
+
+		for {
+			select {
+			case <-requestContext(params.HTTPRequest).Done():
+				return
+			case <-keepAlive:
+				if !write(": keep-alive\n\n") {
+					return
+				}
+			case e, ok := <-ch:
+				if !ok {
+					return
+				}
+				if e.id <= lastID || e.namespace != namespace {
+					continue
+				}
+				if !writeFlagChangeStreamEvent(write, e) {
+					return
+				}
+			}
+		}
+	})
+}
+
+func writeFlagChangeStreamEvent(write func(format string, a ...interface{}) bool, e *flagChangeStreamEvent) bool {
+	data, err := json.Marshal(e)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "flagID": e.FlagID}).Error("failed to marshal the flag change event")
+		return true
+	}
+	return write("id: %d\ndata: %s\n\n", e.id, data)
+}
+
+// findFlagChangeStreamReplay finds the flag changes after the flag history lastID in the namespace of the caller
+func findFlagChangeStreamReplay(r *http.Request, lastID uint) ([]*flagChangeStreamEvent, error) {
+	tx := getRequestDB(r).Where("id > ?", lastID)
+	namespace := getNamespaceFromRequest(r)
+	if namespacesEnabled() {
+		flagIDs := getDB().Unscoped().Model(&entity.Flag{}).Select("id").Where("namespace = ?", namespace).SubQuery()
+		tx = tx.Where("flag_id IN ?", flagIDs)
+	}
+
+	fhs := []entity.FlagHistory{}
+	if err := tx.Order("id").Limit(flagChangeStreamReplayLimit).Find(&fhs).Error; err != nil {
+		return nil, err
+	}
+	replay := make([]*flagChangeStreamEvent, len(fhs))
+	for i := range fhs {
+		replay[i] = newFlagChangeStreamEvent(&fhs[i], namespace)
+	}
+	return replay, nil
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/go-openapi/runtime"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagChangeStream(t *testing.T) {
+	db := entity.NewTestDB()
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	s := newFlagChangeStream()
+	defer gostub.Stub(&entity.FlagHistoryHook, s.Notify).Reset()
+
+	server := newFlagChangeStreamServer(s)
+	defer server.Close()
+
+	connect := func(t *testing.T, server *httptest.Server, lastEventID string) (*http.Response, *bufio.Reader) {
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+		r := bufio.NewReader(res.Body)
+		assert.Equal(t, "retry: 3000\n\n", readFlagChangeStreamMessage(t, r))
+		return res, r
+	}
+	waitSubscribers := func(n int) {
+		for i := 0; i < 100; i++ {
+			s.lock.Lock()
+			l := len(s.subscribers)
+			s.lock.Unlock()
+			if l == n {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("expected %d subscribers", n)
+	}
+
+	t.Run("it streams the flag changes", func(t *testing.T) {
+		res, r := connect(t, server, "")
+		defer res.Body.Close()
+		waitSubscribers(1)
+
+		entity.SaveFlagHistory(db, 1, "flagr-admin", entity.FlagHistoryEntityTypeFlag, 1, nil, map[string]string{"Key": "flag_1"})
+		msg := readFlagChangeStreamMessage(t, r)
+		assert.Contains(t, msg, "id: 1\n")
+		assert.Contains(t, msg, `"flagID":1`)
+		assert.Contains(t, msg, `"changeType":"create"`)
+	})
+
+	t.Run("it replays the flag changes since Last-Event-ID", func(t *testing.T) {
+		entity.SaveFlagHistory(db, 2, "flagr-admin", entity.FlagHistoryEntityTypeFlag, 2, nil, map[string]string{"Key": "flag_2"})
+		waitSubscribers(0)
+
+		res, r := connect(t, server, "1")
+		defer res.Body.Close()
+		assert.Contains(t, readFlagChangeStreamMessage(t, r), `"flagID":2`)
+	})
+
+	t.Run("it sends the keep-alive comments", func(t *testing.T) {
+		s := newFlagChangeStream()
+		s.keepAlive = 10 * time.Millisecond
+		server := newFlagChangeStreamServer(s)
+		defer server.Close()
+
+		res, r := connect(t, server, "")
+		defer res.Body.Close()
+		assert.Equal(t, ": keep-alive\n\n", readFlagChangeStreamMessage(t, r))
+	})
+
+	t.Run("it unsubscribes the disconnected clients", func(t *testing.T) {
+		res, _ := connect(t, server, "")
+		waitSubscribers(1)
+		res.Body.Close()
+		waitSubscribers(0)
+	})
+
+	t.Run("it disconnects the slow clients", func(t *testing.T) {
+		defer gostub.Stub(&flagChangeStreamBufferSize, 0).Reset()
+
+		ch, ok := s.subscribe()
+		assert.True(t, ok)
+		s.Notify(&entity.FlagHistory{FlagID: 1})
+		_, open := <-ch
+		assert.False(t, open)
+	})
+
+	t.Run("it ends the streams on close", func(t *testing.T) {
+		res, r := connect(t, server, "")
+		defer res.Body.Close()
+		waitSubscribers(1)
+
+		s.Close()
+		_, err := r.ReadString('\n')
+		assert.Error(t, err)
+
+		_, ok := s.subscribe()
+		assert.False(t, ok)
+		res2, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, res2.StatusCode)
+		res2.Body.Close()
+	})
+}
+
+func TestFindFlagChangeStreamReplay(t *testing.T) {
+	db := entity.NewTestDB()
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+
+	db.Create(&entity.Flag{Key: "checkout_flag", Namespace: "checkout"})
+	db.Create(&entity.Flag{Key: "search_flag", Namespace: "search"})
+	for _, flagID := range []uint{1, 2, 1} {
+		entity.SaveFlagHistory(db, flagID, "flagr-admin", entity.FlagHistoryEntityTypeFlag, flagID, nil, map[string]uint{"ID": flagID})
+	}
+
+	replay, err := findFlagChangeStreamReplay(genNamespaceRequest("checkout"), 0)
+	assert.NoError(t, err)
+	assert.Len(t, replay, 2)
+	assert.Equal(t, uint(3), replay[1].id)
+	assert.Equal(t, "checkout", replay[1].namespace)
+
+	replay, err = findFlagChangeStreamReplay(genNamespaceRequest("search"), 2)
+	assert.NoError(t, err)
+	assert.Len(t, replay, 0)
+}
+
+func newFlagChangeStreamServer(s *flagChangeStream) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := flag.GetFlagsStreamParams{HTTPRequest: r}
+		if id := r.Header.Get("Last-Event-ID"); id != "" {
+			params.LastEventID = util.Int64Ptr(int64(util.SafeUint(id)))
+		}
+		s.GetFlagsStream(params).WriteResponse(w, runtime.TextProducer())
+	}))
+}
+
+// readFlagChangeStreamMessage reads the lines of the stream up to the blank line ending a message
+func readFlagChangeStreamMessage(t *testing.T, r *bufio.Reader) string {
+	var sb strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return sb.String()
+		}
+		sb.WriteString(line)
+		if line == "\n" {
+			return sb.String()
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// FlagChangeWebhookSignatureHeader is the header of the HMAC-SHA256 signature of the webhook body
+const FlagChangeWebhookSignatureHeader = "X-Flagr-Signature"
+
+// flagChangeEvent is the body of the flag change webhook, and the value of the flag change kafka messages
+type flagChangeEvent struct {
+	ID         uint            `json:"id"`
+	FlagID     uint            `json:"flagID"`
+	FlagKey    string          `json:"flagKey"`
+	ChangeType string          `json:"changeType"`
+	EntityType string          `json:"entityType"`
+	EntityID   uint            `json:"entityID"`
+	Actor      string          `json:"actor"`
+	Note       string          `json:"note,omitempty"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Diff       json.RawMessage `json:"diff"`
+}
+
+type flagChangeWebhook struct {
+	url           string
+	secret        string
+	retryAttempts uint
+	retryDelay    time.Duration
+	client        *http.Client
+
+	events    chan []byte
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// newFlagChangeWebhook creates the webhook that POSTs the flag changes asynchronously
+var newFlagChangeWebhook = func() *flagChangeWebhook {
+	w := &flagChangeWebhook{
+		url:           config.Config.FlagChangeWebhookURL,
+		secret:        config.Config.FlagChangeWebhookSecret,
+		retryAttempts: config.Config.FlagChangeWebhookRetryAttempts,
+		retryDelay:    config.Config.FlagChangeWebhookRetryDelay,
+		client:        &http.Client{Timeout: config.Config.FlagChangeWebhookTimeout},
+		events:        make(chan []byte, config.Config.FlagChangeWebhookBufferSize),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+
+	go w.run()
+	return w
+}
+
+// Notify queues the flag history to be POSTed, it never blocks
+func (w *flagChangeWebhook) Notify(fh *entity.FlagHistory) {
+	body, err := marshalFlagChangeEvent(fh)
+	if err != nil {
+		w.drop(err.Error())
+		return
+	}
+
+	select {
+	case <-w.done:
+		w.drop("webhook is closed")
+	case w.events <- body:
+	default:
+		w.drop("buffer is full")
+	}
+}
+
+// marshalFlagChangeEvent marshals the event of the flag history, the flag key is
+// left empty if the flag can't be found
+func marshalFlagChangeEvent(fh *entity.FlagHistory) ([]byte, error) {
+	f := &entity.Flag{}
+	if err := getDB().Unscoped().First(f, fh.FlagID).Error; err != nil {
+		logrus.WithFields(logrus.Fields{"err": err, "flagID": fh.FlagID}).Error("failed to find the flag of the flag change event")
+	}
+
+	return json.Marshal(flagChangeEvent{
+		ID:         fh.ID,
+		FlagID:     fh.FlagID,
+		FlagKey:    f.Key,
+		ChangeType: fh.Action,
+		EntityType: fh.EntityType,
+		EntityID:   fh.EntityID,
+		Actor:      fh.Actor,
+		Note:       fh.Note,
+		Timestamp:  fh.CreatedAt.UTC(),
+		Diff:       json.RawMessage(fh.Diff),
+	})
+}
+
+// Close stops accepting new events and delivers the ones in the buffer
+func (w *flagChangeWebhook) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		<-w.stopped
+	})
+	return nil
+}
+
+func (w *flagChangeWebhook) run() {
+	defer close(w.stopped)
+
+	for {
+		select {
+		case body := <-w.events:
+			w.post(body)
+		case <-w.done:
+			for {
+				select {
+				case body := <-w.events:
+					w.post(body)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+type flagChangeWebhookRetryableError struct{ error }
+
+func (w *flagChangeWebhook) post(body []byte) {
+	signature := signFlagChangeWebhook(w.secret, body)
+	err := retry.Do(
+		func() error {
+			req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(FlagChangeWebhookSignatureHeader, signature)
+
+			resp, err := w.client.Do(req)
+			if err != nil {
+				return flagChangeWebhookRetryableError{err}
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+				return flagChangeWebhookRetryableError{fmt.Errorf("webhook responded with %d", resp.StatusCode)}
+			}
+			if resp.StatusCode >= 300 {
+				return fmt.Errorf("webhook responded with %d", resp.StatusCode)
+			}
+			return nil
+		},
+		retry.Attempts(w.retryAttempts),
+		retry.Delay(w.retryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.RetryIf(func(err error) bool {
+			_, ok := err.(flagChangeWebhookRetryableError)
+			return ok
+		}),
+		retry.LastErrorOnly(true),
+	)
+	if err != nil {
+		w.drop(err.Error())
+	}
+}
+
+func (w *flagChangeWebhook) drop(reason string) {
+	logrus.WithField("webhook_error", reason).Error("dropping the event of flag change webhook")
+
+	if config.Global.StatsdClient != nil {
+		config.Global.StatsdClient.Incr("flag_change_webhook.dropped", nil, float64(1))
+	}
+}
+
+// signFlagChangeWebhook returns the signature of the body in the form of sha256=<hex of HMAC-SHA256>
+func signFlagChangeWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
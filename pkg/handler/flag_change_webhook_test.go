@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagChangeWebhook(t *testing.T) {
+	db := entity.NewTestDB()
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.FlagChangeWebhookSecret, "secret1").Reset()
+	defer gostub.Stub(&config.Config.FlagChangeWebhookRetryDelay, time.Millisecond).Reset()
+
+	t.Run("it should post the signed flag changes", func(t *testing.T) {
+		var mu sync.Mutex
+		events := []flagChangeEvent{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			body, _ := ioutil.ReadAll(req.Body)
+			assert.Equal(t, signFlagChangeWebhook("secret1", body), req.Header.Get(FlagChangeWebhookSignatureHeader))
+			ev := flagChangeEvent{}
+			assert.NoError(t, json.Unmarshal(body, &ev))
+			mu.Lock()
+			events = append(events, ev)
+			mu.Unlock()
+		}))
+		defer server.Close()
+		defer gostub.Stub(&config.Config.FlagChangeWebhookURL, server.URL).Reset()
+
+		w := newFlagChangeWebhook()
+		defer gostub.Stub(&entity.FlagHistoryHook, w.Notify).Reset()
+
+		c := &crud{}
+		c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{Description: util.StringPtr("funny flag"), Key: "flag_key_1"},
+		})
+		c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+			FlagID: int64(1),
+			Body:   &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true), Note: "launch"},
+		})
+		assert.NoError(t, w.Close())
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Len(t, events, 2)
+		assert.Equal(t, entity.FlagHistoryActionCreate, events[0].ChangeType)
+		assert.Equal(t, "flag_key_1", events[0].FlagKey)
+		assert.Equal(t, entity.FlagHistoryActionUpdate, events[1].ChangeType)
+		assert.Equal(t, entity.FlagHistoryEntityTypeFlag, events[1].EntityType)
+		assert.Equal(t, uint(1), events[1].FlagID)
+		assert.Equal(t, "launch", events[1].Note)
+		assert.Contains(t, string(events[1].Diff), "Enabled")
+		assert.False(t, events[1].Timestamp.IsZero())
+	})
+
+	t.Run("it should retry the server errors only", func(t *testing.T) {
+		var mu sync.Mutex
+		attempts := 0
+		statusCodes := []int{500, 503, 200, 400}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			w.WriteHeader(statusCodes[attempts])
+			attempts++
+		}))
+		defer server.Close()
+		defer gostub.Stub(&config.Config.FlagChangeWebhookURL, server.URL).Reset()
+
+		w := newFlagChangeWebhook()
+		w.Notify(&entity.FlagHistory{FlagID: 1, Action: entity.FlagHistoryActionUpdate, Diff: []byte("{}")})
+		w.Notify(&entity.FlagHistory{FlagID: 1, Action: entity.FlagHistoryActionUpdate, Diff: []byte("{}")})
+		assert.NoError(t, w.Close())
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("it should drop the events after it's closed", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.FlagChangeWebhookURL, "http://localhost:0").Reset()
+
+		w := newFlagChangeWebhook()
+		assert.NoError(t, w.Close())
+		assert.NotPanics(t, func() {
+			w.Notify(&entity.FlagHistory{FlagID: 1, Diff: []byte("{}")})
+		})
+	})
+}
+
+func TestSignFlagChangeWebhook(t *testing.T) {
+	// echo -n '{}' | openssl dgst -sha256 -hmac secret1
+	assert.Equal(t,
+		"sha256=228d8320ad0c175ae86fae4db81b371769afb52c3c6e943a4df7a271586681cc",
+		signFlagChangeWebhook("secret1", []byte("{}")),
+	)
+}
@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/mapper/entity_restapi/r2e"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/jinzhu/gorm"
+)
+
+// saveFlagDefinition creates the flag of the definition, or updates the flag if its key exists.
+// The variants are matched by their keys so that their ids are kept, and the segments are replaced.
+// It returns the flag before the update, which is nil if the flag is created.
+// The flag is created in the namespace, and the flags of the other namespaces are never updated.
+// It's not committed, tx is expected to be a transaction.
+var saveFlagDefinition = func(tx *gorm.DB, def *models.FlagDefinition, actor string, namespace string) (f *entity.Flag, before *entity.Flag, e *Error) {
+	if def == nil {
+		return nil, nil, NewError(400, "empty flag definition")
+	}
+	if e := validateSegmentsLimit(len(def.Segments)); e != nil {
+		return nil, nil, e
+	}
+	if e := validateVariantsLimit(len(def.Variants)); e != nil {
+		return nil, nil, e
+	}
+
+	f = &entity.Flag{}
+	if def.Key != "" {
+		err := tx.Where(entity.Flag{Key: def.Key}).First(f).Error
+		if err != nil && !gorm.IsRecordNotFoundError(err) {
+			return nil, nil, NewError(500, "error finding flag %s. reason: %s", def.Key, err)
+		}
+		if err == nil && f.Namespace != namespace {
+			return nil, nil, NewError(409, "flag key %s already exists", def.Key)
+		}
+		if err == nil {
+			b := *f
+			before = &b
+		}
+	}
+	if before == nil {
+		key, err := entity.CreateFlagKey(def.Key)
+		if err != nil {
+			return nil, nil, NewError(400, "%s", err)
+		}
+		f.Key = key
+		f.CreatedBy = actor
+		f.Namespace = namespace
+	}
+
+	enabled := def.Enabled
+	if before != nil && !enabled {
+		var err error
+		if enabled, err = isFlagEnabledAnywhere(tx, before); err != nil {
+			return nil, nil, NewError(500, "error finding the environments of flag %s. reason: %s", f.Key, err)
+		}
+	}
+	if e := validateWithoutApproval(enabled, "save flag %s", f.Key); e != nil {
+		return nil, nil, e
+	}
+
+	f.Description = util.SafeString(def.Description)
+	f.Enabled = def.Enabled
+	f.Notes = def.Notes
+	f.DataRecordsEnabled = def.DataRecordsEnabled
+	f.BucketingSeed = def.BucketingSeed
+	if err := entity.ValidateBucketingAlgorithm(def.BucketingAlgorithm); err != nil {
+		return nil, nil, NewError(400, "%s", err)
+	}
+	f.BucketingAlgorithm = def.BucketingAlgorithm
+	f.BucketBy = def.BucketBy
+	f.DefaultVariantID = 0
+	if def.EntityType != "" {
+		if err := entity.CreateFlagEntityType(tx, def.EntityType); err != nil {
+			return nil, nil, NewError(400, "%s", err)
+		}
+	}
+	f.EntityType = def.EntityType
+
+	schema, err := r2eMapAttachmentSchema(def.AttachmentSchema)
+	if err != nil {
+		return nil, nil, NewError(400, "%s", err)
+	}
+	if err := entity.ValidateAttachmentSchema(schema); err != nil {
+		return nil, nil, NewError(400, "%s", err)
+	}
+	f.AttachmentSchema = schema
+
+	if err := tx.Save(f).Error; err != nil {
+		return nil, nil, NewError(500, "error saving flag %s. reason: %s", f.Key, err)
+	}
+
+	variantIDs, e := saveVariantDefinitions(tx, f.ID, def.Variants, schema)
+	if e != nil {
+		return nil, nil, e
+	}
+	if e := saveSegmentDefinitions(tx, f.ID, def.Segments, variantIDs); e != nil {
+		return nil, nil, e
+	}
+
+	if def.DefaultVariantKey != "" {
+		vID, ok := variantIDs[def.DefaultVariantKey]
+		if !ok {
+			return nil, nil, NewError(400, "error finding the default variant %s in the variants", def.DefaultVariantKey)
+		}
+		f.DefaultVariantID = vID
+	}
+
+	tags := []entity.Tag{}
+	for _, value := range def.Tags {
+		t, err := entity.CreateTag(tx, value)
+		if err != nil {
+			return nil, nil, NewError(400, "%s", err)
+		}
+		tags = append(tags, *t)
+	}
+	if err := tx.Model(f).Association("Tags").Replace(tags).Error; err != nil {
+		return nil, nil, NewError(500, "error saving the tags of flag %s. reason: %s", f.Key, err)
+	}
+
+	if err := tx.Save(f).Error; err != nil {
+		return nil, nil, NewError(500, "error saving flag %s. reason: %s", f.Key, err)
+	}
+	if err := entity.PreloadSegmentsVariants(tx).First(f, f.ID).Error; err != nil {
+		return nil, nil, NewError(500, "error loading flag %s. reason: %s", f.Key, err)
+	}
+	return f, before, nil
+}
+
+// createFlagFromDefinition creates a new flag from the definition and records its history and snapshot.
+// Unlike saveFlagDefinition, it fails if the key is used by any flag, including the soft deleted ones.
+func createFlagFromDefinition(db *gorm.DB, def *models.FlagDefinition, actor string, namespace string) (*entity.Flag, *Error) {
+	if def.Key != "" {
+		exists, err := flagKeyExists(db, def.Key)
+		if err != nil {
+			return nil, NewError(500, "error finding flag %s. reason: %s", def.Key, err)
+		}
+		if exists {
+			return nil, NewError(409, "flag key %s already exists", def.Key)
+		}
+	}
+
+	tx := db.Begin()
+	f, _, e := saveFlagDefinition(tx, def, actor, namespace)
+	if e != nil {
+		tx.Rollback()
+		return nil, e
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, NewError(500, "error creating flag %s. reason: %s", f.Key, err)
+	}
+
+	after := *f
+	after.Segments, after.Variants, after.Tags = nil, nil, nil
+	entity.SaveFlagHistory(db, f.ID, actor, entity.FlagHistoryEntityTypeFlag, f.ID, nil, &after)
+	entity.SaveFlagSnapshot(db, f.ID, actor)
+	return f, nil
+}
+
+func flagKeyExists(db *gorm.DB, key string) (bool, error) {
+	count := 0
+	err := db.Unscoped().Model(&entity.Flag{}).Where(entity.Flag{Key: key}).Count(&count).Error
+	return count > 0, err
+}
+
+// findCloneFlagKey suffixes the key with _clone, and then _clone_2, _clone_3... until it's not used
+func findCloneFlagKey(db *gorm.DB, key string) (string, error) {
+	cloneKey := key + "_clone"
+	for i := 2; ; i++ {
+		exists, err := flagKeyExists(db, cloneKey)
+		if err != nil || !exists {
+			return cloneKey, err
+		}
+		cloneKey = fmt.Sprintf("%s_clone_%d", key, i)
+	}
+}
+
+// saveVariantDefinitions upserts the variants by their keys and deletes the ones
+// that are not in the definitions. The attachments are validated against the schema.
+// It returns the variant ids by keys.
+func saveVariantDefinitions(tx *gorm.DB, flagID uint, defs []*models.CreateVariantRequest, schema string) (map[string]uint, *Error) {
+	existing := []entity.Variant{}
+	if err := tx.Where(entity.Variant{FlagID: flagID}).Find(&existing).Error; err != nil {
+		return nil, NewError(500, "error finding the variants. reason: %s", err)
+	}
+	existingByKey := make(map[string]entity.Variant, len(existing))
+	for _, v := range existing {
+		existingByKey[v.Key] = v
+	}
+
+	variantIDs := make(map[string]uint, len(defs))
+	for _, vd := range defs {
+		key := util.SafeString(vd.Key)
+		if _, ok := variantIDs[key]; ok {
+			return nil, NewError(400, "duplicate variant key %s", key)
+		}
+
+		v := existingByKey[key]
+		v.FlagID = flagID
+		v.Key = key
+		a, err := r2eMapAttachment(vd.Attachment)
+		if err != nil {
+			return nil, NewError(400, "%s", err)
+		}
+		v.Attachment = a
+		v.AttachmentTypes = entity.AttachmentTypes(vd.AttachmentTypes)
+		if err := v.Validate(); err != nil {
+			return nil, NewError(400, "%s", err)
+		}
+		if err := v.Attachment.ValidateSchema(schema); err != nil {
+			return nil, NewError(400, "error validating variant %s. reason: %s", key, err)
+		}
+		if err := tx.Save(&v).Error; err != nil {
+			return nil, NewError(500, "error saving variant %s. reason: %s", key, err)
+		}
+		variantIDs[key] = v.ID
+	}
+
+	for _, v := range existing {
+		if _, ok := variantIDs[v.Key]; ok {
+			continue
+		}
+		if err := tx.Delete(entity.Variant{}, v.ID).Error; err != nil {
+			return nil, NewError(500, "error deleting variant %s. reason: %s", v.Key, err)
+		}
+	}
+	return variantIDs, nil
+}
+
+// saveSegmentDefinitions replaces the segments of the flag, the rank follows the order of the definitions
+func saveSegmentDefinitions(tx *gorm.DB, flagID uint, defs []*models.SegmentDefinition, variantIDs map[string]uint) *Error {
+	existing := []entity.Segment{}
+	if err := tx.Where(entity.Segment{FlagID: flagID}).Find(&existing).Error; err != nil {
+		return NewError(500, "error finding the segments. reason: %s", err)
+	}
+	for _, s := range existing {
+		if err := tx.Delete(entity.Constraint{}, "segment_id = ?", s.ID).Error; err != nil {
+			return NewError(500, "error deleting the constraints of segment %v. reason: %s", s.ID, err)
+		}
+		if err := tx.Delete(entity.Distribution{}, "segment_id = ?", s.ID).Error; err != nil {
+			return NewError(500, "error deleting the distributions of segment %v. reason: %s", s.ID, err)
+		}
+		if err := tx.Delete(entity.Segment{}, s.ID).Error; err != nil {
+			return NewError(500, "error deleting segment %v. reason: %s", s.ID, err)
+		}
+	}
+
+	for i, sd := range defs {
+		s, e := mapSegmentDefinition(sd)
+		if e != nil {
+			return NewError(e.StatusCode, "invalid segment %d. reason: %s", i, fmt.Sprintf(e.Message, e.Values...))
+		}
+		s.FlagID = flagID
+		s.Rank = uint(i)
+		for j := range s.Distributions {
+			d := &s.Distributions[j]
+			vID, ok := variantIDs[d.VariantKey]
+			if !ok {
+				return NewError(400, "error finding variantKey %s of the distribution in segment %d", d.VariantKey, i)
+			}
+			d.VariantID = vID
+		}
+		if err := tx.Create(s).Error; err != nil {
+			return NewError(500, "error creating segment %d. reason: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// mapSegmentDefinition validates the segment definition and maps it with its constraints and distributions,
+// the distributions only have the variant keys and the variant IDs are left for the caller to fill in
+func mapSegmentDefinition(sd *models.SegmentDefinition) (*entity.Segment, *Error) {
+	if e := validateConstraintsLimit(len(sd.Constraints)); e != nil {
+		return nil, e
+	}
+	s := &entity.Segment{
+		Description:    util.SafeString(sd.Description),
+		RolloutPercent: util.SafeUint(sd.RolloutPercent),
+		Constraints:    make(entity.ConstraintArray, len(sd.Constraints)),
+		Distributions:  make([]entity.Distribution, len(sd.Distributions)),
+	}
+
+	for i, cd := range sd.Constraints {
+		s.Constraints[i] = entity.Constraint{
+			Property: util.SafeString(cd.Property),
+			Operator: util.SafeString(cd.Operator),
+			Value:    util.SafeString(cd.Value),
+		}
+		if err := s.Constraints[i].Validate(); err != nil {
+			return nil, NewError(400, "invalid constraint %d. reason: %s", i, err)
+		}
+	}
+
+	if len(sd.Distributions) == 0 {
+		return s, nil
+	}
+	sum := int64(0)
+	rampingCount := 0
+	for i, dd := range sd.Distributions {
+		d := &s.Distributions[i]
+		d.VariantKey = util.SafeString(dd.VariantKey)
+		d.Percent = util.SafeUint(dd.Percent)
+		r2e.MapDistributionRolloutSchedule(dd.RolloutSchedule, d)
+		if err := d.ValidateRolloutSchedule(); err != nil {
+			return nil, NewError(400, "invalid distribution %d. reason: %s", i, err)
+		}
+		if d.HasRolloutSchedule() {
+			rampingCount++
+		}
+		sum += int64(d.Percent)
+	}
+	if sum != 100 {
+		return nil, NewError(400, "the sum of distributions' percent %v is not 100", sum)
+	}
+	if rampingCount > 1 {
+		return nil, NewError(400, "more than one distribution has a rollout schedule")
+	}
+	return s, nil
+}
+
+// resolveSnapshotDistributions makes sure the distributions of the snapshot
+// reference the variants of the same snapshot, the variant keys of the
+// distributions are set from the referenced variants
+func resolveSnapshotDistributions(snapshot *entity.Flag) *Error {
+	variantKeys := make(map[uint]string, len(snapshot.Variants))
+	for _, v := range snapshot.Variants {
+		variantKeys[v.ID] = v.Key
+	}
+	for i := range snapshot.Segments {
+		ds := snapshot.Segments[i].Distributions
+		for j := range ds {
+			key, ok := variantKeys[ds[j].VariantID]
+			if !ok {
+				return NewError(400, "error finding variant %v of distribution %v in the snapshot", ds[j].VariantID, ds[j].ID)
+			}
+			ds[j].VariantKey = key
+		}
+	}
+	return nil
+}
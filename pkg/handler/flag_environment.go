@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
+)
+
+// environmentHeader sets the environment of the evaluations, the environment in the body takes precedence over it
+const environmentHeader = "X-Flagr-Environment"
+
+// getEnvironmentFromRequest gets the environment of the evaluation, it's the
+// one in the body if it's set, otherwise it's the one of the header
+func getEnvironmentFromRequest(r *http.Request, env string) string {
+	if env != "" || r == nil {
+		return env
+	}
+	return strings.TrimSpace(r.Header.Get(environmentHeader))
+}
+
+// validateEnvironment checks the name of the environment, e.g. prod or staging
+func validateEnvironment(env string) *Error {
+	if ok, reason := util.IsSafeKey(env); !ok {
+		return NewError(400, "invalid environment. reason: %s", reason)
+	}
+	return nil
+}
+
+// PromoteFlagEnvironment copies the enabled state and the distributions of the flag in the environment to another
+// environment, the flag's own config is copied if it has no config of the environment. The config of the target
+// environment is replaced as a whole, so the segments without distributions in the source are pinned to their own.
+func (c *crud) PromoteFlagEnvironment(params flag.PromoteFlagEnvironmentParams) middleware.Responder {
+	from, to := params.Environment, util.SafeString(params.Body.To)
+	for _, env := range []string{from, to} {
+		if e := validateEnvironment(env); e != nil {
+			return flag.NewPromoteFlagEnvironmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+		}
+	}
+	if from == to {
+		return flag.NewPromoteFlagEnvironmentDefault(400).WithPayload(
+			ErrorMessage("cannot promote the environment %s to itself", from))
+	}
+
+	db := getRequestDB(params.HTTPRequest)
+	f := &entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(db).First(f, params.FlagID).Error; err != nil {
+		return flag.NewPromoteFlagEnvironmentDefault(404).WithPayload(
+			ErrorMessage("cannot find flag %v. %s", params.FlagID, err))
+	}
+
+	src := f.InEnvironment(from)
+	if e := validateWithoutApproval(src.Enabled, "promote the environment %s of flag %v to %s", from, f.ID, to); e != nil {
+		return flag.NewPromoteFlagEnvironmentDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	var before interface{}
+	fe := f.Environment(to)
+	if fe != nil {
+		before = flagEnvironmentHistory(fe)
+	} else {
+		fe = &entity.FlagEnvironment{FlagID: f.ID, Environment: to}
+	}
+
+	tx := db.Begin()
+	if fe.ID == 0 {
+		fe.Enabled = src.Enabled
+		if err := tx.Create(fe).Error; err != nil {
+			tx.Rollback()
+			return flag.NewPromoteFlagEnvironmentDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+	} else {
+		err := tx.Model(&entity.FlagEnvironment{}).Where("id = ?", fe.ID).Update("enabled", src.Enabled).Error
+		if err == nil {
+			err = tx.Delete(entity.FlagEnvironmentDistribution{}, "flag_environment_id = ?", fe.ID).Error
+		}
+		if err != nil {
+			tx.Rollback()
+			return flag.NewPromoteFlagEnvironmentDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		fe.Enabled = src.Enabled
+	}
+
+	fe.Distributions = []entity.FlagEnvironmentDistribution{}
+	for _, s := range src.Segments {
+		for _, d := range s.Distributions {
+			ed := entity.FlagEnvironmentDistribution{
+				FlagEnvironmentID: fe.ID,
+				SegmentID:         s.ID,
+				VariantID:         d.VariantID,
+				VariantKey:        d.VariantKey,
+				Percent:           d.Percent,
+			}
+			if err := tx.Create(&ed).Error; err != nil {
+				tx.Rollback()
+				return flag.NewPromoteFlagEnvironmentDefault(500).WithPayload(ErrorMessage("%s", err))
+			}
+			fe.Distributions = append(fe.Distributions, ed)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return flag.NewPromoteFlagEnvironmentDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	actor := getSubjectFromRequest(params.HTTPRequest)
+	entity.SaveFlagHistoryWithNote(getDB(), f.ID, actor, params.Body.Note, entity.FlagHistoryEntityTypeEnvironment, fe.ID, before, flagEnvironmentHistory(fe))
+	entity.SaveFlagSnapshot(getDB(), f.ID, actor)
+
+	promoted := &entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(db).First(promoted, f.ID).Error; err != nil {
+		return flag.NewPromoteFlagEnvironmentDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	payload, err := e2rMapFlag(promoted.InEnvironment(to))
+	if err != nil {
+		return flag.NewPromoteFlagEnvironmentDefault(500).WithPayload(ErrorMessage("cannot map flag. %s", err))
+	}
+	resp := flag.NewPromoteFlagEnvironmentOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+// findFlagEnvironment finds the config of the flag in the environment with its distributions. It's false if the
+// flag has no config of the environment yet, and the config is a new one with the flag's own enabled state then.
+func findFlagEnvironment(db *gorm.DB, f *entity.Flag, env string) (*entity.FlagEnvironment, bool, error) {
+	fe := &entity.FlagEnvironment{}
+	err := db.
+		Preload("Distributions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("variant_id ASC")
+		}).
+		Where(entity.FlagEnvironment{FlagID: f.ID, Environment: env}).
+		First(fe).
+		Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &entity.FlagEnvironment{FlagID: f.ID, Environment: env, Enabled: f.Enabled}, false, nil
+	}
+	return fe, err == nil, err
+}
+
+// setFlagEnvironmentEnabled sets the enabled state of the flag in the environment, the flag's own is untouched.
+// It returns the flag as it's configured in the environment.
+func setFlagEnvironmentEnabled(db *gorm.DB, flagID uint, env string, enabled bool, actor string, note string) (*entity.Flag, *Error) {
+	if e := validateEnvironment(env); e != nil {
+		return nil, e
+	}
+	f := &entity.Flag{}
+	if err := db.First(f, flagID).Error; err != nil {
+		return nil, NewError(404, "%s", err)
+	}
+
+	fe, found, err := findFlagEnvironment(db, f, env)
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	var before interface{}
+	if found {
+		before = flagEnvironmentHistory(fe)
+		err = db.Model(&entity.FlagEnvironment{}).Where("id = ?", fe.ID).Update("enabled", enabled).Error
+	} else {
+		fe.Enabled = enabled
+		err = db.Create(fe).Error
+	}
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	fe.Enabled = enabled
+
+	entity.SaveFlagHistoryWithNote(getDB(), f.ID, actor, note, entity.FlagHistoryEntityTypeEnvironment, fe.ID, before, flagEnvironmentHistory(fe))
+	entity.SaveFlagSnapshot(getDB(), f.ID, actor)
+
+	f.Enabled = enabled
+	return f, nil
+}
+
+// replaceFlagEnvironmentDistributions replaces the distributions of the segment in the environment in one
+// transaction, the config of the environment is created with the flag's own enabled state if it doesn't exist.
+// It returns the new distributions of the segment in the environment.
+func replaceFlagEnvironmentDistributions(db *gorm.DB, flagID uint, segmentID uint, env string, ds []entity.Distribution, actor string) ([]entity.Distribution, *Error) {
+	f := &entity.Flag{}
+	if err := db.First(f, flagID).Error; err != nil {
+		return nil, NewError(404, "error finding flagID %v. reason %s", flagID, err)
+	}
+	fe, found, err := findFlagEnvironment(db, f, env)
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	var before interface{}
+	if found {
+		before = flagEnvironmentHistory(fe)
+	}
+
+	tx := db.Begin()
+	if !found {
+		if err := tx.Create(fe).Error; err != nil {
+			tx.Rollback()
+			return nil, NewError(500, "%s", err)
+		}
+	}
+	if err := tx.Delete(entity.FlagEnvironmentDistribution{}, "flag_environment_id = ? AND segment_id = ?", fe.ID, segmentID).Error; err != nil {
+		tx.Rollback()
+		return nil, NewError(500, "%s", err)
+	}
+	eds := []entity.FlagEnvironmentDistribution{}
+	for _, d := range fe.Distributions {
+		if d.SegmentID != segmentID {
+			eds = append(eds, d)
+		}
+	}
+	for _, d := range ds {
+		ed := entity.FlagEnvironmentDistribution{
+			FlagEnvironmentID: fe.ID,
+			SegmentID:         segmentID,
+			VariantID:         d.VariantID,
+			VariantKey:        d.VariantKey,
+			Percent:           d.Percent,
+		}
+		if err := tx.Create(&ed).Error; err != nil {
+			tx.Rollback()
+			return nil, NewError(500, "%s", err)
+		}
+		eds = append(eds, ed)
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, NewError(500, "%s", err)
+	}
+	fe.Distributions = eds
+
+	entity.SaveFlagHistory(getDB(), f.ID, actor, entity.FlagHistoryEntityTypeEnvironment, fe.ID, before, flagEnvironmentHistory(fe))
+	entity.SaveFlagSnapshot(getDB(), f.ID, actor)
+	return fe.SegmentDistributions(segmentID), nil
+}
+
+// findSegmentDistributionsInEnvironment finds the distributions of the segment in the environment,
+// they're the segment's own if the segment has no distribution in the environment
+func findSegmentDistributionsInEnvironment(db *gorm.DB, flagID uint, segmentID uint, env string) ([]entity.Distribution, error) {
+	fe, found, err := findFlagEnvironment(db, &entity.Flag{Model: gorm.Model{ID: flagID}}, env)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if ds := fe.SegmentDistributions(segmentID); ds != nil {
+			return ds, nil
+		}
+	}
+	ds := []entity.Distribution{}
+	err = db.Order("variant_id").Where(entity.Distribution{SegmentID: segmentID}).Find(&ds).Error
+	return ds, err
+}
+
+// whereEnabledInEnvironment filters the flags by their enabled state in the environment,
+// the flags without the config of the environment are filtered by their own enabled state
+func whereEnabledInEnvironment(tx *gorm.DB, db *gorm.DB, env string, enabled bool) (*gorm.DB, error) {
+	configured, matched := []uint{}, []uint{}
+	if err := db.Model(&entity.FlagEnvironment{}).Where("environment = ?", env).Pluck("flag_id", &configured).Error; err != nil {
+		return nil, err
+	}
+	if len(configured) == 0 {
+		return tx.Where("enabled = ?", enabled), nil
+	}
+	if err := db.Model(&entity.FlagEnvironment{}).Where("environment = ? AND enabled = ?", env, enabled).Pluck("flag_id", &matched).Error; err != nil {
+		return nil, err
+	}
+	return tx.Where("id IN (?) OR (enabled = ? AND id NOT IN (?))", matched, enabled, configured), nil
+}
+
+// flagEnvironmentHistory is the config of the environment in the flag history,
+// the distributions are the percents of the variants by segment
+func flagEnvironmentHistory(fe *entity.FlagEnvironment) map[string]interface{} {
+	ds := make(map[string]map[string]uint)
+	for _, d := range fe.Distributions {
+		k := fmt.Sprintf("segment %d", d.SegmentID)
+		if ds[k] == nil {
+			ds[k] = make(map[string]uint)
+		}
+		ds[k][d.VariantKey] = d.Percent
+	}
+	return map[string]interface{}{
+		"Environment":   fe.Environment,
+		"Enabled":       fe.Enabled,
+		"Distributions": ds,
+	}
+}
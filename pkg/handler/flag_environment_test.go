@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEnvironmentFromRequest(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/api/v1/evaluation", nil)
+	assert.Equal(t, "", getEnvironmentFromRequest(req, ""))
+	assert.Equal(t, "", getEnvironmentFromRequest(nil, ""))
+
+	req.Header.Set(environmentHeader, " prod ")
+	assert.Equal(t, "prod", getEnvironmentFromRequest(req, ""))
+	assert.Equal(t, "staging", getEnvironmentFromRequest(req, "staging"))
+}
+
+func TestCrudFlagEnvironments(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	c.CreateFlag(flag.CreateFlagParams{
+		Body: &models.CreateFlagRequest{
+			Description: util.StringPtr("funny flag"),
+			Key:         "flag_key_1",
+		},
+	})
+	c.CreateSegment(segment.CreateSegmentParams{
+		FlagID: int64(1),
+		Body: &models.CreateSegmentRequest{
+			Description:    util.StringPtr("segment1"),
+			RolloutPercent: util.Int64Ptr(int64(100)),
+		},
+	})
+	for _, key := range []string{"control", "treatment"} {
+		c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body:   &models.CreateVariantRequest{Key: util.StringPtr(key)},
+		})
+	}
+	c.PutDistributions(distribution.PutDistributionsParams{
+		FlagID:    int64(1),
+		SegmentID: int64(1),
+		Body: &models.PutDistributionsRequest{
+			Distributions: []*models.Distribution{
+				{Percent: util.Int64Ptr(100), VariantID: util.Int64Ptr(1), VariantKey: util.StringPtr("control")},
+			},
+		},
+	})
+
+	t.Run("it should set the enabled state in the environment without touching the flag's own", func(t *testing.T) {
+		res = c.SetFlagEnabledState(flag.SetFlagEnabledParams{
+			FlagID:      int64(1),
+			Environment: util.StringPtr("prod"),
+			Body:        &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true)},
+		})
+		assert.True(t, *res.(*flag.SetFlagEnabledOK).Payload.Enabled)
+
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1)})
+		assert.False(t, *res.(*flag.GetFlagOK).Payload.Enabled)
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1), Environment: util.StringPtr("prod")})
+		assert.True(t, *res.(*flag.GetFlagOK).Payload.Enabled)
+		res = c.GetFlag(flag.GetFlagParams{FlagID: int64(1), Environment: util.StringPtr("staging")})
+		assert.False(t, *res.(*flag.GetFlagOK).Payload.Enabled)
+	})
+
+	t.Run("it should patch the enabled state in the environment", func(t *testing.T) {
+		ec := &EvalCache{idCache: map[string]*entity.Flag{}, keyCache: map[string]*entity.Flag{}}
+		defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+		res = c.PatchFlagEnabled(flag.PatchFlagEnabledParams{
+			FlagID:      int64(1),
+			Environment: util.StringPtr("dev"),
+			Body:        &models.SetFlagEnabledRequest{Enabled: util.BoolPtr(true)},
+		})
+		assert.True(t, *res.(*flag.PatchFlagEnabledOK).Payload.Enabled)
+		assert.False(t, ec.idCache["1"].Enabled)
+		assert.True(t, ec.idCache["1"].InEnvironment("dev").Enabled)
+	})
+
+	t.Run("it should find the flags by their enabled state in the environment", func(t *testing.T) {
+		res = c.FindFlags(flag.FindFlagsParams{Enabled: util.BoolPtr(true), Environment: util.StringPtr("prod")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 1)
+		assert.True(t, *res.(*flag.FindFlagsOK).Payload[0].Enabled)
+
+		res = c.FindFlags(flag.FindFlagsParams{Enabled: util.BoolPtr(true), Environment: util.StringPtr("staging")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 0)
+
+		res = c.FindFlags(flag.FindFlagsParams{Enabled: util.BoolPtr(true)})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 0)
+	})
+
+	t.Run("it should put the distributions in the environment", func(t *testing.T) {
+		res = c.PutDistributions(distribution.PutDistributionsParams{
+			FlagID:      int64(1),
+			SegmentID:   int64(1),
+			Environment: util.StringPtr("staging"),
+			Body: &models.PutDistributionsRequest{
+				Distributions: []*models.Distribution{
+					{Percent: util.Int64Ptr(100), VariantID: util.Int64Ptr(2), VariantKey: util.StringPtr("treatment")},
+				},
+			},
+		})
+		assert.Len(t, res.(*distribution.PutDistributionsOK).Payload, 1)
+
+		res = c.FindDistributions(distribution.FindDistributionsParams{FlagID: int64(1), SegmentID: int64(1), Environment: util.StringPtr("staging")})
+		assert.Equal(t, "treatment", *res.(*distribution.FindDistributionsOK).Payload[0].VariantKey)
+		res = c.FindDistributions(distribution.FindDistributionsParams{FlagID: int64(1), SegmentID: int64(1), Environment: util.StringPtr("prod")})
+		assert.Equal(t, "control", *res.(*distribution.FindDistributionsOK).Payload[0].VariantKey)
+		res = c.FindDistributions(distribution.FindDistributionsParams{FlagID: int64(1), SegmentID: int64(1)})
+		assert.Equal(t, "control", *res.(*distribution.FindDistributionsOK).Payload[0].VariantKey)
+	})
+
+	t.Run("it should promote the config of an environment to another", func(t *testing.T) {
+		res = c.PromoteFlagEnvironment(flag.PromoteFlagEnvironmentParams{
+			FlagID:      int64(1),
+			Environment: "staging",
+			Body:        &models.PromoteFlagEnvironmentRequest{To: util.StringPtr("prod"), Note: "ship it"},
+		})
+		payload := res.(*flag.PromoteFlagEnvironmentOK).Payload
+		assert.False(t, *payload.Enabled)
+		assert.Equal(t, "treatment", *payload.Segments[0].Distributions[0].VariantKey)
+
+		res = c.GetFlagHistory(flag.GetFlagHistoryParams{FlagID: int64(1), Limit: util.Int64Ptr(1)})
+		history := res.(*flag.GetFlagHistoryOK).Payload
+		assert.Equal(t, entity.FlagHistoryEntityTypeEnvironment, *history[0].EntityType)
+		assert.Equal(t, "ship it", history[0].Note)
+	})
+
+	t.Run("it should reject promoting an environment to itself or an invalid one", func(t *testing.T) {
+		res = c.PromoteFlagEnvironment(flag.PromoteFlagEnvironmentParams{
+			FlagID:      int64(1),
+			Environment: "prod",
+			Body:        &models.PromoteFlagEnvironmentRequest{To: util.StringPtr("prod")},
+		})
+		assert.NotZero(t, res.(*flag.PromoteFlagEnvironmentDefault).Payload)
+
+		res = c.PromoteFlagEnvironment(flag.PromoteFlagEnvironmentParams{
+			FlagID:      int64(1),
+			Environment: "prod",
+			Body:        &models.PromoteFlagEnvironmentRequest{To: util.StringPtr("Not-Safe")},
+		})
+		assert.NotZero(t, res.(*flag.PromoteFlagEnvironmentDefault).Payload)
+
+		res = c.PromoteFlagEnvironment(flag.PromoteFlagEnvironmentParams{
+			FlagID:      int64(99999),
+			Environment: "prod",
+			Body:        &models.PromoteFlagEnvironmentRequest{To: util.StringPtr("dev")},
+		})
+		assert.NotZero(t, res.(*flag.PromoteFlagEnvironmentDefault).Payload)
+	})
+}
+
+func TestEvalFlagInEnvironment(t *testing.T) {
+	ec := GenFixtureEvalCache()
+	f := ec.idCache["100"]
+	f.Environments = []entity.FlagEnvironment{
+		{FlagID: 100, Environment: "dev", Enabled: false},
+		{
+			FlagID:        100,
+			Environment:   "prod",
+			Enabled:       true,
+			Distributions: []entity.FlagEnvironmentDistribution{{SegmentID: 200, VariantID: 301, VariantKey: "treatment", Percent: 100}},
+		},
+	}
+	assert.NoError(t, f.PrepareEvaluation())
+	defer gostub.StubFunc(&GetEvalCache, ec).Reset()
+
+	evalContext := models.EvalContext{
+		EntityContext: map[string]interface{}{"dl_state": "CA"},
+		EntityID:      "entityID1",
+		EntityType:    "entityType1",
+		FlagID:        int64(100),
+	}
+
+	t.Run("it evaluates the distributions of the environment", func(t *testing.T) {
+		prod := evalContext
+		prod.Environment = "prod"
+		result := evalFlag(context.Background(), "", prod)
+		assert.Equal(t, "treatment", result.VariantKey)
+	})
+
+	t.Run("it evaluates the enabled state of the environment", func(t *testing.T) {
+		dev := evalContext
+		dev.Environment = "dev"
+		result := evalFlag(context.Background(), "", dev)
+		assert.Zero(t, result.VariantID)
+		assert.Contains(t, result.EvalDebugLog.Msg, "not enabled")
+	})
+}
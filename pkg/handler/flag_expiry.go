@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// FlagExpiryActor is the actor recorded in the history and snapshots of the auto-disabled flags
+const FlagExpiryActor = "flagr (auto-disabled on expiry)"
+
+// startFlagExpiryChecker periodically disables the enabled flags past their expiresAt
+func startFlagExpiryChecker() {
+	interval := config.Config.FlagExpiryCheckInterval
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		for now := range time.Tick(interval) {
+			checkFlagExpiry(getDB(), now)
+		}
+	}()
+}
+
+func checkFlagExpiry(db *gorm.DB, now time.Time) {
+	flagIDs, err := disableExpiredFlags(db, now)
+	if err != nil {
+		logrus.WithField("err", err).Error("failed to disable the expired flags")
+	}
+	if len(flagIDs) == 0 {
+		return
+	}
+
+	logrus.WithField("flagIDs", flagIDs).Info("disabled the expired flags")
+	if err := reloadEvalCache(); err != nil {
+		logrus.WithField("err", err).Error("reload evaluation cache error")
+	}
+}
+
+// disableExpiredFlags disables the enabled flags past their expiresAt,
+// the flags that are already disabled are left alone
+var disableExpiredFlags = func(db *gorm.DB, now time.Time) ([]uint, error) {
+	fs := []entity.Flag{}
+	if err := db.Where("enabled = ? AND expires_at <= ?", true, now).Find(&fs).Error; err != nil {
+		return nil, err
+	}
+
+	flagIDs := []uint{}
+	for i := range fs {
+		f := &fs[i]
+		before := *f
+		if err := db.Model(f).Update("enabled", false).Error; err != nil {
+			return flagIDs, err
+		}
+		flagIDs = append(flagIDs, f.ID)
+
+		entity.SaveFlagHistory(db, f.ID, FlagExpiryActor, entity.FlagHistoryEntityTypeFlag, f.ID, &before, f)
+		entity.SaveFlagSnapshot(db, f.ID, FlagExpiryActor)
+	}
+	return flagIDs, nil
+}
+
+var reloadEvalCache = func() error {
+	return GetEvalCache().reloadMapCache()
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisableExpiredFlags(t *testing.T) {
+	db := entity.NewTestDB()
+	defer db.Close()
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	db.Create(&entity.Flag{Key: "expired", Enabled: true, ExpiresAt: &past})
+	db.Create(&entity.Flag{Key: "expired_disabled", Enabled: false, ExpiresAt: &past})
+	db.Create(&entity.Flag{Key: "not_expired", Enabled: true, ExpiresAt: &future})
+	db.Create(&entity.Flag{Key: "no_expiry", Enabled: true})
+
+	flagIDs, err := disableExpiredFlags(db, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1}, flagIDs)
+
+	fs := []entity.Flag{}
+	db.Order("id").Find(&fs)
+	assert.False(t, fs[0].Enabled)
+	assert.False(t, fs[1].Enabled)
+	assert.True(t, fs[2].Enabled)
+	assert.True(t, fs[3].Enabled)
+	assert.Equal(t, FlagExpiryActor, fs[0].UpdatedBy)
+	assert.NotZero(t, fs[0].SnapshotID)
+	assert.Zero(t, fs[1].SnapshotID)
+
+	fh := entity.FlagHistory{}
+	db.Where(entity.FlagHistory{FlagID: 1}).First(&fh)
+	assert.Equal(t, FlagExpiryActor, fh.Actor)
+	assert.Contains(t, string(fh.Diff), "Enabled")
+
+	flagIDs, err = disableExpiredFlags(db, now)
+	assert.NoError(t, err)
+	assert.Empty(t, flagIDs)
+}
+
+func TestCheckFlagExpiry(t *testing.T) {
+	db := entity.NewTestDB()
+	defer db.Close()
+
+	t.Run("it should reload the eval cache when flags are disabled", func(t *testing.T) {
+		reloaded := false
+		defer gostub.StubFunc(&disableExpiredFlags, []uint{1}, nil).Reset()
+		defer gostub.Stub(&reloadEvalCache, func() error {
+			reloaded = true
+			return nil
+		}).Reset()
+
+		checkFlagExpiry(db, time.Now())
+		assert.True(t, reloaded)
+	})
+
+	t.Run("it should not reload the eval cache when nothing is disabled", func(t *testing.T) {
+		reloaded := false
+		defer gostub.StubFunc(&disableExpiredFlags, []uint{}, fmt.Errorf("db error")).Reset()
+		defer gostub.Stub(&reloadEvalCache, func() error {
+			reloaded = true
+			return nil
+		}).Reset()
+
+		checkFlagExpiry(db, time.Now())
+		assert.False(t, reloaded)
+	})
+}
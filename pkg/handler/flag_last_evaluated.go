@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// flagLastEvaluated tracks the last evaluated time of the flags, it's nil if the tracking is turned off
+var flagLastEvaluated *lastEvaluatedTracker
+
+// lastEvaluatedTracker keeps the last evaluated time of every flag in memory until it's flushed.
+// The evaluations only store a timestamp to the flag's own counter, they never take a lock.
+type lastEvaluatedTracker struct {
+	// timestamps maps the flag ID to the *int64 unix nanoseconds, 0 means it's flushed
+	timestamps sync.Map
+}
+
+// startFlagLastEvaluatedFlusher tracks the evaluations and flushes the last evaluated
+// time of the flags to the db every FlagLastEvaluatedFlushInterval
+func startFlagLastEvaluatedFlusher() {
+	interval := config.Config.FlagLastEvaluatedFlushInterval
+	if interval <= 0 {
+		return
+	}
+	t := &lastEvaluatedTracker{}
+	flagLastEvaluated = t
+	go func() {
+		for range time.Tick(interval) {
+			t.flush(getDB())
+		}
+	}()
+}
+
+func (t *lastEvaluatedTracker) touch(flagID uint, now time.Time) {
+	v, ok := t.timestamps.Load(flagID)
+	if !ok {
+		v, _ = t.timestamps.LoadOrStore(flagID, new(int64))
+	}
+	atomic.StoreInt64(v.(*int64), now.UnixNano())
+}
+
+// flush writes the flags evaluated since the last flush, it never moves the last evaluated time
+// backwards, so that the instances flushing the same flag don't overwrite each other
+func (t *lastEvaluatedTracker) flush(db *gorm.DB) {
+	t.timestamps.Range(func(k, v interface{}) bool {
+		ts := atomic.SwapInt64(v.(*int64), 0)
+		if ts == 0 {
+			return true
+		}
+		at := time.Unix(0, ts).UTC()
+		err := db.Model(&entity.Flag{}).
+			Where("id = ? AND (last_evaluated_at IS NULL OR last_evaluated_at < ?)", k, at).
+			UpdateColumn("last_evaluated_at", at).Error
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"err": err, "flagID": k}).Error("failed to flush the last evaluated time of the flag")
+		}
+		return true
+	})
+}
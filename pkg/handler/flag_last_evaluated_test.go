@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/swagger_gen/models"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastEvaluatedTracker(t *testing.T) {
+	db := entity.PopulateTestDB(entity.GenFixtureFlag())
+	defer db.Close()
+
+	findFlag := func() *entity.Flag {
+		f := &entity.Flag{}
+		assert.NoError(t, db.First(f, 100).Error)
+		return f
+	}
+	lastEvaluatedAt := func() *time.Time { return findFlag().LastEvaluatedAt }
+
+	t.Run("it should flush the last evaluated time without touching updated_at", func(t *testing.T) {
+		updatedAt := findFlag().UpdatedAt
+		tracker := &lastEvaluatedTracker{}
+		at := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+		tracker.touch(100, at.Add(-time.Hour))
+		tracker.touch(100, at)
+		tracker.flush(db)
+		assert.True(t, at.Equal(*lastEvaluatedAt()))
+		assert.Equal(t, updatedAt, findFlag().UpdatedAt)
+
+		tracker.flush(db)
+		assert.True(t, at.Equal(*lastEvaluatedAt()))
+	})
+
+	t.Run("it should never move the last evaluated time backwards", func(t *testing.T) {
+		tracker := &lastEvaluatedTracker{}
+		tracker.touch(100, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+		tracker.flush(db)
+		assert.Equal(t, 2019, lastEvaluatedAt().Year())
+	})
+
+	t.Run("it should track the evaluations", func(t *testing.T) {
+		tracker := &lastEvaluatedTracker{}
+		defer gostub.Stub(&flagLastEvaluated, tracker).Reset()
+		defer gostub.StubFunc(&GetEvalCache, GenFixtureEvalCache()).Reset()
+
+		explainFlag(context.Background(), "", models.EvalContext{FlagID: int64(100)})
+		_, ok := tracker.timestamps.Load(uint(100))
+		assert.False(t, ok)
+
+		evalFlag(context.Background(), "", models.EvalContext{FlagID: int64(100)})
+		tracker.flush(db)
+		assert.WithinDuration(t, time.Now(), *lastEvaluatedAt(), time.Minute)
+	})
+}
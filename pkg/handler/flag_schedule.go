@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+)
+
+// startFlagScheduler applies the schedules missed while flagr was down,
+// and then periodically applies the due schedules
+func startFlagScheduler() {
+	interval := config.Config.FlagScheduleCheckInterval
+	if interval <= 0 {
+		return
+	}
+	checkFlagSchedules(getDB(), time.Now())
+	go func() {
+		for now := range time.Tick(interval) {
+			checkFlagSchedules(getDB(), now)
+		}
+	}()
+}
+
+func checkFlagSchedules(db *gorm.DB, now time.Time) {
+	flagIDs, err := applyDueFlagSchedules(db, now)
+	if err != nil {
+		logrus.WithField("err", err).Error("failed to apply the flag schedules")
+	}
+	if len(flagIDs) == 0 {
+		return
+	}
+
+	logrus.WithField("flagIDs", flagIDs).Info("applied the flag schedules")
+	if err := reloadEvalCache(); err != nil {
+		logrus.WithField("err", err).Error("reload evaluation cache error")
+	}
+}
+
+// applyDueFlagSchedules applies the schedules up to now in the order of scheduledAt,
+// it returns the ids of the updated flags
+var applyDueFlagSchedules = func(db *gorm.DB, now time.Time) ([]uint, error) {
+	ss := []entity.FlagSchedule{}
+	if err := db.Where("scheduled_at <= ?", now).Order("scheduled_at").Order("id").Find(&ss).Error; err != nil {
+		return nil, err
+	}
+
+	flagIDs := []uint{}
+	for i := range ss {
+		applied, err := applyFlagSchedule(db, &ss[i])
+		if err != nil {
+			return flagIDs, err
+		}
+		if applied {
+			flagIDs = append(flagIDs, ss[i].FlagID)
+		}
+	}
+	return flagIDs, nil
+}
+
+func applyFlagSchedule(db *gorm.DB, s *entity.FlagSchedule) (bool, error) {
+	tx := db.Begin()
+
+	// the schedule is deleted first, so that it's applied by only one of the flagr instances
+	deleted := tx.Delete(s)
+	if deleted.Error != nil || deleted.RowsAffected == 0 {
+		tx.Rollback()
+		return false, deleted.Error
+	}
+
+	f := &entity.Flag{}
+	if err := tx.First(f, s.FlagID).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			// the flag is deleted, there's nothing to apply
+			return false, tx.Commit().Error
+		}
+		tx.Rollback()
+		return false, err
+	}
+	before := *f
+
+	if err := tx.Model(f).Update("enabled", s.Enabled()).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	actor := flagScheduleActor(s)
+	entity.SaveFlagHistory(db, f.ID, actor, entity.FlagHistoryEntityTypeFlag, f.ID, &before, f)
+	entity.SaveFlagSnapshot(db, f.ID, actor)
+	return true, nil
+}
+
+func flagScheduleActor(s *entity.FlagSchedule) string {
+	if s.CreatedBy == "" {
+		return "flagr (scheduled)"
+	}
+	return s.CreatedBy + " (scheduled)"
+}
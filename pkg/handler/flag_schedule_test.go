@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDueFlagSchedules(t *testing.T) {
+	db := entity.NewTestDB()
+	defer db.Close()
+
+	now := time.Now()
+	db.Create(&entity.Flag{Key: "flag_1"})
+	db.Create(&entity.Flag{Key: "flag_2", Enabled: true})
+	db.Create(&entity.FlagSchedule{FlagID: 1, Action: entity.FlagScheduleActionEnable, ScheduledAt: now.Add(-2 * time.Hour), CreatedBy: "foo"})
+	db.Create(&entity.FlagSchedule{FlagID: 2, Action: entity.FlagScheduleActionDisable, ScheduledAt: now.Add(-time.Hour)})
+	db.Create(&entity.FlagSchedule{FlagID: 2, Action: entity.FlagScheduleActionEnable, ScheduledAt: now.Add(time.Hour)})
+	db.Create(&entity.FlagSchedule{FlagID: 999, Action: entity.FlagScheduleActionEnable, ScheduledAt: now.Add(-time.Hour)})
+
+	t.Run("it should apply the past due schedules", func(t *testing.T) {
+		flagIDs, err := applyDueFlagSchedules(db, now)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1, 2}, flagIDs)
+
+		fs := []entity.Flag{}
+		db.Order("id").Find(&fs)
+		assert.True(t, fs[0].Enabled)
+		assert.Equal(t, "foo (scheduled)", fs[0].UpdatedBy)
+		assert.NotZero(t, fs[0].SnapshotID)
+		assert.False(t, fs[1].Enabled)
+		assert.Equal(t, "flagr (scheduled)", fs[1].UpdatedBy)
+
+		ss := []entity.FlagSchedule{}
+		db.Find(&ss)
+		assert.Len(t, ss, 1)
+		assert.Equal(t, uint(2), ss[0].FlagID)
+	})
+
+	t.Run("it should not apply the schedules twice", func(t *testing.T) {
+		flagIDs, err := applyDueFlagSchedules(db, now)
+		assert.NoError(t, err)
+		assert.Empty(t, flagIDs)
+	})
+
+	t.Run("it should apply the future schedules once they are due", func(t *testing.T) {
+		reloaded := false
+		defer gostub.Stub(&reloadEvalCache, func() error {
+			reloaded = true
+			return nil
+		}).Reset()
+
+		checkFlagSchedules(db, now.Add(2*time.Hour))
+		assert.True(t, reloaded)
+
+		f := entity.Flag{}
+		db.First(&f, 2)
+		assert.True(t, f.Enabled)
+	})
+}
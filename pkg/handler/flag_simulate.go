@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+const (
+	// simulateRolloutDefaultN is the default number of the synthetic entity IDs
+	simulateRolloutDefaultN = 100000
+	// simulateRolloutMaxFlips caps the entity IDs that would flip in the response
+	simulateRolloutMaxFlips = 1000
+)
+
+// SimulateFlagRollout dry-runs the proposed distributions of the segment against the current ones, nothing is saved.
+// The entity IDs are bucketed as they are, so they're the values of the bucketBy property if the flag has it.
+func (c *crud) SimulateFlagRollout(params flag.SimulateFlagRolloutParams) middleware.Responder {
+	db := getRequestDB(params.HTTPRequest)
+	if e := validateDistributions(db, params.FlagID, params.Body.Distributions); e != nil {
+		return flag.NewSimulateFlagRolloutDefault(e.StatusCode).WithPayload(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	f := entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(db).First(&f, params.FlagID).Error; err != nil {
+		return flag.NewSimulateFlagRolloutDefault(404).WithPayload(
+			ErrorMessage("cannot find flag %v. %s", params.FlagID, err))
+	}
+	fs := []entity.Flag{f}
+	if err := entity.AttachConstraintGroups(db, fs); err != nil {
+		return flag.NewSimulateFlagRolloutDefault(500).WithPayload(
+			ErrorMessage("cannot find the constraint groups of flag %v. %s", params.FlagID, err))
+	}
+	if err := fs[0].PrepareEvaluation(); err != nil {
+		return flag.NewSimulateFlagRolloutDefault(500).WithPayload(
+			ErrorMessage("cannot prepare flag %v for evaluation. %s", params.FlagID, err))
+	}
+
+	segmentID := util.SafeUint(params.Body.SegmentID)
+	var s *entity.Segment
+	for i := range fs[0].Segments {
+		if fs[0].Segments[i].ID == segmentID {
+			s = &fs[0].Segments[i]
+		}
+	}
+	if s == nil {
+		return flag.NewSimulateFlagRolloutDefault(404).WithPayload(
+			ErrorMessage("cannot find segment %v of flag %v", segmentID, params.FlagID))
+	}
+	proposed := *s
+	proposed.Distributions = r2eMapDistributions(params.Body.Distributions, s.ID)
+	if err := proposed.PrepareEvaluation(); err != nil {
+		return flag.NewSimulateFlagRolloutDefault(400).WithPayload(
+			ErrorMessage("cannot prepare the proposed distributions for evaluation. %s", err))
+	}
+
+	entityIDs := params.Body.EntityIds
+	if len(entityIDs) == 0 {
+		n := int64(simulateRolloutDefaultN)
+		if params.Body.N != 0 {
+			n = params.Body.N
+		}
+		entityIDs = make([]string, n)
+		for i := range entityIDs {
+			entityIDs[i] = strconv.Itoa(i)
+		}
+	}
+
+	resp := flag.NewSimulateFlagRolloutOK()
+	resp.SetPayload(simulateRollout(&fs[0], s, &proposed, entityIDs, time.Now()))
+	return resp
+}
+
+// simulateRollout buckets the entity IDs through the current distributions of the segment and the proposed ones the
+// same way as the evaluation does, including the rollout percent of the segment. The constraints are left out, the
+// entity IDs are all assumed to be in the segment. The ones out of the rollout are counted with the variantID 0.
+func simulateRollout(f *entity.Flag, current *entity.Segment, proposed *entity.Segment, entityIDs []string, now time.Time) *models.RolloutSimulation {
+	hasher := f.BucketingHasher()
+	salt := f.BucketingSalt()
+	currentArray := current.SegmentEvaluation.DistributionArrayAt(now)
+	proposedArray := proposed.SegmentEvaluation.DistributionArrayAt(now)
+	variantOf := func(d entity.DistributionArray, entityID string) uint {
+		vID, _ := d.Rollout(hasher, entityID, salt, current.RolloutPercent)
+		if vID == nil {
+			return 0
+		}
+		return *vID
+	}
+	variantKeyOf := func(vID uint) string {
+		if v := f.FlagEvaluation.VariantsMap[vID]; v != nil {
+			return v.Key
+		}
+		return ""
+	}
+
+	currentCounts := make(map[uint]int64)
+	proposedCounts := make(map[uint]int64)
+	flipped := int64(0)
+	flips := []*models.VariantFlip{}
+	for _, entityID := range entityIDs {
+		from, to := variantOf(currentArray, entityID), variantOf(proposedArray, entityID)
+		currentCounts[from]++
+		proposedCounts[to]++
+		if from == to {
+			continue
+		}
+		flipped++
+		if len(flips) < simulateRolloutMaxFlips {
+			flips = append(flips, &models.VariantFlip{
+				EntityID:       util.StringPtr(entityID),
+				FromVariantID:  int64(from),
+				FromVariantKey: variantKeyOf(from),
+				ToVariantID:    int64(to),
+				ToVariantKey:   variantKeyOf(to),
+			})
+		}
+	}
+
+	variants := make([]*models.VariantSimulationCount, 0, len(f.Variants)+1)
+	for _, v := range f.Variants {
+		variants = append(variants, &models.VariantSimulationCount{
+			VariantID:  int64(v.ID),
+			VariantKey: v.Key,
+			Current:    util.Int64Ptr(currentCounts[v.ID]),
+			Proposed:   util.Int64Ptr(proposedCounts[v.ID]),
+		})
+	}
+	if currentCounts[0] > 0 || proposedCounts[0] > 0 {
+		variants = append(variants, &models.VariantSimulationCount{
+			Current:  util.Int64Ptr(currentCounts[0]),
+			Proposed: util.Int64Ptr(proposedCounts[0]),
+		})
+	}
+
+	return &models.RolloutSimulation{
+		FlagID:    util.Int64Ptr(int64(f.ID)),
+		SegmentID: util.Int64Ptr(int64(current.ID)),
+		N:         util.Int64Ptr(int64(len(entityIDs))),
+		Variants:  variants,
+		Flipped:   util.Int64Ptr(flipped),
+		Flips:     flips,
+	}
+}
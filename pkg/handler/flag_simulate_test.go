@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateRollout(t *testing.T) {
+	entityIDs := make([]string, 1000)
+	for i := range entityIDs {
+		entityIDs[i] = util.SafeString(i)
+	}
+
+	t.Run("it should flip nobody with the same distributions", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		s := &f.Segments[0]
+		sim := simulateRollout(&f, s, s, entityIDs, time.Now())
+
+		assert.Equal(t, int64(1000), *sim.N)
+		assert.Equal(t, int64(0), *sim.Flipped)
+		assert.Empty(t, sim.Flips)
+		assert.Len(t, sim.Variants, 2)
+		for _, v := range sim.Variants {
+			assert.Equal(t, *v.Current, *v.Proposed)
+		}
+	})
+
+	t.Run("it should flip the entities moving to the other variant", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		s := &f.Segments[0]
+		proposed := *s
+		proposed.Distributions = []entity.Distribution{
+			{SegmentID: 200, VariantID: 300, VariantKey: "control", Percent: 100},
+			{SegmentID: 200, VariantID: 301, VariantKey: "treatment", Percent: 0},
+		}
+		assert.NoError(t, proposed.PrepareEvaluation())
+
+		sim := simulateRollout(&f, s, &proposed, entityIDs, time.Now())
+		assert.Equal(t, "control", sim.Variants[0].VariantKey)
+		assert.Equal(t, int64(1000), *sim.Variants[0].Proposed)
+		assert.Equal(t, int64(0), *sim.Variants[1].Proposed)
+		assert.Equal(t, *sim.Variants[1].Current, *sim.Flipped)
+		assert.Len(t, sim.Flips, int(*sim.Flipped))
+		for _, flip := range sim.Flips {
+			assert.Equal(t, "treatment", flip.FromVariantKey)
+			assert.Equal(t, "control", flip.ToVariantKey)
+
+			vID, _ := s.SegmentEvaluation.DistributionArray.Rollout(f.BucketingHasher(), *flip.EntityID, f.BucketingSalt(), 100)
+			assert.Equal(t, uint(301), *vID)
+		}
+	})
+
+	t.Run("it should count the entities out of the rollout with the variantID 0", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		s := &f.Segments[0]
+		s.RolloutPercent = 50
+		proposed := *s
+		proposed.Distributions = nil
+		assert.NoError(t, proposed.PrepareEvaluation())
+
+		sim := simulateRollout(&f, s, &proposed, entityIDs, time.Now())
+		assert.Len(t, sim.Variants, 3)
+		unassigned := sim.Variants[2]
+		assert.Zero(t, unassigned.VariantID)
+		assert.Equal(t, int64(1000), *unassigned.Proposed)
+		assert.True(t, *unassigned.Current > 0)
+		assert.Equal(t, 1000-*unassigned.Current, *sim.Flipped)
+		assert.Zero(t, sim.Flips[0].ToVariantID)
+	})
+
+	t.Run("it should cap the flips", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		s := &f.Segments[0]
+		proposed := *s
+		proposed.Distributions = nil
+		assert.NoError(t, proposed.PrepareEvaluation())
+
+		many := make([]string, 3000)
+		for i := range many {
+			many[i] = util.SafeString(i)
+		}
+		sim := simulateRollout(&f, s, &proposed, many, time.Now())
+		assert.Equal(t, int64(3000), *sim.Flipped)
+		assert.Len(t, sim.Flips, simulateRolloutMaxFlips)
+	})
+}
+
+func TestSimulateFlagRollout(t *testing.T) {
+	db := entity.PopulateTestDB(entity.GenFixtureFlag())
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	distributions := []*models.Distribution{
+		{Percent: util.Int64Ptr(100), VariantID: util.Int64Ptr(300), VariantKey: util.StringPtr("control")},
+		{Percent: util.Int64Ptr(0), VariantID: util.Int64Ptr(301), VariantKey: util.StringPtr("treatment")},
+	}
+
+	t.Run("it should simulate the synthetic entity IDs without saving anything", func(t *testing.T) {
+		res := c.SimulateFlagRollout(flag.SimulateFlagRolloutParams{
+			FlagID: 100,
+			Body:   &models.SimulateRolloutRequest{SegmentID: util.Int64Ptr(200), Distributions: distributions, N: 1000},
+		})
+		payload := res.(*flag.SimulateFlagRolloutOK).Payload
+		assert.Equal(t, int64(1000), *payload.N)
+		assert.Equal(t, int64(200), *payload.SegmentID)
+		assert.True(t, *payload.Flipped > 0)
+
+		ds := []entity.Distribution{}
+		db.Where(entity.Distribution{SegmentID: 200}).Find(&ds)
+		for _, d := range ds {
+			assert.Equal(t, uint(50), d.Percent)
+		}
+	})
+
+	t.Run("it should simulate the sample of the entity IDs", func(t *testing.T) {
+		res := c.SimulateFlagRollout(flag.SimulateFlagRolloutParams{
+			FlagID: 100,
+			Body: &models.SimulateRolloutRequest{
+				SegmentID:     util.Int64Ptr(200),
+				Distributions: distributions,
+				EntityIds:     []string{"a", "b", "c"},
+				N:             1000,
+			},
+		})
+		assert.Equal(t, int64(3), *res.(*flag.SimulateFlagRolloutOK).Payload.N)
+	})
+
+	t.Run("it should fail on the invalid distributions", func(t *testing.T) {
+		res := c.SimulateFlagRollout(flag.SimulateFlagRolloutParams{
+			FlagID: 100,
+			Body: &models.SimulateRolloutRequest{
+				SegmentID:     util.Int64Ptr(200),
+				Distributions: distributions[1:],
+			},
+		})
+		assert.Contains(t, *res.(*flag.SimulateFlagRolloutDefault).Payload.Message, "is not 100")
+	})
+
+	t.Run("it should fail on the missing segment", func(t *testing.T) {
+		res := c.SimulateFlagRollout(flag.SimulateFlagRolloutParams{
+			FlagID: 100,
+			Body:   &models.SimulateRolloutRequest{SegmentID: util.Int64Ptr(999), Distributions: distributions},
+		})
+		assert.Contains(t, *res.(*flag.SimulateFlagRolloutDefault).Payload.Message, "cannot find segment 999")
+	})
+}
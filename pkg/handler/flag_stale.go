@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Reasons of the stale flags
+const (
+	staleFlagReasonDisabled       = "disabled"
+	staleFlagReasonNeverEvaluated = "never_evaluated"
+	staleFlagReasonSingleVariant  = "single_variant"
+)
+
+// staleFlagDefaultThreshold is the default of disabledFor and unevaluatedFor
+const staleFlagDefaultThreshold = "30d"
+
+func (c *crud) FindStaleFlags(params flag.FindStaleFlagsParams) middleware.Responder {
+	disabledFor, err := util.ParseDuration(util.SafeStringWithDefault(params.DisabledFor, staleFlagDefaultThreshold))
+	if err != nil {
+		return flag.NewFindStaleFlagsDefault(400).WithPayload(ErrorMessage("cannot parse disabledFor. %s", err))
+	}
+	unevaluatedFor, err := util.ParseDuration(util.SafeStringWithDefault(params.UnevaluatedFor, staleFlagDefaultThreshold))
+	if err != nil {
+		return flag.NewFindStaleFlagsDefault(400).WithPayload(ErrorMessage("cannot parse unevaluatedFor. %s", err))
+	}
+
+	fs := []entity.Flag{}
+	tx := entity.PreloadSegmentsVariants(whereNamespace(getRequestDB(params.HTTPRequest), params.HTTPRequest))
+	if err := tx.Order("id").Find(&fs).Error; err != nil {
+		return flag.NewFindStaleFlagsDefault(500).WithPayload(ErrorMessage("cannot query all flags. %s", err))
+	}
+
+	now := time.Now().UTC()
+	payload := []*models.StaleFlag{}
+	for i := range fs {
+		f := &fs[i]
+		reasons := []string{}
+		if !f.Enabled && f.UpdatedAt.Before(now.Add(-disabledFor)) {
+			reasons = append(reasons, staleFlagReasonDisabled)
+		}
+		if f.LastEvaluatedAt == nil && f.CreatedAt.Before(now.Add(-unevaluatedFor)) {
+			reasons = append(reasons, staleFlagReasonNeverEvaluated)
+		}
+		singleVariant := singleVariantOfFlag(f)
+		if f.Enabled && singleVariant != nil {
+			reasons = append(reasons, staleFlagReasonSingleVariant)
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+
+		mf, err := e2rMapFlag(f)
+		if err != nil {
+			return flag.NewFindStaleFlagsDefault(500).WithPayload(ErrorMessage("cannot map flag %v. %s", f.ID, err))
+		}
+		sf := &models.StaleFlag{Flag: mf, Reasons: reasons}
+		if f.Enabled && singleVariant != nil {
+			sf.SingleVariantKey = singleVariant.Key
+		}
+		payload = append(payload, sf)
+	}
+
+	resp := flag.NewFindStaleFlagsOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+// singleVariantOfFlag returns the variant that every entity resolves to judging by the distributions, or nil if there's none.
+// It's the case when all the segments are fully rolled out to the same variant without a rollout schedule, and the
+// entities matching no segment resolve to it as well, either as the default variant or by a segment without constraints.
+func singleVariantOfFlag(f *entity.Flag) *entity.Variant {
+	if len(f.Segments) == 0 {
+		return nil
+	}
+
+	variantID := uint(0)
+	everyoneMatched := false
+	for _, s := range f.Segments {
+		if s.RolloutPercent != 100 {
+			return nil
+		}
+		segmentVariantID := uint(0)
+		for _, d := range s.Distributions {
+			if d.HasRolloutSchedule() {
+				return nil
+			}
+			if d.Percent == 100 {
+				segmentVariantID = d.VariantID
+			}
+		}
+		if segmentVariantID == 0 || (variantID != 0 && segmentVariantID != variantID) {
+			return nil
+		}
+		variantID = segmentVariantID
+		if len(s.Constraints) == 0 && s.ConstraintGroupID == 0 {
+			everyoneMatched = true
+			break
+		}
+	}
+	if !everyoneMatched && f.DefaultVariantID != variantID {
+		return nil
+	}
+
+	for i := range f.Variants {
+		if f.Variants[i].ID == variantID {
+			return &f.Variants[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleVariantOfFlag(t *testing.T) {
+	fullyRolledOut := func() entity.Flag {
+		f := entity.GenFixtureFlag()
+		f.Segments[0].Distributions[0].Percent = 100
+		f.Segments[0].Distributions[1].Percent = 0
+		return f
+	}
+
+	t.Run("it should find no single variant of the split distributions", func(t *testing.T) {
+		f := entity.GenFixtureFlag()
+		assert.Nil(t, singleVariantOfFlag(&f))
+	})
+
+	t.Run("it should need the entities not matching any segment to resolve to the variant", func(t *testing.T) {
+		f := fullyRolledOut()
+		assert.Nil(t, singleVariantOfFlag(&f))
+
+		f.DefaultVariantID = 301
+		assert.Nil(t, singleVariantOfFlag(&f))
+
+		f.DefaultVariantID = 300
+		assert.Equal(t, "control", singleVariantOfFlag(&f).Key)
+
+		f = fullyRolledOut()
+		f.Segments[0].Constraints = nil
+		assert.Equal(t, "control", singleVariantOfFlag(&f).Key)
+	})
+
+	t.Run("it should find no single variant while rolling out", func(t *testing.T) {
+		f := fullyRolledOut()
+		f.Segments[0].Constraints = nil
+		f.Segments[0].RolloutPercent = 50
+		assert.Nil(t, singleVariantOfFlag(&f))
+
+		f = fullyRolledOut()
+		f.Segments[0].Constraints = nil
+		endAt := time.Now()
+		f.Segments[0].Distributions[0].RolloutEndAt = &endAt
+		assert.Nil(t, singleVariantOfFlag(&f))
+	})
+
+	t.Run("it should find no single variant of the segments with different variants", func(t *testing.T) {
+		f := fullyRolledOut()
+		other := entity.GenFixtureSegment()
+		other.Constraints = nil
+		other.Distributions[0].Percent = 0
+		other.Distributions[1].Percent = 100
+		f.Segments = append(f.Segments, other)
+		assert.Nil(t, singleVariantOfFlag(&f))
+	})
+}
+
+func TestFindStaleFlags(t *testing.T) {
+	db := entity.PopulateTestDB(entity.GenFixtureFlag())
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	long := time.Now().UTC().Add(-60 * 24 * time.Hour)
+
+	t.Run("it should find no stale flags", func(t *testing.T) {
+		res := c.FindStaleFlags(flag.FindStaleFlagsParams{})
+		assert.Len(t, res.(*flag.FindStaleFlagsOK).Payload, 0)
+	})
+
+	t.Run("it should find the flags never evaluated", func(t *testing.T) {
+		db.Model(&entity.Flag{}).Where("id = ?", 100).UpdateColumn("created_at", long)
+
+		res := c.FindStaleFlags(flag.FindStaleFlagsParams{})
+		payload := res.(*flag.FindStaleFlagsOK).Payload
+		assert.Len(t, payload, 1)
+		assert.Equal(t, []string{staleFlagReasonNeverEvaluated}, payload[0].Reasons)
+
+		res = c.FindStaleFlags(flag.FindStaleFlagsParams{UnevaluatedFor: util.StringPtr("90d")})
+		assert.Len(t, res.(*flag.FindStaleFlagsOK).Payload, 0)
+
+		db.Model(&entity.Flag{}).Where("id = ?", 100).UpdateColumn("last_evaluated_at", long)
+		res = c.FindStaleFlags(flag.FindStaleFlagsParams{})
+		assert.Len(t, res.(*flag.FindStaleFlagsOK).Payload, 0)
+	})
+
+	t.Run("it should find the flags disabled for long", func(t *testing.T) {
+		db.Model(&entity.Flag{}).Where("id = ?", 100).UpdateColumns(map[string]interface{}{"enabled": false, "updated_at": long})
+
+		res := c.FindStaleFlags(flag.FindStaleFlagsParams{})
+		payload := res.(*flag.FindStaleFlagsOK).Payload
+		assert.Len(t, payload, 1)
+		assert.Equal(t, []string{staleFlagReasonDisabled}, payload[0].Reasons)
+
+		res = c.FindStaleFlags(flag.FindStaleFlagsParams{DisabledFor: util.StringPtr("90d")})
+		assert.Len(t, res.(*flag.FindStaleFlagsOK).Payload, 0)
+
+		db.Model(&entity.Flag{}).Where("id = ?", 100).UpdateColumn("enabled", true)
+	})
+
+	t.Run("it should find the flags always resolving to a single variant", func(t *testing.T) {
+		db.Model(&entity.Distribution{}).Where("id = ?", 400).UpdateColumn("percent", 100)
+		db.Model(&entity.Distribution{}).Where("id = ?", 401).UpdateColumn("percent", 0)
+		db.Model(&entity.Flag{}).Where("id = ?", 100).UpdateColumn("default_variant_id", 300)
+
+		res := c.FindStaleFlags(flag.FindStaleFlagsParams{})
+		payload := res.(*flag.FindStaleFlagsOK).Payload
+		assert.Len(t, payload, 1)
+		assert.Equal(t, []string{staleFlagReasonSingleVariant}, payload[0].Reasons)
+		assert.Equal(t, "control", payload[0].SingleVariantKey)
+	})
+
+	t.Run("it should fail on the invalid thresholds", func(t *testing.T) {
+		res := c.FindStaleFlags(flag.FindStaleFlagsParams{DisabledFor: util.StringPtr("a month")})
+		assert.IsType(t, &flag.FindStaleFlagsDefault{}, res)
+
+		res = c.FindStaleFlags(flag.FindStaleFlagsParams{UnevaluatedFor: util.StringPtr("a month")})
+		assert.IsType(t, &flag.FindStaleFlagsDefault{}, res)
+	})
+}
@@ -1,47 +1,106 @@
 package handler
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
 	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/swagger_gen/models"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint_group"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/evaluation"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/exclusion_group"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/export"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/health"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment_template"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/tag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
 )
 
 var getDB = entity.GetDB
 
+// getRequestDB gets the db with its queries bound to the context of the request, so that they stop with the request,
+// e.g. when it times out. The history and the snapshots of the changes are saved with getDB instead, because they
+// should be saved once the changes are.
+func getRequestDB(r *http.Request) *gorm.DB {
+	if r == nil {
+		return getDB()
+	}
+	return entity.WithContext(r.Context(), getDB())
+}
+
+// requestContext gets the context of the request, it's the background one without a request, e.g. in the tests
+func requestContext(r *http.Request) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.Context()
+}
+
 // Setup initialize all the handler functions
 func Setup(api *operations.FlagrAPI) {
 	if config.Config.EvalOnlyMode {
 		setupHealth(api)
 		setupEvaluation(api)
+		setupEvalCacheInvalidation(api)
+		setupGRPC(api)
 		return
 	}
 
 	setupHealth(api)
 	setupEvaluation(api)
+	setupEvalCacheInvalidation(api)
+	setupGRPC(api)
 	setupCRUD(api)
 	setupExport(api)
+	setupFlagChangeWebhook(api)
+	setupFlagChangeKafka(api)
+	setupFlagChangeStream(api)
+	startFlagExpiryChecker()
+	startFlagScheduler()
+	startFlagBackup()
+	startFlagLastEvaluatedFlusher()
 }
 
 func setupCRUD(api *operations.FlagrAPI) {
 	c := NewCRUD()
 	// flags
 	api.FlagFindFlagsHandler = flag.FindFlagsHandlerFunc(c.FindFlags)
+	api.FlagFindStaleFlagsHandler = flag.FindStaleFlagsHandlerFunc(c.FindStaleFlags)
 	api.FlagCreateFlagHandler = flag.CreateFlagHandlerFunc(c.CreateFlag)
+	api.FlagSaveFlagsBatchHandler = flag.SaveFlagsBatchHandlerFunc(c.SaveFlagsBatch)
+	api.FlagExportFlagHandler = flag.ExportFlagHandlerFunc(c.ExportFlag)
+	api.FlagImportFlagHandler = flag.ImportFlagHandlerFunc(c.ImportFlag)
+	api.FlagValidateFlagHandler = flag.ValidateFlagHandlerFunc(c.ValidateFlag)
+	api.FlagCloneFlagHandler = flag.CloneFlagHandlerFunc(c.CloneFlag)
 	api.FlagGetFlagHandler = flag.GetFlagHandlerFunc(c.GetFlag)
 	api.FlagPutFlagHandler = flag.PutFlagHandlerFunc(c.PutFlag)
 	api.FlagDeleteFlagHandler = flag.DeleteFlagHandlerFunc(c.DeleteFlag)
+	api.FlagRestoreFlagHandler = flag.RestoreFlagHandlerFunc(c.RestoreFlag)
+	api.FlagRestoreFlagSnapshotHandler = flag.RestoreFlagSnapshotHandlerFunc(c.RestoreFlagSnapshot)
 	api.FlagSetFlagEnabledHandler = flag.SetFlagEnabledHandlerFunc(c.SetFlagEnabledState)
+	api.FlagPatchFlagEnabledHandler = flag.PatchFlagEnabledHandlerFunc(c.PatchFlagEnabled)
+	api.FlagPromoteFlagEnvironmentHandler = flag.PromoteFlagEnvironmentHandlerFunc(c.PromoteFlagEnvironment)
+	api.FlagPutFlagOverrideHandler = flag.PutFlagOverrideHandlerFunc(c.PutFlagOverride)
 	api.FlagGetFlagSnapshotsHandler = flag.GetFlagSnapshotsHandlerFunc(c.GetFlagSnapshots)
+	api.FlagGetFlagSnapshotsDiffHandler = flag.GetFlagSnapshotsDiffHandlerFunc(c.GetFlagSnapshotsDiff)
+	api.FlagGetFlagHistoryHandler = flag.GetFlagHistoryHandlerFunc(c.GetFlagHistory)
+	api.FlagGetFlagBucketCheckHandler = flag.GetFlagBucketCheckHandlerFunc(c.GetFlagBucketCheck)
+	api.FlagSimulateFlagRolloutHandler = flag.SimulateFlagRolloutHandlerFunc(c.SimulateFlagRollout)
 	api.FlagGetFlagEntityTypesHandler = flag.GetFlagEntityTypesHandlerFunc(c.GetFlagEntityTypes)
+	api.FlagFindFlagSchedulesHandler = flag.FindFlagSchedulesHandlerFunc(c.FindFlagSchedules)
+	api.FlagCreateFlagScheduleHandler = flag.CreateFlagScheduleHandlerFunc(c.CreateFlagSchedule)
+	api.FlagFindFlagChangesHandler = flag.FindFlagChangesHandlerFunc(c.FindFlagChanges)
+	api.FlagApproveFlagChangeHandler = flag.ApproveFlagChangeHandlerFunc(c.ApproveFlagChange)
 
 	// segments
 	api.SegmentCreateSegmentHandler = segment.CreateSegmentHandlerFunc(c.CreateSegment)
@@ -59,12 +118,43 @@ func setupCRUD(api *operations.FlagrAPI) {
 	// distributions
 	api.DistributionFindDistributionsHandler = distribution.FindDistributionsHandlerFunc(c.FindDistributions)
 	api.DistributionPutDistributionsHandler = distribution.PutDistributionsHandlerFunc(c.PutDistributions)
+	api.DistributionRebalanceDistributionsHandler = distribution.RebalanceDistributionsHandlerFunc(c.RebalanceDistributions)
 
 	// variants
 	api.VariantCreateVariantHandler = variant.CreateVariantHandlerFunc(c.CreateVariant)
 	api.VariantFindVariantsHandler = variant.FindVariantsHandlerFunc(c.FindVariants)
 	api.VariantPutVariantHandler = variant.PutVariantHandlerFunc(c.PutVariant)
 	api.VariantDeleteVariantHandler = variant.DeleteVariantHandlerFunc(c.DeleteVariant)
+
+	// tags
+	api.TagCreateTagHandler = tag.CreateTagHandlerFunc(c.CreateTag)
+	api.TagFindTagsHandler = tag.FindTagsHandlerFunc(c.FindTags)
+	api.TagDeleteTagHandler = tag.DeleteTagHandlerFunc(c.DeleteTag)
+	api.TagFindAllTagsHandler = tag.FindAllTagsHandlerFunc(c.FindAllTags)
+	api.TagBulkTagsHandler = tag.BulkTagsHandlerFunc(c.BulkTags)
+
+	// constraint groups
+	api.ConstraintGroupFindConstraintGroupsHandler = constraint_group.FindConstraintGroupsHandlerFunc(c.FindConstraintGroups)
+	api.ConstraintGroupCreateConstraintGroupHandler = constraint_group.CreateConstraintGroupHandlerFunc(c.CreateConstraintGroup)
+	api.ConstraintGroupGetConstraintGroupHandler = constraint_group.GetConstraintGroupHandlerFunc(c.GetConstraintGroup)
+	api.ConstraintGroupPutConstraintGroupHandler = constraint_group.PutConstraintGroupHandlerFunc(c.PutConstraintGroup)
+	api.ConstraintGroupDeleteConstraintGroupHandler = constraint_group.DeleteConstraintGroupHandlerFunc(c.DeleteConstraintGroup)
+
+	// segment templates
+	api.SegmentTemplateFindSegmentTemplatesHandler = segment_template.FindSegmentTemplatesHandlerFunc(c.FindSegmentTemplates)
+	api.SegmentTemplateCreateSegmentTemplateHandler = segment_template.CreateSegmentTemplateHandlerFunc(c.CreateSegmentTemplate)
+	api.SegmentTemplateGetSegmentTemplateHandler = segment_template.GetSegmentTemplateHandlerFunc(c.GetSegmentTemplate)
+	api.SegmentTemplatePutSegmentTemplateHandler = segment_template.PutSegmentTemplateHandlerFunc(c.PutSegmentTemplate)
+	api.SegmentTemplateDeleteSegmentTemplateHandler = segment_template.DeleteSegmentTemplateHandlerFunc(c.DeleteSegmentTemplate)
+	api.SegmentTemplateApplySegmentTemplateHandler = segment_template.ApplySegmentTemplateHandlerFunc(c.ApplySegmentTemplate)
+
+	// exclusion groups
+	api.ExclusionGroupFindExclusionGroupsHandler = exclusion_group.FindExclusionGroupsHandlerFunc(c.FindExclusionGroups)
+	api.ExclusionGroupCreateExclusionGroupHandler = exclusion_group.CreateExclusionGroupHandlerFunc(c.CreateExclusionGroup)
+	api.ExclusionGroupGetExclusionGroupHandler = exclusion_group.GetExclusionGroupHandlerFunc(c.GetExclusionGroup)
+	api.ExclusionGroupPutExclusionGroupHandler = exclusion_group.PutExclusionGroupHandlerFunc(c.PutExclusionGroup)
+	api.ExclusionGroupDeleteExclusionGroupHandler = exclusion_group.DeleteExclusionGroupHandlerFunc(c.DeleteExclusionGroup)
+	api.ExclusionGroupPutFlagExclusionGroupHandler = exclusion_group.PutFlagExclusionGroupHandlerFunc(c.PutFlagExclusionGroup)
 }
 
 func setupEvaluation(api *operations.FlagrAPI) {
@@ -72,12 +162,125 @@ func setupEvaluation(api *operations.FlagrAPI) {
 	ec.Start()
 
 	e := NewEval()
+	api.EvaluationGetEvaluationHandler = evaluation.GetEvaluationHandlerFunc(e.GetEvaluation)
 	api.EvaluationPostEvaluationHandler = evaluation.PostEvaluationHandlerFunc(e.PostEvaluation)
 	api.EvaluationPostEvaluationBatchHandler = evaluation.PostEvaluationBatchHandlerFunc(e.PostEvaluationBatch)
+	api.EvaluationPostEvaluationByTagHandler = evaluation.PostEvaluationByTagHandlerFunc(e.PostEvaluationByTag)
+	api.EvaluationPostEvaluationExplainHandler = evaluation.PostEvaluationExplainHandlerFunc(e.PostEvaluationExplain)
+	api.EvaluationPostEvaluationFrontendEventsHandler = evaluation.PostEvaluationFrontendEventsHandlerFunc(e.PostEvaluationFrontendEvents)
+	api.EvaluationPostEvaluationCacheRefreshHandler = evaluation.PostEvaluationCacheRefreshHandlerFunc(e.PostEvaluationCacheRefresh)
+	api.ConstraintEvaluateConstraintHandler = constraint.EvaluateConstraintHandlerFunc(e.EvaluateConstraint)
 
 	if config.Config.RecorderEnabled {
 		// Try GetDataRecorder to catch fatal errors before we start the evaluation api
-		GetDataRecorder()
+		rec := GetDataRecorder()
+
+		if config.Global.Prometheus.RecorderQueued != nil {
+			go func() {
+				for range time.Tick(dataRecorderStatsInterval) {
+					exportDataRecorderStats(rec)
+				}
+			}()
+		}
+
+		// flush the buffered data records on shutdown
+		if c, ok := rec.(io.Closer); ok {
+			shutdown := api.ServerShutdown
+			api.ServerShutdown = func() {
+				c.Close()
+				if shutdown != nil {
+					shutdown()
+				}
+			}
+		}
+	}
+}
+
+func setupFlagChangeWebhook(api *operations.FlagrAPI) {
+	if config.Config.FlagChangeWebhookURL == "" {
+		return
+	}
+
+	w := newFlagChangeWebhook()
+	addFlagHistoryHook(w.Notify)
+
+	// deliver the buffered events on shutdown
+	shutdown := api.ServerShutdown
+	api.ServerShutdown = func() {
+		w.Close()
+		if shutdown != nil {
+			shutdown()
+		}
+	}
+}
+
+func setupFlagChangeKafka(api *operations.FlagrAPI) {
+	if config.Config.FlagChangeKafkaTopic == "" {
+		return
+	}
+
+	k := newFlagChangeKafka()
+	addFlagHistoryHook(k.Notify)
+
+	shutdown := api.ServerShutdown
+	api.ServerShutdown = func() {
+		k.Close()
+		if shutdown != nil {
+			shutdown()
+		}
+	}
+}
+
+func setupFlagChangeStream(api *operations.FlagrAPI) {
+	addFlagHistoryHook(flagChangeStreamer.Notify)
+	api.FlagGetFlagsStreamHandler = flag.GetFlagsStreamHandlerFunc(flagChangeStreamer.GetFlagsStream)
+}
+
+func setupEvalCacheInvalidation(api *operations.FlagrAPI) {
+	if config.Config.EvalCacheInvalidationKafkaTopic == "" {
+		return
+	}
+
+	inv := newEvalCacheInvalidation(GetEvalCache())
+	if !config.Config.EvalOnlyMode {
+		addFlagHistoryHook(inv.Notify)
+	}
+
+	shutdown := api.ServerShutdown
+	api.ServerShutdown = func() {
+		inv.Close()
+		if shutdown != nil {
+			shutdown()
+		}
+	}
+}
+
+// addFlagHistoryHook chains the hook after the existing FlagHistoryHook, so that
+// the flag changes can be notified to more than one destination
+func addFlagHistoryHook(hook func(fh *entity.FlagHistory)) {
+	prev := entity.FlagHistoryHook
+	if prev == nil {
+		entity.FlagHistoryHook = hook
+		return
+	}
+	entity.FlagHistoryHook = func(fh *entity.FlagHistory) {
+		prev(fh)
+		hook(fh)
+	}
+}
+
+func setupGRPC(api *operations.FlagrAPI) {
+	if config.Config.GRPCPort == 0 {
+		return
+	}
+
+	s := startGRPCServer(config.Config.Host, config.Config.GRPCPort)
+	shutdown := api.ServerShutdown
+	api.ServerShutdown = func() {
+		s.GracefulStop()
+		if shutdown != nil {
+			shutdown()
+		}
 	}
 }
 
@@ -85,9 +288,24 @@ func setupHealth(api *operations.FlagrAPI) {
 	api.HealthGetHealthHandler = health.GetHealthHandlerFunc(
 		func(health.GetHealthParams) middleware.Responder { return &health.GetHealthOK{} },
 	)
+	api.HealthGetReadyHandler = health.GetReadyHandlerFunc(getReady)
+}
+
+// getReady is the readiness of the evaluations, it's not ready until the evaluation cache is warmed up.
+// The backlog of the data recorder is only a warning, taking the replica out wouldn't make the recorder faster.
+func getReady(health.GetReadyParams) middleware.Responder {
+	if !GetEvalCache().IsWarmedUp() {
+		return health.NewGetReadyServiceUnavailable().WithPayload(ErrorMessage("the evaluation cache is warming up"))
+	}
+	readiness := &models.Readiness{Warnings: []string{}}
+	if w := dataRecorderBacklogWarning(); w != "" {
+		readiness.Warnings = append(readiness.Warnings, w)
+	}
+	return health.NewGetReadyOK().WithPayload(readiness)
 }
 
 func setupExport(api *operations.FlagrAPI) {
 	api.ExportGetExportSqliteHandler = export.GetExportSqliteHandlerFunc(exportSQLiteHandler)
 	api.ExportGetExportEvalCacheJSONHandler = export.GetExportEvalCacheJSONHandlerFunc(exportEvalCacheJSONHandler)
+	api.ExportGetExportFlagsCsvHandler = export.GetExportFlagsCsvHandlerFunc(exportFlagsCSVHandler)
 }
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
 	"github.com/checkr/flagr/pkg/entity"
@@ -16,3 +18,25 @@ func TestSetup(t *testing.T) {
 		Setup(&operations.FlagrAPI{})
 	})
 }
+
+func TestGetRequestDB(t *testing.T) {
+	db := entity.PopulateTestDB(entity.GenFixtureFlag())
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	t.Run("it should get the db without a request", func(t *testing.T) {
+		assert.True(t, getRequestDB(nil) == db)
+	})
+
+	t.Run("it should query with the context of the request", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/flags", nil)
+		assert.NoError(t, getRequestDB(req).First(&entity.Flag{}).Error)
+	})
+
+	t.Run("it should stop querying once the request is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, _ := http.NewRequest("GET", "/api/v1/flags", nil)
+		assert.Equal(t, context.Canceled, getRequestDB(req.WithContext(ctx)).First(&entity.Flag{}).Error)
+	})
+}
@@ -10,17 +10,87 @@ import (
 )
 
 func getSubjectFromRequest(r *http.Request) string {
-	if r == nil {
+	return getClaimFromRequest(r, config.Config.JWTAuthUserClaim)
+}
+
+// getNamespaceFromRequest gets the namespace of the caller, it's empty if the
+// namespaces are not enabled or the request has no JWT token
+func getNamespaceFromRequest(r *http.Request) string {
+	if !namespacesEnabled() {
+		return ""
+	}
+	return getClaimFromRequest(r, config.Config.JWTAuthNamespaceClaim)
+}
+
+func getClaimFromRequest(r *http.Request, claim string) string {
+	claims := getClaimsFromRequest(r)
+	if claims == nil {
 		return ""
 	}
+	return util.SafeString(claims[claim])
+}
+
+// isAdminRequest checks if the caller is in the group of JWTAuthAdminGroupClaim, every caller is
+// an admin if there's no auth
+func isAdminRequest(r *http.Request) bool {
+	if !config.Config.JWTAuthEnabled && !config.Config.ProxyAuthEnabled {
+		return true
+	}
+	if config.Config.JWTAuthAdminGroupClaim == "" {
+		return false
+	}
+	claims := getClaimsFromRequest(r)
+	if claims == nil {
+		return false
+	}
+	for _, g := range util.SafeStringSlice(claims["groups"]) {
+		if g == config.Config.JWTAuthAdminGroupClaim {
+			return true
+		}
+	}
+	return false
+}
+
+// getClaimsFromRequest gets the claims of the valid JWT token of the request, it's nil if there's no such token
+func getClaimsFromRequest(r *http.Request) jwt.MapClaims {
+	if r == nil {
+		return nil
+	}
 
 	token, ok := r.Context().Value(config.Config.JWTAuthUserProperty).(*jwt.Token)
 	if !ok {
-		return ""
+		return nil
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		return util.SafeString(claims[config.Config.JWTAuthUserClaim])
+		return claims
+	}
+	return nil
+}
+
+// withJWTClaims merges the claims of EvalContextFromJWTClaims into the entityContext if EvalContextFromJWT
+// is enabled, the entityContext takes precedence. The invalid entityContext is returned as it is.
+func withJWTClaims(r *http.Request, entityContext interface{}) interface{} {
+	if !config.Config.EvalContextFromJWT {
+		return entityContext
+	}
+	claims := getClaimsFromRequest(r)
+	if claims == nil {
+		return entityContext
+	}
+
+	m, ok := entityContext.(map[string]interface{})
+	if !ok && entityContext != nil {
+		return entityContext
+	}
+	ret := make(map[string]interface{}, len(m)+len(config.Config.EvalContextFromJWTClaims))
+	for _, claim := range config.Config.EvalContextFromJWTClaims {
+		if v, ok := claims[claim]; ok {
+			ret[claim] = v
+		}
+	}
+	for k, v := range m {
+		ret[k] = v
 	}
-	return ""
+	return ret
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/checkr/flagr/pkg/config"
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,3 +31,49 @@ func TestGetSubjectFromRequest(t *testing.T) {
 	})
 	assert.Equal(t, getSubjectFromRequest(r.WithContext(ctx)), "foo@example.com")
 }
+
+func TestGetNamespaceFromRequest(t *testing.T) {
+	r := genNamespaceRequest("checkout")
+	assert.Equal(t, "", getNamespaceFromRequest(r))
+
+	defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+	assert.Equal(t, "checkout", getNamespaceFromRequest(r))
+	assert.Equal(t, "", getNamespaceFromRequest(nil))
+}
+
+func TestWithJWTClaims(t *testing.T) {
+	r, _ := http.NewRequest("POST", "", nil)
+	r = r.WithContext(context.WithValue(context.TODO(), interface{}(config.Config.JWTAuthUserProperty), &jwt.Token{
+		Claims: jwt.MapClaims{"sub": "foo@example.com", "groups": []interface{}{"admins"}, "team": "checkout"},
+		Valid:  true,
+	}))
+	entityContext := map[string]interface{}{"state": "CA"}
+
+	t.Run("it returns the entityContext as it is if it's not enabled", func(t *testing.T) {
+		assert.Equal(t, entityContext, withJWTClaims(r, entityContext))
+	})
+
+	defer gostub.Stub(&config.Config.EvalContextFromJWT, true).Reset()
+	defer gostub.Stub(&config.Config.EvalContextFromJWTClaims, []string{"sub", "groups", "missing"}).Reset()
+
+	t.Run("it merges the selected claims", func(t *testing.T) {
+		assert.Equal(t, map[string]interface{}{
+			"sub":    "foo@example.com",
+			"groups": []interface{}{"admins"},
+			"state":  "CA",
+		}, withJWTClaims(r, entityContext))
+		assert.Equal(t, map[string]interface{}{"state": "CA"}, entityContext)
+		assert.Equal(t, "foo@example.com", withJWTClaims(r, nil).(map[string]interface{})["sub"])
+	})
+
+	t.Run("it lets the entityContext take precedence", func(t *testing.T) {
+		m := withJWTClaims(r, map[string]interface{}{"sub": "bar@example.com"}).(map[string]interface{})
+		assert.Equal(t, "bar@example.com", m["sub"])
+	})
+
+	t.Run("it returns the entityContext as it is without a token", func(t *testing.T) {
+		noToken, _ := http.NewRequest("POST", "", nil)
+		assert.Equal(t, entityContext, withJWTClaims(noToken, entityContext))
+		assert.Equal(t, "invalid", withJWTClaims(r, "invalid"))
+	})
+}
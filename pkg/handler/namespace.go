@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
+)
+
+// namespacesEnabled tells if the flags are isolated by the namespaces of the callers
+func namespacesEnabled() bool {
+	return config.Config.JWTAuthNamespaceClaim != ""
+}
+
+// whereNamespace scopes the query of flags to the namespace of the caller
+func whereNamespace(tx *gorm.DB, r *http.Request) *gorm.DB {
+	if !namespacesEnabled() {
+		return tx
+	}
+	return tx.Where("namespace = ?", getNamespaceFromRequest(r))
+}
+
+// NamespaceMiddleware responds 404 to the requests of a flag outside the namespace of the caller.
+// It covers all the routes with the flagID param, so it has to run after the routing.
+func NamespaceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !namespacesEnabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		route := middleware.MatchedRouteFrom(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// the invalid flagIDs are left to the validation of the params
+		flagID, err := strconv.ParseUint(route.Params.Get("flagID"), 10, 64)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if e := validateFlagNamespace(getDB(), uint(flagID), getNamespaceFromRequest(r)); e != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(e.StatusCode)
+			json.NewEncoder(w).Encode(ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateFlagNamespace makes sure the flag is in the namespace, the flags of
+// the other namespaces are 404 just like the flags that don't exist.
+// The deleted flags are included so that they can only be restored in their own namespace.
+var validateFlagNamespace = func(db *gorm.DB, flagID uint, namespace string) *Error {
+	f := &entity.Flag{}
+	err := db.Unscoped().Select("id, namespace").First(f, flagID).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil
+	}
+	if err != nil {
+		return NewError(500, "error finding flagID %v. reason: %s", flagID, err)
+	}
+	if f.Namespace != namespace {
+		return NewError(404, "cannot find flag %v. record not found", flagID)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/checkr/flagr/pkg/config"
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func genNamespaceRequest(namespace string) *http.Request {
+	r, _ := http.NewRequest("GET", "", nil)
+	ctx := context.WithValue(context.TODO(), interface{}(config.Config.JWTAuthUserProperty), &jwt.Token{
+		Claims: jwt.MapClaims{"sub": "foo@example.com", "team": namespace},
+		Valid:  true,
+	})
+	return r.WithContext(ctx)
+}
+
+func TestValidateFlagNamespace(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	f.Namespace = "checkout"
+	db := entity.PopulateTestDB(f)
+	defer db.Close()
+
+	assert.Nil(t, validateFlagNamespace(db, f.ID, "checkout"))
+	assert.Equal(t, 404, validateFlagNamespace(db, f.ID, "search").StatusCode)
+	assert.Equal(t, 404, validateFlagNamespace(db, f.ID, "").StatusCode)
+
+	t.Run("it leaves the missing flags to the handlers", func(t *testing.T) {
+		assert.Nil(t, validateFlagNamespace(db, 999, "search"))
+	})
+
+	t.Run("it includes the deleted flags", func(t *testing.T) {
+		db.Delete(&entity.Flag{}, f.ID)
+		assert.Equal(t, 404, validateFlagNamespace(db, f.ID, "search").StatusCode)
+	})
+}
+
+func TestNamespaceMiddleware(t *testing.T) {
+	called := false
+	h := NamespaceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	t.Run("it passes through if the namespaces are not enabled", func(t *testing.T) {
+		called = false
+		h.ServeHTTP(httptest.NewRecorder(), genNamespaceRequest("search"))
+		assert.True(t, called)
+	})
+
+	t.Run("it passes through the requests without the flagID param", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+		defer gostub.StubFunc(&validateFlagNamespace, NewError(404, "not found")).Reset()
+
+		called = false
+		h.ServeHTTP(httptest.NewRecorder(), genNamespaceRequest("search"))
+		assert.True(t, called)
+	})
+}
+
+func TestCrudFlagNamespace(t *testing.T) {
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+
+	t.Run("it should create the flags in the namespace of the caller", func(t *testing.T) {
+		res := c.CreateFlag(flag.CreateFlagParams{
+			HTTPRequest: genNamespaceRequest("checkout"),
+			Body:        &models.CreateFlagRequest{Description: util.StringPtr("checkout"), Key: "checkout_redesign"},
+		})
+		assert.Equal(t, "checkout", res.(*flag.CreateFlagOK).Payload.Namespace)
+
+		res = c.CreateFlag(flag.CreateFlagParams{
+			HTTPRequest: genNamespaceRequest("search"),
+			Body:        &models.CreateFlagRequest{Description: util.StringPtr("search"), Key: "search_ranking"},
+		})
+		assert.Equal(t, "search", res.(*flag.CreateFlagOK).Payload.Namespace)
+	})
+
+	t.Run("it should only find the flags of the namespace", func(t *testing.T) {
+		res := c.FindFlags(flag.FindFlagsParams{HTTPRequest: genNamespaceRequest("checkout")})
+		payload := res.(*flag.FindFlagsOK).Payload
+		assert.Len(t, payload, 1)
+		assert.Equal(t, "checkout_redesign", payload[0].Key)
+		assert.Equal(t, int64(1), res.(*flag.FindFlagsOK).XTotalCount)
+
+		res = c.FindFlags(flag.FindFlagsParams{HTTPRequest: genNamespaceRequest("billing")})
+		assert.Len(t, res.(*flag.FindFlagsOK).Payload, 0)
+	})
+
+	t.Run("it should not overwrite the flags of the other namespaces in batch", func(t *testing.T) {
+		res := c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+			HTTPRequest: genNamespaceRequest("search"),
+			Body: &models.SaveFlagsBatchRequest{Flags: []*models.FlagDefinition{
+				{Key: "checkout_redesign", Description: util.StringPtr("taken over")},
+			}},
+		})
+		results := res.(*flag.SaveFlagsBatchBadRequest).Payload.Results
+		assert.Contains(t, results[0].Error, "already exists")
+	})
+}
+
+func TestCrudChildrenOfOtherNamespace(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	f.Namespace = "search"
+	db := entity.PopulateTestDB(f)
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.JWTAuthNamespaceClaim, "team").Reset()
+
+	own := &entity.Flag{Key: "checkout_redesign", Namespace: "checkout"}
+	db.Create(own)
+	flagID := int64(own.ID)
+	r := genNamespaceRequest("checkout")
+
+	t.Run("it should not put or delete the segments of the other flags", func(t *testing.T) {
+		res := c.PutSegment(segment.PutSegmentParams{
+			HTTPRequest: r,
+			FlagID:      flagID,
+			SegmentID:   int64(200),
+			Body:        &models.PutSegmentRequest{Description: util.StringPtr("taken over"), RolloutPercent: util.Int64Ptr(0)},
+		})
+		assert.IsType(t, &segment.PutSegmentDefault{}, res)
+		assert.Contains(t, *res.(*segment.PutSegmentDefault).Payload.Message, "record not found")
+
+		res = c.PutSegmentsReorder(segment.PutSegmentsReorderParams{
+			HTTPRequest: r,
+			FlagID:      flagID,
+			Body:        &models.PutSegmentReorderRequest{SegmentIds: []int64{200}},
+		})
+		assert.IsType(t, &segment.PutSegmentsReorderDefault{}, res)
+
+		res = c.DeleteSegment(segment.DeleteSegmentParams{HTTPRequest: r, FlagID: flagID, SegmentID: int64(200)})
+		assert.IsType(t, &segment.DeleteSegmentDefault{}, res)
+	})
+
+	t.Run("it should not create, find, put or delete the constraints of the other flags", func(t *testing.T) {
+		res := c.CreateConstraint(constraint.CreateConstraintParams{
+			HTTPRequest: r,
+			FlagID:      flagID,
+			SegmentID:   int64(200),
+			Body:        &models.CreateConstraintRequest{Property: util.StringPtr("state"), Operator: util.StringPtr("EQ"), Value: util.StringPtr(`"NY"`)},
+		})
+		assert.IsType(t, &constraint.CreateConstraintDefault{}, res)
+
+		res = c.FindConstraints(constraint.FindConstraintsParams{HTTPRequest: r, FlagID: flagID, SegmentID: int64(200)})
+		assert.IsType(t, &constraint.FindConstraintsDefault{}, res)
+
+		res = c.PutConstraint(constraint.PutConstraintParams{
+			HTTPRequest:  r,
+			FlagID:       flagID,
+			SegmentID:    int64(200),
+			ConstraintID: int64(500),
+			Body:         &models.CreateConstraintRequest{Property: util.StringPtr("state"), Operator: util.StringPtr("EQ"), Value: util.StringPtr(`"NY"`)},
+		})
+		assert.IsType(t, &constraint.PutConstraintDefault{}, res)
+
+		res = c.DeleteConstraint(constraint.DeleteConstraintParams{HTTPRequest: r, FlagID: flagID, SegmentID: int64(200), ConstraintID: int64(500)})
+		assert.IsType(t, &constraint.DeleteConstraintDefault{}, res)
+	})
+
+	t.Run("it should not find or put the distributions of the other flags", func(t *testing.T) {
+		res := c.FindDistributions(distribution.FindDistributionsParams{HTTPRequest: r, FlagID: flagID, SegmentID: int64(200)})
+		assert.IsType(t, &distribution.FindDistributionsDefault{}, res)
+
+		res = c.PutDistributions(distribution.PutDistributionsParams{
+			HTTPRequest: r,
+			FlagID:      flagID,
+			SegmentID:   int64(200),
+			Body: &models.PutDistributionsRequest{Distributions: []*models.Distribution{
+				{Percent: util.Int64Ptr(100), VariantID: util.Int64Ptr(300), VariantKey: util.StringPtr("control")},
+			}},
+		})
+		assert.IsType(t, &distribution.PutDistributionsDefault{}, res)
+	})
+
+	t.Run("it should not put or delete the variants of the other flags", func(t *testing.T) {
+		res := c.PutVariant(variant.PutVariantParams{
+			HTTPRequest: r,
+			FlagID:      flagID,
+			VariantID:   int64(300),
+			Body:        &models.PutVariantRequest{Key: util.StringPtr("taken_over")},
+		})
+		assert.IsType(t, &variant.PutVariantDefault{}, res)
+
+		res = c.DeleteVariant(variant.DeleteVariantParams{HTTPRequest: r, FlagID: flagID, VariantID: int64(301)})
+		assert.IsType(t, &variant.DeleteVariantDefault{}, res)
+	})
+
+	t.Run("it should leave the flag of the other namespace as it was", func(t *testing.T) {
+		other := &entity.Flag{}
+		entity.PreloadSegmentsVariants(db).First(other, 100)
+		assert.Len(t, other.Segments, 1)
+		assert.Equal(t, "", other.Segments[0].Description)
+		assert.Len(t, other.Segments[0].Constraints, 1)
+		assert.Len(t, other.Segments[0].Distributions, 2)
+		assert.Len(t, other.Variants, 2)
+		assert.Equal(t, "control", other.Variants[0].Key)
+	})
+}
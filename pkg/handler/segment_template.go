@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/mapper/entity_restapi/e2r"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment_template"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+func (c *crud) FindSegmentTemplates(params segment_template.FindSegmentTemplatesParams) middleware.Responder {
+	ts := []entity.SegmentTemplate{}
+	if err := getRequestDB(params.HTTPRequest).Order("key").Find(&ts).Error; err != nil {
+		return segment_template.NewFindSegmentTemplatesDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	payload := make([]*models.SegmentTemplate, len(ts))
+	for i := range ts {
+		t, err := e2r.MapSegmentTemplate(&ts[i])
+		if err != nil {
+			return segment_template.NewFindSegmentTemplatesDefault(500).WithPayload(ErrorMessage("%s", err))
+		}
+		payload[i] = t
+	}
+	resp := segment_template.NewFindSegmentTemplatesOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+func (c *crud) CreateSegmentTemplate(params segment_template.CreateSegmentTemplateParams) middleware.Responder {
+	key := util.SafeString(params.Body.Key)
+	if ok, reason := util.IsSafeKey(key); !ok {
+		return segment_template.NewCreateSegmentTemplateDefault(400).WithPayload(
+			ErrorMessage("cannot create segment template due to invalid key. reason: %s", reason))
+	}
+
+	count := 0
+	if err := getRequestDB(params.HTTPRequest).Model(&entity.SegmentTemplate{}).Where(entity.SegmentTemplate{Key: key}).Count(&count).Error; err != nil {
+		return segment_template.NewCreateSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if count > 0 {
+		return segment_template.NewCreateSegmentTemplateDefault(409).WithPayload(
+			ErrorMessage("cannot create segment template. key %s already exists", key))
+	}
+
+	s, e := mapSegmentDefinition(params.Body.Segment)
+	if e != nil {
+		return segment_template.NewCreateSegmentTemplateDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	t := &entity.SegmentTemplate{Key: key, Description: params.Body.Description}
+	if err := t.SetSegment(s); err != nil {
+		return segment_template.NewCreateSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := getRequestDB(params.HTTPRequest).Create(t).Error; err != nil {
+		return segment_template.NewCreateSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	payload, err := e2r.MapSegmentTemplate(t)
+	if err != nil {
+		return segment_template.NewCreateSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp := segment_template.NewCreateSegmentTemplateOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+func (c *crud) GetSegmentTemplate(params segment_template.GetSegmentTemplateParams) middleware.Responder {
+	t := &entity.SegmentTemplate{}
+	if err := getRequestDB(params.HTTPRequest).First(t, params.SegmentTemplateID).Error; err != nil {
+		return segment_template.NewGetSegmentTemplateDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	payload, err := e2r.MapSegmentTemplate(t)
+	if err != nil {
+		return segment_template.NewGetSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp := segment_template.NewGetSegmentTemplateOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+// PutSegmentTemplate replaces the description and the segment of the template,
+// the segments created from it before are left as they are
+func (c *crud) PutSegmentTemplate(params segment_template.PutSegmentTemplateParams) middleware.Responder {
+	t := &entity.SegmentTemplate{}
+	if err := getRequestDB(params.HTTPRequest).First(t, params.SegmentTemplateID).Error; err != nil {
+		return segment_template.NewPutSegmentTemplateDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	s, e := mapSegmentDefinition(params.Body.Segment)
+	if e != nil {
+		return segment_template.NewPutSegmentTemplateDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	t.Description = params.Body.Description
+	if err := t.SetSegment(s); err != nil {
+		return segment_template.NewPutSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if err := getRequestDB(params.HTTPRequest).Save(t).Error; err != nil {
+		return segment_template.NewPutSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	payload, err := e2r.MapSegmentTemplate(t)
+	if err != nil {
+		return segment_template.NewPutSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	resp := segment_template.NewPutSegmentTemplateOK()
+	resp.SetPayload(payload)
+	return resp
+}
+
+func (c *crud) DeleteSegmentTemplate(params segment_template.DeleteSegmentTemplateParams) middleware.Responder {
+	if err := getRequestDB(params.HTTPRequest).Delete(&entity.SegmentTemplate{}, params.SegmentTemplateID).Error; err != nil {
+		return segment_template.NewDeleteSegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	return segment_template.NewDeleteSegmentTemplateOK()
+}
+
+// ApplySegmentTemplate creates a segment of the flag from the template. The variant keys of the template
+// are filled in by the variants in the request, or else by the variants of the flag with the same keys.
+// All of them must be the variants of the flag, nothing is created otherwise.
+func (c *crud) ApplySegmentTemplate(params segment_template.ApplySegmentTemplateParams) middleware.Responder {
+	t := &entity.SegmentTemplate{}
+	if err := getRequestDB(params.HTTPRequest).First(t, params.SegmentTemplateID).Error; err != nil {
+		return segment_template.NewApplySegmentTemplateDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	flagID := util.SafeUint(params.Body.FlagID)
+	f := &entity.Flag{}
+	if err := getRequestDB(params.HTTPRequest).Preload("Variants").First(f, flagID).Error; err != nil {
+		return segment_template.NewApplySegmentTemplateDefault(404).WithPayload(ErrorMessage("%s", err))
+	}
+
+	enabled, err := isFlagEnabledAnywhere(getRequestDB(params.HTTPRequest), f)
+	if err != nil {
+		return segment_template.NewApplySegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if e := validateWithoutApproval(enabled, "apply segment template %v to flag %v", t.ID, f.ID); e != nil {
+		return segment_template.NewApplySegmentTemplateDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	s, err := t.NewSegment(f.ID)
+	if err != nil {
+		return segment_template.NewApplySegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+	if e := fillSegmentTemplateVariants(s, f, params.Body.Variants); e != nil {
+		return segment_template.NewApplySegmentTemplateDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+	if e := validateNewSegment(getRequestDB(params.HTTPRequest), f.ID, len(s.Constraints)); e != nil {
+		return segment_template.NewApplySegmentTemplateDefault(e.StatusCode).WithPayload(
+			ErrorMessage("%s", fmt.Sprintf(e.Message, e.Values...)))
+	}
+
+	if err := getRequestDB(params.HTTPRequest).Create(s).Error; err != nil {
+		return segment_template.NewApplySegmentTemplateDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	resp := segment_template.NewApplySegmentTemplateOK()
+	resp.SetPayload(e2r.MapSegment(s))
+
+	entity.SaveFlagHistory(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest), entity.FlagHistoryEntityTypeSegment, s.ID, nil, s)
+	entity.SaveFlagSnapshot(getDB(), f.ID, getSubjectFromRequest(params.HTTPRequest))
+	return resp
+}
+
+// fillSegmentTemplateVariants sets the variants of the flag on the distributions of the segment created from a template
+func fillSegmentTemplateVariants(s *entity.Segment, f *entity.Flag, rs []*models.SegmentTemplateVariant) *Error {
+	variantsByID := make(map[uint]entity.Variant, len(f.Variants))
+	variants := make(map[string]entity.Variant, len(f.Variants))
+	for _, v := range f.Variants {
+		variantsByID[v.ID] = v
+		variants[v.Key] = v
+	}
+	for _, r := range rs {
+		variantID := util.SafeUint(r.VariantID)
+		v, ok := variantsByID[variantID]
+		if !ok {
+			return NewError(400, "cannot find variant %v in flag %v", variantID, f.ID)
+		}
+		variants[util.SafeString(r.VariantKey)] = v
+	}
+
+	for i := range s.Distributions {
+		d := &s.Distributions[i]
+		v, ok := variants[d.VariantKey]
+		if !ok {
+			return NewError(400, "cannot fill in variantKey %s of the template. there's no variant with the key in flag %v", d.VariantKey, f.ID)
+		}
+		d.VariantID = v.ID
+		d.VariantKey = v.Key
+	}
+	return nil
+}
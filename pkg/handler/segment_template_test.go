@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment_template"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrudSegmentTemplates(t *testing.T) {
+	f := entity.GenFixtureFlag()
+	db := entity.PopulateTestDB(f)
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	halfAndHalf := func(percent int64) *models.SegmentDefinition {
+		return &models.SegmentDefinition{
+			Description:    util.StringPtr("employees"),
+			RolloutPercent: util.Int64Ptr(100),
+			Constraints: []*models.CreateConstraintRequest{{
+				Property: util.StringPtr("employee"),
+				Operator: util.StringPtr(models.ConstraintOperatorEQ),
+				Value:    util.StringPtr(`"yes"`),
+			}},
+			Distributions: []*models.DistributionDefinition{
+				{VariantKey: util.StringPtr("off"), Percent: util.Int64Ptr(percent)},
+				{VariantKey: util.StringPtr("treatment"), Percent: util.Int64Ptr(50)},
+			},
+		}
+	}
+
+	t.Run("it should create the segment template", func(t *testing.T) {
+		res := c.CreateSegmentTemplate(segment_template.CreateSegmentTemplateParams{
+			Body: &models.CreateSegmentTemplateRequest{Key: util.StringPtr("employees"), Segment: halfAndHalf(50)},
+		})
+		payload := res.(*segment_template.CreateSegmentTemplateOK).Payload
+		assert.Equal(t, int64(1), payload.ID)
+		assert.Len(t, payload.Segment.Constraints, 1)
+		assert.Equal(t, "off", *payload.Segment.Distributions[0].VariantKey)
+
+		res = c.CreateSegmentTemplate(segment_template.CreateSegmentTemplateParams{
+			Body: &models.CreateSegmentTemplateRequest{Key: util.StringPtr("employees"), Segment: halfAndHalf(50)},
+		})
+		assert.IsType(t, &segment_template.CreateSegmentTemplateDefault{}, res)
+
+		res = c.CreateSegmentTemplate(segment_template.CreateSegmentTemplateParams{
+			Body: &models.CreateSegmentTemplateRequest{Key: util.StringPtr("admins"), Segment: halfAndHalf(10)},
+		})
+		assert.IsType(t, &segment_template.CreateSegmentTemplateDefault{}, res)
+	})
+
+	t.Run("it should find and get the segment templates", func(t *testing.T) {
+		res := c.FindSegmentTemplates(segment_template.FindSegmentTemplatesParams{})
+		assert.Len(t, res.(*segment_template.FindSegmentTemplatesOK).Payload, 1)
+
+		res = c.GetSegmentTemplate(segment_template.GetSegmentTemplateParams{SegmentTemplateID: 1})
+		assert.Equal(t, "employees", *res.(*segment_template.GetSegmentTemplateOK).Payload.Key)
+
+		res = c.GetSegmentTemplate(segment_template.GetSegmentTemplateParams{SegmentTemplateID: 999})
+		assert.IsType(t, &segment_template.GetSegmentTemplateDefault{}, res)
+	})
+
+	t.Run("it should update the segment template", func(t *testing.T) {
+		res := c.PutSegmentTemplate(segment_template.PutSegmentTemplateParams{
+			SegmentTemplateID: 1,
+			Body:              &models.PutSegmentTemplateRequest{Description: "half of the employees", Segment: halfAndHalf(50)},
+		})
+		assert.Equal(t, "half of the employees", res.(*segment_template.PutSegmentTemplateOK).Payload.Description)
+
+		res = c.PutSegmentTemplate(segment_template.PutSegmentTemplateParams{
+			SegmentTemplateID: 1,
+			Body:              &models.PutSegmentTemplateRequest{Segment: halfAndHalf(60)},
+		})
+		assert.IsType(t, &segment_template.PutSegmentTemplateDefault{}, res)
+	})
+
+	t.Run("it should apply the segment template to the flag", func(t *testing.T) {
+		apply := func(flagID int64, variants ...*models.SegmentTemplateVariant) middleware.Responder {
+			return c.ApplySegmentTemplate(segment_template.ApplySegmentTemplateParams{
+				SegmentTemplateID: 1,
+				Body:              &models.ApplySegmentTemplateRequest{FlagID: util.Int64Ptr(flagID), Variants: variants},
+			})
+		}
+		off := func(variantID int64) *models.SegmentTemplateVariant {
+			return &models.SegmentTemplateVariant{VariantKey: util.StringPtr("off"), VariantID: util.Int64Ptr(variantID)}
+		}
+
+		assert.IsType(t, &segment_template.ApplySegmentTemplateDefault{}, apply(999, off(300)))
+		assert.IsType(t, &segment_template.ApplySegmentTemplateDefault{}, apply(int64(f.ID)))
+		assert.IsType(t, &segment_template.ApplySegmentTemplateDefault{}, apply(int64(f.ID), off(999)))
+
+		count := 0
+		db.Model(&entity.Segment{}).Where(entity.Segment{FlagID: f.ID}).Count(&count)
+		assert.Equal(t, 1, count)
+
+		res := apply(int64(f.ID), off(300))
+		payload := res.(*segment_template.ApplySegmentTemplateOK).Payload
+		assert.Equal(t, "employees", *payload.Description)
+		assert.Len(t, payload.Constraints, 1)
+		assert.Equal(t, int64(300), *payload.Distributions[0].VariantID)
+		assert.Equal(t, "control", *payload.Distributions[0].VariantKey)
+		assert.Equal(t, int64(301), *payload.Distributions[1].VariantID)
+
+		db.Model(&entity.Segment{}).Where(entity.Segment{FlagID: f.ID}).Count(&count)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("it should delete the segment template", func(t *testing.T) {
+		res := c.DeleteSegmentTemplate(segment_template.DeleteSegmentTemplateParams{SegmentTemplateID: 1})
+		assert.IsType(t, &segment_template.DeleteSegmentTemplateOK{}, res)
+
+		res = c.GetSegmentTemplate(segment_template.GetSegmentTemplateParams{SegmentTemplateID: 1})
+		assert.IsType(t, &segment_template.GetSegmentTemplateDefault{}, res)
+	})
+}
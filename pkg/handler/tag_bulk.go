@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/tag"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/gorm"
+)
+
+// BulkTags applies the tag operations in order in one transaction, none of them is applied if any of them fails
+func (c *crud) BulkTags(params tag.BulkTagsParams) middleware.Responder {
+	results := make([]*models.BulkTagOperationResult, len(params.Body.Operations))
+	changed := []uint{}
+	seen := make(map[uint]bool)
+
+	tx := getRequestDB(params.HTTPRequest).Begin()
+	for i, op := range params.Body.Operations {
+		flagIDs, e := applyBulkTagOperation(tx, params.HTTPRequest, op)
+		if e != nil {
+			tx.Rollback()
+			return tag.NewBulkTagsDefault(e.StatusCode).WithPayload(
+				ErrorMessage("operations.%d: %s", i, fmt.Sprintf(e.Message, e.Values...)))
+		}
+		for _, id := range flagIDs {
+			if !seen[id] {
+				seen[id] = true
+				changed = append(changed, id)
+			}
+		}
+		results[i] = &models.BulkTagOperationResult{
+			Op:            op.Op,
+			Value:         op.Value,
+			AffectedFlags: util.Int64Ptr(int64(len(flagIDs))),
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return tag.NewBulkTagsDefault(500).WithPayload(ErrorMessage("%s", err))
+	}
+
+	for _, id := range changed {
+		entity.SaveFlagSnapshot(getDB(), id, getSubjectFromRequest(params.HTTPRequest))
+	}
+
+	resp := tag.NewBulkTagsOK()
+	resp.SetPayload(&models.BulkTagsResponse{Results: results})
+	return resp
+}
+
+// applyBulkTagOperation applies the operation and returns the IDs of the flags whose tags are changed by it
+func applyBulkTagOperation(tx *gorm.DB, r *http.Request, op *models.BulkTagOperation) ([]uint, *Error) {
+	value := util.SafeString(op.Value)
+	switch util.SafeString(op.Op) {
+	case models.BulkTagOperationOpAdd:
+		return bulkAddTag(tx, r, value, op.FlagIds)
+	case models.BulkTagOperationOpRemove:
+		return bulkRemoveTag(tx, r, value, op.FlagIds)
+	case models.BulkTagOperationOpRename:
+		return bulkRenameTag(tx, r, value, op.NewValue)
+	}
+	return nil, NewError(400, "unknown op %s", util.SafeString(op.Op))
+}
+
+func bulkAddTag(tx *gorm.DB, r *http.Request, value string, ids []int64) ([]uint, *Error) {
+	flagIDs, e := findBulkTagFlags(tx, r, ids)
+	if e != nil {
+		return nil, e
+	}
+
+	t, err := entity.CreateTag(tx, value)
+	if err != nil {
+		return nil, NewError(400, "%s", err)
+	}
+
+	tagged, err := findTaggedFlagIDs(tx, t.ID, flagIDs)
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+
+	added := []uint{}
+	for _, id := range flagIDs {
+		if tagged[id] {
+			continue
+		}
+		if err := tx.Exec("INSERT INTO flags_tags (flag_id, tag_id) VALUES (?, ?)", id, t.ID).Error; err != nil {
+			return nil, NewError(500, "%s", err)
+		}
+		added = append(added, id)
+	}
+	return added, nil
+}
+
+func bulkRemoveTag(tx *gorm.DB, r *http.Request, value string, ids []int64) ([]uint, *Error) {
+	flagIDs, e := findBulkTagFlags(tx, r, ids)
+	if e != nil {
+		return nil, e
+	}
+
+	t := &entity.Tag{}
+	err := tx.Where(entity.Tag{Value: value}).First(t).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return []uint{}, nil
+	}
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+
+	tagged, err := findTaggedFlagIDs(tx, t.ID, flagIDs)
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+
+	removed := []uint{}
+	for _, id := range flagIDs {
+		if tagged[id] {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 {
+		return removed, nil
+	}
+	if err := tx.Exec("DELETE FROM flags_tags WHERE tag_id = ? AND flag_id IN (?)", t.ID, removed).Error; err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	return removed, nil
+}
+
+// bulkRenameTag renames the tag of all the flags of the caller. The tag row is renamed in place when
+// no other flag has it, otherwise the flags are moved to the tag of newValue, which may already exist
+// and have some of the flags, in which case they're merged without duplicating the flags.
+func bulkRenameTag(tx *gorm.DB, r *http.Request, value string, newValue string) ([]uint, *Error) {
+	if ok, reason := util.IsSafeKey(newValue); !ok {
+		return nil, NewError(400, "invalid newValue. reason: %s", reason)
+	}
+	if newValue == value {
+		return nil, NewError(400, "newValue %s is the same as value", newValue)
+	}
+
+	old := &entity.Tag{}
+	if err := tx.Where(entity.Tag{Value: value}).First(old).Error; err != nil {
+		return nil, NewError(404, "cannot find tag %s. %s", value, err)
+	}
+
+	flagIDs := []uint{}
+	err := whereNamespace(tx.Model(&entity.Flag{}), r).
+		Joins("JOIN flags_tags ON flags_tags.flag_id = flags.id").
+		Where("flags_tags.tag_id = ?", old.ID).
+		Order("flags.id").
+		Pluck("flags.id", &flagIDs).
+		Error
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+
+	total := 0
+	if err := tx.Table("flags_tags").Where("tag_id = ?", old.ID).Count(&total).Error; err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+
+	count := 0
+	if err := tx.Model(&entity.Tag{}).Where(entity.Tag{Value: newValue}).Count(&count).Error; err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+
+	if count == 0 && total == len(flagIDs) {
+		if err := tx.Model(old).Update("value", newValue).Error; err != nil {
+			return nil, NewError(500, "%s", err)
+		}
+		return flagIDs, nil
+	}
+	if len(flagIDs) == 0 {
+		return flagIDs, nil
+	}
+
+	t, err := entity.CreateTag(tx, newValue)
+	if err != nil {
+		return nil, NewError(400, "%s", err)
+	}
+	tagged, err := findTaggedFlagIDs(tx, t.ID, flagIDs)
+	if err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	for _, id := range flagIDs {
+		if tagged[id] {
+			continue
+		}
+		if err := tx.Exec("INSERT INTO flags_tags (flag_id, tag_id) VALUES (?, ?)", id, t.ID).Error; err != nil {
+			return nil, NewError(500, "%s", err)
+		}
+	}
+	if err := tx.Exec("DELETE FROM flags_tags WHERE tag_id = ? AND flag_id IN (?)", old.ID, flagIDs).Error; err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+
+	// the unique index of the tag value covers the soft deleted tags too
+	if total == len(flagIDs) {
+		if err := tx.Unscoped().Delete(old).Error; err != nil {
+			return nil, NewError(500, "%s", err)
+		}
+	}
+	return flagIDs, nil
+}
+
+// findBulkTagFlags makes sure all the flags exist in the namespace of the caller, and removes the duplicated IDs
+func findBulkTagFlags(tx *gorm.DB, r *http.Request, ids []int64) ([]uint, *Error) {
+	if len(ids) == 0 {
+		return nil, NewError(400, "flagIDs are required")
+	}
+
+	flagIDs := []uint{}
+	seen := make(map[uint]bool)
+	for _, id := range ids {
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			flagIDs = append(flagIDs, uint(id))
+		}
+	}
+
+	found := []uint{}
+	if err := whereNamespace(tx.Model(&entity.Flag{}), r).Where("id IN (?)", flagIDs).Pluck("id", &found).Error; err != nil {
+		return nil, NewError(500, "%s", err)
+	}
+	if len(found) != len(flagIDs) {
+		for _, id := range found {
+			delete(seen, id)
+		}
+		for _, id := range flagIDs {
+			if seen[id] {
+				return nil, NewError(404, "cannot find flag %v. record not found", id)
+			}
+		}
+	}
+	return flagIDs, nil
+}
+
+// findTaggedFlagIDs finds which of the flags have the tag already
+func findTaggedFlagIDs(tx *gorm.DB, tagID uint, flagIDs []uint) (map[uint]bool, error) {
+	ids := []uint{}
+	err := tx.Table("flags_tags").
+		Where("tag_id = ? AND flag_id IN (?)", tagID, flagIDs).
+		Pluck("flag_id", &ids).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		tagged[id] = true
+	}
+	return tagged, nil
+}
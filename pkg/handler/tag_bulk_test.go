@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/tag"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkTags(t *testing.T) {
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	for _, key := range []string{"flag_1", "flag_2", "flag_3"} {
+		c.CreateFlag(flag.CreateFlagParams{Body: &models.CreateFlagRequest{Key: key, Description: util.StringPtr(key)}})
+	}
+
+	bulkTags := func(ops ...*models.BulkTagOperation) interface{} {
+		return c.BulkTags(tag.BulkTagsParams{Body: &models.BulkTagsRequest{Operations: ops}})
+	}
+	tagValues := func(flagID int64) []string {
+		res := c.FindTags(tag.FindTagsParams{FlagID: flagID})
+		values := []string{}
+		for _, t := range res.(*tag.FindTagsOK).Payload {
+			values = append(values, *t.Value)
+		}
+		return values
+	}
+	affected := func(res interface{}) []int64 {
+		counts := []int64{}
+		for _, r := range res.(*tag.BulkTagsOK).Payload.Results {
+			counts = append(counts, *r.AffectedFlags)
+		}
+		return counts
+	}
+
+	t.Run("it should add the tag to the flags", func(t *testing.T) {
+		res := bulkTags(
+			&models.BulkTagOperation{Op: util.StringPtr("add"), Value: util.StringPtr("team_a"), FlagIds: []int64{1, 2}},
+			&models.BulkTagOperation{Op: util.StringPtr("add"), Value: util.StringPtr("team_a"), FlagIds: []int64{2, 3, 3}},
+		)
+		assert.Equal(t, []int64{2, 1}, affected(res))
+		assert.Equal(t, []string{"team_a"}, tagValues(1))
+		assert.Equal(t, []string{"team_a"}, tagValues(3))
+	})
+
+	t.Run("it should remove the tag from the flags", func(t *testing.T) {
+		res := bulkTags(
+			&models.BulkTagOperation{Op: util.StringPtr("remove"), Value: util.StringPtr("team_a"), FlagIds: []int64{3}},
+			&models.BulkTagOperation{Op: util.StringPtr("remove"), Value: util.StringPtr("team_x"), FlagIds: []int64{1}},
+		)
+		assert.Equal(t, []int64{1, 0}, affected(res))
+		assert.Empty(t, tagValues(3))
+	})
+
+	t.Run("it should rename the tag in place", func(t *testing.T) {
+		before := c.FindAllTags(tag.FindAllTagsParams{}).(*tag.FindAllTagsOK).Payload
+		res := bulkTags(&models.BulkTagOperation{Op: util.StringPtr("rename"), Value: util.StringPtr("team_a"), NewValue: "team_b"})
+		assert.Equal(t, []int64{2}, affected(res))
+		assert.Equal(t, []string{"team_b"}, tagValues(1))
+
+		after := c.FindAllTags(tag.FindAllTagsParams{}).(*tag.FindAllTagsOK).Payload
+		assert.Len(t, after, 1)
+		assert.Equal(t, before[0].ID, after[0].ID)
+	})
+
+	t.Run("it should merge the tag into the existing one", func(t *testing.T) {
+		bulkTags(&models.BulkTagOperation{Op: util.StringPtr("add"), Value: util.StringPtr("team_c"), FlagIds: []int64{2, 3}})
+
+		res := bulkTags(&models.BulkTagOperation{Op: util.StringPtr("rename"), Value: util.StringPtr("team_b"), NewValue: "team_c"})
+		assert.Equal(t, []int64{2}, affected(res))
+		assert.Equal(t, []string{"team_c"}, tagValues(1))
+		assert.Equal(t, []string{"team_c"}, tagValues(2))
+		assert.Equal(t, []string{"team_c"}, tagValues(3))
+
+		all := c.FindAllTags(tag.FindAllTagsParams{}).(*tag.FindAllTagsOK).Payload
+		assert.Len(t, all, 1)
+		assert.Equal(t, "team_c", *all[0].Value)
+
+		// the renamed tag can be created again
+		res = bulkTags(&models.BulkTagOperation{Op: util.StringPtr("add"), Value: util.StringPtr("team_b"), FlagIds: []int64{1}})
+		assert.Equal(t, []int64{1}, affected(res))
+	})
+
+	t.Run("it should save the snapshots of the affected flags", func(t *testing.T) {
+		count := 0
+		db.Model(&entity.FlagSnapshot{}).Where("flag_id = ?", 3).Count(&count)
+		assert.Equal(t, 4, count)
+	})
+
+	t.Run("it should roll back all the operations if any of them fails", func(t *testing.T) {
+		res := bulkTags(
+			&models.BulkTagOperation{Op: util.StringPtr("add"), Value: util.StringPtr("team_d"), FlagIds: []int64{1}},
+			&models.BulkTagOperation{Op: util.StringPtr("add"), Value: util.StringPtr("team_d"), FlagIds: []int64{999}},
+		)
+		assert.Contains(t, *res.(*tag.BulkTagsDefault).Payload.Message, "operations.1: cannot find flag 999")
+		assert.Equal(t, []string{"team_b", "team_c"}, tagValues(1))
+
+		res = bulkTags(&models.BulkTagOperation{Op: util.StringPtr("add"), Value: util.StringPtr("Invalid Tag"), FlagIds: []int64{1}})
+		assert.Contains(t, *res.(*tag.BulkTagsDefault).Payload.Message, "invalid tag")
+
+		res = bulkTags(&models.BulkTagOperation{Op: util.StringPtr("remove"), Value: util.StringPtr("team_c")})
+		assert.Contains(t, *res.(*tag.BulkTagsDefault).Payload.Message, "flagIDs are required")
+
+		res = bulkTags(&models.BulkTagOperation{Op: util.StringPtr("rename"), Value: util.StringPtr("team_x"), NewValue: "team_y"})
+		assert.Contains(t, *res.(*tag.BulkTagsDefault).Payload.Message, "cannot find tag team_x")
+
+		res = bulkTags(&models.BulkTagOperation{Op: util.StringPtr("rename"), Value: util.StringPtr("team_c"), NewValue: "Invalid Tag"})
+		assert.Contains(t, *res.(*tag.BulkTagsDefault).Payload.Message, "invalid newValue")
+	})
+}
@@ -1,15 +1,31 @@
 package handler
 
 import (
+	"fmt"
+
+	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
+	"github.com/checkr/flagr/pkg/mapper/entity_restapi/r2e"
 	"github.com/checkr/flagr/pkg/util"
+	"github.com/checkr/flagr/swagger_gen/models"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
+	"github.com/jinzhu/gorm"
 )
 
 var validatePutDistributions = func(params distribution.PutDistributionsParams) *Error {
+	if e := validateFlagSegment(getRequestDB(params.HTTPRequest), util.SafeUint(params.FlagID), uint(params.SegmentID)); e != nil {
+		return e
+	}
+	return validateDistributions(getRequestDB(params.HTTPRequest), params.FlagID, params.Body.Distributions)
+}
+
+// validateDistributions validates the distributions of a segment of the flag, the percents have to add
+// up to 100 with at most one rollout schedule, and the variants have to be the ones of the flag
+func validateDistributions(db *gorm.DB, flagID int64, ds []*models.Distribution) *Error {
 	sum := int64(0)
-	for _, d := range params.Body.Distributions {
+	for _, d := range ds {
 		if d.Percent == nil {
 			return NewError(400, "the percent of distribution %v is empty", d.ID)
 		}
@@ -19,11 +35,26 @@ var validatePutDistributions = func(params distribution.PutDistributionsParams)
 		return NewError(400, "the sum of distributions' percent %v is not 100", sum)
 	}
 
+	rampingCount := 0
+	for _, d := range ds {
+		e := &entity.Distribution{}
+		r2e.MapDistributionRolloutSchedule(d.RolloutSchedule, e)
+		if err := e.ValidateRolloutSchedule(); err != nil {
+			return NewError(400, "invalid rollout schedule of variantID %v. reason: %s", util.SafeUint(d.VariantID), err)
+		}
+		if e.HasRolloutSchedule() {
+			rampingCount++
+		}
+	}
+	if rampingCount > 1 {
+		return NewError(400, "at most one distribution can have a rollout schedule, got %v", rampingCount)
+	}
+
 	f := &entity.Flag{}
-	if err := getDB().First(f, params.FlagID).Error; err != nil {
-		return NewError(400, "error finding flagID %v. reason %s", params.FlagID, err)
+	if err := db.First(f, flagID).Error; err != nil {
+		return NewError(400, "error finding flagID %v. reason %s", flagID, err)
 	}
-	f.Preload(getDB())
+	f.Preload(db)
 
 	vMap := make(map[uint]string)
 	vIDs := []uint{}
@@ -32,7 +63,7 @@ var validatePutDistributions = func(params distribution.PutDistributionsParams)
 		vIDs = append(vIDs, v.ID)
 	}
 
-	for _, v := range params.Body.Distributions {
+	for _, v := range ds {
 		vID := util.SafeUint(v.VariantID)
 		k, ok := vMap[vID]
 		if !ok {
@@ -46,12 +77,154 @@ var validatePutDistributions = func(params distribution.PutDistributionsParams)
 	return nil
 }
 
+// validateSegmentsLimit checks the number of segments of a flag against MaxSegmentsPerFlag
+func validateSegmentsLimit(count int) *Error {
+	if max := config.Config.MaxSegmentsPerFlag; max > 0 && count > max {
+		return NewError(422, "the flag would have %v segments, the limit is %v", count, max)
+	}
+	return nil
+}
+
+// validateConstraintsLimit checks the number of constraints of a segment against MaxConstraintsPerSegment
+func validateConstraintsLimit(count int) *Error {
+	if max := config.Config.MaxConstraintsPerSegment; max > 0 && count > max {
+		return NewError(422, "the segment would have %v constraints, the limit is %v", count, max)
+	}
+	return nil
+}
+
+// validateVariantsLimit checks the number of variants of a flag against MaxVariantsPerFlag
+func validateVariantsLimit(count int) *Error {
+	if max := config.Config.MaxVariantsPerFlag; max > 0 && count > max {
+		return NewError(422, "the flag would have %v variants, the limit is %v", count, max)
+	}
+	return nil
+}
+
+// validateFlagSegment makes sure the segment is under the flag of the route, the segments of
+// the other flags are 404 just like the segments that don't exist
+var validateFlagSegment = func(db *gorm.DB, flagID uint, segmentID uint) *Error {
+	s := &entity.Segment{}
+	err := db.Select("id").Where("flag_id = ?", flagID).First(s, segmentID).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return NewError(404, "error finding segmentID %v under flagID %v. reason %s", segmentID, flagID, err)
+	}
+	if err != nil {
+		return NewError(500, "error finding segmentID %v. reason %s", segmentID, err)
+	}
+	return nil
+}
+
+// validateNewSegment checks the flag has room for one more segment with the constraints
+var validateNewSegment = func(db *gorm.DB, flagID uint, constraints int) *Error {
+	count := 0
+	if err := db.Model(&entity.Segment{}).Where(entity.Segment{FlagID: flagID}).Count(&count).Error; err != nil {
+		return NewError(500, "error counting the segments of flagID %v. reason %s", flagID, err)
+	}
+	if e := validateSegmentsLimit(count + 1); e != nil {
+		return e
+	}
+	return validateConstraintsLimit(constraints)
+}
+
+// validateNewConstraint checks the segment has room for one more constraint
+var validateNewConstraint = func(db *gorm.DB, segmentID uint) *Error {
+	count := 0
+	if err := db.Model(&entity.Constraint{}).Where(entity.Constraint{SegmentID: segmentID}).Count(&count).Error; err != nil {
+		return NewError(500, "error counting the constraints of segmentID %v. reason %s", segmentID, err)
+	}
+	return validateConstraintsLimit(count + 1)
+}
+
+// validateNewVariant checks the flag has room for one more variant
+var validateNewVariant = func(db *gorm.DB, flagID uint) *Error {
+	count := 0
+	if err := db.Model(&entity.Variant{}).Where(entity.Variant{FlagID: flagID}).Count(&count).Error; err != nil {
+		return NewError(500, "error counting the variants of flagID %v. reason %s", flagID, err)
+	}
+	return validateVariantsLimit(count + 1)
+}
+
+// validatePutSegmentsReorder makes sure the segment ids are exactly the segments of the flag,
+// so that none of the segments is left with a rank out of the new order
+var validatePutSegmentsReorder = func(params segment.PutSegmentsReorderParams) *Error {
+	ss := []entity.Segment{}
+	if err := getRequestDB(params.HTTPRequest).Where(entity.Segment{FlagID: util.SafeUint(params.FlagID)}).Find(&ss).Error; err != nil {
+		return NewError(404, "error finding the segments of flagID %v. reason %s", params.FlagID, err)
+	}
+
+	sMap := make(map[uint]bool, len(ss))
+	sIDs := []uint{}
+	for _, s := range ss {
+		sMap[s.ID] = true
+		sIDs = append(sIDs, s.ID)
+	}
+
+	seen := make(map[uint]bool, len(params.Body.SegmentIds))
+	for _, id := range params.Body.SegmentIds {
+		sID := uint(id)
+		if !sMap[sID] {
+			return NewError(400, "error finding segmentID %v under this flag. expecting %v", sID, sIDs)
+		}
+		if seen[sID] {
+			return NewError(400, "duplicate segmentID %v", sID)
+		}
+		seen[sID] = true
+	}
+	if len(seen) != len(sMap) {
+		return NewError(400, "the segmentIDs %v should contain all the segments of the flag. expecting %v", params.Body.SegmentIds, sIDs)
+	}
+	return nil
+}
+
+// validateVariantAttachment validates the attachment of the variant against the attachment schema of its flag
+var validateVariantAttachment = func(v *entity.Variant) *Error {
+	f := &entity.Flag{}
+	if err := getDB().First(f, v.FlagID).Error; err != nil {
+		return NewError(404, "error finding flagID %v. reason %s", v.FlagID, err)
+	}
+	if err := v.Attachment.ValidateSchema(f.AttachmentSchema); err != nil {
+		return NewError(400, "error validating variant %s. reason: %s", v.Key, err)
+	}
+	return nil
+}
+
+// validateAttachmentSchema validates the attachment schema, and the existing variants of the flag against it,
+// so that the flag is never left with attachments that don't match its schema
+var validateAttachmentSchema = func(db *gorm.DB, flagID uint, schema string) *Error {
+	if err := entity.ValidateAttachmentSchema(schema); err != nil {
+		return NewError(400, "%s", err)
+	}
+
+	vs := []entity.Variant{}
+	if err := db.Where(entity.Variant{FlagID: flagID}).Find(&vs).Error; err != nil {
+		return NewError(500, "error finding the variants of flagID %v. reason %s", flagID, err)
+	}
+	for _, v := range vs {
+		if err := v.Attachment.ValidateSchema(schema); err != nil {
+			return NewError(400, "error validating the existing variant %s. reason: %s", v.Key, err)
+		}
+	}
+	return nil
+}
+
 var validateDeleteVariant = func(params variant.DeleteVariantParams) *Error {
 	f := &entity.Flag{}
-	if err := getDB().First(f, params.FlagID).Error; err != nil {
+	if err := getRequestDB(params.HTTPRequest).First(f, params.FlagID).Error; err != nil {
 		return NewError(404, "error finding flagID %v. reason %s", params.FlagID, err)
 	}
-	f.Preload(getDB())
+	v := &entity.Variant{}
+	if err := getRequestDB(params.HTTPRequest).Where("flag_id = ?", f.ID).First(v, params.VariantID).Error; err != nil {
+		return NewError(404, "error finding variantID %v under flagID %v. reason %s", params.VariantID, params.FlagID, err)
+	}
+	f.Preload(getRequestDB(params.HTTPRequest))
+
+	if f.DefaultVariantID == util.SafeUint(params.VariantID) {
+		return NewError(400, "error deleting variant %v. it's the default variant of the flag", params.VariantID)
+	}
+	if f.OverrideVariantID == util.SafeUint(params.VariantID) {
+		return NewError(400, "error deleting variant %v. it's the override variant of the flag", params.VariantID)
+	}
 
 	for _, s := range f.Segments {
 		for _, d := range s.Distributions {
@@ -59,12 +232,24 @@ var validateDeleteVariant = func(params variant.DeleteVariantParams) *Error {
 				if d.Percent != uint(0) {
 					return NewError(400, "error deleting variant %v. distribution %v still has non-zero distribution %v", params.VariantID, d.ID, d.Percent)
 				}
-				if err := getDB().Delete(entity.Distribution{}, d.ID).Error; err != nil {
+				if err := getRequestDB(params.HTTPRequest).Delete(entity.Distribution{}, d.ID).Error; err != nil {
 					return NewError(500, "error deleting distribution %v. reason: %s", d.ID, err)
 				}
 			}
 		}
 	}
+	for _, fe := range f.Environments {
+		for _, d := range fe.Distributions {
+			if d.VariantID == util.SafeUint(params.VariantID) {
+				if d.Percent != uint(0) {
+					return NewError(400, "error deleting variant %v. distribution %v in environment %s still has non-zero distribution %v", params.VariantID, d.ID, fe.Environment, d.Percent)
+				}
+				if err := getRequestDB(params.HTTPRequest).Delete(entity.FlagEnvironmentDistribution{}, d.ID).Error; err != nil {
+					return NewError(500, "error deleting distribution %v in environment %s. reason: %s", d.ID, fe.Environment, err)
+				}
+			}
+		}
+	}
 
 	return nil
 }
@@ -75,8 +260,198 @@ var validatePutVariantForDistributions = func(v *entity.Variant) *Error {
 		Where(entity.Distribution{VariantID: v.ID}).
 		Updates(entity.Distribution{VariantKey: v.Key}).
 		Error
+	if err == nil {
+		err = getDB().
+			Model(entity.FlagEnvironmentDistribution{}).
+			Where(entity.FlagEnvironmentDistribution{VariantID: v.ID}).
+			Updates(entity.FlagEnvironmentDistribution{VariantKey: v.Key}).
+			Error
+	}
 	if err != nil {
 		return NewError(500, "error updating distribution to sync with variantID %v with variantKey %v. reason: %s", v.ID, v.Key, err)
 	}
 	return nil
 }
+
+// validatePrerequisiteFlag checks the prerequisite flag and its expected variant exist,
+// and that following the prerequisites from it never leads back to f
+var validatePrerequisiteFlag = func(f *entity.Flag) *Error {
+	if f.PrerequisiteFlagID == 0 {
+		if f.PrerequisiteVariantKey != "" {
+			return NewError(400, "error setting prerequisiteVariantKey %s without prerequisiteFlagID", f.PrerequisiteVariantKey)
+		}
+		return nil
+	}
+
+	p := &entity.Flag{}
+	if err := entity.PreloadSegmentsVariants(getDB()).First(p, f.PrerequisiteFlagID).Error; err != nil {
+		return NewError(400, "error finding prerequisite flagID %v. reason %s", f.PrerequisiteFlagID, err)
+	}
+	if f.PrerequisiteVariantKey != "" {
+		found := false
+		for _, v := range p.Variants {
+			found = found || v.Key == f.PrerequisiteVariantKey
+		}
+		if !found {
+			return NewError(400, "error finding variantKey %s under prerequisite flagID %v", f.PrerequisiteVariantKey, p.ID)
+		}
+	}
+
+	visited := map[uint]bool{}
+	for id := f.PrerequisiteFlagID; id != 0; id = p.PrerequisiteFlagID {
+		if id == f.ID || visited[id] {
+			return NewError(400, "error setting prerequisite flagID %v. it creates a cycle of prerequisites", f.PrerequisiteFlagID)
+		}
+		visited[id] = true
+
+		p = &entity.Flag{}
+		if err := getDB().First(p, id).Error; err != nil {
+			// a missing flag ends the chain
+			break
+		}
+	}
+	return nil
+}
+
+// validateFlagDefinition runs the validations of saving the flag definition without saving it. Unlike
+// saveFlagDefinition, it doesn't stop at the first error, and the errors are located by their JSON paths.
+var validateFlagDefinition = func(body *models.ValidateFlagRequest, namespace string) ([]*models.ValidationError, *Error) {
+	errs := []*models.ValidationError{}
+	invalid := func(path string, format string, a ...interface{}) {
+		errs = append(errs, &models.ValidationError{
+			Path:    util.StringPtr(path),
+			Message: util.StringPtr(fmt.Sprintf(format, a...)),
+		})
+	}
+	def := body.Flag
+
+	// the flag is updated if its key exists in the namespace, it's used to find the cycles of prerequisites
+	existing := &entity.Flag{}
+	if def.Key != "" {
+		if _, err := entity.CreateFlagKey(def.Key); err != nil {
+			invalid("$.flag.key", "%s", err)
+		} else {
+			err := getDB().Where(entity.Flag{Key: def.Key}).First(existing).Error
+			if err != nil && !gorm.IsRecordNotFoundError(err) {
+				return nil, NewError(500, "error finding flag %s. reason: %s", def.Key, err)
+			}
+			if err == nil && existing.Namespace != namespace {
+				invalid("$.flag.key", "flag key %s already exists", def.Key)
+				existing = &entity.Flag{}
+			}
+		}
+	}
+
+	if err := entity.ValidateBucketingAlgorithm(def.BucketingAlgorithm); err != nil {
+		invalid("$.flag.bucketingAlgorithm", "%s", err)
+	}
+	if ok, reason := util.IsSafeKey(def.EntityType); !ok && def.EntityType != "" {
+		invalid("$.flag.entityType", "invalid DataRecordsEntityType. reason: %s", reason)
+	}
+	schema, err := r2eMapAttachmentSchema(def.AttachmentSchema)
+	if err == nil {
+		err = entity.ValidateAttachmentSchema(schema)
+	}
+	if err != nil {
+		invalid("$.flag.attachmentSchema", "%s", err)
+		schema = ""
+	}
+	for i, t := range def.Tags {
+		if ok, reason := util.IsSafeKey(t); !ok {
+			invalid(fmt.Sprintf("$.flag.tags[%d]", i), "invalid tag. reason: %s", reason)
+		}
+	}
+
+	if e := validateVariantsLimit(len(def.Variants)); e != nil {
+		invalid("$.flag.variants", e.Message, e.Values...)
+	}
+	if e := validateSegmentsLimit(len(def.Segments)); e != nil {
+		invalid("$.flag.segments", e.Message, e.Values...)
+	}
+
+	variantKeys := make(map[string]bool, len(def.Variants))
+	for i, vd := range def.Variants {
+		path := fmt.Sprintf("$.flag.variants[%d]", i)
+		key := util.SafeString(vd.Key)
+		if variantKeys[key] {
+			invalid(path+".key", "duplicate variant key %s", key)
+			continue
+		}
+		variantKeys[key] = true
+
+		a, err := r2eMapAttachment(vd.Attachment)
+		if err != nil {
+			invalid(path+".attachment", "%s", err)
+			continue
+		}
+		v := entity.Variant{Key: key, Attachment: a, AttachmentTypes: entity.AttachmentTypes(vd.AttachmentTypes)}
+		if err := v.Validate(); err != nil {
+			invalid(path, "%s", err)
+			continue
+		}
+		if err := v.Attachment.ValidateSchema(schema); err != nil {
+			invalid(path+".attachment", "error validating variant %s. reason: %s", key, err)
+		}
+	}
+	if def.DefaultVariantKey != "" && !variantKeys[def.DefaultVariantKey] {
+		invalid("$.flag.defaultVariantKey", "error finding the default variant %s in the variants", def.DefaultVariantKey)
+	}
+
+	for i, sd := range def.Segments {
+		path := fmt.Sprintf("$.flag.segments[%d]", i)
+		if e := validateConstraintsLimit(len(sd.Constraints)); e != nil {
+			invalid(path+".constraints", e.Message, e.Values...)
+		}
+		for j, cd := range sd.Constraints {
+			c := entity.Constraint{
+				Property: util.SafeString(cd.Property),
+				Operator: util.SafeString(cd.Operator),
+				Value:    util.SafeString(cd.Value),
+			}
+			if err := c.Validate(); err != nil {
+				invalid(fmt.Sprintf("%s.constraints[%d]", path, j), "%s", err)
+			}
+		}
+
+		if len(sd.Distributions) == 0 {
+			continue
+		}
+		sum := int64(0)
+		rampingCount := 0
+		for j, dd := range sd.Distributions {
+			dPath := fmt.Sprintf("%s.distributions[%d]", path, j)
+			d := &entity.Distribution{VariantKey: util.SafeString(dd.VariantKey), Percent: util.SafeUint(dd.Percent)}
+			r2e.MapDistributionRolloutSchedule(dd.RolloutSchedule, d)
+			if err := d.ValidateRolloutSchedule(); err != nil {
+				invalid(dPath+".rolloutSchedule", "%s", err)
+			}
+			if d.HasRolloutSchedule() {
+				rampingCount++
+			}
+			if !variantKeys[d.VariantKey] {
+				invalid(dPath+".variantKey", "error finding variantKey %s of the distribution in the variants", d.VariantKey)
+			}
+			sum += int64(d.Percent)
+		}
+		if sum != 100 {
+			invalid(path+".distributions", "the sum of distributions' percent %v is not 100", sum)
+		}
+		if rampingCount > 1 {
+			invalid(path+".distributions", "more than one distribution has a rollout schedule")
+		}
+	}
+
+	p := &entity.Flag{
+		Model:                  gorm.Model{ID: existing.ID},
+		PrerequisiteFlagID:     uint(body.PrerequisiteFlagID),
+		PrerequisiteVariantKey: body.PrerequisiteVariantKey,
+	}
+	if e := validatePrerequisiteFlag(p); e != nil {
+		path := "$.prerequisiteFlagID"
+		if body.PrerequisiteFlagID == 0 {
+			path = "$.prerequisiteVariantKey"
+		}
+		invalid(path, "%s", fmt.Sprintf(e.Message, e.Values...))
+	}
+	return errs, nil
+}
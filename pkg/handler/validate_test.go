@@ -3,14 +3,19 @@ package handler
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/checkr/flagr/pkg/config"
 	"github.com/checkr/flagr/pkg/entity"
 	"github.com/checkr/flagr/pkg/util"
 	"github.com/checkr/flagr/swagger_gen/models"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/jinzhu/gorm"
 
 	"github.com/prashantv/gostub"
@@ -132,6 +137,48 @@ func TestValidatePutDistributions(t *testing.T) {
 		err := validatePutDistributions(param)
 		assert.NotZero(t, err)
 	})
+
+	t.Run("rollout schedule", func(t *testing.T) {
+		startAt := strfmt.DateTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+		endAt := strfmt.DateTime(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+		genParam := func(schedules ...*models.DistributionRolloutSchedule) distribution.PutDistributionsParams {
+			ds := []*models.Distribution{}
+			for i, s := range schedules {
+				percent := int64(0)
+				if i == 0 {
+					percent = 100
+				}
+				ds = append(ds, &models.Distribution{
+					Percent:         util.Int64Ptr(percent),
+					VariantID:       util.Int64Ptr(int64(1)),
+					VariantKey:      util.StringPtr("control"),
+					RolloutSchedule: s,
+				})
+			}
+			return distribution.PutDistributionsParams{
+				FlagID:    int64(1),
+				SegmentID: int64(1),
+				Body:      &models.PutDistributionsRequest{Distributions: ds},
+			}
+		}
+
+		valid := &models.DistributionRolloutSchedule{
+			StartAt:      &startAt,
+			EndAt:        &endAt,
+			StartPercent: util.Int64Ptr(0),
+			EndPercent:   util.Int64Ptr(50),
+		}
+		assert.Nil(t, validatePutDistributions(genParam(valid)))
+
+		backwards := &models.DistributionRolloutSchedule{
+			StartAt:      &endAt,
+			EndAt:        &startAt,
+			StartPercent: util.Int64Ptr(0),
+			EndPercent:   util.Int64Ptr(50),
+		}
+		assert.Equal(t, 400, validatePutDistributions(genParam(backwards)).StatusCode)
+		assert.Equal(t, 400, validatePutDistributions(genParam(valid, valid)).StatusCode)
+	})
 }
 
 func TestValidateDeleteVariant(t *testing.T) {
@@ -201,6 +248,43 @@ func TestValidateDeleteVariant(t *testing.T) {
 		err := validateDeleteVariant(param)
 		assert.NotZero(t, err)
 	})
+
+	t.Run("try to delete the default variant of the flag", func(t *testing.T) {
+		c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body: &models.CreateVariantRequest{
+				Key: util.StringPtr("default"),
+			},
+		})
+		c.PutFlag(flag.PutFlagParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagRequest{
+				DefaultVariantID: util.Int64Ptr(int64(3)),
+			},
+		})
+		param := variant.DeleteVariantParams{
+			FlagID:    int64(1),
+			VariantID: int64(3),
+		}
+		err := validateDeleteVariant(param)
+		assert.NotZero(t, err)
+	})
+
+	t.Run("try to delete the override variant of the flag", func(t *testing.T) {
+		c.PutFlagOverride(flag.PutFlagOverrideParams{
+			FlagID: int64(1),
+			Body: &models.PutFlagOverrideRequest{
+				VariantID: util.Int64Ptr(int64(2)),
+				Enabled:   true,
+			},
+		})
+		param := variant.DeleteVariantParams{
+			FlagID:    int64(1),
+			VariantID: int64(2),
+		}
+		err := validateDeleteVariant(param)
+		assert.NotZero(t, err)
+	})
 }
 
 func TestValidatePutVariantForDistributions(t *testing.T) {
@@ -279,3 +363,201 @@ func TestValidatePutVariantForDistributions(t *testing.T) {
 		db.Error = nil
 	})
 }
+
+func TestValidatePrerequisiteFlag(t *testing.T) {
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	for i := 1; i <= 3; i++ {
+		c.CreateFlag(flag.CreateFlagParams{
+			Body: &models.CreateFlagRequest{
+				Description: util.StringPtr(fmt.Sprintf("flag %d", i)),
+			},
+		})
+	}
+	c.CreateVariant(variant.CreateVariantParams{
+		FlagID: int64(1),
+		Body:   &models.CreateVariantRequest{Key: util.StringPtr("on")},
+	})
+
+	t.Run("happy code path", func(t *testing.T) {
+		err := validatePrerequisiteFlag(&entity.Flag{Model: gorm.Model{ID: 2}, PrerequisiteFlagID: 1, PrerequisiteVariantKey: "on"})
+		assert.Nil(t, err)
+		err = validatePrerequisiteFlag(&entity.Flag{Model: gorm.Model{ID: 2}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("missing prerequisite flag or variant", func(t *testing.T) {
+		err := validatePrerequisiteFlag(&entity.Flag{Model: gorm.Model{ID: 2}, PrerequisiteFlagID: 999})
+		assert.NotNil(t, err)
+		err = validatePrerequisiteFlag(&entity.Flag{Model: gorm.Model{ID: 2}, PrerequisiteFlagID: 1, PrerequisiteVariantKey: "off"})
+		assert.NotNil(t, err)
+		err = validatePrerequisiteFlag(&entity.Flag{Model: gorm.Model{ID: 2}, PrerequisiteVariantKey: "on"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("cycles of prerequisites", func(t *testing.T) {
+		err := validatePrerequisiteFlag(&entity.Flag{Model: gorm.Model{ID: 1}, PrerequisiteFlagID: 1})
+		assert.NotNil(t, err)
+
+		// 3 -> 2 -> 1, and then 1 -> 3 makes a cycle
+		db.Model(&entity.Flag{}).Where("id = ?", 3).Update("prerequisite_flag_id", 2)
+		db.Model(&entity.Flag{}).Where("id = ?", 2).Update("prerequisite_flag_id", 1)
+		err = validatePrerequisiteFlag(&entity.Flag{Model: gorm.Model{ID: 1}, PrerequisiteFlagID: 3})
+		assert.NotNil(t, err)
+		assert.Equal(t, 400, err.StatusCode)
+	})
+}
+
+func TestValidateFlagDefinition(t *testing.T) {
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+
+	c.SaveFlagsBatch(flag.SaveFlagsBatchParams{
+		Body: &models.SaveFlagsBatchRequest{
+			Flags: []*models.FlagDefinition{genFlagDefinition("flag_a"), genFlagDefinition("flag_b")},
+		},
+	})
+	// flag_b (2) requires flag_a (1)
+	db.Model(&entity.Flag{}).Where("id = ?", 2).Update("prerequisite_flag_id", 1)
+
+	paths := func(body *models.ValidateFlagRequest, namespace string) []string {
+		errs, e := validateFlagDefinition(body, namespace)
+		assert.Nil(t, e)
+		ps := []string{}
+		for _, err := range errs {
+			ps = append(ps, *err.Path)
+		}
+		return ps
+	}
+
+	t.Run("valid definitions", func(t *testing.T) {
+		assert.Empty(t, paths(&models.ValidateFlagRequest{Flag: genFlagDefinition("flag_a")}, ""))
+		assert.Empty(t, paths(&models.ValidateFlagRequest{Flag: genFlagDefinition(""), PrerequisiteFlagID: 1, PrerequisiteVariantKey: "control"}, ""))
+	})
+
+	t.Run("flag fields", func(t *testing.T) {
+		def := genFlagDefinition("invalid key!")
+		def.BucketingAlgorithm = "unknown"
+		def.EntityType = "invalid entity type!"
+		def.AttachmentSchema = map[string]interface{}{"type": 1}
+		def.Tags = []string{"ok", "invalid tag!"}
+		assert.Equal(t, []string{
+			"$.flag.key",
+			"$.flag.bucketingAlgorithm",
+			"$.flag.entityType",
+			"$.flag.attachmentSchema",
+			"$.flag.tags[1]",
+		}, paths(&models.ValidateFlagRequest{Flag: def}, ""))
+
+		assert.Equal(t, []string{"$.flag.key"}, paths(&models.ValidateFlagRequest{Flag: genFlagDefinition("flag_a")}, "checkout"))
+	})
+
+	t.Run("variants and their references", func(t *testing.T) {
+		def := genFlagDefinition("flag_c")
+		def.Variants = append(def.Variants,
+			&models.CreateVariantRequest{Key: util.StringPtr("control")},
+			&models.CreateVariantRequest{Key: util.StringPtr("invalid key!")},
+		)
+		def.Segments[0].Distributions[0].VariantKey = util.StringPtr("unknown")
+		startAt, endAt := strfmt.DateTime(time.Now()), strfmt.DateTime(time.Now().Add(-time.Hour))
+		def.Segments[0].Distributions[0].RolloutSchedule = &models.DistributionRolloutSchedule{
+			StartAt:      &startAt,
+			EndAt:        &endAt,
+			StartPercent: util.Int64Ptr(int64(0)),
+			EndPercent:   util.Int64Ptr(int64(20)),
+		}
+		assert.Equal(t, []string{
+			"$.flag.variants[2].key",
+			"$.flag.variants[3]",
+			"$.flag.segments[0].distributions[0].rolloutSchedule",
+			"$.flag.segments[0].distributions[0].variantKey",
+		}, paths(&models.ValidateFlagRequest{Flag: def}, ""))
+	})
+
+	t.Run("cycles of prerequisites", func(t *testing.T) {
+		assert.Equal(t, []string{"$.prerequisiteFlagID"}, paths(&models.ValidateFlagRequest{Flag: genFlagDefinition("flag_a"), PrerequisiteFlagID: 2}, ""))
+		assert.Equal(t, []string{"$.prerequisiteFlagID"}, paths(&models.ValidateFlagRequest{Flag: genFlagDefinition("flag_c"), PrerequisiteFlagID: 999}, ""))
+		assert.Equal(t, []string{"$.prerequisiteVariantKey"}, paths(&models.ValidateFlagRequest{Flag: genFlagDefinition("flag_c"), PrerequisiteVariantKey: "control"}, ""))
+	})
+}
+
+func TestValidateFlagLimits(t *testing.T) {
+	var res middleware.Responder
+	db := entity.NewTestDB()
+	c := &crud{}
+
+	defer db.Close()
+	defer gostub.StubFunc(&getDB, db).Reset()
+	defer gostub.Stub(&config.Config.MaxSegmentsPerFlag, 2).Reset()
+	defer gostub.Stub(&config.Config.MaxConstraintsPerSegment, 1).Reset()
+	defer gostub.Stub(&config.Config.MaxVariantsPerFlag, 2).Reset()
+
+	// flag_a has 2 variants, 2 segments and 1 constraint in its first segment
+	res = c.ImportFlag(flag.ImportFlagParams{Body: &models.ImportFlagRequest{Flag: genFlagDefinition("flag_a")}})
+	assert.NotZero(t, res.(*flag.ImportFlagOK).Payload.ID)
+
+	t.Run("it rejects creating a segment, a constraint or a variant over the limits", func(t *testing.T) {
+		res = c.CreateSegment(segment.CreateSegmentParams{
+			FlagID: int64(1),
+			Body:   &models.CreateSegmentRequest{Description: util.StringPtr("one too many"), RolloutPercent: util.Int64Ptr(100)},
+		})
+		assert.Contains(t, *res.(*segment.CreateSegmentDefault).Payload.Message, "the flag would have 3 segments, the limit is 2")
+
+		res = c.CreateConstraint(constraint.CreateConstraintParams{
+			FlagID:    int64(1),
+			SegmentID: int64(1),
+			Body:      &models.CreateConstraintRequest{Property: util.StringPtr("state"), Operator: util.StringPtr("EQ"), Value: util.StringPtr(`"NY"`)},
+		})
+		assert.Contains(t, *res.(*constraint.CreateConstraintDefault).Payload.Message, "the segment would have 2 constraints, the limit is 1")
+
+		res = c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body:   &models.CreateVariantRequest{Key: util.StringPtr("another")},
+		})
+		assert.Contains(t, *res.(*variant.CreateVariantDefault).Payload.Message, "the flag would have 3 variants, the limit is 2")
+	})
+
+	t.Run("it still creates a constraint in a segment under the limit", func(t *testing.T) {
+		res = c.CreateConstraint(constraint.CreateConstraintParams{
+			FlagID:    int64(1),
+			SegmentID: int64(2),
+			Body:      &models.CreateConstraintRequest{Property: util.StringPtr("state"), Operator: util.StringPtr("EQ"), Value: util.StringPtr(`"NY"`)},
+		})
+		assert.NotZero(t, res.(*constraint.CreateConstraintOK).Payload.ID)
+	})
+
+	t.Run("it rejects the flag definitions over the limits", func(t *testing.T) {
+		def := genFlagDefinition("flag_b")
+		def.Segments = append(def.Segments, def.Segments[1])
+		res = c.ImportFlag(flag.ImportFlagParams{Body: &models.ImportFlagRequest{Flag: def}})
+		assert.Contains(t, *res.(*flag.ImportFlagDefault).Payload.Message, "the flag would have 3 segments")
+
+		def = genFlagDefinition("flag_b")
+		def.Segments[0].Constraints = append(def.Segments[0].Constraints, def.Segments[0].Constraints[0])
+		res = c.ImportFlag(flag.ImportFlagParams{Body: &models.ImportFlagRequest{Flag: def}})
+		assert.Contains(t, *res.(*flag.ImportFlagDefault).Payload.Message, "the segment would have 2 constraints")
+
+		def = genFlagDefinition("flag_b")
+		def.Variants = append(def.Variants, &models.CreateVariantRequest{Key: util.StringPtr("another")})
+		errs, e := validateFlagDefinition(&models.ValidateFlagRequest{Flag: def}, "")
+		assert.Nil(t, e)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "$.flag.variants", *errs[0].Path)
+	})
+
+	t.Run("it disables the limits with 0", func(t *testing.T) {
+		defer gostub.Stub(&config.Config.MaxVariantsPerFlag, 0).Reset()
+		res = c.CreateVariant(variant.CreateVariantParams{
+			FlagID: int64(1),
+			Body:   &models.CreateVariantRequest{Key: util.StringPtr("another")},
+		})
+		assert.NotZero(t, res.(*variant.CreateVariantOK).Payload.ID)
+	})
+}
@@ -2,6 +2,8 @@ package e2r
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/checkr/flagr/pkg/entity"
@@ -21,12 +23,53 @@ func MapFlag(e *entity.Flag) (*models.Flag, error) {
 	r.EntityType = e.EntityType
 	r.Description = util.StringPtr(e.Description)
 	r.Notes = e.Notes
+	r.BucketingSeed = e.BucketingSeed
+	r.BucketingAlgorithm = e.BucketingAlgorithm
+	r.BucketBy = e.BucketBy
+	r.DefaultVariantID = int64(e.DefaultVariantID)
+	r.PrerequisiteFlagID = int64(e.PrerequisiteFlagID)
+	r.PrerequisiteVariantKey = e.PrerequisiteVariantKey
+	attachmentSchema, err := MapAttachmentSchema(e.AttachmentSchema)
+	if err != nil {
+		return nil, err
+	}
+	r.AttachmentSchema = attachmentSchema
+	r.Namespace = e.Namespace
+	r.ExclusionGroupID = int64(e.ExclusionGroupID)
+	r.ExclusionGroupPercent = int64(e.ExclusionGroupPercent)
+	r.OverrideEnabled = e.OverrideEnabled
+	r.OverrideVariantID = int64(e.OverrideVariantID)
 	r.Enabled = util.BoolPtr(e.Enabled)
 	r.UpdatedAt = strfmt.DateTime(e.UpdatedAt)
 	r.UpdatedBy = e.UpdatedBy
+	if e.DeletedAt != nil {
+		d := strfmt.DateTime(*e.DeletedAt)
+		r.DeletedAt = &d
+	}
+	if e.ExpiresAt != nil {
+		d := strfmt.DateTime(*e.ExpiresAt)
+		r.ExpiresAt = &d
+	}
+	if e.LastEvaluatedAt != nil {
+		d := strfmt.DateTime(*e.LastEvaluatedAt)
+		r.LastEvaluatedAt = &d
+	}
 	r.Segments = MapSegments(e.Segments)
 	r.Variants = MapVariants(e.Variants)
+	r.Tags = MapTags(e.Tags)
+
+	return r, nil
+}
 
+// MapAttachmentSchema maps the JSON of attachment schema, it's nil if the schema is empty
+func MapAttachmentSchema(s string) (interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var r interface{}
+	if err := json.Unmarshal([]byte(s), &r); err != nil {
+		return nil, fmt.Errorf("cannot map attachment schema %s. reason: %s", s, err)
+	}
 	return r, nil
 }
 
@@ -43,6 +86,77 @@ func MapFlags(e []entity.Flag) ([]*models.Flag, error) {
 	return ret, nil
 }
 
+// MapFlagDefinition maps flag into the self-contained flag definition,
+// the variants are referenced by keys instead of ids
+func MapFlagDefinition(e *entity.Flag) *models.FlagDefinition {
+	r := &models.FlagDefinition{
+		Key:                e.Key,
+		Description:        util.StringPtr(e.Description),
+		Enabled:            e.Enabled,
+		Notes:              e.Notes,
+		DataRecordsEnabled: e.DataRecordsEnabled,
+		EntityType:         e.EntityType,
+		BucketingSeed:      e.BucketingSeed,
+		BucketingAlgorithm: e.BucketingAlgorithm,
+		BucketBy:           e.BucketBy,
+		Tags:               make([]string, len(e.Tags)),
+		Variants:           make([]*models.CreateVariantRequest, len(e.Variants)),
+		Segments:           make([]*models.SegmentDefinition, len(e.Segments)),
+	}
+	// the attachment schema is validated before it's saved, so it's always valid JSON
+	r.AttachmentSchema, _ = MapAttachmentSchema(e.AttachmentSchema)
+	for i, t := range e.Tags {
+		r.Tags[i] = t.Value
+	}
+	for i, v := range e.Variants {
+		if v.ID == e.DefaultVariantID {
+			r.DefaultVariantKey = v.Key
+		}
+		var attachment interface{}
+		if len(v.Attachment) > 0 {
+			m := make(map[string]interface{}, len(v.Attachment))
+			for k, val := range v.Attachment {
+				m[k] = val
+			}
+			attachment = m
+		}
+		r.Variants[i] = &models.CreateVariantRequest{
+			Key:             util.StringPtr(v.Key),
+			Attachment:      attachment,
+			AttachmentTypes: v.AttachmentTypes,
+		}
+	}
+	for i := range e.Segments {
+		r.Segments[i] = MapSegmentDefinition(&e.Segments[i])
+	}
+	return r
+}
+
+// MapSegmentDefinition maps the segment with its constraints and distributions, the distributions reference the variants by key
+func MapSegmentDefinition(s *entity.Segment) *models.SegmentDefinition {
+	sd := &models.SegmentDefinition{
+		Description:    util.StringPtr(s.Description),
+		RolloutPercent: util.Int64Ptr(int64(s.RolloutPercent)),
+		Constraints:    make([]*models.CreateConstraintRequest, len(s.Constraints)),
+		Distributions:  make([]*models.DistributionDefinition, len(s.Distributions)),
+	}
+	for i, c := range s.Constraints {
+		sd.Constraints[i] = &models.CreateConstraintRequest{
+			Property: util.StringPtr(c.Property),
+			Operator: util.StringPtr(c.Operator),
+			Value:    util.StringPtr(c.Value),
+		}
+	}
+	for i, d := range s.Distributions {
+		sd.Distributions[i] = &models.DistributionDefinition{
+			VariantKey:      util.StringPtr(d.VariantKey),
+			Percent:         util.Int64Ptr(int64(d.Percent)),
+			RolloutSchedule: MapDistributionRolloutSchedule(&d),
+		}
+	}
+	return sd
+}
+
 // MapFlagSnapshot maps flag snapshot
 func MapFlagSnapshot(e *entity.FlagSnapshot) (*models.FlagSnapshot, error) {
 	ef := &entity.Flag{}
@@ -75,6 +189,218 @@ func MapFlagSnapshots(e []entity.FlagSnapshot) ([]*models.FlagSnapshot, error) {
 	return ret, nil
 }
 
+// MapFlagSnapshotDiff maps the diff between the snapshots from and to
+func MapFlagSnapshotDiff(fromID uint, toID uint, e *entity.FlagSnapshotDiff) *models.FlagSnapshotDiff {
+	r := &models.FlagSnapshotDiff{
+		FromSnapshotID: util.Int64Ptr(int64(fromID)),
+		ToSnapshotID:   util.Int64Ptr(int64(toID)),
+		Flag:           mapFlagSnapshotFieldDiffs(e.Flag),
+		Variants:       mapFlagSnapshotEntityDiffs(e.Variants),
+		Segments:       make([]*models.FlagSnapshotSegmentDiff, len(e.Segments)),
+	}
+	for i, s := range e.Segments {
+		r.Segments[i] = &models.FlagSnapshotSegmentDiff{
+			ID:            util.Int64Ptr(int64(s.ID)),
+			ChangeType:    util.StringPtr(s.ChangeType),
+			Fields:        mapFlagSnapshotFieldDiffs(s.Fields),
+			Constraints:   mapFlagSnapshotEntityDiffs(s.Constraints),
+			Distributions: mapFlagSnapshotEntityDiffs(s.Distributions),
+		}
+	}
+	return r
+}
+
+func mapFlagSnapshotEntityDiffs(e []entity.FlagSnapshotEntityDiff) []*models.FlagSnapshotEntityDiff {
+	ret := make([]*models.FlagSnapshotEntityDiff, len(e))
+	for i, d := range e {
+		ret[i] = &models.FlagSnapshotEntityDiff{
+			ID:         util.Int64Ptr(int64(d.ID)),
+			ChangeType: util.StringPtr(d.ChangeType),
+			Fields:     mapFlagSnapshotFieldDiffs(d.Fields),
+		}
+	}
+	return ret
+}
+
+// mapFlagSnapshotFieldDiffs maps the field diffs sorted by the field names
+func mapFlagSnapshotFieldDiffs(e map[string]entity.FlagHistoryFieldDiff) []*models.FlagSnapshotFieldDiff {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	ret := make([]*models.FlagSnapshotFieldDiff, len(fields))
+	for i, field := range fields {
+		ret[i] = &models.FlagSnapshotFieldDiff{
+			Field:  util.StringPtr(field),
+			Before: e[field].Before,
+			After:  e[field].After,
+		}
+	}
+	return ret
+}
+
+// MapFlagHistory maps flag history
+func MapFlagHistory(e *entity.FlagHistory) (*models.FlagHistory, error) {
+	var diff interface{}
+	if len(e.Diff) > 0 {
+		if err := json.Unmarshal(e.Diff, &diff); err != nil {
+			return nil, err
+		}
+	}
+	r := &models.FlagHistory{
+		ID:         util.Int64Ptr(int64(e.ID)),
+		Actor:      e.Actor,
+		Action:     util.StringPtr(e.Action),
+		EntityType: util.StringPtr(e.EntityType),
+		EntityID:   util.Int64Ptr(int64(e.EntityID)),
+		Diff:       diff,
+		Note:       e.Note,
+		CreatedAt:  util.StringPtr(e.CreatedAt.UTC().Format(time.RFC3339)),
+	}
+	return r, nil
+}
+
+// MapFlagHistories maps flag histories
+func MapFlagHistories(e []entity.FlagHistory) ([]*models.FlagHistory, error) {
+	ret := make([]*models.FlagHistory, len(e))
+	for i, fh := range e {
+		rf, err := MapFlagHistory(&fh)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = rf
+	}
+	return ret, nil
+}
+
+// MapFlagSchedule maps flag schedule
+func MapFlagSchedule(e *entity.FlagSchedule) *models.FlagSchedule {
+	scheduledAt := strfmt.DateTime(e.ScheduledAt)
+	r := &models.FlagSchedule{
+		ID:          util.Int64Ptr(int64(e.ID)),
+		FlagID:      util.Int64Ptr(int64(e.FlagID)),
+		Action:      util.StringPtr(e.Action),
+		ScheduledAt: &scheduledAt,
+		CreatedBy:   e.CreatedBy,
+	}
+	return r
+}
+
+// MapFlagSchedules maps flag schedules
+func MapFlagSchedules(e []entity.FlagSchedule) []*models.FlagSchedule {
+	ret := make([]*models.FlagSchedule, len(e))
+	for i, s := range e {
+		ret[i] = MapFlagSchedule(&s)
+	}
+	return ret
+}
+
+// MapFlagChange maps flag change
+func MapFlagChange(e *entity.FlagChange) (*models.FlagChange, error) {
+	ds, err := e.GetDistributions()
+	if err != nil {
+		return nil, err
+	}
+	requestedAt := strfmt.DateTime(e.CreatedAt)
+	r := &models.FlagChange{
+		ID:            util.Int64Ptr(int64(e.ID)),
+		FlagID:        util.Int64Ptr(int64(e.FlagID)),
+		Type:          util.StringPtr(e.Type),
+		Environment:   e.Environment,
+		Enabled:       e.Enabled,
+		SegmentID:     int64(e.SegmentID),
+		Distributions: MapDistributions(ds),
+		Note:          e.Note,
+		Status:        util.StringPtr(e.Status),
+		RequestedBy:   util.StringPtr(e.RequestedBy),
+		RequestedAt:   &requestedAt,
+		ApprovedBy:    e.ApprovedBy,
+	}
+	if e.ApprovedAt != nil {
+		approvedAt := strfmt.DateTime(*e.ApprovedAt)
+		r.ApprovedAt = &approvedAt
+	}
+	return r, nil
+}
+
+// MapFlagChanges maps flag changes
+func MapFlagChanges(e []entity.FlagChange) ([]*models.FlagChange, error) {
+	ret := make([]*models.FlagChange, len(e))
+	for i, c := range e {
+		rc, err := MapFlagChange(&c)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = rc
+	}
+	return ret, nil
+}
+
+// MapTag maps tag
+func MapTag(e *entity.Tag) *models.Tag {
+	r := &models.Tag{}
+	r.ID = int64(e.ID)
+	r.Value = util.StringPtr(e.Value)
+	return r
+}
+
+// MapTags maps tags
+func MapTags(e []entity.Tag) []*models.Tag {
+	ret := make([]*models.Tag, len(e))
+	for i, t := range e {
+		ret[i] = MapTag(&t)
+	}
+	return ret
+}
+
+// MapExclusionGroup maps exclusion group with its member flags
+func MapExclusionGroup(e *entity.ExclusionGroup, members []entity.Flag) *models.ExclusionGroup {
+	r := &models.ExclusionGroup{}
+	r.ID = int64(e.ID)
+	r.Key = util.StringPtr(e.Key)
+	r.Description = e.Description
+	r.Members = make([]*models.ExclusionGroupMember, len(members))
+	for i, f := range members {
+		r.Members[i] = &models.ExclusionGroupMember{
+			FlagID:  int64(f.ID),
+			FlagKey: f.Key,
+			Percent: int64(f.ExclusionGroupPercent),
+		}
+	}
+	return r
+}
+
+// MapConstraintGroup maps constraint group with the IDs of the segments referencing it
+func MapConstraintGroup(e *entity.ConstraintGroup, segmentIDs []uint) *models.ConstraintGroup {
+	r := &models.ConstraintGroup{}
+	r.ID = int64(e.ID)
+	r.Key = util.StringPtr(e.Key)
+	r.Description = e.Description
+	r.Namespace = e.Namespace
+	r.Constraints = MapConstraints(e.Constraints)
+	r.SegmentIds = make([]int64, len(segmentIDs))
+	for i, id := range segmentIDs {
+		r.SegmentIds[i] = int64(id)
+	}
+	return r
+}
+
+// MapSegmentTemplate maps segment template
+func MapSegmentTemplate(e *entity.SegmentTemplate) (*models.SegmentTemplate, error) {
+	s, err := e.NewSegment(0)
+	if err != nil {
+		return nil, err
+	}
+	r := &models.SegmentTemplate{}
+	r.ID = int64(e.ID)
+	r.Key = util.StringPtr(e.Key)
+	r.Description = e.Description
+	r.Segment = MapSegmentDefinition(s)
+	return r, nil
+}
+
 // MapSegment maps segment
 func MapSegment(e *entity.Segment) *models.Segment {
 	r := &models.Segment{}
@@ -84,6 +410,7 @@ func MapSegment(e *entity.Segment) *models.Segment {
 	r.RolloutPercent = util.Int64Ptr(int64(e.RolloutPercent))
 	r.Constraints = MapConstraints(e.Constraints)
 	r.Distributions = MapDistributions(e.Distributions)
+	r.ConstraintGroupID = util.Int64Ptr(int64(e.ConstraintGroupID))
 	return r
 }
 
@@ -122,10 +449,30 @@ func MapDistribution(e *entity.Distribution) *models.Distribution {
 		Percent:    util.Int64Ptr(int64(e.Percent)),
 		VariantID:  util.Int64Ptr(int64(e.VariantID)),
 		VariantKey: util.StringPtr(e.VariantKey),
+
+		RolloutSchedule: MapDistributionRolloutSchedule(e),
 	}
 	return r
 }
 
+// MapDistributionRolloutSchedule maps the rollout schedule of the distribution, it's nil if there's no schedule
+func MapDistributionRolloutSchedule(e *entity.Distribution) *models.DistributionRolloutSchedule {
+	if !e.HasRolloutSchedule() {
+		return nil
+	}
+	r := &models.DistributionRolloutSchedule{
+		StartPercent: util.Int64Ptr(int64(e.RolloutStartPercent)),
+		EndPercent:   util.Int64Ptr(int64(e.RolloutEndPercent)),
+	}
+	if e.RolloutStartAt != nil {
+		startAt := strfmt.DateTime(*e.RolloutStartAt)
+		r.StartAt = &startAt
+	}
+	endAt := strfmt.DateTime(*e.RolloutEndAt)
+	r.EndAt = &endAt
+	return r
+}
+
 // MapDistributions maps distribution
 func MapDistributions(e []entity.Distribution) []*models.Distribution {
 	ret := make([]*models.Distribution, len(e))
@@ -138,9 +485,10 @@ func MapDistributions(e []entity.Distribution) []*models.Distribution {
 // MapVariant maps variant
 func MapVariant(e *entity.Variant) *models.Variant {
 	r := &models.Variant{
-		ID:         int64(e.ID),
-		Key:        util.StringPtr(e.Key),
-		Attachment: e.Attachment,
+		ID:              int64(e.ID),
+		Key:             util.StringPtr(e.Key),
+		Attachment:      e.Attachment,
+		AttachmentTypes: e.AttachmentTypes,
 	}
 	return r
 }
@@ -1,7 +1,9 @@
 package r2e
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/checkr/flagr/pkg/entity"
 	"github.com/checkr/flagr/pkg/util"
@@ -26,9 +28,29 @@ func MapDistribution(r *models.Distribution, segmentID uint) entity.Distribution
 		VariantKey: util.SafeString(r.VariantKey),
 		Percent:    uint(*r.Percent),
 	}
+	MapDistributionRolloutSchedule(r.RolloutSchedule, &e)
 	return e
 }
 
+// MapDistributionRolloutSchedule maps the rollout schedule into the distribution, it's unset if r is nil
+func MapDistributionRolloutSchedule(r *models.DistributionRolloutSchedule, e *entity.Distribution) {
+	e.RolloutStartAt, e.RolloutEndAt = nil, nil
+	e.RolloutStartPercent, e.RolloutEndPercent = 0, 0
+	if r == nil {
+		return
+	}
+	if r.StartAt != nil {
+		startAt := time.Time(*r.StartAt)
+		e.RolloutStartAt = &startAt
+	}
+	if r.EndAt != nil {
+		endAt := time.Time(*r.EndAt)
+		e.RolloutEndAt = &endAt
+	}
+	e.RolloutStartPercent = util.SafeUint(r.StartPercent)
+	e.RolloutEndPercent = util.SafeUint(r.EndPercent)
+}
+
 // MapAttachment maps attachment
 func MapAttachment(a interface{}) (entity.Attachment, error) {
 	e := entity.Attachment{}
@@ -48,3 +70,18 @@ func MapAttachment(a interface{}) (entity.Attachment, error) {
 	}
 	return e, nil
 }
+
+// MapAttachmentSchema maps attachment schema into its JSON, an empty schema is mapped to ""
+func MapAttachmentSchema(s interface{}) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	if m, ok := s.(map[string]interface{}); ok && len(m) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid attachment schema format %s. reason: %s", spew.Sdump(s), err)
+	}
+	return string(b), nil
+}
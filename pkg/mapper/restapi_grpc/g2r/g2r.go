@@ -0,0 +1,71 @@
+package g2r
+
+import (
+	"github.com/checkr/flagr/grpc_gen/flagr"
+	"github.com/checkr/flagr/swagger_gen/models"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// MapEvalContext maps eval context
+func MapEvalContext(g *flagr.EvalContext) models.EvalContext {
+	return models.EvalContext{
+		EntityID:      g.EntityID,
+		EntityType:    g.EntityType,
+		EntityContext: MapStruct(g.EntityContext),
+		EnableDebug:   g.EnableDebug,
+		FlagID:        g.FlagID,
+		FlagKey:       g.FlagKey,
+	}
+}
+
+// MapEvaluationBatchRequest maps evaluation batch request
+func MapEvaluationBatchRequest(g *flagr.EvaluationBatchRequest) models.EvaluationBatchRequest {
+	r := models.EvaluationBatchRequest{
+		EnableDebug: g.EnableDebug,
+		Entities:    make([]*models.EvaluationEntity, len(g.Entities)),
+		FlagIds:     g.FlagIDs,
+		FlagKeys:    g.FlagKeys,
+	}
+	for i, e := range g.Entities {
+		r.Entities[i] = &models.EvaluationEntity{
+			EntityID:      e.EntityID,
+			EntityType:    e.EntityType,
+			EntityContext: MapStruct(e.EntityContext),
+		}
+	}
+	return r
+}
+
+// MapStruct maps protobuf struct to a json object like map
+func MapStruct(g *structpb.Struct) interface{} {
+	if g == nil {
+		return nil
+	}
+	m := make(map[string]interface{}, len(g.Fields))
+	for k, v := range g.Fields {
+		m[k] = MapValue(v)
+	}
+	return m
+}
+
+// MapValue maps protobuf value to a json like value
+func MapValue(g *structpb.Value) interface{} {
+	switch v := g.GetKind().(type) {
+	case *structpb.Value_BoolValue:
+		return v.BoolValue
+	case *structpb.Value_StringValue:
+		return v.StringValue
+	case *structpb.Value_NumberValue:
+		return v.NumberValue
+	case *structpb.Value_StructValue:
+		return MapStruct(v.StructValue)
+	case *structpb.Value_ListValue:
+		l := make([]interface{}, len(v.ListValue.GetValues()))
+		for i, e := range v.ListValue.GetValues() {
+			l[i] = MapValue(e)
+		}
+		return l
+	default:
+		return nil
+	}
+}
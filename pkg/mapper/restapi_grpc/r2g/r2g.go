@@ -0,0 +1,126 @@
+package r2g
+
+import (
+	"encoding/json"
+
+	"github.com/checkr/flagr/grpc_gen/flagr"
+	"github.com/checkr/flagr/swagger_gen/models"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// MapEvaluationBatchResponse maps evaluation batch response
+func MapEvaluationBatchResponse(r *models.EvaluationBatchResponse) *flagr.EvaluationBatchResponse {
+	g := &flagr.EvaluationBatchResponse{
+		EvaluationResults: make([]*flagr.EvalResult, len(r.EvaluationResults)),
+	}
+	for i, result := range r.EvaluationResults {
+		g.EvaluationResults[i] = MapEvalResult(result)
+	}
+	return g
+}
+
+// MapEvalResult maps eval result
+func MapEvalResult(r *models.EvalResult) *flagr.EvalResult {
+	g := &flagr.EvalResult{
+		FlagID:            r.FlagID,
+		FlagKey:           r.FlagKey,
+		FlagSnapshotID:    r.FlagSnapshotID,
+		SegmentID:         r.SegmentID,
+		VariantID:         r.VariantID,
+		VariantKey:        r.VariantKey,
+		VariantAttachment: MapStruct(r.VariantAttachment),
+		Timestamp:         r.Timestamp,
+		IsDefaultVariant:  r.IsDefaultVariant,
+		ErrorCode:         r.ErrorCode,
+	}
+	if r.EvalContext != nil {
+		g.EvalContext = MapEvalContext(r.EvalContext)
+	}
+	if r.EvalDebugLog != nil {
+		g.EvalDebugLog = MapEvalDebugLog(r.EvalDebugLog)
+	}
+	return g
+}
+
+// MapEvalContext maps eval context
+func MapEvalContext(r *models.EvalContext) *flagr.EvalContext {
+	return &flagr.EvalContext{
+		EntityID:      r.EntityID,
+		EntityType:    r.EntityType,
+		EntityContext: MapStruct(r.EntityContext),
+		EnableDebug:   r.EnableDebug,
+		FlagID:        r.FlagID,
+		FlagKey:       r.FlagKey,
+	}
+}
+
+// MapEvalDebugLog maps eval debug log
+func MapEvalDebugLog(r *models.EvalDebugLog) *flagr.EvalDebugLog {
+	g := &flagr.EvalDebugLog{
+		Msg:    r.Msg,
+		Reason: r.Reason,
+	}
+	for _, s := range r.SegmentDebugLogs {
+		sg := &flagr.SegmentDebugLog{
+			SegmentID: s.SegmentID,
+			Msg:       s.Msg,
+			Matched:   s.Matched,
+			BucketNum: s.BucketNum,
+		}
+		for _, c := range s.ConstraintDebugLogs {
+			sg.ConstraintDebugLogs = append(sg.ConstraintDebugLogs, &flagr.ConstraintDebugLog{
+				ConstraintID: c.ConstraintID,
+				Property:     c.Property,
+				Operator:     c.Operator,
+				Value:        c.Value,
+				Matched:      c.Matched,
+				Msg:          c.Msg,
+			})
+		}
+		g.SegmentDebugLogs = append(g.SegmentDebugLogs, sg)
+	}
+	return g
+}
+
+// MapStruct maps a json object like map to protobuf struct, it returns nil if it's not an object
+func MapStruct(r interface{}) *structpb.Struct {
+	v := MapValue(r)
+	if s, ok := v.Kind.(*structpb.Value_StructValue); ok {
+		return s.StructValue
+	}
+	return nil
+}
+
+// MapValue maps a json like value to protobuf value
+func MapValue(r interface{}) *structpb.Value {
+	switch v := r.(type) {
+	case nil:
+		return &structpb.Value{Kind: &structpb.Value_NullValue{}}
+	case bool:
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: v}}
+	case string:
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}}
+	case float64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: v}}
+	case map[string]interface{}:
+		s := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(v))}
+		for k, e := range v {
+			s.Fields[k] = MapValue(e)
+		}
+		return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: s}}
+	case []interface{}:
+		l := &structpb.ListValue{Values: make([]*structpb.Value, len(v))}
+		for i, e := range v {
+			l.Values[i] = MapValue(e)
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: l}}
+	default:
+		// other types, e.g. entity.Attachment or ints, are normalized through json
+		var i interface{}
+		b, err := json.Marshal(v)
+		if err != nil || json.Unmarshal(b, &i) != nil {
+			return &structpb.Value{Kind: &structpb.Value_NullValue{}}
+		}
+		return MapValue(i)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dchest/uniuri"
@@ -13,6 +15,7 @@ import (
 var (
 	keyLengthLimit = 63
 	keyRegex       = regexp.MustCompile("^[a-z]+[a-z0-9_]*$")
+	flagKeyRegex   = regexp.MustCompile("^[a-z]+[a-z0-9_-]*$")
 
 	randomKeyCharset = []byte("123456789abcdefghijkmnopqrstuvwxyz")
 	randomKeyPrefix  = "k"
@@ -29,6 +32,17 @@ func IsSafeKey(s string) (bool, string) {
 	return true, ""
 }
 
+// IsSafeFlagKey return if the flag key is safe to store, flag keys can also be slugs like checkout-redesign
+func IsSafeFlagKey(s string) (bool, string) {
+	if !flagKeyRegex.MatchString(s) {
+		return false, fmt.Sprintf("key:%s should have the format %v", s, flagKeyRegex)
+	}
+	if len(s) > keyLengthLimit {
+		return false, fmt.Sprintf("key:%s cannot be longer than %d", s, keyLengthLimit)
+	}
+	return true, ""
+}
+
 // NewSecureRandomKey creates a new secure random key
 func NewSecureRandomKey() string {
 	return randomKeyPrefix + uniuri.NewLenChars(uniuri.StdLen, randomKeyCharset)
@@ -72,6 +86,18 @@ func TimeNow() string {
 	return time.Now().UTC().Format(time.RFC3339)
 }
 
+// ParseDuration parses the duration like time.ParseDuration, and it also takes a whole number of days like 30d
+func ParseDuration(s string) (time.Duration, error) {
+	if !strings.HasSuffix(s, "d") {
+		return time.ParseDuration(s)
+	}
+	days, err := strconv.ParseUint(strings.TrimSuffix(s, "d"), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %s", s)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
 // Float32Ptr ...
 func Float32Ptr(v float32) *float32 { return &v }
 
@@ -3,6 +3,7 @@ package util
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -101,6 +102,31 @@ func TestIsSafeKey(t *testing.T) {
 	assert.NotEmpty(t, msg)
 }
 
+func TestIsSafeFlagKey(t *testing.T) {
+	var b bool
+	var msg string
+
+	b, msg = IsSafeFlagKey("checkout-redesign")
+	assert.True(t, b)
+	assert.Empty(t, msg)
+
+	b, msg = IsSafeFlagKey("a_1")
+	assert.True(t, b)
+	assert.Empty(t, msg)
+
+	b, msg = IsSafeFlagKey("-a")
+	assert.False(t, b)
+	assert.NotEmpty(t, msg)
+
+	b, msg = IsSafeFlagKey("Checkout Redesign")
+	assert.False(t, b)
+	assert.NotEmpty(t, msg)
+
+	b, msg = IsSafeFlagKey(strings.Repeat("a", 64))
+	assert.False(t, b)
+	assert.NotEmpty(t, msg)
+}
+
 func TestPtrs(t *testing.T) {
 	assert.Equal(t, "a", *StringPtr("a"))
 	assert.Equal(t, int(1), *IntPtr(int(1)))
@@ -130,3 +156,20 @@ func TestNewSecureRandomKey(t *testing.T) {
 	ok, _ := IsSafeKey(NewSecureRandomKey())
 	assert.True(t, ok)
 }
+
+func TestParseDuration(t *testing.T) {
+	d, err := ParseDuration("30d")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	d, err = ParseDuration("36h")
+	assert.NoError(t, err)
+	assert.Equal(t, 36*time.Hour, d)
+
+	_, err = ParseDuration("-1d")
+	assert.Error(t, err)
+	_, err = ParseDuration("d")
+	assert.Error(t, err)
+	_, err = ParseDuration("a week")
+	assert.Error(t, err)
+}
@@ -27,18 +27,16 @@ type Constraint struct {
 	// operator
 	// Required: true
 	// Min Length: 1
-	// Enum: [EQ NEQ LT LTE GT GTE EREG NEREG IN NOTIN CONTAINS NOTCONTAINS]
+	// Enum: [EQ NEQ LT LTE GT GTE EREG NEREG IN NOTIN CONTAINS NOTCONTAINS BEFORE AFTER BETWEEN ARRAY_CONTAINS_ANY ARRAY_CONTAINS_ALL EXISTS NOT_EXISTS NUMBER_BETWEEN]
 	Operator *string `json:"operator"`
 
-	// property
+	// the key of the entityContext, or a path of keys separated by dots into the objects of the entityContext, e.g. device.os
 	// Required: true
 	// Min Length: 1
 	Property *string `json:"property"`
 
-	// value
-	// Required: true
-	// Min Length: 1
-	Value *string `json:"value"`
+	// the value to compare the property with, it's required by all the operators but EXISTS and NOT_EXISTS, which ignore it
+	Value *string `json:"value,omitempty"`
 }
 
 // Validate validates this constraint
@@ -57,10 +55,6 @@ func (m *Constraint) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
-	if err := m.validateValue(formats); err != nil {
-		res = append(res, err)
-	}
-
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -84,7 +78,7 @@ var constraintTypeOperatorPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["EQ","NEQ","LT","LTE","GT","GTE","EREG","NEREG","IN","NOTIN","CONTAINS","NOTCONTAINS"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["EQ","NEQ","LT","LTE","GT","GTE","EREG","NEREG","IN","NOTIN","CONTAINS","NOTCONTAINS","BEFORE","AFTER","BETWEEN","ARRAY_CONTAINS_ANY","ARRAY_CONTAINS_ALL","EXISTS","NOT_EXISTS","NUMBER_BETWEEN"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -129,6 +123,30 @@ const (
 
 	// ConstraintOperatorNOTCONTAINS captures enum value "NOTCONTAINS"
 	ConstraintOperatorNOTCONTAINS string = "NOTCONTAINS"
+
+	// ConstraintOperatorBEFORE captures enum value "BEFORE"
+	ConstraintOperatorBEFORE string = "BEFORE"
+
+	// ConstraintOperatorAFTER captures enum value "AFTER"
+	ConstraintOperatorAFTER string = "AFTER"
+
+	// ConstraintOperatorBETWEEN captures enum value "BETWEEN"
+	ConstraintOperatorBETWEEN string = "BETWEEN"
+
+	// ConstraintOperatorARRAYCONTAINSANY captures enum value "ARRAY_CONTAINS_ANY"
+	ConstraintOperatorARRAYCONTAINSANY string = "ARRAY_CONTAINS_ANY"
+
+	// ConstraintOperatorARRAYCONTAINSALL captures enum value "ARRAY_CONTAINS_ALL"
+	ConstraintOperatorARRAYCONTAINSALL string = "ARRAY_CONTAINS_ALL"
+
+	// ConstraintOperatorEXISTS captures enum value "EXISTS"
+	ConstraintOperatorEXISTS string = "EXISTS"
+
+	// ConstraintOperatorNOTEXISTS captures enum value "NOT_EXISTS"
+	ConstraintOperatorNOTEXISTS string = "NOT_EXISTS"
+
+	// ConstraintOperatorNUMBERBETWEEN captures enum value "NUMBER_BETWEEN"
+	ConstraintOperatorNUMBERBETWEEN string = "NUMBER_BETWEEN"
 )
 
 // prop value enum
@@ -170,19 +188,6 @@ func (m *Constraint) validateProperty(formats strfmt.Registry) error {
 	return nil
 }
 
-func (m *Constraint) validateValue(formats strfmt.Registry) error {
-
-	if err := validate.Required("value", "body", m.Value); err != nil {
-		return err
-	}
-
-	if err := validate.MinLength("value", "body", string(*m.Value), 1); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // MarshalBinary interface implementation
 func (m *Constraint) MarshalBinary() ([]byte, error) {
 	if m == nil {
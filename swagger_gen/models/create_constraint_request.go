@@ -22,15 +22,13 @@ type CreateConstraintRequest struct {
 	// Min Length: 1
 	Operator *string `json:"operator"`
 
-	// property
+	// the key of the entityContext, or a path of keys separated by dots into the objects of the entityContext, e.g. device.os
 	// Required: true
 	// Min Length: 1
 	Property *string `json:"property"`
 
-	// value
-	// Required: true
-	// Min Length: 1
-	Value *string `json:"value"`
+	// the value to compare the property with, it's required by all the operators but EXISTS and NOT_EXISTS, which ignore it
+	Value *string `json:"value,omitempty"`
 }
 
 // Validate validates this create constraint request
@@ -45,10 +43,6 @@ func (m *CreateConstraintRequest) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
-	if err := m.validateValue(formats); err != nil {
-		res = append(res, err)
-	}
-
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -81,19 +75,6 @@ func (m *CreateConstraintRequest) validateProperty(formats strfmt.Registry) erro
 	return nil
 }
 
-func (m *CreateConstraintRequest) validateValue(formats strfmt.Registry) error {
-
-	if err := validate.Required("value", "body", m.Value); err != nil {
-		return err
-	}
-
-	if err := validate.MinLength("value", "body", string(*m.Value), 1); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // MarshalBinary interface implementation
 func (m *CreateConstraintRequest) MarshalBinary() ([]byte, error) {
 	if m == nil {
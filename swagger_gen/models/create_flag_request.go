@@ -17,12 +17,19 @@ import (
 // swagger:model createFlagRequest
 type CreateFlagRequest struct {
 
+	// enabled data records will get data logging in the metrics pipeline, for example, kafka. It's disabled if not provided
+	DataRecordsEnabled *bool `json:"dataRecordsEnabled,omitempty"`
+
 	// description
 	// Required: true
 	// Min Length: 1
 	Description *string `json:"description"`
 
-	// unique key representation of the flag
+	// the flag is disabled automatically once it expires
+	// Format: date-time
+	ExpiresAt *strfmt.DateTime `json:"expiresAt,omitempty"`
+
+	// unique key representation of the flag, it's generated if not provided. The key should match ^[a-z]+[a-z0-9_-]*$, and a used key is rejected with 409
 	Key string `json:"key,omitempty"`
 }
 
@@ -34,6 +41,10 @@ func (m *CreateFlagRequest) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateExpiresAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -53,6 +64,19 @@ func (m *CreateFlagRequest) validateDescription(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *CreateFlagRequest) validateExpiresAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ExpiresAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("expiresAt", "body", "date-time", m.ExpiresAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *CreateFlagRequest) MarshalBinary() ([]byte, error) {
 	if m == nil {
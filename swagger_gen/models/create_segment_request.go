@@ -17,6 +17,10 @@ import (
 // swagger:model createSegmentRequest
 type CreateSegmentRequest struct {
 
+	// the constraint group evaluated together with the constraints of the segment, there's none if it's 0
+	// Minimum: 0
+	ConstraintGroupID *int64 `json:"constraintGroupID,omitempty"`
+
 	// description
 	// Required: true
 	// Min Length: 1
@@ -33,6 +37,10 @@ type CreateSegmentRequest struct {
 func (m *CreateSegmentRequest) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateConstraintGroupID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDescription(formats); err != nil {
 		res = append(res, err)
 	}
@@ -47,6 +55,19 @@ func (m *CreateSegmentRequest) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *CreateSegmentRequest) validateConstraintGroupID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ConstraintGroupID) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("constraintGroupID", "body", int64(*m.ConstraintGroupID), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *CreateSegmentRequest) validateDescription(formats strfmt.Registry) error {
 
 	if err := validate.Required("description", "body", m.Description); err != nil {
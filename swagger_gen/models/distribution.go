@@ -28,6 +28,9 @@ type Distribution struct {
 	// Minimum: 0
 	Percent *int64 `json:"percent"`
 
+	// rollout schedule
+	RolloutSchedule *DistributionRolloutSchedule `json:"rolloutSchedule,omitempty"`
+
 	// variant ID
 	// Required: true
 	// Minimum: 1
@@ -51,6 +54,10 @@ func (m *Distribution) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRolloutSchedule(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateVariantID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -95,6 +102,24 @@ func (m *Distribution) validatePercent(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Distribution) validateRolloutSchedule(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RolloutSchedule) { // not required
+		return nil
+	}
+
+	if m.RolloutSchedule != nil {
+		if err := m.RolloutSchedule.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("rolloutSchedule")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Distribution) validateVariantID(formats strfmt.Registry) error {
 
 	if err := validate.Required("variantID", "body", m.VariantID); err != nil {
@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
@@ -17,6 +19,10 @@ import (
 // swagger:model error
 type Error struct {
 
+	// the machine-readable code of the evaluation errors, it's empty for the other errors. INVALID_REQUEST is the request that can't be evaluated, EVAL_DEBUG_DISABLED is the explanation with the debugging disabled, ENRICHMENT_FAILED is the failed enrichment of the entityContext, RATE_LIMITED is the throttled frontend events, and EVAL_CACHE_REFRESH_FAILED is the failed refresh of the evaluation cache
+	// Enum: [INVALID_REQUEST EVAL_DEBUG_DISABLED ENRICHMENT_FAILED RATE_LIMITED EVAL_CACHE_REFRESH_FAILED]
+	ErrorCode string `json:"errorCode,omitempty"`
+
 	// message
 	// Required: true
 	// Min Length: 1
@@ -27,6 +33,10 @@ type Error struct {
 func (m *Error) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateErrorCode(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMessage(formats); err != nil {
 		res = append(res, err)
 	}
@@ -37,6 +47,58 @@ func (m *Error) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+var errorTypeErrorCodePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["INVALID_REQUEST","EVAL_DEBUG_DISABLED","ENRICHMENT_FAILED","RATE_LIMITED","EVAL_CACHE_REFRESH_FAILED"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		errorTypeErrorCodePropEnum = append(errorTypeErrorCodePropEnum, v)
+	}
+}
+
+const (
+
+	// ErrorErrorCodeINVALIDREQUEST captures enum value "INVALID_REQUEST"
+	ErrorErrorCodeINVALIDREQUEST string = "INVALID_REQUEST"
+
+	// ErrorErrorCodeEVALDEBUGDISABLED captures enum value "EVAL_DEBUG_DISABLED"
+	ErrorErrorCodeEVALDEBUGDISABLED string = "EVAL_DEBUG_DISABLED"
+
+	// ErrorErrorCodeENRICHMENTFAILED captures enum value "ENRICHMENT_FAILED"
+	ErrorErrorCodeENRICHMENTFAILED string = "ENRICHMENT_FAILED"
+
+	// ErrorErrorCodeRATELIMITED captures enum value "RATE_LIMITED"
+	ErrorErrorCodeRATELIMITED string = "RATE_LIMITED"
+
+	// ErrorErrorCodeEVALCACHEREFRESHFAILED captures enum value "EVAL_CACHE_REFRESH_FAILED"
+	ErrorErrorCodeEVALCACHEREFRESHFAILED string = "EVAL_CACHE_REFRESH_FAILED"
+)
+
+// prop value enum
+func (m *Error) validateErrorCodeEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, errorTypeErrorCodePropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Error) validateErrorCode(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ErrorCode) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateErrorCodeEnum("errorCode", "body", m.ErrorCode); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Error) validateMessage(formats strfmt.Registry) error {
 
 	if err := validate.Required("message", "body", m.Message); err != nil {
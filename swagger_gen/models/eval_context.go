@@ -29,12 +29,18 @@ type EvalContext struct {
 	// entity type
 	EntityType string `json:"entityType,omitempty"`
 
+	// the environment whose config of the flag is evaluated, e.g. prod. It falls back to the X-Flagr-Environment header, and the flag's own config is evaluated without it or if the flag has no config of the environment
+	Environment string `json:"environment,omitempty"`
+
 	// flagID
 	// Minimum: 1
 	FlagID int64 `json:"flagID,omitempty"`
 
 	// flagKey. flagID or flagKey will resolve to the same flag. Either works.
 	FlagKey string `json:"flagKey,omitempty"`
+
+	// returns all the segments whose constraints passed in matchedSegments of the result, the variant is still from the first one
+	IncludeAllMatches bool `json:"includeAllMatches,omitempty"`
 }
 
 // Validate validates this eval context
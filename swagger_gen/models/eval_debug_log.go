@@ -18,9 +18,18 @@ import (
 // swagger:model evalDebugLog
 type EvalDebugLog struct {
 
+	// exclusion group debug log
+	ExclusionGroupDebugLog *ExclusionGroupDebugLog `json:"exclusionGroupDebugLog,omitempty"`
+
 	// msg
 	Msg string `json:"msg,omitempty"`
 
+	// prerequisite debug log
+	PrerequisiteDebugLog *PrerequisiteDebugLog `json:"prerequisiteDebugLog,omitempty"`
+
+	// the reason of the final decision, one of FLAG_NOT_FOUND, FLAG_DISABLED, OVERRIDDEN, PREREQUISITE_NOT_MET, EXCLUDED_BY_GROUP, NO_SEGMENTS, SEGMENT_MATCHED, OUT_OF_ROLLOUT, NO_SEGMENT_MATCHED
+	Reason string `json:"reason,omitempty"`
+
 	// segment debug logs
 	SegmentDebugLogs []*SegmentDebugLog `json:"segmentDebugLogs"`
 }
@@ -29,6 +38,14 @@ type EvalDebugLog struct {
 func (m *EvalDebugLog) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateExclusionGroupDebugLog(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePrerequisiteDebugLog(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSegmentDebugLogs(formats); err != nil {
 		res = append(res, err)
 	}
@@ -39,6 +56,42 @@ func (m *EvalDebugLog) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EvalDebugLog) validateExclusionGroupDebugLog(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ExclusionGroupDebugLog) { // not required
+		return nil
+	}
+
+	if m.ExclusionGroupDebugLog != nil {
+		if err := m.ExclusionGroupDebugLog.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("exclusionGroupDebugLog")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *EvalDebugLog) validatePrerequisiteDebugLog(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PrerequisiteDebugLog) { // not required
+		return nil
+	}
+
+	if m.PrerequisiteDebugLog != nil {
+		if err := m.PrerequisiteDebugLog.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("prerequisiteDebugLog")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *EvalDebugLog) validateSegmentDebugLogs(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.SegmentDebugLogs) { // not required
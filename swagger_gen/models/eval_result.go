@@ -6,10 +6,14 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // EvalResult eval result
@@ -22,6 +26,13 @@ type EvalResult struct {
 	// eval debug log
 	EvalDebugLog *EvalDebugLog `json:"evalDebugLog,omitempty"`
 
+	// why no variant is resolved, it's empty when a variant is. FLAG_NOT_FOUND and FLAG_DISABLED are about the flag, INVALID_CONTEXT is when the entityContext can't be evaluated against the constraints of a segment, and NO_SEGMENT_MATCH is when the entity is not in any segment or rollout, see evalDebugLog.reason for the details
+	// Enum: [FLAG_NOT_FOUND FLAG_DISABLED INVALID_CONTEXT NO_SEGMENT_MATCH]
+	ErrorCode string `json:"errorCode,omitempty"`
+
+	// the type of the frontend event, only set when source is frontend
+	EventType string `json:"eventType,omitempty"`
+
 	// flag ID
 	FlagID int64 `json:"flagID,omitempty"`
 
@@ -31,15 +42,33 @@ type EvalResult struct {
 	// flag snapshot ID
 	FlagSnapshotID int64 `json:"flagSnapshotID,omitempty"`
 
+	// it's true if no segment matched and the variant is the flag's default variant
+	IsDefaultVariant bool `json:"isDefaultVariant,omitempty"`
+
+	// it's true if the variant is forced by the override of the flag, the segments are not evaluated then
+	IsOverridden bool `json:"isOverridden,omitempty"`
+
+	// all the segments whose constraints passed in the order of rank, only set when includeAllMatches is requested. It's left out of the data records
+	MatchedSegments []*MatchedSegment `json:"matchedSegments,omitempty"`
+
 	// segment ID
 	SegmentID int64 `json:"segmentID,omitempty"`
 
+	// where the record comes from. It's empty for the server side evaluations, and frontend for the events reported by the frontend clients
+	Source string `json:"source,omitempty"`
+
 	// timestamp
 	Timestamp string `json:"timestamp,omitempty"`
 
+	// the attachment coerced into the attachmentTypes of the variant, only set when the variant declares them
+	TypedVariantAttachment interface{} `json:"typedVariantAttachment,omitempty"`
+
 	// variant attachment
 	VariantAttachment interface{} `json:"variantAttachment,omitempty"`
 
+	// the reason why the attachment doesn't conform to the attachmentTypes of the variant, typedVariantAttachment is not set then
+	VariantAttachmentError string `json:"variantAttachmentError,omitempty"`
+
 	// variant ID
 	VariantID int64 `json:"variantID,omitempty"`
 
@@ -59,6 +88,14 @@ func (m *EvalResult) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateErrorCode(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMatchedSegments(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -101,6 +138,80 @@ func (m *EvalResult) validateEvalDebugLog(formats strfmt.Registry) error {
 	return nil
 }
 
+var evalResultTypeErrorCodePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["FLAG_NOT_FOUND","FLAG_DISABLED","INVALID_CONTEXT","NO_SEGMENT_MATCH"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		evalResultTypeErrorCodePropEnum = append(evalResultTypeErrorCodePropEnum, v)
+	}
+}
+
+const (
+
+	// EvalResultErrorCodeFLAGNOTFOUND captures enum value "FLAG_NOT_FOUND"
+	EvalResultErrorCodeFLAGNOTFOUND string = "FLAG_NOT_FOUND"
+
+	// EvalResultErrorCodeFLAGDISABLED captures enum value "FLAG_DISABLED"
+	EvalResultErrorCodeFLAGDISABLED string = "FLAG_DISABLED"
+
+	// EvalResultErrorCodeINVALIDCONTEXT captures enum value "INVALID_CONTEXT"
+	EvalResultErrorCodeINVALIDCONTEXT string = "INVALID_CONTEXT"
+
+	// EvalResultErrorCodeNOSEGMENTMATCH captures enum value "NO_SEGMENT_MATCH"
+	EvalResultErrorCodeNOSEGMENTMATCH string = "NO_SEGMENT_MATCH"
+)
+
+// prop value enum
+func (m *EvalResult) validateErrorCodeEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, evalResultTypeErrorCodePropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *EvalResult) validateErrorCode(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ErrorCode) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateErrorCodeEnum("errorCode", "body", m.ErrorCode); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *EvalResult) validateMatchedSegments(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.MatchedSegments) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.MatchedSegments); i++ {
+		if swag.IsZero(m.MatchedSegments[i]) { // not required
+			continue
+		}
+
+		if m.MatchedSegments[i] != nil {
+			if err := m.MatchedSegments[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("matchedSegments" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *EvalResult) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -27,6 +27,9 @@ type EvaluationBatchRequest struct {
 	// Min Items: 1
 	Entities []*EvaluationEntity `json:"entities"`
 
+	// the environment whose configs of the flags are evaluated, it falls back to the X-Flagr-Environment header
+	Environment string `json:"environment,omitempty"`
+
 	// flagIDs
 	// Min Items: 1
 	FlagIds []int64 `json:"flagIDs"`
@@ -34,6 +37,9 @@ type EvaluationBatchRequest struct {
 	// flagKeys. Either flagIDs or flagKeys works. If pass in both, Flagr may return duplicate results.
 	// Min Items: 1
 	FlagKeys []string `json:"flagKeys"`
+
+	// include all matches
+	IncludeAllMatches bool `json:"includeAllMatches,omitempty"`
 }
 
 // Validate validates this evaluation batch request
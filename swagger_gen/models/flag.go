@@ -19,6 +19,18 @@ import (
 // swagger:model flag
 type Flag struct {
 
+	// the JSON schema (draft 4) that the attachments of the variants are validated against, it's not set if it's empty
+	AttachmentSchema interface{} `json:"attachmentSchema,omitempty"`
+
+	// the entityContext attribute to bucket by if it's not empty, otherwise (or if the attribute is missing) the entityID is used
+	BucketBy string `json:"bucketBy,omitempty"`
+
+	// the hash of the distribution bucketing of the flag, one of crc32, murmur3 and xxhash. The global FLAGR_BUCKETING_ALGORITHM is used if it's empty. Changing it reshuffles the assignments of the entities.
+	BucketingAlgorithm string `json:"bucketingAlgorithm,omitempty"`
+
+	// it will be mixed into the bucketing hash of the flag if it's not empty, otherwise the flag ID is used
+	BucketingSeed string `json:"bucketingSeed,omitempty"`
+
 	// created by
 	CreatedBy string `json:"createdBy,omitempty"`
 
@@ -26,6 +38,13 @@ type Flag struct {
 	// Required: true
 	DataRecordsEnabled *bool `json:"dataRecordsEnabled"`
 
+	// the variant returned when no segment matches, it's not set if it's 0
+	DefaultVariantID int64 `json:"defaultVariantID,omitempty"`
+
+	// it's only set for the soft deleted flags
+	// Format: date-time
+	DeletedAt *strfmt.DateTime `json:"deletedAt,omitempty"`
+
 	// description
 	// Required: true
 	// Min Length: 1
@@ -38,6 +57,18 @@ type Flag struct {
 	// it will override the entityType in the evaluation logs if it's not empty
 	EntityType string `json:"entityType,omitempty"`
 
+	// the exclusion group of the flag, it's not in any group if it's 0
+	// Read Only: true
+	ExclusionGroupID int64 `json:"exclusionGroupID,omitempty"`
+
+	// the percent of the exclusion group's buckets routed to the flag
+	// Read Only: true
+	ExclusionGroupPercent int64 `json:"exclusionGroupPercent,omitempty"`
+
+	// the flag is disabled automatically once it expires
+	// Format: date-time
+	ExpiresAt *strfmt.DateTime `json:"expiresAt,omitempty"`
+
 	// id
 	// Read Only: true
 	// Minimum: 1
@@ -47,12 +78,38 @@ type Flag struct {
 	// Min Length: 1
 	Key string `json:"key,omitempty"`
 
+	// the last time the flag was evaluated, it's null if it's never evaluated. It's flushed from the evaluations every FLAGR_FLAG_LAST_EVALUATED_FLUSH_INTERVAL
+	// Read Only: true
+	// Format: date-time
+	LastEvaluatedAt *strfmt.DateTime `json:"lastEvaluatedAt,omitempty"`
+
+	// the namespace of the flag, it's the namespace of the creator if the namespaces are enabled by FLAGR_JWT_AUTH_NAMESPACE_CLAIM
+	// Read Only: true
+	Namespace string `json:"namespace,omitempty"`
+
 	// flag usage details in markdown format
 	Notes string `json:"notes,omitempty"`
 
+	// the flag evaluates to overrideVariantID for every entity if it's true, ignoring the segments
+	// Read Only: true
+	OverrideEnabled bool `json:"overrideEnabled,omitempty"`
+
+	// the variant the flag is forced to, there's no override if it's 0
+	// Read Only: true
+	OverrideVariantID int64 `json:"overrideVariantID,omitempty"`
+
+	// the flag that has to evaluate to prerequisiteVariantKey for the same entity before this flag is evaluated, it's not set if it's 0
+	PrerequisiteFlagID int64 `json:"prerequisiteFlagID,omitempty"`
+
+	// the expected variant of the prerequisite flag, any variant is accepted if it's empty
+	PrerequisiteVariantKey string `json:"prerequisiteVariantKey,omitempty"`
+
 	// segments
 	Segments []*Segment `json:"segments"`
 
+	// tags
+	Tags []*Tag `json:"tags"`
+
 	// updated at
 	// Format: date-time
 	UpdatedAt strfmt.DateTime `json:"updatedAt,omitempty"`
@@ -72,6 +129,10 @@ func (m *Flag) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateDeletedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDescription(formats); err != nil {
 		res = append(res, err)
 	}
@@ -80,6 +141,10 @@ func (m *Flag) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateExpiresAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -88,10 +153,18 @@ func (m *Flag) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateLastEvaluatedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSegments(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateTags(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateUpdatedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -115,6 +188,19 @@ func (m *Flag) validateDataRecordsEnabled(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Flag) validateDeletedAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DeletedAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("deletedAt", "body", "date-time", m.DeletedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Flag) validateDescription(formats strfmt.Registry) error {
 
 	if err := validate.Required("description", "body", m.Description); err != nil {
@@ -137,6 +223,19 @@ func (m *Flag) validateEnabled(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Flag) validateExpiresAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ExpiresAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("expiresAt", "body", "date-time", m.ExpiresAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Flag) validateID(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ID) { // not required
@@ -163,6 +262,19 @@ func (m *Flag) validateKey(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Flag) validateLastEvaluatedAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LastEvaluatedAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("lastEvaluatedAt", "body", "date-time", m.LastEvaluatedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Flag) validateSegments(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Segments) { // not required
@@ -188,6 +300,31 @@ func (m *Flag) validateSegments(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Flag) validateTags(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Tags) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Tags); i++ {
+		if swag.IsZero(m.Tags[i]) { // not required
+			continue
+		}
+
+		if m.Tags[i] != nil {
+			if err := m.Tags[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("tags" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Flag) validateUpdatedAt(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.UpdatedAt) { // not required
@@ -17,9 +17,25 @@ import (
 // swagger:model putFlagRequest
 type PutFlagRequest struct {
 
+	// the JSON schema (draft 4) that the attachments of the variants are validated against, the existing variants are validated when it's set. $ref and id are not supported. Set it to {} to unset it
+	AttachmentSchema interface{} `json:"attachmentSchema,omitempty"`
+
+	// the entityContext attribute to bucket by if it's not empty, otherwise (or if the attribute is missing) the entityID is used
+	BucketBy *string `json:"bucketBy,omitempty"`
+
+	// the hash of the distribution bucketing of the flag, one of crc32, murmur3 and xxhash. The global FLAGR_BUCKETING_ALGORITHM is used if it's empty. Changing it reshuffles the assignments of the entities.
+	BucketingAlgorithm *string `json:"bucketingAlgorithm,omitempty"`
+
+	// it will be mixed into the bucketing hash of the flag if it's not empty, otherwise the flag ID is used
+	BucketingSeed *string `json:"bucketingSeed,omitempty"`
+
 	// enabled data records will get data logging in the metrics pipeline, for example, kafka.
 	DataRecordsEnabled *bool `json:"dataRecordsEnabled,omitempty"`
 
+	// the variant returned when no segment matches, set it to 0 to unset it
+	// Minimum: 0
+	DefaultVariantID *int64 `json:"defaultVariantID,omitempty"`
+
 	// description
 	// Min Length: 1
 	Description *string `json:"description,omitempty"`
@@ -30,27 +46,66 @@ type PutFlagRequest struct {
 	// it will overwrite entityType into evaluation logs if it's not empty
 	EntityType *string `json:"entityType,omitempty"`
 
+	// the flag is disabled automatically once it expires, set it to 0001-01-01T00:00:00Z to unset it
+	// Format: date-time
+	ExpiresAt *strfmt.DateTime `json:"expiresAt,omitempty"`
+
 	// key
 	Key *string `json:"key,omitempty"`
 
+	// the rationale of the change, it's stored in the history of the flag
+	Note string `json:"note,omitempty"`
+
 	// notes
 	Notes *string `json:"notes,omitempty"`
+
+	// the flag that has to evaluate to prerequisiteVariantKey for the same entity before this flag is evaluated, set it to 0 to unset it
+	// Minimum: 0
+	PrerequisiteFlagID *int64 `json:"prerequisiteFlagID,omitempty"`
+
+	// the expected variant of the prerequisite flag, any variant is accepted if it's empty
+	PrerequisiteVariantKey *string `json:"prerequisiteVariantKey,omitempty"`
 }
 
 // Validate validates this put flag request
 func (m *PutFlagRequest) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDefaultVariantID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDescription(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateExpiresAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePrerequisiteFlagID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *PutFlagRequest) validateDefaultVariantID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DefaultVariantID) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("defaultVariantID", "body", int64(*m.DefaultVariantID), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *PutFlagRequest) validateDescription(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Description) { // not required
@@ -64,6 +119,32 @@ func (m *PutFlagRequest) validateDescription(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *PutFlagRequest) validateExpiresAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ExpiresAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("expiresAt", "body", "date-time", m.ExpiresAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PutFlagRequest) validatePrerequisiteFlagID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PrerequisiteFlagID) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("prerequisiteFlagID", "body", int64(*m.PrerequisiteFlagID), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *PutFlagRequest) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
@@ -20,6 +22,9 @@ type PutVariantRequest struct {
 	// attachment
 	Attachment interface{} `json:"attachment,omitempty"`
 
+	// the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings
+	AttachmentTypes map[string]string `json:"attachmentTypes,omitempty"`
+
 	// key
 	// Required: true
 	// Min Length: 1
@@ -30,6 +35,10 @@ type PutVariantRequest struct {
 func (m *PutVariantRequest) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAttachmentTypes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateKey(formats); err != nil {
 		res = append(res, err)
 	}
@@ -40,6 +49,44 @@ func (m *PutVariantRequest) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+// additional properties value enum
+var putVariantRequestAttachmentTypesValueEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["string","number","integer","boolean","json"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		putVariantRequestAttachmentTypesValueEnum = append(putVariantRequestAttachmentTypesValueEnum, v)
+	}
+}
+
+func (m *PutVariantRequest) validateAttachmentTypesValueEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, putVariantRequestAttachmentTypesValueEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PutVariantRequest) validateAttachmentTypes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AttachmentTypes) { // not required
+		return nil
+	}
+
+	for k := range m.AttachmentTypes {
+
+		// value enum
+		if err := m.validateAttachmentTypesValueEnum("attachmentTypes"+"."+k, "body", m.AttachmentTypes[k]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
 func (m *PutVariantRequest) validateKey(formats strfmt.Registry) error {
 
 	if err := validate.Required("key", "body", m.Key); err != nil {
@@ -19,6 +19,10 @@ import (
 // swagger:model segment
 type Segment struct {
 
+	// the constraint group evaluated together with the constraints of the segment, there's none if it's 0
+	// Minimum: 0
+	ConstraintGroupID *int64 `json:"constraintGroupID,omitempty"`
+
 	// constraints
 	Constraints []*Constraint `json:"constraints"`
 
@@ -51,6 +55,10 @@ type Segment struct {
 func (m *Segment) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateConstraintGroupID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateConstraints(formats); err != nil {
 		res = append(res, err)
 	}
@@ -81,6 +89,19 @@ func (m *Segment) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Segment) validateConstraintGroupID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ConstraintGroupID) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("constraintGroupID", "body", int64(*m.ConstraintGroupID), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Segment) validateConstraints(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Constraints) { // not required
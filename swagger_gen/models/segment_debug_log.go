@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
@@ -17,6 +19,15 @@ import (
 // swagger:model segmentDebugLog
 type SegmentDebugLog struct {
 
+	// the bucket number the entity falls into, only set when the constraints are matched
+	BucketNum int64 `json:"bucketNum,omitempty"`
+
+	// constraint debug logs
+	ConstraintDebugLogs []*ConstraintDebugLog `json:"constraintDebugLogs"`
+
+	// whether all the constraints of the segment are matched
+	Matched bool `json:"matched,omitempty"`
+
 	// msg
 	Msg string `json:"msg,omitempty"`
 
@@ -29,6 +40,10 @@ type SegmentDebugLog struct {
 func (m *SegmentDebugLog) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateConstraintDebugLogs(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSegmentID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -39,6 +54,31 @@ func (m *SegmentDebugLog) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SegmentDebugLog) validateConstraintDebugLogs(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ConstraintDebugLogs) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ConstraintDebugLogs); i++ {
+		if swag.IsZero(m.ConstraintDebugLogs[i]) { // not required
+			continue
+		}
+
+		if m.ConstraintDebugLogs[i] != nil {
+			if err := m.ConstraintDebugLogs[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("constraintDebugLogs" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *SegmentDebugLog) validateSegmentID(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.SegmentID) { // not required
@@ -20,6 +20,9 @@ type SetFlagEnabledRequest struct {
 	// enabled
 	// Required: true
 	Enabled *bool `json:"enabled"`
+
+	// the rationale of the change, it's stored in the history of the flag
+	Note string `json:"note,omitempty"`
 }
 
 // Validate validates this set flag enabled request
@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
@@ -20,6 +22,9 @@ type Variant struct {
 	// attachment
 	Attachment interface{} `json:"attachment,omitempty"`
 
+	// the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings
+	AttachmentTypes map[string]string `json:"attachmentTypes,omitempty"`
+
 	// id
 	// Read Only: true
 	// Minimum: 1
@@ -35,6 +40,10 @@ type Variant struct {
 func (m *Variant) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAttachmentTypes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -49,6 +58,44 @@ func (m *Variant) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+// additional properties value enum
+var variantAttachmentTypesValueEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["string","number","integer","boolean","json"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		variantAttachmentTypesValueEnum = append(variantAttachmentTypesValueEnum, v)
+	}
+}
+
+func (m *Variant) validateAttachmentTypesValueEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, variantAttachmentTypesValueEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *Variant) validateAttachmentTypes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AttachmentTypes) { // not required
+		return nil
+	}
+
+	for k := range m.AttachmentTypes {
+
+		// value enum
+		if err := m.validateAttachmentTypesValueEnum("attachmentTypes"+"."+k, "body", m.AttachmentTypes[k]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Variant) validateID(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ID) { // not required
@@ -21,6 +21,11 @@ import (
 
 func configureFlags(api *operations.FlagrAPI) {
 	// api.CommandLineOptionsGroups = []swag.CommandLineOptionsGroup{ ... }
+
+	// the --scheme flag still takes precedence
+	if config.Config.TLSEnabled {
+		defaultSchemes = []string{schemeHTTPS}
+	}
 }
 
 func configureAPI(api *operations.FlagrAPI) http.Handler {
@@ -28,6 +33,8 @@ func configureAPI(api *operations.FlagrAPI) http.Handler {
 
 	api.JSONConsumer = runtime.JSONConsumer()
 	api.JSONProducer = runtime.JSONProducer()
+	api.CsvProducer = runtime.TextProducer()
+	api.TextEventStreamProducer = runtime.TextProducer()
 	api.Logger = logrus.Infof
 	api.ServerShutdown = config.ServerShutdown
 
@@ -37,7 +44,9 @@ func configureAPI(api *operations.FlagrAPI) http.Handler {
 
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
-	// Make all necessary changes to the TLS configuration here.
+	if err := config.SetupTLS(tlsConfig); err != nil {
+		logrus.WithField("err", err).Fatal("failed to setup TLS")
+	}
 }
 
 // As soon as server is initialized but not run yet, this function will be called.
@@ -45,12 +54,17 @@ func configureTLS(tlsConfig *tls.Config) {
 // This function can be called multiple times, depending on the number of serving schemes.
 // scheme value will be set accordingly: "http", "https" or "unix"
 func configureServer(s *http.Server, scheme, addr string) {
+	config.SetupServer(s)
+	s.RegisterOnShutdown(handler.CloseFlagChangeStream)
+	if scheme == "http" {
+		config.SetupH2C(s)
+	}
 }
 
 // The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
 // The middleware executes after routing but before authentication, binding and validation
-func setupMiddlewares(handler http.Handler) http.Handler {
-	return handler
+func setupMiddlewares(h http.Handler) http.Handler {
+	return handler.NamespaceMiddleware(h)
 }
 
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
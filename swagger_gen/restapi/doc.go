@@ -18,6 +18,8 @@ Flagr is a feature flagging, A/B testing and dynamic configuration microservice.
     Produces:
     - application/json
     - application/octet-stream
+    - text/csv
+    - text/event-stream
 
 swagger:meta
 */
@@ -35,28 +35,51 @@ func init() {
   },
   "basePath": "/api/v1",
   "paths": {
-    "/evaluation": {
+    "/constraint_groups": {
+      "get": {
+        "tags": [
+          "constraintGroup"
+        ],
+        "operationId": "findConstraintGroups",
+        "responses": {
+          "200": {
+            "description": "all the constraint groups ordered by key",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/constraintGroup"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
       "post": {
         "tags": [
-          "evaluation"
+          "constraintGroup"
         ],
-        "operationId": "postEvaluation",
+        "operationId": "createConstraintGroup",
         "parameters": [
           {
-            "description": "evalution context",
+            "description": "create a constraint group",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/evalContext"
+              "$ref": "#/definitions/createConstraintGroupRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "evaluation result",
+            "description": "returns the created constraint group",
             "schema": {
-              "$ref": "#/definitions/evalResult"
+              "$ref": "#/definitions/constraintGroup"
             }
           },
           "default": {
@@ -68,28 +91,69 @@ func init() {
         }
       }
     },
-    "/evaluation/batch": {
-      "post": {
+    "/constraint_groups/{constraintGroupID}": {
+      "get": {
         "tags": [
-          "evaluation"
+          "constraintGroup"
         ],
-        "operationId": "postEvaluationBatch",
+        "operationId": "getConstraintGroup",
         "parameters": [
           {
-            "description": "evalution batch request",
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint group",
+            "name": "constraintGroupID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the constraint group with the segments referencing it",
+            "schema": {
+              "$ref": "#/definitions/constraintGroup"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "replaces the description and the constraints of the constraint group, all the segments referencing it are evaluated with the new constraints",
+        "tags": [
+          "constraintGroup"
+        ],
+        "operationId": "putConstraintGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint group",
+            "name": "constraintGroupID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update a constraint group",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/evaluationBatchRequest"
+              "$ref": "#/definitions/putConstraintGroupRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "evaluation batch result",
+            "description": "returns the constraint group",
             "schema": {
-              "$ref": "#/definitions/evaluationBatchResponse"
+              "$ref": "#/definitions/constraintGroup"
             }
           },
           "default": {
@@ -99,24 +163,27 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/export/eval_cache/json": {
-      "get": {
-        "description": "Export JSON format of the eval cache dump",
-        "produces": [
-          "application/json"
-        ],
+      },
+      "delete": {
+        "description": "deletes the constraint group, it fails if any segment still references it",
         "tags": [
-          "export"
+          "constraintGroup"
+        ],
+        "operationId": "deleteConstraintGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint group",
+            "name": "constraintGroupID",
+            "in": "path",
+            "required": true
+          }
         ],
-        "operationId": "getExportEvalCacheJSON",
         "responses": {
           "200": {
-            "description": "OK",
-            "schema": {
-              "type": "object"
-            }
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -127,21 +194,29 @@ func init() {
         }
       }
     },
-    "/export/sqlite": {
-      "get": {
-        "description": "Export sqlite3 format of the db dump, which is converted from the main database.",
-        "produces": [
-          "application/octet-stream"
-        ],
+    "/constraints/evaluate": {
+      "post": {
+        "description": "evaluates the constraint against the entity contexts without saving it, for example, to preview a constraint before it's saved. It's stateless and it doesn't write any data record.",
         "tags": [
-          "export"
+          "constraint"
+        ],
+        "operationId": "evaluateConstraint",
+        "parameters": [
+          {
+            "description": "the constraint and the entity contexts to evaluate it against",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluateConstraintRequest"
+            }
+          }
         ],
-        "operationId": "getExportSQLite",
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "the results of the evaluation, in the order of the entity contexts",
             "schema": {
-              "type": "file"
+              "$ref": "#/definitions/evaluateConstraintResponse"
             }
           },
           "default": {
@@ -153,66 +228,68 @@ func init() {
         }
       }
     },
-    "/flags": {
+    "/evaluation": {
       "get": {
+        "description": "evaluates the flag like postEvaluation, with the evaluation context in the query parameters, e.g. for the clients that can only send GET requests. The whole URL is the cache key of the result if it's cached by an HTTP cache, see docs/flagr_env.md",
         "tags": [
-          "flag"
+          "evaluation"
         ],
-        "operationId": "findFlags",
+        "operationId": "getEvaluation",
         "parameters": [
           {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "the numbers of flags to return",
-            "name": "limit",
+            "description": "flagID",
+            "name": "flagID",
             "in": "query"
           },
           {
-            "type": "boolean",
-            "description": "return flags having given enabled status",
-            "name": "enabled",
+            "type": "string",
+            "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
+            "name": "flagKey",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "return flags exactly matching given description",
-            "name": "description",
+            "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
+            "name": "entityID",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "return flags partially matching given description",
-            "name": "description_like",
+            "name": "entityType",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "return flags matching given key",
-            "name": "key",
+            "description": "the entity context as a JSON object, URL encoded, e.g. entityContext=%7B%22state%22%3A%22CA%22%7D for {\"state\":\"CA\"}",
+            "name": "entityContext",
             "in": "query"
           },
           {
-            "type": "integer",
-            "format": "int64",
-            "description": "return flags given the offset, it should usually set together with limit",
-            "name": "offset",
+            "type": "boolean",
+            "name": "enableDebug",
             "in": "query"
           },
           {
             "type": "boolean",
-            "description": "return flags with preloaded segments and variants",
-            "name": "preload",
+            "description": "returns all the segments whose constraints passed in matchedSegments of the result, the variant is still from the first one",
+            "name": "includeAllMatches",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the environment whose config of the flag is evaluated, e.g. prod. It falls back to the X-Flagr-Environment header, and the flag's own config is evaluated without it or if the flag has no config of the environment",
+            "name": "environment",
             "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "list all the flags",
+            "description": "evaluation result",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/flag"
-              }
+              "$ref": "#/definitions/evalResult"
             }
           },
           "default": {
@@ -225,25 +302,25 @@ func init() {
       },
       "post": {
         "tags": [
-          "flag"
+          "evaluation"
         ],
-        "operationId": "createFlag",
+        "operationId": "postEvaluation",
         "parameters": [
           {
-            "description": "create a flag",
+            "description": "evalution context",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/createFlagRequest"
+              "$ref": "#/definitions/evalContext"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the created flag",
+            "description": "evaluation result",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "$ref": "#/definitions/evalResult"
             }
           },
           "default": {
@@ -255,21 +332,28 @@ func init() {
         }
       }
     },
-    "/flags/entity_types": {
-      "get": {
+    "/evaluation/batch": {
+      "post": {
         "tags": [
-          "flag"
+          "evaluation"
+        ],
+        "operationId": "postEvaluationBatch",
+        "parameters": [
+          {
+            "description": "evalution batch request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationBatchRequest"
+            }
+          }
         ],
-        "operationId": "getFlagEntityTypes",
         "responses": {
           "200": {
-            "description": "returns all the FlagEntityTypes",
+            "description": "evaluation batch result",
             "schema": {
-              "type": "array",
-              "items": {
-                "type": "string",
-                "minLength": 1
-              }
+              "$ref": "#/definitions/evaluationBatchResponse"
             }
           },
           "default": {
@@ -281,28 +365,29 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}": {
-      "get": {
+    "/evaluation/bytag": {
+      "post": {
+        "description": "evaluates all the enabled flags carrying the tag for the entity, the results are empty if no flag carries the tag",
         "tags": [
-          "flag"
+          "evaluation"
         ],
-        "operationId": "getFlag",
+        "operationId": "postEvaluationByTag",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
-            "in": "path",
-            "required": true
+            "description": "evaluation by tag request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationByTagRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
+            "description": "evaluation results of the flags carrying the tag",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "$ref": "#/definitions/evaluationBatchResponse"
             }
           },
           "default": {
@@ -312,37 +397,54 @@ func init() {
             }
           }
         }
-      },
-      "put": {
+      }
+    },
+    "/evaluation/cache/refresh": {
+      "post": {
+        "description": "reloads the in-memory evaluation cache immediately instead of waiting for the next refresh interval, for example, after a bulk import. It requires the auth even though the other evaluation endpoints may not.",
         "tags": [
-          "flag"
+          "evaluation"
         ],
-        "operationId": "putFlag",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
-            "in": "path",
-            "required": true
+        "operationId": "postEvaluationCacheRefresh",
+        "responses": {
+          "200": {
+            "description": "the evaluation cache is reloaded",
+            "schema": {
+              "$ref": "#/definitions/evalCacheRefreshResponse"
+            }
           },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/explain": {
+      "post": {
+        "description": "evaluates the flag with debugging enabled and explains the result. It's read-only and it doesn't write any data record.",
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluationExplain",
+        "parameters": [
           {
-            "description": "update a flag",
+            "description": "evalution context",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putFlagRequest"
+              "$ref": "#/definitions/evalContext"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
+            "description": "evaluation result with the debug log",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "$ref": "#/definitions/evalResult"
             }
           },
           "default": {
@@ -352,68 +454,29 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/evaluation/frontend-events": {
+      "post": {
+        "description": "records the impression or conversion events reported by the frontend clients. The events are sent to the data recorder with the source marked as frontend.",
         "tags": [
-          "flag"
-        ],
-        "operationId": "deleteFlag",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "OK deleted"
-          },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          }
-        }
-      }
-    },
-    "/flags/{flagID}/enabled": {
-      "put": {
-        "tags": [
-          "flag"
+          "evaluation"
         ],
-        "operationId": "setFlagEnabled",
+        "operationId": "postEvaluationFrontendEvents",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "set flag enabled state",
+            "description": "frontend events",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/setFlagEnabledRequest"
+              "$ref": "#/definitions/frontendEventsRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
-            "schema": {
-              "$ref": "#/definitions/flag"
-            }
+            "description": "OK recorded"
           },
           "default": {
             "description": "generic error response",
@@ -424,30 +487,19 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments": {
+    "/exclusion_groups": {
       "get": {
         "tags": [
-          "segment"
-        ],
-        "operationId": "findSegments",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          }
+          "exclusionGroup"
         ],
+        "operationId": "findExclusionGroups",
         "responses": {
           "200": {
-            "description": "segments ordered by rank of the flag",
+            "description": "all the exclusion groups ordered by key",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/segment"
+                "$ref": "#/definitions/exclusionGroup"
               }
             }
           },
@@ -461,34 +513,25 @@ func init() {
       },
       "post": {
         "tags": [
-          "segment"
+          "exclusionGroup"
         ],
-        "operationId": "createSegment",
+        "operationId": "createExclusionGroup",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "create a segment under a flag",
+            "description": "create an exclusion group",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/createSegmentRequest"
+              "$ref": "#/definitions/createExclusionGroupRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "segment created",
+            "description": "returns the created exclusion group",
             "schema": {
-              "$ref": "#/definitions/segment"
+              "$ref": "#/definitions/exclusionGroup"
             }
           },
           "default": {
@@ -500,35 +543,29 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/reorder": {
-      "put": {
+    "/exclusion_groups/{exclusionGroupID}": {
+      "get": {
         "tags": [
-          "segment"
+          "exclusionGroup"
         ],
-        "operationId": "putSegmentsReorder",
+        "operationId": "getExclusionGroup",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
+            "description": "numeric ID of the exclusion group",
+            "name": "exclusionGroupID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "reorder segments",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/putSegmentReorderRequest"
-            }
           }
         ],
         "responses": {
           "200": {
-            "description": "segments reordered"
+            "description": "returns the exclusion group with its member flags",
+            "schema": {
+              "$ref": "#/definitions/exclusionGroup"
+            }
           },
           "default": {
             "description": "generic error response",
@@ -537,48 +574,37 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/segments/{segmentID}": {
+      },
       "put": {
         "tags": [
-          "segment"
+          "exclusionGroup"
         ],
-        "operationId": "putSegment",
+        "operationId": "putExclusionGroup",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
+            "description": "numeric ID of the exclusion group",
+            "name": "exclusionGroupID",
             "in": "path",
             "required": true
           },
           {
-            "description": "update a segment",
+            "description": "update an exclusion group",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putSegmentRequest"
+              "$ref": "#/definitions/putExclusionGroupRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "segment updated",
+            "description": "returns the exclusion group",
             "schema": {
-              "$ref": "#/definitions/segment"
+              "$ref": "#/definitions/exclusionGroup"
             }
           },
           "default": {
@@ -590,26 +616,18 @@ func init() {
         }
       },
       "delete": {
+        "description": "deletes the exclusion group, its member flags are left out of any group",
         "tags": [
-          "segment"
+          "exclusionGroup"
         ],
-        "operationId": "deleteSegment",
+        "operationId": "deleteExclusionGroup",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
+            "description": "numeric ID of the exclusion group",
+            "name": "exclusionGroupID",
             "in": "path",
             "required": true
           }
@@ -627,40 +645,21 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/{segmentID}/constraints": {
+    "/export/eval_cache/json": {
       "get": {
-        "tags": [
-          "constraint"
+        "description": "Export JSON format of the eval cache dump",
+        "produces": [
+          "application/json"
         ],
-        "operationId": "findConstraints",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          }
+        "tags": [
+          "export"
         ],
+        "operationId": "getExportEvalCacheJSON",
         "responses": {
           "200": {
-            "description": "constraints under the segment",
+            "description": "OK",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/constraint"
-              }
+              "type": "object"
             }
           },
           "default": {
@@ -670,46 +669,23 @@ func init() {
             }
           }
         }
-      },
-      "post": {
-        "tags": [
-          "constraint"
+      }
+    },
+    "/export/sqlite": {
+      "get": {
+        "description": "Export sqlite3 format of the db dump, which is converted from the main database.",
+        "produces": [
+          "application/octet-stream"
         ],
-        "operationId": "createConstraint",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "create a constraint",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createConstraintRequest"
-            }
-          }
+        "tags": [
+          "export"
         ],
+        "operationId": "getExportSQLite",
         "responses": {
           "200": {
-            "description": "the constraint created",
+            "description": "OK",
             "schema": {
-              "$ref": "#/definitions/constraint"
+              "type": "file"
             }
           },
           "default": {
@@ -721,102 +697,105 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}": {
-      "put": {
+    "/flags": {
+      "get": {
         "tags": [
-          "constraint"
+          "flag"
         ],
-        "operationId": "putConstraint",
+        "operationId": "findFlags",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
+            "description": "the numbers of flags to return, it defaults to FLAGR_FLAGS_DEFAULT_LIMIT and it's capped by FLAGR_FLAGS_MAX_LIMIT",
+            "name": "limit",
+            "in": "query"
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
+            "type": "boolean",
+            "description": "return flags having given enabled status",
+            "name": "enabled",
+            "in": "query"
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the constraint",
-            "name": "constraintID",
-            "in": "path",
-            "required": true
+            "type": "string",
+            "description": "return flags exactly matching given description",
+            "name": "description",
+            "in": "query"
           },
           {
-            "description": "create a constraint",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createConstraintRequest"
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "constraint just updated",
-            "schema": {
-              "$ref": "#/definitions/constraint"
-            }
+            "type": "string",
+            "description": "return flags partially matching given description",
+            "name": "description_like",
+            "in": "query"
           },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          }
-        }
-      },
-      "delete": {
-        "tags": [
-          "constraint"
-        ],
-        "operationId": "deleteConstraint",
-        "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
+            "type": "string",
+            "description": "return flags having all the given tags, comma separated",
+            "name": "tags",
+            "in": "query"
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
+            "type": "string",
+            "description": "search flags by the whitespace separated terms, case-insensitively. Every term has to be part of the key, the description or one of the tags. Flags with the exact key are returned first, and the number of results is capped by FLAGR_FLAGS_SEARCH_MAX_LIMIT",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags matching given key",
+            "name": "key",
+            "in": "query"
           },
           {
-            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the constraint",
-            "name": "constraintID",
-            "in": "path",
-            "required": true
+            "description": "return flags given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return flags with preloaded segments and variants",
+            "name": "preload",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return the soft deleted flags as well, only for the admins",
+            "name": "includeDeleted",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "list the flags as they're configured in the environment, the enabled filter is on their enabled state in it",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags not evaluated within the duration, e.g. 30d or 12h, including the ones never evaluated that were created before it",
+            "name": "unusedSince",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "list all the flags ordered by id",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flag"
+              }
+            },
+            "headers": {
+              "X-Total-Count": {
+                "type": "integer",
+                "format": "int64",
+                "description": "the total number of flags matching the filters, regardless of limit and offset"
+              }
+            }
           },
           "default": {
             "description": "generic error response",
@@ -825,42 +804,28 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/segments/{segmentID}/distributions": {
-      "get": {
+      },
+      "post": {
         "tags": [
-          "distribution"
+          "flag"
         ],
-        "operationId": "findDistributions",
+        "operationId": "createFlag",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
+            "description": "create a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createFlagRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "distribution under the segment",
+            "description": "returns the created flag",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/distribution"
-              }
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -870,50 +835,37 @@ func init() {
             }
           }
         }
-      },
-      "put": {
-        "description": "replace the distribution with the new setting",
+      }
+    },
+    "/flags/batch": {
+      "post": {
+        "description": "creates or updates the flags in a single transaction. A flag is updated if its key exists, and its segments are replaced by the definition. If any of the flags fails, nothing is saved and the per-flag errors are returned.\n",
         "tags": [
-          "distribution"
+          "flag"
         ],
-        "operationId": "putDistributions",
+        "operationId": "saveFlagsBatch",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "array of distributions",
+            "description": "the flag definitions",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putDistributionsRequest"
+              "$ref": "#/definitions/saveFlagsBatchRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "distribution under the segment",
+            "description": "returns the saved flags in the order of the request",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/distribution"
-              }
+              "$ref": "#/definitions/saveFlagsBatchResponse"
+            }
+          },
+          "400": {
+            "description": "returns the per-flag errors, nothing is saved",
+            "schema": {
+              "$ref": "#/definitions/saveFlagsBatchResponse"
             }
           },
           "default": {
@@ -925,30 +877,20 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/snapshots": {
+    "/flags/entity_types": {
       "get": {
         "tags": [
           "flag"
         ],
-        "operationId": "getFlagSnapshots",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          }
-        ],
+        "operationId": "getFlagEntityTypes",
         "responses": {
           "200": {
-            "description": "returns the flag snapshots",
+            "description": "returns all the FlagEntityTypes",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/flagSnapshot"
+                "type": "string",
+                "minLength": 1
               }
             }
           },
@@ -961,31 +903,35 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/variants": {
+    "/flags/export.csv": {
       "get": {
+        "description": "Export the flags in CSV, one row per distribution of the segments, or one row per flag if the flag has no distribution. The rows are streamed, so it doesn't load all the flags into memory.",
+        "produces": [
+          "text/csv"
+        ],
         "tags": [
-          "variant"
+          "export"
         ],
-        "operationId": "findVariants",
+        "operationId": "getExportFlagsCsv",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
+            "type": "boolean",
+            "description": "return flags having given enabled status",
+            "name": "enabled",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags having all the given tags, comma separated",
+            "name": "tags",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "variant ordered by variantID",
+            "description": "OK",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/variant"
-              }
+              "type": "file"
             }
           },
           "default": {
@@ -995,37 +941,31 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/flags/import": {
       "post": {
+        "description": "creates a new flag from the flag definition, it fails if the key already exists",
         "tags": [
-          "variant"
+          "flag"
         ],
-        "operationId": "createVariant",
+        "operationId": "importFlag",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "create a variant",
+            "description": "the flag definition to import",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/createVariantRequest"
+              "$ref": "#/definitions/importFlagRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "variant just created",
+            "description": "returns the imported flag",
             "schema": {
-              "$ref": "#/definitions/variant"
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -1037,46 +977,145 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/variants/{variantID}": {
-      "put": {
+    "/flags/stale": {
+      "get": {
+        "description": "returns the flags to clean up, the ones disabled for longer than disabledFor, the ones never evaluated since they were created longer than unevaluatedFor ago, and the enabled ones always resolving to a single variant according to their distributions",
         "tags": [
-          "variant"
+          "flag"
         ],
-        "operationId": "putVariant",
+        "operationId": "findStaleFlags",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the variant",
-            "name": "variantID",
-            "in": "path",
-            "required": true
+            "type": "string",
+            "default": "30d",
+            "description": "the flags disabled and not changed for the duration are stale, e.g. 30d or 12h",
+            "name": "disabledFor",
+            "in": "query"
           },
           {
-            "description": "update a variant",
+            "type": "string",
+            "default": "30d",
+            "description": "the flags never evaluated and created longer than the duration ago are stale, e.g. 30d or 12h",
+            "name": "unevaluatedFor",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the stale flags ordered by id",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/staleFlag"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/stream": {
+      "get": {
+        "description": "Stream the changes of the flags as server-sent events, one event per record of the flag history, i.e. the changes of the flags, their segments, constraints and distributions. The data of the event is the JSON of the flag ID and the change type, and its id is the ID of the flag history. A comment is sent periodically to keep the connection alive. The changes since Last-Event-ID are replayed first on reconnect.",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagsStream",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "the id of the last event received, the changes after it are replayed",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the stream of the flag changes",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/validate": {
+      "post": {
+        "description": "validates the flag definition like creating or updating the flag would, without saving anything",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "validateFlag",
+        "parameters": [
+          {
+            "description": "the flag definition to validate",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putVariantRequest"
+              "$ref": "#/definitions/validateFlagRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "variant just updated",
+            "description": "returns the validation errors of the flag definition, it's valid if there is none",
             "schema": {
-              "$ref": "#/definitions/variant"
+              "$ref": "#/definitions/validateFlagResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "get the flag as it's configured in the environment, i.e. its enabled state and the distributions of its segments",
+            "name": "environment",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
             }
           },
           "default": {
@@ -1087,34 +1126,65 @@ func init() {
           }
         }
       },
-      "delete": {
+      "put": {
         "tags": [
-          "variant"
+          "flag"
         ],
-        "operationId": "deleteVariant",
+        "operationId": "putFlag",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
+            "description": "numeric ID of the flag to get",
             "name": "flagID",
             "in": "path",
             "required": true
           },
+          {
+            "description": "update a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putFlagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "deleteFlag",
+        "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the variant",
-            "name": "variantID",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "OK deleted"
           },
           "default": {
             "description": "generic error response",
@@ -1125,16 +1195,40 @@ func init() {
         }
       }
     },
-    "/health": {
+    "/flags/{flagID}/bucketcheck": {
       "get": {
-        "description": "Check if Flagr is healthy",
+        "description": "simulates bucketing n synthetic entity IDs through the current distributions of every segment of the flag, and returns the observed counts of the variants versus the expected ones with the chi-square statistic, e.g. to check that an A/A test splits evenly. Nothing is evaluated or recorded for real.",
         "tags": [
-          "health"
+          "flag"
+        ],
+        "operationId": "getFlagBucketCheck",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 1000000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100000,
+            "description": "the number of synthetic entity IDs to bucket",
+            "name": "n",
+            "in": "query"
+          }
         ],
-        "operationId": "getHealth",
         "responses": {
           "200": {
-            "description": "OK"
+            "description": "the bucketing of the segments ordered by rank",
+            "schema": {
+              "$ref": "#/definitions/bucketCheck"
+            }
           },
           "default": {
             "description": "generic error response",
@@ -1144,681 +1238,612 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "constraint": {
-      "type": "object",
-      "required": [
-        "property",
-        "operator",
-        "value"
-      ],
-      "properties": {
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "operator": {
-          "type": "string",
-          "minLength": 1,
-          "enum": [
-            "EQ",
-            "NEQ",
-            "LT",
-            "LTE",
-            "GT",
-            "GTE",
-            "EREG",
-            "NEREG",
-            "IN",
-            "NOTIN",
-            "CONTAINS",
-            "NOTCONTAINS"
-          ]
-        },
-        "property": {
-          "type": "string",
-          "minLength": 1
-        },
-        "value": {
-          "type": "string",
-          "minLength": 1
-        }
-      }
     },
-    "createConstraintRequest": {
-      "type": "object",
-      "required": [
-        "property",
-        "operator",
-        "value"
-      ],
-      "properties": {
-        "operator": {
-          "type": "string",
-          "minLength": 1
-        },
-        "property": {
-          "type": "string",
-          "minLength": 1
-        },
-        "value": {
-          "type": "string",
-          "minLength": 1
+    "/flags/{flagID}/changes": {
+      "get": {
+        "description": "find the changes of the flag requested with FLAGR_APPROVAL_REQUIRED, the latest first",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "findFlagChanges",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "pending",
+              "approved"
+            ],
+            "type": "string",
+            "description": "return the changes of the status only, all of them are returned if it's not set",
+            "name": "status",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the changes of the flag",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagChange"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
       }
     },
-    "createFlagRequest": {
-      "type": "object",
-      "required": [
-        "description"
-      ],
-      "properties": {
-        "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "key": {
-          "description": "unique key representation of the flag",
-          "type": "string"
-        }
-      }
-    },
-    "createSegmentRequest": {
-      "type": "object",
-      "required": [
-        "description",
-        "rolloutPercent"
-      ],
-      "properties": {
-        "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "rolloutPercent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100
+    "/flags/{flagID}/changes/{changeID}/approve": {
+      "post": {
+        "description": "approve the pending change of the flag and apply it with a new snapshot of the flag. It has to be approved by another user than the one who requested it.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "approveFlagChange",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the change to approve",
+            "name": "changeID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the approved change",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
       }
     },
-    "createVariantRequest": {
-      "type": "object",
-      "required": [
-        "key"
-      ],
-      "properties": {
-        "attachment": {
-          "type": "object"
-        },
-        "key": {
-          "type": "string",
-          "minLength": 1
+    "/flags/{flagID}/clone": {
+      "post": {
+        "description": "deep copies the flag with its variants, segments, constraints and distributions into a new flag. The new flag is always disabled.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "cloneFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to clone",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the key of the new flag",
+            "name": "body",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/cloneFlagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the new flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
       }
     },
-    "distribution": {
-      "type": "object",
-      "required": [
-        "percent",
-        "variantID",
-        "variantKey"
-      ],
-      "properties": {
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "percent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100
-        },
-        "variantID": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1
-        },
-        "variantKey": {
-          "type": "string",
-          "minLength": 1
+    "/flags/{flagID}/enabled": {
+      "put": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "setFlagEnabled",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "set the enabled state of the flag in the environment, the flag's own enabled state is untouched",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "description": "set flag enabled state",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/setFlagEnabledRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
-      }
-    },
-    "error": {
-      "type": "object",
-      "required": [
-        "message"
-      ],
-      "properties": {
-        "message": {
-          "type": "string",
-          "minLength": 1
+      },
+      "patch": {
+        "description": "sets the enabled state of the flag in one update without the rest of the flag, so it doesn't race with the other changes of the flag",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "patchFlagEnabled",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "set the enabled state of the flag in the environment, the flag's own enabled state is untouched",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "description": "set flag enabled state",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/setFlagEnabledRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
       }
     },
-    "evalContext": {
-      "type": "object",
-      "properties": {
-        "enableDebug": {
-          "type": "boolean"
-        },
-        "entityContext": {
-          "type": "object"
-        },
-        "entityID": {
-          "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
-          "type": "string"
-        },
-        "entityType": {
-          "type": "string"
-        },
-        "flagID": {
-          "description": "flagID",
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1
-        },
-        "flagKey": {
-          "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
-          "type": "string"
-        }
-      }
-    },
-    "evalDebugLog": {
-      "type": "object",
-      "properties": {
-        "msg": {
-          "type": "string"
-        },
-        "segmentDebugLogs": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/segmentDebugLog"
+    "/flags/{flagID}/environments/{environment}/promote": {
+      "post": {
+        "description": "copies the enabled state and the distributions of the flag in the environment to another environment, e.g. from staging to prod. The flag's own config is copied if it has no config of the environment",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "promoteFlagEnvironment",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "the environment the config is copied from",
+            "name": "environment",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the environment to promote the config to",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/promoteFlagEnvironmentRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag as it's configured in the environment it's promoted to",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "evalResult": {
-      "type": "object",
-      "properties": {
-        "evalContext": {
-          "$ref": "#/definitions/evalContext"
-        },
-        "evalDebugLog": {
-          "$ref": "#/definitions/evalDebugLog"
-        },
-        "flagID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "flagKey": {
-          "type": "string"
-        },
-        "flagSnapshotID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "segmentID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "timestamp": {
-          "type": "string"
-        },
-        "variantAttachment": {
-          "type": "object"
-        },
-        "variantID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "variantKey": {
-          "type": "string"
-        }
-      }
-    },
-    "evaluationBatchRequest": {
-      "type": "object",
-      "required": [
-        "entities"
-      ],
-      "properties": {
-        "enableDebug": {
-          "type": "boolean"
-        },
-        "entities": {
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "$ref": "#/definitions/evaluationEntity"
-          }
-        },
-        "flagIDs": {
-          "description": "flagIDs",
-          "type": "array",
-          "minItems": 1,
-          "items": {
+    "/flags/{flagID}/exclusion_group": {
+      "put": {
+        "description": "adds the flag to the exclusion group with its percent of the group's buckets, or removes it from its group if exclusionGroupID is 0",
+        "tags": [
+          "exclusionGroup"
+        ],
+        "operationId": "putFlagExclusionGroup",
+        "parameters": [
+          {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "minimum": 1
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the exclusion group of the flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putFlagExclusionGroupRequest"
+            }
           }
-        },
-        "flagKeys": {
-          "description": "flagKeys. Either flagIDs or flagKeys works. If pass in both, Flagr may return duplicate results.",
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "type": "string",
-            "minLength": 1
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "evaluationBatchResponse": {
-      "type": "object",
-      "required": [
-        "evaluationResults"
-      ],
-      "properties": {
-        "evaluationResults": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/evalResult"
+    "/flags/{flagID}/export": {
+      "get": {
+        "description": "exports the self-contained definition of the flag, it can be imported into another environment",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "exportFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to export",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag definition",
+            "schema": {
+              "$ref": "#/definitions/flagDefinition"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "evaluationEntity": {
-      "type": "object",
-      "properties": {
-        "entityContext": {
-          "type": "object"
-        },
-        "entityID": {
-          "type": "string"
-        },
-        "entityType": {
-          "type": "string"
+    "/flags/{flagID}/history": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagHistory",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "the numbers of history records to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "return history records given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the audit history of the flag, the latest first",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagHistory"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
       }
     },
-    "flag": {
-      "type": "object",
-      "required": [
-        "description",
-        "enabled",
-        "dataRecordsEnabled"
-      ],
-      "properties": {
-        "createdBy": {
-          "type": "string"
-        },
-        "dataRecordsEnabled": {
-          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
-          "type": "boolean"
-        },
-        "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "enabled": {
-          "type": "boolean"
-        },
-        "entityType": {
-          "description": "it will override the entityType in the evaluation logs if it's not empty",
-          "type": "string"
-        },
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "key": {
-          "description": "unique key representation of the flag",
-          "type": "string",
-          "minLength": 1
-        },
-        "notes": {
-          "description": "flag usage details in markdown format",
-          "type": "string"
-        },
-        "segments": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/segment"
+    "/flags/{flagID}/override": {
+      "put": {
+        "description": "forces the flag to the variant for every entity while the override is enabled, ignoring the segments. The override is cleared if variantID is 0. It takes effect on the next refresh of the evaluation cache.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "putFlagOverride",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the override of the flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putFlagOverrideRequest"
+            }
           }
-        },
-        "updatedAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "updatedBy": {
-          "type": "string"
-        },
-        "variants": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/variant"
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "flagSnapshot": {
-      "type": "object",
-      "required": [
-        "id",
-        "flag",
-        "updatedAt"
-      ],
-      "properties": {
-        "flag": {
-          "$ref": "#/definitions/flag"
-        },
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1
-        },
-        "updatedAt": {
-          "type": "string",
-          "minLength": 1
-        },
-        "updatedBy": {
-          "type": "string"
-        }
-      }
-    },
-    "putDistributionsRequest": {
-      "type": "object",
-      "required": [
-        "distributions"
-      ],
-      "properties": {
-        "distributions": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/distribution"
+    "/flags/{flagID}/restore": {
+      "put": {
+        "description": "restores a soft deleted flag together with its segments and variants, only for the admins",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "restoreFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to restore",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the restored flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "putFlagRequest": {
-      "type": "object",
-      "properties": {
-        "dataRecordsEnabled": {
-          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
-          "type": "boolean",
-          "x-nullable": true
-        },
-        "description": {
-          "type": "string",
-          "minLength": 1,
-          "x-nullable": true
-        },
-        "enabled": {
-          "type": "boolean",
-          "x-nullable": true
-        },
-        "entityType": {
-          "description": "it will overwrite entityType into evaluation logs if it's not empty",
-          "type": "string",
-          "x-nullable": true
-        },
-        "key": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "notes": {
-          "type": "string",
-          "x-nullable": true
-        }
-      }
-    },
-    "putSegmentReorderRequest": {
-      "type": "object",
-      "required": [
-        "segmentIDs"
-      ],
-      "properties": {
-        "segmentIDs": {
-          "type": "array",
-          "minItems": 1,
-          "items": {
+    "/flags/{flagID}/restore/{snapshotID}": {
+      "put": {
+        "description": "applies a prior snapshot of the flag back onto the live flag, including its variants, segments, constraints and distributions. The state before the restore is kept as a new snapshot.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "restoreFlagSnapshot",
+        "parameters": [
+          {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "minimum": 1
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the snapshot to restore",
+            "name": "snapshotID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag after the restore",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "putSegmentRequest": {
-      "type": "object",
-      "required": [
-        "description",
-        "rolloutPercent"
-      ],
-      "properties": {
-        "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "rolloutPercent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100
+    "/flags/{flagID}/schedule": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "findFlagSchedules",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the pending schedules of the flag, the earliest first",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagSchedule"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
-      }
-    },
-    "putVariantRequest": {
-      "type": "object",
-      "required": [
-        "key"
-      ],
-      "properties": {
-        "attachment": {
-          "type": "object"
-        },
-        "key": {
-          "type": "string",
-          "minLength": 1
-        }
-      }
-    },
-    "segment": {
-      "type": "object",
-      "required": [
-        "description",
-        "rank",
-        "rolloutPercent"
-      ],
-      "properties": {
-        "constraints": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/constraint"
-          }
-        },
-        "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "distributions": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/distribution"
-          }
-        },
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "rank": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "rolloutPercent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100
-        }
-      }
-    },
-    "segmentDebugLog": {
-      "type": "object",
-      "properties": {
-        "msg": {
-          "type": "string"
-        },
-        "segmentID": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1
-        }
-      }
-    },
-    "setFlagEnabledRequest": {
-      "type": "object",
-      "required": [
-        "enabled"
-      ],
-      "properties": {
-        "enabled": {
-          "type": "boolean"
-        }
-      }
-    },
-    "variant": {
-      "type": "object",
-      "required": [
-        "key"
-      ],
-      "properties": {
-        "attachment": {
-          "type": "object"
-        },
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "key": {
-          "type": "string",
-          "minLength": 1
-        }
-      }
-    }
-  },
-  "tags": [
-    {
-      "description": "Everything about the flag",
-      "name": "flag"
-    },
-    {
-      "description": "Segment defines the audience of the flag, it's the user segmentation",
-      "name": "segment"
-    },
-    {
-      "description": "Constraint is the unit of defining a small subset of users",
-      "name": "constraint"
-    },
-    {
-      "description": "Distribution is the percent distribution of variants within that segment",
-      "name": "distribution"
-    },
-    {
-      "description": "Variants are the possible outcomes of flag evaluation",
-      "name": "variant"
-    },
-    {
-      "description": "Evaluation is the process of evaluating a flag given the entity context",
-      "name": "evaluation"
-    },
-    {
-      "description": "Check if Flagr is healthy",
-      "name": "health"
-    }
-  ],
-  "x-tagGroups": [
-    {
-      "name": "Flag Management",
-      "tags": [
-        "flag",
-        "segment",
-        "constraint",
-        "distribution",
-        "variant"
-      ]
-    },
-    {
-      "name": "Flag Evaluation",
-      "tags": [
-        "evaluation"
-      ]
-    },
-    {
-      "name": "Health Check",
-      "tags": [
-        "health"
-      ]
-    },
-    {
-      "name": "Export",
-      "tags": [
-        "export"
-      ]
-    }
-  ]
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "Flagr is a feature flagging, A/B testing and dynamic configuration microservice. The base path for all the APIs is \"/api/v1\".\n",
-    "title": "Flagr",
-    "version": "1.1.0"
-  },
-  "basePath": "/api/v1",
-  "paths": {
-    "/evaluation": {
+      },
       "post": {
+        "description": "schedules the flag to be enabled or disabled at the given time. The schedule is removed once it's applied, and the schedules missed while flagr was down are applied on startup.",
         "tags": [
-          "evaluation"
+          "flag"
         ],
-        "operationId": "postEvaluation",
+        "operationId": "createFlagSchedule",
         "parameters": [
           {
-            "description": "evalution context",
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the action and the time to apply it",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/evalContext"
+              "$ref": "#/definitions/createFlagScheduleRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "evaluation result",
+            "description": "returns the created schedule",
             "schema": {
-              "$ref": "#/definitions/evalResult"
+              "$ref": "#/definitions/flagSchedule"
             }
           },
           "default": {
@@ -1830,28 +1855,71 @@ func init() {
         }
       }
     },
-    "/evaluation/batch": {
+    "/flags/{flagID}/segments": {
+      "get": {
+        "tags": [
+          "segment"
+        ],
+        "operationId": "findSegments",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "segments ordered by rank of the flag",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/segment"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
       "post": {
         "tags": [
-          "evaluation"
+          "segment"
         ],
-        "operationId": "postEvaluationBatch",
+        "operationId": "createSegment",
         "parameters": [
           {
-            "description": "evalution batch request",
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a segment under a flag",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/evaluationBatchRequest"
+              "$ref": "#/definitions/createSegmentRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "evaluation batch result",
+            "description": "segment created",
             "schema": {
-              "$ref": "#/definitions/evaluationBatchResponse"
+              "$ref": "#/definitions/segment"
             }
           },
           "default": {
@@ -1863,48 +1931,36 @@ func init() {
         }
       }
     },
-    "/export/eval_cache/json": {
-      "get": {
-        "description": "Export JSON format of the eval cache dump",
-        "produces": [
-          "application/json"
-        ],
+    "/flags/{flagID}/segments/reorder": {
+      "put": {
+        "description": "reorder the segments in one transaction, the segmentIDs should be exactly the segments of the flag",
         "tags": [
-          "export"
+          "segment"
         ],
-        "operationId": "getExportEvalCacheJSON",
-        "responses": {
-          "200": {
-            "description": "OK",
-            "schema": {
-              "type": "object"
-            }
+        "operationId": "putSegmentsReorder",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
           },
-          "default": {
-            "description": "generic error response",
+          {
+            "description": "reorder segments",
+            "name": "body",
+            "in": "body",
+            "required": true,
             "schema": {
-              "$ref": "#/definitions/error"
+              "$ref": "#/definitions/putSegmentReorderRequest"
             }
           }
-        }
-      }
-    },
-    "/export/sqlite": {
-      "get": {
-        "description": "Export sqlite3 format of the db dump, which is converted from the main database.",
-        "produces": [
-          "application/octet-stream"
-        ],
-        "tags": [
-          "export"
         ],
-        "operationId": "getExportSQLite",
         "responses": {
           "200": {
-            "description": "OK",
-            "schema": {
-              "type": "file"
-            }
+            "description": "segments reordered"
           },
           "default": {
             "description": "generic error response",
@@ -1915,66 +1971,46 @@ func init() {
         }
       }
     },
-    "/flags": {
-      "get": {
+    "/flags/{flagID}/segments/{segmentID}": {
+      "put": {
         "tags": [
-          "flag"
+          "segment"
         ],
-        "operationId": "findFlags",
+        "operationId": "putSegment",
         "parameters": [
           {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "the numbers of flags to return",
-            "name": "limit",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "description": "return flags having given enabled status",
-            "name": "enabled",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "return flags exactly matching given description",
-            "name": "description",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "return flags partially matching given description",
-            "name": "description_like",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "return flags matching given key",
-            "name": "key",
-            "in": "query"
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
           },
           {
+            "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "return flags given the offset, it should usually set together with limit",
-            "name": "offset",
-            "in": "query"
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
           },
           {
-            "type": "boolean",
-            "description": "return flags with preloaded segments and variants",
-            "name": "preload",
-            "in": "query"
+            "description": "update a segment",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putSegmentRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "list all the flags",
+            "description": "segment updated",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/flag"
-              }
+              "$ref": "#/definitions/segment"
             }
           },
           "default": {
@@ -1985,28 +2021,34 @@ func init() {
           }
         }
       },
-      "post": {
+      "delete": {
         "tags": [
-          "flag"
+          "segment"
         ],
-        "operationId": "createFlag",
+        "operationId": "deleteSegment",
         "parameters": [
           {
-            "description": "create a flag",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createFlagRequest"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the created flag",
-            "schema": {
-              "$ref": "#/definitions/flag"
-            }
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -2017,20 +2059,39 @@ func init() {
         }
       }
     },
-    "/flags/entity_types": {
+    "/flags/{flagID}/segments/{segmentID}/constraints": {
       "get": {
         "tags": [
-          "flag"
+          "constraint"
+        ],
+        "operationId": "findConstraints",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          }
         ],
-        "operationId": "getFlagEntityTypes",
         "responses": {
           "200": {
-            "description": "returns all the FlagEntityTypes",
+            "description": "constraints under the segment",
             "schema": {
               "type": "array",
               "items": {
-                "type": "string",
-                "minLength": 1
+                "$ref": "#/definitions/constraint"
               }
             }
           },
@@ -2041,30 +2102,46 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}": {
-      "get": {
+      },
+      "post": {
         "tags": [
-          "flag"
+          "constraint"
         ],
-        "operationId": "getFlag",
+        "operationId": "createConstraint",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a constraint",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createConstraintRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
+            "description": "the constraint created",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "$ref": "#/definitions/constraint"
             }
           },
           "default": {
@@ -2074,37 +2151,57 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}": {
       "put": {
         "tags": [
-          "flag"
+          "constraint"
         ],
-        "operationId": "putFlag",
+        "operationId": "putConstraint",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "description": "update a flag",
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint",
+            "name": "constraintID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a constraint",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putFlagRequest"
+              "$ref": "#/definitions/createConstraintRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
+            "description": "constraint just updated",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "$ref": "#/definitions/constraint"
             }
           },
           "default": {
@@ -2117,9 +2214,9 @@ func init() {
       },
       "delete": {
         "tags": [
-          "flag"
+          "constraint"
         ],
-        "operationId": "deleteFlag",
+        "operationId": "deleteConstraint",
         "parameters": [
           {
             "minimum": 1,
@@ -2129,11 +2226,29 @@ func init() {
             "name": "flagID",
             "in": "path",
             "required": true
-          }
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint",
+            "name": "constraintID",
+            "in": "path",
+            "required": true
+          }
         ],
         "responses": {
           "200": {
-            "description": "OK deleted"
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -2144,37 +2259,46 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/enabled": {
-      "put": {
+    "/flags/{flagID}/segments/{segmentID}/distributions": {
+      "get": {
         "tags": [
-          "flag"
+          "distribution"
         ],
-        "operationId": "setFlagEnabled",
+        "operationId": "findDistributions",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "description": "set flag enabled state",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/setFlagEnabledRequest"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "get the distributions of the segment in the environment, they're the segment's own if it has none in the environment",
+            "name": "environment",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag",
+            "description": "distribution under the segment",
             "schema": {
-              "$ref": "#/definitions/flag"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/distribution"
+              }
             }
           },
           "default": {
@@ -2184,35 +2308,64 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/segments": {
-      "get": {
+      },
+      "put": {
+        "description": "replace the distribution with the new setting",
         "tags": [
-          "segment"
+          "distribution"
         ],
-        "operationId": "findSegments",
+        "operationId": "putDistributions",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "replace the distributions of the segment in the environment, the segment's own distributions are untouched",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "description": "array of distributions",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putDistributionsRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "segments ordered by rank of the flag",
+            "description": "distribution under the segment",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/segment"
+                "$ref": "#/definitions/distribution"
               }
             }
           },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
           "default": {
             "description": "generic error response",
             "schema": {
@@ -2220,37 +2373,58 @@ func init() {
             }
           }
         }
-      },
-      "post": {
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}/distributions/rebalance": {
+      "put": {
+        "description": "replace the distribution by splitting 100 percent evenly across the variants, the remainder goes to the first variants in the given order",
         "tags": [
-          "segment"
+          "distribution"
         ],
-        "operationId": "createSegment",
+        "operationId": "rebalanceDistributions",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
+            "description": "numeric ID of the flag",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
-            "description": "create a segment under a flag",
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "variants to split the distribution across",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/createSegmentRequest"
+              "$ref": "#/definitions/rebalanceDistributionsRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "segment created",
+            "description": "distribution under the segment",
             "schema": {
-              "$ref": "#/definitions/segment"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/distribution"
+              }
+            }
+          },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
             }
           },
           "default": {
@@ -2262,12 +2436,13 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/reorder": {
-      "put": {
+    "/flags/{flagID}/simulate": {
+      "post": {
+        "description": "dry-runs the proposed distributions of a segment, and returns how the sample of the entity IDs would be bucketed into the variants by the current distributions and the proposed ones, with the entity IDs that would flip. It's all bucketed in memory the same way as the evaluation does, and nothing is saved or recorded.",
         "tags": [
-          "segment"
+          "flag"
         ],
-        "operationId": "putSegmentsReorder",
+        "operationId": "simulateFlagRollout",
         "parameters": [
           {
             "minimum": 1,
@@ -2279,18 +2454,21 @@ func init() {
             "required": true
           },
           {
-            "description": "reorder segments",
+            "description": "the proposed distributions of the segment and the entity IDs to simulate",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putSegmentReorderRequest"
+              "$ref": "#/definitions/simulateRolloutRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "segments reordered"
+            "description": "the projected counts of the variants and the entity IDs that would flip",
+            "schema": {
+              "$ref": "#/definitions/rolloutSimulation"
+            }
           },
           "default": {
             "description": "generic error response",
@@ -2301,46 +2479,68 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/{segmentID}": {
-      "put": {
+    "/flags/{flagID}/snapshots": {
+      "get": {
         "tags": [
-          "segment"
+          "flag"
         ],
-        "operationId": "putSegment",
+        "operationId": "getFlagSnapshots",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
+            "description": "numeric ID of the flag to get",
             "name": "flagID",
             "in": "path",
             "required": true
           },
           {
+            "maximum": 1000,
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
+            "description": "the numbers of snapshots to return, all of them are returned if it's not set",
+            "name": "limit",
+            "in": "query"
           },
           {
-            "description": "update a segment",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/putSegmentRequest"
-            }
+            "type": "integer",
+            "format": "int64",
+            "description": "return snapshots given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "return snapshots created at or after the time",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "return snapshots created before the time",
+            "name": "to",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "segment updated",
+            "description": "returns the flag snapshots, the latest first",
             "schema": {
-              "$ref": "#/definitions/segment"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagSnapshot"
+              }
+            },
+            "headers": {
+              "X-Total-Count": {
+                "type": "integer",
+                "format": "int64",
+                "description": "the total number of snapshots in the date range, regardless of limit and offset"
+              }
             }
           },
           "default": {
@@ -2350,12 +2550,15 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/flags/{flagID}/snapshots/diff": {
+      "get": {
+        "description": "returns the changes to get from the flag in the snapshot ` + "`" + `from` + "`" + ` to the one in the snapshot ` + "`" + `to` + "`" + `. Either of them can be the older one, swapping them reverses the diff. The variants, segments, constraints and distributions are matched by ID and sorted by ID, the unchanged ones are left out.",
         "tags": [
-          "segment"
+          "flag"
         ],
-        "operationId": "deleteSegment",
+        "operationId": "getFlagSnapshotsDiff",
         "parameters": [
           {
             "minimum": 1,
@@ -2370,15 +2573,27 @@ func init() {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
+            "description": "numeric ID of the snapshot to diff from",
+            "name": "from",
+            "in": "query",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the snapshot to diff to",
+            "name": "to",
+            "in": "query",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "returns the diff between the snapshots",
+            "schema": {
+              "$ref": "#/definitions/flagSnapshotDiff"
+            }
           },
           "default": {
             "description": "generic error response",
@@ -2389,12 +2604,12 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/{segmentID}/constraints": {
+    "/flags/{flagID}/tags": {
       "get": {
         "tags": [
-          "constraint"
+          "tag"
         ],
-        "operationId": "findConstraints",
+        "operationId": "findTags",
         "parameters": [
           {
             "minimum": 1,
@@ -2404,24 +2619,15 @@ func init() {
             "name": "flagID",
             "in": "path",
             "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "constraints under the segment",
+            "description": "tags ordered by value",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/constraint"
+                "$ref": "#/definitions/tag"
               }
             }
           },
@@ -2434,10 +2640,11 @@ func init() {
         }
       },
       "post": {
+        "description": "adds the tag to the flag, the tag is created if it doesn't exist",
         "tags": [
-          "constraint"
+          "tag"
         ],
-        "operationId": "createConstraint",
+        "operationId": "createTag",
         "parameters": [
           {
             "minimum": 1,
@@ -2449,29 +2656,20 @@ func init() {
             "required": true
           },
           {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "create a constraint",
+            "description": "create a tag",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/createConstraintRequest"
+              "$ref": "#/definitions/createTagRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "the constraint created",
+            "description": "tag just added to the flag",
             "schema": {
-              "$ref": "#/definitions/constraint"
+              "$ref": "#/definitions/tag"
             }
           },
           "default": {
@@ -2483,12 +2681,13 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}": {
-      "put": {
+    "/flags/{flagID}/tags/{tagID}": {
+      "delete": {
+        "description": "removes the tag from the flag, the tag itself is kept for the other flags",
         "tags": [
-          "constraint"
+          "tag"
         ],
-        "operationId": "putConstraint",
+        "operationId": "deleteTag",
         "parameters": [
           {
             "minimum": 1,
@@ -2503,35 +2702,50 @@ func init() {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the constraint",
-            "name": "constraintID",
+            "description": "numeric ID of the tag",
+            "name": "tagID",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "create a constraint",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createConstraintRequest"
-            }
           }
         ],
         "responses": {
           "200": {
-            "description": "constraint just updated",
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
             "schema": {
-              "$ref": "#/definitions/constraint"
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/variants": {
+      "get": {
+        "tags": [
+          "variant"
+        ],
+        "operationId": "findVariants",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant ordered by variantID",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/variant"
+              }
             }
           },
           "default": {
@@ -2542,11 +2756,11 @@ func init() {
           }
         }
       },
-      "delete": {
+      "post": {
         "tags": [
-          "constraint"
+          "variant"
         ],
-        "operationId": "deleteConstraint",
+        "operationId": "createVariant",
         "parameters": [
           {
             "minimum": 1,
@@ -2557,12 +2771,45 @@ func init() {
             "in": "path",
             "required": true
           },
+          {
+            "description": "create a variant",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createVariantRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant just created",
+            "schema": {
+              "$ref": "#/definitions/variant"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/variants/{variantID}": {
+      "put": {
+        "tags": [
+          "variant"
+        ],
+        "operationId": "putVariant",
+        "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
             "in": "path",
             "required": true
           },
@@ -2570,15 +2817,27 @@ func init() {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the constraint",
-            "name": "constraintID",
+            "description": "numeric ID of the variant",
+            "name": "variantID",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "update a variant",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putVariantRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "deleted"
+            "description": "variant just updated",
+            "schema": {
+              "$ref": "#/definitions/variant"
+            }
           },
           "default": {
             "description": "generic error response",
@@ -2587,14 +2846,12 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/segments/{segmentID}/distributions": {
-      "get": {
+      },
+      "delete": {
         "tags": [
-          "distribution"
+          "variant"
         ],
-        "operationId": "findDistributions",
+        "operationId": "deleteVariant",
         "parameters": [
           {
             "minimum": 1,
@@ -2609,19 +2866,87 @@ func init() {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
+            "description": "numeric ID of the variant",
+            "name": "variantID",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "distribution under the segment",
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/health": {
+      "get": {
+        "description": "Check if Flagr is healthy",
+        "tags": [
+          "health"
+        ],
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/ready": {
+      "get": {
+        "description": "Check if Flagr is ready to serve the evaluations, it's not until all the flags are loaded into the evaluation cache. A ready Flagr can still report warnings, e.g. the backlog of the data records",
+        "tags": [
+          "health"
+        ],
+        "operationId": "getReady",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/readiness"
+            }
+          },
+          "503": {
+            "description": "the evaluation cache is not warmed up yet",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/segment_templates": {
+      "get": {
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "findSegmentTemplates",
+        "responses": {
+          "200": {
+            "description": "all the segment templates ordered by key",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/distribution"
+                "$ref": "#/definitions/segmentTemplate"
               }
             }
           },
@@ -2633,49 +2958,101 @@ func init() {
           }
         }
       },
-      "put": {
-        "description": "replace the distribution with the new setting",
+      "post": {
         "tags": [
-          "distribution"
+          "segmentTemplate"
         ],
-        "operationId": "putDistributions",
+        "operationId": "createSegmentTemplate",
+        "parameters": [
+          {
+            "description": "create a segment template",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createSegmentTemplateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created segment template",
+            "schema": {
+              "$ref": "#/definitions/segmentTemplate"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/segment_templates/{segmentTemplateID}": {
+      "get": {
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "getSegmentTemplate",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
             "in": "path",
             "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the segment template",
+            "schema": {
+              "$ref": "#/definitions/segmentTemplate"
+            }
           },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "replaces the description and the segment of the segment template, the segments already created from it are not changed",
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "putSegmentTemplate",
+        "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the segment",
-            "name": "segmentID",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
             "in": "path",
             "required": true
           },
           {
-            "description": "array of distributions",
+            "description": "update a segment template",
             "name": "body",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/putDistributionsRequest"
+              "$ref": "#/definitions/putSegmentTemplateRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "distribution under the segment",
+            "description": "returns the segment template",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/distribution"
-              }
+              "$ref": "#/definitions/segmentTemplate"
             }
           },
           "default": {
@@ -2685,34 +3062,26 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/flags/{flagID}/snapshots": {
-      "get": {
+      },
+      "delete": {
         "tags": [
-          "flag"
+          "segmentTemplate"
         ],
-        "operationId": "getFlagSnapshots",
+        "operationId": "deleteSegmentTemplate",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
             "format": "int64",
-            "description": "numeric ID of the flag to get",
-            "name": "flagID",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "returns the flag snapshots",
-            "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/flagSnapshot"
-              }
-            }
+            "description": "deleted"
           },
           "default": {
             "description": "generic error response",
@@ -2723,279 +3092,7594 @@ func init() {
         }
       }
     },
-    "/flags/{flagID}/variants": {
-      "get": {
-        "tags": [
-          "variant"
-        ],
-        "operationId": "findVariants",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "variant ordered by variantID",
-            "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/variant"
-              }
-            }
-          },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
+    "/segment_templates/{segmentTemplateID}/apply": {
+      "post": {
+        "description": "creates a segment of the flag from the segment template, the segment is appended after the existing segments of the flag",
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "applySegmentTemplate",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the flag to create the segment on and its variants referenced by the distributions",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/applySegmentTemplateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created segment",
+            "schema": {
+              "$ref": "#/definitions/segment"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/tags": {
+      "get": {
+        "tags": [
+          "tag"
+        ],
+        "operationId": "findAllTags",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "the numbers of tags to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "return tags given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return tags partially matching given value",
+            "name": "value_like",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "all the tags ordered by value",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/tag"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/tags/bulk": {
+      "post": {
+        "description": "adds a tag to flags, removes a tag from flags or renames a tag, in one transaction. Renaming a tag to an existing one merges them.",
+        "tags": [
+          "tag"
+        ],
+        "operationId": "bulkTags",
+        "parameters": [
+          {
+            "description": "the tag operations, they're applied in order",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/bulkTagsRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the number of flags affected by each operation",
+            "schema": {
+              "$ref": "#/definitions/bulkTagsResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "applySegmentTemplateRequest": {
+      "type": "object",
+      "required": [
+        "flagID"
+      ],
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variants": {
+          "description": "the variants of the flag filling in the variant keys of the template, the keys left out are filled in by the variants of the flag with the same keys",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentTemplateVariant"
+          }
+        }
+      }
+    },
+    "bucketCheck": {
+      "type": "object",
+      "required": [
+        "flagID",
+        "n",
+        "segments"
+      ],
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "n": {
+          "description": "the number of synthetic entity IDs bucketed",
+          "type": "integer",
+          "format": "int64"
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentBucketCheck"
+          }
+        }
+      }
+    },
+    "bulkTagOperation": {
+      "type": "object",
+      "required": [
+        "op",
+        "value"
+      ],
+      "properties": {
+        "flagIDs": {
+          "description": "the flags to add the tag to or remove it from",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        },
+        "newValue": {
+          "description": "the new value of the renamed tag, the tags are merged if a tag of newValue exists",
+          "type": "string"
+        },
+        "op": {
+          "description": "add or remove the tag of value to or from the flags of flagIDs, or rename the tag of value to newValue",
+          "type": "string",
+          "enum": [
+            "add",
+            "remove",
+            "rename"
+          ]
+        },
+        "value": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "bulkTagOperationResult": {
+      "type": "object",
+      "required": [
+        "op",
+        "value",
+        "affectedFlags"
+      ],
+      "properties": {
+        "affectedFlags": {
+          "description": "the number of flags whose tags are changed by the operation",
+          "type": "integer",
+          "format": "int64"
+        },
+        "op": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "bulkTagsRequest": {
+      "type": "object",
+      "required": [
+        "operations"
+      ],
+      "properties": {
+        "operations": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/bulkTagOperation"
+          }
+        }
+      }
+    },
+    "bulkTagsResponse": {
+      "type": "object",
+      "required": [
+        "results"
+      ],
+      "properties": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/bulkTagOperationResult"
+          }
+        }
+      }
+    },
+    "cloneFlagRequest": {
+      "type": "object",
+      "properties": {
+        "key": {
+          "description": "the key of the new flag, it defaults to the key of the flag suffixed with _clone",
+          "type": "string"
+        }
+      }
+    },
+    "constraint": {
+      "type": "object",
+      "required": [
+        "property",
+        "operator"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "operator": {
+          "type": "string",
+          "minLength": 1,
+          "enum": [
+            "EQ",
+            "NEQ",
+            "LT",
+            "LTE",
+            "GT",
+            "GTE",
+            "EREG",
+            "NEREG",
+            "IN",
+            "NOTIN",
+            "CONTAINS",
+            "NOTCONTAINS",
+            "BEFORE",
+            "AFTER",
+            "BETWEEN",
+            "ARRAY_CONTAINS_ANY",
+            "ARRAY_CONTAINS_ALL",
+            "EXISTS",
+            "NOT_EXISTS",
+            "NUMBER_BETWEEN"
+          ]
+        },
+        "property": {
+          "description": "the key of the entityContext, or a path of keys separated by dots into the objects of the entityContext, e.g. device.os",
+          "type": "string",
+          "minLength": 1
+        },
+        "value": {
+          "description": "the value to compare the property with, it's required by all the operators but EXISTS and NOT_EXISTS, which ignore it",
+          "type": "string",
+          "x-nullable": true
+        }
+      }
+    },
+    "constraintDebugLog": {
+      "type": "object",
+      "properties": {
+        "constraintID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "matched": {
+          "type": "boolean"
+        },
+        "msg": {
+          "type": "string"
+        },
+        "operator": {
+          "type": "string"
+        },
+        "property": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "constraintEvaluationResult": {
+      "type": "object",
+      "properties": {
+        "entityContext": {
+          "type": "object"
+        },
+        "error": {
+          "description": "the error of parsing the constraint or evaluating it against the entity context, the constraint doesn't pass if it's not empty",
+          "type": "string"
+        },
+        "passed": {
+          "type": "boolean"
+        }
+      }
+    },
+    "constraintGroup": {
+      "type": "object",
+      "required": [
+        "key",
+        "constraints"
+      ],
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraint"
+          }
+        },
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the constraint group",
+          "type": "string",
+          "minLength": 1
+        },
+        "namespace": {
+          "description": "the namespace of the constraint group, it's the namespace of the creator if the namespaces are enabled by FLAGR_JWT_AUTH_NAMESPACE_CLAIM",
+          "type": "string",
+          "readOnly": true
+        },
+        "segmentIDs": {
+          "description": "the segments referencing the constraint group",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "createConstraintGroupRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createConstraintRequest"
+          }
+        },
+        "description": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "createConstraintRequest": {
+      "type": "object",
+      "required": [
+        "property",
+        "operator"
+      ],
+      "properties": {
+        "operator": {
+          "type": "string",
+          "minLength": 1
+        },
+        "property": {
+          "description": "the key of the entityContext, or a path of keys separated by dots into the objects of the entityContext, e.g. device.os",
+          "type": "string",
+          "minLength": 1
+        },
+        "value": {
+          "description": "the value to compare the property with, it's required by all the operators but EXISTS and NOT_EXISTS, which ignore it",
+          "type": "string",
+          "x-nullable": true
+        }
+      }
+    },
+    "createExclusionGroupRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "createFlagRequest": {
+      "type": "object",
+      "required": [
+        "description"
+      ],
+      "properties": {
+        "dataRecordsEnabled": {
+          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka. It's disabled if not provided",
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "expiresAt": {
+          "description": "the flag is disabled automatically once it expires",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "key": {
+          "description": "unique key representation of the flag, it's generated if not provided. The key should match ^[a-z]+[a-z0-9_-]*$, and a used key is rejected with 409",
+          "type": "string"
+        }
+      }
+    },
+    "createFlagScheduleRequest": {
+      "type": "object",
+      "required": [
+        "action",
+        "scheduledAt"
+      ],
+      "properties": {
+        "action": {
+          "type": "string",
+          "enum": [
+            "enable",
+            "disable"
+          ]
+        },
+        "scheduledAt": {
+          "description": "RFC3339 time to apply the action, it has to be in the future",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "createSegmentRequest": {
+      "type": "object",
+      "required": [
+        "description",
+        "rolloutPercent"
+      ],
+      "properties": {
+        "constraintGroupID": {
+          "description": "the constraint group evaluated together with the constraints of the segment, there's none if it's 0",
+          "type": "integer",
+          "format": "int64"
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "createSegmentTemplateRequest": {
+      "type": "object",
+      "required": [
+        "key",
+        "segment"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        },
+        "segment": {
+          "description": "the distributions reference the variants by key, the keys are filled in when the template is applied",
+          "$ref": "#/definitions/segmentDefinition"
+        }
+      }
+    },
+    "createTagRequest": {
+      "type": "object",
+      "required": [
+        "value"
+      ],
+      "properties": {
+        "value": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "createVariantRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "attachment": {
+          "type": "object"
+        },
+        "attachmentTypes": {
+          "description": "the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string",
+            "enum": [
+              "string",
+              "number",
+              "integer",
+              "boolean",
+              "json"
+            ]
+          }
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "distribution": {
+      "type": "object",
+      "required": [
+        "percent",
+        "variantID",
+        "variantKey"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "percent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        },
+        "rolloutSchedule": {
+          "$ref": "#/definitions/distributionRolloutSchedule"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variantKey": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "distributionDefinition": {
+      "type": "object",
+      "required": [
+        "variantKey",
+        "percent"
+      ],
+      "properties": {
+        "percent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        },
+        "rolloutSchedule": {
+          "$ref": "#/definitions/distributionRolloutSchedule"
+        },
+        "variantKey": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "distributionRolloutSchedule": {
+      "description": "it ramps the percent of the distribution from startPercent to endPercent between startAt and endAt, and the other distributions of the segment share the rest by their percents. The entities already in the distribution stay in it as the percent increases. At most one distribution of a segment can have a rollout schedule",
+      "type": "object",
+      "required": [
+        "startAt",
+        "endAt",
+        "startPercent",
+        "endPercent"
+      ],
+      "properties": {
+        "endAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "endPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        },
+        "startAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "startPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "error": {
+      "type": "object",
+      "required": [
+        "message"
+      ],
+      "properties": {
+        "errorCode": {
+          "description": "the machine-readable code of the evaluation errors, it's empty for the other errors. INVALID_REQUEST is the request that can't be evaluated, EVAL_DEBUG_DISABLED is the explanation with the debugging disabled, ENRICHMENT_FAILED is the failed enrichment of the entityContext, RATE_LIMITED is the throttled frontend events, and EVAL_CACHE_REFRESH_FAILED is the failed refresh of the evaluation cache",
+          "type": "string",
+          "enum": [
+            "INVALID_REQUEST",
+            "EVAL_DEBUG_DISABLED",
+            "ENRICHMENT_FAILED",
+            "RATE_LIMITED",
+            "EVAL_CACHE_REFRESH_FAILED"
+          ]
+        },
+        "message": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "evalCacheRefreshResponse": {
+      "type": "object",
+      "required": [
+        "flagsCount",
+        "durationMillis"
+      ],
+      "properties": {
+        "durationMillis": {
+          "description": "the duration of loading the flags in milliseconds",
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagsCount": {
+          "description": "the number of flags loaded into the evaluation cache",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "evalContext": {
+      "type": "object",
+      "properties": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entityContext": {
+          "type": "object"
+        },
+        "entityID": {
+          "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
+          "type": "string"
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "environment": {
+          "description": "the environment whose config of the flag is evaluated, e.g. prod. It falls back to the X-Flagr-Environment header, and the flag's own config is evaluated without it or if the flag has no config of the environment",
+          "type": "string"
+        },
+        "flagID": {
+          "description": "flagID",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "flagKey": {
+          "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
+          "type": "string"
+        },
+        "includeAllMatches": {
+          "description": "returns all the segments whose constraints passed in matchedSegments of the result, the variant is still from the first one",
+          "type": "boolean"
+        }
+      }
+    },
+    "evalDebugLog": {
+      "type": "object",
+      "properties": {
+        "exclusionGroupDebugLog": {
+          "$ref": "#/definitions/exclusionGroupDebugLog"
+        },
+        "msg": {
+          "type": "string"
+        },
+        "prerequisiteDebugLog": {
+          "$ref": "#/definitions/prerequisiteDebugLog"
+        },
+        "reason": {
+          "description": "the reason of the final decision, one of FLAG_NOT_FOUND, FLAG_DISABLED, OVERRIDDEN, PREREQUISITE_NOT_MET, EXCLUDED_BY_GROUP, NO_SEGMENTS, SEGMENT_MATCHED, OUT_OF_ROLLOUT, NO_SEGMENT_MATCHED",
+          "type": "string"
+        },
+        "segmentDebugLogs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentDebugLog"
+          }
+        }
+      }
+    },
+    "evalResult": {
+      "type": "object",
+      "properties": {
+        "errorCode": {
+          "description": "why no variant is resolved, it's empty when a variant is. FLAG_NOT_FOUND and FLAG_DISABLED are about the flag, INVALID_CONTEXT is when the entityContext can't be evaluated against the constraints of a segment, and NO_SEGMENT_MATCH is when the entity is not in any segment or rollout, see evalDebugLog.reason for the details",
+          "type": "string",
+          "enum": [
+            "FLAG_NOT_FOUND",
+            "FLAG_DISABLED",
+            "INVALID_CONTEXT",
+            "NO_SEGMENT_MATCH"
+          ]
+        },
+        "evalContext": {
+          "$ref": "#/definitions/evalContext"
+        },
+        "evalDebugLog": {
+          "$ref": "#/definitions/evalDebugLog"
+        },
+        "eventType": {
+          "description": "the type of the frontend event, only set when source is frontend",
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        },
+        "flagSnapshotID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "isDefaultVariant": {
+          "description": "it's true if no segment matched and the variant is the flag's default variant",
+          "type": "boolean"
+        },
+        "isOverridden": {
+          "description": "it's true if the variant is forced by the override of the flag, the segments are not evaluated then",
+          "type": "boolean"
+        },
+        "matchedSegments": {
+          "description": "all the segments whose constraints passed in the order of rank, only set when includeAllMatches is requested. It's left out of the data records",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/matchedSegment"
+          },
+          "x-omitempty": true
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "source": {
+          "description": "where the record comes from. It's empty for the server side evaluations, and frontend for the events reported by the frontend clients",
+          "type": "string"
+        },
+        "timestamp": {
+          "type": "string"
+        },
+        "typedVariantAttachment": {
+          "description": "the attachment coerced into the attachmentTypes of the variant, only set when the variant declares them",
+          "type": "object"
+        },
+        "variantAttachment": {
+          "type": "object"
+        },
+        "variantAttachmentError": {
+          "description": "the reason why the attachment doesn't conform to the attachmentTypes of the variant, typedVariantAttachment is not set then",
+          "type": "string"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "evaluateConstraintRequest": {
+      "type": "object",
+      "required": [
+        "constraint",
+        "entityContexts"
+      ],
+      "properties": {
+        "constraint": {
+          "$ref": "#/definitions/createConstraintRequest"
+        },
+        "entityContexts": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "object"
+          }
+        }
+      }
+    },
+    "evaluateConstraintResponse": {
+      "type": "object",
+      "properties": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintEvaluationResult"
+          }
+        }
+      }
+    },
+    "evaluationBatchRequest": {
+      "type": "object",
+      "required": [
+        "entities"
+      ],
+      "properties": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entities": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/evaluationEntity"
+          }
+        },
+        "environment": {
+          "description": "the environment whose configs of the flags are evaluated, it falls back to the X-Flagr-Environment header",
+          "type": "string"
+        },
+        "flagIDs": {
+          "description": "flagIDs",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        },
+        "flagKeys": {
+          "description": "flagKeys. Either flagIDs or flagKeys works. If pass in both, Flagr may return duplicate results.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "includeAllMatches": {
+          "type": "boolean"
+        }
+      }
+    },
+    "evaluationBatchResponse": {
+      "type": "object",
+      "required": [
+        "evaluationResults"
+      ],
+      "properties": {
+        "evaluationResults": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/evalResult"
+          }
+        }
+      }
+    },
+    "evaluationByTagRequest": {
+      "type": "object",
+      "required": [
+        "tag",
+        "entity"
+      ],
+      "properties": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entity": {
+          "$ref": "#/definitions/evaluationEntity"
+        },
+        "environment": {
+          "description": "the environment whose configs of the flags are evaluated, it falls back to the X-Flagr-Environment header",
+          "type": "string"
+        },
+        "includeAllMatches": {
+          "type": "boolean"
+        },
+        "tag": {
+          "description": "the value of the tag that the flags carry",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "evaluationEntity": {
+      "type": "object",
+      "properties": {
+        "entityContext": {
+          "type": "object"
+        },
+        "entityID": {
+          "type": "string"
+        },
+        "entityType": {
+          "type": "string"
+        }
+      }
+    },
+    "exclusionGroup": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the exclusion group",
+          "type": "string",
+          "minLength": 1
+        },
+        "members": {
+          "description": "the member flags ordered by ID, which is also the order of their buckets",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/exclusionGroupMember"
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "exclusionGroupDebugLog": {
+      "type": "object",
+      "properties": {
+        "bucketNum": {
+          "description": "the bucket of the entity in the group's bucketing space",
+          "type": "integer",
+          "format": "int64"
+        },
+        "exclusionGroupID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagID": {
+          "description": "the member flag that the bucket is routed to, it's 0 if the bucket isn't routed to any member",
+          "type": "integer",
+          "format": "int64"
+        },
+        "included": {
+          "description": "it's true if the bucket is routed to the evaluated flag",
+          "type": "boolean"
+        },
+        "msg": {
+          "type": "string"
+        }
+      }
+    },
+    "exclusionGroupMember": {
+      "type": "object",
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        },
+        "percent": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "flag": {
+      "type": "object",
+      "required": [
+        "description",
+        "enabled",
+        "dataRecordsEnabled"
+      ],
+      "properties": {
+        "attachmentSchema": {
+          "description": "the JSON schema (draft 4) that the attachments of the variants are validated against, it's not set if it's empty",
+          "type": "object"
+        },
+        "bucketBy": {
+          "description": "the entityContext attribute to bucket by if it's not empty, otherwise (or if the attribute is missing) the entityID is used",
+          "type": "string"
+        },
+        "bucketingAlgorithm": {
+          "description": "the hash of the distribution bucketing of the flag, one of crc32, murmur3 and xxhash. The global FLAGR_BUCKETING_ALGORITHM is used if it's empty. Changing it reshuffles the assignments of the entities.",
+          "type": "string"
+        },
+        "bucketingSeed": {
+          "description": "it will be mixed into the bucketing hash of the flag if it's not empty, otherwise the flag ID is used",
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "dataRecordsEnabled": {
+          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
+          "type": "boolean"
+        },
+        "defaultVariantID": {
+          "description": "the variant returned when no segment matches, it's not set if it's 0",
+          "type": "integer",
+          "format": "int64"
+        },
+        "deletedAt": {
+          "description": "it's only set for the soft deleted flags",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "entityType": {
+          "description": "it will override the entityType in the evaluation logs if it's not empty",
+          "type": "string"
+        },
+        "exclusionGroupID": {
+          "description": "the exclusion group of the flag, it's not in any group if it's 0",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "exclusionGroupPercent": {
+          "description": "the percent of the exclusion group's buckets routed to the flag",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "expiresAt": {
+          "description": "the flag is disabled automatically once it expires",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the flag",
+          "type": "string",
+          "minLength": 1
+        },
+        "lastEvaluatedAt": {
+          "description": "the last time the flag was evaluated, it's null if it's never evaluated. It's flushed from the evaluations every FLAGR_FLAG_LAST_EVALUATED_FLUSH_INTERVAL",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true,
+          "readOnly": true
+        },
+        "namespace": {
+          "description": "the namespace of the flag, it's the namespace of the creator if the namespaces are enabled by FLAGR_JWT_AUTH_NAMESPACE_CLAIM",
+          "type": "string",
+          "readOnly": true
+        },
+        "notes": {
+          "description": "flag usage details in markdown format",
+          "type": "string"
+        },
+        "overrideEnabled": {
+          "description": "the flag evaluates to overrideVariantID for every entity if it's true, ignoring the segments",
+          "type": "boolean",
+          "readOnly": true
+        },
+        "overrideVariantID": {
+          "description": "the variant the flag is forced to, there's no override if it's 0",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "prerequisiteFlagID": {
+          "description": "the flag that has to evaluate to prerequisiteVariantKey for the same entity before this flag is evaluated, it's not set if it's 0",
+          "type": "integer",
+          "format": "int64"
+        },
+        "prerequisiteVariantKey": {
+          "description": "the expected variant of the prerequisite flag, any variant is accepted if it's empty",
+          "type": "string"
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segment"
+          }
+        },
+        "tags": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/tag"
+          }
+        },
+        "updatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "updatedBy": {
+          "type": "string"
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variant"
+          }
+        }
+      }
+    },
+    "flagChange": {
+      "description": "a change of the flag pending the approval of another user than its requester, it's applied once it's approved. It's either the enabled state of the flag, or the distributions of one of its segments",
+      "type": "object",
+      "required": [
+        "id",
+        "flagID",
+        "type",
+        "status",
+        "requestedBy"
+      ],
+      "properties": {
+        "approvedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "approvedBy": {
+          "type": "string"
+        },
+        "distributions": {
+          "description": "the new distributions of the segment, for the distributions changes",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        },
+        "enabled": {
+          "description": "the enabled state of the flag, for the enabled changes",
+          "type": "boolean"
+        },
+        "environment": {
+          "description": "the environment of the change, it's the flag's own config if it's not set",
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "note": {
+          "type": "string"
+        },
+        "requestedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "requestedBy": {
+          "type": "string"
+        },
+        "segmentID": {
+          "description": "the segment of the distributions, for the distributions changes",
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "type": "string",
+          "enum": [
+            "pending",
+            "approved"
+          ]
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "enabled",
+            "distributions"
+          ]
+        }
+      }
+    },
+    "flagDefinition": {
+      "description": "the self-contained definition of a flag. The variants are referenced by their keys, so it doesn't depend on the ids of any environment",
+      "type": "object",
+      "required": [
+        "description"
+      ],
+      "properties": {
+        "attachmentSchema": {
+          "description": "the JSON schema (draft 4) that the attachments of the variants are validated against",
+          "type": "object"
+        },
+        "bucketBy": {
+          "type": "string"
+        },
+        "bucketingAlgorithm": {
+          "type": "string"
+        },
+        "bucketingSeed": {
+          "type": "string"
+        },
+        "dataRecordsEnabled": {
+          "type": "boolean"
+        },
+        "defaultVariantKey": {
+          "description": "the key of the variant returned when no segment matches",
+          "type": "string"
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "key": {
+          "description": "unique key representation of the flag, a random key is generated if it's empty",
+          "type": "string"
+        },
+        "notes": {
+          "type": "string"
+        },
+        "segments": {
+          "description": "segments in the order of evaluation",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentDefinition"
+          }
+        },
+        "tags": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createVariantRequest"
+          }
+        }
+      }
+    },
+    "flagHistory": {
+      "type": "object",
+      "required": [
+        "id",
+        "action",
+        "entityType",
+        "entityID",
+        "createdAt"
+      ],
+      "properties": {
+        "action": {
+          "type": "string",
+          "enum": [
+            "create",
+            "update",
+            "delete"
+          ]
+        },
+        "actor": {
+          "type": "string"
+        },
+        "createdAt": {
+          "type": "string",
+          "minLength": 1
+        },
+        "diff": {
+          "description": "the changed fields, each of them has the before and after values",
+          "type": "object"
+        },
+        "entityID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "entityType": {
+          "type": "string",
+          "enum": [
+            "flag",
+            "segment",
+            "constraint",
+            "distribution",
+            "environment",
+            "constraint_group"
+          ]
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "note": {
+          "description": "the rationale of the change given by the actor",
+          "type": "string"
+        }
+      }
+    },
+    "flagSchedule": {
+      "type": "object",
+      "required": [
+        "id",
+        "flagID",
+        "action",
+        "scheduledAt"
+      ],
+      "properties": {
+        "action": {
+          "type": "string",
+          "enum": [
+            "enable",
+            "disable"
+          ]
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "scheduledAt": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "flagSnapshot": {
+      "type": "object",
+      "required": [
+        "id",
+        "flag",
+        "updatedAt"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flag"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "updatedAt": {
+          "type": "string",
+          "minLength": 1
+        },
+        "updatedBy": {
+          "type": "string"
+        }
+      }
+    },
+    "flagSnapshotDiff": {
+      "type": "object",
+      "required": [
+        "fromSnapshotID",
+        "toSnapshotID",
+        "flag",
+        "variants",
+        "segments"
+      ],
+      "properties": {
+        "flag": {
+          "description": "the changed fields of the flag itself, e.g. Enabled",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotFieldDiff"
+          }
+        },
+        "fromSnapshotID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotSegmentDiff"
+          }
+        },
+        "toSnapshotID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotEntityDiff"
+          }
+        }
+      }
+    },
+    "flagSnapshotEntityDiff": {
+      "type": "object",
+      "required": [
+        "id",
+        "changeType",
+        "fields"
+      ],
+      "properties": {
+        "changeType": {
+          "type": "string",
+          "enum": [
+            "added",
+            "removed",
+            "changed"
+          ]
+        },
+        "fields": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotFieldDiff"
+          }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        }
+      }
+    },
+    "flagSnapshotFieldDiff": {
+      "type": "object",
+      "required": [
+        "field"
+      ],
+      "properties": {
+        "after": {
+          "description": "the value in the snapshot to, it's null if the field is removed"
+        },
+        "before": {
+          "description": "the value in the snapshot from, it's null if the field is added"
+        },
+        "field": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "flagSnapshotSegmentDiff": {
+      "type": "object",
+      "required": [
+        "id",
+        "changeType",
+        "fields",
+        "constraints",
+        "distributions"
+      ],
+      "properties": {
+        "changeType": {
+          "type": "string",
+          "enum": [
+            "added",
+            "removed",
+            "changed"
+          ]
+        },
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotEntityDiff"
+          }
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotEntityDiff"
+          }
+        },
+        "fields": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotFieldDiff"
+          }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        }
+      }
+    },
+    "frontendEvent": {
+      "type": "object",
+      "required": [
+        "flagKey",
+        "variantKey",
+        "entityID",
+        "eventType"
+      ],
+      "properties": {
+        "entityID": {
+          "type": "string",
+          "minLength": 1
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "eventType": {
+          "type": "string",
+          "enum": [
+            "impression",
+            "conversion"
+          ]
+        },
+        "flagKey": {
+          "type": "string",
+          "minLength": 1
+        },
+        "timestamp": {
+          "description": "RFC3339 time when the event happened. It defaults to the time the server receives it",
+          "type": "string"
+        },
+        "variantKey": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "frontendEventsRequest": {
+      "type": "object",
+      "required": [
+        "events"
+      ],
+      "properties": {
+        "events": {
+          "type": "array",
+          "maxItems": 100,
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/frontendEvent"
+          }
+        }
+      }
+    },
+    "importFlagRequest": {
+      "type": "object",
+      "required": [
+        "flag"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flagDefinition"
+        },
+        "key": {
+          "description": "it overrides the key of the flag definition if it's not empty, useful to avoid key collisions",
+          "type": "string"
+        }
+      }
+    },
+    "matchedSegment": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "rank": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "prerequisiteDebugLog": {
+      "type": "object",
+      "properties": {
+        "expectedVariantKey": {
+          "description": "the expected variant of the prerequisite flag, any variant is accepted if it's empty",
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        },
+        "met": {
+          "type": "boolean"
+        },
+        "msg": {
+          "type": "string"
+        },
+        "variantKey": {
+          "description": "the variant the prerequisite flag evaluated to",
+          "type": "string"
+        }
+      }
+    },
+    "promoteFlagEnvironmentRequest": {
+      "type": "object",
+      "required": [
+        "to"
+      ],
+      "properties": {
+        "note": {
+          "description": "the rationale of the change, it's stored in the history of the flag",
+          "type": "string"
+        },
+        "to": {
+          "description": "the environment the config is copied to, its enabled state and distributions are replaced",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "putConstraintGroupRequest": {
+      "type": "object",
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createConstraintRequest"
+          }
+        },
+        "description": {
+          "type": "string"
+        }
+      }
+    },
+    "putDistributionsRequest": {
+      "type": "object",
+      "required": [
+        "distributions"
+      ],
+      "properties": {
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        }
+      }
+    },
+    "putExclusionGroupRequest": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        }
+      }
+    },
+    "putFlagExclusionGroupRequest": {
+      "type": "object",
+      "required": [
+        "exclusionGroupID"
+      ],
+      "properties": {
+        "exclusionGroupID": {
+          "description": "the exclusion group to join, the flag leaves its group if it's 0",
+          "type": "integer",
+          "format": "int64"
+        },
+        "percent": {
+          "description": "the percent of the group's buckets routed to the flag, the percents of all the members add up to at most 100",
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "putFlagOverrideRequest": {
+      "type": "object",
+      "required": [
+        "variantID"
+      ],
+      "properties": {
+        "enabled": {
+          "description": "the override is kept but not applied if it's false",
+          "type": "boolean"
+        },
+        "variantID": {
+          "description": "the variant to force the flag to, the override is cleared if it's 0",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "putFlagRequest": {
+      "type": "object",
+      "properties": {
+        "attachmentSchema": {
+          "description": "the JSON schema (draft 4) that the attachments of the variants are validated against, the existing variants are validated when it's set. $ref and id are not supported. Set it to {} to unset it",
+          "type": "object"
+        },
+        "bucketBy": {
+          "description": "the entityContext attribute to bucket by if it's not empty, otherwise (or if the attribute is missing) the entityID is used",
+          "type": "string",
+          "x-nullable": true
+        },
+        "bucketingAlgorithm": {
+          "description": "the hash of the distribution bucketing of the flag, one of crc32, murmur3 and xxhash. The global FLAGR_BUCKETING_ALGORITHM is used if it's empty. Changing it reshuffles the assignments of the entities.",
+          "type": "string",
+          "x-nullable": true
+        },
+        "bucketingSeed": {
+          "description": "it will be mixed into the bucketing hash of the flag if it's not empty, otherwise the flag ID is used",
+          "type": "string",
+          "x-nullable": true
+        },
+        "dataRecordsEnabled": {
+          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "defaultVariantID": {
+          "description": "the variant returned when no segment matches, set it to 0 to unset it",
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1,
+          "x-nullable": true
+        },
+        "enabled": {
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "entityType": {
+          "description": "it will overwrite entityType into evaluation logs if it's not empty",
+          "type": "string",
+          "x-nullable": true
+        },
+        "expiresAt": {
+          "description": "the flag is disabled automatically once it expires, set it to 0001-01-01T00:00:00Z to unset it",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "key": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "note": {
+          "description": "the rationale of the change, it's stored in the history of the flag",
+          "type": "string"
+        },
+        "notes": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "prerequisiteFlagID": {
+          "description": "the flag that has to evaluate to prerequisiteVariantKey for the same entity before this flag is evaluated, set it to 0 to unset it",
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "prerequisiteVariantKey": {
+          "description": "the expected variant of the prerequisite flag, any variant is accepted if it's empty",
+          "type": "string",
+          "x-nullable": true
+        }
+      }
+    },
+    "putSegmentReorderRequest": {
+      "type": "object",
+      "required": [
+        "segmentIDs"
+      ],
+      "properties": {
+        "segmentIDs": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        }
+      }
+    },
+    "putSegmentRequest": {
+      "type": "object",
+      "required": [
+        "description",
+        "rolloutPercent"
+      ],
+      "properties": {
+        "constraintGroupID": {
+          "description": "the constraint group evaluated together with the constraints of the segment, 0 removes the reference and null keeps it",
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "putSegmentTemplateRequest": {
+      "type": "object",
+      "required": [
+        "segment"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "segment": {
+          "$ref": "#/definitions/segmentDefinition"
+        }
+      }
+    },
+    "putVariantRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "attachment": {
+          "type": "object"
+        },
+        "attachmentTypes": {
+          "description": "the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string",
+            "enum": [
+              "string",
+              "number",
+              "integer",
+              "boolean",
+              "json"
+            ]
+          }
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "readiness": {
+      "type": "object",
+      "properties": {
+        "warnings": {
+          "description": "the problems that don't stop Flagr from serving the evaluations, e.g. the backlog of the data records over the high-water mark",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "rebalanceDistributionsRequest": {
+      "type": "object",
+      "required": [
+        "variantIDs"
+      ],
+      "properties": {
+        "variantIDs": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        }
+      }
+    },
+    "rolloutSimulation": {
+      "type": "object",
+      "required": [
+        "flagID",
+        "segmentID",
+        "n",
+        "variants",
+        "flipped",
+        "flips"
+      ],
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "flipped": {
+          "description": "the number of entity IDs that would get another variant, or get in or out of the rollout",
+          "type": "integer",
+          "format": "int64"
+        },
+        "flips": {
+          "description": "the entity IDs that would flip, the first 1000 of them",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variantFlip"
+          }
+        },
+        "n": {
+          "description": "the number of entity IDs simulated",
+          "type": "integer",
+          "format": "int64"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variantSimulationCount"
+          }
+        }
+      }
+    },
+    "saveFlagsBatchRequest": {
+      "type": "object",
+      "required": [
+        "flags"
+      ],
+      "properties": {
+        "flags": {
+          "type": "array",
+          "maxItems": 1000,
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/flagDefinition"
+          }
+        }
+      }
+    },
+    "saveFlagsBatchResponse": {
+      "type": "object",
+      "required": [
+        "results"
+      ],
+      "properties": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/saveFlagsBatchResult"
+          }
+        }
+      }
+    },
+    "saveFlagsBatchResult": {
+      "type": "object",
+      "properties": {
+        "error": {
+          "type": "string"
+        },
+        "flag": {
+          "$ref": "#/definitions/flag"
+        },
+        "index": {
+          "description": "the index of the flag definition in the request",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "segment": {
+      "type": "object",
+      "required": [
+        "description",
+        "rank",
+        "rolloutPercent"
+      ],
+      "properties": {
+        "constraintGroupID": {
+          "description": "the constraint group evaluated together with the constraints of the segment, there's none if it's 0",
+          "type": "integer",
+          "format": "int64"
+        },
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraint"
+          }
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "rank": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "segmentBucketCheck": {
+      "description": "unassigned counts the entity IDs falling into no distribution, it's only set when the distributions don't cover all the buckets, e.g. while a rollout schedule ramps",
+      "type": "object",
+      "required": [
+        "segmentID",
+        "variants",
+        "chiSquare",
+        "degreesOfFreedom"
+      ],
+      "properties": {
+        "chiSquare": {
+          "description": "the chi-square statistic of the observed counts versus the expected ones, the segment rollout percent is not considered",
+          "type": "number",
+          "format": "double"
+        },
+        "degreesOfFreedom": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "description": {
+          "type": "string"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "unassigned": {
+          "$ref": "#/definitions/variantBucketCount"
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variantBucketCount"
+          }
+        }
+      }
+    },
+    "segmentDebugLog": {
+      "type": "object",
+      "properties": {
+        "bucketNum": {
+          "description": "the bucket number the entity falls into, only set when the constraints are matched",
+          "type": "integer",
+          "format": "int64"
+        },
+        "constraintDebugLogs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintDebugLog"
+          }
+        },
+        "matched": {
+          "description": "whether all the constraints of the segment are matched",
+          "type": "boolean"
+        },
+        "msg": {
+          "type": "string"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        }
+      }
+    },
+    "segmentDefinition": {
+      "type": "object",
+      "required": [
+        "description",
+        "rolloutPercent"
+      ],
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createConstraintRequest"
+          }
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distributionDefinition"
+          }
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100
+        }
+      }
+    },
+    "segmentTemplate": {
+      "type": "object",
+      "required": [
+        "key",
+        "segment"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the segment template",
+          "type": "string",
+          "minLength": 1
+        },
+        "segment": {
+          "$ref": "#/definitions/segmentDefinition"
+        }
+      }
+    },
+    "segmentTemplateVariant": {
+      "type": "object",
+      "required": [
+        "variantKey",
+        "variantID"
+      ],
+      "properties": {
+        "variantID": {
+          "description": "the variant of the flag it's filled in with",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variantKey": {
+          "description": "the variant key in the distributions of the template",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "setFlagEnabledRequest": {
+      "type": "object",
+      "required": [
+        "enabled"
+      ],
+      "properties": {
+        "enabled": {
+          "type": "boolean"
+        },
+        "note": {
+          "description": "the rationale of the change, it's stored in the history of the flag",
+          "type": "string"
+        }
+      }
+    },
+    "simulateRolloutRequest": {
+      "type": "object",
+      "required": [
+        "segmentID",
+        "distributions"
+      ],
+      "properties": {
+        "distributions": {
+          "description": "the proposed distributions, they're validated the same way as putting the distributions",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        },
+        "entityIDs": {
+          "description": "the sample of the active entity IDs, n synthetic entity IDs are simulated if it's empty",
+          "type": "array",
+          "maxItems": 100000,
+          "items": {
+            "type": "string"
+          }
+        },
+        "n": {
+          "description": "the number of the synthetic entity IDs to simulate if there's no entityIDs, it's 100000 by default",
+          "type": "integer",
+          "format": "int64",
+          "maximum": 1000000,
+          "minimum": 1
+        },
+        "segmentID": {
+          "description": "the segment the distributions are proposed for",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        }
+      }
+    },
+    "staleFlag": {
+      "type": "object",
+      "required": [
+        "flag",
+        "reasons"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flag"
+        },
+        "reasons": {
+          "description": "why the flag is stale, it can be for more than one reason",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "enum": [
+              "disabled",
+              "never_evaluated",
+              "single_variant"
+            ]
+          }
+        },
+        "singleVariantKey": {
+          "description": "the variant the flag always resolves to, it's only set for the single_variant reason",
+          "type": "string"
+        }
+      }
+    },
+    "tag": {
+      "type": "object",
+      "required": [
+        "value"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "value": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "validateFlagRequest": {
+      "type": "object",
+      "required": [
+        "flag"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flagDefinition"
+        },
+        "prerequisiteFlagID": {
+          "description": "the prerequisite flag to check for cycles, as the flag definition doesn't carry it",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "prerequisiteVariantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "validateFlagResponse": {
+      "type": "object",
+      "required": [
+        "valid",
+        "errors"
+      ],
+      "properties": {
+        "errors": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/validationError"
+          }
+        },
+        "valid": {
+          "type": "boolean"
+        }
+      }
+    },
+    "validationError": {
+      "type": "object",
+      "required": [
+        "path",
+        "message"
+      ],
+      "properties": {
+        "message": {
+          "type": "string"
+        },
+        "path": {
+          "description": "the JSON path of the invalid field in the request body, e.g. $.flag.segments[0].distributions",
+          "type": "string"
+        }
+      }
+    },
+    "variant": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "attachment": {
+          "type": "object"
+        },
+        "attachmentTypes": {
+          "description": "the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string",
+            "enum": [
+              "string",
+              "number",
+              "integer",
+              "boolean",
+              "json"
+            ]
+          }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "variantBucketCount": {
+      "type": "object",
+      "required": [
+        "expected",
+        "observed"
+      ],
+      "properties": {
+        "expected": {
+          "type": "number",
+          "format": "double"
+        },
+        "observed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "variantFlip": {
+      "description": "the variantIDs are 0 if the entity ID is out of the rollout or the distributions",
+      "type": "object",
+      "required": [
+        "entityID"
+      ],
+      "properties": {
+        "entityID": {
+          "type": "string"
+        },
+        "fromVariantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "fromVariantKey": {
+          "type": "string"
+        },
+        "toVariantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "toVariantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "variantSimulationCount": {
+      "description": "the entity IDs out of the rollout or the distributions are counted with the variantID 0",
+      "type": "object",
+      "required": [
+        "current",
+        "proposed"
+      ],
+      "properties": {
+        "current": {
+          "description": "the number of entity IDs bucketed into the variant by the current distributions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "proposed": {
+          "description": "the number of entity IDs bucketed into the variant by the proposed distributions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    }
+  },
+  "tags": [
+    {
+      "description": "Everything about the flag",
+      "name": "flag"
+    },
+    {
+      "description": "Segment defines the audience of the flag, it's the user segmentation",
+      "name": "segment"
+    },
+    {
+      "description": "Constraint is the unit of defining a small subset of users",
+      "name": "constraint"
+    },
+    {
+      "description": "Distribution is the percent distribution of variants within that segment",
+      "name": "distribution"
+    },
+    {
+      "description": "Variants are the possible outcomes of flag evaluation",
+      "name": "variant"
+    },
+    {
+      "description": "Tags are the labels to organize and filter the flags",
+      "name": "tag"
+    },
+    {
+      "description": "Constraint groups are the named sets of constraints shared by the segments referencing them",
+      "name": "constraintGroup"
+    },
+    {
+      "description": "Segment templates are the reusable skeletons of a segment with its constraints and distributions",
+      "name": "segmentTemplate"
+    },
+    {
+      "description": "Exclusion groups split a shared bucketing space across their member flags, so that an entity is in at most one of them",
+      "name": "exclusionGroup"
+    },
+    {
+      "description": "Evaluation is the process of evaluating a flag given the entity context",
+      "name": "evaluation"
+    },
+    {
+      "description": "Check if Flagr is healthy",
+      "name": "health"
+    }
+  ],
+  "x-tagGroups": [
+    {
+      "name": "Flag Management",
+      "tags": [
+        "flag",
+        "segment",
+        "constraint",
+        "distribution",
+        "variant",
+        "tag",
+        "constraintGroup",
+        "segmentTemplate",
+        "exclusionGroup"
+      ]
+    },
+    {
+      "name": "Flag Evaluation",
+      "tags": [
+        "evaluation"
+      ]
+    },
+    {
+      "name": "Health Check",
+      "tags": [
+        "health"
+      ]
+    },
+    {
+      "name": "Export",
+      "tags": [
+        "export"
+      ]
+    }
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "Flagr is a feature flagging, A/B testing and dynamic configuration microservice. The base path for all the APIs is \"/api/v1\".\n",
+    "title": "Flagr",
+    "version": "1.1.0"
+  },
+  "basePath": "/api/v1",
+  "paths": {
+    "/constraint_groups": {
+      "get": {
+        "tags": [
+          "constraintGroup"
+        ],
+        "operationId": "findConstraintGroups",
+        "responses": {
+          "200": {
+            "description": "all the constraint groups ordered by key",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/constraintGroup"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "constraintGroup"
+        ],
+        "operationId": "createConstraintGroup",
+        "parameters": [
+          {
+            "description": "create a constraint group",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createConstraintGroupRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created constraint group",
+            "schema": {
+              "$ref": "#/definitions/constraintGroup"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/constraint_groups/{constraintGroupID}": {
+      "get": {
+        "tags": [
+          "constraintGroup"
+        ],
+        "operationId": "getConstraintGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint group",
+            "name": "constraintGroupID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the constraint group with the segments referencing it",
+            "schema": {
+              "$ref": "#/definitions/constraintGroup"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "replaces the description and the constraints of the constraint group, all the segments referencing it are evaluated with the new constraints",
+        "tags": [
+          "constraintGroup"
+        ],
+        "operationId": "putConstraintGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint group",
+            "name": "constraintGroupID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update a constraint group",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putConstraintGroupRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the constraint group",
+            "schema": {
+              "$ref": "#/definitions/constraintGroup"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "deletes the constraint group, it fails if any segment still references it",
+        "tags": [
+          "constraintGroup"
+        ],
+        "operationId": "deleteConstraintGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint group",
+            "name": "constraintGroupID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/constraints/evaluate": {
+      "post": {
+        "description": "evaluates the constraint against the entity contexts without saving it, for example, to preview a constraint before it's saved. It's stateless and it doesn't write any data record.",
+        "tags": [
+          "constraint"
+        ],
+        "operationId": "evaluateConstraint",
+        "parameters": [
+          {
+            "description": "the constraint and the entity contexts to evaluate it against",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluateConstraintRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the results of the evaluation, in the order of the entity contexts",
+            "schema": {
+              "$ref": "#/definitions/evaluateConstraintResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation": {
+      "get": {
+        "description": "evaluates the flag like postEvaluation, with the evaluation context in the query parameters, e.g. for the clients that can only send GET requests. The whole URL is the cache key of the result if it's cached by an HTTP cache, see docs/flagr_env.md",
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "getEvaluation",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "flagID",
+            "name": "flagID",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
+            "name": "flagKey",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
+            "name": "entityID",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "entityType",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the entity context as a JSON object, URL encoded, e.g. entityContext=%7B%22state%22%3A%22CA%22%7D for {\"state\":\"CA\"}",
+            "name": "entityContext",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "name": "enableDebug",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "returns all the segments whose constraints passed in matchedSegments of the result, the variant is still from the first one",
+            "name": "includeAllMatches",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the environment whose config of the flag is evaluated, e.g. prod. It falls back to the X-Flagr-Environment header, and the flag's own config is evaluated without it or if the flag has no config of the environment",
+            "name": "environment",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation result",
+            "schema": {
+              "$ref": "#/definitions/evalResult"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluation",
+        "parameters": [
+          {
+            "description": "evalution context",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evalContext"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation result",
+            "schema": {
+              "$ref": "#/definitions/evalResult"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/batch": {
+      "post": {
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluationBatch",
+        "parameters": [
+          {
+            "description": "evalution batch request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationBatchRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation batch result",
+            "schema": {
+              "$ref": "#/definitions/evaluationBatchResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/bytag": {
+      "post": {
+        "description": "evaluates all the enabled flags carrying the tag for the entity, the results are empty if no flag carries the tag",
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluationByTag",
+        "parameters": [
+          {
+            "description": "evaluation by tag request",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evaluationByTagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation results of the flags carrying the tag",
+            "schema": {
+              "$ref": "#/definitions/evaluationBatchResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/cache/refresh": {
+      "post": {
+        "description": "reloads the in-memory evaluation cache immediately instead of waiting for the next refresh interval, for example, after a bulk import. It requires the auth even though the other evaluation endpoints may not.",
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluationCacheRefresh",
+        "responses": {
+          "200": {
+            "description": "the evaluation cache is reloaded",
+            "schema": {
+              "$ref": "#/definitions/evalCacheRefreshResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/explain": {
+      "post": {
+        "description": "evaluates the flag with debugging enabled and explains the result. It's read-only and it doesn't write any data record.",
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluationExplain",
+        "parameters": [
+          {
+            "description": "evalution context",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/evalContext"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "evaluation result with the debug log",
+            "schema": {
+              "$ref": "#/definitions/evalResult"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/evaluation/frontend-events": {
+      "post": {
+        "description": "records the impression or conversion events reported by the frontend clients. The events are sent to the data recorder with the source marked as frontend.",
+        "tags": [
+          "evaluation"
+        ],
+        "operationId": "postEvaluationFrontendEvents",
+        "parameters": [
+          {
+            "description": "frontend events",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/frontendEventsRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK recorded"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/exclusion_groups": {
+      "get": {
+        "tags": [
+          "exclusionGroup"
+        ],
+        "operationId": "findExclusionGroups",
+        "responses": {
+          "200": {
+            "description": "all the exclusion groups ordered by key",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/exclusionGroup"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "exclusionGroup"
+        ],
+        "operationId": "createExclusionGroup",
+        "parameters": [
+          {
+            "description": "create an exclusion group",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createExclusionGroupRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created exclusion group",
+            "schema": {
+              "$ref": "#/definitions/exclusionGroup"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/exclusion_groups/{exclusionGroupID}": {
+      "get": {
+        "tags": [
+          "exclusionGroup"
+        ],
+        "operationId": "getExclusionGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the exclusion group",
+            "name": "exclusionGroupID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the exclusion group with its member flags",
+            "schema": {
+              "$ref": "#/definitions/exclusionGroup"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "exclusionGroup"
+        ],
+        "operationId": "putExclusionGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the exclusion group",
+            "name": "exclusionGroupID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update an exclusion group",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putExclusionGroupRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the exclusion group",
+            "schema": {
+              "$ref": "#/definitions/exclusionGroup"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "deletes the exclusion group, its member flags are left out of any group",
+        "tags": [
+          "exclusionGroup"
+        ],
+        "operationId": "deleteExclusionGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the exclusion group",
+            "name": "exclusionGroupID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/export/eval_cache/json": {
+      "get": {
+        "description": "Export JSON format of the eval cache dump",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "export"
+        ],
+        "operationId": "getExportEvalCacheJSON",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "object"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/export/sqlite": {
+      "get": {
+        "description": "Export sqlite3 format of the db dump, which is converted from the main database.",
+        "produces": [
+          "application/octet-stream"
+        ],
+        "tags": [
+          "export"
+        ],
+        "operationId": "getExportSQLite",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "findFlags",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "the numbers of flags to return, it defaults to FLAGR_FLAGS_DEFAULT_LIMIT and it's capped by FLAGR_FLAGS_MAX_LIMIT",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return flags having given enabled status",
+            "name": "enabled",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags exactly matching given description",
+            "name": "description",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags partially matching given description",
+            "name": "description_like",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags having all the given tags, comma separated",
+            "name": "tags",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "search flags by the whitespace separated terms, case-insensitively. Every term has to be part of the key, the description or one of the tags. Flags with the exact key are returned first, and the number of results is capped by FLAGR_FLAGS_SEARCH_MAX_LIMIT",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags matching given key",
+            "name": "key",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "return flags given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return flags with preloaded segments and variants",
+            "name": "preload",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "return the soft deleted flags as well, only for the admins",
+            "name": "includeDeleted",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "list the flags as they're configured in the environment, the enabled filter is on their enabled state in it",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags not evaluated within the duration, e.g. 30d or 12h, including the ones never evaluated that were created before it",
+            "name": "unusedSince",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "list all the flags ordered by id",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flag"
+              }
+            },
+            "headers": {
+              "X-Total-Count": {
+                "type": "integer",
+                "format": "int64",
+                "description": "the total number of flags matching the filters, regardless of limit and offset"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "createFlag",
+        "parameters": [
+          {
+            "description": "create a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createFlagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/batch": {
+      "post": {
+        "description": "creates or updates the flags in a single transaction. A flag is updated if its key exists, and its segments are replaced by the definition. If any of the flags fails, nothing is saved and the per-flag errors are returned.\n",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "saveFlagsBatch",
+        "parameters": [
+          {
+            "description": "the flag definitions",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/saveFlagsBatchRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the saved flags in the order of the request",
+            "schema": {
+              "$ref": "#/definitions/saveFlagsBatchResponse"
+            }
+          },
+          "400": {
+            "description": "returns the per-flag errors, nothing is saved",
+            "schema": {
+              "$ref": "#/definitions/saveFlagsBatchResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/entity_types": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagEntityTypes",
+        "responses": {
+          "200": {
+            "description": "returns all the FlagEntityTypes",
+            "schema": {
+              "type": "array",
+              "items": {
+                "type": "string",
+                "minLength": 1
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/export.csv": {
+      "get": {
+        "description": "Export the flags in CSV, one row per distribution of the segments, or one row per flag if the flag has no distribution. The rows are streamed, so it doesn't load all the flags into memory.",
+        "produces": [
+          "text/csv"
+        ],
+        "tags": [
+          "export"
+        ],
+        "operationId": "getExportFlagsCsv",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "return flags having given enabled status",
+            "name": "enabled",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return flags having all the given tags, comma separated",
+            "name": "tags",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/import": {
+      "post": {
+        "description": "creates a new flag from the flag definition, it fails if the key already exists",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "importFlag",
+        "parameters": [
+          {
+            "description": "the flag definition to import",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/importFlagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the imported flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/stale": {
+      "get": {
+        "description": "returns the flags to clean up, the ones disabled for longer than disabledFor, the ones never evaluated since they were created longer than unevaluatedFor ago, and the enabled ones always resolving to a single variant according to their distributions",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "findStaleFlags",
+        "parameters": [
+          {
+            "type": "string",
+            "default": "30d",
+            "description": "the flags disabled and not changed for the duration are stale, e.g. 30d or 12h",
+            "name": "disabledFor",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "default": "30d",
+            "description": "the flags never evaluated and created longer than the duration ago are stale, e.g. 30d or 12h",
+            "name": "unevaluatedFor",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the stale flags ordered by id",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/staleFlag"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/stream": {
+      "get": {
+        "description": "Stream the changes of the flags as server-sent events, one event per record of the flag history, i.e. the changes of the flags, their segments, constraints and distributions. The data of the event is the JSON of the flag ID and the change type, and its id is the ID of the flag history. A comment is sent periodically to keep the connection alive. The changes since Last-Event-ID are replayed first on reconnect.",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagsStream",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "the id of the last event received, the changes after it are replayed",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the stream of the flag changes",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/validate": {
+      "post": {
+        "description": "validates the flag definition like creating or updating the flag would, without saving anything",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "validateFlag",
+        "parameters": [
+          {
+            "description": "the flag definition to validate",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/validateFlagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the validation errors of the flag definition, it's valid if there is none",
+            "schema": {
+              "$ref": "#/definitions/validateFlagResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "get the flag as it's configured in the environment, i.e. its enabled state and the distributions of its segments",
+            "name": "environment",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "putFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putFlagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "deleteFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/bucketcheck": {
+      "get": {
+        "description": "simulates bucketing n synthetic entity IDs through the current distributions of every segment of the flag, and returns the observed counts of the variants versus the expected ones with the chi-square statistic, e.g. to check that an A/A test splits evenly. Nothing is evaluated or recorded for real.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagBucketCheck",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 1000000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100000,
+            "description": "the number of synthetic entity IDs to bucket",
+            "name": "n",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the bucketing of the segments ordered by rank",
+            "schema": {
+              "$ref": "#/definitions/bucketCheck"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/changes": {
+      "get": {
+        "description": "find the changes of the flag requested with FLAGR_APPROVAL_REQUIRED, the latest first",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "findFlagChanges",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "enum": [
+              "pending",
+              "approved"
+            ],
+            "type": "string",
+            "description": "return the changes of the status only, all of them are returned if it's not set",
+            "name": "status",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the changes of the flag",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagChange"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/changes/{changeID}/approve": {
+      "post": {
+        "description": "approve the pending change of the flag and apply it with a new snapshot of the flag. It has to be approved by another user than the one who requested it.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "approveFlagChange",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the change to approve",
+            "name": "changeID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the approved change",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/clone": {
+      "post": {
+        "description": "deep copies the flag with its variants, segments, constraints and distributions into a new flag. The new flag is always disabled.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "cloneFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to clone",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the key of the new flag",
+            "name": "body",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/cloneFlagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the new flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/enabled": {
+      "put": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "setFlagEnabled",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "set the enabled state of the flag in the environment, the flag's own enabled state is untouched",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "description": "set flag enabled state",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/setFlagEnabledRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "patch": {
+        "description": "sets the enabled state of the flag in one update without the rest of the flag, so it doesn't race with the other changes of the flag",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "patchFlagEnabled",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "set the enabled state of the flag in the environment, the flag's own enabled state is untouched",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "description": "set flag enabled state",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/setFlagEnabledRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/environments/{environment}/promote": {
+      "post": {
+        "description": "copies the enabled state and the distributions of the flag in the environment to another environment, e.g. from staging to prod. The flag's own config is copied if it has no config of the environment",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "promoteFlagEnvironment",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "the environment the config is copied from",
+            "name": "environment",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the environment to promote the config to",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/promoteFlagEnvironmentRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag as it's configured in the environment it's promoted to",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/exclusion_group": {
+      "put": {
+        "description": "adds the flag to the exclusion group with its percent of the group's buckets, or removes it from its group if exclusionGroupID is 0",
+        "tags": [
+          "exclusionGroup"
+        ],
+        "operationId": "putFlagExclusionGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the exclusion group of the flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putFlagExclusionGroupRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/export": {
+      "get": {
+        "description": "exports the self-contained definition of the flag, it can be imported into another environment",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "exportFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to export",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag definition",
+            "schema": {
+              "$ref": "#/definitions/flagDefinition"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/history": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagHistory",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "the numbers of history records to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "description": "return history records given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the audit history of the flag, the latest first",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagHistory"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/override": {
+      "put": {
+        "description": "forces the flag to the variant for every entity while the override is enabled, ignoring the segments. The override is cleared if variantID is 0. It takes effect on the next refresh of the evaluation cache.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "putFlagOverride",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the override of the flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putFlagOverrideRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/restore": {
+      "put": {
+        "description": "restores a soft deleted flag together with its segments and variants, only for the admins",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "restoreFlag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to restore",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the restored flag",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/restore/{snapshotID}": {
+      "put": {
+        "description": "applies a prior snapshot of the flag back onto the live flag, including its variants, segments, constraints and distributions. The state before the restore is kept as a new snapshot.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "restoreFlagSnapshot",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the snapshot to restore",
+            "name": "snapshotID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag after the restore",
+            "schema": {
+              "$ref": "#/definitions/flag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/schedule": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "findFlagSchedules",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the pending schedules of the flag, the earliest first",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagSchedule"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "schedules the flag to be enabled or disabled at the given time. The schedule is removed once it's applied, and the schedules missed while flagr was down are applied on startup.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "createFlagSchedule",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the action and the time to apply it",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createFlagScheduleRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created schedule",
+            "schema": {
+              "$ref": "#/definitions/flagSchedule"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/segments": {
+      "get": {
+        "tags": [
+          "segment"
+        ],
+        "operationId": "findSegments",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "segments ordered by rank of the flag",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/segment"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "segment"
+        ],
+        "operationId": "createSegment",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a segment under a flag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createSegmentRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "segment created",
+            "schema": {
+              "$ref": "#/definitions/segment"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/segments/reorder": {
+      "put": {
+        "description": "reorder the segments in one transaction, the segmentIDs should be exactly the segments of the flag",
+        "tags": [
+          "segment"
+        ],
+        "operationId": "putSegmentsReorder",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "reorder segments",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putSegmentReorderRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "segments reordered"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}": {
+      "put": {
+        "tags": [
+          "segment"
+        ],
+        "operationId": "putSegment",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update a segment",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putSegmentRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "segment updated",
+            "schema": {
+              "$ref": "#/definitions/segment"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "segment"
+        ],
+        "operationId": "deleteSegment",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}/constraints": {
+      "get": {
+        "tags": [
+          "constraint"
+        ],
+        "operationId": "findConstraints",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "constraints under the segment",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/constraint"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "constraint"
+        ],
+        "operationId": "createConstraint",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a constraint",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createConstraintRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the constraint created",
+            "schema": {
+              "$ref": "#/definitions/constraint"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}": {
+      "put": {
+        "tags": [
+          "constraint"
+        ],
+        "operationId": "putConstraint",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint",
+            "name": "constraintID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a constraint",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createConstraintRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "constraint just updated",
+            "schema": {
+              "$ref": "#/definitions/constraint"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "constraint"
+        ],
+        "operationId": "deleteConstraint",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the constraint",
+            "name": "constraintID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}/distributions": {
+      "get": {
+        "tags": [
+          "distribution"
+        ],
+        "operationId": "findDistributions",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "get the distributions of the segment in the environment, they're the segment's own if it has none in the environment",
+            "name": "environment",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "distribution under the segment",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/distribution"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "replace the distribution with the new setting",
+        "tags": [
+          "distribution"
+        ],
+        "operationId": "putDistributions",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "replace the distributions of the segment in the environment, the segment's own distributions are untouched",
+            "name": "environment",
+            "in": "query"
+          },
+          {
+            "description": "array of distributions",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putDistributionsRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "distribution under the segment",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/distribution"
+              }
+            }
+          },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/segments/{segmentID}/distributions/rebalance": {
+      "put": {
+        "description": "replace the distribution by splitting 100 percent evenly across the variants, the remainder goes to the first variants in the given order",
+        "tags": [
+          "distribution"
+        ],
+        "operationId": "rebalanceDistributions",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment",
+            "name": "segmentID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "variants to split the distribution across",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/rebalanceDistributionsRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "distribution under the segment",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/distribution"
+              }
+            }
+          },
+          "202": {
+            "description": "the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED",
+            "schema": {
+              "$ref": "#/definitions/flagChange"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/simulate": {
+      "post": {
+        "description": "dry-runs the proposed distributions of a segment, and returns how the sample of the entity IDs would be bucketed into the variants by the current distributions and the proposed ones, with the entity IDs that would flip. It's all bucketed in memory the same way as the evaluation does, and nothing is saved or recorded.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "simulateFlagRollout",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the proposed distributions of the segment and the entity IDs to simulate",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/simulateRolloutRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the projected counts of the variants and the entity IDs that would flip",
+            "schema": {
+              "$ref": "#/definitions/rolloutSimulation"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/snapshots": {
+      "get": {
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagSnapshots",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag to get",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "the numbers of snapshots to return, all of them are returned if it's not set",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "return snapshots given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "return snapshots created at or after the time",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "return snapshots created before the time",
+            "name": "to",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the flag snapshots, the latest first",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/flagSnapshot"
+              }
+            },
+            "headers": {
+              "X-Total-Count": {
+                "type": "integer",
+                "format": "int64",
+                "description": "the total number of snapshots in the date range, regardless of limit and offset"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/snapshots/diff": {
+      "get": {
+        "description": "returns the changes to get from the flag in the snapshot ` + "`" + `from` + "`" + ` to the one in the snapshot ` + "`" + `to` + "`" + `. Either of them can be the older one, swapping them reverses the diff. The variants, segments, constraints and distributions are matched by ID and sorted by ID, the unchanged ones are left out.",
+        "tags": [
+          "flag"
+        ],
+        "operationId": "getFlagSnapshotsDiff",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the snapshot to diff from",
+            "name": "from",
+            "in": "query",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the snapshot to diff to",
+            "name": "to",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the diff between the snapshots",
+            "schema": {
+              "$ref": "#/definitions/flagSnapshotDiff"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/tags": {
+      "get": {
+        "tags": [
+          "tag"
+        ],
+        "operationId": "findTags",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "tags ordered by value",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/tag"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "adds the tag to the flag, the tag is created if it doesn't exist",
+        "tags": [
+          "tag"
+        ],
+        "operationId": "createTag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a tag",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createTagRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "tag just added to the flag",
+            "schema": {
+              "$ref": "#/definitions/tag"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/tags/{tagID}": {
+      "delete": {
+        "description": "removes the tag from the flag, the tag itself is kept for the other flags",
+        "tags": [
+          "tag"
+        ],
+        "operationId": "deleteTag",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the tag",
+            "name": "tagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/variants": {
+      "get": {
+        "tags": [
+          "variant"
+        ],
+        "operationId": "findVariants",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant ordered by variantID",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/variant"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "variant"
+        ],
+        "operationId": "createVariant",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "create a variant",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createVariantRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant just created",
+            "schema": {
+              "$ref": "#/definitions/variant"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/flags/{flagID}/variants/{variantID}": {
+      "put": {
+        "tags": [
+          "variant"
+        ],
+        "operationId": "putVariant",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the variant",
+            "name": "variantID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update a variant",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putVariantRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "variant just updated",
+            "schema": {
+              "$ref": "#/definitions/variant"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "variant"
+        ],
+        "operationId": "deleteVariant",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the flag",
+            "name": "flagID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the variant",
+            "name": "variantID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/health": {
+      "get": {
+        "description": "Check if Flagr is healthy",
+        "tags": [
+          "health"
+        ],
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/ready": {
+      "get": {
+        "description": "Check if Flagr is ready to serve the evaluations, it's not until all the flags are loaded into the evaluation cache. A ready Flagr can still report warnings, e.g. the backlog of the data records",
+        "tags": [
+          "health"
+        ],
+        "operationId": "getReady",
+        "responses": {
+          "200": {
+            "description": "OK",
+            "schema": {
+              "$ref": "#/definitions/readiness"
+            }
+          },
+          "503": {
+            "description": "the evaluation cache is not warmed up yet",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/segment_templates": {
+      "get": {
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "findSegmentTemplates",
+        "responses": {
+          "200": {
+            "description": "all the segment templates ordered by key",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/segmentTemplate"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "createSegmentTemplate",
+        "parameters": [
+          {
+            "description": "create a segment template",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/createSegmentTemplateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created segment template",
+            "schema": {
+              "$ref": "#/definitions/segmentTemplate"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/segment_templates/{segmentTemplateID}": {
+      "get": {
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "getSegmentTemplate",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the segment template",
+            "schema": {
+              "$ref": "#/definitions/segmentTemplate"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "replaces the description and the segment of the segment template, the segments already created from it are not changed",
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "putSegmentTemplate",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "update a segment template",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/putSegmentTemplateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the segment template",
+            "schema": {
+              "$ref": "#/definitions/segmentTemplate"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "deleteSegmentTemplate",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "deleted"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/segment_templates/{segmentTemplateID}/apply": {
+      "post": {
+        "description": "creates a segment of the flag from the segment template, the segment is appended after the existing segments of the flag",
+        "tags": [
+          "segmentTemplate"
+        ],
+        "operationId": "applySegmentTemplate",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "numeric ID of the segment template",
+            "name": "segmentTemplateID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "the flag to create the segment on and its variants referenced by the distributions",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/applySegmentTemplateRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "returns the created segment",
+            "schema": {
+              "$ref": "#/definitions/segment"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/tags": {
+      "get": {
+        "tags": [
+          "tag"
+        ],
+        "operationId": "findAllTags",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "the numbers of tags to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "return tags given the offset, it should usually set together with limit",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "return tags partially matching given value",
+            "name": "value_like",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "all the tags ordered by value",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/tag"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/tags/bulk": {
+      "post": {
+        "description": "adds a tag to flags, removes a tag from flags or renames a tag, in one transaction. Renaming a tag to an existing one merges them.",
+        "tags": [
+          "tag"
+        ],
+        "operationId": "bulkTags",
+        "parameters": [
+          {
+            "description": "the tag operations, they're applied in order",
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/bulkTagsRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the number of flags affected by each operation",
+            "schema": {
+              "$ref": "#/definitions/bulkTagsResponse"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "applySegmentTemplateRequest": {
+      "type": "object",
+      "required": [
+        "flagID"
+      ],
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variants": {
+          "description": "the variants of the flag filling in the variant keys of the template, the keys left out are filled in by the variants of the flag with the same keys",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentTemplateVariant"
+          }
+        }
+      }
+    },
+    "bucketCheck": {
+      "type": "object",
+      "required": [
+        "flagID",
+        "n",
+        "segments"
+      ],
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "n": {
+          "description": "the number of synthetic entity IDs bucketed",
+          "type": "integer",
+          "format": "int64"
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentBucketCheck"
+          }
+        }
+      }
+    },
+    "bulkTagOperation": {
+      "type": "object",
+      "required": [
+        "op",
+        "value"
+      ],
+      "properties": {
+        "flagIDs": {
+          "description": "the flags to add the tag to or remove it from",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        },
+        "newValue": {
+          "description": "the new value of the renamed tag, the tags are merged if a tag of newValue exists",
+          "type": "string"
+        },
+        "op": {
+          "description": "add or remove the tag of value to or from the flags of flagIDs, or rename the tag of value to newValue",
+          "type": "string",
+          "enum": [
+            "add",
+            "remove",
+            "rename"
+          ]
+        },
+        "value": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "bulkTagOperationResult": {
+      "type": "object",
+      "required": [
+        "op",
+        "value",
+        "affectedFlags"
+      ],
+      "properties": {
+        "affectedFlags": {
+          "description": "the number of flags whose tags are changed by the operation",
+          "type": "integer",
+          "format": "int64"
+        },
+        "op": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "bulkTagsRequest": {
+      "type": "object",
+      "required": [
+        "operations"
+      ],
+      "properties": {
+        "operations": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/bulkTagOperation"
+          }
+        }
+      }
+    },
+    "bulkTagsResponse": {
+      "type": "object",
+      "required": [
+        "results"
+      ],
+      "properties": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/bulkTagOperationResult"
+          }
+        }
+      }
+    },
+    "cloneFlagRequest": {
+      "type": "object",
+      "properties": {
+        "key": {
+          "description": "the key of the new flag, it defaults to the key of the flag suffixed with _clone",
+          "type": "string"
+        }
+      }
+    },
+    "constraint": {
+      "type": "object",
+      "required": [
+        "property",
+        "operator"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "operator": {
+          "type": "string",
+          "minLength": 1,
+          "enum": [
+            "EQ",
+            "NEQ",
+            "LT",
+            "LTE",
+            "GT",
+            "GTE",
+            "EREG",
+            "NEREG",
+            "IN",
+            "NOTIN",
+            "CONTAINS",
+            "NOTCONTAINS",
+            "BEFORE",
+            "AFTER",
+            "BETWEEN",
+            "ARRAY_CONTAINS_ANY",
+            "ARRAY_CONTAINS_ALL",
+            "EXISTS",
+            "NOT_EXISTS",
+            "NUMBER_BETWEEN"
+          ]
+        },
+        "property": {
+          "description": "the key of the entityContext, or a path of keys separated by dots into the objects of the entityContext, e.g. device.os",
+          "type": "string",
+          "minLength": 1
+        },
+        "value": {
+          "description": "the value to compare the property with, it's required by all the operators but EXISTS and NOT_EXISTS, which ignore it",
+          "type": "string",
+          "x-nullable": true
+        }
+      }
+    },
+    "constraintDebugLog": {
+      "type": "object",
+      "properties": {
+        "constraintID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "matched": {
+          "type": "boolean"
+        },
+        "msg": {
+          "type": "string"
+        },
+        "operator": {
+          "type": "string"
+        },
+        "property": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "constraintEvaluationResult": {
+      "type": "object",
+      "properties": {
+        "entityContext": {
+          "type": "object"
+        },
+        "error": {
+          "description": "the error of parsing the constraint or evaluating it against the entity context, the constraint doesn't pass if it's not empty",
+          "type": "string"
+        },
+        "passed": {
+          "type": "boolean"
+        }
+      }
+    },
+    "constraintGroup": {
+      "type": "object",
+      "required": [
+        "key",
+        "constraints"
+      ],
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraint"
+          }
+        },
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the constraint group",
+          "type": "string",
+          "minLength": 1
+        },
+        "namespace": {
+          "description": "the namespace of the constraint group, it's the namespace of the creator if the namespaces are enabled by FLAGR_JWT_AUTH_NAMESPACE_CLAIM",
+          "type": "string",
+          "readOnly": true
+        },
+        "segmentIDs": {
+          "description": "the segments referencing the constraint group",
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "createConstraintGroupRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createConstraintRequest"
+          }
+        },
+        "description": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "createConstraintRequest": {
+      "type": "object",
+      "required": [
+        "property",
+        "operator"
+      ],
+      "properties": {
+        "operator": {
+          "type": "string",
+          "minLength": 1
+        },
+        "property": {
+          "description": "the key of the entityContext, or a path of keys separated by dots into the objects of the entityContext, e.g. device.os",
+          "type": "string",
+          "minLength": 1
+        },
+        "value": {
+          "description": "the value to compare the property with, it's required by all the operators but EXISTS and NOT_EXISTS, which ignore it",
+          "type": "string",
+          "x-nullable": true
+        }
+      }
+    },
+    "createExclusionGroupRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "createFlagRequest": {
+      "type": "object",
+      "required": [
+        "description"
+      ],
+      "properties": {
+        "dataRecordsEnabled": {
+          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka. It's disabled if not provided",
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "expiresAt": {
+          "description": "the flag is disabled automatically once it expires",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "key": {
+          "description": "unique key representation of the flag, it's generated if not provided. The key should match ^[a-z]+[a-z0-9_-]*$, and a used key is rejected with 409",
+          "type": "string"
+        }
+      }
+    },
+    "createFlagScheduleRequest": {
+      "type": "object",
+      "required": [
+        "action",
+        "scheduledAt"
+      ],
+      "properties": {
+        "action": {
+          "type": "string",
+          "enum": [
+            "enable",
+            "disable"
+          ]
+        },
+        "scheduledAt": {
+          "description": "RFC3339 time to apply the action, it has to be in the future",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "createSegmentRequest": {
+      "type": "object",
+      "required": [
+        "description",
+        "rolloutPercent"
+      ],
+      "properties": {
+        "constraintGroupID": {
+          "description": "the constraint group evaluated together with the constraints of the segment, there's none if it's 0",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
+        }
+      }
+    },
+    "createSegmentTemplateRequest": {
+      "type": "object",
+      "required": [
+        "key",
+        "segment"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        },
+        "segment": {
+          "description": "the distributions reference the variants by key, the keys are filled in when the template is applied",
+          "$ref": "#/definitions/segmentDefinition"
+        }
+      }
+    },
+    "createTagRequest": {
+      "type": "object",
+      "required": [
+        "value"
+      ],
+      "properties": {
+        "value": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "createVariantRequest": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "attachment": {
+          "type": "object"
+        },
+        "attachmentTypes": {
+          "description": "the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string",
+            "enum": [
+              "string",
+              "number",
+              "integer",
+              "boolean",
+              "json"
+            ]
+          }
+        },
+        "key": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "distribution": {
+      "type": "object",
+      "required": [
+        "percent",
+        "variantID",
+        "variantKey"
+      ],
+      "properties": {
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "percent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
+        },
+        "rolloutSchedule": {
+          "$ref": "#/definitions/distributionRolloutSchedule"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variantKey": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "distributionDefinition": {
+      "type": "object",
+      "required": [
+        "variantKey",
+        "percent"
+      ],
+      "properties": {
+        "percent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
+        },
+        "rolloutSchedule": {
+          "$ref": "#/definitions/distributionRolloutSchedule"
+        },
+        "variantKey": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "distributionRolloutSchedule": {
+      "description": "it ramps the percent of the distribution from startPercent to endPercent between startAt and endAt, and the other distributions of the segment share the rest by their percents. The entities already in the distribution stay in it as the percent increases. At most one distribution of a segment can have a rollout schedule",
+      "type": "object",
+      "required": [
+        "startAt",
+        "endAt",
+        "startPercent",
+        "endPercent"
+      ],
+      "properties": {
+        "endAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "endPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
+        },
+        "startAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "startPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
+        }
+      }
+    },
+    "error": {
+      "type": "object",
+      "required": [
+        "message"
+      ],
+      "properties": {
+        "errorCode": {
+          "description": "the machine-readable code of the evaluation errors, it's empty for the other errors. INVALID_REQUEST is the request that can't be evaluated, EVAL_DEBUG_DISABLED is the explanation with the debugging disabled, ENRICHMENT_FAILED is the failed enrichment of the entityContext, RATE_LIMITED is the throttled frontend events, and EVAL_CACHE_REFRESH_FAILED is the failed refresh of the evaluation cache",
+          "type": "string",
+          "enum": [
+            "INVALID_REQUEST",
+            "EVAL_DEBUG_DISABLED",
+            "ENRICHMENT_FAILED",
+            "RATE_LIMITED",
+            "EVAL_CACHE_REFRESH_FAILED"
+          ]
+        },
+        "message": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "evalCacheRefreshResponse": {
+      "type": "object",
+      "required": [
+        "flagsCount",
+        "durationMillis"
+      ],
+      "properties": {
+        "durationMillis": {
+          "description": "the duration of loading the flags in milliseconds",
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagsCount": {
+          "description": "the number of flags loaded into the evaluation cache",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "evalContext": {
+      "type": "object",
+      "properties": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entityContext": {
+          "type": "object"
+        },
+        "entityID": {
+          "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
+          "type": "string"
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "environment": {
+          "description": "the environment whose config of the flag is evaluated, e.g. prod. It falls back to the X-Flagr-Environment header, and the flag's own config is evaluated without it or if the flag has no config of the environment",
+          "type": "string"
+        },
+        "flagID": {
+          "description": "flagID",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "flagKey": {
+          "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
+          "type": "string"
+        },
+        "includeAllMatches": {
+          "description": "returns all the segments whose constraints passed in matchedSegments of the result, the variant is still from the first one",
+          "type": "boolean"
+        }
+      }
+    },
+    "evalDebugLog": {
+      "type": "object",
+      "properties": {
+        "exclusionGroupDebugLog": {
+          "$ref": "#/definitions/exclusionGroupDebugLog"
+        },
+        "msg": {
+          "type": "string"
+        },
+        "prerequisiteDebugLog": {
+          "$ref": "#/definitions/prerequisiteDebugLog"
+        },
+        "reason": {
+          "description": "the reason of the final decision, one of FLAG_NOT_FOUND, FLAG_DISABLED, OVERRIDDEN, PREREQUISITE_NOT_MET, EXCLUDED_BY_GROUP, NO_SEGMENTS, SEGMENT_MATCHED, OUT_OF_ROLLOUT, NO_SEGMENT_MATCHED",
+          "type": "string"
+        },
+        "segmentDebugLogs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentDebugLog"
+          }
+        }
+      }
+    },
+    "evalResult": {
+      "type": "object",
+      "properties": {
+        "errorCode": {
+          "description": "why no variant is resolved, it's empty when a variant is. FLAG_NOT_FOUND and FLAG_DISABLED are about the flag, INVALID_CONTEXT is when the entityContext can't be evaluated against the constraints of a segment, and NO_SEGMENT_MATCH is when the entity is not in any segment or rollout, see evalDebugLog.reason for the details",
+          "type": "string",
+          "enum": [
+            "FLAG_NOT_FOUND",
+            "FLAG_DISABLED",
+            "INVALID_CONTEXT",
+            "NO_SEGMENT_MATCH"
+          ]
+        },
+        "evalContext": {
+          "$ref": "#/definitions/evalContext"
+        },
+        "evalDebugLog": {
+          "$ref": "#/definitions/evalDebugLog"
+        },
+        "eventType": {
+          "description": "the type of the frontend event, only set when source is frontend",
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        },
+        "flagSnapshotID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "isDefaultVariant": {
+          "description": "it's true if no segment matched and the variant is the flag's default variant",
+          "type": "boolean"
+        },
+        "isOverridden": {
+          "description": "it's true if the variant is forced by the override of the flag, the segments are not evaluated then",
+          "type": "boolean"
+        },
+        "matchedSegments": {
+          "description": "all the segments whose constraints passed in the order of rank, only set when includeAllMatches is requested. It's left out of the data records",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/matchedSegment"
+          },
+          "x-omitempty": true
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "source": {
+          "description": "where the record comes from. It's empty for the server side evaluations, and frontend for the events reported by the frontend clients",
+          "type": "string"
+        },
+        "timestamp": {
+          "type": "string"
+        },
+        "typedVariantAttachment": {
+          "description": "the attachment coerced into the attachmentTypes of the variant, only set when the variant declares them",
+          "type": "object"
+        },
+        "variantAttachment": {
+          "type": "object"
+        },
+        "variantAttachmentError": {
+          "description": "the reason why the attachment doesn't conform to the attachmentTypes of the variant, typedVariantAttachment is not set then",
+          "type": "string"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
+        }
+      }
+    },
+    "evaluateConstraintRequest": {
+      "type": "object",
+      "required": [
+        "constraint",
+        "entityContexts"
+      ],
+      "properties": {
+        "constraint": {
+          "$ref": "#/definitions/createConstraintRequest"
+        },
+        "entityContexts": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "object"
+          }
+        }
+      }
+    },
+    "evaluateConstraintResponse": {
+      "type": "object",
+      "properties": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintEvaluationResult"
+          }
+        }
+      }
+    },
+    "evaluationBatchRequest": {
+      "type": "object",
+      "required": [
+        "entities"
+      ],
+      "properties": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entities": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/evaluationEntity"
+          }
+        },
+        "environment": {
+          "description": "the environment whose configs of the flags are evaluated, it falls back to the X-Flagr-Environment header",
+          "type": "string"
+        },
+        "flagIDs": {
+          "description": "flagIDs",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        },
+        "flagKeys": {
+          "description": "flagKeys. Either flagIDs or flagKeys works. If pass in both, Flagr may return duplicate results.",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "includeAllMatches": {
+          "type": "boolean"
+        }
+      }
+    },
+    "evaluationBatchResponse": {
+      "type": "object",
+      "required": [
+        "evaluationResults"
+      ],
+      "properties": {
+        "evaluationResults": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/evalResult"
+          }
+        }
+      }
+    },
+    "evaluationByTagRequest": {
+      "type": "object",
+      "required": [
+        "tag",
+        "entity"
+      ],
+      "properties": {
+        "enableDebug": {
+          "type": "boolean"
+        },
+        "entity": {
+          "$ref": "#/definitions/evaluationEntity"
+        },
+        "environment": {
+          "description": "the environment whose configs of the flags are evaluated, it falls back to the X-Flagr-Environment header",
+          "type": "string"
+        },
+        "includeAllMatches": {
+          "type": "boolean"
+        },
+        "tag": {
+          "description": "the value of the tag that the flags carry",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "evaluationEntity": {
+      "type": "object",
+      "properties": {
+        "entityContext": {
+          "type": "object"
+        },
+        "entityID": {
+          "type": "string"
+        },
+        "entityType": {
+          "type": "string"
+        }
+      }
+    },
+    "exclusionGroup": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the exclusion group",
+          "type": "string",
+          "minLength": 1
+        },
+        "members": {
+          "description": "the member flags ordered by ID, which is also the order of their buckets",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/exclusionGroupMember"
+          },
+          "readOnly": true
+        }
+      }
+    },
+    "exclusionGroupDebugLog": {
+      "type": "object",
+      "properties": {
+        "bucketNum": {
+          "description": "the bucket of the entity in the group's bucketing space",
+          "type": "integer",
+          "format": "int64"
+        },
+        "exclusionGroupID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagID": {
+          "description": "the member flag that the bucket is routed to, it's 0 if the bucket isn't routed to any member",
+          "type": "integer",
+          "format": "int64"
+        },
+        "included": {
+          "description": "it's true if the bucket is routed to the evaluated flag",
+          "type": "boolean"
+        },
+        "msg": {
+          "type": "string"
+        }
+      }
+    },
+    "exclusionGroupMember": {
+      "type": "object",
+      "properties": {
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        },
+        "percent": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "flag": {
+      "type": "object",
+      "required": [
+        "description",
+        "enabled",
+        "dataRecordsEnabled"
+      ],
+      "properties": {
+        "attachmentSchema": {
+          "description": "the JSON schema (draft 4) that the attachments of the variants are validated against, it's not set if it's empty",
+          "type": "object"
+        },
+        "bucketBy": {
+          "description": "the entityContext attribute to bucket by if it's not empty, otherwise (or if the attribute is missing) the entityID is used",
+          "type": "string"
+        },
+        "bucketingAlgorithm": {
+          "description": "the hash of the distribution bucketing of the flag, one of crc32, murmur3 and xxhash. The global FLAGR_BUCKETING_ALGORITHM is used if it's empty. Changing it reshuffles the assignments of the entities.",
+          "type": "string"
+        },
+        "bucketingSeed": {
+          "description": "it will be mixed into the bucketing hash of the flag if it's not empty, otherwise the flag ID is used",
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "dataRecordsEnabled": {
+          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
+          "type": "boolean"
+        },
+        "defaultVariantID": {
+          "description": "the variant returned when no segment matches, it's not set if it's 0",
+          "type": "integer",
+          "format": "int64"
+        },
+        "deletedAt": {
+          "description": "it's only set for the soft deleted flags",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "entityType": {
+          "description": "it will override the entityType in the evaluation logs if it's not empty",
+          "type": "string"
+        },
+        "exclusionGroupID": {
+          "description": "the exclusion group of the flag, it's not in any group if it's 0",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "exclusionGroupPercent": {
+          "description": "the percent of the exclusion group's buckets routed to the flag",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "expiresAt": {
+          "description": "the flag is disabled automatically once it expires",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "key": {
+          "description": "unique key representation of the flag",
+          "type": "string",
+          "minLength": 1
+        },
+        "lastEvaluatedAt": {
+          "description": "the last time the flag was evaluated, it's null if it's never evaluated. It's flushed from the evaluations every FLAGR_FLAG_LAST_EVALUATED_FLUSH_INTERVAL",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true,
+          "readOnly": true
+        },
+        "namespace": {
+          "description": "the namespace of the flag, it's the namespace of the creator if the namespaces are enabled by FLAGR_JWT_AUTH_NAMESPACE_CLAIM",
+          "type": "string",
+          "readOnly": true
+        },
+        "notes": {
+          "description": "flag usage details in markdown format",
+          "type": "string"
+        },
+        "overrideEnabled": {
+          "description": "the flag evaluates to overrideVariantID for every entity if it's true, ignoring the segments",
+          "type": "boolean",
+          "readOnly": true
+        },
+        "overrideVariantID": {
+          "description": "the variant the flag is forced to, there's no override if it's 0",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "prerequisiteFlagID": {
+          "description": "the flag that has to evaluate to prerequisiteVariantKey for the same entity before this flag is evaluated, it's not set if it's 0",
+          "type": "integer",
+          "format": "int64"
+        },
+        "prerequisiteVariantKey": {
+          "description": "the expected variant of the prerequisite flag, any variant is accepted if it's empty",
+          "type": "string"
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segment"
+          }
+        },
+        "tags": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/tag"
+          }
+        },
+        "updatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "updatedBy": {
+          "type": "string"
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variant"
+          }
+        }
+      }
+    },
+    "flagChange": {
+      "description": "a change of the flag pending the approval of another user than its requester, it's applied once it's approved. It's either the enabled state of the flag, or the distributions of one of its segments",
+      "type": "object",
+      "required": [
+        "id",
+        "flagID",
+        "type",
+        "status",
+        "requestedBy"
+      ],
+      "properties": {
+        "approvedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "approvedBy": {
+          "type": "string"
+        },
+        "distributions": {
+          "description": "the new distributions of the segment, for the distributions changes",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        },
+        "enabled": {
+          "description": "the enabled state of the flag, for the enabled changes",
+          "type": "boolean"
+        },
+        "environment": {
+          "description": "the environment of the change, it's the flag's own config if it's not set",
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "note": {
+          "type": "string"
+        },
+        "requestedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "requestedBy": {
+          "type": "string"
+        },
+        "segmentID": {
+          "description": "the segment of the distributions, for the distributions changes",
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "type": "string",
+          "enum": [
+            "pending",
+            "approved"
+          ]
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "enabled",
+            "distributions"
+          ]
+        }
+      }
+    },
+    "flagDefinition": {
+      "description": "the self-contained definition of a flag. The variants are referenced by their keys, so it doesn't depend on the ids of any environment",
+      "type": "object",
+      "required": [
+        "description"
+      ],
+      "properties": {
+        "attachmentSchema": {
+          "description": "the JSON schema (draft 4) that the attachments of the variants are validated against",
+          "type": "object"
+        },
+        "bucketBy": {
+          "type": "string"
+        },
+        "bucketingAlgorithm": {
+          "type": "string"
+        },
+        "bucketingSeed": {
+          "type": "string"
+        },
+        "dataRecordsEnabled": {
+          "type": "boolean"
+        },
+        "defaultVariantKey": {
+          "description": "the key of the variant returned when no segment matches",
+          "type": "string"
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "entityType": {
+          "type": "string"
+        },
+        "key": {
+          "description": "unique key representation of the flag, a random key is generated if it's empty",
+          "type": "string"
+        },
+        "notes": {
+          "type": "string"
+        },
+        "segments": {
+          "description": "segments in the order of evaluation",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/segmentDefinition"
+          }
+        },
+        "tags": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createVariantRequest"
+          }
+        }
+      }
+    },
+    "flagHistory": {
+      "type": "object",
+      "required": [
+        "id",
+        "action",
+        "entityType",
+        "entityID",
+        "createdAt"
+      ],
+      "properties": {
+        "action": {
+          "type": "string",
+          "enum": [
+            "create",
+            "update",
+            "delete"
+          ]
+        },
+        "actor": {
+          "type": "string"
+        },
+        "createdAt": {
+          "type": "string",
+          "minLength": 1
+        },
+        "diff": {
+          "description": "the changed fields, each of them has the before and after values",
+          "type": "object"
+        },
+        "entityID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "entityType": {
+          "type": "string",
+          "enum": [
+            "flag",
+            "segment",
+            "constraint",
+            "distribution",
+            "environment",
+            "constraint_group"
+          ]
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "note": {
+          "description": "the rationale of the change given by the actor",
+          "type": "string"
+        }
+      }
+    },
+    "flagSchedule": {
+      "type": "object",
+      "required": [
+        "id",
+        "flagID",
+        "action",
+        "scheduledAt"
+      ],
+      "properties": {
+        "action": {
+          "type": "string",
+          "enum": [
+            "enable",
+            "disable"
+          ]
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "scheduledAt": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "flagSnapshot": {
+      "type": "object",
+      "required": [
+        "id",
+        "flag",
+        "updatedAt"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flag"
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "updatedAt": {
+          "type": "string",
+          "minLength": 1
+        },
+        "updatedBy": {
+          "type": "string"
+        }
+      }
+    },
+    "flagSnapshotDiff": {
+      "type": "object",
+      "required": [
+        "fromSnapshotID",
+        "toSnapshotID",
+        "flag",
+        "variants",
+        "segments"
+      ],
+      "properties": {
+        "flag": {
+          "description": "the changed fields of the flag itself, e.g. Enabled",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotFieldDiff"
           }
-        }
-      },
-      "post": {
-        "tags": [
-          "variant"
-        ],
-        "operationId": "createVariant",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "create a variant",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/createVariantRequest"
-            }
+        },
+        "fromSnapshotID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "segments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotSegmentDiff"
           }
-        ],
-        "responses": {
-          "200": {
-            "description": "variant just created",
-            "schema": {
-              "$ref": "#/definitions/variant"
-            }
-          },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
+        },
+        "toSnapshotID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotEntityDiff"
           }
         }
       }
     },
-    "/flags/{flagID}/variants/{variantID}": {
-      "put": {
-        "tags": [
-          "variant"
-        ],
-        "operationId": "putVariant",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the variant",
-            "name": "variantID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "description": "update a variant",
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/putVariantRequest"
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "variant just updated",
-            "schema": {
-              "$ref": "#/definitions/variant"
-            }
-          },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
+    "flagSnapshotEntityDiff": {
+      "type": "object",
+      "required": [
+        "id",
+        "changeType",
+        "fields"
+      ],
+      "properties": {
+        "changeType": {
+          "type": "string",
+          "enum": [
+            "added",
+            "removed",
+            "changed"
+          ]
+        },
+        "fields": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotFieldDiff"
           }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
         }
-      },
-      "delete": {
-        "tags": [
-          "variant"
-        ],
-        "operationId": "deleteVariant",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the flag",
-            "name": "flagID",
-            "in": "path",
-            "required": true
-          },
-          {
-            "minimum": 1,
-            "type": "integer",
-            "format": "int64",
-            "description": "numeric ID of the variant",
-            "name": "variantID",
-            "in": "path",
-            "required": true
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "deleted"
-          },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          }
+      }
+    },
+    "flagSnapshotFieldDiff": {
+      "type": "object",
+      "required": [
+        "field"
+      ],
+      "properties": {
+        "after": {
+          "description": "the value in the snapshot to, it's null if the field is removed"
+        },
+        "before": {
+          "description": "the value in the snapshot from, it's null if the field is added"
+        },
+        "field": {
+          "type": "string",
+          "minLength": 1
         }
       }
     },
-    "/health": {
-      "get": {
-        "description": "Check if Flagr is healthy",
-        "tags": [
-          "health"
-        ],
-        "operationId": "getHealth",
-        "responses": {
-          "200": {
-            "description": "OK"
-          },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
+    "flagSnapshotSegmentDiff": {
+      "type": "object",
+      "required": [
+        "id",
+        "changeType",
+        "fields",
+        "constraints",
+        "distributions"
+      ],
+      "properties": {
+        "changeType": {
+          "type": "string",
+          "enum": [
+            "added",
+            "removed",
+            "changed"
+          ]
+        },
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotEntityDiff"
           }
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotEntityDiff"
+          }
+        },
+        "fields": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/flagSnapshotFieldDiff"
+          }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
         }
       }
-    }
-  },
-  "definitions": {
-    "constraint": {
+    },
+    "frontendEvent": {
       "type": "object",
       "required": [
-        "property",
-        "operator",
-        "value"
+        "flagKey",
+        "variantKey",
+        "entityID",
+        "eventType"
       ],
       "properties": {
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
+        "entityID": {
+          "type": "string",
+          "minLength": 1
         },
-        "operator": {
+        "entityType": {
+          "type": "string"
+        },
+        "eventType": {
           "type": "string",
-          "minLength": 1,
           "enum": [
-            "EQ",
-            "NEQ",
-            "LT",
-            "LTE",
-            "GT",
-            "GTE",
-            "EREG",
-            "NEREG",
-            "IN",
-            "NOTIN",
-            "CONTAINS",
-            "NOTCONTAINS"
+            "impression",
+            "conversion"
           ]
         },
-        "property": {
+        "flagKey": {
           "type": "string",
           "minLength": 1
         },
-        "value": {
+        "timestamp": {
+          "description": "RFC3339 time when the event happened. It defaults to the time the server receives it",
+          "type": "string"
+        },
+        "variantKey": {
           "type": "string",
           "minLength": 1
         }
       }
     },
-    "createConstraintRequest": {
+    "frontendEventsRequest": {
       "type": "object",
       "required": [
-        "property",
-        "operator",
-        "value"
+        "events"
       ],
       "properties": {
-        "operator": {
-          "type": "string",
-          "minLength": 1
+        "events": {
+          "type": "array",
+          "maxItems": 100,
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/frontendEvent"
+          }
+        }
+      }
+    },
+    "importFlagRequest": {
+      "type": "object",
+      "required": [
+        "flag"
+      ],
+      "properties": {
+        "flag": {
+          "$ref": "#/definitions/flagDefinition"
         },
-        "property": {
-          "type": "string",
-          "minLength": 1
+        "key": {
+          "description": "it overrides the key of the flag definition if it's not empty, useful to avoid key collisions",
+          "type": "string"
+        }
+      }
+    },
+    "matchedSegment": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
         },
-        "value": {
+        "rank": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "prerequisiteDebugLog": {
+      "type": "object",
+      "properties": {
+        "expectedVariantKey": {
+          "description": "the expected variant of the prerequisite flag, any variant is accepted if it's empty",
+          "type": "string"
+        },
+        "flagID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "flagKey": {
+          "type": "string"
+        },
+        "met": {
+          "type": "boolean"
+        },
+        "msg": {
+          "type": "string"
+        },
+        "variantKey": {
+          "description": "the variant the prerequisite flag evaluated to",
+          "type": "string"
+        }
+      }
+    },
+    "promoteFlagEnvironmentRequest": {
+      "type": "object",
+      "required": [
+        "to"
+      ],
+      "properties": {
+        "note": {
+          "description": "the rationale of the change, it's stored in the history of the flag",
+          "type": "string"
+        },
+        "to": {
+          "description": "the environment the config is copied to, its enabled state and distributions are replaced",
           "type": "string",
           "minLength": 1
         }
       }
     },
-    "createFlagRequest": {
+    "putConstraintGroupRequest": {
+      "type": "object",
+      "properties": {
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createConstraintRequest"
+          }
+        },
+        "description": {
+          "type": "string"
+        }
+      }
+    },
+    "putDistributionsRequest": {
       "type": "object",
       "required": [
-        "description"
+        "distributions"
       ],
+      "properties": {
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distribution"
+          }
+        }
+      }
+    },
+    "putExclusionGroupRequest": {
+      "type": "object",
       "properties": {
         "description": {
+          "type": "string"
+        }
+      }
+    },
+    "putFlagExclusionGroupRequest": {
+      "type": "object",
+      "required": [
+        "exclusionGroupID"
+      ],
+      "properties": {
+        "exclusionGroupID": {
+          "description": "the exclusion group to join, the flag leaves its group if it's 0",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        },
+        "percent": {
+          "description": "the percent of the group's buckets routed to the flag, the percents of all the members add up to at most 100",
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
+        }
+      }
+    },
+    "putFlagOverrideRequest": {
+      "type": "object",
+      "required": [
+        "variantID"
+      ],
+      "properties": {
+        "enabled": {
+          "description": "the override is kept but not applied if it's false",
+          "type": "boolean"
+        },
+        "variantID": {
+          "description": "the variant to force the flag to, the override is cleared if it's 0",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "putFlagRequest": {
+      "type": "object",
+      "properties": {
+        "attachmentSchema": {
+          "description": "the JSON schema (draft 4) that the attachments of the variants are validated against, the existing variants are validated when it's set. $ref and id are not supported. Set it to {} to unset it",
+          "type": "object"
+        },
+        "bucketBy": {
+          "description": "the entityContext attribute to bucket by if it's not empty, otherwise (or if the attribute is missing) the entityID is used",
           "type": "string",
-          "minLength": 1
+          "x-nullable": true
+        },
+        "bucketingAlgorithm": {
+          "description": "the hash of the distribution bucketing of the flag, one of crc32, murmur3 and xxhash. The global FLAGR_BUCKETING_ALGORITHM is used if it's empty. Changing it reshuffles the assignments of the entities.",
+          "type": "string",
+          "x-nullable": true
+        },
+        "bucketingSeed": {
+          "description": "it will be mixed into the bucketing hash of the flag if it's not empty, otherwise the flag ID is used",
+          "type": "string",
+          "x-nullable": true
+        },
+        "dataRecordsEnabled": {
+          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "defaultVariantID": {
+          "description": "the variant returned when no segment matches, set it to 0 to unset it",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0,
+          "x-nullable": true
+        },
+        "description": {
+          "type": "string",
+          "minLength": 1,
+          "x-nullable": true
+        },
+        "enabled": {
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "entityType": {
+          "description": "it will overwrite entityType into evaluation logs if it's not empty",
+          "type": "string",
+          "x-nullable": true
+        },
+        "expiresAt": {
+          "description": "the flag is disabled automatically once it expires, set it to 0001-01-01T00:00:00Z to unset it",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
         },
         "key": {
-          "description": "unique key representation of the flag",
+          "type": "string",
+          "x-nullable": true
+        },
+        "note": {
+          "description": "the rationale of the change, it's stored in the history of the flag",
           "type": "string"
+        },
+        "notes": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "prerequisiteFlagID": {
+          "description": "the flag that has to evaluate to prerequisiteVariantKey for the same entity before this flag is evaluated, set it to 0 to unset it",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0,
+          "x-nullable": true
+        },
+        "prerequisiteVariantKey": {
+          "description": "the expected variant of the prerequisite flag, any variant is accepted if it's empty",
+          "type": "string",
+          "x-nullable": true
         }
       }
     },
-    "createSegmentRequest": {
+    "putSegmentReorderRequest": {
+      "type": "object",
+      "required": [
+        "segmentIDs"
+      ],
+      "properties": {
+        "segmentIDs": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
+        }
+      }
+    },
+    "putSegmentRequest": {
       "type": "object",
       "required": [
         "description",
         "rolloutPercent"
       ],
       "properties": {
+        "constraintGroupID": {
+          "description": "the constraint group evaluated together with the constraints of the segment, 0 removes the reference and null keeps it",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0,
+          "x-nullable": true
+        },
         "description": {
           "type": "string",
           "minLength": 1
@@ -3008,234 +10692,324 @@ func init() {
         }
       }
     },
-    "createVariantRequest": {
+    "putSegmentTemplateRequest": {
       "type": "object",
       "required": [
-        "key"
+        "segment"
       ],
       "properties": {
-        "attachment": {
-          "type": "object"
+        "description": {
+          "type": "string"
         },
-        "key": {
-          "type": "string",
-          "minLength": 1
+        "segment": {
+          "$ref": "#/definitions/segmentDefinition"
         }
       }
     },
-    "distribution": {
+    "putVariantRequest": {
       "type": "object",
       "required": [
-        "percent",
-        "variantID",
-        "variantKey"
+        "key"
       ],
       "properties": {
-        "id": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
-        },
-        "percent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100,
-          "minimum": 0
+        "attachment": {
+          "type": "object"
         },
-        "variantID": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 1
+        "attachmentTypes": {
+          "description": "the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string",
+            "enum": [
+              "string",
+              "number",
+              "integer",
+              "boolean",
+              "json"
+            ]
+          }
         },
-        "variantKey": {
+        "key": {
           "type": "string",
           "minLength": 1
         }
       }
     },
-    "error": {
+    "readiness": {
+      "type": "object",
+      "properties": {
+        "warnings": {
+          "description": "the problems that don't stop Flagr from serving the evaluations, e.g. the backlog of the data records over the high-water mark",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "rebalanceDistributionsRequest": {
       "type": "object",
       "required": [
-        "message"
+        "variantIDs"
       ],
       "properties": {
-        "message": {
-          "type": "string",
-          "minLength": 1
+        "variantIDs": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "format": "int64",
+            "minimum": 1
+          }
         }
       }
     },
-    "evalContext": {
+    "rolloutSimulation": {
       "type": "object",
+      "required": [
+        "flagID",
+        "segmentID",
+        "n",
+        "variants",
+        "flipped",
+        "flips"
+      ],
       "properties": {
-        "enableDebug": {
-          "type": "boolean"
+        "flagID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
         },
-        "entityContext": {
-          "type": "object"
+        "flipped": {
+          "description": "the number of entity IDs that would get another variant, or get in or out of the rollout",
+          "type": "integer",
+          "format": "int64"
         },
-        "entityID": {
-          "description": "entityID is used to deterministically at random to evaluate the flag result. If it's empty, flagr will randomly generate one.",
-          "type": "string"
+        "flips": {
+          "description": "the entity IDs that would flip, the first 1000 of them",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variantFlip"
+          }
         },
-        "entityType": {
-          "type": "string"
+        "n": {
+          "description": "the number of entity IDs simulated",
+          "type": "integer",
+          "format": "int64"
         },
-        "flagID": {
-          "description": "flagID",
+        "segmentID": {
           "type": "integer",
           "format": "int64",
           "minimum": 1
         },
-        "flagKey": {
-          "description": "flagKey. flagID or flagKey will resolve to the same flag. Either works.",
-          "type": "string"
+        "variants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/variantSimulationCount"
+          }
         }
       }
     },
-    "evalDebugLog": {
+    "saveFlagsBatchRequest": {
       "type": "object",
+      "required": [
+        "flags"
+      ],
       "properties": {
-        "msg": {
-          "type": "string"
-        },
-        "segmentDebugLogs": {
+        "flags": {
           "type": "array",
+          "maxItems": 1000,
+          "minItems": 1,
           "items": {
-            "$ref": "#/definitions/segmentDebugLog"
+            "$ref": "#/definitions/flagDefinition"
           }
         }
       }
     },
-    "evalResult": {
+    "saveFlagsBatchResponse": {
       "type": "object",
+      "required": [
+        "results"
+      ],
       "properties": {
-        "evalContext": {
-          "$ref": "#/definitions/evalContext"
-        },
-        "evalDebugLog": {
-          "$ref": "#/definitions/evalDebugLog"
-        },
-        "flagID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "flagKey": {
-          "type": "string"
-        },
-        "flagSnapshotID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "segmentID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "timestamp": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/saveFlagsBatchResult"
+          }
+        }
+      }
+    },
+    "saveFlagsBatchResult": {
+      "type": "object",
+      "properties": {
+        "error": {
           "type": "string"
         },
-        "variantAttachment": {
-          "type": "object"
+        "flag": {
+          "$ref": "#/definitions/flag"
         },
-        "variantID": {
+        "index": {
+          "description": "the index of the flag definition in the request",
           "type": "integer",
           "format": "int64"
-        },
-        "variantKey": {
-          "type": "string"
         }
       }
     },
-    "evaluationBatchRequest": {
+    "segment": {
       "type": "object",
       "required": [
-        "entities"
+        "description",
+        "rank",
+        "rolloutPercent"
       ],
       "properties": {
-        "enableDebug": {
-          "type": "boolean"
+        "constraintGroupID": {
+          "description": "the constraint group evaluated together with the constraints of the segment, there's none if it's 0",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
         },
-        "entities": {
+        "constraints": {
           "type": "array",
-          "minItems": 1,
           "items": {
-            "$ref": "#/definitions/evaluationEntity"
+            "$ref": "#/definitions/constraint"
           }
         },
-        "flagIDs": {
-          "description": "flagIDs",
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "type": "integer",
-            "format": "int64",
-            "minimum": 1
-          }
+        "description": {
+          "type": "string",
+          "minLength": 1
         },
-        "flagKeys": {
-          "description": "flagKeys. Either flagIDs or flagKeys works. If pass in both, Flagr may return duplicate results.",
+        "distributions": {
           "type": "array",
-          "minItems": 1,
           "items": {
-            "type": "string",
-            "minLength": 1
+            "$ref": "#/definitions/distribution"
           }
+        },
+        "id": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1,
+          "readOnly": true
+        },
+        "rank": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 0
+        },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
         }
       }
     },
-    "evaluationBatchResponse": {
+    "segmentBucketCheck": {
+      "description": "unassigned counts the entity IDs falling into no distribution, it's only set when the distributions don't cover all the buckets, e.g. while a rollout schedule ramps",
       "type": "object",
       "required": [
-        "evaluationResults"
+        "segmentID",
+        "variants",
+        "chiSquare",
+        "degreesOfFreedom"
       ],
       "properties": {
-        "evaluationResults": {
+        "chiSquare": {
+          "description": "the chi-square statistic of the observed counts versus the expected ones, the segment rollout percent is not considered",
+          "type": "number",
+          "format": "double"
+        },
+        "degreesOfFreedom": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "description": {
+          "type": "string"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "unassigned": {
+          "$ref": "#/definitions/variantBucketCount"
+        },
+        "variants": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/evalResult"
+            "$ref": "#/definitions/variantBucketCount"
           }
         }
       }
     },
-    "evaluationEntity": {
+    "segmentDebugLog": {
       "type": "object",
       "properties": {
-        "entityContext": {
-          "type": "object"
+        "bucketNum": {
+          "description": "the bucket number the entity falls into, only set when the constraints are matched",
+          "type": "integer",
+          "format": "int64"
         },
-        "entityID": {
-          "type": "string"
+        "constraintDebugLogs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/constraintDebugLog"
+          }
         },
-        "entityType": {
+        "matched": {
+          "description": "whether all the constraints of the segment are matched",
+          "type": "boolean"
+        },
+        "msg": {
           "type": "string"
+        },
+        "segmentID": {
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
         }
       }
     },
-    "flag": {
+    "segmentDefinition": {
       "type": "object",
       "required": [
         "description",
-        "enabled",
-        "dataRecordsEnabled"
+        "rolloutPercent"
       ],
       "properties": {
-        "createdBy": {
-          "type": "string"
+        "constraints": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/createConstraintRequest"
+          }
         },
-        "dataRecordsEnabled": {
-          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
-          "type": "boolean"
+        "description": {
+          "type": "string",
+          "minLength": 1
+        },
+        "distributions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/distributionDefinition"
+          }
         },
+        "rolloutPercent": {
+          "type": "integer",
+          "format": "int64",
+          "maximum": 100,
+          "minimum": 0
+        }
+      }
+    },
+    "segmentTemplate": {
+      "type": "object",
+      "required": [
+        "key",
+        "segment"
+      ],
+      "properties": {
         "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "enabled": {
-          "type": "boolean"
-        },
-        "entityType": {
-          "description": "it will override the entityType in the evaluation logs if it's not empty",
           "type": "string"
         },
         "id": {
@@ -3245,179 +11019,208 @@ func init() {
           "readOnly": true
         },
         "key": {
-          "description": "unique key representation of the flag",
+          "description": "unique key representation of the segment template",
           "type": "string",
           "minLength": 1
         },
-        "notes": {
-          "description": "flag usage details in markdown format",
-          "type": "string"
-        },
-        "segments": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/segment"
-          }
-        },
-        "updatedAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "updatedBy": {
-          "type": "string"
-        },
-        "variants": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/variant"
-          }
+        "segment": {
+          "$ref": "#/definitions/segmentDefinition"
         }
       }
     },
-    "flagSnapshot": {
+    "segmentTemplateVariant": {
       "type": "object",
       "required": [
-        "id",
-        "flag",
-        "updatedAt"
+        "variantKey",
+        "variantID"
       ],
       "properties": {
-        "flag": {
-          "$ref": "#/definitions/flag"
-        },
-        "id": {
+        "variantID": {
+          "description": "the variant of the flag it's filled in with",
           "type": "integer",
           "format": "int64",
           "minimum": 1
         },
-        "updatedAt": {
+        "variantKey": {
+          "description": "the variant key in the distributions of the template",
           "type": "string",
           "minLength": 1
+        }
+      }
+    },
+    "setFlagEnabledRequest": {
+      "type": "object",
+      "required": [
+        "enabled"
+      ],
+      "properties": {
+        "enabled": {
+          "type": "boolean"
         },
-        "updatedBy": {
+        "note": {
+          "description": "the rationale of the change, it's stored in the history of the flag",
           "type": "string"
         }
       }
     },
-    "putDistributionsRequest": {
+    "simulateRolloutRequest": {
       "type": "object",
       "required": [
+        "segmentID",
         "distributions"
       ],
       "properties": {
         "distributions": {
+          "description": "the proposed distributions, they're validated the same way as putting the distributions",
           "type": "array",
           "items": {
             "$ref": "#/definitions/distribution"
           }
-        }
-      }
-    },
-    "putFlagRequest": {
-      "type": "object",
-      "properties": {
-        "dataRecordsEnabled": {
-          "description": "enabled data records will get data logging in the metrics pipeline, for example, kafka.",
-          "type": "boolean",
-          "x-nullable": true
-        },
-        "description": {
-          "type": "string",
-          "minLength": 1,
-          "x-nullable": true
-        },
-        "enabled": {
-          "type": "boolean",
-          "x-nullable": true
         },
-        "entityType": {
-          "description": "it will overwrite entityType into evaluation logs if it's not empty",
-          "type": "string",
-          "x-nullable": true
+        "entityIDs": {
+          "description": "the sample of the active entity IDs, n synthetic entity IDs are simulated if it's empty",
+          "type": "array",
+          "maxItems": 100000,
+          "items": {
+            "type": "string"
+          }
         },
-        "key": {
-          "type": "string",
-          "x-nullable": true
+        "n": {
+          "description": "the number of the synthetic entity IDs to simulate if there's no entityIDs, it's 100000 by default",
+          "type": "integer",
+          "format": "int64",
+          "maximum": 1000000,
+          "minimum": 1
         },
-        "notes": {
-          "type": "string",
-          "x-nullable": true
+        "segmentID": {
+          "description": "the segment the distributions are proposed for",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
         }
       }
     },
-    "putSegmentReorderRequest": {
+    "staleFlag": {
       "type": "object",
       "required": [
-        "segmentIDs"
+        "flag",
+        "reasons"
       ],
       "properties": {
-        "segmentIDs": {
+        "flag": {
+          "$ref": "#/definitions/flag"
+        },
+        "reasons": {
+          "description": "why the flag is stale, it can be for more than one reason",
           "type": "array",
-          "minItems": 1,
           "items": {
-            "type": "integer",
-            "format": "int64",
-            "minimum": 1
+            "type": "string",
+            "enum": [
+              "disabled",
+              "never_evaluated",
+              "single_variant"
+            ]
           }
+        },
+        "singleVariantKey": {
+          "description": "the variant the flag always resolves to, it's only set for the single_variant reason",
+          "type": "string"
         }
       }
     },
-    "putSegmentRequest": {
+    "tag": {
       "type": "object",
       "required": [
-        "description",
-        "rolloutPercent"
+        "value"
       ],
       "properties": {
-        "description": {
-          "type": "string",
-          "minLength": 1
-        },
-        "rolloutPercent": {
+        "id": {
           "type": "integer",
           "format": "int64",
-          "maximum": 100,
-          "minimum": 0
+          "minimum": 1,
+          "readOnly": true
+        },
+        "value": {
+          "type": "string",
+          "minLength": 1
         }
       }
     },
-    "putVariantRequest": {
+    "validateFlagRequest": {
       "type": "object",
       "required": [
-        "key"
+        "flag"
       ],
       "properties": {
-        "attachment": {
-          "type": "object"
+        "flag": {
+          "$ref": "#/definitions/flagDefinition"
         },
-        "key": {
-          "type": "string",
-          "minLength": 1
+        "prerequisiteFlagID": {
+          "description": "the prerequisite flag to check for cycles, as the flag definition doesn't carry it",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "prerequisiteVariantKey": {
+          "type": "string"
         }
       }
     },
-    "segment": {
+    "validateFlagResponse": {
       "type": "object",
       "required": [
-        "description",
-        "rank",
-        "rolloutPercent"
+        "valid",
+        "errors"
       ],
       "properties": {
-        "constraints": {
+        "errors": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/constraint"
+            "$ref": "#/definitions/validationError"
           }
         },
-        "description": {
-          "type": "string",
-          "minLength": 1
+        "valid": {
+          "type": "boolean"
+        }
+      }
+    },
+    "validationError": {
+      "type": "object",
+      "required": [
+        "path",
+        "message"
+      ],
+      "properties": {
+        "message": {
+          "type": "string"
         },
-        "distributions": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/distribution"
+        "path": {
+          "description": "the JSON path of the invalid field in the request body, e.g. $.flag.segments[0].distributions",
+          "type": "string"
+        }
+      }
+    },
+    "variant": {
+      "type": "object",
+      "required": [
+        "key"
+      ],
+      "properties": {
+        "attachment": {
+          "type": "object"
+        },
+        "attachmentTypes": {
+          "description": "the types of the attachment keys, the evaluation returns the attachment coerced into them in typedVariantAttachment. The keys without a type stay strings",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string",
+            "enum": [
+              "string",
+              "number",
+              "integer",
+              "boolean",
+              "json"
+            ]
           }
         },
         "id": {
@@ -3426,61 +11229,86 @@ func init() {
           "minimum": 1,
           "readOnly": true
         },
-        "rank": {
-          "type": "integer",
-          "format": "int64",
-          "minimum": 0
-        },
-        "rolloutPercent": {
-          "type": "integer",
-          "format": "int64",
-          "maximum": 100,
-          "minimum": 0
+        "key": {
+          "type": "string",
+          "minLength": 1
         }
       }
     },
-    "segmentDebugLog": {
+    "variantBucketCount": {
       "type": "object",
+      "required": [
+        "expected",
+        "observed"
+      ],
       "properties": {
-        "msg": {
-          "type": "string"
+        "expected": {
+          "type": "number",
+          "format": "double"
         },
-        "segmentID": {
+        "observed": {
           "type": "integer",
-          "format": "int64",
-          "minimum": 1
+          "format": "int64"
+        },
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
         }
       }
     },
-    "setFlagEnabledRequest": {
+    "variantFlip": {
+      "description": "the variantIDs are 0 if the entity ID is out of the rollout or the distributions",
       "type": "object",
       "required": [
-        "enabled"
+        "entityID"
       ],
       "properties": {
-        "enabled": {
-          "type": "boolean"
+        "entityID": {
+          "type": "string"
+        },
+        "fromVariantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "fromVariantKey": {
+          "type": "string"
+        },
+        "toVariantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "toVariantKey": {
+          "type": "string"
         }
       }
     },
-    "variant": {
+    "variantSimulationCount": {
+      "description": "the entity IDs out of the rollout or the distributions are counted with the variantID 0",
       "type": "object",
       "required": [
-        "key"
+        "current",
+        "proposed"
       ],
       "properties": {
-        "attachment": {
-          "type": "object"
+        "current": {
+          "description": "the number of entity IDs bucketed into the variant by the current distributions",
+          "type": "integer",
+          "format": "int64"
         },
-        "id": {
+        "proposed": {
+          "description": "the number of entity IDs bucketed into the variant by the proposed distributions",
           "type": "integer",
-          "format": "int64",
-          "minimum": 1,
-          "readOnly": true
+          "format": "int64"
         },
-        "key": {
-          "type": "string",
-          "minLength": 1
+        "variantID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "variantKey": {
+          "type": "string"
         }
       }
     }
@@ -3506,6 +11334,22 @@ func init() {
       "description": "Variants are the possible outcomes of flag evaluation",
       "name": "variant"
     },
+    {
+      "description": "Tags are the labels to organize and filter the flags",
+      "name": "tag"
+    },
+    {
+      "description": "Constraint groups are the named sets of constraints shared by the segments referencing them",
+      "name": "constraintGroup"
+    },
+    {
+      "description": "Segment templates are the reusable skeletons of a segment with its constraints and distributions",
+      "name": "segmentTemplate"
+    },
+    {
+      "description": "Exclusion groups split a shared bucketing space across their member flags, so that an entity is in at most one of them",
+      "name": "exclusionGroup"
+    },
     {
       "description": "Evaluation is the process of evaluating a flag given the entity context",
       "name": "evaluation"
@@ -3523,7 +11367,11 @@ func init() {
         "segment",
         "constraint",
         "distribution",
-        "variant"
+        "variant",
+        "tag",
+        "constraintGroup",
+        "segmentTemplate",
+        "exclusionGroup"
       ]
     },
     {
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
@@ -32,6 +33,10 @@ type FindDistributionsParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*get the distributions of the segment in the environment, they're the segment's own if it has none in the environment
+	  In: query
+	*/
+	Environment *string
 	/*numeric ID of the flag
 	  Required: true
 	  Minimum: 1
@@ -55,6 +60,13 @@ func (o *FindDistributionsParams) BindRequest(r *http.Request, route *middleware
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qEnvironment, qhkEnvironment, _ := qs.GetOK("environment")
+	if err := o.bindEnvironment(qEnvironment, qhkEnvironment, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rFlagID, rhkFlagID, _ := route.Params.GetOK("flagID")
 	if err := o.bindFlagID(rFlagID, rhkFlagID, route.Formats); err != nil {
 		res = append(res, err)
@@ -71,6 +83,24 @@ func (o *FindDistributionsParams) BindRequest(r *http.Request, route *middleware
 	return nil
 }
 
+// bindEnvironment binds and validates parameter Environment from query.
+func (o *FindDistributionsParams) bindEnvironment(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Environment = &raw
+
+	return nil
+}
+
 // bindFlagID binds and validates parameter FlagID from path.
 func (o *FindDistributionsParams) bindFlagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -19,6 +19,8 @@ type FindDistributionsURL struct {
 	FlagID    int64
 	SegmentID int64
 
+	Environment *string
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -65,6 +67,18 @@ func (o *FindDistributionsURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var environment string
+	if o.Environment != nil {
+		environment = *o.Environment
+	}
+	if environment != "" {
+		qs.Set("environment", environment)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
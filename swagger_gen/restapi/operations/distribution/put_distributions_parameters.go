@@ -41,6 +41,10 @@ type PutDistributionsParams struct {
 	  In: body
 	*/
 	Body *models.PutDistributionsRequest
+	/*replace the distributions of the segment in the environment, the segment's own distributions are untouched
+	  In: query
+	*/
+	Environment *string
 	/*numeric ID of the flag
 	  Required: true
 	  Minimum: 1
@@ -64,6 +68,8 @@ func (o *PutDistributionsParams) BindRequest(r *http.Request, route *middleware.
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.PutDistributionsRequest
@@ -86,6 +92,11 @@ func (o *PutDistributionsParams) BindRequest(r *http.Request, route *middleware.
 	} else {
 		res = append(res, errors.Required("body", "body"))
 	}
+	qEnvironment, qhkEnvironment, _ := qs.GetOK("environment")
+	if err := o.bindEnvironment(qEnvironment, qhkEnvironment, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rFlagID, rhkFlagID, _ := route.Params.GetOK("flagID")
 	if err := o.bindFlagID(rFlagID, rhkFlagID, route.Formats); err != nil {
 		res = append(res, err)
@@ -102,6 +113,24 @@ func (o *PutDistributionsParams) BindRequest(r *http.Request, route *middleware.
 	return nil
 }
 
+// bindEnvironment binds and validates parameter Environment from query.
+func (o *PutDistributionsParams) bindEnvironment(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Environment = &raw
+
+	return nil
+}
+
 // bindFlagID binds and validates parameter FlagID from path.
 func (o *PutDistributionsParams) bindFlagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -60,6 +60,50 @@ func (o *PutDistributionsOK) WriteResponse(rw http.ResponseWriter, producer runt
 	}
 }
 
+// PutDistributionsAcceptedCode is the HTTP code returned for type PutDistributionsAccepted
+const PutDistributionsAcceptedCode int = 202
+
+/*PutDistributionsAccepted the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED
+
+swagger:response putDistributionsAccepted
+*/
+type PutDistributionsAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.FlagChange `json:"body,omitempty"`
+}
+
+// NewPutDistributionsAccepted creates PutDistributionsAccepted with default headers values
+func NewPutDistributionsAccepted() *PutDistributionsAccepted {
+
+	return &PutDistributionsAccepted{}
+}
+
+// WithPayload adds the payload to the put distributions accepted response
+func (o *PutDistributionsAccepted) WithPayload(payload *models.FlagChange) *PutDistributionsAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put distributions accepted response
+func (o *PutDistributionsAccepted) SetPayload(payload *models.FlagChange) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutDistributionsAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 /*PutDistributionsDefault generic error response
 
 swagger:response putDistributionsDefault
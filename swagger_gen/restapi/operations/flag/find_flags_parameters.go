@@ -12,6 +12,7 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 
 	strfmt "github.com/go-openapi/strfmt"
 )
@@ -44,15 +45,25 @@ type FindFlagsParams struct {
 	  In: query
 	*/
 	Enabled *bool
+	/*list the flags as they're configured in the environment, the enabled filter is on their enabled state in it
+	  In: query
+	*/
+	Environment *string
+	/*return the soft deleted flags as well, only for the admins
+	  In: query
+	*/
+	IncludeDeleted *bool
 	/*return flags matching given key
 	  In: query
 	*/
 	Key *string
-	/*the numbers of flags to return
+	/*the numbers of flags to return, it defaults to FLAGR_FLAGS_DEFAULT_LIMIT and it's capped by FLAGR_FLAGS_MAX_LIMIT
+	  Minimum: 1
 	  In: query
 	*/
 	Limit *int64
 	/*return flags given the offset, it should usually set together with limit
+	  Minimum: 0
 	  In: query
 	*/
 	Offset *int64
@@ -60,6 +71,18 @@ type FindFlagsParams struct {
 	  In: query
 	*/
 	Preload *bool
+	/*search flags by the whitespace separated terms, case-insensitively. Every term has to be part of the key, the description or one of the tags. Flags with the exact key are returned first, and the number of results is capped by FLAGR_FLAGS_SEARCH_MAX_LIMIT
+	  In: query
+	*/
+	Q *string
+	/*return flags having all the given tags, comma separated
+	  In: query
+	*/
+	Tags *string
+	/*return flags not evaluated within the duration, e.g. 30d or 12h, including the ones never evaluated that were created before it
+	  In: query
+	*/
+	UnusedSince *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -88,6 +111,16 @@ func (o *FindFlagsParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qEnvironment, qhkEnvironment, _ := qs.GetOK("environment")
+	if err := o.bindEnvironment(qEnvironment, qhkEnvironment, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qIncludeDeleted, qhkIncludeDeleted, _ := qs.GetOK("includeDeleted")
+	if err := o.bindIncludeDeleted(qIncludeDeleted, qhkIncludeDeleted, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qKey, qhkKey, _ := qs.GetOK("key")
 	if err := o.bindKey(qKey, qhkKey, route.Formats); err != nil {
 		res = append(res, err)
@@ -108,6 +141,21 @@ func (o *FindFlagsParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qQ, qhkQ, _ := qs.GetOK("q")
+	if err := o.bindQ(qQ, qhkQ, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qTags, qhkTags, _ := qs.GetOK("tags")
+	if err := o.bindTags(qTags, qhkTags, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qUnusedSince, qhkUnusedSince, _ := qs.GetOK("unusedSince")
+	if err := o.bindUnusedSince(qUnusedSince, qhkUnusedSince, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -172,6 +220,46 @@ func (o *FindFlagsParams) bindEnabled(rawData []string, hasKey bool, formats str
 	return nil
 }
 
+// bindEnvironment binds and validates parameter Environment from query.
+func (o *FindFlagsParams) bindEnvironment(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Environment = &raw
+
+	return nil
+}
+
+// bindIncludeDeleted binds and validates parameter IncludeDeleted from query.
+func (o *FindFlagsParams) bindIncludeDeleted(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("includeDeleted", "query", "bool", raw)
+	}
+	o.IncludeDeleted = &value
+
+	return nil
+}
+
 // bindKey binds and validates parameter Key from query.
 func (o *FindFlagsParams) bindKey(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -209,6 +297,20 @@ func (o *FindFlagsParams) bindLimit(rawData []string, hasKey bool, formats strfm
 	}
 	o.Limit = &value
 
+	if err := o.validateLimit(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateLimit carries on validations for parameter Limit
+func (o *FindFlagsParams) validateLimit(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("limit", "query", int64(*o.Limit), 1, false); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -231,6 +333,20 @@ func (o *FindFlagsParams) bindOffset(rawData []string, hasKey bool, formats strf
 	}
 	o.Offset = &value
 
+	if err := o.validateOffset(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateOffset carries on validations for parameter Offset
+func (o *FindFlagsParams) validateOffset(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("offset", "query", int64(*o.Offset), 0, false); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -255,3 +371,57 @@ func (o *FindFlagsParams) bindPreload(rawData []string, hasKey bool, formats str
 
 	return nil
 }
+
+// bindQ binds and validates parameter Q from query.
+func (o *FindFlagsParams) bindQ(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Q = &raw
+
+	return nil
+}
+
+// bindTags binds and validates parameter Tags from query.
+func (o *FindFlagsParams) bindTags(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Tags = &raw
+
+	return nil
+}
+
+// bindUnusedSince binds and validates parameter UnusedSince from query.
+func (o *FindFlagsParams) bindUnusedSince(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.UnusedSince = &raw
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 
 	models "github.com/checkr/flagr/swagger_gen/models"
 )
@@ -16,11 +17,15 @@ import (
 // FindFlagsOKCode is the HTTP code returned for type FindFlagsOK
 const FindFlagsOKCode int = 200
 
-/*FindFlagsOK list all the flags
+/*FindFlagsOK list all the flags ordered by id
 
 swagger:response findFlagsOK
 */
 type FindFlagsOK struct {
+	/*the total number of flags matching the filters, regardless of limit and offset
+
+	 */
+	XTotalCount int64 `json:"X-Total-Count"`
 
 	/*
 	  In: Body
@@ -34,6 +39,17 @@ func NewFindFlagsOK() *FindFlagsOK {
 	return &FindFlagsOK{}
 }
 
+// WithXTotalCount adds the xTotalCount to the find flags o k response
+func (o *FindFlagsOK) WithXTotalCount(xTotalCount int64) *FindFlagsOK {
+	o.XTotalCount = xTotalCount
+	return o
+}
+
+// SetXTotalCount sets the xTotalCount to the find flags o k response
+func (o *FindFlagsOK) SetXTotalCount(xTotalCount int64) {
+	o.XTotalCount = xTotalCount
+}
+
 // WithPayload adds the payload to the find flags o k response
 func (o *FindFlagsOK) WithPayload(payload []*models.Flag) *FindFlagsOK {
 	o.Payload = payload
@@ -48,6 +64,13 @@ func (o *FindFlagsOK) SetPayload(payload []*models.Flag) {
 // WriteResponse to the client
 func (o *FindFlagsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header X-Total-Count
+
+	xTotalCount := swag.FormatInt64(o.XTotalCount)
+	if xTotalCount != "" {
+		rw.Header().Set("X-Total-Count", xTotalCount)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
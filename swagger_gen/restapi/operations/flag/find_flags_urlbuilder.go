@@ -18,10 +18,15 @@ type FindFlagsURL struct {
 	Description     *string
 	DescriptionLike *string
 	Enabled         *bool
+	Environment     *string
+	IncludeDeleted  *bool
 	Key             *string
 	Limit           *int64
 	Offset          *int64
 	Preload         *bool
+	Q               *string
+	Tags            *string
+	UnusedSince     *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -81,6 +86,22 @@ func (o *FindFlagsURL) Build() (*url.URL, error) {
 		qs.Set("enabled", enabled)
 	}
 
+	var environment string
+	if o.Environment != nil {
+		environment = *o.Environment
+	}
+	if environment != "" {
+		qs.Set("environment", environment)
+	}
+
+	var includeDeleted string
+	if o.IncludeDeleted != nil {
+		includeDeleted = swag.FormatBool(*o.IncludeDeleted)
+	}
+	if includeDeleted != "" {
+		qs.Set("includeDeleted", includeDeleted)
+	}
+
 	var key string
 	if o.Key != nil {
 		key = *o.Key
@@ -113,6 +134,30 @@ func (o *FindFlagsURL) Build() (*url.URL, error) {
 		qs.Set("preload", preload)
 	}
 
+	var q string
+	if o.Q != nil {
+		q = *o.Q
+	}
+	if q != "" {
+		qs.Set("q", q)
+	}
+
+	var tags string
+	if o.Tags != nil {
+		tags = *o.Tags
+	}
+	if tags != "" {
+		qs.Set("tags", tags)
+	}
+
+	var unusedSince string
+	if o.UnusedSince != nil {
+		unusedSince = *o.UnusedSince
+	}
+	if unusedSince != "" {
+		qs.Set("unusedSince", unusedSince)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
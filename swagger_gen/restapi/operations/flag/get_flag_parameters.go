@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
@@ -32,6 +33,10 @@ type GetFlagParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*get the flag as it's configured in the environment, i.e. its enabled state and the distributions of its segments
+	  In: query
+	*/
+	Environment *string
 	/*numeric ID of the flag to get
 	  Required: true
 	  Minimum: 1
@@ -49,6 +54,13 @@ func (o *GetFlagParams) BindRequest(r *http.Request, route *middleware.MatchedRo
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qEnvironment, qhkEnvironment, _ := qs.GetOK("environment")
+	if err := o.bindEnvironment(qEnvironment, qhkEnvironment, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rFlagID, rhkFlagID, _ := route.Params.GetOK("flagID")
 	if err := o.bindFlagID(rFlagID, rhkFlagID, route.Formats); err != nil {
 		res = append(res, err)
@@ -60,6 +72,24 @@ func (o *GetFlagParams) BindRequest(r *http.Request, route *middleware.MatchedRo
 	return nil
 }
 
+// bindEnvironment binds and validates parameter Environment from query.
+func (o *GetFlagParams) bindEnvironment(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Environment = &raw
+
+	return nil
+}
+
 // bindFlagID binds and validates parameter FlagID from path.
 func (o *GetFlagParams) bindFlagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 
 	models "github.com/checkr/flagr/swagger_gen/models"
 )
@@ -16,11 +17,15 @@ import (
 // GetFlagSnapshotsOKCode is the HTTP code returned for type GetFlagSnapshotsOK
 const GetFlagSnapshotsOKCode int = 200
 
-/*GetFlagSnapshotsOK returns the flag snapshots
+/*GetFlagSnapshotsOK returns the flag snapshots, the latest first
 
 swagger:response getFlagSnapshotsOK
 */
 type GetFlagSnapshotsOK struct {
+	/*the total number of snapshots in the date range, regardless of limit and offset
+
+	 */
+	XTotalCount int64 `json:"X-Total-Count"`
 
 	/*
 	  In: Body
@@ -34,6 +39,17 @@ func NewGetFlagSnapshotsOK() *GetFlagSnapshotsOK {
 	return &GetFlagSnapshotsOK{}
 }
 
+// WithXTotalCount adds the xTotalCount to the get flag snapshots o k response
+func (o *GetFlagSnapshotsOK) WithXTotalCount(xTotalCount int64) *GetFlagSnapshotsOK {
+	o.XTotalCount = xTotalCount
+	return o
+}
+
+// SetXTotalCount sets the xTotalCount to the get flag snapshots o k response
+func (o *GetFlagSnapshotsOK) SetXTotalCount(xTotalCount int64) {
+	o.XTotalCount = xTotalCount
+}
+
 // WithPayload adds the payload to the get flag snapshots o k response
 func (o *GetFlagSnapshotsOK) WithPayload(payload []*models.FlagSnapshot) *GetFlagSnapshotsOK {
 	o.Payload = payload
@@ -48,6 +64,13 @@ func (o *GetFlagSnapshotsOK) SetPayload(payload []*models.FlagSnapshot) {
 // WriteResponse to the client
 func (o *GetFlagSnapshotsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header X-Total-Count
+
+	xTotalCount := swag.FormatInt64(o.XTotalCount)
+	if xTotalCount != "" {
+		rw.Header().Set("X-Total-Count", xTotalCount)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
@@ -12,12 +12,19 @@ import (
 	"strings"
 
 	"github.com/go-openapi/swag"
+
+	strfmt "github.com/go-openapi/strfmt"
 )
 
 // GetFlagSnapshotsURL generates an URL for the get flag snapshots operation
 type GetFlagSnapshotsURL struct {
 	FlagID int64
 
+	From   *strfmt.DateTime
+	Limit  *int64
+	Offset *int64
+	To     *strfmt.DateTime
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -57,6 +64,42 @@ func (o *GetFlagSnapshotsURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var from string
+	if o.From != nil {
+		from = o.From.String()
+	}
+	if from != "" {
+		qs.Set("from", from)
+	}
+
+	var limit string
+	if o.Limit != nil {
+		limit = swag.FormatInt64(*o.Limit)
+	}
+	if limit != "" {
+		qs.Set("limit", limit)
+	}
+
+	var offset string
+	if o.Offset != nil {
+		offset = swag.FormatInt64(*o.Offset)
+	}
+	if offset != "" {
+		qs.Set("offset", offset)
+	}
+
+	var to string
+	if o.To != nil {
+		to = o.To.String()
+	}
+	if to != "" {
+		qs.Set("to", to)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
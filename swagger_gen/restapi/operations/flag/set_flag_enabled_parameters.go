@@ -41,6 +41,10 @@ type SetFlagEnabledParams struct {
 	  In: body
 	*/
 	Body *models.SetFlagEnabledRequest
+	/*set the enabled state of the flag in the environment, the flag's own enabled state is untouched
+	  In: query
+	*/
+	Environment *string
 	/*numeric ID of the flag to get
 	  Required: true
 	  Minimum: 1
@@ -58,6 +62,8 @@ func (o *SetFlagEnabledParams) BindRequest(r *http.Request, route *middleware.Ma
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.SetFlagEnabledRequest
@@ -80,6 +86,11 @@ func (o *SetFlagEnabledParams) BindRequest(r *http.Request, route *middleware.Ma
 	} else {
 		res = append(res, errors.Required("body", "body"))
 	}
+	qEnvironment, qhkEnvironment, _ := qs.GetOK("environment")
+	if err := o.bindEnvironment(qEnvironment, qhkEnvironment, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rFlagID, rhkFlagID, _ := route.Params.GetOK("flagID")
 	if err := o.bindFlagID(rFlagID, rhkFlagID, route.Formats); err != nil {
 		res = append(res, err)
@@ -91,6 +102,24 @@ func (o *SetFlagEnabledParams) BindRequest(r *http.Request, route *middleware.Ma
 	return nil
 }
 
+// bindEnvironment binds and validates parameter Environment from query.
+func (o *SetFlagEnabledParams) bindEnvironment(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Environment = &raw
+
+	return nil
+}
+
 // bindFlagID binds and validates parameter FlagID from path.
 func (o *SetFlagEnabledParams) bindFlagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
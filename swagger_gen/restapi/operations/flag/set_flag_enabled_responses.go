@@ -57,6 +57,50 @@ func (o *SetFlagEnabledOK) WriteResponse(rw http.ResponseWriter, producer runtim
 	}
 }
 
+// SetFlagEnabledAcceptedCode is the HTTP code returned for type SetFlagEnabledAccepted
+const SetFlagEnabledAcceptedCode int = 202
+
+/*SetFlagEnabledAccepted the change is pending the approval of another user, see FLAGR_APPROVAL_REQUIRED
+
+swagger:response setFlagEnabledAccepted
+*/
+type SetFlagEnabledAccepted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.FlagChange `json:"body,omitempty"`
+}
+
+// NewSetFlagEnabledAccepted creates SetFlagEnabledAccepted with default headers values
+func NewSetFlagEnabledAccepted() *SetFlagEnabledAccepted {
+
+	return &SetFlagEnabledAccepted{}
+}
+
+// WithPayload adds the payload to the set flag enabled accepted response
+func (o *SetFlagEnabledAccepted) WithPayload(payload *models.FlagChange) *SetFlagEnabledAccepted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the set flag enabled accepted response
+func (o *SetFlagEnabledAccepted) SetPayload(payload *models.FlagChange) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *SetFlagEnabledAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(202)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 /*SetFlagEnabledDefault generic error response
 
 swagger:response setFlagEnabledDefault
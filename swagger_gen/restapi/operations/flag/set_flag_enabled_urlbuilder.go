@@ -18,6 +18,8 @@ import (
 type SetFlagEnabledURL struct {
 	FlagID int64
 
+	Environment *string
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -57,6 +59,18 @@ func (o *SetFlagEnabledURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var environment string
+	if o.Environment != nil {
+		environment = *o.Environment
+	}
+	if environment != "" {
+		qs.Set("environment", environment)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -7,6 +7,7 @@ package operations
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -20,12 +21,16 @@ import (
 	"github.com/go-openapi/swag"
 
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/constraint_group"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/distribution"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/evaluation"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/exclusion_group"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/export"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/flag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/health"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/segment_template"
+	"github.com/checkr/flagr/swagger_gen/restapi/operations/tag"
 	"github.com/checkr/flagr/swagger_gen/restapi/operations/variant"
 )
 
@@ -47,90 +52,249 @@ func NewFlagrAPI(spec *loads.Document) *FlagrAPI {
 		JSONConsumer:        runtime.JSONConsumer(),
 		JSONProducer:        runtime.JSONProducer(),
 		BinProducer:         runtime.ByteStreamProducer(),
+		CsvProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("csv producer has not yet been implemented")
+		}),
+		TextEventStreamProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("textEventStream producer has not yet been implemented")
+		}),
+		SegmentTemplateApplySegmentTemplateHandler: segment_template.ApplySegmentTemplateHandlerFunc(func(params segment_template.ApplySegmentTemplateParams) middleware.Responder {
+			return middleware.NotImplemented("operation SegmentTemplateApplySegmentTemplate has not yet been implemented")
+		}),
+		FlagApproveFlagChangeHandler: flag.ApproveFlagChangeHandlerFunc(func(params flag.ApproveFlagChangeParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagApproveFlagChange has not yet been implemented")
+		}),
+		TagBulkTagsHandler: tag.BulkTagsHandlerFunc(func(params tag.BulkTagsParams) middleware.Responder {
+			return middleware.NotImplemented("operation TagBulkTags has not yet been implemented")
+		}),
+		FlagCloneFlagHandler: flag.CloneFlagHandlerFunc(func(params flag.CloneFlagParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagCloneFlag has not yet been implemented")
+		}),
 		ConstraintCreateConstraintHandler: constraint.CreateConstraintHandlerFunc(func(params constraint.CreateConstraintParams) middleware.Responder {
 			return middleware.NotImplemented("operation ConstraintCreateConstraint has not yet been implemented")
 		}),
+		ConstraintGroupCreateConstraintGroupHandler: constraint_group.CreateConstraintGroupHandlerFunc(func(params constraint_group.CreateConstraintGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ConstraintGroupCreateConstraintGroup has not yet been implemented")
+		}),
+		ExclusionGroupCreateExclusionGroupHandler: exclusion_group.CreateExclusionGroupHandlerFunc(func(params exclusion_group.CreateExclusionGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ExclusionGroupCreateExclusionGroup has not yet been implemented")
+		}),
 		FlagCreateFlagHandler: flag.CreateFlagHandlerFunc(func(params flag.CreateFlagParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagCreateFlag has not yet been implemented")
 		}),
+		FlagCreateFlagScheduleHandler: flag.CreateFlagScheduleHandlerFunc(func(params flag.CreateFlagScheduleParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagCreateFlagSchedule has not yet been implemented")
+		}),
 		SegmentCreateSegmentHandler: segment.CreateSegmentHandlerFunc(func(params segment.CreateSegmentParams) middleware.Responder {
 			return middleware.NotImplemented("operation SegmentCreateSegment has not yet been implemented")
 		}),
+		SegmentTemplateCreateSegmentTemplateHandler: segment_template.CreateSegmentTemplateHandlerFunc(func(params segment_template.CreateSegmentTemplateParams) middleware.Responder {
+			return middleware.NotImplemented("operation SegmentTemplateCreateSegmentTemplate has not yet been implemented")
+		}),
+		TagCreateTagHandler: tag.CreateTagHandlerFunc(func(params tag.CreateTagParams) middleware.Responder {
+			return middleware.NotImplemented("operation TagCreateTag has not yet been implemented")
+		}),
 		VariantCreateVariantHandler: variant.CreateVariantHandlerFunc(func(params variant.CreateVariantParams) middleware.Responder {
 			return middleware.NotImplemented("operation VariantCreateVariant has not yet been implemented")
 		}),
 		ConstraintDeleteConstraintHandler: constraint.DeleteConstraintHandlerFunc(func(params constraint.DeleteConstraintParams) middleware.Responder {
 			return middleware.NotImplemented("operation ConstraintDeleteConstraint has not yet been implemented")
 		}),
+		ConstraintGroupDeleteConstraintGroupHandler: constraint_group.DeleteConstraintGroupHandlerFunc(func(params constraint_group.DeleteConstraintGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ConstraintGroupDeleteConstraintGroup has not yet been implemented")
+		}),
+		ExclusionGroupDeleteExclusionGroupHandler: exclusion_group.DeleteExclusionGroupHandlerFunc(func(params exclusion_group.DeleteExclusionGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ExclusionGroupDeleteExclusionGroup has not yet been implemented")
+		}),
 		FlagDeleteFlagHandler: flag.DeleteFlagHandlerFunc(func(params flag.DeleteFlagParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagDeleteFlag has not yet been implemented")
 		}),
 		SegmentDeleteSegmentHandler: segment.DeleteSegmentHandlerFunc(func(params segment.DeleteSegmentParams) middleware.Responder {
 			return middleware.NotImplemented("operation SegmentDeleteSegment has not yet been implemented")
 		}),
+		SegmentTemplateDeleteSegmentTemplateHandler: segment_template.DeleteSegmentTemplateHandlerFunc(func(params segment_template.DeleteSegmentTemplateParams) middleware.Responder {
+			return middleware.NotImplemented("operation SegmentTemplateDeleteSegmentTemplate has not yet been implemented")
+		}),
+		TagDeleteTagHandler: tag.DeleteTagHandlerFunc(func(params tag.DeleteTagParams) middleware.Responder {
+			return middleware.NotImplemented("operation TagDeleteTag has not yet been implemented")
+		}),
 		VariantDeleteVariantHandler: variant.DeleteVariantHandlerFunc(func(params variant.DeleteVariantParams) middleware.Responder {
 			return middleware.NotImplemented("operation VariantDeleteVariant has not yet been implemented")
 		}),
+		ConstraintEvaluateConstraintHandler: constraint.EvaluateConstraintHandlerFunc(func(params constraint.EvaluateConstraintParams) middleware.Responder {
+			return middleware.NotImplemented("operation ConstraintEvaluateConstraint has not yet been implemented")
+		}),
+		FlagExportFlagHandler: flag.ExportFlagHandlerFunc(func(params flag.ExportFlagParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagExportFlag has not yet been implemented")
+		}),
+		TagFindAllTagsHandler: tag.FindAllTagsHandlerFunc(func(params tag.FindAllTagsParams) middleware.Responder {
+			return middleware.NotImplemented("operation TagFindAllTags has not yet been implemented")
+		}),
+		ConstraintGroupFindConstraintGroupsHandler: constraint_group.FindConstraintGroupsHandlerFunc(func(params constraint_group.FindConstraintGroupsParams) middleware.Responder {
+			return middleware.NotImplemented("operation ConstraintGroupFindConstraintGroups has not yet been implemented")
+		}),
 		ConstraintFindConstraintsHandler: constraint.FindConstraintsHandlerFunc(func(params constraint.FindConstraintsParams) middleware.Responder {
 			return middleware.NotImplemented("operation ConstraintFindConstraints has not yet been implemented")
 		}),
 		DistributionFindDistributionsHandler: distribution.FindDistributionsHandlerFunc(func(params distribution.FindDistributionsParams) middleware.Responder {
 			return middleware.NotImplemented("operation DistributionFindDistributions has not yet been implemented")
 		}),
+		ExclusionGroupFindExclusionGroupsHandler: exclusion_group.FindExclusionGroupsHandlerFunc(func(params exclusion_group.FindExclusionGroupsParams) middleware.Responder {
+			return middleware.NotImplemented("operation ExclusionGroupFindExclusionGroups has not yet been implemented")
+		}),
+		FlagFindFlagChangesHandler: flag.FindFlagChangesHandlerFunc(func(params flag.FindFlagChangesParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagFindFlagChanges has not yet been implemented")
+		}),
+		FlagFindFlagSchedulesHandler: flag.FindFlagSchedulesHandlerFunc(func(params flag.FindFlagSchedulesParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagFindFlagSchedules has not yet been implemented")
+		}),
 		FlagFindFlagsHandler: flag.FindFlagsHandlerFunc(func(params flag.FindFlagsParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagFindFlags has not yet been implemented")
 		}),
+		SegmentTemplateFindSegmentTemplatesHandler: segment_template.FindSegmentTemplatesHandlerFunc(func(params segment_template.FindSegmentTemplatesParams) middleware.Responder {
+			return middleware.NotImplemented("operation SegmentTemplateFindSegmentTemplates has not yet been implemented")
+		}),
 		SegmentFindSegmentsHandler: segment.FindSegmentsHandlerFunc(func(params segment.FindSegmentsParams) middleware.Responder {
 			return middleware.NotImplemented("operation SegmentFindSegments has not yet been implemented")
 		}),
+		FlagFindStaleFlagsHandler: flag.FindStaleFlagsHandlerFunc(func(params flag.FindStaleFlagsParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagFindStaleFlags has not yet been implemented")
+		}),
+		TagFindTagsHandler: tag.FindTagsHandlerFunc(func(params tag.FindTagsParams) middleware.Responder {
+			return middleware.NotImplemented("operation TagFindTags has not yet been implemented")
+		}),
 		VariantFindVariantsHandler: variant.FindVariantsHandlerFunc(func(params variant.FindVariantsParams) middleware.Responder {
 			return middleware.NotImplemented("operation VariantFindVariants has not yet been implemented")
 		}),
+		ConstraintGroupGetConstraintGroupHandler: constraint_group.GetConstraintGroupHandlerFunc(func(params constraint_group.GetConstraintGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ConstraintGroupGetConstraintGroup has not yet been implemented")
+		}),
+		EvaluationGetEvaluationHandler: evaluation.GetEvaluationHandlerFunc(func(params evaluation.GetEvaluationParams) middleware.Responder {
+			return middleware.NotImplemented("operation EvaluationGetEvaluation has not yet been implemented")
+		}),
+		ExclusionGroupGetExclusionGroupHandler: exclusion_group.GetExclusionGroupHandlerFunc(func(params exclusion_group.GetExclusionGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ExclusionGroupGetExclusionGroup has not yet been implemented")
+		}),
 		ExportGetExportEvalCacheJSONHandler: export.GetExportEvalCacheJSONHandlerFunc(func(params export.GetExportEvalCacheJSONParams) middleware.Responder {
 			return middleware.NotImplemented("operation ExportGetExportEvalCacheJSON has not yet been implemented")
 		}),
+		ExportGetExportFlagsCsvHandler: export.GetExportFlagsCsvHandlerFunc(func(params export.GetExportFlagsCsvParams) middleware.Responder {
+			return middleware.NotImplemented("operation ExportGetExportFlagsCsv has not yet been implemented")
+		}),
 		ExportGetExportSqliteHandler: export.GetExportSqliteHandlerFunc(func(params export.GetExportSqliteParams) middleware.Responder {
 			return middleware.NotImplemented("operation ExportGetExportSqlite has not yet been implemented")
 		}),
 		FlagGetFlagHandler: flag.GetFlagHandlerFunc(func(params flag.GetFlagParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagGetFlag has not yet been implemented")
 		}),
+		FlagGetFlagBucketCheckHandler: flag.GetFlagBucketCheckHandlerFunc(func(params flag.GetFlagBucketCheckParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagGetFlagBucketCheck has not yet been implemented")
+		}),
 		FlagGetFlagEntityTypesHandler: flag.GetFlagEntityTypesHandlerFunc(func(params flag.GetFlagEntityTypesParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagGetFlagEntityTypes has not yet been implemented")
 		}),
+		FlagGetFlagHistoryHandler: flag.GetFlagHistoryHandlerFunc(func(params flag.GetFlagHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagGetFlagHistory has not yet been implemented")
+		}),
 		FlagGetFlagSnapshotsHandler: flag.GetFlagSnapshotsHandlerFunc(func(params flag.GetFlagSnapshotsParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagGetFlagSnapshots has not yet been implemented")
 		}),
+		FlagGetFlagSnapshotsDiffHandler: flag.GetFlagSnapshotsDiffHandlerFunc(func(params flag.GetFlagSnapshotsDiffParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagGetFlagSnapshotsDiff has not yet been implemented")
+		}),
+		FlagGetFlagsStreamHandler: flag.GetFlagsStreamHandlerFunc(func(params flag.GetFlagsStreamParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagGetFlagsStream has not yet been implemented")
+		}),
 		HealthGetHealthHandler: health.GetHealthHandlerFunc(func(params health.GetHealthParams) middleware.Responder {
 			return middleware.NotImplemented("operation HealthGetHealth has not yet been implemented")
 		}),
+		HealthGetReadyHandler: health.GetReadyHandlerFunc(func(params health.GetReadyParams) middleware.Responder {
+			return middleware.NotImplemented("operation HealthGetReady has not yet been implemented")
+		}),
+		SegmentTemplateGetSegmentTemplateHandler: segment_template.GetSegmentTemplateHandlerFunc(func(params segment_template.GetSegmentTemplateParams) middleware.Responder {
+			return middleware.NotImplemented("operation SegmentTemplateGetSegmentTemplate has not yet been implemented")
+		}),
+		FlagImportFlagHandler: flag.ImportFlagHandlerFunc(func(params flag.ImportFlagParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagImportFlag has not yet been implemented")
+		}),
+		FlagPatchFlagEnabledHandler: flag.PatchFlagEnabledHandlerFunc(func(params flag.PatchFlagEnabledParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagPatchFlagEnabled has not yet been implemented")
+		}),
 		EvaluationPostEvaluationHandler: evaluation.PostEvaluationHandlerFunc(func(params evaluation.PostEvaluationParams) middleware.Responder {
 			return middleware.NotImplemented("operation EvaluationPostEvaluation has not yet been implemented")
 		}),
 		EvaluationPostEvaluationBatchHandler: evaluation.PostEvaluationBatchHandlerFunc(func(params evaluation.PostEvaluationBatchParams) middleware.Responder {
 			return middleware.NotImplemented("operation EvaluationPostEvaluationBatch has not yet been implemented")
 		}),
+		EvaluationPostEvaluationByTagHandler: evaluation.PostEvaluationByTagHandlerFunc(func(params evaluation.PostEvaluationByTagParams) middleware.Responder {
+			return middleware.NotImplemented("operation EvaluationPostEvaluationByTag has not yet been implemented")
+		}),
+		EvaluationPostEvaluationCacheRefreshHandler: evaluation.PostEvaluationCacheRefreshHandlerFunc(func(params evaluation.PostEvaluationCacheRefreshParams) middleware.Responder {
+			return middleware.NotImplemented("operation EvaluationPostEvaluationCacheRefresh has not yet been implemented")
+		}),
+		EvaluationPostEvaluationExplainHandler: evaluation.PostEvaluationExplainHandlerFunc(func(params evaluation.PostEvaluationExplainParams) middleware.Responder {
+			return middleware.NotImplemented("operation EvaluationPostEvaluationExplain has not yet been implemented")
+		}),
+		EvaluationPostEvaluationFrontendEventsHandler: evaluation.PostEvaluationFrontendEventsHandlerFunc(func(params evaluation.PostEvaluationFrontendEventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation EvaluationPostEvaluationFrontendEvents has not yet been implemented")
+		}),
+		FlagPromoteFlagEnvironmentHandler: flag.PromoteFlagEnvironmentHandlerFunc(func(params flag.PromoteFlagEnvironmentParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagPromoteFlagEnvironment has not yet been implemented")
+		}),
 		ConstraintPutConstraintHandler: constraint.PutConstraintHandlerFunc(func(params constraint.PutConstraintParams) middleware.Responder {
 			return middleware.NotImplemented("operation ConstraintPutConstraint has not yet been implemented")
 		}),
+		ConstraintGroupPutConstraintGroupHandler: constraint_group.PutConstraintGroupHandlerFunc(func(params constraint_group.PutConstraintGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ConstraintGroupPutConstraintGroup has not yet been implemented")
+		}),
 		DistributionPutDistributionsHandler: distribution.PutDistributionsHandlerFunc(func(params distribution.PutDistributionsParams) middleware.Responder {
 			return middleware.NotImplemented("operation DistributionPutDistributions has not yet been implemented")
 		}),
+		ExclusionGroupPutExclusionGroupHandler: exclusion_group.PutExclusionGroupHandlerFunc(func(params exclusion_group.PutExclusionGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ExclusionGroupPutExclusionGroup has not yet been implemented")
+		}),
 		FlagPutFlagHandler: flag.PutFlagHandlerFunc(func(params flag.PutFlagParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagPutFlag has not yet been implemented")
 		}),
+		ExclusionGroupPutFlagExclusionGroupHandler: exclusion_group.PutFlagExclusionGroupHandlerFunc(func(params exclusion_group.PutFlagExclusionGroupParams) middleware.Responder {
+			return middleware.NotImplemented("operation ExclusionGroupPutFlagExclusionGroup has not yet been implemented")
+		}),
+		FlagPutFlagOverrideHandler: flag.PutFlagOverrideHandlerFunc(func(params flag.PutFlagOverrideParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagPutFlagOverride has not yet been implemented")
+		}),
 		SegmentPutSegmentHandler: segment.PutSegmentHandlerFunc(func(params segment.PutSegmentParams) middleware.Responder {
 			return middleware.NotImplemented("operation SegmentPutSegment has not yet been implemented")
 		}),
+		SegmentTemplatePutSegmentTemplateHandler: segment_template.PutSegmentTemplateHandlerFunc(func(params segment_template.PutSegmentTemplateParams) middleware.Responder {
+			return middleware.NotImplemented("operation SegmentTemplatePutSegmentTemplate has not yet been implemented")
+		}),
 		SegmentPutSegmentsReorderHandler: segment.PutSegmentsReorderHandlerFunc(func(params segment.PutSegmentsReorderParams) middleware.Responder {
 			return middleware.NotImplemented("operation SegmentPutSegmentsReorder has not yet been implemented")
 		}),
 		VariantPutVariantHandler: variant.PutVariantHandlerFunc(func(params variant.PutVariantParams) middleware.Responder {
 			return middleware.NotImplemented("operation VariantPutVariant has not yet been implemented")
 		}),
+		DistributionRebalanceDistributionsHandler: distribution.RebalanceDistributionsHandlerFunc(func(params distribution.RebalanceDistributionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation DistributionRebalanceDistributions has not yet been implemented")
+		}),
+		FlagRestoreFlagHandler: flag.RestoreFlagHandlerFunc(func(params flag.RestoreFlagParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagRestoreFlag has not yet been implemented")
+		}),
+		FlagRestoreFlagSnapshotHandler: flag.RestoreFlagSnapshotHandlerFunc(func(params flag.RestoreFlagSnapshotParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagRestoreFlagSnapshot has not yet been implemented")
+		}),
+		FlagSaveFlagsBatchHandler: flag.SaveFlagsBatchHandlerFunc(func(params flag.SaveFlagsBatchParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagSaveFlagsBatch has not yet been implemented")
+		}),
 		FlagSetFlagEnabledHandler: flag.SetFlagEnabledHandlerFunc(func(params flag.SetFlagEnabledParams) middleware.Responder {
 			return middleware.NotImplemented("operation FlagSetFlagEnabled has not yet been implemented")
 		}),
+		FlagSimulateFlagRolloutHandler: flag.SimulateFlagRolloutHandlerFunc(func(params flag.SimulateFlagRolloutParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagSimulateFlagRollout has not yet been implemented")
+		}),
+		FlagValidateFlagHandler: flag.ValidateFlagHandlerFunc(func(params flag.ValidateFlagParams) middleware.Responder {
+			return middleware.NotImplemented("operation FlagValidateFlag has not yet been implemented")
+		}),
 	}
 }
 
@@ -164,63 +328,169 @@ type FlagrAPI struct {
 	JSONProducer runtime.Producer
 	// BinProducer registers a producer for a "application/octet-stream" mime type
 	BinProducer runtime.Producer
-
+	// CsvProducer registers a producer for a "text/csv" mime type
+	CsvProducer runtime.Producer
+	// TextEventStreamProducer registers a producer for a "text/event-stream" mime type
+	TextEventStreamProducer runtime.Producer
+
+	// SegmentTemplateApplySegmentTemplateHandler sets the operation handler for the apply segment template operation
+	SegmentTemplateApplySegmentTemplateHandler segment_template.ApplySegmentTemplateHandler
+	// FlagApproveFlagChangeHandler sets the operation handler for the approve flag change operation
+	FlagApproveFlagChangeHandler flag.ApproveFlagChangeHandler
+	// TagBulkTagsHandler sets the operation handler for the bulk tags operation
+	TagBulkTagsHandler tag.BulkTagsHandler
+	// FlagCloneFlagHandler sets the operation handler for the clone flag operation
+	FlagCloneFlagHandler flag.CloneFlagHandler
 	// ConstraintCreateConstraintHandler sets the operation handler for the create constraint operation
 	ConstraintCreateConstraintHandler constraint.CreateConstraintHandler
+	// ConstraintGroupCreateConstraintGroupHandler sets the operation handler for the create constraint group operation
+	ConstraintGroupCreateConstraintGroupHandler constraint_group.CreateConstraintGroupHandler
+	// ExclusionGroupCreateExclusionGroupHandler sets the operation handler for the create exclusion group operation
+	ExclusionGroupCreateExclusionGroupHandler exclusion_group.CreateExclusionGroupHandler
 	// FlagCreateFlagHandler sets the operation handler for the create flag operation
 	FlagCreateFlagHandler flag.CreateFlagHandler
+	// FlagCreateFlagScheduleHandler sets the operation handler for the create flag schedule operation
+	FlagCreateFlagScheduleHandler flag.CreateFlagScheduleHandler
 	// SegmentCreateSegmentHandler sets the operation handler for the create segment operation
 	SegmentCreateSegmentHandler segment.CreateSegmentHandler
+	// SegmentTemplateCreateSegmentTemplateHandler sets the operation handler for the create segment template operation
+	SegmentTemplateCreateSegmentTemplateHandler segment_template.CreateSegmentTemplateHandler
+	// TagCreateTagHandler sets the operation handler for the create tag operation
+	TagCreateTagHandler tag.CreateTagHandler
 	// VariantCreateVariantHandler sets the operation handler for the create variant operation
 	VariantCreateVariantHandler variant.CreateVariantHandler
 	// ConstraintDeleteConstraintHandler sets the operation handler for the delete constraint operation
 	ConstraintDeleteConstraintHandler constraint.DeleteConstraintHandler
+	// ConstraintGroupDeleteConstraintGroupHandler sets the operation handler for the delete constraint group operation
+	ConstraintGroupDeleteConstraintGroupHandler constraint_group.DeleteConstraintGroupHandler
+	// ExclusionGroupDeleteExclusionGroupHandler sets the operation handler for the delete exclusion group operation
+	ExclusionGroupDeleteExclusionGroupHandler exclusion_group.DeleteExclusionGroupHandler
 	// FlagDeleteFlagHandler sets the operation handler for the delete flag operation
 	FlagDeleteFlagHandler flag.DeleteFlagHandler
 	// SegmentDeleteSegmentHandler sets the operation handler for the delete segment operation
 	SegmentDeleteSegmentHandler segment.DeleteSegmentHandler
+	// SegmentTemplateDeleteSegmentTemplateHandler sets the operation handler for the delete segment template operation
+	SegmentTemplateDeleteSegmentTemplateHandler segment_template.DeleteSegmentTemplateHandler
+	// TagDeleteTagHandler sets the operation handler for the delete tag operation
+	TagDeleteTagHandler tag.DeleteTagHandler
 	// VariantDeleteVariantHandler sets the operation handler for the delete variant operation
 	VariantDeleteVariantHandler variant.DeleteVariantHandler
+	// ConstraintEvaluateConstraintHandler sets the operation handler for the evaluate constraint operation
+	ConstraintEvaluateConstraintHandler constraint.EvaluateConstraintHandler
+	// FlagExportFlagHandler sets the operation handler for the export flag operation
+	FlagExportFlagHandler flag.ExportFlagHandler
+	// TagFindAllTagsHandler sets the operation handler for the find all tags operation
+	TagFindAllTagsHandler tag.FindAllTagsHandler
+	// ConstraintGroupFindConstraintGroupsHandler sets the operation handler for the find constraint groups operation
+	ConstraintGroupFindConstraintGroupsHandler constraint_group.FindConstraintGroupsHandler
 	// ConstraintFindConstraintsHandler sets the operation handler for the find constraints operation
 	ConstraintFindConstraintsHandler constraint.FindConstraintsHandler
 	// DistributionFindDistributionsHandler sets the operation handler for the find distributions operation
 	DistributionFindDistributionsHandler distribution.FindDistributionsHandler
+	// ExclusionGroupFindExclusionGroupsHandler sets the operation handler for the find exclusion groups operation
+	ExclusionGroupFindExclusionGroupsHandler exclusion_group.FindExclusionGroupsHandler
+	// FlagFindFlagChangesHandler sets the operation handler for the find flag changes operation
+	FlagFindFlagChangesHandler flag.FindFlagChangesHandler
+	// FlagFindFlagSchedulesHandler sets the operation handler for the find flag schedules operation
+	FlagFindFlagSchedulesHandler flag.FindFlagSchedulesHandler
 	// FlagFindFlagsHandler sets the operation handler for the find flags operation
 	FlagFindFlagsHandler flag.FindFlagsHandler
+	// SegmentTemplateFindSegmentTemplatesHandler sets the operation handler for the find segment templates operation
+	SegmentTemplateFindSegmentTemplatesHandler segment_template.FindSegmentTemplatesHandler
 	// SegmentFindSegmentsHandler sets the operation handler for the find segments operation
 	SegmentFindSegmentsHandler segment.FindSegmentsHandler
+	// FlagFindStaleFlagsHandler sets the operation handler for the find stale flags operation
+	FlagFindStaleFlagsHandler flag.FindStaleFlagsHandler
+	// TagFindTagsHandler sets the operation handler for the find tags operation
+	TagFindTagsHandler tag.FindTagsHandler
 	// VariantFindVariantsHandler sets the operation handler for the find variants operation
 	VariantFindVariantsHandler variant.FindVariantsHandler
+	// ConstraintGroupGetConstraintGroupHandler sets the operation handler for the get constraint group operation
+	ConstraintGroupGetConstraintGroupHandler constraint_group.GetConstraintGroupHandler
+	// EvaluationGetEvaluationHandler sets the operation handler for the get evaluation operation
+	EvaluationGetEvaluationHandler evaluation.GetEvaluationHandler
+	// ExclusionGroupGetExclusionGroupHandler sets the operation handler for the get exclusion group operation
+	ExclusionGroupGetExclusionGroupHandler exclusion_group.GetExclusionGroupHandler
 	// ExportGetExportEvalCacheJSONHandler sets the operation handler for the get export eval cache JSON operation
 	ExportGetExportEvalCacheJSONHandler export.GetExportEvalCacheJSONHandler
+	// ExportGetExportFlagsCsvHandler sets the operation handler for the get export flags csv operation
+	ExportGetExportFlagsCsvHandler export.GetExportFlagsCsvHandler
 	// ExportGetExportSqliteHandler sets the operation handler for the get export sqlite operation
 	ExportGetExportSqliteHandler export.GetExportSqliteHandler
 	// FlagGetFlagHandler sets the operation handler for the get flag operation
 	FlagGetFlagHandler flag.GetFlagHandler
+	// FlagGetFlagBucketCheckHandler sets the operation handler for the get flag bucket check operation
+	FlagGetFlagBucketCheckHandler flag.GetFlagBucketCheckHandler
 	// FlagGetFlagEntityTypesHandler sets the operation handler for the get flag entity types operation
 	FlagGetFlagEntityTypesHandler flag.GetFlagEntityTypesHandler
+	// FlagGetFlagHistoryHandler sets the operation handler for the get flag history operation
+	FlagGetFlagHistoryHandler flag.GetFlagHistoryHandler
 	// FlagGetFlagSnapshotsHandler sets the operation handler for the get flag snapshots operation
 	FlagGetFlagSnapshotsHandler flag.GetFlagSnapshotsHandler
+	// FlagGetFlagSnapshotsDiffHandler sets the operation handler for the get flag snapshots diff operation
+	FlagGetFlagSnapshotsDiffHandler flag.GetFlagSnapshotsDiffHandler
+	// FlagGetFlagsStreamHandler sets the operation handler for the get flags stream operation
+	FlagGetFlagsStreamHandler flag.GetFlagsStreamHandler
 	// HealthGetHealthHandler sets the operation handler for the get health operation
 	HealthGetHealthHandler health.GetHealthHandler
+	// HealthGetReadyHandler sets the operation handler for the get ready operation
+	HealthGetReadyHandler health.GetReadyHandler
+	// SegmentTemplateGetSegmentTemplateHandler sets the operation handler for the get segment template operation
+	SegmentTemplateGetSegmentTemplateHandler segment_template.GetSegmentTemplateHandler
+	// FlagImportFlagHandler sets the operation handler for the import flag operation
+	FlagImportFlagHandler flag.ImportFlagHandler
+	// FlagPatchFlagEnabledHandler sets the operation handler for the patch flag enabled operation
+	FlagPatchFlagEnabledHandler flag.PatchFlagEnabledHandler
 	// EvaluationPostEvaluationHandler sets the operation handler for the post evaluation operation
 	EvaluationPostEvaluationHandler evaluation.PostEvaluationHandler
 	// EvaluationPostEvaluationBatchHandler sets the operation handler for the post evaluation batch operation
 	EvaluationPostEvaluationBatchHandler evaluation.PostEvaluationBatchHandler
+	// EvaluationPostEvaluationByTagHandler sets the operation handler for the post evaluation by tag operation
+	EvaluationPostEvaluationByTagHandler evaluation.PostEvaluationByTagHandler
+	// EvaluationPostEvaluationCacheRefreshHandler sets the operation handler for the post evaluation cache refresh operation
+	EvaluationPostEvaluationCacheRefreshHandler evaluation.PostEvaluationCacheRefreshHandler
+	// EvaluationPostEvaluationExplainHandler sets the operation handler for the post evaluation explain operation
+	EvaluationPostEvaluationExplainHandler evaluation.PostEvaluationExplainHandler
+	// EvaluationPostEvaluationFrontendEventsHandler sets the operation handler for the post evaluation frontend events operation
+	EvaluationPostEvaluationFrontendEventsHandler evaluation.PostEvaluationFrontendEventsHandler
+	// FlagPromoteFlagEnvironmentHandler sets the operation handler for the promote flag environment operation
+	FlagPromoteFlagEnvironmentHandler flag.PromoteFlagEnvironmentHandler
 	// ConstraintPutConstraintHandler sets the operation handler for the put constraint operation
 	ConstraintPutConstraintHandler constraint.PutConstraintHandler
+	// ConstraintGroupPutConstraintGroupHandler sets the operation handler for the put constraint group operation
+	ConstraintGroupPutConstraintGroupHandler constraint_group.PutConstraintGroupHandler
 	// DistributionPutDistributionsHandler sets the operation handler for the put distributions operation
 	DistributionPutDistributionsHandler distribution.PutDistributionsHandler
+	// ExclusionGroupPutExclusionGroupHandler sets the operation handler for the put exclusion group operation
+	ExclusionGroupPutExclusionGroupHandler exclusion_group.PutExclusionGroupHandler
 	// FlagPutFlagHandler sets the operation handler for the put flag operation
 	FlagPutFlagHandler flag.PutFlagHandler
+	// ExclusionGroupPutFlagExclusionGroupHandler sets the operation handler for the put flag exclusion group operation
+	ExclusionGroupPutFlagExclusionGroupHandler exclusion_group.PutFlagExclusionGroupHandler
+	// FlagPutFlagOverrideHandler sets the operation handler for the put flag override operation
+	FlagPutFlagOverrideHandler flag.PutFlagOverrideHandler
 	// SegmentPutSegmentHandler sets the operation handler for the put segment operation
 	SegmentPutSegmentHandler segment.PutSegmentHandler
+	// SegmentTemplatePutSegmentTemplateHandler sets the operation handler for the put segment template operation
+	SegmentTemplatePutSegmentTemplateHandler segment_template.PutSegmentTemplateHandler
 	// SegmentPutSegmentsReorderHandler sets the operation handler for the put segments reorder operation
 	SegmentPutSegmentsReorderHandler segment.PutSegmentsReorderHandler
 	// VariantPutVariantHandler sets the operation handler for the put variant operation
 	VariantPutVariantHandler variant.PutVariantHandler
+	// DistributionRebalanceDistributionsHandler sets the operation handler for the rebalance distributions operation
+	DistributionRebalanceDistributionsHandler distribution.RebalanceDistributionsHandler
+	// FlagRestoreFlagHandler sets the operation handler for the restore flag operation
+	FlagRestoreFlagHandler flag.RestoreFlagHandler
+	// FlagRestoreFlagSnapshotHandler sets the operation handler for the restore flag snapshot operation
+	FlagRestoreFlagSnapshotHandler flag.RestoreFlagSnapshotHandler
+	// FlagSaveFlagsBatchHandler sets the operation handler for the save flags batch operation
+	FlagSaveFlagsBatchHandler flag.SaveFlagsBatchHandler
 	// FlagSetFlagEnabledHandler sets the operation handler for the set flag enabled operation
 	FlagSetFlagEnabledHandler flag.SetFlagEnabledHandler
+	// FlagSimulateFlagRolloutHandler sets the operation handler for the simulate flag rollout operation
+	FlagSimulateFlagRolloutHandler flag.SimulateFlagRolloutHandler
+	// FlagValidateFlagHandler sets the operation handler for the validate flag operation
+	FlagValidateFlagHandler flag.ValidateFlagHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -288,18 +558,62 @@ func (o *FlagrAPI) Validate() error {
 		unregistered = append(unregistered, "BinProducer")
 	}
 
+	if o.CsvProducer == nil {
+		unregistered = append(unregistered, "CsvProducer")
+	}
+
+	if o.TextEventStreamProducer == nil {
+		unregistered = append(unregistered, "TextEventStreamProducer")
+	}
+
+	if o.SegmentTemplateApplySegmentTemplateHandler == nil {
+		unregistered = append(unregistered, "segment_template.ApplySegmentTemplateHandler")
+	}
+
+	if o.FlagApproveFlagChangeHandler == nil {
+		unregistered = append(unregistered, "flag.ApproveFlagChangeHandler")
+	}
+
+	if o.TagBulkTagsHandler == nil {
+		unregistered = append(unregistered, "tag.BulkTagsHandler")
+	}
+
+	if o.FlagCloneFlagHandler == nil {
+		unregistered = append(unregistered, "flag.CloneFlagHandler")
+	}
+
 	if o.ConstraintCreateConstraintHandler == nil {
 		unregistered = append(unregistered, "constraint.CreateConstraintHandler")
 	}
 
+	if o.ConstraintGroupCreateConstraintGroupHandler == nil {
+		unregistered = append(unregistered, "constraint_group.CreateConstraintGroupHandler")
+	}
+
+	if o.ExclusionGroupCreateExclusionGroupHandler == nil {
+		unregistered = append(unregistered, "exclusion_group.CreateExclusionGroupHandler")
+	}
+
 	if o.FlagCreateFlagHandler == nil {
 		unregistered = append(unregistered, "flag.CreateFlagHandler")
 	}
 
+	if o.FlagCreateFlagScheduleHandler == nil {
+		unregistered = append(unregistered, "flag.CreateFlagScheduleHandler")
+	}
+
 	if o.SegmentCreateSegmentHandler == nil {
 		unregistered = append(unregistered, "segment.CreateSegmentHandler")
 	}
 
+	if o.SegmentTemplateCreateSegmentTemplateHandler == nil {
+		unregistered = append(unregistered, "segment_template.CreateSegmentTemplateHandler")
+	}
+
+	if o.TagCreateTagHandler == nil {
+		unregistered = append(unregistered, "tag.CreateTagHandler")
+	}
+
 	if o.VariantCreateVariantHandler == nil {
 		unregistered = append(unregistered, "variant.CreateVariantHandler")
 	}
@@ -308,6 +622,14 @@ func (o *FlagrAPI) Validate() error {
 		unregistered = append(unregistered, "constraint.DeleteConstraintHandler")
 	}
 
+	if o.ConstraintGroupDeleteConstraintGroupHandler == nil {
+		unregistered = append(unregistered, "constraint_group.DeleteConstraintGroupHandler")
+	}
+
+	if o.ExclusionGroupDeleteExclusionGroupHandler == nil {
+		unregistered = append(unregistered, "exclusion_group.DeleteExclusionGroupHandler")
+	}
+
 	if o.FlagDeleteFlagHandler == nil {
 		unregistered = append(unregistered, "flag.DeleteFlagHandler")
 	}
@@ -316,10 +638,34 @@ func (o *FlagrAPI) Validate() error {
 		unregistered = append(unregistered, "segment.DeleteSegmentHandler")
 	}
 
+	if o.SegmentTemplateDeleteSegmentTemplateHandler == nil {
+		unregistered = append(unregistered, "segment_template.DeleteSegmentTemplateHandler")
+	}
+
+	if o.TagDeleteTagHandler == nil {
+		unregistered = append(unregistered, "tag.DeleteTagHandler")
+	}
+
 	if o.VariantDeleteVariantHandler == nil {
 		unregistered = append(unregistered, "variant.DeleteVariantHandler")
 	}
 
+	if o.ConstraintEvaluateConstraintHandler == nil {
+		unregistered = append(unregistered, "constraint.EvaluateConstraintHandler")
+	}
+
+	if o.FlagExportFlagHandler == nil {
+		unregistered = append(unregistered, "flag.ExportFlagHandler")
+	}
+
+	if o.TagFindAllTagsHandler == nil {
+		unregistered = append(unregistered, "tag.FindAllTagsHandler")
+	}
+
+	if o.ConstraintGroupFindConstraintGroupsHandler == nil {
+		unregistered = append(unregistered, "constraint_group.FindConstraintGroupsHandler")
+	}
+
 	if o.ConstraintFindConstraintsHandler == nil {
 		unregistered = append(unregistered, "constraint.FindConstraintsHandler")
 	}
@@ -328,22 +674,62 @@ func (o *FlagrAPI) Validate() error {
 		unregistered = append(unregistered, "distribution.FindDistributionsHandler")
 	}
 
+	if o.ExclusionGroupFindExclusionGroupsHandler == nil {
+		unregistered = append(unregistered, "exclusion_group.FindExclusionGroupsHandler")
+	}
+
+	if o.FlagFindFlagChangesHandler == nil {
+		unregistered = append(unregistered, "flag.FindFlagChangesHandler")
+	}
+
+	if o.FlagFindFlagSchedulesHandler == nil {
+		unregistered = append(unregistered, "flag.FindFlagSchedulesHandler")
+	}
+
 	if o.FlagFindFlagsHandler == nil {
 		unregistered = append(unregistered, "flag.FindFlagsHandler")
 	}
 
+	if o.SegmentTemplateFindSegmentTemplatesHandler == nil {
+		unregistered = append(unregistered, "segment_template.FindSegmentTemplatesHandler")
+	}
+
 	if o.SegmentFindSegmentsHandler == nil {
 		unregistered = append(unregistered, "segment.FindSegmentsHandler")
 	}
 
+	if o.FlagFindStaleFlagsHandler == nil {
+		unregistered = append(unregistered, "flag.FindStaleFlagsHandler")
+	}
+
+	if o.TagFindTagsHandler == nil {
+		unregistered = append(unregistered, "tag.FindTagsHandler")
+	}
+
 	if o.VariantFindVariantsHandler == nil {
 		unregistered = append(unregistered, "variant.FindVariantsHandler")
 	}
 
+	if o.ConstraintGroupGetConstraintGroupHandler == nil {
+		unregistered = append(unregistered, "constraint_group.GetConstraintGroupHandler")
+	}
+
+	if o.EvaluationGetEvaluationHandler == nil {
+		unregistered = append(unregistered, "evaluation.GetEvaluationHandler")
+	}
+
+	if o.ExclusionGroupGetExclusionGroupHandler == nil {
+		unregistered = append(unregistered, "exclusion_group.GetExclusionGroupHandler")
+	}
+
 	if o.ExportGetExportEvalCacheJSONHandler == nil {
 		unregistered = append(unregistered, "export.GetExportEvalCacheJSONHandler")
 	}
 
+	if o.ExportGetExportFlagsCsvHandler == nil {
+		unregistered = append(unregistered, "export.GetExportFlagsCsvHandler")
+	}
+
 	if o.ExportGetExportSqliteHandler == nil {
 		unregistered = append(unregistered, "export.GetExportSqliteHandler")
 	}
@@ -352,18 +738,50 @@ func (o *FlagrAPI) Validate() error {
 		unregistered = append(unregistered, "flag.GetFlagHandler")
 	}
 
+	if o.FlagGetFlagBucketCheckHandler == nil {
+		unregistered = append(unregistered, "flag.GetFlagBucketCheckHandler")
+	}
+
 	if o.FlagGetFlagEntityTypesHandler == nil {
 		unregistered = append(unregistered, "flag.GetFlagEntityTypesHandler")
 	}
 
+	if o.FlagGetFlagHistoryHandler == nil {
+		unregistered = append(unregistered, "flag.GetFlagHistoryHandler")
+	}
+
 	if o.FlagGetFlagSnapshotsHandler == nil {
 		unregistered = append(unregistered, "flag.GetFlagSnapshotsHandler")
 	}
 
+	if o.FlagGetFlagSnapshotsDiffHandler == nil {
+		unregistered = append(unregistered, "flag.GetFlagSnapshotsDiffHandler")
+	}
+
+	if o.FlagGetFlagsStreamHandler == nil {
+		unregistered = append(unregistered, "flag.GetFlagsStreamHandler")
+	}
+
 	if o.HealthGetHealthHandler == nil {
 		unregistered = append(unregistered, "health.GetHealthHandler")
 	}
 
+	if o.HealthGetReadyHandler == nil {
+		unregistered = append(unregistered, "health.GetReadyHandler")
+	}
+
+	if o.SegmentTemplateGetSegmentTemplateHandler == nil {
+		unregistered = append(unregistered, "segment_template.GetSegmentTemplateHandler")
+	}
+
+	if o.FlagImportFlagHandler == nil {
+		unregistered = append(unregistered, "flag.ImportFlagHandler")
+	}
+
+	if o.FlagPatchFlagEnabledHandler == nil {
+		unregistered = append(unregistered, "flag.PatchFlagEnabledHandler")
+	}
+
 	if o.EvaluationPostEvaluationHandler == nil {
 		unregistered = append(unregistered, "evaluation.PostEvaluationHandler")
 	}
@@ -372,22 +790,62 @@ func (o *FlagrAPI) Validate() error {
 		unregistered = append(unregistered, "evaluation.PostEvaluationBatchHandler")
 	}
 
+	if o.EvaluationPostEvaluationByTagHandler == nil {
+		unregistered = append(unregistered, "evaluation.PostEvaluationByTagHandler")
+	}
+
+	if o.EvaluationPostEvaluationCacheRefreshHandler == nil {
+		unregistered = append(unregistered, "evaluation.PostEvaluationCacheRefreshHandler")
+	}
+
+	if o.EvaluationPostEvaluationExplainHandler == nil {
+		unregistered = append(unregistered, "evaluation.PostEvaluationExplainHandler")
+	}
+
+	if o.EvaluationPostEvaluationFrontendEventsHandler == nil {
+		unregistered = append(unregistered, "evaluation.PostEvaluationFrontendEventsHandler")
+	}
+
+	if o.FlagPromoteFlagEnvironmentHandler == nil {
+		unregistered = append(unregistered, "flag.PromoteFlagEnvironmentHandler")
+	}
+
 	if o.ConstraintPutConstraintHandler == nil {
 		unregistered = append(unregistered, "constraint.PutConstraintHandler")
 	}
 
+	if o.ConstraintGroupPutConstraintGroupHandler == nil {
+		unregistered = append(unregistered, "constraint_group.PutConstraintGroupHandler")
+	}
+
 	if o.DistributionPutDistributionsHandler == nil {
 		unregistered = append(unregistered, "distribution.PutDistributionsHandler")
 	}
 
+	if o.ExclusionGroupPutExclusionGroupHandler == nil {
+		unregistered = append(unregistered, "exclusion_group.PutExclusionGroupHandler")
+	}
+
 	if o.FlagPutFlagHandler == nil {
 		unregistered = append(unregistered, "flag.PutFlagHandler")
 	}
 
+	if o.ExclusionGroupPutFlagExclusionGroupHandler == nil {
+		unregistered = append(unregistered, "exclusion_group.PutFlagExclusionGroupHandler")
+	}
+
+	if o.FlagPutFlagOverrideHandler == nil {
+		unregistered = append(unregistered, "flag.PutFlagOverrideHandler")
+	}
+
 	if o.SegmentPutSegmentHandler == nil {
 		unregistered = append(unregistered, "segment.PutSegmentHandler")
 	}
 
+	if o.SegmentTemplatePutSegmentTemplateHandler == nil {
+		unregistered = append(unregistered, "segment_template.PutSegmentTemplateHandler")
+	}
+
 	if o.SegmentPutSegmentsReorderHandler == nil {
 		unregistered = append(unregistered, "segment.PutSegmentsReorderHandler")
 	}
@@ -396,10 +854,34 @@ func (o *FlagrAPI) Validate() error {
 		unregistered = append(unregistered, "variant.PutVariantHandler")
 	}
 
+	if o.DistributionRebalanceDistributionsHandler == nil {
+		unregistered = append(unregistered, "distribution.RebalanceDistributionsHandler")
+	}
+
+	if o.FlagRestoreFlagHandler == nil {
+		unregistered = append(unregistered, "flag.RestoreFlagHandler")
+	}
+
+	if o.FlagRestoreFlagSnapshotHandler == nil {
+		unregistered = append(unregistered, "flag.RestoreFlagSnapshotHandler")
+	}
+
+	if o.FlagSaveFlagsBatchHandler == nil {
+		unregistered = append(unregistered, "flag.SaveFlagsBatchHandler")
+	}
+
 	if o.FlagSetFlagEnabledHandler == nil {
 		unregistered = append(unregistered, "flag.SetFlagEnabledHandler")
 	}
 
+	if o.FlagSimulateFlagRolloutHandler == nil {
+		unregistered = append(unregistered, "flag.SimulateFlagRolloutHandler")
+	}
+
+	if o.FlagValidateFlagHandler == nil {
+		unregistered = append(unregistered, "flag.ValidateFlagHandler")
+	}
+
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
 	}
@@ -459,6 +941,12 @@ func (o *FlagrAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 		case "application/octet-stream":
 			result["application/octet-stream"] = o.BinProducer
 
+		case "text/csv":
+			result["text/csv"] = o.CsvProducer
+
+		case "text/event-stream":
+			result["text/event-stream"] = o.TextEventStreamProducer
+
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -501,21 +989,66 @@ func (o *FlagrAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/segment_templates/{segmentTemplateID}/apply"] = segment_template.NewApplySegmentTemplate(o.context, o.SegmentTemplateApplySegmentTemplateHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/{flagID}/changes/{changeID}/approve"] = flag.NewApproveFlagChange(o.context, o.FlagApproveFlagChangeHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/tags/bulk"] = tag.NewBulkTags(o.context, o.TagBulkTagsHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/{flagID}/clone"] = flag.NewCloneFlag(o.context, o.FlagCloneFlagHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/flags/{flagID}/segments/{segmentID}/constraints"] = constraint.NewCreateConstraint(o.context, o.ConstraintCreateConstraintHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/constraint_groups"] = constraint_group.NewCreateConstraintGroup(o.context, o.ConstraintGroupCreateConstraintGroupHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/exclusion_groups"] = exclusion_group.NewCreateExclusionGroup(o.context, o.ExclusionGroupCreateExclusionGroupHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/flags"] = flag.NewCreateFlag(o.context, o.FlagCreateFlagHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/{flagID}/schedule"] = flag.NewCreateFlagSchedule(o.context, o.FlagCreateFlagScheduleHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/flags/{flagID}/segments"] = segment.NewCreateSegment(o.context, o.SegmentCreateSegmentHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/segment_templates"] = segment_template.NewCreateSegmentTemplate(o.context, o.SegmentTemplateCreateSegmentTemplateHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/{flagID}/tags"] = tag.NewCreateTag(o.context, o.TagCreateTagHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -526,6 +1059,16 @@ func (o *FlagrAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}"] = constraint.NewDeleteConstraint(o.context, o.ConstraintDeleteConstraintHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/constraint_groups/{constraintGroupID}"] = constraint_group.NewDeleteConstraintGroup(o.context, o.ConstraintGroupDeleteConstraintGroupHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/exclusion_groups/{exclusionGroupID}"] = exclusion_group.NewDeleteExclusionGroup(o.context, o.ExclusionGroupDeleteExclusionGroupHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -536,11 +1079,41 @@ func (o *FlagrAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/flags/{flagID}/segments/{segmentID}"] = segment.NewDeleteSegment(o.context, o.SegmentDeleteSegmentHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/segment_templates/{segmentTemplateID}"] = segment_template.NewDeleteSegmentTemplate(o.context, o.SegmentTemplateDeleteSegmentTemplateHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/flags/{flagID}/tags/{tagID}"] = tag.NewDeleteTag(o.context, o.TagDeleteTagHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/flags/{flagID}/variants/{variantID}"] = variant.NewDeleteVariant(o.context, o.VariantDeleteVariantHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/constraints/evaluate"] = constraint.NewEvaluateConstraint(o.context, o.ConstraintEvaluateConstraintHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/{flagID}/export"] = flag.NewExportFlag(o.context, o.FlagExportFlagHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/tags"] = tag.NewFindAllTags(o.context, o.TagFindAllTagsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/constraint_groups"] = constraint_group.NewFindConstraintGroups(o.context, o.ConstraintGroupFindConstraintGroupsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -551,26 +1124,76 @@ func (o *FlagrAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/flags/{flagID}/segments/{segmentID}/distributions"] = distribution.NewFindDistributions(o.context, o.DistributionFindDistributionsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/exclusion_groups"] = exclusion_group.NewFindExclusionGroups(o.context, o.ExclusionGroupFindExclusionGroupsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/{flagID}/changes"] = flag.NewFindFlagChanges(o.context, o.FlagFindFlagChangesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/{flagID}/schedule"] = flag.NewFindFlagSchedules(o.context, o.FlagFindFlagSchedulesHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/flags"] = flag.NewFindFlags(o.context, o.FlagFindFlagsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/segment_templates"] = segment_template.NewFindSegmentTemplates(o.context, o.SegmentTemplateFindSegmentTemplatesHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/flags/{flagID}/segments"] = segment.NewFindSegments(o.context, o.SegmentFindSegmentsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/stale"] = flag.NewFindStaleFlags(o.context, o.FlagFindStaleFlagsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/{flagID}/tags"] = tag.NewFindTags(o.context, o.TagFindTagsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/flags/{flagID}/variants"] = variant.NewFindVariants(o.context, o.VariantFindVariantsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/constraint_groups/{constraintGroupID}"] = constraint_group.NewGetConstraintGroup(o.context, o.ConstraintGroupGetConstraintGroupHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/evaluation"] = evaluation.NewGetEvaluation(o.context, o.EvaluationGetEvaluationHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/exclusion_groups/{exclusionGroupID}"] = exclusion_group.NewGetExclusionGroup(o.context, o.ExclusionGroupGetExclusionGroupHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/export/eval_cache/json"] = export.NewGetExportEvalCacheJSON(o.context, o.ExportGetExportEvalCacheJSONHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/export.csv"] = export.NewGetExportFlagsCsv(o.context, o.ExportGetExportFlagsCsvHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -581,21 +1204,61 @@ func (o *FlagrAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/flags/{flagID}"] = flag.NewGetFlag(o.context, o.FlagGetFlagHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/{flagID}/bucketcheck"] = flag.NewGetFlagBucketCheck(o.context, o.FlagGetFlagBucketCheckHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/flags/entity_types"] = flag.NewGetFlagEntityTypes(o.context, o.FlagGetFlagEntityTypesHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/{flagID}/history"] = flag.NewGetFlagHistory(o.context, o.FlagGetFlagHistoryHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/flags/{flagID}/snapshots"] = flag.NewGetFlagSnapshots(o.context, o.FlagGetFlagSnapshotsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/{flagID}/snapshots/diff"] = flag.NewGetFlagSnapshotsDiff(o.context, o.FlagGetFlagSnapshotsDiffHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/flags/stream"] = flag.NewGetFlagsStream(o.context, o.FlagGetFlagsStreamHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/health"] = health.NewGetHealth(o.context, o.HealthGetHealthHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ready"] = health.NewGetReady(o.context, o.HealthGetReadyHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/segment_templates/{segmentTemplateID}"] = segment_template.NewGetSegmentTemplate(o.context, o.SegmentTemplateGetSegmentTemplateHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/import"] = flag.NewImportFlag(o.context, o.FlagImportFlagHandler)
+
+	if o.handlers["PATCH"] == nil {
+		o.handlers["PATCH"] = make(map[string]http.Handler)
+	}
+	o.handlers["PATCH"]["/flags/{flagID}/enabled"] = flag.NewPatchFlagEnabled(o.context, o.FlagPatchFlagEnabledHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -606,26 +1269,76 @@ func (o *FlagrAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/evaluation/batch"] = evaluation.NewPostEvaluationBatch(o.context, o.EvaluationPostEvaluationBatchHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/evaluation/bytag"] = evaluation.NewPostEvaluationByTag(o.context, o.EvaluationPostEvaluationByTagHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/evaluation/cache/refresh"] = evaluation.NewPostEvaluationCacheRefresh(o.context, o.EvaluationPostEvaluationCacheRefreshHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/evaluation/explain"] = evaluation.NewPostEvaluationExplain(o.context, o.EvaluationPostEvaluationExplainHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/evaluation/frontend-events"] = evaluation.NewPostEvaluationFrontendEvents(o.context, o.EvaluationPostEvaluationFrontendEventsHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/{flagID}/environments/{environment}/promote"] = flag.NewPromoteFlagEnvironment(o.context, o.FlagPromoteFlagEnvironmentHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/flags/{flagID}/segments/{segmentID}/constraints/{constraintID}"] = constraint.NewPutConstraint(o.context, o.ConstraintPutConstraintHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/constraint_groups/{constraintGroupID}"] = constraint_group.NewPutConstraintGroup(o.context, o.ConstraintGroupPutConstraintGroupHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/flags/{flagID}/segments/{segmentID}/distributions"] = distribution.NewPutDistributions(o.context, o.DistributionPutDistributionsHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/exclusion_groups/{exclusionGroupID}"] = exclusion_group.NewPutExclusionGroup(o.context, o.ExclusionGroupPutExclusionGroupHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/flags/{flagID}"] = flag.NewPutFlag(o.context, o.FlagPutFlagHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/flags/{flagID}/exclusion_group"] = exclusion_group.NewPutFlagExclusionGroup(o.context, o.ExclusionGroupPutFlagExclusionGroupHandler)
+
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/flags/{flagID}/override"] = flag.NewPutFlagOverride(o.context, o.FlagPutFlagOverrideHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/flags/{flagID}/segments/{segmentID}"] = segment.NewPutSegment(o.context, o.SegmentPutSegmentHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/segment_templates/{segmentTemplateID}"] = segment_template.NewPutSegmentTemplate(o.context, o.SegmentTemplatePutSegmentTemplateHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -636,11 +1349,41 @@ func (o *FlagrAPI) initHandlerCache() {
 	}
 	o.handlers["PUT"]["/flags/{flagID}/variants/{variantID}"] = variant.NewPutVariant(o.context, o.VariantPutVariantHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/flags/{flagID}/segments/{segmentID}/distributions/rebalance"] = distribution.NewRebalanceDistributions(o.context, o.DistributionRebalanceDistributionsHandler)
+
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/flags/{flagID}/restore"] = flag.NewRestoreFlag(o.context, o.FlagRestoreFlagHandler)
+
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/flags/{flagID}/restore/{snapshotID}"] = flag.NewRestoreFlagSnapshot(o.context, o.FlagRestoreFlagSnapshotHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/batch"] = flag.NewSaveFlagsBatch(o.context, o.FlagSaveFlagsBatchHandler)
+
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
 	o.handlers["PUT"]["/flags/{flagID}/enabled"] = flag.NewSetFlagEnabled(o.context, o.FlagSetFlagEnabledHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/{flagID}/simulate"] = flag.NewSimulateFlagRollout(o.context, o.FlagSimulateFlagRolloutHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/flags/validate"] = flag.NewValidateFlag(o.context, o.FlagValidateFlagHandler)
+
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -31,7 +31,7 @@ func NewPutSegmentsReorder(ctx *middleware.Context, handler PutSegmentsReorderHa
 
 /*PutSegmentsReorder swagger:route PUT /flags/{flagID}/segments/reorder segment putSegmentsReorder
 
-PutSegmentsReorder put segments reorder API
+reorder the segments in one transaction, the segmentIDs should be exactly the segments of the flag
 
 */
 type PutSegmentsReorder struct {